@@ -107,6 +107,36 @@ type AnalogPinner interface {
 	Write(val int) error
 }
 
+// AnalogBufferOptioner is the interface for options of a buffered analog input
+type AnalogBufferOptioner interface {
+	// SetSamplingFrequency sets the sampling frequency in Hz of the device
+	SetSamplingFrequency(frequency int) (changed bool)
+	// SetBufferLength sets the capacity of the buffer in number of scans
+	SetBufferLength(length int) (changed bool)
+	// SetTrigger sets the name of the trigger, which starts each scan
+	SetTrigger(name string) (changed bool)
+	// SetTimestamp activates the capture of the timestamp for each scan
+	SetTimestamp() (changed bool)
+}
+
+// AnalogScan contains the raw values of all channels of a buffered analog input, captured together by one trigger.
+type AnalogScan struct {
+	// Values contains one value per channel, in the order of the channels
+	Values []int
+	// Timestamp is the capture time, only filled if activated, the clock depends on the system (usually realtime)
+	Timestamp time.Duration
+}
+
+// AnalogBufferSystemDevicer is the interface to a buffered analog input at system level, e.g. the IIO buffer
+type AnalogBufferSystemDevicer interface {
+	// Channels returns the names of the channels, in the order of the values in each scan
+	Channels() []string
+	// ReadScans blocks until scans are available and returns not more than the given count of scans
+	ReadScans(maxScans int) ([]AnalogScan, error)
+	// Close stops the sampling and releases the device
+	Close() error
+}
+
 // I2cSystemDevicer is the interface to a i2c bus at system level, according to I2C/SMBus specification.
 // Some functions are not in the interface yet:
 // * Process Call (WriteWordDataReadWordData)
@@ -176,6 +206,170 @@ type SpiSystemDevicer interface {
 	Close() error
 }
 
+// SpiXfer describes a single transfer of a SPI message. The zero values of the settings means the value of the
+// connection is used.
+type SpiXfer struct {
+	// Tx contains the data to send, if nil, zeros are send for the length of Rx.
+	Tx []byte
+	// Rx will be filled with the received data, can be nil. If given, the length must be the same as for Tx.
+	Rx []byte
+	// SpeedHz overrides the maximum speed of the connection for this transfer.
+	SpeedHz int64
+	// BitsPerWord overrides the number of bits per word of the connection for this transfer.
+	BitsPerWord int
+	// Delay is the time to wait after this transfer, before the next transfer starts or chip select is released.
+	Delay time.Duration
+	// CSChange releases the chip select after this transfer, before the next transfer starts.
+	CSChange bool
+}
+
+// SpiSystemTransferer is the interface to a SPI bus at system level, which supports messages of multiple transfers.
+type SpiSystemTransferer interface {
+	// Transfer sends/receives all given transfers as one message. The chip select is hold active for the whole
+	// message, except CSChange is set for a transfer.
+	Transfer(xfers []SpiXfer) error
+}
+
+// SerialPortSystemDevicer is the interface to a serial port at system level.
+type SerialPortSystemDevicer interface {
+	io.ReadWriteCloser
+	// Drain waits until all written data are transmitted.
+	Drain() error
+	// ResetInputBuffer discards all received, but not yet read data.
+	ResetInputBuffer() error
+	// SetReadTimeout sets the timeout for the read operation, a value <= 0 disables the timeout.
+	SetReadTimeout(timeout time.Duration) error
+}
+
+// OneWireSystemDevicer is the interface to a 1-wire device at system level.
+type OneWireSystemDevicer interface {
+	// ID returns the device id in the form "family code"-"serial number".
+	ID() string
+	// ReadData reads byte data from the device by the given command.
+	ReadData(command string, data []byte) error
+	// WriteData writes byte data to the device by the given command.
+	WriteData(command string, data []byte) error
+	// ReadInteger reads an integer value from the device by the given command.
+	ReadInteger(command string) (int, error)
+	// WriteInteger writes an integer value to the device by the given command.
+	WriteInteger(command string, val int) error
+	// Close the 1-wire connection.
+	Close() error
+}
+
+// InputEvent is an event of an input device, according to the Linux input subsystem (evdev), see
+// "linux/input-event-codes.h" for the types and codes.
+type InputEvent struct {
+	Time  time.Time
+	Type  uint16 // e.g. EV_KEY, EV_REL, EV_ABS
+	Code  uint16 // e.g. KEY_A, REL_WHEEL, ABS_X
+	Value int32  // e.g. 0 for release, 1 for press and 2 for auto repeat of keys
+}
+
+// InputSystemDevicer is the interface to an input device at system level, e.g. "/dev/input/event0".
+type InputSystemDevicer interface {
+	// ReadEvents blocks until at least one event is available and returns all available events.
+	ReadEvents() ([]InputEvent, error)
+	// Grab gets (true) or releases (false) the exclusive access to the device, so the events are not delivered to
+	// other readers, e.g. the console.
+	Grab(grab bool) error
+	// Close releases the device, a blocked ReadEvents() returns with an error.
+	Close() error
+}
+
+// LedSystemDevicer is the interface to a LED at system level, e.g. "/sys/class/leds/ACT".
+type LedSystemDevicer interface {
+	// Name returns the name of the LED, e.g. "ACT" or "beaglebone:green:usr0".
+	Name() string
+	// Brightness reads the current brightness, 0 means off.
+	Brightness() (int, error)
+	// SetBrightness writes the brightness, a value of 0 deactivates the trigger also.
+	SetBrightness(val int) error
+	// MaxBrightness reads the maximum brightness, which is 1 for LEDs without dimming.
+	MaxBrightness() (int, error)
+	// Trigger reads the current trigger, e.g. "none", "heartbeat" or "mmc0".
+	Trigger() (string, error)
+	// Triggers reads all available triggers.
+	Triggers() ([]string, error)
+	// SetTrigger activates the given trigger, "none" deactivates the trigger.
+	SetTrigger(trigger string) error
+	// SetTimerDelays writes the on and off time for blinking, the trigger "timer" needs to be active.
+	SetTimerDelays(on, off time.Duration) error
+}
+
+// HwmonChannel describes a sensor channel of a hardware monitoring device at system level.
+type HwmonChannel struct {
+	Name  string // the channel, e.g. "temp1", "in0" or "fan1"
+	Label string // the label given by the kernel driver, e.g. "Core 0", empty if not provided
+	Unit  string // the unit of the scaled value, e.g. "°C", "V" or "RPM"
+}
+
+// HwmonSystemDevicer is the interface to a hardware monitoring device at system level, e.g. "/sys/class/hwmon/hwmon0".
+type HwmonSystemDevicer interface {
+	// Name returns the name of the device given by the kernel driver, e.g. "cpu_thermal".
+	Name() string
+	// Channels returns all sensor channels of the device.
+	Channels() []HwmonChannel
+	// Read reads the value of the given channel, scaled to the unit of the channel.
+	Read(channel string) (float64, error)
+}
+
+// WatchdogSystemDevicer is the interface to a hardware watchdog at system level, e.g. "/dev/watchdog". The watchdog
+// is started by opening the device and resets the system, if it is not kept alive within the timeout.
+type WatchdogSystemDevicer interface {
+	// Keepalive resets the timer of the watchdog.
+	Keepalive() error
+	// SetTimeout sets the timeout and returns the timeout used by the kernel driver, which can differ.
+	SetTimeout(timeout time.Duration) (time.Duration, error)
+	// Timeout reads the current timeout.
+	Timeout() (time.Duration, error)
+	// TimeLeft reads the time left before the system is reset, not supported by all kernel drivers.
+	TimeLeft() (time.Duration, error)
+	// BootStatus reads the flags for the reason of the last reboot, e.g. WDIOF_CARDRESET (0x20), see
+	// "linux/watchdog.h".
+	BootStatus() (uint32, error)
+	// MagicClose stops the watchdog and releases the device, not possible if the kernel option "nowayout" is set.
+	MagicClose() error
+	// Close releases the device without stopping the watchdog, so the system will be reset after the timeout.
+	Close() error
+}
+
+// CanFrame is a frame of a CAN bus, including CAN FD frames, see "linux/can.h".
+type CanFrame struct {
+	Time     time.Time // time of reception, zero for frames to send
+	ID       uint32    // 11 bit identifier, 29 bit for extended frames, the error class for error frames
+	Extended bool      // extended frame format (29 bit identifier)
+	Remote   bool      // remote transmission request, the length of Data is used as DLC, not for CAN FD
+	Error    bool      // error frame, only received if activated by the error filter, see "linux/can/error.h"
+	FD       bool      // CAN FD frame with up to 64 bytes of data
+	BRS      bool      // bit rate switch, only for CAN FD
+	ESI      bool      // error state indicator, only for CAN FD
+	Data     []byte    // up to 8 bytes, for CAN FD up to 64 bytes
+}
+
+// CanFilter is a filter for received frames, which is applied by the kernel. A frame is received, if
+// "received ID & Mask == ID & Mask".
+type CanFilter struct {
+	ID       uint32
+	Mask     uint32
+	Extended bool // matches only extended frames, otherwise only standard frames
+	Invert   bool // matches all frames, which are not matched by ID and Mask
+}
+
+// CanSystemDevicer is the interface to a CAN socket at system level, e.g. for the network interface "can0".
+type CanSystemDevicer interface {
+	// ReadFrame blocks until a frame is received.
+	ReadFrame() (CanFrame, error)
+	// WriteFrame sends the given frame.
+	WriteFrame(frame CanFrame) error
+	// SetFilters replaces the filters for received frames, no filter means no frame is received.
+	SetFilters(filters []CanFilter) error
+	// SetErrorFilter activates the reception of error frames for the given error classes, 0 deactivates it.
+	SetErrorFilter(mask uint32) error
+	// Close releases the socket, a blocked ReadFrame() returns with an error.
+	Close() error
+}
+
 // BusOperations are functions provided by a bus device, e.g. SPI, i2c.
 type BusOperations interface {
 	// ReadByteData reads a byte from the given register of bus device.
@@ -192,6 +386,66 @@ type BusOperations interface {
 	WriteBytes(data []byte) error
 }
 
+// I2cProber is the interface for i2c system devices, which are able to probe for the presence of a device, e.g. to
+// scan the bus.
+type I2cProber interface {
+	// ProbeAddress returns true, if a device acknowledges the given address. Like "i2cdetect" this must be implemented
+	// as the sequence of "quick write":
+	// "S Addr Wr [A] P"
+	// but for the address ranges 0x30-0x37 and 0x50-0x5F as the sequence of "read byte", to prevent corrupting
+	// EEPROMs:
+	// "S Addr Rd [A] [Data] NA P"
+	ProbeAddress(address int) (bool, error)
+}
+
+const (
+	// PinFunctionGpio is the function of a pin used as digital input or output.
+	PinFunctionGpio = "gpio"
+	// PinFunctionPwm is the function of a pin used as PWM output, e.g. for servos.
+	PinFunctionPwm = "pwm"
+	// PinFunctionI2c is the function of a pin used by an i2c bus.
+	PinFunctionI2c = "i2c"
+	// PinFunctionSpi is the function of a pin used by a SPI bus.
+	PinFunctionSpi = "spi"
+)
+
+// PinAllocation describes the usage of a header pin.
+type PinAllocation struct {
+	Pin      string `json:"pin"`
+	Function string `json:"function"` // e.g. PinFunctionGpio
+	Owner    string `json:"owner"`    // e.g. the name of the driver or "i2c bus 1"
+}
+
+// PinOwner is the interface for users of header pins, e.g. drivers and buses. The owner is identified by the value
+// of the interface (e.g. the pointer of the driver), so two drivers with the same name can not share a pin. The name
+// is used for messages and for the list of allocations.
+type PinOwner interface {
+	Name() string
+}
+
+// PinClaimer is the interface for adaptors, which record the usage of header pins by drivers and buses, to reject
+// conflicting usages.
+type PinClaimer interface {
+	// ClaimPin records the usage of the pin in the given function (e.g. PinFunctionGpio) by the given owner (e.g. the
+	// driver). An error is returned, if the pin is already used by another owner.
+	ClaimPin(pin string, function string, owner PinOwner) error
+	// ReleasePins removes all records of the given owner.
+	ReleasePins(owner PinOwner)
+}
+
+// PinAllocationLister is the interface for adaptors, which provide the current usage of the header pins, e.g. for
+// the API.
+type PinAllocationLister interface {
+	// PinAllocations returns all currently used pins, sorted by the pin.
+	PinAllocations() []PinAllocation
+}
+
+// BusRegisterNamer is the interface for bus devices, which are able to use the names of registers, e.g. for tracing.
+type BusRegisterNamer interface {
+	// SetRegisterNamer sets the function to get the name of a register for the device with the given address.
+	SetRegisterNamer(address int, namer func(reg uint8) string)
+}
+
 // I2cOperations represents the i2c methods according to I2C/SMBus specification.
 type I2cOperations interface {
 	io.ReadWriteCloser
@@ -209,6 +463,24 @@ type SpiOperations interface {
 	BusOperations
 	// ReadCommandData uses the SPI device TX to send/receive data.
 	ReadCommandData(command []byte, data []byte) error
+	// Transfer sends/receives all given transfers as one message.
+	Transfer(xfers []SpiXfer) error
+	// Close the connection.
+	Close() error
+}
+
+// OneWireOperations are the wrappers around the actual functions used by the 1-wire device interface
+type OneWireOperations interface {
+	// ID returns the device id in the form "family code"-"serial number".
+	ID() string
+	// ReadData reads from the device
+	ReadData(command string, data []byte) error
+	// WriteData writes to the device
+	WriteData(command string, data []byte) error
+	// ReadInteger reads an integer value from the device
+	ReadInteger(command string) (int, error)
+	// WriteInteger writes an integer value to the device
+	WriteInteger(command string, val int) error
 	// Close the connection.
 	Close() error
 }
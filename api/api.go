@@ -146,6 +146,7 @@ func (a *API) AddC3PIORoutes() {
 	a.Post(robotDeviceCommandRoute, a.executeRobotDeviceCommand)
 	a.Get("/api/robots/:robot/connections", a.robotConnections)
 	a.Get("/api/robots/:robot/connections/:connection", a.robotConnection)
+	a.Get("/api/robots/:robot/connections/:connection/pins", a.robotConnectionPins)
 	a.Get("/api/", a.mcp)
 }
 
@@ -334,6 +335,23 @@ func (a *API) robotConnection(res http.ResponseWriter, req *http.Request) {
 	}
 }
 
+// robotConnectionPins returns connection pins route handler
+// writes JSON with the usage of the header pins, if recorded by the connection
+func (a *API) robotConnectionPins(res http.ResponseWriter, req *http.Request) {
+	name := req.URL.Query().Get(":connection")
+	connection := a.master.Robot(req.URL.Query().Get(":robot")).Connection(name)
+	if connection == nil {
+		a.writeJSON(map[string]interface{}{"error": "No Connection found with the name " + name}, res)
+		return
+	}
+
+	if lister, ok := connection.(gobot.PinAllocationLister); ok {
+		a.writeJSON(map[string]interface{}{"pins": lister.PinAllocations()}, res)
+	} else {
+		a.writeJSON(map[string]interface{}{"error": "No usage of pins recorded by the connection " + name}, res)
+	}
+}
+
 // executeMcpCommand calls a global command associated to requested route
 func (a *API) executeMcpCommand(res http.ResponseWriter, req *http.Request) {
 	a.executeCommand(a.master.Command(req.URL.Query().Get(":command")),
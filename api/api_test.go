@@ -382,6 +382,38 @@ func TestRobotConnection(t *testing.T) {
 	assert.Equal(t, "No Connection found with the name UnknownConnection1", body["error"])
 }
 
+func TestRobotConnectionPins(t *testing.T) {
+	a := initTestAPI()
+	a.master.AddRobot(gobot.NewRobot("PinsRobot",
+		[]gobot.Connection{&testPinsAdaptor{testAdaptor: newTestAdaptor("PinsConnection", "/dev/null")}}))
+
+	// connection with recorded pins
+	request, _ := http.NewRequest("GET", "/api/robots/PinsRobot/connections/PinsConnection/pins", nil)
+	response := httptest.NewRecorder()
+	a.ServeHTTP(response, request)
+
+	var body map[string]interface{}
+	_ = json.NewDecoder(response.Body).Decode(&body)
+	assert.Equal(t, []interface{}{map[string]interface{}{"pin": "7", "function": "gpio", "owner": "Device1"}},
+		body["pins"])
+
+	// connection without recorded pins
+	request, _ = http.NewRequest("GET", "/api/robots/Robot1/connections/Connection1/pins", nil)
+	response = httptest.NewRecorder()
+	a.ServeHTTP(response, request)
+	body = map[string]interface{}{}
+	_ = json.NewDecoder(response.Body).Decode(&body)
+	assert.Equal(t, "No usage of pins recorded by the connection Connection1", body["error"])
+
+	// unknown connection
+	request, _ = http.NewRequest("GET", "/api/robots/Robot1/connections/UnknownConnection1/pins", nil)
+	response = httptest.NewRecorder()
+	a.ServeHTTP(response, request)
+	body = map[string]interface{}{}
+	_ = json.NewDecoder(response.Body).Decode(&body)
+	assert.Equal(t, "No Connection found with the name UnknownConnection1", body["error"])
+}
+
 func TestRobotDeviceEvent(t *testing.T) {
 	a := initTestAPI()
 	server := httptest.NewServer(a)
@@ -76,6 +76,15 @@ func (t *testAdaptor) Name() string     { return t.name }
 func (t *testAdaptor) SetName(n string) { t.name = n }
 func (t *testAdaptor) Port() string     { return t.port }
 
+// testPinsAdaptor records the usage of pins
+type testPinsAdaptor struct {
+	*testAdaptor
+}
+
+func (t *testPinsAdaptor) PinAllocations() []gobot.PinAllocation {
+	return []gobot.PinAllocation{{Pin: "7", Function: gobot.PinFunctionGpio, Owner: "Device1"}}
+}
+
 func newTestAdaptor(name string, port string) *testAdaptor {
 	return &testAdaptor{
 		name: name,
@@ -16,6 +16,8 @@ const (
 	Value = "value"
 	// Vibration event
 	Vibration = "vibration"
+	// Samples event
+	Samples = "samples"
 )
 
 // AnalogReader interface represents an Adaptor which has AnalogRead capabilities
@@ -30,6 +32,20 @@ type AnalogWriter interface {
 	AnalogWrite(pin string, val int) error
 }
 
+// AnalogBufferOpener interface represents an Adaptor which is able to sample analog pins into a buffer
+type AnalogBufferOpener interface {
+	// gobot.Adaptor
+	OpenAnalogBuffer(pins []string, opts ...func(gobot.AnalogBufferOptioner) bool) (gobot.AnalogBufferSystemDevicer,
+		error)
+}
+
+// HwmonOpener interface represents an Adaptor which provides access to the hardware monitoring devices of the board
+// by the Linux hwmon class
+type HwmonOpener interface {
+	// gobot.Adaptor
+	OpenHwmon(name string) (gobot.HwmonSystemDevicer, error)
+}
+
 // optionApplier needs to be implemented by each configurable option type
 type optionApplier interface {
 	apply(cfg *configuration)
@@ -0,0 +1,250 @@
+package aio
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"gobot.io/x/gobot/v2"
+)
+
+const streamDefaultBatchSize = 64 // scans
+
+// streamReadErrorPause is the pause after a failed read, e.g. if the device was removed
+const streamReadErrorPause = 100 * time.Millisecond
+
+// streamOptionApplier needs to be implemented by each configurable option type
+type streamOptionApplier interface {
+	apply(cfg *streamConfiguration)
+}
+
+// streamConfiguration contains all changeable attributes of the driver.
+type streamConfiguration struct {
+	batchSize     int
+	bufferOptions []func(gobot.AnalogBufferOptioner) bool
+}
+
+// streamBatchSizeOption is the type for applying another maximum count of scans for each event
+type streamBatchSizeOption int
+
+// streamSamplingFrequencyOption is the type for applying a sampling frequency
+type streamSamplingFrequencyOption int
+
+// streamBufferLengthOption is the type for applying another buffer length of the device
+type streamBufferLengthOption int
+
+// streamTriggerOption is the type for applying a trigger of the device
+type streamTriggerOption string
+
+// streamTimestampOption is the type for activating the timestamp of each scan
+type streamTimestampOption struct{}
+
+// AnalogStreamDriver represents a buffered, continuously sampled group of analog inputs, e.g. for vibration or
+// audio-rate sensing. In contrast to the AnalogSensorDriver the sampling is done by the hardware with a regular
+// timing and the values are read in batches.
+type AnalogStreamDriver struct {
+	*driver
+	streamCfg *streamConfiguration
+	pins      []string
+	buffer    gobot.AnalogBufferSystemDevicer
+	halt      chan struct{}
+	readMutex sync.Mutex
+	readErr   error // error of the last read, reset by the next successful read
+	pause     func() <-chan time.Time
+	gobot.Eventer
+}
+
+// NewAnalogStreamDriver returns a new driver for the buffered sampling of the given pins. All pins needs to belong
+// to the same device, e.g. the ADC of the board. Sampling starts on Start() and each read batch of scans is emitted
+// as event. The values of each scan are in the order of the given pins.
+//
+// Supported options:
+//
+//	"WithName"
+//	"WithStreamBatchSize"
+//	"WithStreamSamplingFrequency"
+//	"WithStreamBufferLength"
+//	"WithStreamTrigger"
+//	"WithStreamTimestamp"
+func NewAnalogStreamDriver(a AnalogBufferOpener, pins []string, opts ...interface{}) *AnalogStreamDriver {
+	d := &AnalogStreamDriver{
+		driver:    newDriver(a, "AnalogStream"),
+		streamCfg: &streamConfiguration{batchSize: streamDefaultBatchSize},
+		pins:      pins,
+		pause:     func() <-chan time.Time { return time.After(streamReadErrorPause) },
+		Eventer:   gobot.NewEventer(),
+	}
+	d.afterStart = d.initialize
+	d.beforeHalt = d.shutdown
+	d.AddEvent(Samples)
+	d.AddEvent(Error)
+
+	for _, opt := range opts {
+		switch o := opt.(type) {
+		case optionApplier:
+			o.apply(d.driverCfg)
+		case streamOptionApplier:
+			o.apply(d.streamCfg)
+		default:
+			panic(fmt.Sprintf("'%s' can not be applied on '%s'", opt, d.driverCfg.name))
+		}
+	}
+
+	return d
+}
+
+// WithStreamBatchSize substitutes the default of maximal 64 scans, emitted with each event.
+func WithStreamBatchSize(scans int) streamOptionApplier {
+	return streamBatchSizeOption(scans)
+}
+
+// WithStreamSamplingFrequency sets the sampling frequency in Hz. Without this option, the current setting of the
+// device is used.
+func WithStreamSamplingFrequency(frequency int) streamOptionApplier {
+	return streamSamplingFrequencyOption(frequency)
+}
+
+// WithStreamBufferLength substitutes the default buffer length of the system, given in scans.
+func WithStreamBufferLength(scans int) streamOptionApplier {
+	return streamBufferLengthOption(scans)
+}
+
+// WithStreamTrigger sets the trigger of the device, e.g. "hrtimer0". Some devices sample continuously and do not
+// need a trigger.
+func WithStreamTrigger(name string) streamOptionApplier {
+	return streamTriggerOption(name)
+}
+
+// WithStreamTimestamp activates the capture of the timestamp for each scan.
+func WithStreamTimestamp() streamOptionApplier {
+	return streamTimestampOption{}
+}
+
+// Pins returns the pins of the driver
+func (d *AnalogStreamDriver) Pins() []string {
+	return append([]string(nil), d.pins...)
+}
+
+// Healthy returns the error of the last read from the buffer, nil if the last read was successful.
+func (d *AnalogStreamDriver) Healthy() error {
+	d.readMutex.Lock()
+	defer d.readMutex.Unlock()
+
+	return d.readErr
+}
+
+// initialize starts the sampling and the reading of the buffer.
+// Emits the Events:
+//
+//	Samples []gobot.AnalogScan - Event is emitted for each read batch of scans.
+//	Error error - Event is emitted on error reading from the buffer.
+func (d *AnalogStreamDriver) initialize() error {
+	opener, ok := d.connection.(AnalogBufferOpener)
+	if !ok {
+		return fmt.Errorf("analog buffer is not supported by the platform '%s'", d.Connection().Name())
+	}
+
+	buffer, err := opener.OpenAnalogBuffer(d.pins, d.streamCfg.bufferOptions...)
+	if err != nil {
+		return err
+	}
+	d.buffer = buffer
+
+	halt := make(chan struct{})
+	d.halt = halt
+
+	go func() {
+		for {
+			scans, err := buffer.ReadScans(d.streamCfg.batchSize)
+
+			select {
+			case <-halt:
+				// an error can be caused by closing the buffer, so it is not published
+				return
+			default:
+			}
+
+			d.readMutex.Lock()
+			d.readErr = err
+			d.readMutex.Unlock()
+
+			if err != nil {
+				d.Publish(d.Event(Error), err)
+				select {
+				case <-d.pause():
+					continue
+				case <-halt:
+					return
+				}
+			}
+
+			if len(scans) > 0 {
+				d.Publish(d.Event(Samples), scans)
+			}
+		}
+	}()
+
+	return nil
+}
+
+// shutdown stops the reading and the sampling.
+func (d *AnalogStreamDriver) shutdown() error {
+	if d.halt == nil {
+		return nil
+	}
+
+	close(d.halt) // broadcast halt, also to the test
+	d.halt = nil
+
+	err := d.buffer.Close()
+	d.buffer = nil
+	return err
+}
+
+func (o streamBatchSizeOption) String() string {
+	return "batch size option for analog streams"
+}
+
+func (o streamSamplingFrequencyOption) String() string {
+	return "sampling frequency option for analog streams"
+}
+
+func (o streamBufferLengthOption) String() string {
+	return "buffer length option for analog streams"
+}
+
+func (o streamTriggerOption) String() string {
+	return "trigger option for analog streams"
+}
+
+func (o streamTimestampOption) String() string {
+	return "timestamp option for analog streams"
+}
+
+func (o streamBatchSizeOption) apply(cfg *streamConfiguration) {
+	cfg.batchSize = int(o)
+}
+
+func (o streamSamplingFrequencyOption) apply(cfg *streamConfiguration) {
+	cfg.bufferOptions = append(cfg.bufferOptions, func(b gobot.AnalogBufferOptioner) bool {
+		return b.SetSamplingFrequency(int(o))
+	})
+}
+
+func (o streamBufferLengthOption) apply(cfg *streamConfiguration) {
+	cfg.bufferOptions = append(cfg.bufferOptions, func(b gobot.AnalogBufferOptioner) bool {
+		return b.SetBufferLength(int(o))
+	})
+}
+
+func (o streamTriggerOption) apply(cfg *streamConfiguration) {
+	cfg.bufferOptions = append(cfg.bufferOptions, func(b gobot.AnalogBufferOptioner) bool {
+		return b.SetTrigger(string(o))
+	})
+}
+
+func (o streamTimestampOption) apply(cfg *streamConfiguration) {
+	cfg.bufferOptions = append(cfg.bufferOptions, func(b gobot.AnalogBufferOptioner) bool {
+		return b.SetTimestamp()
+	})
+}
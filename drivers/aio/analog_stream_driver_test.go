@@ -0,0 +1,280 @@
+//nolint:forcetypeassert // ok here
+package aio
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+)
+
+var _ gobot.Driver = (*AnalogStreamDriver)(nil)
+
+type aioTestBufferOptions struct {
+	samplingFrequency int
+	length            int
+	trigger           string
+	timestamp         bool
+}
+
+func (o *aioTestBufferOptions) SetSamplingFrequency(frequency int) bool {
+	o.samplingFrequency = frequency
+	return true
+}
+
+func (o *aioTestBufferOptions) SetBufferLength(length int) bool {
+	o.length = length
+	return true
+}
+
+func (o *aioTestBufferOptions) SetTrigger(name string) bool {
+	o.trigger = name
+	return true
+}
+
+func (o *aioTestBufferOptions) SetTimestamp() bool {
+	o.timestamp = true
+	return true
+}
+
+type aioTestBuffer struct {
+	channels []string
+	scans    chan []gobot.AnalogScan
+	readErr  error
+	closed   chan struct{}
+	maxScans int
+	reads    int
+	mtx      sync.Mutex
+}
+
+func (b *aioTestBuffer) Channels() []string { return b.channels }
+
+func (b *aioTestBuffer) ReadScans(maxScans int) ([]gobot.AnalogScan, error) {
+	b.mtx.Lock()
+	b.maxScans = maxScans
+	b.reads++
+	readErr := b.readErr
+	b.mtx.Unlock()
+
+	select {
+	case scans := <-b.scans:
+		return scans, readErr
+	case <-b.closed:
+		return nil, fmt.Errorf("closed")
+	}
+}
+
+func (b *aioTestBuffer) Close() error {
+	close(b.closed)
+	return nil
+}
+
+type aioTestBufferAdaptor struct {
+	*aioTestAdaptor
+	buffer      *aioTestBuffer
+	options     aioTestBufferOptions
+	openedPins  []string
+	simulateErr bool
+}
+
+func newAioTestBufferAdaptor() *aioTestBufferAdaptor {
+	return &aioTestBufferAdaptor{
+		aioTestAdaptor: newAioTestAdaptor(),
+		buffer: &aioTestBuffer{
+			scans:  make(chan []gobot.AnalogScan, 1),
+			closed: make(chan struct{}),
+		},
+	}
+}
+
+func (a *aioTestBufferAdaptor) OpenAnalogBuffer(
+	pins []string,
+	opts ...func(gobot.AnalogBufferOptioner) bool,
+) (gobot.AnalogBufferSystemDevicer, error) {
+	if a.simulateErr {
+		return nil, fmt.Errorf("open error")
+	}
+	for _, o := range opts {
+		o(&a.options)
+	}
+	a.openedPins = pins
+	a.buffer.channels = pins
+	return a.buffer, nil
+}
+
+func TestNewAnalogStreamDriver(t *testing.T) {
+	// arrange
+	pins := []string{"in_voltage0", "in_voltage1"}
+	a := newAioTestBufferAdaptor()
+	// act
+	d := NewAnalogStreamDriver(a, pins)
+	// assert: driver attributes
+	assert.IsType(t, &AnalogStreamDriver{}, d)
+	assert.NotNil(t, d.driverCfg)
+	assert.True(t, strings.HasPrefix(d.Name(), "AnalogStream"))
+	assert.Equal(t, a, d.Connection())
+	assert.NotNil(t, d.afterStart)
+	assert.NotNil(t, d.beforeHalt)
+	assert.NotNil(t, d.Commander)
+	assert.NotNil(t, d.mutex)
+	// assert: stream attributes
+	assert.Equal(t, pins, d.Pins())
+	assert.Nil(t, d.halt) // will be created on initialize
+	assert.NotNil(t, d.Eventer)
+	require.NotNil(t, d.streamCfg)
+	assert.Equal(t, 64, d.streamCfg.batchSize)
+	assert.Empty(t, d.streamCfg.bufferOptions)
+}
+
+func TestNewAnalogStreamDriver_options(t *testing.T) {
+	// arrange
+	const myName = "vibration"
+	a := newAioTestBufferAdaptor()
+	panicFunc := func() {
+		NewAnalogStreamDriver(a, []string{"in_voltage0"}, WithName("crazy"), WithSensorCyclicRead(time.Second))
+	}
+	// act
+	d := NewAnalogStreamDriver(a, []string{"in_voltage0"}, WithName(myName), WithStreamBatchSize(16),
+		WithStreamSamplingFrequency(8000), WithStreamBufferLength(1024), WithStreamTrigger("hrtimer0"),
+		WithStreamTimestamp())
+	// assert
+	assert.Equal(t, myName, d.Name())
+	assert.Equal(t, 16, d.streamCfg.batchSize)
+	assert.Len(t, d.streamCfg.bufferOptions, 4)
+	assert.PanicsWithValue(t, "'read interval option for analog sensors' can not be applied on 'crazy'", panicFunc)
+}
+
+func TestAnalogStreamDriverStartHalt(t *testing.T) {
+	// arrange
+	a := newAioTestBufferAdaptor()
+	d := NewAnalogStreamDriver(a, []string{"in_voltage1", "in_voltage0"}, WithStreamBatchSize(8),
+		WithStreamSamplingFrequency(8000), WithStreamBufferLength(1024), WithStreamTrigger("hrtimer0"),
+		WithStreamTimestamp())
+	want := []gobot.AnalogScan{{Values: []int{1, 2}}, {Values: []int{3, 4}}}
+	gotChan := make(chan []gobot.AnalogScan, 1)
+	_ = d.Once(d.Event(Samples), func(data interface{}) {
+		gotChan <- data.([]gobot.AnalogScan)
+	})
+	// act
+	require.NoError(t, d.Start())
+	a.buffer.scans <- want
+	// assert
+	select {
+	case got := <-gotChan:
+		assert.Equal(t, want, got)
+	case <-time.After(time.Second):
+		require.Fail(t, "Samples event was not published")
+	}
+	assert.Equal(t, []string{"in_voltage1", "in_voltage0"}, a.openedPins)
+	assert.Equal(t, aioTestBufferOptions{samplingFrequency: 8000, length: 1024, trigger: "hrtimer0", timestamp: true},
+		a.options)
+	a.buffer.mtx.Lock()
+	assert.Equal(t, 8, a.buffer.maxScans)
+	a.buffer.mtx.Unlock()
+	// act & assert halt
+	require.NoError(t, d.Halt())
+	assert.Nil(t, d.halt)
+	select {
+	case <-a.buffer.closed:
+	default:
+		require.Fail(t, "buffer was not closed")
+	}
+	require.NoError(t, d.Halt())
+}
+
+func TestAnalogStreamDriverReadError(t *testing.T) {
+	// arrange
+	a := newAioTestBufferAdaptor()
+	a.buffer.readErr = fmt.Errorf("read error")
+	d := NewAnalogStreamDriver(a, []string{"in_voltage0"})
+	errChan := make(chan error, 1)
+	_ = d.Once(d.Event(Error), func(data interface{}) {
+		errChan <- data.(error)
+	})
+	// act
+	require.NoError(t, d.Start())
+	a.buffer.scans <- nil
+	// assert
+	select {
+	case err := <-errChan:
+		require.EqualError(t, err, "read error")
+	case <-time.After(time.Second):
+		require.Fail(t, "Error event was not published")
+	}
+	require.EqualError(t, d.Healthy(), "read error")
+	// arrange
+	a.buffer.mtx.Lock()
+	a.buffer.readErr = nil
+	a.buffer.mtx.Unlock()
+	// act: the pending read still returns the error
+	a.buffer.scans <- nil
+	a.buffer.scans <- nil
+	// assert: the error is reset by the next successful read
+	assert.Eventually(t, func() bool { return d.Healthy() == nil }, time.Second, 5*time.Millisecond)
+	require.NoError(t, d.Halt())
+}
+
+func TestAnalogStreamDriverReadError_pause(t *testing.T) {
+	// arrange
+	a := newAioTestBufferAdaptor()
+	a.buffer.readErr = fmt.Errorf("persistent read error")
+	close(a.buffer.scans) // each read returns immediately
+	d := NewAnalogStreamDriver(a, []string{"in_voltage0"})
+	pauses := make(chan chan time.Time)
+	d.pause = func() <-chan time.Time {
+		p := make(chan time.Time)
+		pauses <- p
+		return p
+	}
+	readCount := func() int {
+		a.buffer.mtx.Lock()
+		defer a.buffer.mtx.Unlock()
+		return a.buffer.reads
+	}
+	nextPause := func() chan time.Time {
+		select {
+		case p := <-pauses:
+			return p
+		case <-time.After(time.Second):
+			require.Fail(t, "no pause after the failed read")
+			return nil
+		}
+	}
+	// act
+	require.NoError(t, d.Start())
+	p := nextPause()
+	// assert: the next read waits for the end of the pause
+	assert.Equal(t, 1, readCount())
+	// act
+	close(p)
+	_ = nextPause()
+	// assert
+	assert.Equal(t, 2, readCount())
+	// act: halt during the pause
+	require.NoError(t, d.Halt())
+	// assert: no further read after halt
+	select {
+	case <-pauses:
+		require.Fail(t, "read after halt")
+	case <-time.After(10 * time.Millisecond):
+	}
+	assert.Equal(t, 2, readCount())
+}
+
+func TestAnalogStreamDriverStartError(t *testing.T) {
+	// arrange
+	a := newAioTestBufferAdaptor()
+	a.simulateErr = true
+	d := NewAnalogStreamDriver(a, []string{"in_voltage0"})
+	// act
+	err := d.Start()
+	// assert
+	require.EqualError(t, err, "open error")
+	assert.Nil(t, d.halt)
+}
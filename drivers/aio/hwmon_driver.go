@@ -0,0 +1,284 @@
+package aio
+
+import (
+	"fmt"
+	"time"
+
+	"gobot.io/x/gobot/v2"
+)
+
+// hwmonOptionApplier needs to be implemented by each configurable option type
+type hwmonOptionApplier interface {
+	apply(cfg *hwmonConfiguration)
+}
+
+// hwmonConfiguration contains all changeable attributes of the driver.
+type hwmonConfiguration struct {
+	readInterval time.Duration
+	channels     []string
+}
+
+// hwmonReadIntervalOption is the type for applying another read interval to the configuration
+type hwmonReadIntervalOption time.Duration
+
+// hwmonChannelsOption is the type for applying a selection of channels to the configuration
+type hwmonChannelsOption []string
+
+// HwmonValue is the data of the Value event of the HwmonDriver.
+type HwmonValue struct {
+	gobot.HwmonChannel
+	Value float64
+}
+
+// HwmonDriver represents a hardware monitoring device of the board, which is provided by the Linux hwmon class, e.g.
+// the temperature sensor of the CPU ("cpu_thermal"), a fan controller or a power monitor like the INA3221.
+type HwmonDriver struct {
+	*driver
+	hwmonCfg   *hwmonConfiguration
+	deviceName string
+	device     gobot.HwmonSystemDevicer
+	channels   []gobot.HwmonChannel
+	lastValues map[string]float64
+	halt       chan struct{}
+	gobot.Eventer
+}
+
+// NewHwmonDriver returns a new driver for the hardware monitoring device with the given name, e.g. "cpu_thermal",
+// or the given directory, e.g. "hwmon0". All devices are listed in "/sys/class/hwmon". The values are scaled to the
+// unit of the channel, e.g. °C, V, A, W or RPM.
+//
+// Supported options:
+//
+//	"WithName"
+//	"WithHwmonCyclicRead"
+//	"WithHwmonChannels"
+//
+// Adds the following API Commands:
+//
+//	"Read"    - See HwmonDriver.Read
+//	"ReadAll" - See HwmonDriver.ReadAll
+func NewHwmonDriver(a HwmonOpener, deviceName string, opts ...interface{}) *HwmonDriver {
+	d := &HwmonDriver{
+		driver:     newDriver(a, "Hwmon"),
+		hwmonCfg:   &hwmonConfiguration{},
+		deviceName: deviceName,
+		Eventer:    gobot.NewEventer(),
+	}
+	d.afterStart = d.initialize
+	d.beforeHalt = d.shutdown
+	d.AddEvent(Value)
+	d.AddEvent(Error)
+
+	for _, opt := range opts {
+		switch o := opt.(type) {
+		case optionApplier:
+			o.apply(d.driverCfg)
+		case hwmonOptionApplier:
+			o.apply(d.hwmonCfg)
+		default:
+			panic(fmt.Sprintf("'%s' can not be applied on '%s'", opt, d.driverCfg.name))
+		}
+	}
+
+	d.AddCommand("Read", func(params map[string]interface{}) interface{} {
+		channel := params["channel"].(string) //nolint:forcetypeassert // ok here
+		val, err := d.Read(channel)
+		return map[string]interface{}{"val": val, "err": err}
+	})
+
+	d.AddCommand("ReadAll", func(params map[string]interface{}) interface{} {
+		val, err := d.ReadAll()
+		return map[string]interface{}{"val": val, "err": err}
+	})
+
+	return d
+}
+
+// WithHwmonCyclicRead add a asynchronous cyclic reading of all channels with the given read interval.
+func WithHwmonCyclicRead(interval time.Duration) hwmonOptionApplier {
+	return hwmonReadIntervalOption(interval)
+}
+
+// WithHwmonChannels restricts the channels to the given ones, e.g. "temp1" and "fan1". By default all channels
+// of the device are used.
+func WithHwmonChannels(channels ...string) hwmonOptionApplier {
+	return hwmonChannelsOption(channels)
+}
+
+// DeviceName returns the name of the device in the system.
+func (d *HwmonDriver) DeviceName() string {
+	return d.deviceName
+}
+
+// Channels returns the used channels with label and unit. The channels are available after Start().
+func (d *HwmonDriver) Channels() []gobot.HwmonChannel {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return append([]gobot.HwmonChannel(nil), d.channels...)
+}
+
+// Read reads the value of the given channel, e.g. "temp1".
+func (d *HwmonDriver) Read(channel string) (float64, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.device == nil {
+		return 0, fmt.Errorf("hwmon device '%s' not started", d.deviceName)
+	}
+	val, err := d.device.Read(channel)
+	if err != nil {
+		return 0, err
+	}
+	d.lastValues[channel] = val
+	return val, nil
+}
+
+// ReadAll reads the values of all used channels.
+func (d *HwmonDriver) ReadAll() (map[string]float64, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.device == nil {
+		return nil, fmt.Errorf("hwmon device '%s' not started", d.deviceName)
+	}
+
+	values := make(map[string]float64, len(d.channels))
+	for _, ch := range d.channels {
+		val, err := d.device.Read(ch.Name)
+		if err != nil {
+			return nil, err
+		}
+		values[ch.Name] = val
+		d.lastValues[ch.Name] = val
+	}
+	return values, nil
+}
+
+// Value returns the last read value of the given channel.
+func (d *HwmonDriver) Value(channel string) float64 {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.lastValues[channel]
+}
+
+// initialize opens the device, selects the channels and, if the cyclic reading is active, reads all channels at the
+// given interval.
+// Emits the Events:
+//
+//	Value HwmonValue - Event is emitted on change of the value of a channel.
+//	Error error - Event is emitted on error reading from the device.
+func (d *HwmonDriver) initialize() error {
+	opener, ok := d.connection.(HwmonOpener)
+	if !ok {
+		return fmt.Errorf("hwmon devices are not supported by the platform '%s'", d.Connection().Name())
+	}
+
+	device, err := opener.OpenHwmon(d.deviceName)
+	if err != nil {
+		return err
+	}
+
+	channels, err := d.selectChannels(device.Channels())
+	if err != nil {
+		return err
+	}
+
+	d.device = device
+	d.channels = channels
+	d.lastValues = make(map[string]float64)
+
+	if d.hwmonCfg.readInterval == 0 {
+		// cyclic reading deactivated
+		return nil
+	}
+
+	d.halt = make(chan struct{})
+	go d.poll(d.halt)
+	return nil
+}
+
+// shutdown stops the cyclic reading
+func (d *HwmonDriver) shutdown() error {
+	if d.halt != nil {
+		close(d.halt)
+		d.halt = nil
+	}
+	d.device = nil
+	return nil
+}
+
+func (d *HwmonDriver) poll(halt chan struct{}) {
+	ticker := time.NewTicker(d.hwmonCfg.readInterval)
+	defer ticker.Stop()
+
+	oldValues := make(map[string]float64)
+	for {
+		// the first read is done immediately
+		values, err := d.ReadAll()
+
+		select {
+		case <-halt:
+			// the device was closed while waiting for the mutex
+			return
+		default:
+		}
+
+		if err != nil {
+			d.Publish(d.Event(Error), err)
+		} else {
+			for _, ch := range d.Channels() {
+				if old, ok := oldValues[ch.Name]; !ok || old != values[ch.Name] {
+					d.Publish(d.Event(Value), HwmonValue{HwmonChannel: ch, Value: values[ch.Name]})
+					oldValues[ch.Name] = values[ch.Name]
+				}
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-halt:
+			return
+		}
+	}
+}
+
+// selectChannels returns the configured channels in the given order, or all channels if nothing is configured
+func (d *HwmonDriver) selectChannels(available []gobot.HwmonChannel) ([]gobot.HwmonChannel, error) {
+	if len(d.hwmonCfg.channels) == 0 {
+		return available, nil
+	}
+
+	selected := make([]gobot.HwmonChannel, 0, len(d.hwmonCfg.channels))
+	for _, name := range d.hwmonCfg.channels {
+		found := false
+		for _, ch := range available {
+			if ch.Name == name {
+				selected = append(selected, ch)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("channel '%s' not available for hwmon device '%s'", name, d.deviceName)
+		}
+	}
+	return selected, nil
+}
+
+func (o hwmonReadIntervalOption) String() string {
+	return "read interval option for hwmon devices"
+}
+
+func (o hwmonChannelsOption) String() string {
+	return "channels option for hwmon devices"
+}
+
+func (o hwmonReadIntervalOption) apply(cfg *hwmonConfiguration) {
+	cfg.readInterval = time.Duration(o)
+}
+
+func (o hwmonChannelsOption) apply(cfg *hwmonConfiguration) {
+	cfg.channels = []string(o)
+}
@@ -0,0 +1,206 @@
+//nolint:forcetypeassert // ok here
+package aio
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+)
+
+var _ gobot.Driver = (*HwmonDriver)(nil)
+
+type aioTestHwmon struct {
+	mtx         sync.Mutex
+	values      map[string]float64
+	simulateErr bool
+}
+
+func (h *aioTestHwmon) Name() string { return "ina3221" }
+
+func (h *aioTestHwmon) Channels() []gobot.HwmonChannel {
+	return []gobot.HwmonChannel{
+		{Name: "temp1", Unit: "°C"},
+		{Name: "in1", Label: "VDD_IN", Unit: "V"},
+		{Name: "fan1", Unit: "RPM"},
+	}
+}
+
+func (h *aioTestHwmon) Read(channel string) (float64, error) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	if h.simulateErr {
+		return 0, fmt.Errorf("read error")
+	}
+	return h.values[channel], nil
+}
+
+func (h *aioTestHwmon) setValue(channel string, val float64) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	h.values[channel] = val
+}
+
+type aioTestHwmonAdaptor struct {
+	*aioTestAdaptor
+	device *aioTestHwmon
+}
+
+func newAioTestHwmonAdaptor() *aioTestHwmonAdaptor {
+	return &aioTestHwmonAdaptor{
+		aioTestAdaptor: newAioTestAdaptor(),
+		device:         &aioTestHwmon{values: map[string]float64{"temp1": 45.5, "in1": 5.1, "fan1": 2400}},
+	}
+}
+
+func (a *aioTestHwmonAdaptor) OpenHwmon(name string) (gobot.HwmonSystemDevicer, error) {
+	if name != a.device.Name() {
+		return nil, fmt.Errorf("hwmon device '%s' not found", name)
+	}
+	return a.device, nil
+}
+
+func TestNewHwmonDriver(t *testing.T) {
+	// arrange
+	a := newAioTestHwmonAdaptor()
+	// act
+	d := NewHwmonDriver(a, "ina3221")
+	// assert
+	assert.IsType(t, &HwmonDriver{}, d)
+	assert.True(t, strings.HasPrefix(d.Name(), "Hwmon"))
+	assert.Equal(t, a, d.Connection())
+	assert.NotNil(t, d.Commander)
+	assert.NotNil(t, d.Eventer)
+	assert.Equal(t, "ina3221", d.DeviceName())
+	assert.Equal(t, &hwmonConfiguration{}, d.hwmonCfg)
+	assert.Empty(t, d.Channels())
+}
+
+func TestNewHwmonDriver_options(t *testing.T) {
+	// arrange
+	panicFunc := func() {
+		NewHwmonDriver(newAioTestHwmonAdaptor(), "ina3221", WithName("crazy"), WithSensorCyclicRead(time.Second))
+	}
+	// act
+	d := NewHwmonDriver(newAioTestHwmonAdaptor(), "ina3221", WithName("power"),
+		WithHwmonCyclicRead(10*time.Millisecond), WithHwmonChannels("in1", "temp1"))
+	// assert
+	assert.Equal(t, "power", d.Name())
+	assert.Equal(t, 10*time.Millisecond, d.hwmonCfg.readInterval)
+	assert.Equal(t, []string{"in1", "temp1"}, d.hwmonCfg.channels)
+	assert.PanicsWithValue(t, "'read interval option for analog sensors' can not be applied on 'crazy'", panicFunc)
+}
+
+func TestHwmonStart(t *testing.T) {
+	tests := map[string]struct {
+		device  string
+		opts    []interface{}
+		want    []gobot.HwmonChannel
+		wantErr string
+	}{
+		"all_channels": {
+			device: "ina3221",
+			want: []gobot.HwmonChannel{
+				{Name: "temp1", Unit: "°C"},
+				{Name: "in1", Label: "VDD_IN", Unit: "V"},
+				{Name: "fan1", Unit: "RPM"},
+			},
+		},
+		"selected_channels": {
+			device: "ina3221",
+			opts:   []interface{}{WithHwmonChannels("fan1", "temp1")},
+			want:   []gobot.HwmonChannel{{Name: "fan1", Unit: "RPM"}, {Name: "temp1", Unit: "°C"}},
+		},
+		"error_unknown_channel": {
+			device:  "ina3221",
+			opts:    []interface{}{WithHwmonChannels("temp2")},
+			wantErr: "channel 'temp2' not available for hwmon device 'ina3221'",
+		},
+		"error_unknown_device": {
+			device:  "cpu_thermal",
+			wantErr: "hwmon device 'cpu_thermal' not found",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d := NewHwmonDriver(newAioTestHwmonAdaptor(), tc.device, tc.opts...)
+			// act
+			err := d.Start()
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, d.Channels())
+			require.NoError(t, d.Halt())
+		})
+	}
+}
+
+func TestHwmonRead(t *testing.T) {
+	// arrange
+	a := newAioTestHwmonAdaptor()
+	d := NewHwmonDriver(a, "ina3221", WithHwmonChannels("temp1", "in1"))
+	_, err := d.Read("temp1")
+	require.EqualError(t, err, "hwmon device 'ina3221' not started")
+	require.NoError(t, d.Start())
+	// act & assert
+	got, err := d.Read("fan1")
+	require.NoError(t, err)
+	assert.InDelta(t, 2400.0, got, 0.0)
+	all, err := d.ReadAll()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float64{"temp1": 45.5, "in1": 5.1}, all)
+	assert.InDelta(t, 5.1, d.Value("in1"), 0.0)
+	ret := d.Command("Read")(map[string]interface{}{"channel": "temp1"}).(map[string]interface{})
+	assert.InDelta(t, 45.5, ret["val"], 0.0)
+	assert.Nil(t, ret["err"])
+	ret = d.Command("ReadAll")(nil).(map[string]interface{})
+	assert.Len(t, ret["val"], 2)
+	// arrange
+	a.device.simulateErr = true
+	// act & assert
+	_, err = d.ReadAll()
+	require.EqualError(t, err, "read error")
+	require.NoError(t, d.Halt())
+}
+
+func TestHwmonCyclicRead(t *testing.T) {
+	// arrange
+	a := newAioTestHwmonAdaptor()
+	d := NewHwmonDriver(a, "ina3221", WithHwmonCyclicRead(10*time.Millisecond), WithHwmonChannels("temp1"))
+	values := make(chan HwmonValue, 10)
+	errs := make(chan error, 10)
+	_ = d.On(d.Event(Value), func(data interface{}) { values <- data.(HwmonValue) })
+	_ = d.On(d.Event(Error), func(data interface{}) { errs <- data.(error) })
+	// act
+	require.NoError(t, d.Start())
+	// assert: first value immediately
+	select {
+	case v := <-values:
+		assert.Equal(t, HwmonValue{HwmonChannel: gobot.HwmonChannel{Name: "temp1", Unit: "°C"}, Value: 45.5}, v)
+	case <-time.After(time.Second):
+		require.Fail(t, "value event was not published")
+	}
+	// act: value changed
+	a.device.setValue("temp1", 46.0)
+	// assert
+	select {
+	case v := <-values:
+		assert.InDelta(t, 46.0, v.Value, 0.0)
+	case <-time.After(time.Second):
+		require.Fail(t, "value event was not published on change")
+	}
+	require.NoError(t, d.Halt())
+	assert.Empty(t, errs)
+}
@@ -0,0 +1,190 @@
+package can
+
+import (
+	"log"
+	"sync"
+
+	"gobot.io/x/gobot/v2"
+)
+
+const (
+	// Frame event
+	Frame = "frame"
+	// ErrorFrame event
+	ErrorFrame = "error-frame"
+	// Signals event
+	Signals = "signals"
+	// Error event
+	Error = "error"
+)
+
+// Connector lets adaptors provide the drivers to get access to a CAN bus on platforms.
+type Connector interface {
+	// GetCanConnection returns a new connection to the CAN bus, which is used exclusively by the caller. Each
+	// connection receives all frames of the bus, according to its own filters.
+	GetCanConnection() (Connection, error)
+}
+
+// Connection is a connection to a CAN bus, e.g. a raw socket of SocketCAN.
+// Provided by an Adaptor, usually just by calling the can package's GetCanConnection() function.
+type Connection gobot.CanSystemDevicer
+
+// optionApplier needs to be implemented by each configurable option type
+type optionApplier interface {
+	apply(cfg *configuration)
+}
+
+// configuration contains all changeable attributes of the driver.
+type configuration struct {
+	name      string
+	filters   []gobot.CanFilter
+	errorMask uint32
+}
+
+// nameOption is the type for applying another name to the configuration
+type nameOption string
+
+// filtersOption is the type for applying the filters for received frames to the configuration
+type filtersOption []gobot.CanFilter
+
+// errorFramesOption is the type for applying the error classes for received error frames to the configuration
+type errorFramesOption uint32
+
+// driver implements the interface gobot.Driver.
+type driver struct {
+	driverCfg  *configuration
+	connector  Connector
+	connection Connection
+	afterStart func() error
+	beforeHalt func() error
+	gobot.Commander
+	mutex *sync.Mutex // mutex often needed to ensure that write-read sequences are not interrupted
+}
+
+// newDriver creates a new generic and basic CAN gobot driver.
+//
+// Supported options:
+//
+//	"WithName"
+//	"WithFilters"
+//	"WithErrorFrames"
+func newDriver(a Connector, name string) *driver {
+	d := &driver{
+		driverCfg:  &configuration{name: gobot.DefaultName(name)},
+		connector:  a,
+		afterStart: func() error { return nil },
+		beforeHalt: func() error { return nil },
+		Commander:  gobot.NewCommander(),
+		mutex:      &sync.Mutex{},
+	}
+
+	return d
+}
+
+// WithName is used to replace the default name of the driver.
+func WithName(name string) optionApplier {
+	return nameOption(name)
+}
+
+// WithFilters restricts the received frames to the given filters, which are applied by the kernel. By default all
+// frames are received.
+func WithFilters(filters ...gobot.CanFilter) optionApplier {
+	return filtersOption(filters)
+}
+
+// WithErrorFrames activates the reception of error frames for the given error classes, e.g. 0x1FFFFFFF for all
+// classes (CAN_ERR_MASK), see "linux/can/error.h".
+func WithErrorFrames(mask uint32) optionApplier {
+	return errorFramesOption(mask)
+}
+
+// Name returns the name of the device.
+func (d *driver) Name() string {
+	return d.driverCfg.name
+}
+
+// SetName sets the name of the device.
+func (d *driver) SetName(name string) {
+	WithName(name).apply(d.driverCfg)
+}
+
+// Connection returns the connection of the device.
+func (d *driver) Connection() gobot.Connection {
+	if conn, ok := d.connector.(gobot.Connection); ok {
+		return conn
+	}
+
+	log.Printf("%s has no gobot connection\n", d.driverCfg.name)
+	return nil
+}
+
+// Start opens the connection to the bus and applies the filters.
+func (d *driver) Start() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	connection, err := d.connector.GetCanConnection()
+	if err != nil {
+		return err
+	}
+
+	if d.driverCfg.filters != nil {
+		if err := connection.SetFilters(d.driverCfg.filters); err != nil {
+			_ = connection.Close()
+			return err
+		}
+	}
+	if d.driverCfg.errorMask != 0 {
+		if err := connection.SetErrorFilter(d.driverCfg.errorMask); err != nil {
+			_ = connection.Close()
+			return err
+		}
+	}
+
+	d.connection = connection
+	return d.afterStart()
+}
+
+// Halt closes the connection to the bus.
+func (d *driver) Halt() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.connection == nil {
+		return nil
+	}
+
+	err := d.beforeHalt()
+	if closeErr := d.connection.Close(); err == nil {
+		err = closeErr
+	}
+	d.connection = nil
+	return err
+}
+
+func (o nameOption) String() string {
+	return "name option for CAN drivers"
+}
+
+func (o filtersOption) String() string {
+	return "filters option for CAN drivers"
+}
+
+func (o errorFramesOption) String() string {
+	return "error frames option for CAN drivers"
+}
+
+// apply change the name in the configuration.
+func (o nameOption) apply(c *configuration) {
+	c.name = string(o)
+}
+
+// apply change the filters in the configuration.
+func (o filtersOption) apply(c *configuration) {
+	c.filters = []gobot.CanFilter(o)
+}
+
+// apply change the error mask in the configuration.
+func (o errorFramesOption) apply(c *configuration) {
+	c.errorMask = uint32(o)
+}
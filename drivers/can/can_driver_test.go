@@ -0,0 +1,80 @@
+package can
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+)
+
+var _ gobot.Driver = (*driver)(nil)
+
+func TestNewDriver(t *testing.T) {
+	// arrange
+	a := newCanTestAdaptor()
+	// act
+	d := newDriver(a, "CAN_TEST")
+	// assert
+	assert.IsType(t, &driver{}, d)
+	assert.Contains(t, d.driverCfg.name, "CAN_TEST")
+	assert.Equal(t, a, d.connector)
+	assert.Equal(t, a, d.Connection())
+	require.NoError(t, d.afterStart())
+	require.NoError(t, d.beforeHalt())
+	assert.NotNil(t, d.Commander)
+	assert.NotNil(t, d.mutex)
+}
+
+func TestDriverOptions(t *testing.T) {
+	// arrange
+	cfg := &configuration{}
+	filters := []gobot.CanFilter{{ID: 0x100, Mask: 0x700}}
+	// act
+	WithName("my driver name").apply(cfg)
+	WithFilters(filters...).apply(cfg)
+	WithErrorFrames(0x1FFFFFFF).apply(cfg)
+	// assert
+	assert.Equal(t, "my driver name", cfg.name)
+	assert.Equal(t, filters, cfg.filters)
+	assert.Equal(t, uint32(0x1FFFFFFF), cfg.errorMask)
+}
+
+func TestDriverStartHalt(t *testing.T) {
+	// arrange
+	a := newCanTestAdaptor()
+	d := newDriver(a, "CAN_TEST")
+	d.driverCfg.filters = []gobot.CanFilter{{ID: 0x100, Mask: 0x7FF}}
+	d.driverCfg.errorMask = 0x04
+	// act
+	require.NoError(t, d.Start())
+	// assert: filters are applied by the kernel emulation of the mock
+	require.NoError(t, a.bus.SimulateReceive(canTestInterface, gobot.CanFrame{ID: 0x200}))
+	require.NoError(t, a.bus.SimulateReceive(canTestInterface, gobot.CanFrame{ID: 0x100}))
+	require.NoError(t, a.bus.SimulateReceive(canTestInterface, gobot.CanFrame{ID: 0x04, Error: true}))
+	frame, err := d.connection.ReadFrame()
+	require.NoError(t, err)
+	assert.Equal(t, uint32(0x100), frame.ID)
+	frame, err = d.connection.ReadFrame()
+	require.NoError(t, err)
+	assert.True(t, frame.Error)
+	assert.Equal(t, 1, a.bus.OpenSockets())
+	// act
+	require.NoError(t, d.Halt())
+	// assert
+	assert.Nil(t, d.connection)
+	assert.Equal(t, 0, a.bus.OpenSockets())
+	require.NoError(t, d.Halt())
+}
+
+func TestDriverStart_error(t *testing.T) {
+	// arrange
+	a := newCanTestAdaptor()
+	a.connectErr = true
+	d := newDriver(a, "CAN_TEST")
+	// act
+	err := d.Start()
+	// assert
+	require.EqualError(t, err, "invalid CAN connection in helper")
+}
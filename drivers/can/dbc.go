@@ -0,0 +1,467 @@
+package can
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// dbcExtendedIDFlag marks extended identifiers in DBC files
+const dbcExtendedIDFlag = 0x80000000
+
+var (
+	dbcMessageRegex = regexp.MustCompile(`^BO_\s+(\d+)\s+(\w+)\s*:\s*(\d+)\s+(\w+)`)
+	dbcSignalRegex  = regexp.MustCompile(`^SG_\s+(\w+)\s*(M|m\d+M?)?\s*:\s*(\d+)\|(\d+)@([01])([+-])\s*` +
+		`\(([^,]+),([^)]+)\)\s*\[([^|]+)\|([^\]]+)\]\s*"([^"]*)"\s*(.*)$`)
+	dbcValTypeRegex   = regexp.MustCompile(`^SIG_VALTYPE_\s+(\d+)\s+(\w+)\s*:?\s*([012])\s*;`)
+	dbcValuesRegex    = regexp.MustCompile(`^VAL_\s+(\d+)\s+(\w+)\s+(.*);`)
+	dbcValueDescRegex = regexp.MustCompile(`(-?\d+)\s+"([^"]*)"`)
+)
+
+// SignalValueType is the type of the raw value of a signal.
+type SignalValueType int
+
+const (
+	// SignalInteger is a signed or unsigned integer, which is the default type.
+	SignalInteger SignalValueType = iota
+	// SignalFloat32 is a IEEE float with 32 bit.
+	SignalFloat32
+	// SignalFloat64 is a IEEE double with 64 bit.
+	SignalFloat64
+)
+
+// Signal describes a signal of a message, as defined in a DBC file by "SG_". The physical value is calculated by
+// "raw value * Factor + Offset".
+type Signal struct {
+	Name           string
+	StartBit       int  // the least significant bit for little endian, the most significant bit for big endian
+	Length         int  // count of bits
+	LittleEndian   bool // byte order "Intel" (@1), otherwise "Motorola" (@0)
+	Signed         bool
+	ValueType      SignalValueType
+	Factor         float64
+	Offset         float64
+	Min            float64
+	Max            float64
+	Unit           string
+	Receivers      []string
+	IsMultiplexer  bool // the signal is the multiplexer of the message (M)
+	Multiplexed    bool // the signal is only present, if the multiplexer has the MultiplexValue (mN)
+	MultiplexValue int
+	Values         map[int64]string // descriptions of raw values, defined by "VAL_"
+}
+
+// Message describes a message, as defined in a DBC file by "BO_".
+type Message struct {
+	ID          uint32
+	Extended    bool
+	Name        string
+	Length      int
+	Transmitter string
+	Signals     []*Signal
+}
+
+// Database contains all messages of a DBC file.
+type Database struct {
+	Messages []*Message
+}
+
+// LoadDBC reads and parses the given DBC file.
+func LoadDBC(path string) (*Database, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ParseDBC(f)
+}
+
+// ParseDBC parses the messages, signals, value descriptions and signal value types of a DBC file, all other
+// definitions are ignored. Extended multiplexing is not supported.
+func ParseDBC(r io.Reader) (*Database, error) {
+	db := &Database{}
+	var current *Message
+	scanner := bufio.NewScanner(r)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case strings.HasPrefix(line, "BO_ "):
+			msg, err := parseDbcMessage(line)
+			if err != nil {
+				return nil, fmt.Errorf("DBC line %d: %v", lineNum, err)
+			}
+			db.Messages = append(db.Messages, msg)
+			current = msg
+		case strings.HasPrefix(line, "SG_ "):
+			if current == nil {
+				return nil, fmt.Errorf("DBC line %d: signal without message", lineNum)
+			}
+			sig, err := parseDbcSignal(line)
+			if err != nil {
+				return nil, fmt.Errorf("DBC line %d: %v", lineNum, err)
+			}
+			current.Signals = append(current.Signals, sig)
+		case strings.HasPrefix(line, "SIG_VALTYPE_ "):
+			if err := db.parseValueType(line); err != nil {
+				return nil, fmt.Errorf("DBC line %d: %v", lineNum, err)
+			}
+		case strings.HasPrefix(line, "VAL_ "):
+			if err := db.parseValueDescriptions(line); err != nil {
+				return nil, fmt.Errorf("DBC line %d: %v", lineNum, err)
+			}
+		default:
+			current = nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// MessageByID returns the message with the given identifier or nil, if not found.
+func (db *Database) MessageByID(id uint32, extended bool) *Message {
+	for _, msg := range db.Messages {
+		if msg.ID == id && msg.Extended == extended {
+			return msg
+		}
+	}
+	return nil
+}
+
+// MessageByName returns the message with the given name or nil, if not found.
+func (db *Database) MessageByName(name string) *Message {
+	for _, msg := range db.Messages {
+		if msg.Name == name {
+			return msg
+		}
+	}
+	return nil
+}
+
+// Signal returns the signal with the given name or nil, if not found.
+func (m *Message) Signal(name string) *Signal {
+	for _, sig := range m.Signals {
+		if sig.Name == name {
+			return sig
+		}
+	}
+	return nil
+}
+
+// Decode returns the physical values of all signals contained in the given data. Multiplexed signals are only
+// decoded, if the multiplexer has the matching value.
+func (m *Message) Decode(data []byte) (map[string]float64, error) {
+	multiplexValue, err := m.multiplexValue(data)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]float64, len(m.Signals))
+	for _, sig := range m.Signals {
+		if sig.Multiplexed && sig.MultiplexValue != multiplexValue {
+			continue
+		}
+		val, err := sig.Decode(data)
+		if err != nil {
+			return nil, fmt.Errorf("message '%s': %v", m.Name, err)
+		}
+		values[sig.Name] = val
+	}
+	return values, nil
+}
+
+// Encode returns the data of the message with the given physical values. Signals without a given value are encoded
+// with a raw value of 0. Multiplexed signals are only encoded, if the given value of the multiplexer matches.
+func (m *Message) Encode(values map[string]float64) ([]byte, error) {
+	for name := range values {
+		if m.Signal(name) == nil {
+			return nil, fmt.Errorf("signal '%s' not available for message '%s'", name, m.Name)
+		}
+	}
+
+	data := make([]byte, m.Length)
+	multiplexValue := -1
+	for _, sig := range m.Signals {
+		if sig.IsMultiplexer {
+			multiplexValue = int(math.Round(values[sig.Name]))
+		}
+	}
+	for _, sig := range m.Signals {
+		val, ok := values[sig.Name]
+		if !ok || (sig.Multiplexed && sig.MultiplexValue != multiplexValue) {
+			continue
+		}
+		if err := sig.Encode(data, val); err != nil {
+			return nil, fmt.Errorf("message '%s': %v", m.Name, err)
+		}
+	}
+	return data, nil
+}
+
+// Decode returns the physical value of the signal contained in the given data.
+func (s *Signal) Decode(data []byte) (float64, error) {
+	raw, err := s.readRaw(data)
+	if err != nil {
+		return 0, err
+	}
+
+	var val float64
+	switch {
+	case s.ValueType == SignalFloat32:
+		val = float64(math.Float32frombits(uint32(raw)))
+	case s.ValueType == SignalFloat64:
+		val = math.Float64frombits(raw)
+	case s.Signed && s.Length < 64 && raw&(1<<(s.Length-1)) != 0:
+		val = float64(int64(raw) - int64(1)<<s.Length)
+	case s.Signed:
+		val = float64(int64(raw))
+	default:
+		val = float64(raw)
+	}
+	return val*s.Factor + s.Offset, nil
+}
+
+// Encode writes the raw value of the given physical value to the data.
+func (s *Signal) Encode(data []byte, value float64) error {
+	scaled := (value - s.Offset) / s.Factor
+
+	var raw uint64
+	switch s.ValueType {
+	case SignalFloat32:
+		raw = uint64(math.Float32bits(float32(scaled)))
+	case SignalFloat64:
+		raw = math.Float64bits(scaled)
+	default:
+		rounded := math.Round(scaled)
+		minRaw, maxRaw := 0.0, math.Exp2(float64(s.Length))-1
+		if s.Signed {
+			minRaw, maxRaw = -math.Exp2(float64(s.Length-1)), math.Exp2(float64(s.Length-1))-1
+		}
+		if rounded < minRaw || rounded > maxRaw {
+			return fmt.Errorf("value %v out of range for signal '%s'", value, s.Name)
+		}
+		if s.Signed {
+			raw = uint64(int64(rounded))
+		} else {
+			raw = uint64(rounded)
+		}
+	}
+
+	return s.writeRaw(data, raw)
+}
+
+// multiplexValue returns the value of the multiplexer or -1, if the message has no multiplexer
+func (m *Message) multiplexValue(data []byte) (int, error) {
+	for _, sig := range m.Signals {
+		if sig.IsMultiplexer {
+			raw, err := sig.readRaw(data)
+			if err != nil {
+				return 0, fmt.Errorf("message '%s': %v", m.Name, err)
+			}
+			return int(raw), nil
+		}
+	}
+	return -1, nil
+}
+
+// bitPositions returns the positions of all bits in the data, starting with the least significant bit of the value
+func (s *Signal) bitPositions(dataLen int) ([]int, error) {
+	positions := make([]int, s.Length)
+	if s.LittleEndian {
+		for i := range positions {
+			positions[i] = s.StartBit + i
+		}
+	} else {
+		// the start bit is the most significant bit, the numbering within a byte is from LSB (0) to MSB (7), so the
+		// next bit is the predecessor or, at the end of a byte, the MSB of the next byte
+		pos := s.StartBit
+		for i := s.Length - 1; i >= 0; i-- {
+			positions[i] = pos
+			if pos%8 == 0 {
+				pos += 15
+			} else {
+				pos--
+			}
+		}
+	}
+
+	for _, pos := range positions {
+		if pos >= dataLen*8 {
+			return nil, fmt.Errorf("signal '%s' exceeds data length of %d bytes", s.Name, dataLen)
+		}
+	}
+	return positions, nil
+}
+
+func (s *Signal) readRaw(data []byte) (uint64, error) {
+	positions, err := s.bitPositions(len(data))
+	if err != nil {
+		return 0, err
+	}
+
+	var raw uint64
+	for i, pos := range positions {
+		if data[pos/8]&(1<<(pos%8)) != 0 {
+			raw |= 1 << i
+		}
+	}
+	return raw, nil
+}
+
+func (s *Signal) writeRaw(data []byte, raw uint64) error {
+	positions, err := s.bitPositions(len(data))
+	if err != nil {
+		return err
+	}
+
+	for i, pos := range positions {
+		if raw&(1<<i) != 0 {
+			data[pos/8] |= 1 << (pos % 8)
+		} else {
+			data[pos/8] &^= 1 << (pos % 8)
+		}
+	}
+	return nil
+}
+
+func parseDbcMessage(line string) (*Message, error) {
+	m := dbcMessageRegex.FindStringSubmatch(line)
+	if m == nil {
+		return nil, fmt.Errorf("invalid message definition '%s'", line)
+	}
+
+	rawID, err := strconv.ParseUint(m[1], 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid message ID '%s'", m[1])
+	}
+	length, err := strconv.Atoi(m[3])
+	if err != nil {
+		return nil, fmt.Errorf("invalid message length '%s'", m[3])
+	}
+
+	msg := &Message{Name: m[2], Length: length, Transmitter: m[4]}
+	msg.ID, msg.Extended = dbcID(uint32(rawID))
+	return msg, nil
+}
+
+func parseDbcSignal(line string) (*Signal, error) {
+	m := dbcSignalRegex.FindStringSubmatch(line)
+	if m == nil {
+		return nil, fmt.Errorf("invalid signal definition '%s'", line)
+	}
+
+	sig := &Signal{
+		Name:         m[1],
+		LittleEndian: m[5] == "1",
+		Signed:       m[6] == "-",
+		Unit:         m[11],
+	}
+
+	switch {
+	case m[2] == "M":
+		sig.IsMultiplexer = true
+	case m[2] != "":
+		sig.Multiplexed = true
+		sig.MultiplexValue, _ = strconv.Atoi(strings.TrimSuffix(m[2][1:], "M"))
+	}
+
+	var err error
+	if sig.StartBit, err = strconv.Atoi(m[3]); err != nil {
+		return nil, err
+	}
+	if sig.Length, err = strconv.Atoi(m[4]); err != nil {
+		return nil, err
+	}
+	if sig.Length < 1 || sig.Length > 64 {
+		return nil, fmt.Errorf("invalid length %d of signal '%s'", sig.Length, sig.Name)
+	}
+	for i, dst := range []*float64{&sig.Factor, &sig.Offset, &sig.Min, &sig.Max} {
+		if *dst, err = strconv.ParseFloat(strings.TrimSpace(m[7+i]), 64); err != nil {
+			return nil, fmt.Errorf("invalid number in signal '%s': %v", sig.Name, err)
+		}
+	}
+	if sig.Factor == 0 {
+		return nil, fmt.Errorf("invalid factor 0 of signal '%s'", sig.Name)
+	}
+	for _, receiver := range strings.FieldsFunc(m[12], func(r rune) bool { return r == ',' || r == ' ' }) {
+		sig.Receivers = append(sig.Receivers, receiver)
+	}
+
+	return sig, nil
+}
+
+func (db *Database) parseValueType(line string) error {
+	m := dbcValTypeRegex.FindStringSubmatch(line)
+	if m == nil {
+		return fmt.Errorf("invalid signal value type '%s'", line)
+	}
+
+	sig, err := db.findSignal(m[1], m[2])
+	if err != nil {
+		return err
+	}
+
+	sig.ValueType = map[string]SignalValueType{"0": SignalInteger, "1": SignalFloat32, "2": SignalFloat64}[m[3]]
+	if (sig.ValueType == SignalFloat32 && sig.Length != 32) || (sig.ValueType == SignalFloat64 && sig.Length != 64) {
+		return fmt.Errorf("length %d does not match the value type of signal '%s'", sig.Length, sig.Name)
+	}
+	return nil
+}
+
+func (db *Database) parseValueDescriptions(line string) error {
+	m := dbcValuesRegex.FindStringSubmatch(line)
+	if m == nil {
+		return fmt.Errorf("invalid value descriptions '%s'", line)
+	}
+
+	sig, err := db.findSignal(m[1], m[2])
+	if err != nil {
+		return err
+	}
+
+	sig.Values = make(map[int64]string)
+	for _, desc := range dbcValueDescRegex.FindAllStringSubmatch(m[3], -1) {
+		val, err := strconv.ParseInt(desc[1], 10, 64)
+		if err != nil {
+			return err
+		}
+		sig.Values[val] = desc[2]
+	}
+	return nil
+}
+
+func (db *Database) findSignal(rawID string, name string) (*Signal, error) {
+	id, err := strconv.ParseUint(rawID, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid message ID '%s'", rawID)
+	}
+
+	msg := db.MessageByID(dbcID(uint32(id)))
+	if msg == nil {
+		return nil, fmt.Errorf("message with ID %s not found", rawID)
+	}
+	sig := msg.Signal(name)
+	if sig == nil {
+		return nil, fmt.Errorf("signal '%s' not available for message '%s'", name, msg.Name)
+	}
+	return sig, nil
+}
+
+// dbcID converts the identifier of a DBC file, where extended identifiers are marked by the MSB
+func dbcID(rawID uint32) (uint32, bool) {
+	if rawID&dbcExtendedIDFlag != 0 {
+		return rawID &^ dbcExtendedIDFlag, true
+	}
+	return rawID, false
+}
@@ -0,0 +1,243 @@
+package can
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const dbcTestContent = `VERSION ""
+
+BU_: BMS MCU
+
+BO_ 100 BatteryStatus: 8 BMS
+ SG_ Voltage : 0|16@1+ (0.01,0) [0|655.35] "V" MCU
+ SG_ Current : 16|16@1- (0.1,0) [-3276.8|3276.7] "A" MCU
+ SG_ SoC : 32|8@1+ (0.5,0) [0|100] "%" MCU
+ SG_ State : 40|2@1+ (1,0) [0|3] "" MCU
+
+BO_ 2566869221 MotorSpeed: 8 MCU
+ SG_ Speed : 7|16@0- (1,0) [-32768|32767] "rpm" BMS,Display
+ SG_ Temperature : 23|8@0+ (1,-40) [-40|215] "degC" BMS
+
+BO_ 512 CellInfo: 8 BMS
+ SG_ Page M : 0|8@1+ (1,0) [0|255] "" MCU
+ SG_ CellVoltage1 m0 : 8|16@1+ (0.001,0) [0|65.535] "V" MCU
+ SG_ CellTemp1 m1 : 8|8@1- (1,0) [-128|127] "degC" MCU
+
+BO_ 768 Torque: 4 MCU
+ SG_ Torque : 0|32@1- (1,0) [0|0] "Nm" BMS
+
+CM_ SG_ 100 Voltage "pack voltage";
+VAL_ 100 State 0 "Idle" 1 "Charging" 2 "Discharging" 3 "Fault" ;
+SIG_VALTYPE_ 768 Torque : 1;
+`
+
+func initTestDatabase(t *testing.T) *Database {
+	db, err := ParseDBC(strings.NewReader(dbcTestContent))
+	require.NoError(t, err)
+	return db
+}
+
+func TestParseDBC(t *testing.T) {
+	// act
+	db := initTestDatabase(t)
+	// assert
+	require.Len(t, db.Messages, 4)
+	msg := db.MessageByID(0x18FF50E5, true)
+	require.NotNil(t, msg)
+	assert.Equal(t, "MotorSpeed", msg.Name)
+	assert.Equal(t, 8, msg.Length)
+	assert.Equal(t, "MCU", msg.Transmitter)
+	assert.Nil(t, db.MessageByID(0x18FF50E5, false))
+	assert.Equal(t, &Signal{
+		Name:      "Speed",
+		StartBit:  7,
+		Length:    16,
+		Signed:    true,
+		Factor:    1,
+		Min:       -32768,
+		Max:       32767,
+		Unit:      "rpm",
+		Receivers: []string{"BMS", "Display"},
+	}, msg.Signal("Speed"))
+	state := db.MessageByName("BatteryStatus").Signal("State")
+	assert.Equal(t, map[int64]string{0: "Idle", 1: "Charging", 2: "Discharging", 3: "Fault"}, state.Values)
+	cellInfo := db.MessageByName("CellInfo")
+	assert.True(t, cellInfo.Signal("Page").IsMultiplexer)
+	assert.True(t, cellInfo.Signal("CellTemp1").Multiplexed)
+	assert.Equal(t, 1, cellInfo.Signal("CellTemp1").MultiplexValue)
+	assert.Equal(t, SignalFloat32, db.MessageByName("Torque").Signal("Torque").ValueType)
+	assert.Nil(t, db.MessageByName("Unknown"))
+}
+
+func TestParseDBC_errors(t *testing.T) {
+	tests := map[string]struct {
+		content string
+		wantErr string
+	}{
+		"signal_without_message": {
+			content: "\n SG_ Voltage : 0|16@1+ (0.01,0) [0|655.35] \"V\" MCU\n",
+			wantErr: "DBC line 2: signal without message",
+		},
+		"invalid_message": {
+			content: "BO_ 100 BatteryStatus 8 BMS\n",
+			wantErr: "DBC line 1: invalid message definition 'BO_ 100 BatteryStatus 8 BMS'",
+		},
+		"invalid_signal": {
+			content: "BO_ 100 BatteryStatus: 8 BMS\n SG_ Voltage : 0|16@2+ (0.01,0) [0|655.35] \"V\" MCU\n",
+			wantErr: "DBC line 2: invalid signal definition 'SG_ Voltage : 0|16@2+ (0.01,0) [0|655.35] \"V\" MCU'",
+		},
+		"invalid_factor": {
+			content: "BO_ 100 BatteryStatus: 8 BMS\n SG_ Voltage : 0|16@1+ (0,0) [0|655.35] \"V\" MCU\n",
+			wantErr: "DBC line 2: invalid factor 0 of signal 'Voltage'",
+		},
+		"unknown_message": {
+			content: "VAL_ 100 State 0 \"Idle\" ;\n",
+			wantErr: "DBC line 1: message with ID 100 not found",
+		},
+		"float_length": {
+			content: "BO_ 100 BatteryStatus: 8 BMS\n SG_ Voltage : 0|16@1+ (1,0) [0|0] \"V\" MCU\n" +
+				"SIG_VALTYPE_ 100 Voltage : 1;\n",
+			wantErr: "DBC line 3: length 16 does not match the value type of signal 'Voltage'",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// act
+			_, err := ParseDBC(strings.NewReader(tc.content))
+			// assert
+			require.EqualError(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestLoadDBC(t *testing.T) {
+	// arrange
+	path := filepath.Join(t.TempDir(), "robot.dbc")
+	require.NoError(t, os.WriteFile(path, []byte(dbcTestContent), 0o600))
+	// act
+	db, err := LoadDBC(path)
+	// assert
+	require.NoError(t, err)
+	assert.Len(t, db.Messages, 4)
+	_, err = LoadDBC(filepath.Join(t.TempDir(), "missing.dbc"))
+	require.Error(t, err)
+}
+
+func TestMessageDecode(t *testing.T) {
+	tests := map[string]struct {
+		message string
+		data    []byte
+		want    map[string]float64
+		wantErr string
+	}{
+		"little_endian": {
+			message: "BatteryStatus",
+			data:    []byte{0xF2, 0x12, 0x83, 0xFF, 0xA0, 0x02, 0x00, 0x00},
+			want:    map[string]float64{"Voltage": 48.5, "Current": -12.5, "SoC": 80, "State": 2},
+		},
+		"big_endian": {
+			message: "MotorSpeed",
+			data:    []byte{0xFF, 0x38, 0x5A, 0x00, 0x00, 0x00, 0x00, 0x00},
+			want:    map[string]float64{"Speed": -200, "Temperature": 50},
+		},
+		"multiplexed_0": {
+			message: "CellInfo",
+			data:    []byte{0x00, 0xE4, 0x0C, 0x00, 0x00, 0x00, 0x00, 0x00},
+			want:    map[string]float64{"Page": 0, "CellVoltage1": 3.3},
+		},
+		"multiplexed_1": {
+			message: "CellInfo",
+			data:    []byte{0x01, 0xE7, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+			want:    map[string]float64{"Page": 1, "CellTemp1": -25},
+		},
+		"float": {
+			message: "Torque",
+			data:    []byte{0x00, 0x00, 0x48, 0x41},
+			want:    map[string]float64{"Torque": 12.5},
+		},
+		"error_data_length": {
+			message: "BatteryStatus",
+			data:    []byte{0xF2, 0x12, 0x83},
+			wantErr: "message 'BatteryStatus': signal 'Current' exceeds data length of 3 bytes",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			msg := initTestDatabase(t).MessageByName(tc.message)
+			// act
+			got, err := msg.Decode(tc.data)
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, got, len(tc.want))
+			for k, v := range tc.want {
+				assert.InDelta(t, v, got[k], 0.000001, k)
+			}
+		})
+	}
+}
+
+func TestMessageEncode(t *testing.T) {
+	tests := map[string]struct {
+		message string
+		values  map[string]float64
+		want    []byte
+		wantErr string
+	}{
+		"little_endian": {
+			message: "BatteryStatus",
+			values:  map[string]float64{"Voltage": 48.5, "Current": -12.5, "SoC": 80, "State": 2},
+			want:    []byte{0xF2, 0x12, 0x83, 0xFF, 0xA0, 0x02, 0x00, 0x00},
+		},
+		"big_endian": {
+			message: "MotorSpeed",
+			values:  map[string]float64{"Speed": -200, "Temperature": 50},
+			want:    []byte{0xFF, 0x38, 0x5A, 0x00, 0x00, 0x00, 0x00, 0x00},
+		},
+		"multiplexed": {
+			message: "CellInfo",
+			values:  map[string]float64{"Page": 1, "CellVoltage1": 3.3, "CellTemp1": -25},
+			want:    []byte{0x01, 0xE7, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+		},
+		"float": {
+			message: "Torque",
+			values:  map[string]float64{"Torque": 12.5},
+			want:    []byte{0x00, 0x00, 0x48, 0x41},
+		},
+		"error_range": {
+			message: "BatteryStatus",
+			values:  map[string]float64{"SoC": 130},
+			wantErr: "message 'BatteryStatus': value 130 out of range for signal 'SoC'",
+		},
+		"error_unknown_signal": {
+			message: "BatteryStatus",
+			values:  map[string]float64{"Speed": 1},
+			wantErr: "signal 'Speed' not available for message 'BatteryStatus'",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			msg := initTestDatabase(t).MessageByName(tc.message)
+			// act
+			got, err := msg.Encode(tc.values)
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
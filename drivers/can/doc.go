@@ -0,0 +1,12 @@
+/*
+Package can provides Gobot drivers for devices connected to a CAN bus, e.g. motor controllers or battery management
+systems. Messages and signals can be decoded and encoded by a DBC database.
+
+Installing:
+
+	Please refer to the main [README.md](https://github.com/hybridgroup/gobot/blob/release/README.md)
+
+For further information refer to can README:
+https://github.com/hybridgroup/gobot/blob/master/drivers/can/README.md
+*/
+package can // import "gobot.io/x/gobot/v2/drivers/can"
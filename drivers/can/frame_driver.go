@@ -0,0 +1,306 @@
+package can
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"gobot.io/x/gobot/v2"
+)
+
+// frameReadErrorPause is the pause after a failed read, e.g. if the network interface is down
+const frameReadErrorPause = 100 * time.Millisecond
+
+// frameOptionApplier needs to be implemented by each configurable option type
+type frameOptionApplier interface {
+	apply(cfg *frameConfiguration)
+}
+
+// frameConfiguration contains all changeable attributes of the driver.
+type frameConfiguration struct {
+	database *Database
+}
+
+// databaseOption is the type for applying a DBC database to the configuration
+type databaseOption struct {
+	database *Database
+}
+
+// DecodedMessage is the data of the Signals event of the FrameDriver.
+type DecodedMessage struct {
+	Name    string
+	Frame   gobot.CanFrame
+	Signals map[string]float64
+}
+
+// frameWaiterKey identifies the frames, a request is waiting for
+type frameWaiterKey struct {
+	id       uint32
+	extended bool
+}
+
+// FrameDriver is a driver for sending and receiving frames of a CAN bus. All received frames are published as
+// events, if a DBC database is given, the signals of known messages are published also.
+type FrameDriver struct {
+	*driver
+	frameCfg    *frameConfiguration
+	waiterMutex sync.Mutex
+	waiters     map[frameWaiterKey][]chan gobot.CanFrame
+	halt        chan struct{}
+	readMutex   sync.Mutex
+	readErr     error // error of the last read, reset by the next successful read
+	gobot.Eventer
+}
+
+// NewFrameDriver returns a new driver for the given CAN connector, e.g. the SocketCAN adaptor.
+//
+// Supported options:
+//
+//	"WithName"
+//	"WithFilters"
+//	"WithErrorFrames"
+//	"WithDatabase"
+//
+// Adds the following API Commands:
+//
+//	"Send" - See FrameDriver.Send
+//	"SendMessage" - See FrameDriver.SendMessage
+func NewFrameDriver(a Connector, opts ...interface{}) *FrameDriver {
+	d := &FrameDriver{
+		driver:   newDriver(a, "CanFrame"),
+		frameCfg: &frameConfiguration{},
+		waiters:  make(map[frameWaiterKey][]chan gobot.CanFrame),
+		Eventer:  gobot.NewEventer(),
+	}
+	d.afterStart = d.initialize
+	d.beforeHalt = d.shutdown
+	d.AddEvent(Frame)
+	d.AddEvent(ErrorFrame)
+	d.AddEvent(Signals)
+	d.AddEvent(Error)
+
+	for _, opt := range opts {
+		switch o := opt.(type) {
+		case optionApplier:
+			o.apply(d.driverCfg)
+		case frameOptionApplier:
+			o.apply(d.frameCfg)
+		default:
+			panic(fmt.Sprintf("'%s' can not be applied on '%s'", opt, d.driverCfg.name))
+		}
+	}
+
+	d.AddCommand("Send", func(params map[string]interface{}) interface{} {
+		id := uint32(params["id"].(float64)) //nolint:forcetypeassert // ok here
+		extended, _ := params["extended"].(bool)
+		var data []byte
+		if values, ok := params["data"].([]interface{}); ok {
+			for _, v := range values {
+				data = append(data, byte(v.(float64))) //nolint:forcetypeassert // ok here
+			}
+		}
+		return d.Send(gobot.CanFrame{ID: id, Extended: extended, Data: data})
+	})
+
+	d.AddCommand("SendMessage", func(params map[string]interface{}) interface{} {
+		name := params["name"].(string) //nolint:forcetypeassert // ok here
+		values := make(map[string]float64)
+		if signals, ok := params["signals"].(map[string]interface{}); ok {
+			for k, v := range signals {
+				values[k] = v.(float64) //nolint:forcetypeassert // ok here
+			}
+		}
+		return d.SendMessage(name, values)
+	})
+
+	return d
+}
+
+// WithDatabase sets the DBC database, which is used to decode received frames and to encode messages.
+func WithDatabase(db *Database) frameOptionApplier {
+	return databaseOption{database: db}
+}
+
+// Database returns the DBC database or nil, if not given.
+func (d *FrameDriver) Database() *Database {
+	return d.frameCfg.database
+}
+
+// Send writes the given frame to the bus.
+func (d *FrameDriver) Send(frame gobot.CanFrame) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.connection == nil {
+		return fmt.Errorf("CAN driver '%s' not started", d.driverCfg.name)
+	}
+	return d.connection.WriteFrame(frame)
+}
+
+// SendMessage encodes the given physical values of the signals by the message with the given name of the DBC
+// database and writes the frame to the bus.
+func (d *FrameDriver) SendMessage(name string, values map[string]float64) error {
+	if d.frameCfg.database == nil {
+		return fmt.Errorf("no DBC database given for CAN driver '%s'", d.driverCfg.name)
+	}
+
+	msg := d.frameCfg.database.MessageByName(name)
+	if msg == nil {
+		return fmt.Errorf("message '%s' not found in DBC database", name)
+	}
+
+	data, err := msg.Encode(values)
+	if err != nil {
+		return err
+	}
+	return d.Send(gobot.CanFrame{ID: msg.ID, Extended: msg.Extended, Data: data})
+}
+
+// Request writes the given frame to the bus and waits for the next frame with the given response ID in the same
+// frame format (standard or extended) as the request. The response is published as event also. An error is returned,
+// if the response is not received within the given timeout.
+func (d *FrameDriver) Request(
+	request gobot.CanFrame,
+	responseID uint32,
+	timeout time.Duration,
+) (gobot.CanFrame, error) {
+	key := frameWaiterKey{id: responseID, extended: request.Extended}
+	response := make(chan gobot.CanFrame, 1)
+
+	// register before sending, so a fast response is not missed
+	d.waiterMutex.Lock()
+	d.waiters[key] = append(d.waiters[key], response)
+	d.waiterMutex.Unlock()
+
+	if err := d.Send(request); err != nil {
+		d.removeWaiter(key, response)
+		return gobot.CanFrame{}, err
+	}
+
+	select {
+	case frame := <-response:
+		return frame, nil
+	case <-time.After(timeout):
+		d.removeWaiter(key, response)
+		return gobot.CanFrame{}, fmt.Errorf("no response with ID 0x%X within %s", responseID, timeout)
+	}
+}
+
+// initialize starts the reception of frames.
+// Emits the Events:
+//
+//	Frame gobot.CanFrame - Event is emitted on each received data or remote frame.
+//	ErrorFrame gobot.CanFrame - Event is emitted on each received error frame, if activated by "WithErrorFrames".
+//	Signals DecodedMessage - Event is emitted on each received frame, which is known by the DBC database.
+//	Error error - Event is emitted on error reading from the bus or decoding the signals.
+func (d *FrameDriver) initialize() error {
+	d.halt = make(chan struct{})
+	go d.receive(d.connection, d.halt)
+	return nil
+}
+
+// shutdown stops the reception, the blocked read returns on close of the connection
+func (d *FrameDriver) shutdown() error {
+	if d.halt != nil {
+		close(d.halt)
+		d.halt = nil
+	}
+	return nil
+}
+
+// Healthy returns the error of the last read from the bus, nil if the last read was successful.
+func (d *FrameDriver) Healthy() error {
+	d.readMutex.Lock()
+	defer d.readMutex.Unlock()
+
+	return d.readErr
+}
+
+func (d *FrameDriver) receive(connection Connection, halt chan struct{}) {
+	for {
+		frame, err := connection.ReadFrame()
+
+		select {
+		case <-halt:
+			return
+		default:
+		}
+
+		d.readMutex.Lock()
+		d.readErr = err
+		d.readMutex.Unlock()
+
+		if err != nil {
+			d.Publish(d.Event(Error), err)
+			select {
+			case <-time.After(frameReadErrorPause):
+				continue
+			case <-halt:
+				return
+			}
+		}
+
+		if frame.Error {
+			d.Publish(d.Event(ErrorFrame), frame)
+			continue
+		}
+
+		d.notifyWaiters(frame)
+		d.Publish(d.Event(Frame), frame)
+		d.publishSignals(frame)
+	}
+}
+
+func (d *FrameDriver) publishSignals(frame gobot.CanFrame) {
+	if d.frameCfg.database == nil || frame.Remote {
+		return
+	}
+
+	msg := d.frameCfg.database.MessageByID(frame.ID, frame.Extended)
+	if msg == nil {
+		return
+	}
+
+	values, err := msg.Decode(frame.Data)
+	if err != nil {
+		d.Publish(d.Event(Error), err)
+		return
+	}
+	d.Publish(d.Event(Signals), DecodedMessage{Name: msg.Name, Frame: frame, Signals: values})
+}
+
+func (d *FrameDriver) notifyWaiters(frame gobot.CanFrame) {
+	key := frameWaiterKey{id: frame.ID, extended: frame.Extended}
+
+	d.waiterMutex.Lock()
+	defer d.waiterMutex.Unlock()
+
+	for _, waiter := range d.waiters[key] {
+		waiter <- frame // buffered, each waiter is notified only once
+	}
+	delete(d.waiters, key)
+}
+
+func (d *FrameDriver) removeWaiter(key frameWaiterKey, waiter chan gobot.CanFrame) {
+	d.waiterMutex.Lock()
+	defer d.waiterMutex.Unlock()
+
+	waiters := d.waiters[key]
+	for i, w := range waiters {
+		if w == waiter {
+			d.waiters[key] = append(waiters[:i], waiters[i+1:]...)
+			break
+		}
+	}
+	if len(d.waiters[key]) == 0 {
+		delete(d.waiters, key)
+	}
+}
+
+func (o databaseOption) String() string {
+	return "DBC database option for CAN frame drivers"
+}
+
+func (o databaseOption) apply(cfg *frameConfiguration) {
+	cfg.database = o.database
+}
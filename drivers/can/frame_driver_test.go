@@ -0,0 +1,186 @@
+//nolint:forcetypeassert // ok here
+package can
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+)
+
+var _ gobot.Driver = (*FrameDriver)(nil)
+
+func TestNewFrameDriver(t *testing.T) {
+	// arrange
+	a := newCanTestAdaptor()
+	// act
+	d := NewFrameDriver(a)
+	// assert
+	assert.IsType(t, &FrameDriver{}, d)
+	assert.True(t, strings.HasPrefix(d.Name(), "CanFrame"))
+	assert.Equal(t, a, d.Connection())
+	assert.NotNil(t, d.Commander)
+	assert.NotNil(t, d.Eventer)
+	assert.Nil(t, d.Database())
+}
+
+func TestNewFrameDriver_options(t *testing.T) {
+	// arrange
+	db := initTestDatabase(t)
+	// act
+	d := NewFrameDriver(newCanTestAdaptor(), WithName("bms"), WithDatabase(db), WithErrorFrames(0x04),
+		WithFilters(gobot.CanFilter{ID: 0x100, Mask: 0x700}))
+	// assert
+	assert.Equal(t, "bms", d.Name())
+	assert.Equal(t, db, d.Database())
+	assert.Equal(t, uint32(0x04), d.driverCfg.errorMask)
+	assert.Equal(t, []gobot.CanFilter{{ID: 0x100, Mask: 0x700}}, d.driverCfg.filters)
+	assert.PanicsWithValue(t, "'unknown option' can not be applied on 'bms'", func() {
+		_ = NewFrameDriver(newCanTestAdaptor(), WithName("bms"), "unknown option")
+	})
+}
+
+func TestFrameDriverPublish(t *testing.T) {
+	// arrange
+	a := newCanTestAdaptor()
+	d := NewFrameDriver(a, WithDatabase(initTestDatabase(t)), WithErrorFrames(0x1FFFFFFF))
+	frames := make(chan gobot.CanFrame, 10)
+	errorFrames := make(chan gobot.CanFrame, 10)
+	messages := make(chan DecodedMessage, 10)
+	_ = d.On(d.Event(Frame), func(data interface{}) { frames <- data.(gobot.CanFrame) })
+	_ = d.On(d.Event(ErrorFrame), func(data interface{}) { errorFrames <- data.(gobot.CanFrame) })
+	_ = d.On(d.Event(Signals), func(data interface{}) { messages <- data.(DecodedMessage) })
+	require.NoError(t, d.Start())
+	// act
+	require.NoError(t, a.bus.SimulateReceive(canTestInterface, gobot.CanFrame{ID: 0x300, Data: []byte{0x01}}))
+	errFrame := gobot.CanFrame{ID: 0x04, Error: true, Data: make([]byte, 8)}
+	require.NoError(t, a.bus.SimulateReceive(canTestInterface, errFrame))
+	require.NoError(t, a.bus.SimulateReceive(canTestInterface, gobot.CanFrame{
+		ID:       0x18FF50E5,
+		Extended: true,
+		Data:     []byte{0xFF, 0x38, 0x5A, 0x00, 0x00, 0x00, 0x00, 0x00},
+	}))
+	// assert
+	for _, wantID := range []uint32{0x300, 0x18FF50E5} {
+		select {
+		case frame := <-frames:
+			assert.Equal(t, wantID, frame.ID)
+		case <-time.After(time.Second):
+			require.Fail(t, "frame event was not published")
+		}
+	}
+	select {
+	case frame := <-errorFrames:
+		assert.Equal(t, uint32(0x04), frame.ID)
+	case <-time.After(time.Second):
+		require.Fail(t, "error frame event was not published")
+	}
+	select {
+	case msg := <-messages:
+		assert.Equal(t, "MotorSpeed", msg.Name)
+		assert.Equal(t, map[string]float64{"Speed": -200, "Temperature": 50}, msg.Signals)
+	case <-time.After(time.Second):
+		require.Fail(t, "signals event was not published")
+	}
+	require.NoError(t, d.Halt())
+	assert.Equal(t, 0, a.bus.OpenSockets())
+}
+
+func TestFrameDriverSend(t *testing.T) {
+	// arrange
+	a := newCanTestAdaptor()
+	d := NewFrameDriver(a, WithDatabase(initTestDatabase(t)))
+	require.EqualError(t, d.Send(gobot.CanFrame{ID: 0x1}), "CAN driver '"+d.Name()+"' not started")
+	require.NoError(t, d.Start())
+	// act & assert
+	require.NoError(t, d.Send(gobot.CanFrame{ID: 0x123, Data: []byte{0x01, 0x02}}))
+	require.NoError(t, d.SendMessage("MotorSpeed", map[string]float64{"Speed": -200, "Temperature": 50}))
+	require.EqualError(t, d.SendMessage("Unknown", nil), "message 'Unknown' not found in DBC database")
+	assert.Nil(t, d.Command("Send")(map[string]interface{}{"id": 292.0, "data": []interface{}{3.0}}))
+	assert.Nil(t, d.Command("SendMessage")(map[string]interface{}{
+		"name": "BatteryStatus", "signals": map[string]interface{}{"SoC": 80.0},
+	}))
+	assert.Equal(t, []gobot.CanFrame{
+		{ID: 0x123, Data: []byte{0x01, 0x02}},
+		{ID: 0x18FF50E5, Extended: true, Data: []byte{0xFF, 0x38, 0x5A, 0x00, 0x00, 0x00, 0x00, 0x00}},
+		{ID: 0x124, Data: []byte{0x03}},
+		{ID: 0x64, Data: []byte{0x00, 0x00, 0x00, 0x00, 0xA0, 0x00, 0x00, 0x00}},
+	}, a.bus.Written())
+	require.NoError(t, d.Halt())
+	// act & assert: no database
+	d = NewFrameDriver(a)
+	require.EqualError(t, d.SendMessage("MotorSpeed", nil), "no DBC database given for CAN driver '"+d.Name()+"'")
+}
+
+func TestFrameDriverRequest(t *testing.T) {
+	// arrange
+	a := newCanTestAdaptor()
+	d := NewFrameDriver(a)
+	require.NoError(t, d.Start())
+	// the motor controller is emulated by another connection to the bus
+	controller, err := a.GetCanConnection()
+	require.NoError(t, err)
+	go func() {
+		for {
+			req, err := controller.ReadFrame()
+			if err != nil {
+				return
+			}
+			if req.ID == 0x601 {
+				_ = controller.WriteFrame(gobot.CanFrame{ID: 0x581, Data: []byte{0x4B, req.Data[1]}})
+			}
+		}
+	}()
+	// act
+	resp, err := d.Request(gobot.CanFrame{ID: 0x601, Data: []byte{0x40, 0x10}}, 0x581, time.Second)
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x4B, 0x10}, resp.Data)
+	// act: no response
+	_, err = d.Request(gobot.CanFrame{ID: 0x602}, 0x582, 10*time.Millisecond)
+	// assert
+	require.EqualError(t, err, "no response with ID 0x582 within 10ms")
+	assert.Empty(t, d.waiters)
+	require.NoError(t, controller.Close())
+	require.NoError(t, d.Halt())
+}
+
+// frameTestConnection delivers the given read results one after another
+type frameTestConnection struct {
+	Connection
+	results chan error
+}
+
+func (c *frameTestConnection) ReadFrame() (gobot.CanFrame, error) {
+	return gobot.CanFrame{ID: 0x100}, <-c.results
+}
+
+func TestFrameDriverHealthy(t *testing.T) {
+	// arrange
+	d := NewFrameDriver(newCanTestAdaptor())
+	conn := &frameTestConnection{results: make(chan error)}
+	defer close(conn.results) // unblocks the reader after halt
+	errs := make(chan error, 10)
+	_ = d.On(d.Event(Error), func(data interface{}) { errs <- data.(error) })
+	halt := make(chan struct{})
+	defer close(halt)
+	go d.receive(conn, halt)
+	// act & assert
+	require.NoError(t, d.Healthy())
+	conn.results <- errors.New("network is down")
+	select {
+	case err := <-errs:
+		require.EqualError(t, err, "network is down")
+	case <-time.After(time.Second):
+		require.Fail(t, "error event was not published")
+	}
+	require.EqualError(t, d.Healthy(), "network is down")
+	// act & assert: the error is reset by the next successful read
+	conn.results <- nil
+	assert.Eventually(t, func() bool { return d.Healthy() == nil }, time.Second, 5*time.Millisecond)
+}
@@ -0,0 +1,42 @@
+package can
+
+import (
+	"fmt"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/system"
+)
+
+// make sure that this adaptor fulfills all the required interfaces
+var (
+	_ Connector        = (*canTestAdaptor)(nil)
+	_ gobot.Connection = (*canTestAdaptor)(nil)
+)
+
+const canTestInterface = "vcan0"
+
+type canTestAdaptor struct {
+	sys        *system.Accesser
+	bus        *system.MockCanSocketAccess
+	connectErr bool
+}
+
+func newCanTestAdaptor() *canTestAdaptor {
+	sys := system.NewAccesser()
+	bus := sys.UseMockCanSocket()
+	return &canTestAdaptor{sys: sys, bus: bus}
+}
+
+// can.Connector interfaces
+func (a *canTestAdaptor) GetCanConnection() (Connection, error) {
+	if a.connectErr {
+		return nil, fmt.Errorf("invalid CAN connection in helper")
+	}
+	return a.sys.NewCanSocket(canTestInterface, true)
+}
+
+// gobot.Connection interfaces
+func (a *canTestAdaptor) Connect() error  { return nil }
+func (a *canTestAdaptor) Finalize() error { return nil }
+func (a *canTestAdaptor) Name() string    { return "board name" }
+func (a *canTestAdaptor) SetName(string)  {}
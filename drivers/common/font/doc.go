@@ -0,0 +1,5 @@
+/*
+Package font contains fonts and number formatting for 7-segment and LED dot matrix displays, together with a helper to
+show static and scrolling text on each display driver, which implements SegmentDisplayer or MatrixDisplayer.
+*/
+package font // import "gobot.io/x/gobot/v2/drivers/common/font"
@@ -0,0 +1,52 @@
+package font
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMatrixFont(t *testing.T) {
+	// act
+	f := NewMatrixFont()
+	// assert: all printable ASCII characters are contained, with 7 rows at maximum
+	for r := ' '; r <= '~'; r++ {
+		glyph, ok := f[r]
+		assert.True(t, ok, "character '%c' is missing", r)
+		assert.Len(t, glyph, MatrixGlyphWidth, "character '%c'", r)
+		for _, column := range glyph {
+			assert.Zero(t, column&0x80, "character '%c' uses the 8th row", r)
+		}
+	}
+}
+
+func TestMatrixFontRender(t *testing.T) {
+	tests := map[string]struct {
+		text string
+		want []byte
+	}{
+		"one_character": {
+			text: "1",
+			want: []byte{0x00, 0x42, 0x7F, 0x40, 0x00},
+		},
+		"separated": {
+			text: "-1",
+			want: []byte{0x08, 0x08, 0x08, 0x08, 0x08, 0x00, 0x00, 0x42, 0x7F, 0x40, 0x00},
+		},
+		"unknown_character": {
+			text: "µ-",
+			want: []byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x08, 0x08, 0x08, 0x08, 0x08},
+		},
+		"empty": {},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			f := NewMatrixFont()
+			// act
+			got := f.Render(tc.text)
+			// assert
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package font
+
+import (
+	"strconv"
+	"strings"
+)
+
+// FormatInt returns the value right aligned to the given width, e.g. the count of digits of the display. With leading
+// zeros the free digits are filled with "0" instead of blanks. If the value does not fit, all digits are "-".
+func FormatInt(value int, width int, leadingZeros bool) string {
+	text := strconv.Itoa(value)
+	if leadingZeros {
+		text = strings.TrimPrefix(text, "-")
+		fill := width - len(text)
+		if value < 0 {
+			fill--
+		}
+		if fill > 0 {
+			text = strings.Repeat("0", fill) + text
+		}
+		if value < 0 {
+			text = "-" + text
+		}
+	}
+
+	return alignRight(text, width, 0)
+}
+
+// FormatFloat returns the value with the given count of decimals, right aligned to the given width, e.g. the count of
+// digits of the display. The decimal point is not counted, because it is merged into the previous digit by
+// SevenSegmentFont.Encode(). If the value does not fit, the decimals are reduced. If it still does not fit, all digits
+// are "-".
+func FormatFloat(value float64, width int, decimals int) string {
+	return formatFloat(value, width, decimals, 0)
+}
+
+// formatFloat is the implementation of FormatFloat, the decimal point takes the given width
+func formatFloat(value float64, width int, decimals int, pointWidth int) string {
+	for ; decimals > 0; decimals-- {
+		text := strconv.FormatFloat(value, 'f', decimals, 64)
+		if len(text)-1+pointWidth <= width {
+			return alignRight(text, width, 1-pointWidth)
+		}
+	}
+
+	return alignRight(strconv.FormatFloat(value, 'f', 0, 64), width, 0)
+}
+
+// alignRight fills the text with leading blanks, the given count of characters does not take a digit
+func alignRight(text string, width int, uncounted int) string {
+	fill := width - len(text) + uncounted
+	if fill < 0 {
+		return strings.Repeat("-", width)
+	}
+
+	return strings.Repeat(" ", fill) + text
+}
@@ -0,0 +1,58 @@
+package font
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatInt(t *testing.T) {
+	tests := map[string]struct {
+		value        int
+		width        int
+		leadingZeros bool
+		want         string
+	}{
+		"aligned":              {value: 42, width: 4, want: "  42"},
+		"negative":             {value: -42, width: 4, want: " -42"},
+		"leading_zeros":        {value: 42, width: 4, leadingZeros: true, want: "0042"},
+		"negative_with_zeros":  {value: -7, width: 4, leadingZeros: true, want: "-007"},
+		"exact":                {value: 1234, width: 4, want: "1234"},
+		"overflow":             {value: 12345, width: 4, want: "----"},
+		"negative_overflow":    {value: -1234, width: 4, want: "----"},
+		"zeros_without_effect": {value: 1234, width: 4, leadingZeros: true, want: "1234"},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// act
+			got := FormatInt(tc.value, tc.width, tc.leadingZeros)
+			// assert
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestFormatFloat(t *testing.T) {
+	tests := map[string]struct {
+		value    float64
+		width    int
+		decimals int
+		want     string
+	}{
+		"aligned":           {value: 3.14159, width: 4, decimals: 2, want: " 3.14"},
+		"exact":             {value: 12.345, width: 4, decimals: 2, want: "12.35"},
+		"negative":          {value: -1.5, width: 4, decimals: 1, want: " -1.5"},
+		"reduced_decimals":  {value: 123.456, width: 4, decimals: 3, want: "123.5"},
+		"without_decimals":  {value: 1234.6, width: 4, decimals: 2, want: "1235"},
+		"no_decimals_given": {value: 7.7, width: 4, want: "   8"},
+		"overflow":          {value: 12345.6, width: 4, decimals: 1, want: "----"},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// act
+			got := FormatFloat(tc.value, tc.width, tc.decimals)
+			// assert
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package font
+
+// Bits of the segments of a 7-segment digit, which is the most common order, used e.g. by TM1637, TM1638 and HT16K33.
+//
+//	 -A-
+//	F   B
+//	 -G-
+//	E   C
+//	 -D-  DP
+const (
+	SegmentA  byte = 0x01
+	SegmentB  byte = 0x02
+	SegmentC  byte = 0x04
+	SegmentD  byte = 0x08
+	SegmentE  byte = 0x10
+	SegmentF  byte = 0x20
+	SegmentG  byte = 0x40
+	SegmentDP byte = 0x80
+)
+
+// SevenSegmentFont maps characters to the segments of a 7-segment digit.
+type SevenSegmentFont map[rune]byte
+
+// NewSevenSegmentFont returns a new font with the printable ASCII characters for 7-segment displays. Some characters
+// are only an approximation, others are not representable and will be blank. The returned map can be modified to add
+// or change characters.
+func NewSevenSegmentFont() SevenSegmentFont {
+	return SevenSegmentFont{
+		' ':  0x00,
+		'!':  0x86,
+		'\'': 0x22,
+		'#':  0x7E,
+		'$':  0x6D,
+		'%':  0x00,
+		'&':  0x00,
+		'"':  0x02,
+		'(':  0x30,
+		')':  0x06,
+		'*':  0x63,
+		'+':  0x00,
+		',':  0x04,
+		'-':  0x40,
+		'.':  0x80,
+		'/':  0x52,
+		'0':  0x3F,
+		'1':  0x06,
+		'2':  0x5B,
+		'3':  0x4F,
+		'4':  0x66,
+		'5':  0x6D,
+		'6':  0x7D,
+		'7':  0x27,
+		'8':  0x7F,
+		'9':  0x6F,
+		':':  0x00,
+		';':  0x00,
+		'<':  0x00,
+		'=':  0x48,
+		'>':  0x00,
+		'?':  0x53,
+		'@':  0x5F,
+		'A':  0x77,
+		'B':  0x7F,
+		'C':  0x39,
+		'D':  0x3F,
+		'E':  0x79,
+		'F':  0x71,
+		'G':  0x3D,
+		'H':  0x76,
+		'I':  0x06,
+		'J':  0x1F,
+		'K':  0x69,
+		'L':  0x38,
+		'M':  0x15,
+		'N':  0x37,
+		'O':  0x3F,
+		'P':  0x73,
+		'Q':  0x67,
+		'R':  0x31,
+		'S':  0x6D,
+		'T':  0x78,
+		'U':  0x3E,
+		'V':  0x2A,
+		'W':  0x1D,
+		'X':  0x76,
+		'Y':  0x6E,
+		'Z':  0x5B,
+		'[':  0x39,
+		'\\': 0x64,
+		']':  0x0F,
+		'^':  0x00,
+		'_':  0x08,
+		'`':  0x20,
+		'a':  0x5F,
+		'b':  0x7C,
+		'c':  0x58,
+		'd':  0x5E,
+		'e':  0x7B,
+		'f':  0x31,
+		'g':  0x6F,
+		'h':  0x74,
+		'i':  0x04,
+		'j':  0x0E,
+		'k':  0x75,
+		'l':  0x30,
+		'm':  0x55,
+		'n':  0x54,
+		'o':  0x5C,
+		'p':  0x73,
+		'q':  0x67,
+		'r':  0x50,
+		's':  0x6D,
+		't':  0x78,
+		'u':  0x1C,
+		'v':  0x2A,
+		'w':  0x1D,
+		'x':  0x76,
+		'y':  0x6E,
+		'z':  0x47,
+		'{':  0x46,
+		'|':  0x06,
+		'}':  0x70,
+		'~':  0x01,
+	}
+}
+
+// Encode translates the text to the segments of the digits. A "." is merged into the decimal point of the previous
+// digit, if this has no decimal point yet, so "12.34" needs only 4 digits. A ":" is not encoded as digit, but
+// reported by the second return value, because displays with a colon use separate LEDs for it. Characters, which are
+// not contained in the font, are encoded as blank digit.
+func (f SevenSegmentFont) Encode(text string) ([]byte, bool) {
+	var colon bool
+	digits := make([]byte, 0, len(text))
+	for _, r := range text {
+		switch {
+		case r == ':':
+			colon = true
+		case r == '.' && len(digits) > 0 && digits[len(digits)-1]&SegmentDP == 0:
+			digits[len(digits)-1] |= SegmentDP
+		default:
+			digits = append(digits, f[r])
+		}
+	}
+
+	return digits, colon
+}
@@ -0,0 +1,77 @@
+package font
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSevenSegmentFont(t *testing.T) {
+	// act
+	f := NewSevenSegmentFont()
+	// assert: all printable ASCII characters are contained
+	for r := ' '; r <= '~'; r++ {
+		_, ok := f[r]
+		assert.True(t, ok, "character '%c' is missing", r)
+	}
+	assert.Equal(t, SegmentA|SegmentB|SegmentC|SegmentD|SegmentE|SegmentF, f['0'])
+	assert.Equal(t, SegmentG, f['-'])
+}
+
+func TestSevenSegmentFontEncode(t *testing.T) {
+	tests := map[string]struct {
+		text       string
+		wantDigits []byte
+		wantColon  bool
+	}{
+		"text": {
+			text:       "Hello World",
+			wantDigits: []byte{0x76, 0x7B, 0x30, 0x30, 0x5C, 0x00, 0x1D, 0x5C, 0x50, 0x30, 0x5E},
+		},
+		"decimal_point_merged": {
+			text:       "12.34",
+			wantDigits: []byte{0x06, 0x5B | SegmentDP, 0x4F, 0x66},
+		},
+		"leading_point": {
+			text:       ".5",
+			wantDigits: []byte{SegmentDP, 0x6D},
+		},
+		"double_point": {
+			text:       "1..",
+			wantDigits: []byte{0x06 | SegmentDP, SegmentDP},
+		},
+		"colon": {
+			text:       "12:34",
+			wantDigits: []byte{0x06, 0x5B, 0x4F, 0x66},
+			wantColon:  true,
+		},
+		"unknown_character": {
+			text:       "1µ2",
+			wantDigits: []byte{0x06, 0x00, 0x5B},
+		},
+		"empty": {
+			wantDigits: []byte{},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			f := NewSevenSegmentFont()
+			// act
+			digits, colon := f.Encode(tc.text)
+			// assert
+			assert.Equal(t, tc.wantDigits, digits)
+			assert.Equal(t, tc.wantColon, colon)
+		})
+	}
+}
+
+func TestSevenSegmentFontEncode_modified(t *testing.T) {
+	// arrange
+	f := NewSevenSegmentFont()
+	f['µ'] = 0x1C
+	// act
+	digits, _ := f.Encode("µ")
+	// assert
+	assert.Equal(t, []byte{0x1C}, digits)
+}
@@ -0,0 +1,115 @@
+package font
+
+import (
+	"time"
+)
+
+// SegmentDisplayer is the interface of drivers for 7-segment displays, which can be used by the TextDisplay.
+type SegmentDisplayer interface {
+	// Digits returns the count of digits of the display.
+	Digits() int
+	// WriteSegments writes the segments of the digits, beginning with the left digit. The segment bits are given in the
+	// order of the Segment constants. If the display has a colon, it is switched on or off.
+	WriteSegments(digits []byte, colon bool) error
+}
+
+// MatrixDisplayer is the interface of drivers for LED dot matrix displays, which can be used by the TextDisplay.
+type MatrixDisplayer interface {
+	// Columns returns the count of columns of the display.
+	Columns() int
+	// WriteColumns writes the columns, beginning with the left column. The lowest bit of each column is the top row.
+	WriteColumns(columns []byte) error
+}
+
+// TextDisplay shows static or scrolling text and numbers on a segment or matrix display.
+type TextDisplay struct {
+	width      int // count of digits or columns
+	glyphWidth int // count of digits or columns for one character
+	spacing    int // count of digits or columns between two characters
+	pointWidth int // count of characters for the decimal point
+	encode     func(text string) ([]byte, bool)
+	write      func(cells []byte, colon bool) error
+}
+
+// NewSegmentTextDisplay creates a new text display for the given 7-segment display, using the given font. For nil,
+// the font returned by NewSevenSegmentFont() is used.
+func NewSegmentTextDisplay(d SegmentDisplayer, f SevenSegmentFont) *TextDisplay {
+	if f == nil {
+		f = NewSevenSegmentFont()
+	}
+	return &TextDisplay{
+		width:      d.Digits(),
+		glyphWidth: 1,
+		encode:     f.Encode,
+		write:      d.WriteSegments,
+	}
+}
+
+// NewMatrixTextDisplay creates a new text display for the given LED dot matrix display, using the given font. For nil,
+// the font returned by NewMatrixFont() is used.
+func NewMatrixTextDisplay(d MatrixDisplayer, f MatrixFont) *TextDisplay {
+	if f == nil {
+		f = NewMatrixFont()
+	}
+	return &TextDisplay{
+		width:      d.Columns(),
+		glyphWidth: MatrixGlyphWidth,
+		spacing:    1,
+		pointWidth: 1,
+		encode:     func(text string) ([]byte, bool) { return f.Render(text), false },
+		write:      func(columns []byte, _ bool) error { return d.WriteColumns(columns) },
+	}
+}
+
+// Show writes the text left aligned to the display, the text is cut, if it is too long for the display.
+func (t *TextDisplay) Show(text string) error {
+	cells, colon := t.encode(text)
+	return t.write(t.window(cells, 0), colon)
+}
+
+// ShowNumber writes the value with the given count of decimals right aligned to the display. The decimals are reduced,
+// if the value is too long for the display.
+func (t *TextDisplay) ShowNumber(value float64, decimals int) error {
+	chars := (t.width + t.spacing) / (t.glyphWidth + t.spacing)
+	return t.Show(formatFloat(value, chars, decimals, t.pointWidth))
+}
+
+// Scroll moves the text from the right to the left through the display, until it has left the display completely.
+// The function blocks until finished, the interval is the duration between two steps.
+func (t *TextDisplay) Scroll(text string, interval time.Duration) error {
+	frames := t.scrollFrames(text)
+	for i, frame := range frames {
+		if i > 0 {
+			time.Sleep(interval)
+		}
+		if err := t.write(frame, false); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// scrollFrames returns the content of the display for each step of the scrolling
+func (t *TextDisplay) scrollFrames(text string) [][]byte {
+	cells, _ := t.encode(text)
+	padded := make([]byte, t.width+len(cells)+t.width)
+	copy(padded[t.width:], cells)
+
+	frames := make([][]byte, 0, len(cells)+t.width+1)
+	for start := 0; start <= len(cells)+t.width; start++ {
+		frames = append(frames, t.window(padded, start))
+	}
+
+	return frames
+}
+
+// window returns the part of the cells, which fits into the display, beginning at the given start
+func (t *TextDisplay) window(cells []byte, start int) []byte {
+	frame := make([]byte, t.width)
+	if start < len(cells) {
+		copy(frame, cells[start:])
+	}
+
+	return frame
+}
@@ -0,0 +1,159 @@
+package font
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type segmentDisplayMock struct {
+	digits   int
+	written  [][]byte
+	colons   []bool
+	writeErr error
+}
+
+func (d *segmentDisplayMock) Digits() int { return d.digits }
+
+func (d *segmentDisplayMock) WriteSegments(digits []byte, colon bool) error {
+	d.written = append(d.written, digits)
+	d.colons = append(d.colons, colon)
+	return d.writeErr
+}
+
+type matrixDisplayMock struct {
+	columns int
+	written [][]byte
+}
+
+func (d *matrixDisplayMock) Columns() int { return d.columns }
+
+func (d *matrixDisplayMock) WriteColumns(columns []byte) error {
+	d.written = append(d.written, columns)
+	return nil
+}
+
+func TestTextDisplayShow(t *testing.T) {
+	tests := map[string]struct {
+		text       string
+		wantDigits []byte
+		wantColon  bool
+	}{
+		"filled": {
+			text:       "1",
+			wantDigits: []byte{0x06, 0x00, 0x00, 0x00},
+		},
+		"cut": {
+			text:       "1.2345",
+			wantDigits: []byte{0x06 | SegmentDP, 0x5B, 0x4F, 0x66},
+		},
+		"time": {
+			text:       "12:05",
+			wantDigits: []byte{0x06, 0x5B, 0x3F, 0x6D},
+			wantColon:  true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d := &segmentDisplayMock{digits: 4}
+			td := NewSegmentTextDisplay(d, nil)
+			// act
+			err := td.Show(tc.text)
+			// assert
+			require.NoError(t, err)
+			assert.Equal(t, [][]byte{tc.wantDigits}, d.written)
+			assert.Equal(t, []bool{tc.wantColon}, d.colons)
+		})
+	}
+}
+
+func TestTextDisplayShow_customFont(t *testing.T) {
+	// arrange
+	d := &segmentDisplayMock{digits: 2}
+	td := NewSegmentTextDisplay(d, SevenSegmentFont{'x': 0x55})
+	// act
+	err := td.Show("x1")
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{{0x55, 0x00}}, d.written)
+}
+
+func TestTextDisplayShow_error(t *testing.T) {
+	// arrange
+	d := &segmentDisplayMock{digits: 4, writeErr: errors.New("write error")}
+	td := NewSegmentTextDisplay(d, nil)
+	// act
+	err := td.Show("1")
+	// assert
+	require.EqualError(t, err, "write error")
+}
+
+func TestTextDisplayShowNumber(t *testing.T) {
+	// arrange
+	d := &segmentDisplayMock{digits: 4}
+	td := NewSegmentTextDisplay(d, nil)
+	// act
+	err := td.ShowNumber(-3.14159, 2)
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{{0x40, 0x4F | SegmentDP, 0x06, 0x66}}, d.written)
+}
+
+func TestTextDisplayShowNumber_matrix(t *testing.T) {
+	// arrange: 2 characters fit into 11 columns
+	d := &matrixDisplayMock{columns: 11}
+	f := MatrixFont{'1': {0x01, 0x01, 0x01, 0x01, 0x01}, '.': {0x02, 0x02, 0x02, 0x02, 0x02}}
+	td := NewMatrixTextDisplay(d, f)
+	// act: decimals are reduced, because the point needs an own character
+	err := td.ShowNumber(11.1, 1)
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{{0x01, 0x01, 0x01, 0x01, 0x01, 0x00, 0x01, 0x01, 0x01, 0x01, 0x01}}, d.written)
+}
+
+func TestTextDisplayScroll(t *testing.T) {
+	// arrange
+	d := &segmentDisplayMock{digits: 2}
+	td := NewSegmentTextDisplay(d, nil)
+	// act
+	err := td.Scroll("1:2", 0)
+	// assert
+	require.NoError(t, err)
+	want := [][]byte{
+		{0x00, 0x00},
+		{0x00, 0x06},
+		{0x06, 0x5B},
+		{0x5B, 0x00},
+		{0x00, 0x00},
+	}
+	assert.Equal(t, want, d.written)
+	assert.Equal(t, []bool{false, false, false, false, false}, d.colons)
+}
+
+func TestTextDisplayScroll_matrix(t *testing.T) {
+	// arrange
+	d := &matrixDisplayMock{columns: 3}
+	td := NewMatrixTextDisplay(d, nil)
+	// act
+	err := td.Scroll("-", 0)
+	// assert
+	require.NoError(t, err)
+	assert.Len(t, d.written, 3+MatrixGlyphWidth+1)
+	assert.Equal(t, []byte{0x00, 0x00, 0x08}, d.written[1])
+	assert.Equal(t, []byte{0x08, 0x08, 0x08}, d.written[3])
+	assert.Equal(t, []byte{0x00, 0x00, 0x00}, d.written[len(d.written)-1])
+}
+
+func TestTextDisplayScroll_error(t *testing.T) {
+	// arrange
+	d := &segmentDisplayMock{digits: 2, writeErr: errors.New("write error")}
+	td := NewSegmentTextDisplay(d, nil)
+	// act
+	err := td.Scroll("12", 0)
+	// assert
+	require.EqualError(t, err, "write error")
+	assert.Len(t, d.written, 1)
+}
@@ -0,0 +1,546 @@
+package drive
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/drivers/gpio"
+)
+
+const (
+	// OdometryChanged event
+	OdometryChanged = "odometry"
+	// Timeout event
+	Timeout = "timeout"
+	// Error event
+	Error = "error"
+)
+
+const defaultUpdateInterval = 20 * time.Millisecond
+
+// Odometry contains the position of the robot, which is calculated by the wheel encoders. The position is relative to
+// the position at start or at the last reset, the x-axis is the initial forward direction.
+type Odometry struct {
+	X       float64 // [m]
+	Y       float64 // [m]
+	Heading float64 // [rad] in the range -π..π, positive is counter-clockwise
+}
+
+// optionApplier needs to be implemented by each configurable option type
+type optionApplier interface {
+	apply(cfg *configuration)
+}
+
+// configuration contains all changeable attributes of the driver.
+type configuration struct {
+	name            string
+	updateInterval  time.Duration
+	linearAccel     float64 // [m/s²], zero for unlimited
+	angularAccel    float64 // [rad/s²], zero for unlimited
+	trim            float64
+	commandTimeout  time.Duration
+	invertLeft      bool
+	invertRight     bool
+	leftEncoder     gpio.PositionReader
+	rightEncoder    gpio.PositionReader
+	encoderPerMeter float64 // [steps/m]
+}
+
+// nameOption is the type for applying another name to the configuration
+type nameOption string
+
+// updateIntervalOption is the type for applying another update interval to the configuration
+type updateIntervalOption time.Duration
+
+// accelerationLimitsOption is the type for applying the limits of acceleration to the configuration
+type accelerationLimitsOption struct {
+	linear  float64
+	angular float64
+}
+
+// trimOption is the type for applying a trim to the configuration
+type trimOption float64
+
+// commandTimeoutOption is the type for applying a safety timeout to the configuration
+type commandTimeoutOption time.Duration
+
+// invertedMotorsOption is the type for applying the direction of the motors to the configuration
+type invertedMotorsOption struct {
+	left  bool
+	right bool
+}
+
+// encodersOption is the type for applying the wheel encoders to the configuration
+type encodersOption struct {
+	left          gpio.PositionReader
+	right         gpio.PositionReader
+	stepsPerMeter float64
+}
+
+// DifferentialDrive controls a wheeled robot with two independently driven wheels (left and right) by the linear and
+// angular velocity. The motors can be of any kind, which implements the Motor interface, see e.g. GpioMotor().
+type DifferentialDrive struct {
+	cfg           *configuration
+	left          Motor
+	right         Motor
+	wheelBase     float64 // [m]
+	maxWheelSpeed float64 // [m/s]
+	mutex         sync.Mutex
+	targetLinear  float64 // [m/s]
+	targetAngular float64 // [rad/s]
+	linear        float64 // [m/s], ramped
+	angular       float64 // [rad/s], ramped
+	lastUpdate    time.Time
+	lastCommand   time.Time
+	written       [2]float64 // last speeds written to the motors
+	writtenValid  bool
+	odometry      Odometry
+	lastPositions [2]int
+	halt          chan struct{}
+	done          chan struct{}
+	gobot.Commander
+	gobot.Eventer
+}
+
+// NewDifferentialDrive creates a new differential drive for the given motors. The wheel base is the distance between
+// the wheels in meter. The maximum wheel speed is the speed of a wheel in meter per second at full motor speed, which
+// is used to convert the velocities to motor speeds.
+//
+// Supported options:
+//
+//	"WithName"
+//	"WithUpdateInterval"
+//	"WithAccelerationLimits"
+//	"WithTrim"
+//	"WithCommandTimeout"
+//	"WithInvertedMotors"
+//	"WithEncoders"
+//
+// Adds the following API Commands:
+//
+//	"Drive" - See DifferentialDrive.Drive
+//	"Stop" - See DifferentialDrive.Stop
+//	"Odometry" - See DifferentialDrive.Odometry
+func NewDifferentialDrive(
+	left, right Motor,
+	wheelBase, maxWheelSpeed float64,
+	opts ...optionApplier,
+) *DifferentialDrive {
+	if wheelBase <= 0 || maxWheelSpeed <= 0 {
+		panic("wheel base and maximum wheel speed needs to be greater than zero")
+	}
+
+	d := &DifferentialDrive{
+		cfg:           &configuration{name: gobot.DefaultName("DifferentialDrive"), updateInterval: defaultUpdateInterval},
+		left:          left,
+		right:         right,
+		wheelBase:     wheelBase,
+		maxWheelSpeed: maxWheelSpeed,
+		Commander:     gobot.NewCommander(),
+		Eventer:       gobot.NewEventer(),
+	}
+	d.AddEvent(OdometryChanged)
+	d.AddEvent(Timeout)
+	d.AddEvent(Error)
+
+	for _, o := range opts {
+		o.apply(d.cfg)
+	}
+
+	d.AddCommand("Drive", func(params map[string]interface{}) interface{} {
+		linear, _ := params["linear"].(float64)
+		angular, _ := params["angular"].(float64)
+		return d.Drive(linear, angular)
+	})
+
+	d.AddCommand("Stop", func(params map[string]interface{}) interface{} {
+		return d.Stop()
+	})
+
+	d.AddCommand("Odometry", func(params map[string]interface{}) interface{} {
+		o := d.Odometry()
+		return map[string]interface{}{"x": o.X, "y": o.Y, "heading": o.Heading}
+	})
+
+	return d
+}
+
+// WithName is used to replace the default name of the driver.
+func WithName(name string) optionApplier {
+	return nameOption(name)
+}
+
+// WithUpdateInterval changes the interval for ramping, safety timeout and odometry from default 20ms to the given
+// value.
+func WithUpdateInterval(interval time.Duration) optionApplier {
+	return updateIntervalOption(interval)
+}
+
+// WithAccelerationLimits limits the change of the linear velocity [m/s²] and the angular velocity [rad/s²], so the
+// commanded velocities are ramped. Zero means unlimited, which is the default.
+func WithAccelerationLimits(linear, angular float64) optionApplier {
+	return accelerationLimitsOption{linear: linear, angular: angular}
+}
+
+// WithTrim compensates different motors, so the robot drives straight. A positive value reduces the speed of the
+// right motor by the given fraction, a negative value reduces the speed of the left motor, e.g. 0.05 for 5%.
+func WithTrim(trim float64) optionApplier {
+	return trimOption(trim)
+}
+
+// WithCommandTimeout activates the safety timeout. The robot stops immediately and the event "timeout" is published,
+// if no new command is given within the timeout while moving. By default the timeout is disabled.
+func WithCommandTimeout(timeout time.Duration) optionApplier {
+	return commandTimeoutOption(timeout)
+}
+
+// WithInvertedMotors inverts the direction of the left or right motor and its encoder, e.g. for motors mounted
+// mirrored.
+func WithInvertedMotors(left, right bool) optionApplier {
+	return invertedMotorsOption{left: left, right: right}
+}
+
+// WithEncoders activates the odometry by the given wheel encoders, e.g. gpio.RotaryEncoderDriver. The position of the
+// encoders needs to increase, when the wheel moves forward (after applying the inversion of the motor).
+func WithEncoders(left, right gpio.PositionReader, stepsPerMeter float64) optionApplier {
+	return encodersOption{left: left, right: right, stepsPerMeter: stepsPerMeter}
+}
+
+// Name returns the name of the driver.
+func (d *DifferentialDrive) Name() string {
+	return d.cfg.name
+}
+
+// SetName sets the name of the driver.
+func (d *DifferentialDrive) SetName(name string) {
+	d.cfg.name = name
+}
+
+// Connection returns nil, because the drive uses the connections of the motors. Please add the motors also as devices
+// to the robot.
+func (d *DifferentialDrive) Connection() gobot.Connection {
+	return nil
+}
+
+// Start stops the motors, resets the odometry and starts the update for ramping, safety timeout and odometry.
+func (d *DifferentialDrive) Start() error {
+	if d.cfg.updateInterval <= 0 {
+		return fmt.Errorf("the update interval for the differential drive needs to be greater than zero")
+	}
+
+	if err := d.Stop(); err != nil {
+		return err
+	}
+	d.ResetOdometry()
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.halt != nil {
+		return nil
+	}
+
+	halt := make(chan struct{})
+	done := make(chan struct{})
+	d.halt = halt
+	d.done = done
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(d.cfg.updateInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case now := <-ticker.C:
+				d.update(now)
+			case <-halt:
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Halt stops the update and the motors.
+func (d *DifferentialDrive) Halt() error {
+	d.mutex.Lock()
+	halt := d.halt
+	done := d.done
+	d.halt = nil
+	d.mutex.Unlock()
+
+	if halt != nil {
+		close(halt)
+		<-done
+	}
+
+	return d.Stop()
+}
+
+// Drive sets the linear velocity [m/s] (positive is forward) and the angular velocity [rad/s] (positive is
+// counter-clockwise). If a wheel would exceed the maximum speed, both wheels are slowed down, so the curvature is
+// kept.
+func (d *DifferentialDrive) Drive(linear, angular float64) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.targetLinear = linear
+	d.targetAngular = angular
+	d.lastCommand = time.Now()
+
+	return d.rampLocked(d.lastCommand)
+}
+
+// Arc drives with the given linear velocity [m/s] along a circle with the given radius [m], a positive radius turns
+// to the left (counter-clockwise).
+func (d *DifferentialDrive) Arc(linear, radius float64) error {
+	if radius == 0 {
+		return fmt.Errorf("the radius of the arc needs to be not zero, use Drive() to turn in place")
+	}
+
+	return d.Drive(linear, linear/radius)
+}
+
+// Stop stops the motors immediately without ramping.
+func (d *DifferentialDrive) Stop() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.stopLocked()
+	return d.writeLocked(true)
+}
+
+// Velocity returns the current (ramped) linear velocity [m/s] and angular velocity [rad/s].
+//
+//nolint:nonamedreturns // sufficient here
+func (d *DifferentialDrive) Velocity() (linear float64, angular float64) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.linear, d.angular
+}
+
+// Odometry returns the current position of the robot, if encoders are configured.
+func (d *DifferentialDrive) Odometry() Odometry {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.odometry
+}
+
+// ResetOdometry sets the current position of the robot to the origin.
+func (d *DifferentialDrive) ResetOdometry() {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.odometry = Odometry{}
+	if d.hasEncoders() {
+		d.lastPositions = [2]int{d.cfg.leftEncoder.CurrentPosition(), d.cfg.rightEncoder.CurrentPosition()}
+	}
+}
+
+// update is called cyclic for ramping, safety timeout and odometry
+func (d *DifferentialDrive) update(now time.Time) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	moving := d.targetLinear != 0 || d.targetAngular != 0 || d.linear != 0 || d.angular != 0
+	if d.cfg.commandTimeout > 0 && moving && now.Sub(d.lastCommand) > d.cfg.commandTimeout {
+		d.stopLocked()
+		d.Publish(Timeout, now.Sub(d.lastCommand))
+	}
+
+	if err := d.rampLocked(now); err != nil {
+		d.Publish(Error, err)
+	}
+
+	if d.hasEncoders() {
+		d.updateOdometryLocked()
+	}
+}
+
+func (d *DifferentialDrive) stopLocked() {
+	d.targetLinear = 0
+	d.targetAngular = 0
+	d.linear = 0
+	d.angular = 0
+}
+
+// rampLocked changes the velocities towards the target velocities and writes the motors
+func (d *DifferentialDrive) rampLocked(now time.Time) error {
+	dt := now.Sub(d.lastUpdate).Seconds()
+	if d.lastUpdate.IsZero() {
+		dt = d.cfg.updateInterval.Seconds()
+	}
+	d.lastUpdate = now
+
+	d.linear = ramp(d.linear, d.targetLinear, d.cfg.linearAccel*dt)
+	d.angular = ramp(d.angular, d.targetAngular, d.cfg.angularAccel*dt)
+
+	return d.writeLocked(false)
+}
+
+// writeLocked converts the velocities to the speeds of the motors and writes them, if changed or forced
+func (d *DifferentialDrive) writeLocked(force bool) error {
+	leftSpeed := (d.linear - d.angular*d.wheelBase/2) / d.maxWheelSpeed
+	rightSpeed := (d.linear + d.angular*d.wheelBase/2) / d.maxWheelSpeed
+
+	// keep the curvature, if a wheel is too fast
+	if highest := math.Max(math.Abs(leftSpeed), math.Abs(rightSpeed)); highest > 1 {
+		leftSpeed /= highest
+		rightSpeed /= highest
+	}
+
+	if d.cfg.trim > 0 {
+		rightSpeed *= 1 - d.cfg.trim
+	} else if d.cfg.trim < 0 {
+		leftSpeed *= 1 + d.cfg.trim
+	}
+
+	if d.cfg.invertLeft {
+		leftSpeed = -leftSpeed
+	}
+	if d.cfg.invertRight {
+		rightSpeed = -rightSpeed
+	}
+
+	speeds := [2]float64{leftSpeed, rightSpeed}
+	if !force && d.writtenValid && speeds == d.written {
+		return nil
+	}
+
+	var err error
+	if e := d.left.SetSpeed(leftSpeed); e != nil {
+		err = multierror.Append(err, e)
+	}
+	if e := d.right.SetSpeed(rightSpeed); e != nil {
+		err = multierror.Append(err, e)
+	}
+
+	// on error the speeds are written again with the next update
+	d.written = speeds
+	d.writtenValid = err == nil
+
+	return err
+}
+
+// updateOdometryLocked reads the encoders and calculates the new position
+func (d *DifferentialDrive) updateOdometryLocked() {
+	positions := [2]int{d.cfg.leftEncoder.CurrentPosition(), d.cfg.rightEncoder.CurrentPosition()}
+	if positions == d.lastPositions {
+		return
+	}
+
+	leftDistance := float64(positions[0]-d.lastPositions[0]) / d.cfg.encoderPerMeter
+	rightDistance := float64(positions[1]-d.lastPositions[1]) / d.cfg.encoderPerMeter
+	d.lastPositions = positions
+
+	if d.cfg.invertLeft {
+		leftDistance = -leftDistance
+	}
+	if d.cfg.invertRight {
+		rightDistance = -rightDistance
+	}
+
+	distance := (leftDistance + rightDistance) / 2
+	deltaHeading := (rightDistance - leftDistance) / d.wheelBase
+
+	// the movement is approximated by a straight line in the mean direction
+	heading := d.odometry.Heading + deltaHeading/2
+	d.odometry.X += distance * math.Cos(heading)
+	d.odometry.Y += distance * math.Sin(heading)
+	d.odometry.Heading = normalizeAngle(d.odometry.Heading + deltaHeading)
+
+	d.Publish(OdometryChanged, d.odometry)
+}
+
+func (d *DifferentialDrive) hasEncoders() bool {
+	return d.cfg.leftEncoder != nil && d.cfg.rightEncoder != nil && d.cfg.encoderPerMeter > 0
+}
+
+// ramp changes the value towards the target by the given maximum step, a step of zero means unlimited
+func ramp(value, target, maxStep float64) float64 {
+	if maxStep <= 0 {
+		return target
+	}
+
+	return value + math.Max(-maxStep, math.Min(maxStep, target-value))
+}
+
+// normalizeAngle maps the angle to the range -π..π
+func normalizeAngle(angle float64) float64 {
+	angle = math.Mod(angle+math.Pi, 2*math.Pi)
+	if angle < 0 {
+		angle += 2 * math.Pi
+	}
+
+	return angle - math.Pi
+}
+
+func (o nameOption) String() string {
+	return "name option for differential drive"
+}
+
+func (o updateIntervalOption) String() string {
+	return "update interval option for differential drive"
+}
+
+func (o accelerationLimitsOption) String() string {
+	return "acceleration limits option for differential drive"
+}
+
+func (o trimOption) String() string {
+	return "trim option for differential drive"
+}
+
+func (o commandTimeoutOption) String() string {
+	return "command timeout option for differential drive"
+}
+
+func (o invertedMotorsOption) String() string {
+	return "inverted motors option for differential drive"
+}
+
+func (o encodersOption) String() string {
+	return "encoders option for differential drive"
+}
+
+func (o nameOption) apply(cfg *configuration) {
+	cfg.name = string(o)
+}
+
+func (o updateIntervalOption) apply(cfg *configuration) {
+	cfg.updateInterval = time.Duration(o)
+}
+
+func (o accelerationLimitsOption) apply(cfg *configuration) {
+	cfg.linearAccel = o.linear
+	cfg.angularAccel = o.angular
+}
+
+func (o trimOption) apply(cfg *configuration) {
+	cfg.trim = float64(o)
+}
+
+func (o commandTimeoutOption) apply(cfg *configuration) {
+	cfg.commandTimeout = time.Duration(o)
+}
+
+func (o invertedMotorsOption) apply(cfg *configuration) {
+	cfg.invertLeft = o.left
+	cfg.invertRight = o.right
+}
+
+func (o encodersOption) apply(cfg *configuration) {
+	cfg.leftEncoder = o.left
+	cfg.rightEncoder = o.right
+	cfg.encoderPerMeter = o.stepsPerMeter
+}
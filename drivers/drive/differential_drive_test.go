@@ -0,0 +1,460 @@
+//nolint:forcetypeassert // ok here
+package drive
+
+import (
+	"errors"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+)
+
+// make sure that this driver fulfills all the required interfaces
+var _ gobot.Driver = (*DifferentialDrive)(nil)
+
+type driveTestMotor struct {
+	mtx    sync.Mutex
+	speeds []float64
+	err    error
+}
+
+func (m *driveTestMotor) SetSpeed(speed float64) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.speeds = append(m.speeds, speed)
+	return m.err
+}
+
+func (m *driveTestMotor) last() float64 {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	if len(m.speeds) == 0 {
+		return math.NaN()
+	}
+	return m.speeds[len(m.speeds)-1]
+}
+
+func (m *driveTestMotor) count() int {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	return len(m.speeds)
+}
+
+type driveTestEncoder struct {
+	mtx      sync.Mutex
+	position int
+}
+
+func (e *driveTestEncoder) CurrentPosition() int {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	return e.position
+}
+
+func (e *driveTestEncoder) add(steps int) {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	e.position += steps
+}
+
+func initTestDifferentialDrive(opts ...optionApplier) (*DifferentialDrive, *driveTestMotor, *driveTestMotor) {
+	left := &driveTestMotor{}
+	right := &driveTestMotor{}
+	// wheel base 0.2m, 1m/s at full speed
+	return NewDifferentialDrive(left, right, 0.2, 1, opts...), left, right
+}
+
+func TestNewDifferentialDrive(t *testing.T) {
+	// arrange
+	left := &driveTestMotor{}
+	right := &driveTestMotor{}
+	// act
+	d := NewDifferentialDrive(left, right, 0.2, 1)
+	// assert
+	assert.IsType(t, &DifferentialDrive{}, d)
+	assert.True(t, len(d.Name()) > 0)
+	assert.Nil(t, d.Connection())
+	assert.Equal(t, defaultUpdateInterval, d.cfg.updateInterval)
+	assert.Equal(t, time.Duration(0), d.cfg.commandTimeout)
+	assert.InDelta(t, 0.0, d.cfg.linearAccel, 0.0)
+	assert.InDelta(t, 0.0, d.cfg.trim, 0.0)
+	assert.NotNil(t, d.Command("Drive"))
+	assert.NotNil(t, d.Command("Stop"))
+	assert.NotNil(t, d.Command("Odometry"))
+	for _, e := range []string{OdometryChanged, Timeout, Error} {
+		assert.Equal(t, e, d.Event(e))
+	}
+	assert.PanicsWithValue(t, "wheel base and maximum wheel speed needs to be greater than zero", func() {
+		NewDifferentialDrive(left, right, 0, 1)
+	})
+}
+
+func TestNewDifferentialDrive_options(t *testing.T) {
+	// arrange
+	leftEnc := &driveTestEncoder{}
+	rightEnc := &driveTestEncoder{}
+	// act
+	d, _, _ := initTestDifferentialDrive(WithName("base"), WithUpdateInterval(5*time.Millisecond),
+		WithAccelerationLimits(0.5, 2), WithTrim(0.1), WithCommandTimeout(time.Second),
+		WithInvertedMotors(true, false), WithEncoders(leftEnc, rightEnc, 1000))
+	d.SetName("newbase")
+	// assert
+	assert.Equal(t, "newbase", d.Name())
+	assert.Equal(t, 5*time.Millisecond, d.cfg.updateInterval)
+	assert.InDelta(t, 0.5, d.cfg.linearAccel, 0.0)
+	assert.InDelta(t, 2.0, d.cfg.angularAccel, 0.0)
+	assert.InDelta(t, 0.1, d.cfg.trim, 0.0)
+	assert.Equal(t, time.Second, d.cfg.commandTimeout)
+	assert.True(t, d.cfg.invertLeft)
+	assert.False(t, d.cfg.invertRight)
+	assert.Same(t, leftEnc, d.cfg.leftEncoder)
+	assert.Same(t, rightEnc, d.cfg.rightEncoder)
+	assert.InDelta(t, 1000.0, d.cfg.encoderPerMeter, 0.0)
+}
+
+func TestDifferentialDriveDrive(t *testing.T) {
+	tests := map[string]struct {
+		opts      []optionApplier
+		linear    float64
+		angular   float64
+		wantLeft  float64
+		wantRight float64
+	}{
+		"forward": {
+			linear:    0.5,
+			wantLeft:  0.5,
+			wantRight: 0.5,
+		},
+		"backward": {
+			linear:    -0.25,
+			wantLeft:  -0.25,
+			wantRight: -0.25,
+		},
+		"rotate_counter_clockwise": {
+			angular:   2,
+			wantLeft:  -0.2,
+			wantRight: 0.2,
+		},
+		"curve_left": {
+			linear:    0.5,
+			angular:   1,
+			wantLeft:  0.4,
+			wantRight: 0.6,
+		},
+		"too_fast_keeps_curvature": {
+			linear:    2,
+			angular:   10,
+			wantLeft:  1.0 / 3,
+			wantRight: 1,
+		},
+		"trim_right": {
+			opts:      []optionApplier{WithTrim(0.1)},
+			linear:    0.5,
+			wantLeft:  0.5,
+			wantRight: 0.45,
+		},
+		"trim_left": {
+			opts:      []optionApplier{WithTrim(-0.2)},
+			linear:    0.5,
+			wantLeft:  0.4,
+			wantRight: 0.5,
+		},
+		"inverted_left": {
+			opts:      []optionApplier{WithInvertedMotors(true, false)},
+			linear:    0.5,
+			wantLeft:  -0.5,
+			wantRight: 0.5,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, left, right := initTestDifferentialDrive(tc.opts...)
+			// act
+			err := d.Drive(tc.linear, tc.angular)
+			// assert
+			require.NoError(t, err)
+			assert.InDelta(t, tc.wantLeft, left.last(), 1e-9)
+			assert.InDelta(t, tc.wantRight, right.last(), 1e-9)
+			linear, angular := d.Velocity()
+			assert.InDelta(t, tc.linear, linear, 0.0)
+			assert.InDelta(t, tc.angular, angular, 0.0)
+		})
+	}
+}
+
+func TestDifferentialDriveDrive_unchangedIsNotWritten(t *testing.T) {
+	// arrange
+	d, left, right := initTestDifferentialDrive()
+	require.NoError(t, d.Drive(0.5, 0))
+	// act
+	err := d.Drive(0.5, 0)
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, 1, left.count())
+	assert.Equal(t, 1, right.count())
+}
+
+func TestDifferentialDriveDrive_error(t *testing.T) {
+	// arrange
+	d, left, right := initTestDifferentialDrive()
+	left.err = errors.New("left error")
+	right.err = errors.New("right error")
+	// act
+	err := d.Drive(0.5, 0)
+	// assert
+	require.ErrorContains(t, err, "left error")
+	require.ErrorContains(t, err, "right error")
+	// act: the speeds are written again after an error
+	err = d.Drive(0.5, 0)
+	// assert
+	require.Error(t, err)
+	assert.Equal(t, 2, left.count())
+}
+
+func TestDifferentialDriveArc(t *testing.T) {
+	// arrange
+	d, left, right := initTestDifferentialDrive()
+	// act
+	err := d.Arc(0.5, -0.5)
+	// assert
+	require.NoError(t, err)
+	_, angular := d.Velocity()
+	assert.InDelta(t, -1.0, angular, 1e-9)
+	assert.InDelta(t, 0.6, left.last(), 1e-9)
+	assert.InDelta(t, 0.4, right.last(), 1e-9)
+	// act & assert: a radius of zero is not possible
+	require.ErrorContains(t, d.Arc(0.5, 0), "radius of the arc needs to be not zero")
+}
+
+func TestDifferentialDriveRamp(t *testing.T) {
+	// arrange
+	d, left, _ := initTestDifferentialDrive(WithAccelerationLimits(1, 10))
+	start := time.Now()
+	d.lastUpdate = start
+	d.targetLinear = 0.5
+	d.targetAngular = -2
+	// act & assert: the velocities change by the acceleration limits
+	require.NoError(t, d.rampLocked(start.Add(100*time.Millisecond)))
+	linear, angular := d.Velocity()
+	assert.InDelta(t, 0.1, linear, 1e-9)
+	assert.InDelta(t, -1.0, angular, 1e-9)
+	assert.InDelta(t, 0.2, left.last(), 1e-9)
+	// act & assert: the targets are reached and not overshot
+	require.NoError(t, d.rampLocked(start.Add(time.Second)))
+	linear, angular = d.Velocity()
+	assert.InDelta(t, 0.5, linear, 1e-9)
+	assert.InDelta(t, -2.0, angular, 1e-9)
+	// act & assert: stop is immediate, without ramping
+	require.NoError(t, d.Stop())
+	linear, angular = d.Velocity()
+	assert.InDelta(t, 0.0, linear, 0.0)
+	assert.InDelta(t, 0.0, angular, 0.0)
+	assert.InDelta(t, 0.0, left.last(), 0.0)
+}
+
+func TestDifferentialDriveCommandTimeout(t *testing.T) {
+	// arrange
+	d, left, right := initTestDifferentialDrive(WithUpdateInterval(time.Millisecond),
+		WithCommandTimeout(20*time.Millisecond))
+	sem := make(chan time.Duration, 1)
+	_ = d.Once(Timeout, func(data interface{}) {
+		sem <- data.(time.Duration)
+	})
+	require.NoError(t, d.Start())
+	defer func() { _ = d.Halt() }()
+	// act
+	require.NoError(t, d.Drive(0.5, 0))
+	// assert
+	select {
+	case since := <-sem:
+		assert.GreaterOrEqual(t, since, 20*time.Millisecond)
+	case <-time.After(time.Second):
+		require.Fail(t, "timeout event was not published")
+	}
+	assert.Eventually(t, func() bool { return left.last() == 0 && right.last() == 0 }, time.Second, time.Millisecond)
+	linear, _ := d.Velocity()
+	assert.InDelta(t, 0.0, linear, 0.0)
+}
+
+func TestDifferentialDriveOdometry(t *testing.T) {
+	tests := map[string]struct {
+		leftSteps   int
+		rightSteps  int
+		wantX       float64
+		wantY       float64
+		wantHeading float64
+	}{
+		"straight_forward": {
+			leftSteps:  500,
+			rightSteps: 500,
+			wantX:      0.5,
+		},
+		"straight_backward": {
+			leftSteps:  -100,
+			rightSteps: -100,
+			wantX:      -0.1,
+		},
+		"turn_in_place_counter_clockwise": {
+			leftSteps:   -100,
+			rightSteps:  100,
+			wantHeading: 1,
+		},
+		"quarter_circle_left": {
+			// radius 0.5m: left wheel on 0.4m, right wheel on 0.6m, π/2 => 0.2π and 0.3π
+			leftSteps:   int(math.Round(200 * math.Pi)),
+			rightSteps:  int(math.Round(300 * math.Pi)),
+			wantX:       0.5,
+			wantY:       0.5,
+			wantHeading: math.Pi / 2,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			leftEnc := &driveTestEncoder{position: 10}
+			rightEnc := &driveTestEncoder{position: -20}
+			d, _, _ := initTestDifferentialDrive(WithEncoders(leftEnc, rightEnc, 1000))
+			d.ResetOdometry()
+			var mtx sync.Mutex
+			var published []Odometry
+			_ = d.On(OdometryChanged, func(data interface{}) {
+				mtx.Lock()
+				defer mtx.Unlock()
+				published = append(published, data.(Odometry))
+			})
+			// act: the movement is done in small pieces like in the update loop
+			const pieces = 100
+			for i := 0; i < pieces; i++ {
+				leftEnc.add(tc.leftSteps / pieces)
+				rightEnc.add(tc.rightSteps / pieces)
+				if i == pieces-1 {
+					leftEnc.add(tc.leftSteps % pieces)
+					rightEnc.add(tc.rightSteps % pieces)
+				}
+				d.updateOdometryLocked()
+			}
+			// assert
+			got := d.Odometry()
+			assert.InDelta(t, tc.wantX, got.X, 0.005)
+			assert.InDelta(t, tc.wantY, got.Y, 0.005)
+			assert.InDelta(t, tc.wantHeading, got.Heading, 0.005)
+			assert.Eventually(t, func() bool {
+				mtx.Lock()
+				defer mtx.Unlock()
+				return len(published) > 0
+			}, time.Second, time.Millisecond)
+		})
+	}
+}
+
+func TestDifferentialDriveOdometry_invertedAndReset(t *testing.T) {
+	// arrange
+	leftEnc := &driveTestEncoder{}
+	rightEnc := &driveTestEncoder{}
+	d, _, _ := initTestDifferentialDrive(WithEncoders(leftEnc, rightEnc, 1000), WithInvertedMotors(true, true))
+	d.ResetOdometry()
+	leftEnc.add(-200)
+	rightEnc.add(-200)
+	// act
+	d.updateOdometryLocked()
+	// assert
+	assert.InDelta(t, 0.2, d.Odometry().X, 1e-9)
+	// act
+	d.ResetOdometry()
+	d.updateOdometryLocked()
+	// assert
+	assert.Equal(t, Odometry{}, d.Odometry())
+}
+
+func TestDifferentialDriveStartHalt(t *testing.T) {
+	// arrange
+	leftEnc := &driveTestEncoder{position: 5}
+	rightEnc := &driveTestEncoder{position: 5}
+	d, left, right := initTestDifferentialDrive(WithUpdateInterval(time.Millisecond),
+		WithEncoders(leftEnc, rightEnc, 1000))
+	sem := make(chan Odometry, 1)
+	_ = d.Once(OdometryChanged, func(data interface{}) {
+		sem <- data.(Odometry)
+	})
+	// act
+	require.NoError(t, d.Start())
+	require.NoError(t, d.Start()) // second start is ignored
+	leftEnc.add(100)
+	rightEnc.add(100)
+	// assert
+	select {
+	case o := <-sem:
+		assert.InDelta(t, 0.1, o.X, 1e-9)
+	case <-time.After(time.Second):
+		require.Fail(t, "odometry event was not published")
+	}
+	// act
+	require.NoError(t, d.Drive(0.3, 0))
+	require.NoError(t, d.Halt())
+	// assert
+	assert.InDelta(t, 0.0, left.last(), 0.0)
+	assert.InDelta(t, 0.0, right.last(), 0.0)
+	assert.Nil(t, d.halt)
+}
+
+func TestDifferentialDriveStart_error(t *testing.T) {
+	// arrange
+	d, _, _ := initTestDifferentialDrive(WithUpdateInterval(0))
+	// act & assert
+	require.ErrorContains(t, d.Start(), "update interval for the differential drive needs to be greater than zero")
+	// arrange
+	d, left, _ := initTestDifferentialDrive()
+	left.err = errors.New("write error")
+	// act & assert
+	require.ErrorContains(t, d.Start(), "write error")
+}
+
+func TestDifferentialDriveCommands(t *testing.T) {
+	// arrange
+	leftEnc := &driveTestEncoder{}
+	rightEnc := &driveTestEncoder{}
+	d, left, right := initTestDifferentialDrive(WithEncoders(leftEnc, rightEnc, 1000))
+	// act
+	result := d.Command("Drive")(map[string]interface{}{"linear": 0.5, "angular": 1.0})
+	// assert
+	assert.Nil(t, result)
+	assert.InDelta(t, 0.4, left.last(), 1e-9)
+	assert.InDelta(t, 0.6, right.last(), 1e-9)
+	// act
+	result = d.Command("Stop")(nil)
+	// assert
+	assert.Nil(t, result)
+	assert.InDelta(t, 0.0, left.last(), 0.0)
+	// act
+	leftEnc.add(100)
+	rightEnc.add(100)
+	d.updateOdometryLocked()
+	result = d.Command("Odometry")(nil)
+	// assert
+	assert.InDelta(t, 0.1, result.(map[string]interface{})["x"].(float64), 1e-9)
+}
+
+func TestNormalizeAngle(t *testing.T) {
+	tests := map[string]struct {
+		angle float64
+		want  float64
+	}{
+		"zero":          {angle: 0, want: 0},
+		"positive":      {angle: 3, want: 3},
+		"over_pi":       {angle: 1.5 * math.Pi, want: -0.5 * math.Pi},
+		"under_minus":   {angle: -1.5 * math.Pi, want: 0.5 * math.Pi},
+		"multiple_turn": {angle: 4.25 * math.Pi, want: 0.25 * math.Pi},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// act & assert
+			assert.InDelta(t, tc.want, normalizeAngle(tc.angle), 1e-9)
+		})
+	}
+}
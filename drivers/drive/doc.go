@@ -0,0 +1,12 @@
+/*
+Package drive provides a Gobot driver for a differential drive, which moves a wheeled robot with two motors by linear
+and angular velocity commands. With wheel encoders the position of the robot is tracked by odometry.
+
+Installing:
+
+	Please refer to the main [README.md](https://github.com/hybridgroup/gobot/blob/release/README.md)
+
+For further information refer to drive README:
+https://github.com/hybridgroup/gobot/blob/master/drivers/drive/README.md
+*/
+package drive // import "gobot.io/x/gobot/v2/drivers/drive"
@@ -0,0 +1,45 @@
+package drive
+
+import (
+	"math"
+
+	"gobot.io/x/gobot/v2/drivers/gpio"
+)
+
+// Motor is the interface for a motor, which can be used by the differential drive.
+type Motor interface {
+	// SetSpeed sets the speed in the range -1 (full backward) .. 1 (full forward), zero stops the motor.
+	SetSpeed(speed float64) error
+}
+
+// MotorFunc adapts a function to the Motor interface, e.g. for motors without a ready-made adapter. Adapters for
+// motors of platforms are located in the platform packages, e.g. megapi.DriveMotor().
+type MotorFunc func(speed float64) error
+
+// SetSpeed calls the function with the given speed.
+func (f MotorFunc) SetSpeed(speed float64) error {
+	return f(speed)
+}
+
+// GpioMotor adapts a motor driver of the gpio package. The motor is used in analog mode, so the speed pin needs to
+// support PWM and the direction is controlled by the direction pin or the forward and backward pins.
+func GpioMotor(m *gpio.MotorDriver) Motor {
+	return MotorFunc(func(speed float64) error {
+		switch {
+		case speed > 0:
+			return m.Forward(scaleSpeed(speed, 255))
+		case speed < 0:
+			return m.Backward(scaleSpeed(-speed, 255))
+		default:
+			return m.SetSpeed(0)
+		}
+	})
+}
+
+func clampSpeed(speed float64) float64 {
+	return math.Max(-1, math.Min(1, speed))
+}
+
+func scaleSpeed(speed float64, full float64) byte {
+	return byte(math.Round(clampSpeed(speed) * full))
+}
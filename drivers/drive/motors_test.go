@@ -0,0 +1,75 @@
+package drive
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2/drivers/gpio"
+)
+
+type motorTestAdaptor struct {
+	digital map[string]byte
+	pwm     map[string]byte
+}
+
+func newMotorTestAdaptor() *motorTestAdaptor {
+	return &motorTestAdaptor{digital: map[string]byte{}, pwm: map[string]byte{}}
+}
+
+func (a *motorTestAdaptor) Connect() error  { return nil }
+func (a *motorTestAdaptor) Finalize() error { return nil }
+func (a *motorTestAdaptor) Name() string    { return "motorTestAdaptor" }
+func (a *motorTestAdaptor) SetName(string)  {}
+
+func (a *motorTestAdaptor) DigitalWrite(pin string, val byte) error {
+	a.digital[pin] = val
+	return nil
+}
+
+func (a *motorTestAdaptor) PwmWrite(pin string, val byte) error {
+	a.pwm[pin] = val
+	return nil
+}
+
+func TestMotorFunc(t *testing.T) {
+	// arrange
+	var got float64
+	m := MotorFunc(func(speed float64) error {
+		got = speed
+		return errors.New("motor error")
+	})
+	// act
+	err := m.SetSpeed(0.3)
+	// assert
+	require.ErrorContains(t, err, "motor error")
+	assert.InDelta(t, 0.3, got, 0.0)
+}
+
+func TestGpioMotor(t *testing.T) {
+	tests := map[string]struct {
+		speed     float64
+		wantDir   byte
+		wantSpeed byte
+	}{
+		"forward":         {speed: 0.5, wantDir: 1, wantSpeed: 128},
+		"backward":        {speed: -1, wantDir: 0, wantSpeed: 255},
+		"forward_clamped": {speed: 2, wantDir: 1, wantSpeed: 255},
+		"stop":            {speed: 0, wantDir: 0, wantSpeed: 0},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := newMotorTestAdaptor()
+			m := GpioMotor(gpio.NewMotorDriver(a, "1", gpio.WithMotorDirectionPin("2")))
+			// act
+			err := m.SetSpeed(tc.speed)
+			// assert
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantSpeed, a.pwm["1"])
+			assert.Equal(t, tc.wantDir, a.digital["2"])
+		})
+	}
+}
@@ -43,6 +43,7 @@ func NewAIP1640Driver(a gobot.Connection, clockPin string, dataPin string, opts
 		intensity: 7,
 	}
 	d.afterStart = d.initialize
+	d.usedPins = func() map[string]string { return directPinsUsage(d.pinClock, d.pinData) }
 
 	/* TODO : Add commands */
 
@@ -117,6 +118,24 @@ func (d *AIP1640Driver) DrawMatrix(data [8]byte) {
 	}
 }
 
+// Columns returns the count of columns of the display (interface font.MatrixDisplayer).
+func (d *AIP1640Driver) Columns() int {
+	return len(d.buffer)
+}
+
+// WriteColumns replaces the buffer by the columns, beginning with the left column, and sends it to the display
+// (interface font.MatrixDisplayer). The lowest bit of each column is the top row, which is row 0 of DrawPixel().
+func (d *AIP1640Driver) WriteColumns(columns []byte) error {
+	d.Clear()
+	for x := 0; x < len(columns) && x < len(d.buffer); x++ {
+		for y := 0; y < 8; y++ {
+			d.DrawPixel(byte(x), byte(y), columns[x]&(1<<y) > 0)
+		}
+	}
+
+	return d.Display()
+}
+
 // initialize initializes the tm1638, it uses a SPI-like communication protocol
 func (d *AIP1640Driver) initialize() error {
 	if err := d.pinData.On(); err != nil {
@@ -110,3 +110,15 @@ func TestAIP1640SetIntensityHigherThan7(t *testing.T) {
 	d.SetIntensity(19)
 	assert.Equal(t, uint8(7), d.intensity)
 }
+
+func TestAIP1640WriteColumns(t *testing.T) {
+	// arrange
+	d := initTestAIP1640Driver()
+	d.DrawRow(5, 0xFF)
+	// act: first column all rows on, second column only top row
+	err := d.WriteColumns([]byte{0xFF, 0x01})
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, 8, d.Columns())
+	assert.Equal(t, [8]byte{0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x03}, d.buffer)
+}
@@ -14,8 +14,12 @@ type buttonOptionApplier interface {
 
 // buttonConfiguration contains all changeable attributes of the driver.
 type buttonConfiguration struct {
-	readInterval time.Duration
-	defaultState int
+	readInterval  time.Duration
+	defaultState  int
+	debounce      time.Duration
+	longPress     time.Duration
+	holdRepeat    time.Duration
+	multiClickGap time.Duration
 }
 
 // buttonReadIntervalOption is the type for applying another read interval to the configuration
@@ -24,6 +28,18 @@ type buttonReadIntervalOption time.Duration
 // buttonDefaultStateOption is the type for applying another default state to the configuration
 type buttonDefaultStateOption int
 
+// buttonDebounceOption is the type for applying a debounce period to the configuration
+type buttonDebounceOption time.Duration
+
+// buttonLongPressOption is the type for applying a long press duration to the configuration
+type buttonLongPressOption time.Duration
+
+// buttonHoldRepeatOption is the type for applying a hold repeat interval to the configuration
+type buttonHoldRepeatOption time.Duration
+
+// buttonMultiClickOption is the type for applying the maximum gap between clicks to the configuration
+type buttonMultiClickOption time.Duration
+
 // ButtonDriver Represents a digital Button
 type ButtonDriver struct {
 	*driver
@@ -31,15 +47,35 @@ type ButtonDriver struct {
 	gobot.Eventer
 	active bool
 	halt   chan struct{}
+	edges  chan int // nil, if the pin is polled
+}
+
+// buttonState contains the state of the gesture recognition, only used by the reading go routine
+type buttonState struct {
+	raw         int       // last value read from the pin
+	rawSince    time.Time // time of the last change of the read value
+	stable      int       // debounced value
+	pressedAt   time.Time
+	longPressed bool
+	repeats     int
+	nextRepeat  time.Time
+	clicks      int
+	clicksEnd   time.Time
 }
 
 // NewButtonDriver returns a driver for a button with a polling interval for changed state of 10 milliseconds,
-// given a DigitalReader and pin.
+// given a DigitalReader and pin. If the adaptor implements the DigitalEdgeNotifier interface, the edge notification
+// is used instead of polling. If this fails, e.g. for sysfs access, the driver falls back to polling.
 //
 // Supported options:
 //
 //	"WithName"
 //	"WithButtonPollInterval"
+//	"WithButtonDefaultState"
+//	"WithButtonDebounce"
+//	"WithButtonLongPress"
+//	"WithButtonHoldRepeat"
+//	"WithButtonMultiClick"
 func NewButtonDriver(a DigitalReader, pin string, opts ...interface{}) *ButtonDriver {
 	//nolint:forcetypeassert // no error return value, so there is no better way
 	d := &ButtonDriver{
@@ -76,6 +112,30 @@ func WithButtonDefaultState(s int) buttonOptionApplier {
 	return buttonDefaultStateOption(s)
 }
 
+// WithButtonDebounce sets the period, for which a changed value needs to be stable before it is accepted. The default
+// is zero, which means no debouncing.
+func WithButtonDebounce(period time.Duration) buttonOptionApplier {
+	return buttonDebounceOption(period)
+}
+
+// WithButtonLongPress activates the "long-press" event, which is published once, when the button is held for the
+// given duration. A long press is not counted as click.
+func WithButtonLongPress(duration time.Duration) buttonOptionApplier {
+	return buttonLongPressOption(duration)
+}
+
+// WithButtonHoldRepeat activates the "hold-repeat" event, which is published at the given interval while the button
+// is held. The repetition starts after the long press duration, if configured. A held button is not counted as click.
+func WithButtonHoldRepeat(interval time.Duration) buttonOptionApplier {
+	return buttonHoldRepeatOption(interval)
+}
+
+// WithButtonMultiClick activates the detection of double and multi clicks. A click is counted to the previous one,
+// if the button is pushed again within the given gap after the release. The click event is delayed by the gap.
+func WithButtonMultiClick(gap time.Duration) buttonOptionApplier {
+	return buttonMultiClickOption(gap)
+}
+
 // Active gets the current state
 func (d *ButtonDriver) Active() bool {
 	// ensure that read and write can not interfere
@@ -95,12 +155,18 @@ func (d *ButtonDriver) SetDefaultState(s int) {
 	WithButtonDefaultState(s).apply(d.buttonCfg)
 }
 
-// initialize the ButtonDriver and polls the state of the button at the given interval.
+// initialize the ButtonDriver and starts the notification by edges or polls the state of the button at the given
+// interval.
 //
 // Emits the Events:
 //
 //	Push int - On button push
 //	Release int - On button release
+//	Click int - On a single click, data is the count of clicks (1)
+//	DoubleClick int - On a double click, data is the count of clicks (2)
+//	MultiClick int - On three or more clicks, data is the count of clicks
+//	LongPress int - When the button is held for the long press duration
+//	HoldRepeat int - Repeatedly while the button is held, data is the count of repetitions
 //	Error error - On button error
 func (d *ButtonDriver) initialize() error {
 	if d.buttonCfg.readInterval == 0 {
@@ -110,28 +176,32 @@ func (d *ButtonDriver) initialize() error {
 	d.Eventer = gobot.NewEventer()
 	d.AddEvent(ButtonPush)
 	d.AddEvent(ButtonRelease)
+	d.AddEvent(ButtonClick)
+	d.AddEvent(ButtonDoubleClick)
+	d.AddEvent(ButtonMultiClick)
+	d.AddEvent(ButtonLongPress)
+	d.AddEvent(ButtonHoldRepeat)
 	d.AddEvent(Error)
 
-	d.halt = make(chan struct{})
+	halt := make(chan struct{})
+	d.halt = halt
+	d.edges = nil
 
-	state := d.buttonCfg.defaultState
-
-	go func() {
-		for {
+	if notifier, ok := d.connection.(DigitalEdgeNotifier); ok {
+		edges := make(chan int, 16)
+		err := notifier.NotifyDigitalEdges(d.driverCfg.pin, func(val int) {
 			select {
-			case <-time.After(d.buttonCfg.readInterval):
-				newValue, err := d.digitalRead(d.driverCfg.pin)
-				if err != nil {
-					d.Publish(Error, err)
-				} else if newValue != state && newValue != -1 {
-					state = newValue
-					d.update(newValue)
-				}
-			case <-d.halt:
-				return
+			case edges <- val:
+			case <-halt:
 			}
+		})
+		if err == nil {
+			d.edges = edges
 		}
-	}()
+	}
+
+	go d.run(d.edges, halt)
+
 	return nil
 }
 
@@ -141,8 +211,165 @@ func (d *ButtonDriver) shutdown() error {
 		return nil
 	}
 
+	var err error
+	if d.edges != nil {
+		//nolint:forcetypeassert // ok here, the edges are only used for notifiers
+		err = d.connection.(DigitalEdgeNotifier).NotifyDigitalEdges(d.driverCfg.pin, nil)
+	}
+
 	close(d.halt) // broadcast halt, also to the test
-	return nil
+	return err
+}
+
+// run reads the values from the edge notification or by polling and processes the gestures until halt
+func (d *ButtonDriver) run(edges <-chan int, halt <-chan struct{}) {
+	var poll <-chan time.Time
+	if edges == nil {
+		ticker := time.NewTicker(d.buttonCfg.readInterval)
+		defer ticker.Stop()
+		poll = ticker.C
+	}
+
+	timer := time.NewTimer(time.Hour)
+	timer.Stop()
+	defer timer.Stop()
+
+	s := buttonState{raw: d.buttonCfg.defaultState, stable: d.buttonCfg.defaultState}
+	for {
+		if deadline, ok := d.nextDeadline(&s); ok {
+			timer.Reset(time.Until(deadline))
+		}
+
+		select {
+		case <-poll:
+			newValue, err := d.digitalRead(d.driverCfg.pin)
+			if err != nil {
+				d.Publish(Error, err)
+			} else {
+				d.input(&s, newValue, time.Now())
+			}
+		case newValue := <-edges:
+			d.input(&s, newValue, time.Now())
+		case <-timer.C:
+			d.elapse(&s, time.Now())
+		case <-halt:
+			return
+		}
+
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+	}
+}
+
+// input processes a new value of the pin
+func (d *ButtonDriver) input(s *buttonState, newValue int, now time.Time) {
+	if newValue == s.raw || newValue == -1 {
+		return
+	}
+
+	s.raw = newValue
+	s.rawSince = now
+	if d.buttonCfg.debounce <= 0 {
+		d.change(s, now)
+	}
+}
+
+// nextDeadline gives the next time, when a timed gesture needs to be evaluated
+func (d *ButtonDriver) nextDeadline(s *buttonState) (time.Time, bool) {
+	var deadline time.Time
+	add := func(t time.Time) {
+		if deadline.IsZero() || t.Before(deadline) {
+			deadline = t
+		}
+	}
+
+	if s.raw != s.stable && d.buttonCfg.debounce > 0 {
+		add(s.rawSince.Add(d.buttonCfg.debounce))
+	}
+
+	if s.stable != d.buttonCfg.defaultState {
+		if d.buttonCfg.longPress > 0 && !s.longPressed {
+			add(s.pressedAt.Add(d.buttonCfg.longPress))
+		}
+		if d.buttonCfg.holdRepeat > 0 {
+			add(s.nextRepeat)
+		}
+	} else if s.clicks > 0 {
+		add(s.clicksEnd)
+	}
+
+	return deadline, !deadline.IsZero()
+}
+
+// elapse processes all timed gestures, which are due
+func (d *ButtonDriver) elapse(s *buttonState, now time.Time) {
+	if s.raw != s.stable && d.buttonCfg.debounce > 0 && !now.Before(s.rawSince.Add(d.buttonCfg.debounce)) {
+		d.change(s, now)
+	}
+
+	if s.stable != d.buttonCfg.defaultState {
+		if d.buttonCfg.longPress > 0 && !s.longPressed && !now.Before(s.pressedAt.Add(d.buttonCfg.longPress)) {
+			d.publishClicks(s)
+			s.longPressed = true
+			d.Publish(ButtonLongPress, s.stable)
+		}
+		if d.buttonCfg.holdRepeat > 0 && !now.Before(s.nextRepeat) {
+			d.publishClicks(s)
+			s.repeats++
+			s.nextRepeat = s.nextRepeat.Add(d.buttonCfg.holdRepeat)
+			d.Publish(ButtonHoldRepeat, s.repeats)
+		}
+		return
+	}
+
+	if s.clicks > 0 && !now.Before(s.clicksEnd) {
+		d.publishClicks(s)
+	}
+}
+
+// change accepts the read value as new state of the button
+func (d *ButtonDriver) change(s *buttonState, now time.Time) {
+	s.stable = s.raw
+	d.update(s.stable)
+
+	if s.stable != d.buttonCfg.defaultState {
+		s.pressedAt = now
+		s.longPressed = false
+		s.repeats = 0
+		s.nextRepeat = now.Add(d.buttonCfg.longPress + d.buttonCfg.holdRepeat)
+		return
+	}
+
+	if s.longPressed || s.repeats > 0 {
+		// a held button is not a click
+		return
+	}
+
+	s.clicks++
+	if d.buttonCfg.multiClickGap <= 0 {
+		d.publishClicks(s)
+		return
+	}
+	s.clicksEnd = now.Add(d.buttonCfg.multiClickGap)
+}
+
+// publishClicks publishes the pending clicks, if any
+func (d *ButtonDriver) publishClicks(s *buttonState) {
+	switch s.clicks {
+	case 0:
+		return
+	case 1:
+		d.Publish(ButtonClick, s.clicks)
+	case 2:
+		d.Publish(ButtonDoubleClick, s.clicks)
+	default:
+		d.Publish(ButtonMultiClick, s.clicks)
+	}
+	s.clicks = 0
 }
 
 func (d *ButtonDriver) update(newValue int) {
@@ -167,6 +394,22 @@ func (o buttonDefaultStateOption) String() string {
 	return "default state option for buttons"
 }
 
+func (o buttonDebounceOption) String() string {
+	return "debounce option for buttons"
+}
+
+func (o buttonLongPressOption) String() string {
+	return "long press option for buttons"
+}
+
+func (o buttonHoldRepeatOption) String() string {
+	return "hold repeat option for buttons"
+}
+
+func (o buttonMultiClickOption) String() string {
+	return "multi click option for buttons"
+}
+
 func (o buttonReadIntervalOption) apply(cfg *buttonConfiguration) {
 	cfg.readInterval = time.Duration(o)
 }
@@ -174,3 +417,19 @@ func (o buttonReadIntervalOption) apply(cfg *buttonConfiguration) {
 func (o buttonDefaultStateOption) apply(cfg *buttonConfiguration) {
 	cfg.defaultState = int(o)
 }
+
+func (o buttonDebounceOption) apply(cfg *buttonConfiguration) {
+	cfg.debounce = time.Duration(o)
+}
+
+func (o buttonLongPressOption) apply(cfg *buttonConfiguration) {
+	cfg.longPress = time.Duration(o)
+}
+
+func (o buttonHoldRepeatOption) apply(cfg *buttonConfiguration) {
+	cfg.holdRepeat = time.Duration(o)
+}
+
+func (o buttonMultiClickOption) apply(cfg *buttonConfiguration) {
+	cfg.multiClickGap = time.Duration(o)
+}
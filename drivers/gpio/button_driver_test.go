@@ -1,3 +1,4 @@
+//nolint:forcetypeassert // ok here
 package gpio
 
 import (
@@ -69,6 +70,21 @@ func TestNewButtonDriver_options(t *testing.T) {
 	assert.PanicsWithValue(t, "'scaler option for analog actuators' can not be applied on 'crazy'", panicFunc)
 }
 
+func TestButton_gestureOptions(t *testing.T) {
+	// arrange
+	cfg := buttonConfiguration{}
+	// act
+	WithButtonDebounce(20 * time.Millisecond).apply(&cfg)
+	WithButtonLongPress(time.Second).apply(&cfg)
+	WithButtonHoldRepeat(200 * time.Millisecond).apply(&cfg)
+	WithButtonMultiClick(300 * time.Millisecond).apply(&cfg)
+	// assert
+	assert.Equal(t, 20*time.Millisecond, cfg.debounce)
+	assert.Equal(t, time.Second, cfg.longPress)
+	assert.Equal(t, 200*time.Millisecond, cfg.holdRepeat)
+	assert.Equal(t, 300*time.Millisecond, cfg.multiClickGap)
+}
+
 func TestButton_WithButtonDefaultState(t *testing.T) {
 	// arrange
 	const myDefaultState = 5 // only for test, usually it would be 0 or 1
@@ -234,3 +250,192 @@ func TestButtonActive(t *testing.T) {
 		})
 	}
 }
+
+func TestButtonStart_WithEdgeNotification(t *testing.T) {
+	// arrange
+	a := newGpioTestEdgeAdaptor()
+	a.digitalReadFunc = func(string) (int, error) {
+		assert.Fail(t, "the pin should not be polled")
+		return 0, nil
+	}
+	d := NewButtonDriver(a, "1")
+	pushed := make(chan interface{}, 1)
+	// act
+	require.NoError(t, d.Start())
+	_ = d.Once(ButtonPush, func(data interface{}) { pushed <- data })
+	require.True(t, a.edge("1", 1))
+	// assert
+	select {
+	case data := <-pushed:
+		assert.Equal(t, 1, data)
+		assert.True(t, d.Active())
+	case <-time.After(buttonTestDelay * time.Millisecond):
+		assert.Fail(t, "Button Event \"Push\" was not published")
+	}
+	// act & assert: the notification is stopped on halt
+	require.NoError(t, d.Halt())
+	assert.False(t, a.notified("1"))
+}
+
+func TestButtonStart_FallbackToPolling(t *testing.T) {
+	// arrange
+	a := newGpioTestEdgeAdaptor()
+	a.notifyErr = fmt.Errorf("not supported")
+	d := NewButtonDriver(a, "1")
+	pushed := make(chan interface{}, 1)
+	// act
+	require.NoError(t, d.Start())
+	_ = d.Once(ButtonPush, func(data interface{}) { pushed <- data })
+	// assert
+	select {
+	case data := <-pushed:
+		assert.Equal(t, 1, data)
+	case <-time.After(buttonTestDelay * time.Millisecond):
+		assert.Fail(t, "Button Event \"Push\" was not published")
+	}
+	assert.Nil(t, d.edges)
+	require.NoError(t, d.Halt())
+}
+
+func TestButtonGestures(t *testing.T) {
+	type input struct {
+		at  time.Duration
+		val int
+	}
+	type event struct {
+		name string
+		data int
+	}
+	const ms = time.Millisecond
+	tests := map[string]struct {
+		opts   []interface{}
+		inputs []input
+		end    time.Duration
+		want   []event
+	}{
+		"click": {
+			inputs: []input{{0, 1}, {50 * ms, 0}},
+			want:   []event{{ButtonPush, 1}, {ButtonRelease, 0}, {ButtonClick, 1}},
+		},
+		"click_with_default_state": {
+			opts:   []interface{}{WithButtonDefaultState(1)},
+			inputs: []input{{0, 0}, {50 * ms, 1}},
+			want:   []event{{ButtonPush, 0}, {ButtonRelease, 1}, {ButtonClick, 1}},
+		},
+		"debounce": {
+			opts: []interface{}{WithButtonDebounce(20 * ms)},
+			inputs: []input{
+				{0, 1}, {2 * ms, 0}, {4 * ms, 1},
+				{100 * ms, 0}, {102 * ms, 1}, {104 * ms, 0},
+			},
+			end:  200 * ms,
+			want: []event{{ButtonPush, 1}, {ButtonRelease, 0}, {ButtonClick, 1}},
+		},
+		"single_click_with_multi_click": {
+			opts:   []interface{}{WithButtonMultiClick(100 * ms)},
+			inputs: []input{{0, 1}, {30 * ms, 0}},
+			end:    300 * ms,
+			want:   []event{{ButtonPush, 1}, {ButtonRelease, 0}, {ButtonClick, 1}},
+		},
+		"double_click": {
+			opts:   []interface{}{WithButtonMultiClick(100 * ms)},
+			inputs: []input{{0, 1}, {30 * ms, 0}, {80 * ms, 1}, {110 * ms, 0}},
+			end:    300 * ms,
+			want: []event{
+				{ButtonPush, 1}, {ButtonRelease, 0}, {ButtonPush, 1}, {ButtonRelease, 0},
+				{ButtonDoubleClick, 2},
+			},
+		},
+		"multi_click": {
+			opts:   []interface{}{WithButtonMultiClick(100 * ms)},
+			inputs: []input{{0, 1}, {30 * ms, 0}, {80 * ms, 1}, {110 * ms, 0}, {160 * ms, 1}, {190 * ms, 0}},
+			end:    400 * ms,
+			want: []event{
+				{ButtonPush, 1}, {ButtonRelease, 0}, {ButtonPush, 1}, {ButtonRelease, 0}, {ButtonPush, 1},
+				{ButtonRelease, 0}, {ButtonMultiClick, 3},
+			},
+		},
+		"clicks_separated_by_gap": {
+			opts:   []interface{}{WithButtonMultiClick(100 * ms)},
+			inputs: []input{{0, 1}, {30 * ms, 0}, {200 * ms, 1}, {230 * ms, 0}},
+			end:    400 * ms,
+			want: []event{
+				{ButtonPush, 1}, {ButtonRelease, 0}, {ButtonClick, 1},
+				{ButtonPush, 1}, {ButtonRelease, 0}, {ButtonClick, 1},
+			},
+		},
+		"long_press": {
+			opts:   []interface{}{WithButtonLongPress(500 * ms)},
+			inputs: []input{{0, 1}, {800 * ms, 0}},
+			want:   []event{{ButtonPush, 1}, {ButtonLongPress, 1}, {ButtonRelease, 0}},
+		},
+		"short_press_with_long_press": {
+			opts:   []interface{}{WithButtonLongPress(500 * ms)},
+			inputs: []input{{0, 1}, {300 * ms, 0}},
+			want:   []event{{ButtonPush, 1}, {ButtonRelease, 0}, {ButtonClick, 1}},
+		},
+		"hold_repeat_after_long_press": {
+			opts:   []interface{}{WithButtonLongPress(500 * ms), WithButtonHoldRepeat(100 * ms)},
+			inputs: []input{{0, 1}, {760 * ms, 0}},
+			want: []event{
+				{ButtonPush, 1}, {ButtonLongPress, 1}, {ButtonHoldRepeat, 1}, {ButtonHoldRepeat, 2},
+				{ButtonRelease, 0},
+			},
+		},
+		"hold_repeat": {
+			opts:   []interface{}{WithButtonHoldRepeat(100 * ms)},
+			inputs: []input{{0, 1}, {250 * ms, 0}},
+			want:   []event{{ButtonPush, 1}, {ButtonHoldRepeat, 1}, {ButtonHoldRepeat, 2}, {ButtonRelease, 0}},
+		},
+		"long_press_publishes_pending_clicks": {
+			opts:   []interface{}{WithButtonMultiClick(100 * ms), WithButtonLongPress(500 * ms)},
+			inputs: []input{{0, 1}, {30 * ms, 0}, {80 * ms, 1}, {700 * ms, 0}},
+			end:    900 * ms,
+			want: []event{
+				{ButtonPush, 1}, {ButtonRelease, 0}, {ButtonPush, 1}, {ButtonClick, 1}, {ButtonLongPress, 1},
+				{ButtonRelease, 0},
+			},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d := NewButtonDriver(newGpioTestAdaptor(), "1", tc.opts...)
+			d.Eventer = gobot.NewEventer()
+			events := d.Subscribe()
+			s := buttonState{raw: d.buttonCfg.defaultState, stable: d.buttonCfg.defaultState}
+			start := time.Now()
+			// advance processes all timed gestures up to the given time, like the reading go routine
+			advance := func(to time.Duration) {
+				for {
+					deadline, ok := d.nextDeadline(&s)
+					if !ok || deadline.After(start.Add(to)) {
+						return
+					}
+					d.elapse(&s, deadline)
+				}
+			}
+			// act
+			for _, in := range tc.inputs {
+				advance(in.at)
+				d.input(&s, in.val, start.Add(in.at))
+			}
+			advance(tc.end)
+			// assert
+			var got []event
+			for range tc.want {
+				select {
+				case evt := <-events:
+					got = append(got, event{name: evt.Name, data: evt.Data.(int)})
+				case <-time.After(buttonTestDelay * time.Millisecond):
+				}
+			}
+			assert.Equal(t, tc.want, got)
+			select {
+			case evt := <-events:
+				assert.Fail(t, "unexpected event", "%s: %v", evt.Name, evt.Data)
+			case <-time.After(10 * time.Millisecond):
+			}
+		})
+	}
+}
@@ -0,0 +1,441 @@
+package gpio
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/system"
+)
+
+const (
+	// the host starts the transmission by pulling the line low, the sensor answers with a low and high pulse of 80 us
+	// each, followed by 40 bits, each bit starts with a low pulse of 50 us and the duration of the following high pulse
+	// (26-28 us or 70 us) gives the value of the bit
+	dhtBitCount        = 40
+	dhtBitOneThreshold = 50 * time.Microsecond
+	// the transmission takes ~5 ms
+	dhtReceiveTimeout = 10 * time.Millisecond
+	// reading is typically much slower than the shortest pulse of 26 us, so polling works only on fast systems
+	dhtPollInputInterval = 5 * time.Microsecond
+	dhtDefaultRetries    = 3
+)
+
+// DHTModel is the type of the sensor
+type DHTModel int
+
+const (
+	// DHT11 is the sensor with a resolution of 1 %RH and 0.1 °C, readable each second
+	DHT11 DHTModel = iota + 1
+	// DHT22 is the sensor with a resolution of 0.1 %RH and 0.1 °C, readable each 2 seconds (same as AM2302)
+	DHT22
+)
+
+// dhtOptionApplier needs to be implemented by each configurable option type
+type dhtOptionApplier interface {
+	apply(cfg *dhtConfiguration)
+}
+
+// dhtConfiguration contains all changeable attributes of the driver.
+type dhtConfiguration struct {
+	retries        int
+	readInterval   time.Duration
+	useEdgePolling bool
+}
+
+// dhtRetriesOption is the type for applying another count of retries to the configuration
+type dhtRetriesOption int
+
+// dhtReadIntervalOption is the type for applying a cyclic read interval to the configuration
+type dhtReadIntervalOption time.Duration
+
+// dhtUseEdgePollingOption is the type for applying to use discrete edge polling instead pin edge detection
+// by "cdev" from gpiod.
+type dhtUseEdgePollingOption bool
+
+// dhtEdge is a detected edge of the data line
+type dhtEdge struct {
+	timestamp time.Duration
+	rising    bool
+}
+
+// DHTDriver is a driver for the humidity and temperature sensors DHT11 and DHT22 (AM2302), which are connected by a
+// single wire with a proprietary timing protocol.
+type DHTDriver struct {
+	*driver
+	dhtCfg        *dhtConfiguration
+	model         DHTModel
+	startDuration time.Duration // duration of the start signal
+	minInterval   time.Duration // minimal duration between two transmissions
+	pin           gobot.DigitalPinner
+	lastRead      time.Time
+	temperature   float64
+	humidity      float64
+	measureErr    error // error of the last measurement
+	edgeMutex     sync.Mutex
+	collecting    bool
+	edges         []dhtEdge
+	highPulses    int
+	received      chan struct{}
+	halt          chan struct{}
+	gobot.Eventer
+}
+
+// NewDHTDriver creates a new instance of the driver for a DHT11 or DHT22 (AM2302) sensor. The adaptor needs to
+// provide access to the pin (interface gobot.DigitalPinnerProvider). The edge detection with timestamps of the
+// "cdev" GPIO character device is used for measuring the pulses, for sysfs the edge polling can be activated.
+//
+// Datasheet: https://www.mouser.com/datasheet/2/737/dht-932870.pdf
+//
+// Supported options:
+//
+//	"WithName"
+//	"WithDHTRetries"
+//	"WithDHTCyclicRead"
+//	"WithDHTUseEdgePolling"
+//
+// Adds the following API Commands:
+//
+//	"Measure" - See DHTDriver.Measure
+func NewDHTDriver(a gobot.Adaptor, pin string, model DHTModel, opts ...interface{}) *DHTDriver {
+	d := &DHTDriver{
+		driver:        newDriver(a, "DHT", withPin(pin)),
+		dhtCfg:        &dhtConfiguration{retries: dhtDefaultRetries},
+		model:         model,
+		startDuration: 1100 * time.Microsecond,
+		minInterval:   2 * time.Second,
+		received:      make(chan struct{}, 1),
+		Eventer:       gobot.NewEventer(),
+	}
+	if model == DHT11 {
+		d.startDuration = 20 * time.Millisecond
+		d.minInterval = time.Second
+	}
+	d.afterStart = d.initialize
+	d.beforeHalt = d.shutdown
+
+	for _, opt := range opts {
+		switch o := opt.(type) {
+		case optionApplier:
+			o.apply(d.driverCfg)
+		case dhtOptionApplier:
+			o.apply(d.dhtCfg)
+		default:
+			panic(fmt.Sprintf("'%s' can not be applied on '%s'", opt, d.driverCfg.name))
+		}
+	}
+
+	d.AddCommand("Measure", func(params map[string]interface{}) interface{} {
+		err := d.Measure()
+		return map[string]interface{}{"temperature": d.Temperature(), "humidity": d.Humidity(), "err": err}
+	})
+
+	return d
+}
+
+// WithDHTRetries changes the count of retries on failed transmissions, e.g. a wrong checksum, from default 3 to the
+// given value. Please note, that each retry waits the minimal read interval of the sensor (1 s for DHT11, 2 s for
+// DHT22).
+func WithDHTRetries(retries int) dhtOptionApplier {
+	return dhtRetriesOption(retries)
+}
+
+// WithDHTCyclicRead adds an asynchronous cyclic reading with the given interval. The events "temperature" and
+// "humidity" are published on change, "error" is published if a reading fails after all retries.
+func WithDHTCyclicRead(interval time.Duration) dhtOptionApplier {
+	return dhtReadIntervalOption(interval)
+}
+
+// WithDHTUseEdgePolling uses discrete edge polling instead pin edge detection by "cdev" from gpiod. Please note, that
+// this works only on very fast systems, because the shortest pulse is 26 us.
+func WithDHTUseEdgePolling() dhtOptionApplier {
+	return dhtUseEdgePollingOption(true)
+}
+
+// Measure reads the temperature and humidity from the sensor, the values can be accessed afterwards by Temperature()
+// and Humidity(). The call waits until the minimal read interval of the sensor is elapsed since the last transmission
+// and retries on failed transmissions.
+func (d *DHTDriver) Measure() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.measure()
+}
+
+// Healthy returns the error of the last measurement, nil if it was successful or nothing was measured yet.
+func (d *DHTDriver) Healthy() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.measureErr
+}
+
+// Temperature returns the last measured temperature in °C, it does not start a measurement.
+func (d *DHTDriver) Temperature() float64 {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.temperature
+}
+
+// Humidity returns the last measured relative humidity in %, it does not start a measurement.
+func (d *DHTDriver) Humidity() float64 {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.humidity
+}
+
+// initialize gets the pin, activates the edge detection and starts the cyclic reading, if configured
+func (d *DHTDriver) initialize() error {
+	provider, ok := d.connection.(gobot.DigitalPinnerProvider)
+	if !ok {
+		return fmt.Errorf("the adaptor of '%s' does not provide access to digital pins", d.driverCfg.name)
+	}
+
+	pin, err := provider.DigitalPin(d.driverCfg.pin)
+	if err != nil {
+		return fmt.Errorf("error on get data pin: %v", err)
+	}
+
+	if err := pin.ApplyOptions(system.WithPinDirectionInput(), system.WithPinEventOnBothEdges(d.onEdge)); err != nil {
+		return fmt.Errorf("error on apply options for data pin: %v", err)
+	}
+	d.pin = pin
+
+	if d.dhtCfg.readInterval <= 0 {
+		return nil
+	}
+
+	d.AddEvent(Temperature)
+	d.AddEvent(Humidity)
+	d.AddEvent(Error)
+
+	d.halt = make(chan struct{})
+	go d.cyclicRead(d.halt)
+
+	return nil
+}
+
+// shutdown stops the cyclic reading
+func (d *DHTDriver) shutdown() error {
+	if d.halt != nil {
+		close(d.halt)
+		d.halt = nil
+	}
+
+	// note: Unexport() of the pin will be done on adaptor.Finalize()
+
+	return nil
+}
+
+func (d *DHTDriver) cyclicRead(halt chan struct{}) {
+	var oldTemperature, oldHumidity float64
+	timer := time.NewTimer(d.dhtCfg.readInterval)
+	timer.Stop()
+
+	for {
+		if err := d.Measure(); err != nil {
+			d.Publish(d.Event(Error), err)
+		} else {
+			temperature, humidity := d.Temperature(), d.Humidity()
+			if temperature != oldTemperature {
+				d.Publish(d.Event(Temperature), temperature)
+				oldTemperature = temperature
+			}
+			if humidity != oldHumidity {
+				d.Publish(d.Event(Humidity), humidity)
+				oldHumidity = humidity
+			}
+		}
+
+		timer.Reset(d.dhtCfg.readInterval)
+		select {
+		case <-timer.C:
+		case <-halt:
+			timer.Stop()
+			return
+		}
+	}
+}
+
+// measure does the transmission with retries, the mutex needs to be locked before
+func (d *DHTDriver) measure() error {
+	if d.pin == nil {
+		return fmt.Errorf("'%s' is not started", d.driverCfg.name)
+	}
+
+	var err error
+	for try := 0; try <= d.dhtCfg.retries; try++ {
+		var data [5]byte
+		if data, err = d.transmit(); err == nil {
+			d.humidity, d.temperature, err = d.convert(data)
+			if err == nil {
+				d.measureErr = nil
+				return nil
+			}
+		}
+	}
+
+	d.measureErr = fmt.Errorf("reading of '%s' failed after %d retries: %v", d.driverCfg.name, d.dhtCfg.retries, err)
+	return d.measureErr
+}
+
+// transmit sends the start signal and receives the 5 data bytes
+func (d *DHTDriver) transmit() ([5]byte, error) {
+	if wait := time.Until(d.lastRead.Add(d.minInterval)); wait > 0 {
+		time.Sleep(wait)
+	}
+
+	d.edgeMutex.Lock()
+	d.edges = d.edges[:0]
+	d.highPulses = 0
+	d.collecting = true
+	d.edgeMutex.Unlock()
+	select {
+	case <-d.received: // drop a signal of an older transmission
+	default:
+	}
+
+	defer func() {
+		d.edgeMutex.Lock()
+		d.collecting = false
+		d.edgeMutex.Unlock()
+	}()
+
+	if err := d.pin.ApplyOptions(system.WithPinDirectionOutput(0)); err != nil {
+		return [5]byte{}, err
+	}
+	time.Sleep(d.startDuration)
+
+	// release the line, the sensor answers immediately
+	inputOptions := []func(gobot.DigitalPinOptioner) bool{system.WithPinDirectionInput()}
+	if d.dhtCfg.useEdgePolling {
+		pollQuitChan := make(chan struct{})
+		defer close(pollQuitChan)
+		inputOptions = append(inputOptions, system.WithPinPollForEdgeDetection(dhtPollInputInterval, pollQuitChan))
+	}
+	err := d.pin.ApplyOptions(inputOptions...)
+	d.lastRead = time.Now()
+	if err != nil {
+		return [5]byte{}, err
+	}
+
+	select {
+	case <-d.received:
+	case <-time.After(dhtReceiveTimeout):
+	}
+
+	d.edgeMutex.Lock()
+	edges := append([]dhtEdge(nil), d.edges...)
+	d.edgeMutex.Unlock()
+
+	if len(edges) == 0 {
+		return [5]byte{}, fmt.Errorf("no response of the sensor on pin %s", d.driverCfg.pin)
+	}
+
+	return dhtDecode(edges)
+}
+
+// onEdge is the event handler of the data pin, it collects the edges while a transmission is running
+func (d *DHTDriver) onEdge(_ int, timestamp time.Duration, detectedEdge string, _ uint32, _ uint32) {
+	d.edgeMutex.Lock()
+	defer d.edgeMutex.Unlock()
+
+	if !d.collecting {
+		return
+	}
+
+	rising := detectedEdge == system.DigitalPinEventRisingEdge
+	if !rising && len(d.edges) > 0 && d.edges[len(d.edges)-1].rising {
+		d.highPulses++
+	}
+	d.edges = append(d.edges, dhtEdge{timestamp: timestamp, rising: rising})
+
+	// the answer of the sensor and all data bits are received
+	if d.highPulses == dhtBitCount+1 {
+		select {
+		case d.received <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// convert checks the plausibility of the data and converts it to humidity [%] and temperature [°C]
+//
+//nolint:nonamedreturns // sufficient here
+func (d *DHTDriver) convert(data [5]byte) (humidity float64, temperature float64, err error) {
+	if d.model == DHT11 {
+		humidity = float64(data[0]) + float64(data[1])/10
+		temperature = float64(data[2]) + float64(data[3]&0x7F)/10
+		if data[3]&0x80 != 0 {
+			temperature = -temperature
+		}
+	} else {
+		humidity = float64(uint16(data[0])<<8|uint16(data[1])) / 10
+		temperature = float64(uint16(data[2]&0x7F)<<8|uint16(data[3])) / 10
+		if data[2]&0x80 != 0 {
+			temperature = -temperature
+		}
+	}
+
+	if humidity > 100 {
+		return 0, 0, fmt.Errorf("implausible humidity %.1f %%", humidity)
+	}
+
+	return humidity, temperature, nil
+}
+
+// dhtDecode creates the 5 data bytes from the duration of the high pulses and verifies the checksum. The last 40 high
+// pulses are used, so a lost edge of the answer of the sensor does not matter.
+func dhtDecode(edges []dhtEdge) ([5]byte, error) {
+	var pulses []time.Duration
+	for i := 1; i < len(edges); i++ {
+		if edges[i-1].rising && !edges[i].rising {
+			pulses = append(pulses, edges[i].timestamp-edges[i-1].timestamp)
+		}
+	}
+
+	var data [5]byte
+	if len(pulses) < dhtBitCount {
+		return data, fmt.Errorf("only %d of %d bits received", len(pulses), dhtBitCount)
+	}
+
+	for i, pulse := range pulses[len(pulses)-dhtBitCount:] {
+		data[i/8] <<= 1
+		if pulse > dhtBitOneThreshold {
+			data[i/8] |= 1
+		}
+	}
+
+	if sum := data[0] + data[1] + data[2] + data[3]; sum != data[4] {
+		return data, fmt.Errorf("checksum mismatch, calculated 0x%02X, received 0x%02X", sum, data[4])
+	}
+
+	return data, nil
+}
+
+func (o dhtRetriesOption) String() string {
+	return "retries option for DHT"
+}
+
+func (o dhtReadIntervalOption) String() string {
+	return "read interval option for DHT"
+}
+
+func (o dhtUseEdgePollingOption) String() string {
+	return "use edge polling option for DHT"
+}
+
+func (o dhtRetriesOption) apply(cfg *dhtConfiguration) {
+	cfg.retries = int(o)
+}
+
+func (o dhtReadIntervalOption) apply(cfg *dhtConfiguration) {
+	cfg.readInterval = time.Duration(o)
+}
+
+func (o dhtUseEdgePollingOption) apply(cfg *dhtConfiguration) {
+	cfg.useEdgePolling = bool(o)
+}
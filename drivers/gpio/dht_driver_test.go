@@ -0,0 +1,397 @@
+//nolint:forcetypeassert // ok here
+package gpio
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/drivers/aio"
+	"gobot.io/x/gobot/v2/system"
+)
+
+var _ gobot.Driver = (*DHTDriver)(nil)
+
+// dhtTestRecordingDHT22 is a recorded transmission of a DHT22 with 65.2 %RH and 23.1 °C, the values are the durations
+// of the levels in microseconds: the line released by the host, the answer of the sensor (low, high), the 40 bits
+// (low, high) and the final low level
+var dhtTestRecordingDHT22 = []int{
+	27, 79, 81, 48, 23, 56, 23, 53, 27, 48, 27, 51, 23, 49, 26, 54,
+	68, 51, 23, 56, 71, 48, 29, 49, 24, 48, 27, 54, 68, 51, 68, 56,
+	29, 50, 25, 54, 24, 56, 23, 52, 27, 50, 23, 51, 25, 49, 27, 49,
+	27, 48, 27, 51, 71, 56, 71, 53, 71, 55, 25, 52, 24, 50, 73, 51,
+	68, 52, 72, 55, 25, 55, 70, 49, 68, 56, 71, 50, 29, 53, 69, 55,
+	26, 48, 73, 49,
+}
+
+// dhtTestEdges creates the edges from the durations of the levels, starting with the released (high) line
+func dhtTestEdges(levels []int) []gpioTestEdge {
+	var edges []gpioTestEdge
+	var at time.Duration
+	val := 1
+	for _, level := range levels {
+		at += time.Duration(level) * time.Microsecond
+		val = 1 - val
+		edges = append(edges, gpioTestEdge{at: at, val: val})
+	}
+	return edges
+}
+
+// dhtTestWaveform creates the waveform of an ideal transmission of the given bytes
+func dhtTestWaveform(data ...byte) []gpioTestEdge {
+	levels := []int{30, 80, 80}
+	for _, b := range data {
+		for i := 7; i >= 0; i-- {
+			high := 27
+			if b&(1<<i) != 0 {
+				high = 70
+			}
+			levels = append(levels, 50, high)
+		}
+	}
+	return dhtTestEdges(append(levels, 50))
+}
+
+func initTestDHTDriverWithStubbedAdaptor(model DHTModel, opts ...interface{}) (*DHTDriver, *gpioTestWaveformPin) {
+	a := newGpioTestAdaptor()
+	pin := a.addWaveformPin("7")
+	d := NewDHTDriver(a, "7", model, opts...)
+	d.minInterval = 0
+	if err := d.Start(); err != nil {
+		panic(err)
+	}
+	return d, pin
+}
+
+func TestNewDHTDriver(t *testing.T) {
+	// arrange
+	a := newGpioTestAdaptor()
+	// act
+	d := NewDHTDriver(a, "7", DHT22)
+	// assert
+	assert.IsType(t, &DHTDriver{}, d)
+	// assert: gpio.driver attributes
+	assert.NotNil(t, d.driver)
+	assert.True(t, strings.HasPrefix(d.driverCfg.name, "DHT"))
+	assert.Equal(t, "7", d.Pin())
+	assert.Equal(t, a, d.connection)
+	assert.NotNil(t, d.Commander)
+	assert.NotNil(t, d.mutex)
+	assert.NotNil(t, d.Command("Measure"))
+	// assert: driver specific attributes
+	assert.Equal(t, DHT22, d.model)
+	assert.Equal(t, dhtDefaultRetries, d.dhtCfg.retries)
+	assert.Equal(t, time.Duration(0), d.dhtCfg.readInterval)
+	assert.False(t, d.dhtCfg.useEdgePolling)
+	assert.Equal(t, 1100*time.Microsecond, d.startDuration)
+	assert.Equal(t, 2*time.Second, d.minInterval)
+	assert.NotNil(t, d.Eventer)
+	// act
+	d = NewDHTDriver(a, "7", DHT11)
+	// assert
+	assert.Equal(t, 20*time.Millisecond, d.startDuration)
+	assert.Equal(t, time.Second, d.minInterval)
+}
+
+func TestNewDHTDriver_options(t *testing.T) {
+	// This is a general test, that options are applied in constructor by using the common WithName() option, least one
+	// option of this driver and one of another driver (which should lead to panic). Further tests for options can also
+	// be done by call of "WithOption(val).apply(cfg)".
+	// arrange
+	const myName = "climate"
+	panicFunc := func() {
+		NewDHTDriver(newGpioTestAdaptor(), "1", DHT22, WithName("crazy"),
+			aio.WithActuatorScaler(func(float64) int { return 0 }))
+	}
+	// act
+	d := NewDHTDriver(newGpioTestAdaptor(), "1", DHT22, WithName(myName), WithDHTRetries(5),
+		WithDHTCyclicRead(3*time.Second), WithDHTUseEdgePolling())
+	// assert
+	assert.Equal(t, myName, d.Name())
+	assert.Equal(t, 5, d.dhtCfg.retries)
+	assert.Equal(t, 3*time.Second, d.dhtCfg.readInterval)
+	assert.True(t, d.dhtCfg.useEdgePolling)
+	assert.PanicsWithValue(t, "'scaler option for analog actuators' can not be applied on 'crazy'", panicFunc)
+}
+
+func TestDHTStart(t *testing.T) {
+	tests := map[string]struct {
+		adaptor gobot.Adaptor
+		wantErr string
+	}{
+		"ok": {
+			adaptor: func() gobot.Adaptor { a := newGpioTestAdaptor(); a.addWaveformPin("7"); return a }(),
+		},
+		"error_no_pin_provider": {
+			adaptor: &gpioTestBareAdaptor{},
+			wantErr: "does not provide access to digital pins",
+		},
+		"error_pin_not_found": {
+			adaptor: newGpioTestAdaptor(),
+			wantErr: "error on get data pin: pin '7' not found",
+		},
+		"error_apply_options": {
+			adaptor: func() gobot.Adaptor {
+				a := newGpioTestAdaptor()
+				a.addWaveformPin("7").applyErr = errors.New("apply error")
+				return a
+			}(),
+			wantErr: "error on apply options for data pin: apply error",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d := NewDHTDriver(tc.adaptor, "7", DHT22)
+			// act
+			err := d.Start()
+			// assert
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			pin := d.pin.(*gpioTestWaveformPin)
+			assert.Equal(t, system.IN, pin.currentDirection())
+			assert.NotNil(t, pin.edgeHandler)
+			require.NoError(t, d.Halt())
+		})
+	}
+}
+
+func TestDHTMeasure(t *testing.T) {
+	tests := map[string]struct {
+		model           DHTModel
+		opts            []interface{}
+		waveforms       [][]gpioTestEdge
+		wantTemperature float64
+		wantHumidity    float64
+		wantErr         string
+	}{
+		"recorded_dht22": {
+			model:           DHT22,
+			waveforms:       [][]gpioTestEdge{dhtTestEdges(dhtTestRecordingDHT22)},
+			wantTemperature: 23.1,
+			wantHumidity:    65.2,
+		},
+		"dht22_negative_temperature": {
+			model:           DHT22,
+			waveforms:       [][]gpioTestEdge{dhtTestWaveform(0x01, 0x90, 0x80, 0x65, 0x76)},
+			wantTemperature: -10.1,
+			wantHumidity:    40,
+		},
+		"dht11": {
+			model:           DHT11,
+			waveforms:       [][]gpioTestEdge{dhtTestWaveform(45, 0, 22, 5, 72)},
+			wantTemperature: 22.5,
+			wantHumidity:    45,
+		},
+		"answer_of_sensor_lost": {
+			model:           DHT22,
+			waveforms:       [][]gpioTestEdge{dhtTestEdges(dhtTestRecordingDHT22)[3:]},
+			wantTemperature: 23.1,
+			wantHumidity:    65.2,
+		},
+		"retry_after_checksum_mismatch": {
+			model: DHT22,
+			waveforms: [][]gpioTestEdge{
+				dhtTestWaveform(0x02, 0x8C, 0x00, 0xE7, 0x74),
+				dhtTestEdges(dhtTestRecordingDHT22),
+			},
+			wantTemperature: 23.1,
+			wantHumidity:    65.2,
+		},
+		"retry_after_lost_bits": {
+			model: DHT22,
+			waveforms: [][]gpioTestEdge{
+				dhtTestEdges(dhtTestRecordingDHT22)[:40],
+				dhtTestEdges(dhtTestRecordingDHT22),
+			},
+			wantTemperature: 23.1,
+			wantHumidity:    65.2,
+		},
+		"error_checksum_mismatch": {
+			model: DHT22,
+			opts:  []interface{}{WithDHTRetries(1)},
+			waveforms: [][]gpioTestEdge{
+				dhtTestWaveform(0x02, 0x8C, 0x00, 0xE7, 0x74),
+				dhtTestWaveform(0x02, 0x8C, 0x00, 0xE7, 0x74),
+			},
+			wantErr: "failed after 1 retries: checksum mismatch, calculated 0x75, received 0x74",
+		},
+		"error_implausible_humidity": {
+			model:     DHT22,
+			opts:      []interface{}{WithDHTRetries(0)},
+			waveforms: [][]gpioTestEdge{dhtTestWaveform(0x03, 0xF0, 0x00, 0xE7, 0xDA)},
+			wantErr:   "implausible humidity 100.8 %",
+		},
+		"error_no_response": {
+			model:   DHT22,
+			opts:    []interface{}{WithDHTRetries(0)},
+			wantErr: "no response of the sensor on pin 7",
+		},
+		"error_lost_bits": {
+			model:     DHT22,
+			opts:      []interface{}{WithDHTRetries(0)},
+			waveforms: [][]gpioTestEdge{dhtTestEdges(dhtTestRecordingDHT22)[:60]},
+			wantErr:   "only 29 of 40 bits received",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, pin := initTestDHTDriverWithStubbedAdaptor(tc.model, tc.opts...)
+			pin.addWaveforms(tc.waveforms...)
+			// act
+			err := d.Measure()
+			pin.replays.Wait()
+			// assert
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				require.Equal(t, err, d.Healthy())
+				return
+			}
+			require.NoError(t, err)
+			require.NoError(t, d.Healthy())
+			assert.InDelta(t, tc.wantTemperature, d.Temperature(), 1e-9)
+			assert.InDelta(t, tc.wantHumidity, d.Humidity(), 1e-9)
+			// assert: the start signal is written and the line is released afterwards
+			assert.Equal(t, make([]int, len(tc.waveforms)), pin.writtenValues())
+			assert.Equal(t, system.IN, pin.currentDirection())
+		})
+	}
+}
+
+func TestDHTMeasure_notStarted(t *testing.T) {
+	// arrange
+	d := NewDHTDriver(newGpioTestAdaptor(), "7", DHT22)
+	// act
+	err := d.Measure()
+	// assert
+	require.ErrorContains(t, err, "is not started")
+}
+
+func TestDHTMeasure_waitsMinInterval(t *testing.T) {
+	// arrange
+	d, pin := initTestDHTDriverWithStubbedAdaptor(DHT22)
+	d.minInterval = 30 * time.Millisecond
+	pin.addWaveforms(dhtTestEdges(dhtTestRecordingDHT22), dhtTestEdges(dhtTestRecordingDHT22))
+	require.NoError(t, d.Measure())
+	start := time.Now()
+	// act
+	err := d.Measure()
+	// assert
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), 25*time.Millisecond)
+}
+
+func TestDHTMeasure_edgePolling(t *testing.T) {
+	// arrange
+	d, pin := initTestDHTDriverWithStubbedAdaptor(DHT22, WithDHTUseEdgePolling())
+	pin.addWaveforms(dhtTestEdges(dhtTestRecordingDHT22))
+	// act
+	err := d.Measure()
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, dhtPollInputInterval, pin.pollInterval)
+}
+
+func TestDHTMeasureCommand(t *testing.T) {
+	// arrange
+	d, pin := initTestDHTDriverWithStubbedAdaptor(DHT22)
+	pin.addWaveforms(dhtTestEdges(dhtTestRecordingDHT22))
+	// act
+	result := d.Command("Measure")(nil).(map[string]interface{})
+	// assert
+	assert.Nil(t, result["err"])
+	assert.InDelta(t, 23.1, result["temperature"].(float64), 1e-9)
+	assert.InDelta(t, 65.2, result["humidity"].(float64), 1e-9)
+}
+
+func TestDHTCyclicRead(t *testing.T) {
+	// arrange
+	a := newGpioTestAdaptor()
+	pin := a.addWaveformPin("7")
+	pin.addWaveforms(dhtTestEdges(dhtTestRecordingDHT22))
+	d := NewDHTDriver(a, "7", DHT22, WithDHTCyclicRead(10*time.Millisecond), WithDHTRetries(0))
+	d.minInterval = 0
+	temperatures := make(chan float64, 1)
+	humidities := make(chan float64, 1)
+	errs := make(chan error, 1)
+	_ = d.Once(Temperature, func(data interface{}) { temperatures <- data.(float64) })
+	_ = d.Once(Humidity, func(data interface{}) { humidities <- data.(float64) })
+	_ = d.Once(Error, func(data interface{}) { errs <- data.(error) })
+	// act
+	require.NoError(t, d.Start())
+	defer func() { _ = d.Halt() }()
+	// assert
+	select {
+	case temperature := <-temperatures:
+		assert.InDelta(t, 23.1, temperature, 1e-9)
+	case <-time.After(time.Second):
+		require.Fail(t, "temperature event was not published")
+	}
+	select {
+	case humidity := <-humidities:
+		assert.InDelta(t, 65.2, humidity, 1e-9)
+	case <-time.After(time.Second):
+		require.Fail(t, "humidity event was not published")
+	}
+	// assert: the next reading fails, because no more waveforms
+	select {
+	case err := <-errs:
+		require.ErrorContains(t, err, "no response of the sensor")
+	case <-time.After(time.Second):
+		require.Fail(t, "error event was not published")
+	}
+}
+
+func TestDHTDecode(t *testing.T) {
+	tests := map[string]struct {
+		edges   []dhtEdge
+		want    [5]byte
+		wantErr string
+	}{
+		"error_too_few_bits": {
+			edges: func() []dhtEdge {
+				var edges []dhtEdge
+				for i := 0; i < 39; i++ {
+					at := time.Duration(i) * 100 * time.Microsecond
+					edges = append(edges, dhtEdge{timestamp: at, rising: true},
+						dhtEdge{timestamp: at + 70*time.Microsecond, rising: false})
+				}
+				return edges
+			}(),
+			wantErr: "only 39 of 40 bits received",
+		},
+		"all_zero": {
+			edges: func() []dhtEdge {
+				var edges []dhtEdge
+				for i := 0; i < 40; i++ {
+					at := time.Duration(i) * 100 * time.Microsecond
+					edges = append(edges, dhtEdge{timestamp: at, rising: true},
+						dhtEdge{timestamp: at + 26*time.Microsecond, rising: false})
+				}
+				return edges
+			}(),
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// act
+			got, err := dhtDecode(tc.edges)
+			// assert
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -54,6 +54,11 @@ type EasyDriver struct {
 //	"WithEasyDirectionPin"
 //	"WithEasyEnablePin"
 //	"WithEasySleepPin"
+//	"WithStepperAcceleration"
+//	"WithStepperProfile"
+//	"WithStepperMaxSpeed"
+//	"WithStepperSoftLimits"
+//	"WithStepperHomeSwitch"
 func NewEasyDriver(a DigitalWriter, anglePerStep float32, stepPin string, opts ...interface{}) *EasyDriver {
 	if anglePerStep <= 0 {
 		panic("angle per step needs to be greater than zero")
@@ -76,10 +81,17 @@ func NewEasyDriver(a DigitalWriter, anglePerStep float32, stepPin string, opts .
 	}
 	d.stepFunc = d.onePinStepping
 	d.sleepFunc = d.sleepWithSleepPin
+	d.directionFunc = d.writeDirection
 	d.beforeHalt = d.shutdown
-
-	// 1/4 of max speed. Not too fast, not too slow
-	d.speedRpm = d.MaxSpeed() / 4
+	d.usedPins = func() map[string]string {
+		return map[string]string{
+			d.stepPin:                  gobot.PinFunctionGpio,
+			d.easyCfg.dirPin:           gobot.PinFunctionGpio,
+			d.easyCfg.enPin:            gobot.PinFunctionGpio,
+			d.easyCfg.sleepPin:         gobot.PinFunctionGpio,
+			d.stepperCfg.homeSwitchPin: gobot.PinFunctionGpio,
+		}
+	}
 
 	for _, opt := range opts {
 		switch o := opt.(type) {
@@ -87,14 +99,20 @@ func NewEasyDriver(a DigitalWriter, anglePerStep float32, stepPin string, opts .
 			o.apply(d.driverCfg)
 		case easyOptionApplier:
 			o.apply(d.easyCfg)
+		case stepperOptionApplier:
+			o.apply(&d.stepperCfg)
 		default:
-			oNames := []string{"WithEasyDirectionPin", "WithEasyEnablePin", "WithEasySleepPin"}
+			oNames := []string{"WithEasyDirectionPin", "WithEasyEnablePin", "WithEasySleepPin", "WithStepperAcceleration",
+				"WithStepperProfile", "WithStepperMaxSpeed", "WithStepperSoftLimits", "WithStepperHomeSwitch"}
 			msg := fmt.Sprintf("'%s' can not be applied on '%s', consider to use one of the options instead: %s",
 				opt, d.driverCfg.name, strings.Join(oNames, ", "))
 			panic(msg)
 		}
 	}
 
+	// 1/4 of max speed. Not too fast, not too slow
+	d.speedRpm = d.MaxSpeed() / 4
+
 	return d
 }
 
@@ -125,13 +143,20 @@ func (d *EasyDriver) SetDirection(direction string) error {
 			direction, StepperDriverForward, StepperDriverBackward)
 	}
 
-	writeVal := byte(0) // low is forward
-	if direction == StepperDriverBackward {
-		writeVal = 1 // high is backward
-	}
+	return d.writeDirection(direction)
+}
 
-	if err := d.digitalWrite(d.easyCfg.dirPin, writeVal); err != nil {
-		return err
+// writeDirection writes the direction pin, if set, and stores the direction for the next step
+func (d *EasyDriver) writeDirection(direction string) error {
+	if d.easyCfg.dirPin != "" {
+		writeVal := byte(0) // low is forward
+		if direction == StepperDriverBackward {
+			writeVal = 1 // high is backward
+		}
+
+		if err := d.digitalWrite(d.easyCfg.dirPin, writeVal); err != nil {
+			return err
+		}
 	}
 
 	// ensure that write of variable can not interfere with read in step()
@@ -214,15 +239,17 @@ func (d *EasyDriver) onePinStepping() error {
 		return err
 	}
 
-	time.Sleep(d.getDelayPerStep())
+	time.Sleep(d.currentDelayPerStep())
 	if err := d.digitalWrite(d.stepPin, 1); err != nil {
 		return err
 	}
 
 	if d.direction == StepperDriverForward {
 		d.stepNum++
+		d.position++
 	} else {
 		d.stepNum--
+		d.position--
 	}
 
 	return nil
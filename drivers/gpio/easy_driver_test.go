@@ -71,7 +71,9 @@ func TestNewEasyDriver_options(t *testing.T) {
 	assert.Equal(t, dirPin, d.easyCfg.dirPin)
 	assert.Equal(t, myName, d.Name())
 	assert.PanicsWithValue(t, "'scaler option for analog actuators' can not be applied on 'crazy', "+
-		"consider to use one of the options instead: WithEasyDirectionPin, WithEasyEnablePin, WithEasySleepPin", panicFunc)
+		"consider to use one of the options instead: WithEasyDirectionPin, WithEasyEnablePin, WithEasySleepPin, "+
+		"WithStepperAcceleration, WithStepperProfile, WithStepperMaxSpeed, WithStepperSoftLimits, WithStepperHomeSwitch",
+		panicFunc)
 }
 
 func TestEasy_WithEasyEnablePin(t *testing.T) {
@@ -804,3 +806,20 @@ func TestEasyWake_IsSleeping(t *testing.T) {
 		})
 	}
 }
+
+func TestEasyMoveTo_CurrentPosition(t *testing.T) {
+	// arrange
+	a := newGpioTestAdaptor()
+	d := NewEasyDriver(a, 0.5, "1", WithEasyDirectionPin("2"), WithStepperMaxSpeed(240), WithStepperAcceleration(480))
+	d.SetCurrentPosition(3)
+	a.written = nil
+	// act
+	err := d.MoveTo(-2)
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, uint(60), d.speedRpm)
+	assert.Equal(t, -2, d.CurrentPosition())
+	assert.Equal(t, "backward", d.direction)
+	require.Len(t, a.written, 11)
+	assert.Equal(t, gpioTestWritten{pin: "2", val: 1}, a.written[0])
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"sort"
 	"sync"
 
 	"gobot.io/x/gobot/v2"
@@ -31,10 +32,56 @@ const (
 	ButtonRelease = "release"
 	// ButtonPush event
 	ButtonPush = "push"
+	// ButtonClick event
+	ButtonClick = "click"
+	// ButtonDoubleClick event
+	ButtonDoubleClick = "double-click"
+	// ButtonMultiClick event
+	ButtonMultiClick = "multi-click"
+	// ButtonLongPress event
+	ButtonLongPress = "long-press"
+	// ButtonHoldRepeat event
+	ButtonHoldRepeat = "hold-repeat"
 	// MotionDetected event
 	MotionDetected = "motion-detected"
 	// MotionStopped event
 	MotionStopped = "motion-stopped"
+	// StepperMoveDone event
+	StepperMoveDone = "move-done"
+	// ServoReached event
+	ServoReached = "reached"
+	// RotaryEncoderClockwise event
+	RotaryEncoderClockwise = "clockwise"
+	// RotaryEncoderCounterClockwise event
+	RotaryEncoderCounterClockwise = "counter-clockwise"
+	// RotaryEncoderPositionChanged event
+	RotaryEncoderPositionChanged = "position-changed"
+	// Temperature event
+	Temperature = "temperature"
+	// Humidity event
+	Humidity = "humidity"
+	// Data event
+	Data = "data"
+	// Weight event
+	Weight = "weight"
+	// KeypadKeyDown event
+	KeypadKeyDown = "key-down"
+	// KeypadKeyUp event
+	KeypadKeyUp = "key-up"
+	// KeypadKeyHold event
+	KeypadKeyHold = "key-hold"
+	// KeypadGhosting event
+	KeypadGhosting = "ghosting"
+	// KeypadEntry event
+	KeypadEntry = "entry"
+	// KeypadEntryTimeout event
+	KeypadEntryTimeout = "entry-timeout"
+	// IRCodeReceived event
+	IRCodeReceived = "code"
+	// IRCodeRepeated event
+	IRCodeRepeated = "repeat"
+	// IRRawPulses event
+	IRRawPulses = "raw-pulses"
 )
 
 // PwmWriter interface represents an Adaptor which has Pwm capabilities
@@ -57,6 +104,23 @@ type DigitalReader interface {
 	DigitalRead(pin string) (val int, err error)
 }
 
+// DigitalEdgeNotifier interface represents an Adaptor which can notify about changes of a digital input, so no polling
+// is needed. A nil handler stops the notification.
+type DigitalEdgeNotifier interface {
+	NotifyDigitalEdges(pin string, handler func(val int)) error
+}
+
+// PositionReader interface represents a driver, which provides the position of an axis in steps, e.g. a rotary encoder
+// or a stepper. It can be used as feedback for a closed-loop control of a motor.
+type PositionReader interface {
+	CurrentPosition() int
+}
+
+// SysLedOpener interface represents an Adaptor which provides access to the LEDs of the board by the Linux LED class
+type SysLedOpener interface {
+	OpenLed(name string) (gobot.LedSystemDevicer, error)
+}
+
 // optionApplier needs to be implemented by each configurable option type
 type optionApplier interface {
 	apply(cfg *configuration)
@@ -80,6 +144,8 @@ type driver struct {
 	connection gobot.Adaptor
 	afterStart func() error
 	beforeHalt func() error
+	// usedPins returns all pins used by the driver and their function, see gobot.PinClaimer
+	usedPins func() map[string]string
 	gobot.Commander
 	mutex *sync.Mutex // mutex often needed to ensure that write-read sequences are not interrupted
 }
@@ -99,6 +165,7 @@ func newDriver(a gobot.Adaptor, name string, opts ...interface{}) *driver {
 		Commander:  gobot.NewCommander(),
 		mutex:      &sync.Mutex{},
 	}
+	d.usedPins = func() map[string]string { return map[string]string{d.driverCfg.pin: gobot.PinFunctionGpio} }
 
 	for _, opt := range opts {
 		switch o := opt.(type) {
@@ -149,24 +216,77 @@ func (d *driver) Connection() gobot.Connection {
 	return nil
 }
 
-// Start initializes the gpio device.
+// Start initializes the gpio device. If the connection records the usage of pins, the pins of the driver are
+// claimed and an error is returned on conflicts with other drivers or buses.
 func (d *driver) Start() error {
 	d.mutex.Lock()
 	defer d.mutex.Unlock()
 
-	// currently there is nothing to do here for the driver
+	if err := d.claimPins(); err != nil {
+		return err
+	}
+
+	if err := d.afterStart(); err != nil {
+		d.releasePins()
+		return err
+	}
 
-	return d.afterStart()
+	return nil
 }
 
-// Halt halts the gpio device.
+// Halt halts the gpio device and releases the claimed pins.
 func (d *driver) Halt() error {
 	d.mutex.Lock()
 	defer d.mutex.Unlock()
 
-	// currently there is nothing to do after halt for the driver
+	err := d.beforeHalt()
+	d.releasePins()
+
+	return err
+}
+
+// claimPins is a helper function to claim all used pins, if the connection implements gobot.PinClaimer
+func (d *driver) claimPins() error {
+	claimer, ok := d.connection.(gobot.PinClaimer)
+	if !ok {
+		return nil
+	}
+
+	pinsWithFunction := d.usedPins()
+	pins := make([]string, 0, len(pinsWithFunction))
+	for pin := range pinsWithFunction {
+		if pin != "" {
+			pins = append(pins, pin)
+		}
+	}
+	sort.Strings(pins)
+
+	for _, pin := range pins {
+		if err := claimer.ClaimPin(pin, pinsWithFunction[pin], d); err != nil {
+			claimer.ReleasePins(d)
+			return err
+		}
+	}
+
+	return nil
+}
 
-	return d.beforeHalt()
+// releasePins is a helper function to release all claimed pins, if the connection implements gobot.PinClaimer
+func (d *driver) releasePins() {
+	if claimer, ok := d.connection.(gobot.PinClaimer); ok {
+		claimer.ReleasePins(d)
+	}
+}
+
+// directPinsUsage is a helper function to create the used pins of drivers, which are composed by direct pin drivers
+func directPinsUsage(pinDrivers ...*DirectPinDriver) map[string]string {
+	pins := make(map[string]string, len(pinDrivers))
+	for _, pd := range pinDrivers {
+		if pd != nil {
+			pins[pd.Pin()] = gobot.PinFunctionGpio
+		}
+	}
+	return pins
 }
 
 // digitalRead is a helper function with check that the connection implements DigitalReader
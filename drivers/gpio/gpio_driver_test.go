@@ -86,3 +86,65 @@ func TestHalt(t *testing.T) {
 	// act, assert
 	require.EqualError(t, d.Halt(), "before halt error")
 }
+
+// gpioTestClaimerAdaptor records the claimed pins and rejects pins claimed by another owner
+type gpioTestClaimerAdaptor struct {
+	*gpioTestAdaptor
+	owners    map[string]string
+	functions map[string]string
+}
+
+func (a *gpioTestClaimerAdaptor) ClaimPin(pin string, function string, owner gobot.PinOwner) error {
+	if o, ok := a.owners[pin]; ok && o != owner.Name() {
+		return fmt.Errorf("pin '%s' already used by '%s'", pin, o)
+	}
+	a.owners[pin] = owner.Name()
+	a.functions[pin] = function
+	return nil
+}
+
+func (a *gpioTestClaimerAdaptor) ReleasePins(owner gobot.PinOwner) {
+	for pin, o := range a.owners {
+		if o == owner.Name() {
+			delete(a.owners, pin)
+			delete(a.functions, pin)
+		}
+	}
+}
+
+func TestStartHaltClaimPins(t *testing.T) {
+	// arrange
+	a := &gpioTestClaimerAdaptor{
+		gpioTestAdaptor: newGpioTestAdaptor(),
+		owners:          make(map[string]string),
+		functions:       make(map[string]string),
+	}
+	servo := NewServoDriver(a, "12", WithName("servo"))
+	led := NewLedDriver(a, "12", WithName("led"))
+	rgb := NewRgbLedDriver(a, "3", "5", "7", WithName("rgb"))
+	// act & assert
+	require.NoError(t, servo.Start())
+	require.NoError(t, rgb.Start())
+	assert.Equal(t, map[string]string{"12": "servo", "3": "rgb", "5": "rgb", "7": "rgb"}, a.owners)
+	assert.Equal(t, map[string]string{"12": "pwm", "3": "pwm", "5": "pwm", "7": "pwm"}, a.functions)
+	require.EqualError(t, led.Start(), "pin '12' already used by 'servo'")
+	require.NoError(t, servo.Halt())
+	assert.Equal(t, map[string]string{"3": "rgb", "5": "rgb", "7": "rgb"}, a.owners)
+	require.NoError(t, led.Start())
+	assert.Equal(t, "gpio", a.functions["12"])
+}
+
+func TestStartClaimPinsReleasedOnConflict(t *testing.T) {
+	// arrange
+	a := &gpioTestClaimerAdaptor{
+		gpioTestAdaptor: newGpioTestAdaptor(),
+		owners:          map[string]string{"5": "other"},
+		functions:       map[string]string{"5": "i2c"},
+	}
+	d := NewStepperDriver(a, [4]string{"3", "5", "7", "11"}, StepperModes.SinglePhaseStepping, 32, WithName("stepper"))
+	// act
+	err := d.Start()
+	// assert
+	require.EqualError(t, err, "pin '5' already used by 'other'")
+	assert.Equal(t, map[string]string{"5": "other"}, a.owners)
+}
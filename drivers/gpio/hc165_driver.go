@@ -0,0 +1,245 @@
+package gpio
+
+import (
+	"fmt"
+	"time"
+
+	"gobot.io/x/gobot/v2"
+)
+
+// hc165OptionApplier needs to be implemented by each configurable option type
+type hc165OptionApplier interface {
+	apply(cfg *hc165Configuration)
+}
+
+// hc165Configuration contains all changeable attributes of the driver.
+type hc165Configuration struct {
+	loadActiveHigh bool
+	maxAge         time.Duration
+}
+
+// hc165LoadActiveHighOption is the type for applying the inverted level of the parallel load pin to the configuration
+type hc165LoadActiveHighOption bool
+
+// hc165MaxAgeOption is the type for applying the maximum age of the read inputs to the configuration
+type hc165MaxAgeOption time.Duration
+
+// HC165Driver is a driver for the parallel-in, serial-out shift register 74HC165, which is mostly used as input pin
+// expander. The CD4021 can be used the same way with the option "WithHC165LoadActiveHigh". Multiple devices can be
+// daisy-chained, the serial output (QH) is connected to the serial input (SER) of the previous device, so the inputs
+// of the device nearest to the controller are read first. The driver implements the interfaces gobot.Adaptor and
+// DigitalReader, so it can be used as connection for other gpio drivers, e.g. ButtonDriver. The virtual pins are named
+// "0" (input A of the first device) up to "7" (input H of the first device), "8" (input A of the second device) and
+// so on.
+//
+// Datasheet: https://www.ti.com/lit/ds/symlink/sn74hc165.pdf
+type HC165Driver struct {
+	*driver
+	hc165Cfg  *hc165Configuration
+	pinData   *DirectPinDriver
+	pinClock  *DirectPinDriver
+	pinLoad   *DirectPinDriver
+	count     uint
+	inputs    []byte    // one byte for each device, the lowest bit is input A
+	readTime  time.Time // time of the last shift of all inputs
+	batches   int       // count of currently running batches
+	batchRead bool      // inputs are already read in the current batch
+}
+
+// NewHC165Driver return a new HC165Driver given a gobot.Connection, the pins for serial data (QH), clock (CLK) and
+// parallel load (SH/LD) and how many devices are daisy-chained. The clock inhibit pin (CLK INH) needs to be connected
+// to ground.
+//
+// Supported options:
+//
+//	"WithName"
+//	"WithHC165LoadActiveHigh"
+//	"WithHC165MaxAge"
+func NewHC165Driver(
+	a gobot.Connection,
+	dataPin, clockPin, loadPin string,
+	count uint,
+	opts ...interface{},
+) *HC165Driver {
+	d := &HC165Driver{
+		driver:   newDriver(a, "HC165"),
+		hc165Cfg: &hc165Configuration{},
+		pinData:  NewDirectPinDriver(a, dataPin),
+		pinClock: NewDirectPinDriver(a, clockPin),
+		pinLoad:  NewDirectPinDriver(a, loadPin),
+		count:    count,
+		inputs:   make([]byte, count),
+	}
+	d.afterStart = d.initialize
+	d.usedPins = func() map[string]string { return directPinsUsage(d.pinData, d.pinClock, d.pinLoad) }
+
+	for _, opt := range opts {
+		switch o := opt.(type) {
+		case optionApplier:
+			o.apply(d.driverCfg)
+		case hc165OptionApplier:
+			o.apply(d.hc165Cfg)
+		default:
+			panic(fmt.Sprintf("'%s' can not be applied on '%s'", opt, d.driverCfg.name))
+		}
+	}
+
+	return d
+}
+
+// WithHC165LoadActiveHigh is used for devices with an inverted parallel load pin, e.g. the CD4021 (P/S pin).
+func WithHC165LoadActiveHigh() hc165OptionApplier {
+	return hc165LoadActiveHighOption(true)
+}
+
+// WithHC165MaxAge changes the maximum age of the inputs from default 0 to the given value. Reads within this time
+// after the last shift use the already read inputs. This reduces the count of shifts, if multiple drivers poll their
+// inputs, e.g. some ButtonDriver with the same poll interval.
+func WithHC165MaxAge(maxAge time.Duration) hc165OptionApplier {
+	return hc165MaxAgeOption(maxAge)
+}
+
+// Connect implements the interface gobot.Adaptor, so the driver can be used as connection for other gpio drivers. It
+// just starts the driver, so it needs to be added to the connections of the robot after the adaptor of the pins.
+func (d *HC165Driver) Connect() error {
+	return d.Start()
+}
+
+// Finalize implements the interface gobot.Adaptor and halts the driver.
+func (d *HC165Driver) Finalize() error {
+	return d.Halt()
+}
+
+// DigitalRead returns the state of the given virtual pin (interface DigitalReader). The inputs of all devices are
+// loaded and shifted, except the call is done within Batch() after a previous read or the inputs are not older than
+// the configured maximum age.
+func (d *HC165Driver) DigitalRead(pin string) (int, error) {
+	index, err := shiftRegisterPinIndex(pin, d.count)
+	if err != nil {
+		return 0, err
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if err := d.update(); err != nil {
+		return 0, err
+	}
+
+	return int(d.inputs[index/8]>>(index%8)) & 1, nil
+}
+
+// ReadInputs returns the inputs of all devices, one byte for each device beginning with the device nearest to the
+// controller. The lowest bit is input A. The inputs are loaded and shifted with the same rules like for DigitalRead().
+func (d *HC165Driver) ReadInputs() ([]byte, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if err := d.update(); err != nil {
+		return nil, err
+	}
+
+	return append([]byte(nil), d.inputs...), nil
+}
+
+// Batch calls the given function. Only the first read within loads and shifts the inputs, all other reads use the
+// same inputs. This reduces the count of shifts and all inputs are read at the same time. Batches can be nested.
+func (d *HC165Driver) Batch(f func() error) error {
+	d.mutex.Lock()
+	if d.batches == 0 {
+		d.batchRead = false
+	}
+	d.batches++
+	d.mutex.Unlock()
+
+	defer func() {
+		d.mutex.Lock()
+		d.batches--
+		d.mutex.Unlock()
+	}()
+
+	return f()
+}
+
+// initialize sets the pins to the idle state
+func (d *HC165Driver) initialize() error {
+	if d.count == 0 {
+		return fmt.Errorf("at least one device is needed for '%s'", d.driverCfg.name)
+	}
+	if err := d.pinClock.Off(); err != nil {
+		return err
+	}
+
+	d.readTime = time.Time{}
+
+	return d.pinLoad.DigitalWrite(d.loadLevel(false))
+}
+
+// update loads and shifts the inputs, if needed
+func (d *HC165Driver) update() error {
+	if d.batches > 0 && d.batchRead {
+		return nil
+	}
+	if d.hc165Cfg.maxAge > 0 && !d.readTime.IsZero() && time.Since(d.readTime) <= d.hc165Cfg.maxAge {
+		return nil
+	}
+
+	if err := d.pinLoad.DigitalWrite(d.loadLevel(true)); err != nil {
+		return err
+	}
+	if err := d.pinLoad.DigitalWrite(d.loadLevel(false)); err != nil {
+		return err
+	}
+
+	// the input H of the device nearest to the controller is available first
+	inputs := make([]byte, len(d.inputs))
+	for i := range inputs {
+		for bit := 7; bit >= 0; bit-- {
+			val, err := d.pinData.DigitalRead()
+			if err != nil {
+				return err
+			}
+			if val > 0 {
+				inputs[i] |= 1 << bit
+			}
+			if err := d.pinClock.On(); err != nil {
+				return err
+			}
+			if err := d.pinClock.Off(); err != nil {
+				return err
+			}
+		}
+	}
+
+	copy(d.inputs, inputs)
+	d.readTime = time.Now()
+	if d.batches > 0 {
+		d.batchRead = true
+	}
+
+	return nil
+}
+
+// loadLevel returns the level of the parallel load pin for load or shift
+func (d *HC165Driver) loadLevel(load bool) byte {
+	if load == d.hc165Cfg.loadActiveHigh {
+		return 1
+	}
+	return 0
+}
+
+func (o hc165LoadActiveHighOption) String() string {
+	return "load active high option for HC165"
+}
+
+func (o hc165MaxAgeOption) String() string {
+	return "maximum age option for HC165"
+}
+
+func (o hc165LoadActiveHighOption) apply(cfg *hc165Configuration) {
+	cfg.loadActiveHigh = bool(o)
+}
+
+func (o hc165MaxAgeOption) apply(cfg *hc165Configuration) {
+	cfg.maxAge = time.Duration(o)
+}
@@ -0,0 +1,277 @@
+package gpio
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/drivers/aio"
+)
+
+var (
+	_ gobot.Driver  = (*HC165Driver)(nil)
+	_ gobot.Adaptor = (*HC165Driver)(nil)
+	_ DigitalReader = (*HC165Driver)(nil)
+)
+
+// hc165TestDevices simulates daisy-chained devices on data pin "1", clock pin "2" and load pin "3"
+type hc165TestDevices struct {
+	inputs     []byte // inputs of the devices, beginning with the device nearest to the controller
+	activeHigh bool
+	shifted    []byte // bits in the shift register chain, the first one is at the serial output
+	clock      byte
+	loads      int
+}
+
+func (s *hc165TestDevices) write(pin string, val byte) error {
+	switch pin {
+	case "2":
+		if s.clock == 0 && val == 1 && len(s.shifted) > 0 {
+			s.shifted = append(s.shifted[1:], 0)
+		}
+		s.clock = val
+	case "3":
+		if (val == 1) == s.activeHigh {
+			s.loads++
+			s.shifted = nil
+			for _, in := range s.inputs {
+				for bit := 7; bit >= 0; bit-- {
+					s.shifted = append(s.shifted, (in>>bit)&1)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (s *hc165TestDevices) read(pin string) (int, error) {
+	if pin != "1" || len(s.shifted) == 0 {
+		return 0, nil
+	}
+	return int(s.shifted[0]), nil
+}
+
+func initTestHC165DriverWithStubbedAdaptor(
+	inputs []byte,
+	opts ...interface{},
+) (*HC165Driver, *gpioTestAdaptor, *hc165TestDevices) {
+	a := newGpioTestAdaptor()
+	devices := &hc165TestDevices{inputs: inputs}
+	a.digitalWriteFunc = devices.write
+	a.digitalReadFunc = devices.read
+	d := NewHC165Driver(a, "1", "2", "3", uint(len(inputs)), opts...)
+	devices.activeHigh = d.hc165Cfg.loadActiveHigh
+	if err := d.Start(); err != nil {
+		panic(err)
+	}
+	return d, a, devices
+}
+
+func TestNewHC165Driver(t *testing.T) {
+	// arrange
+	a := newGpioTestAdaptor()
+	// act
+	d := NewHC165Driver(a, "10", "20", "30", 2)
+	// assert
+	assert.IsType(t, &HC165Driver{}, d)
+	// assert: gpio.driver attributes
+	require.NotNil(t, d.driver)
+	assert.True(t, strings.HasPrefix(d.driverCfg.name, "HC165"))
+	assert.Equal(t, a, d.connection)
+	assert.NotNil(t, d.afterStart)
+	assert.NotNil(t, d.beforeHalt)
+	assert.NotNil(t, d.Commander)
+	assert.NotNil(t, d.mutex)
+	// assert: driver specific attributes
+	assert.NotNil(t, d.pinData)
+	assert.NotNil(t, d.pinClock)
+	assert.NotNil(t, d.pinLoad)
+	assert.Equal(t, uint(2), d.count)
+	assert.False(t, d.hc165Cfg.loadActiveHigh)
+	assert.Equal(t, time.Duration(0), d.hc165Cfg.maxAge)
+}
+
+func TestNewHC165Driver_options(t *testing.T) {
+	// This is a general test, that options are applied in constructor by using the common WithName() option, least one
+	// option of this driver and one of another driver (which should lead to panic). Further tests for options can also
+	// be done by call of "WithOption(val).apply(cfg)".
+	// arrange
+	const (
+		myName = "inputs"
+	)
+	panicFunc := func() {
+		NewHC165Driver(newGpioTestAdaptor(), "1", "2", "3", 1, WithName("crazy"),
+			aio.WithActuatorScaler(func(float64) int { return 0 }))
+	}
+	// act
+	d := NewHC165Driver(newGpioTestAdaptor(), "1", "2", "3", 1, WithName(myName), WithHC165LoadActiveHigh(),
+		WithHC165MaxAge(5*time.Millisecond))
+	// assert
+	assert.Equal(t, myName, d.Name())
+	assert.True(t, d.hc165Cfg.loadActiveHigh)
+	assert.Equal(t, 5*time.Millisecond, d.hc165Cfg.maxAge)
+	assert.PanicsWithValue(t, "'scaler option for analog actuators' can not be applied on 'crazy'", panicFunc)
+}
+
+func TestHC165Start(t *testing.T) {
+	tests := map[string]struct {
+		opts     []interface{}
+		wantLoad byte
+	}{
+		"active_low": {wantLoad: 1},
+		"active_high": {
+			opts:     []interface{}{WithHC165LoadActiveHigh()},
+			wantLoad: 0,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := newGpioTestAdaptor()
+			d := NewHC165Driver(a, "1", "2", "3", 1, tc.opts...)
+			// act
+			err := d.Start()
+			// assert
+			require.NoError(t, err)
+			assert.Equal(t, []gpioTestWritten{{pin: "2", val: 0}, {pin: "3", val: tc.wantLoad}}, a.written)
+		})
+	}
+}
+
+func TestHC165Start_error(t *testing.T) {
+	// arrange
+	d := NewHC165Driver(newGpioTestAdaptor(), "1", "2", "3", 0)
+	// act
+	err := d.Connect()
+	// assert
+	require.ErrorContains(t, err, "at least one device is needed for 'HC165")
+}
+
+func TestHC165DigitalRead(t *testing.T) {
+	tests := map[string]struct {
+		opts    []interface{}
+		pin     string
+		want    int
+		wantErr string
+	}{
+		"input_a_first_device": {pin: "0", want: 1},
+		"input_b_first_device": {pin: "1", want: 0},
+		"input_h_first_device": {pin: "7", want: 1},
+		"input_e_second_device": {
+			pin:  "12",
+			want: 1,
+		},
+		"cd4021": {
+			opts: []interface{}{WithHC165LoadActiveHigh()},
+			pin:  "12",
+			want: 1,
+		},
+		"error_pin": {
+			pin:     "-1",
+			wantErr: "'-1' is not a valid pin, use 0..15",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, _, _ := initTestHC165DriverWithStubbedAdaptor([]byte{0x81, 0x10}, tc.opts...)
+			// act
+			got, err := d.DigitalRead(tc.pin)
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestHC165DigitalRead_error(t *testing.T) {
+	// arrange
+	d, a, _ := initTestHC165DriverWithStubbedAdaptor([]byte{0x01})
+	a.digitalReadFunc = func(string) (int, error) { return 0, errors.New("read error") }
+	// act
+	_, err := d.DigitalRead("0")
+	// assert
+	require.EqualError(t, err, "read error")
+}
+
+func TestHC165ReadInputs(t *testing.T) {
+	// arrange
+	d, _, devices := initTestHC165DriverWithStubbedAdaptor([]byte{0x5A, 0xC3, 0x01})
+	// act
+	got, err := d.ReadInputs()
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x5A, 0xC3, 0x01}, got)
+	assert.Equal(t, 1, devices.loads)
+}
+
+func TestHC165Batch(t *testing.T) {
+	// arrange
+	d, _, devices := initTestHC165DriverWithStubbedAdaptor([]byte{0x03})
+	var got []int
+	// act: inputs are changed after the first read
+	err := d.Batch(func() error {
+		for _, pin := range []string{"0", "1", "2"} {
+			val, err := d.DigitalRead(pin)
+			if err != nil {
+				return err
+			}
+			got = append(got, val)
+			devices.inputs[0] = 0x04
+		}
+		return nil
+	})
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 1, 0}, got)
+	assert.Equal(t, 1, devices.loads)
+	// assert: next read outside the batch loads again
+	val, err := d.DigitalRead("2")
+	require.NoError(t, err)
+	assert.Equal(t, 1, val)
+	assert.Equal(t, 2, devices.loads)
+}
+
+func TestHC165MaxAge(t *testing.T) {
+	// arrange
+	d, _, devices := initTestHC165DriverWithStubbedAdaptor([]byte{0x01}, WithHC165MaxAge(time.Hour))
+	// act
+	val1, err1 := d.DigitalRead("0")
+	devices.inputs[0] = 0x00
+	val2, err2 := d.DigitalRead("0")
+	// assert
+	require.NoError(t, err1)
+	require.NoError(t, err2)
+	assert.Equal(t, 1, val1)
+	assert.Equal(t, 1, val2)
+	assert.Equal(t, 1, devices.loads)
+}
+
+func TestHC165AsConnection(t *testing.T) {
+	// arrange
+	d, a, devices := initTestHC165DriverWithStubbedAdaptor([]byte{0x00, 0x00})
+	button := NewButtonDriver(d, "13", WithButtonPollInterval(time.Millisecond))
+	require.NoError(t, button.Start())
+	defer func() { _ = button.Halt() }()
+	pushed := make(chan struct{}, 1)
+	_ = button.Once(ButtonPush, func(interface{}) { pushed <- struct{}{} })
+	// act
+	a.mtx.Lock()
+	devices.inputs[1] = 0x20
+	a.mtx.Unlock()
+	// assert
+	select {
+	case <-pushed:
+	case <-time.After(time.Second):
+		t.Errorf("button push not detected")
+	}
+}
@@ -0,0 +1,194 @@
+package gpio
+
+import (
+	"fmt"
+	"strconv"
+
+	"gobot.io/x/gobot/v2"
+)
+
+// HC595Driver is a driver for the serial-in, parallel-out shift register 74HC595, which is mostly used as output pin
+// expander. Multiple devices can be daisy-chained, the serial output (Q7S) is connected to the serial input (DS) of
+// the next device. The driver implements the interfaces gobot.Adaptor and DigitalWriter, so it can be used as
+// connection for other gpio drivers, e.g. LedDriver or RelayDriver. The virtual pins are named "0" (Q0 of the first
+// device) up to "7" (Q7 of the first device), "8" (Q0 of the second device) and so on.
+//
+// Datasheet: https://www.ti.com/lit/ds/symlink/sn74hc595.pdf
+type HC595Driver struct {
+	*driver
+	pinData  *DirectPinDriver
+	pinClock *DirectPinDriver
+	pinLatch *DirectPinDriver
+	count    uint
+	outputs  []byte // one byte for each device, the lowest bit is Q0
+	latched  []byte // outputs, which are currently latched on the devices
+	batches  int    // count of currently running batches
+}
+
+// NewHC595Driver return a new HC595Driver given a gobot.Connection, the pins for serial data (DS), shift clock (SHCP)
+// and latch clock (STCP) and how many devices are daisy-chained. The output enable pin (OE) needs to be connected to
+// ground and the master reset pin (MR) to VCC.
+//
+// Supported options:
+//
+//	"WithName"
+func NewHC595Driver(
+	a gobot.Connection,
+	dataPin, clockPin, latchPin string,
+	count uint,
+	opts ...interface{},
+) *HC595Driver {
+	d := &HC595Driver{
+		driver:   newDriver(a, "HC595", opts...),
+		pinData:  NewDirectPinDriver(a, dataPin),
+		pinClock: NewDirectPinDriver(a, clockPin),
+		pinLatch: NewDirectPinDriver(a, latchPin),
+		count:    count,
+		outputs:  make([]byte, count),
+	}
+	d.afterStart = d.initialize
+	d.usedPins = func() map[string]string { return directPinsUsage(d.pinData, d.pinClock, d.pinLatch) }
+
+	return d
+}
+
+// Connect implements the interface gobot.Adaptor, so the driver can be used as connection for other gpio drivers. It
+// just starts the driver, so it needs to be added to the connections of the robot after the adaptor of the pins.
+func (d *HC595Driver) Connect() error {
+	return d.Start()
+}
+
+// Finalize implements the interface gobot.Adaptor and halts the driver.
+func (d *HC595Driver) Finalize() error {
+	return d.Halt()
+}
+
+// DigitalWrite changes the output of the given virtual pin (interface DigitalWriter). The outputs of all devices
+// are shifted and latched immediately, except the call is done within Batch().
+func (d *HC595Driver) DigitalWrite(pin string, val byte) error {
+	index, err := shiftRegisterPinIndex(pin, d.count)
+	if err != nil {
+		return err
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if val > 0 {
+		d.outputs[index/8] |= 1 << (index % 8)
+	} else {
+		d.outputs[index/8] &^= 1 << (index % 8)
+	}
+
+	return d.update()
+}
+
+// WriteOutputs changes the outputs of all devices, one byte for each device beginning with the first device in the
+// chain. The lowest bit is Q0. Missing bytes are used as zero. The outputs are shifted and latched immediately, except
+// the call is done within Batch().
+func (d *HC595Driver) WriteOutputs(outputs []byte) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	for i := range d.outputs {
+		d.outputs[i] = 0
+		if i < len(outputs) {
+			d.outputs[i] = outputs[i]
+		}
+	}
+
+	return d.update()
+}
+
+// Outputs returns a copy of the outputs of all devices, including changes not latched yet.
+func (d *HC595Driver) Outputs() []byte {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return append([]byte(nil), d.outputs...)
+}
+
+// Batch calls the given function and collects all output changes done within. Afterwards the outputs are shifted and
+// latched all at once, if changed. This reduces the count of shifts and all outputs will change at the same time.
+// Batches can be nested, the outputs are latched at the end of the outermost batch. An error of the given function
+// is returned without latching.
+func (d *HC595Driver) Batch(f func() error) error {
+	d.mutex.Lock()
+	d.batches++
+	d.mutex.Unlock()
+
+	fErr := f()
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.batches--
+	if fErr != nil {
+		return fErr
+	}
+
+	return d.update()
+}
+
+// initialize switches off all outputs
+func (d *HC595Driver) initialize() error {
+	if d.count == 0 {
+		return fmt.Errorf("at least one device is needed for '%s'", d.driverCfg.name)
+	}
+	if err := d.pinClock.Off(); err != nil {
+		return err
+	}
+	if err := d.pinLatch.Off(); err != nil {
+		return err
+	}
+
+	d.latched = nil
+	for i := range d.outputs {
+		d.outputs[i] = 0
+	}
+
+	return d.update()
+}
+
+// update shifts and latches the outputs, if changed and no batch is running
+func (d *HC595Driver) update() error {
+	if d.batches > 0 || (d.latched != nil && string(d.latched) == string(d.outputs)) {
+		return nil
+	}
+
+	// the first shifted bit reaches Q7 of the last device in the chain
+	for i := len(d.outputs) - 1; i >= 0; i-- {
+		for bit := 7; bit >= 0; bit-- {
+			if err := d.pinData.DigitalWrite((d.outputs[i] >> bit) & 1); err != nil {
+				return err
+			}
+			if err := d.pinClock.On(); err != nil {
+				return err
+			}
+			if err := d.pinClock.Off(); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := d.pinLatch.On(); err != nil {
+		return err
+	}
+	if err := d.pinLatch.Off(); err != nil {
+		return err
+	}
+
+	d.latched = append(d.latched[:0], d.outputs...)
+
+	return nil
+}
+
+// shiftRegisterPinIndex converts the virtual pin name to the index of the output or input
+func shiftRegisterPinIndex(pin string, count uint) (int, error) {
+	index, err := strconv.Atoi(pin)
+	if err != nil || index < 0 || index >= int(count)*8 {
+		return 0, fmt.Errorf("'%s' is not a valid pin, use 0..%d", pin, int(count)*8-1)
+	}
+
+	return index, nil
+}
@@ -0,0 +1,255 @@
+package gpio
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/drivers/aio"
+)
+
+var (
+	_ gobot.Driver  = (*HC595Driver)(nil)
+	_ gobot.Adaptor = (*HC595Driver)(nil)
+	_ DigitalWriter = (*HC595Driver)(nil)
+)
+
+func initTestHC595DriverWithStubbedAdaptor(count uint) (*HC595Driver, *gpioTestAdaptor) {
+	a := newGpioTestAdaptor()
+	d := NewHC595Driver(a, "1", "2", "3", count)
+	if err := d.Start(); err != nil {
+		panic(err)
+	}
+	a.written = nil
+	return d, a
+}
+
+// hc595TestLatched decodes the written values of data pin "1", clock pin "2" and latch pin "3" to the latched bytes,
+// the bytes are in shift order, so the last device in the chain comes first
+func hc595TestLatched(written []gpioTestWritten) [][]byte {
+	var latched [][]byte
+	var bits []byte
+	var data, clock, latch byte
+	for _, w := range written {
+		switch w.pin {
+		case "1":
+			data = w.val
+		case "2":
+			if clock == 0 && w.val == 1 {
+				bits = append(bits, data)
+			}
+			clock = w.val
+		case "3":
+			if latch == 0 && w.val == 1 {
+				shifted := make([]byte, len(bits)/8)
+				for i, bit := range bits {
+					shifted[i/8] = shifted[i/8]<<1 | bit
+				}
+				latched = append(latched, shifted)
+				bits = nil
+			}
+			latch = w.val
+		}
+	}
+	return latched
+}
+
+func TestNewHC595Driver(t *testing.T) {
+	// arrange
+	a := newGpioTestAdaptor()
+	// act
+	d := NewHC595Driver(a, "10", "20", "30", 2)
+	// assert
+	assert.IsType(t, &HC595Driver{}, d)
+	// assert: gpio.driver attributes
+	require.NotNil(t, d.driver)
+	assert.True(t, strings.HasPrefix(d.driverCfg.name, "HC595"))
+	assert.Equal(t, a, d.connection)
+	assert.NotNil(t, d.afterStart)
+	assert.NotNil(t, d.beforeHalt)
+	assert.NotNil(t, d.Commander)
+	assert.NotNil(t, d.mutex)
+	// assert: driver specific attributes
+	assert.NotNil(t, d.pinData)
+	assert.NotNil(t, d.pinClock)
+	assert.NotNil(t, d.pinLatch)
+	assert.Equal(t, uint(2), d.count)
+	assert.Equal(t, []byte{0x00, 0x00}, d.outputs)
+}
+
+func TestNewHC595Driver_options(t *testing.T) {
+	// This is a general test, that options are applied in constructor by using the common WithName() option and one
+	// option of another driver (which should lead to panic).
+	// arrange
+	const (
+		myName = "outputs"
+	)
+	panicFunc := func() {
+		NewHC595Driver(newGpioTestAdaptor(), "1", "2", "3", 1, WithName("crazy"),
+			aio.WithActuatorScaler(func(float64) int { return 0 }))
+	}
+	// act
+	d := NewHC595Driver(newGpioTestAdaptor(), "1", "2", "3", 1, WithName(myName))
+	// assert
+	assert.Equal(t, myName, d.Name())
+	assert.PanicsWithValue(t, "'scaler option for analog actuators' can not be applied on 'crazy'", panicFunc)
+}
+
+func TestHC595Start(t *testing.T) {
+	// arrange
+	a := newGpioTestAdaptor()
+	d := NewHC595Driver(a, "1", "2", "3", 2)
+	// act
+	err := d.Start()
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{{0x00, 0x00}}, hc595TestLatched(a.written))
+}
+
+func TestHC595Start_error(t *testing.T) {
+	// arrange
+	d := NewHC595Driver(newGpioTestAdaptor(), "1", "2", "3", 0)
+	// act
+	err := d.Start()
+	// assert
+	require.ErrorContains(t, err, "at least one device is needed for 'HC595")
+}
+
+func TestHC595ConnectFinalize(t *testing.T) {
+	// arrange
+	a := newGpioTestAdaptor()
+	d := NewHC595Driver(a, "1", "2", "3", 1)
+	// act & assert
+	require.NoError(t, d.Connect())
+	assert.Equal(t, [][]byte{{0x00}}, hc595TestLatched(a.written))
+	require.NoError(t, d.Finalize())
+}
+
+func TestHC595DigitalWrite(t *testing.T) {
+	tests := map[string]struct {
+		pin         string
+		val         byte
+		wantLatched [][]byte
+		wantErr     string
+	}{
+		"first_device": {
+			pin:         "0",
+			val:         1,
+			wantLatched: [][]byte{{0x00, 0x01}},
+		},
+		"second_device": {
+			pin:         "15",
+			val:         1,
+			wantLatched: [][]byte{{0x80, 0x00}},
+		},
+		"unchanged": {
+			pin: "3",
+			val: 0,
+		},
+		"error_pin_too_big": {
+			pin:     "16",
+			wantErr: "'16' is not a valid pin, use 0..15",
+		},
+		"error_pin_no_number": {
+			pin:     "Q1",
+			wantErr: "'Q1' is not a valid pin, use 0..15",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, a := initTestHC595DriverWithStubbedAdaptor(2)
+			// act
+			err := d.DigitalWrite(tc.pin, tc.val)
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.wantLatched, hc595TestLatched(a.written))
+		})
+	}
+}
+
+func TestHC595DigitalWrite_error(t *testing.T) {
+	// arrange
+	d, a := initTestHC595DriverWithStubbedAdaptor(1)
+	a.simulateWriteError = true
+	// act
+	err := d.DigitalWrite("1", 1)
+	// assert: the change is shifted again on next write
+	require.EqualError(t, err, "write error")
+	a.simulateWriteError = false
+	require.NoError(t, d.DigitalWrite("1", 1))
+	assert.Equal(t, [][]byte{{0x02}}, hc595TestLatched(a.written))
+}
+
+func TestHC595WriteOutputs(t *testing.T) {
+	// arrange
+	d, a := initTestHC595DriverWithStubbedAdaptor(3)
+	// act
+	err := d.WriteOutputs([]byte{0x12, 0x34})
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x12, 0x34, 0x00}, d.Outputs())
+	assert.Equal(t, [][]byte{{0x00, 0x34, 0x12}}, hc595TestLatched(a.written))
+}
+
+func TestHC595Batch(t *testing.T) {
+	// arrange
+	d, a := initTestHC595DriverWithStubbedAdaptor(1)
+	// act
+	err := d.Batch(func() error {
+		if err := d.DigitalWrite("0", 1); err != nil {
+			return err
+		}
+		return d.Batch(func() error {
+			if err := d.DigitalWrite("1", 1); err != nil {
+				return err
+			}
+			return d.DigitalWrite("7", 1)
+		})
+	})
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{{0x83}}, hc595TestLatched(a.written))
+}
+
+func TestHC595Batch_error(t *testing.T) {
+	// arrange
+	d, a := initTestHC595DriverWithStubbedAdaptor(1)
+	// act
+	err := d.Batch(func() error {
+		_ = d.DigitalWrite("0", 1)
+		return errors.New("batch error")
+	})
+	// assert: nothing latched, but the change is kept for the next update
+	require.EqualError(t, err, "batch error")
+	assert.Empty(t, hc595TestLatched(a.written))
+	require.NoError(t, d.DigitalWrite("1", 1))
+	assert.Equal(t, [][]byte{{0x03}}, hc595TestLatched(a.written))
+}
+
+func TestHC595AsConnection(t *testing.T) {
+	// arrange
+	d, a := initTestHC595DriverWithStubbedAdaptor(1)
+	led := NewLedDriver(d, "4")
+	relay := NewRelayDriver(d, "5")
+	require.NoError(t, led.Start())
+	require.NoError(t, relay.Start())
+	// act
+	err := d.Batch(func() error {
+		if err := led.On(); err != nil {
+			return err
+		}
+		return relay.On()
+	})
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{{0x30}}, hc595TestLatched(a.written))
+}
@@ -70,6 +70,9 @@ func NewHCSR04Driver(a gobot.Adaptor, triggerPinID, echoPinID string, opts ...in
 		echoPinID:    echoPinID,
 		measureMutex: &sync.Mutex{},
 	}
+	d.usedPins = func() map[string]string {
+		return map[string]string{d.triggerPinID: gobot.PinFunctionGpio, d.echoPinID: gobot.PinFunctionGpio}
+	}
 
 	for _, opt := range opts {
 		switch o := opt.(type) {
@@ -129,6 +129,9 @@ func NewHD44780Driver(
 		pinEN:      NewDirectPinDriver(a, pinEN),
 	}
 	d.afterStart = d.initialize
+	d.usedPins = func() map[string]string {
+		return directPinsUsage(append([]*DirectPinDriver{d.pinRS, d.pinEN, d.pinRW}, d.pinDataBits...)...)
+	}
 
 	for _, opt := range opts {
 		switch o := opt.(type) {
@@ -3,8 +3,10 @@ package gpio
 import (
 	"fmt"
 	"sync"
+	"time"
 
 	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/system"
 )
 
 type gpioTestBareAdaptor struct{}
@@ -137,3 +139,361 @@ func (t *gpioTestAdaptor) addDigitalPin(id string) *digitalPinMock {
 	t.pinMap[id] = dpm
 	return dpm
 }
+
+// gpioTestEdgeAdaptor is a gpioTestAdaptor, which supports the edge notification (interface DigitalEdgeNotifier)
+type gpioTestEdgeAdaptor struct {
+	*gpioTestAdaptor
+	notifyErr error
+	edgeMtx   sync.Mutex
+	handlers  map[string]func(val int)
+}
+
+func newGpioTestEdgeAdaptor() *gpioTestEdgeAdaptor {
+	return &gpioTestEdgeAdaptor{gpioTestAdaptor: newGpioTestAdaptor(), handlers: make(map[string]func(val int))}
+}
+
+// NotifyDigitalEdges (interface DigitalEdgeNotifier) registers or removes the handler of the pin
+func (t *gpioTestEdgeAdaptor) NotifyDigitalEdges(pin string, handler func(val int)) error {
+	if t.notifyErr != nil {
+		return t.notifyErr
+	}
+
+	t.edgeMtx.Lock()
+	defer t.edgeMtx.Unlock()
+
+	if handler == nil {
+		delete(t.handlers, pin)
+	} else {
+		t.handlers[pin] = handler
+	}
+	return nil
+}
+
+// edge simulates an edge of the given pin by calling the registered handler, returns false without a handler
+func (t *gpioTestEdgeAdaptor) edge(pin string, val int) bool {
+	t.edgeMtx.Lock()
+	handler := t.handlers[pin]
+	t.edgeMtx.Unlock()
+
+	if handler == nil {
+		return false
+	}
+	handler(val)
+	return true
+}
+
+func (t *gpioTestEdgeAdaptor) notified(pin string) bool {
+	t.edgeMtx.Lock()
+	defer t.edgeMtx.Unlock()
+
+	_, ok := t.handlers[pin]
+	return ok
+}
+
+// gpioTestEdge is an edge of a recorded waveform, the time is relative to the release of the line by the driver
+type gpioTestEdge struct {
+	at  time.Duration
+	val int
+}
+
+// gpioTestWaveformPin is a simulated pin (interface DigitalPinner), which replays a recorded waveform by calling the
+// edge handler each time the pin is switched from output to input, e.g. after the start signal of a single-wire
+// protocol. Read() returns the scripted values, the last value stays.
+type gpioTestWaveformPin struct {
+	mtx          sync.Mutex
+	direction    string
+	edgeHandler  func(lineOffset int, timestamp time.Duration, detectedEdge string, seqno uint32, lseqno uint32)
+	pollInterval time.Duration
+	waveforms    [][]gpioTestEdge
+	reads        []int
+	value        int
+	readErr      error
+	written      []int
+	writeErr     error
+	applyErr     error
+	replays      sync.WaitGroup
+}
+
+func newGpioTestWaveformPin() *gpioTestWaveformPin {
+	return &gpioTestWaveformPin{direction: system.IN}
+}
+
+// addWaveformPin adds a simulated pin to the adaptor
+func (t *gpioTestAdaptor) addWaveformPin(id string) *gpioTestWaveformPin {
+	pin := newGpioTestWaveformPin()
+	t.pinMap[id] = pin
+	return pin
+}
+
+// addWaveforms queues waveforms, each is replayed on the next switch from output to input
+func (p *gpioTestWaveformPin) addWaveforms(waveforms ...[]gpioTestEdge) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.waveforms = append(p.waveforms, waveforms...)
+}
+
+// scriptReads adds values, which are returned by the next calls of Read()
+func (p *gpioTestWaveformPin) scriptReads(vals ...int) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.reads = append(p.reads, vals...)
+}
+
+func (p *gpioTestWaveformPin) pendingReads() int {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	return len(p.reads)
+}
+
+func (p *gpioTestWaveformPin) writtenValues() []int {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	return append([]int(nil), p.written...)
+}
+
+func (p *gpioTestWaveformPin) currentDirection() string {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	return p.direction
+}
+
+// ApplyOptions (interface DigitalPinOptionApplier by DigitalPinner) applies all options and replays the next waveform
+// on switch from output to input
+func (p *gpioTestWaveformPin) ApplyOptions(options ...func(gobot.DigitalPinOptioner) bool) error {
+	p.mtx.Lock()
+	if p.applyErr != nil {
+		p.mtx.Unlock()
+		return p.applyErr
+	}
+
+	wasOutput := p.direction == system.OUT
+	for _, option := range options {
+		option((*gpioTestWaveformPinOptions)(p))
+	}
+
+	var waveform []gpioTestEdge
+	handler := p.edgeHandler
+	if wasOutput && p.direction == system.IN && handler != nil && len(p.waveforms) > 0 {
+		waveform = p.waveforms[0]
+		p.waveforms = p.waveforms[1:]
+	}
+	p.mtx.Unlock()
+
+	if waveform != nil {
+		p.replays.Add(1)
+		go func() {
+			defer p.replays.Done()
+			released := time.Duration(time.Now().UnixNano())
+			for i, edge := range waveform {
+				detectedEdge := system.DigitalPinEventFallingEdge
+				if edge.val != 0 {
+					detectedEdge = system.DigitalPinEventRisingEdge
+				}
+				handler(0, released+edge.at, detectedEdge, uint32(i+1), uint32(i+1))
+			}
+		}()
+	}
+
+	return nil
+}
+
+// Export (interface DigitalPinner) exports the pin for use by the adaptor
+func (p *gpioTestWaveformPin) Export() error {
+	return nil
+}
+
+// Unexport (interface DigitalPinner) releases the pin from the adaptor, so it is free for the operating system
+func (p *gpioTestWaveformPin) Unexport() error {
+	return nil
+}
+
+// Read (interface DigitalPinner) returns the next scripted value or the last value
+func (p *gpioTestWaveformPin) Read() (int, error) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if p.readErr != nil {
+		return 0, p.readErr
+	}
+	if len(p.reads) > 0 {
+		p.value = p.reads[0]
+		p.reads = p.reads[1:]
+	}
+	return p.value, nil
+}
+
+// Write (interface DigitalPinner) records the written value
+func (p *gpioTestWaveformPin) Write(val int) error {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if p.writeErr != nil {
+		return p.writeErr
+	}
+	p.written = append(p.written, val)
+	return nil
+}
+
+// gpioTestWaveformPinOptions implements the interface DigitalPinOptioner for the simulated pin, the mutex is locked
+// by ApplyOptions()
+type gpioTestWaveformPinOptions gpioTestWaveformPin
+
+func (o *gpioTestWaveformPinOptions) SetLabel(string) bool { return false }
+
+func (o *gpioTestWaveformPinOptions) SetDirectionOutput(initialState int) bool {
+	o.direction = system.OUT
+	o.written = append(o.written, initialState)
+	return true
+}
+
+func (o *gpioTestWaveformPinOptions) SetDirectionInput() bool {
+	changed := o.direction != system.IN
+	o.direction = system.IN
+	return changed
+}
+
+func (o *gpioTestWaveformPinOptions) SetActiveLow() bool             { return false }
+func (o *gpioTestWaveformPinOptions) SetBias(int) bool               { return false }
+func (o *gpioTestWaveformPinOptions) SetDrive(int) bool              { return false }
+func (o *gpioTestWaveformPinOptions) SetDebounce(time.Duration) bool { return false }
+
+func (o *gpioTestWaveformPinOptions) SetEventHandlerForEdge(
+	handler func(lineOffset int, timestamp time.Duration, detectedEdge string, seqno uint32, lseqno uint32),
+	edge int,
+) bool {
+	o.edgeHandler = handler
+	return true
+}
+
+func (o *gpioTestWaveformPinOptions) SetPollForEdgeDetection(pollInterval time.Duration, _ chan struct{}) bool {
+	o.pollInterval = pollInterval
+	return true
+}
+
+// gpioTestSerialFrames decodes the written values of a serial bus with clock, data and select pin to the transmitted
+// frames, a frame begins with select low and ends with select high, the data is sampled on the rising clock edge
+func gpioTestSerialFrames(written []gpioTestWritten, clockPin, dataPin, selectPin string, lsbFirst bool) [][]byte {
+	var frames [][]byte
+	var frame []byte
+	var clock, data, bits, value byte
+	for _, w := range written {
+		switch w.pin {
+		case clockPin:
+			if clock == 0 && w.val == 1 && frame != nil {
+				if lsbFirst {
+					value |= data << bits
+				} else {
+					value = value<<1 | data
+				}
+				bits++
+				if bits == 8 {
+					frame = append(frame, value)
+					bits, value = 0, 0
+				}
+			}
+			clock = w.val
+		case dataPin:
+			data = w.val
+		case selectPin:
+			if w.val == 0 {
+				frame = []byte{}
+				bits, value = 0, 0
+			} else if frame != nil {
+				frames = append(frames, frame)
+				frame = nil
+			}
+		}
+	}
+	return frames
+}
+
+// gpioTestPwmAdaptor is a gpioTestAdaptor, which provides access to PWM pins (interface gobot.PWMPinnerProvider)
+type gpioTestPwmAdaptor struct {
+	*gpioTestAdaptor
+	pwmPins map[string]*gpioTestPwmPin
+}
+
+func newGpioTestPwmAdaptor() *gpioTestPwmAdaptor {
+	return &gpioTestPwmAdaptor{gpioTestAdaptor: newGpioTestAdaptor(), pwmPins: make(map[string]*gpioTestPwmPin)}
+}
+
+// PWMPin (interface gobot.PWMPinnerProvider) returns a simulated PWM pin
+func (t *gpioTestPwmAdaptor) PWMPin(id string) (gobot.PWMPinner, error) {
+	if pin, ok := t.pwmPins[id]; ok {
+		return pin, nil
+	}
+	return nil, fmt.Errorf("PWM pin '%s' not found in '%s'", id, t.name)
+}
+
+// addPwmPin adds a simulated PWM pin to the adaptor
+func (t *gpioTestPwmAdaptor) addPwmPin(id string) *gpioTestPwmPin {
+	pin := &gpioTestPwmPin{}
+	t.pwmPins[id] = pin
+	return pin
+}
+
+// gpioTestPwmPin is a simulated PWM pin (interface gobot.PWMPinner), which records all written duty cycles
+type gpioTestPwmPin struct {
+	mtx        sync.Mutex
+	enabled    bool
+	period     uint32
+	dutyCycles []uint32
+	dutyErr    error
+}
+
+func (p *gpioTestPwmPin) Export() error   { return nil }
+func (p *gpioTestPwmPin) Unexport() error { return nil }
+
+func (p *gpioTestPwmPin) Enabled() (bool, error) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	return p.enabled, nil
+}
+
+func (p *gpioTestPwmPin) SetEnabled(val bool) error {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.enabled = val
+	return nil
+}
+
+func (p *gpioTestPwmPin) Polarity() (bool, error) { return true, nil }
+func (p *gpioTestPwmPin) SetPolarity(bool) error  { return nil }
+
+func (p *gpioTestPwmPin) Period() (uint32, error) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	return p.period, nil
+}
+
+func (p *gpioTestPwmPin) SetPeriod(period uint32) error {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.period = period
+	return nil
+}
+
+func (p *gpioTestPwmPin) DutyCycle() (uint32, error) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if len(p.dutyCycles) == 0 {
+		return 0, nil
+	}
+	return p.dutyCycles[len(p.dutyCycles)-1], nil
+}
+
+func (p *gpioTestPwmPin) SetDutyCycle(duty uint32) error {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if p.dutyErr != nil {
+		return p.dutyErr
+	}
+	p.dutyCycles = append(p.dutyCycles, duty)
+	return nil
+}
+
+func (p *gpioTestPwmPin) writtenDutyCycles() []uint32 {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	return append([]uint32(nil), p.dutyCycles...)
+}
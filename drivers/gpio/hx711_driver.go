@@ -0,0 +1,533 @@
+package gpio
+
+import (
+	"fmt"
+	"runtime"
+	"time"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/system"
+)
+
+const (
+	hx711DataBits = 24
+	// the conversion rate is 10 or 80 samples per second, after power up the output settles within 400 ms
+	hx711ReadyTimeout      = time.Second
+	hx711ReadyPollInterval = time.Millisecond
+	// if the clock stays high for longer than 60 us, the chip enters the power down mode and the readout is invalid
+	hx711MaxClockHigh   = 60 * time.Microsecond
+	hx711DefaultRetries = 3
+)
+
+// HX711Gain is the input channel and gain of the amplifier, which is selected for the next conversion
+type HX711Gain int
+
+const (
+	// HX711ChannelA128 selects the channel A with gain 128 (default)
+	HX711ChannelA128 HX711Gain = iota
+	// HX711ChannelA64 selects the channel A with gain 64
+	HX711ChannelA64
+	// HX711ChannelB32 selects the channel B with gain 32
+	HX711ChannelB32
+)
+
+// hx711OptionApplier needs to be implemented by each configurable option type
+type hx711OptionApplier interface {
+	apply(cfg *hx711Configuration)
+}
+
+// hx711Configuration contains all changeable attributes of the driver.
+type hx711Configuration struct {
+	gain         HX711Gain
+	offset       int
+	scale        float64
+	retries      int
+	readInterval time.Duration
+}
+
+// hx711GainOption is the type for applying another gain to the configuration
+type hx711GainOption HX711Gain
+
+// hx711CalibrationOption is the type for applying a stored calibration to the configuration
+type hx711CalibrationOption struct {
+	offset int
+	scale  float64
+}
+
+// hx711RetriesOption is the type for applying another count of retries to the configuration
+type hx711RetriesOption int
+
+// hx711ReadIntervalOption is the type for applying a cyclic read interval to the configuration
+type hx711ReadIntervalOption time.Duration
+
+// HX711Driver is a driver for the 24 bit analog-to-digital converter HX711, which is mostly used for load cells. The
+// chip is connected by a clock and a data line with a proprietary timing protocol.
+type HX711Driver struct {
+	*driver
+	hx711Cfg   *hx711Configuration
+	clockPinID string
+	dataPinID  string
+	clockPin   gobot.DigitalPinner
+	dataPin    gobot.DigitalPinner
+	settled    bool // false, if the next conversion still uses the former gain
+	halt       chan struct{}
+	gobot.Eventer
+}
+
+// NewHX711Driver creates a new instance of the driver for HX711. The adaptor needs to provide access to the pins
+// (interface gobot.DigitalPinnerProvider). The read value is converted to a weight by the offset (tare) and the scale
+// (calibration), see Tare() and Calibrate().
+//
+// Datasheet: https://cdn.sparkfun.com/datasheets/Sensors/ForceFlex/hx711_english.pdf
+//
+// Supported options:
+//
+//	"WithName"
+//	"WithHX711Gain"
+//	"WithHX711Calibration"
+//	"WithHX711Retries"
+//	"WithHX711CyclicRead"
+//
+// Adds the following API Commands:
+//
+//	"Read" - See HX711Driver.Read
+//	"ReadRaw" - See HX711Driver.ReadRaw
+//	"Tare" - See HX711Driver.Tare
+func NewHX711Driver(a gobot.Adaptor, clockPinID, dataPinID string, opts ...interface{}) *HX711Driver {
+	d := &HX711Driver{
+		driver:     newDriver(a, "HX711"),
+		hx711Cfg:   &hx711Configuration{scale: 1, retries: hx711DefaultRetries},
+		clockPinID: clockPinID,
+		dataPinID:  dataPinID,
+		settled:    true,
+		Eventer:    gobot.NewEventer(),
+	}
+	d.afterStart = d.initialize
+	d.beforeHalt = d.shutdown
+	d.usedPins = func() map[string]string {
+		return map[string]string{d.clockPinID: gobot.PinFunctionGpio, d.dataPinID: gobot.PinFunctionGpio}
+	}
+
+	for _, opt := range opts {
+		switch o := opt.(type) {
+		case optionApplier:
+			o.apply(d.driverCfg)
+		case hx711OptionApplier:
+			o.apply(d.hx711Cfg)
+		default:
+			panic(fmt.Sprintf("'%s' can not be applied on '%s'", opt, d.driverCfg.name))
+		}
+	}
+
+	// the chip starts with channel A and gain 128
+	d.settled = d.hx711Cfg.gain == HX711ChannelA128
+
+	d.AddCommand("Read", func(params map[string]interface{}) interface{} {
+		val, err := d.Read()
+		return map[string]interface{}{"val": val, "err": err}
+	})
+
+	d.AddCommand("ReadRaw", func(params map[string]interface{}) interface{} {
+		val, err := d.ReadRaw()
+		return map[string]interface{}{"val": val, "err": err}
+	})
+
+	d.AddCommand("Tare", func(params map[string]interface{}) interface{} {
+		samples, _ := params["samples"].(int)
+		return d.Tare(samples)
+	})
+
+	return d
+}
+
+// WithHX711Gain changes the input channel and gain from default channel A with gain 128 to the given value.
+func WithHX711Gain(gain HX711Gain) hx711OptionApplier {
+	return hx711GainOption(gain)
+}
+
+// WithHX711Calibration sets a stored calibration, which was determined by Tare() and Calibrate() before, see Offset()
+// and Scale(). The default is an offset of 0 and a scale of 1.
+func WithHX711Calibration(offset int, scale float64) hx711OptionApplier {
+	return hx711CalibrationOption{offset: offset, scale: scale}
+}
+
+// WithHX711Retries changes the count of retries on failed readouts from default 3 to the given value.
+func WithHX711Retries(retries int) hx711OptionApplier {
+	return hx711RetriesOption(retries)
+}
+
+// WithHX711CyclicRead adds an asynchronous cyclic reading with the given interval. The events "data" (raw value) and
+// "weight" are published on change, "error" is published if a reading fails after all retries.
+func WithHX711CyclicRead(interval time.Duration) hx711OptionApplier {
+	return hx711ReadIntervalOption(interval)
+}
+
+// ReadRaw waits for the next conversion and returns the raw value in the range -8388608..8388607.
+func (d *HX711Driver) ReadRaw() (int, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.readRaw()
+}
+
+// Read waits for the next conversion and returns the weight, converted by the offset and scale.
+func (d *HX711Driver) Read() (float64, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	raw, err := d.readRaw()
+	if err != nil {
+		return 0, err
+	}
+
+	return d.weight(raw), nil
+}
+
+// Tare reads the given count of samples (at least one) without load and uses the average as offset.
+func (d *HX711Driver) Tare(samples int) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	avg, err := d.readAverage(samples)
+	if err != nil {
+		return err
+	}
+
+	d.hx711Cfg.offset = int(avg)
+	return nil
+}
+
+// Calibrate reads the given count of samples (at least one) with the given known weight on the load cell and
+// calculates the scale, so Read() returns the weight in the same unit. Tare() needs to be done before.
+func (d *HX711Driver) Calibrate(knownWeight float64, samples int) error {
+	if knownWeight == 0 {
+		return fmt.Errorf("the known weight for calibration of '%s' needs to be not zero", d.driverCfg.name)
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	avg, err := d.readAverage(samples)
+	if err != nil {
+		return err
+	}
+
+	scale := (avg - float64(d.hx711Cfg.offset)) / knownWeight
+	if scale == 0 {
+		return fmt.Errorf("no change of the value detected for calibration of '%s'", d.driverCfg.name)
+	}
+
+	d.hx711Cfg.scale = scale
+	return nil
+}
+
+// Offset returns the current offset in raw counts, which is determined by Tare().
+func (d *HX711Driver) Offset() int {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.hx711Cfg.offset
+}
+
+// Scale returns the current scale in raw counts per unit, which is determined by Calibrate().
+func (d *HX711Driver) Scale() float64 {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.hx711Cfg.scale
+}
+
+// SetGain changes the input channel and gain. The change takes effect on the conversion after the next readout, so
+// the next reading will be done twice.
+func (d *HX711Driver) SetGain(gain HX711Gain) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.hx711Cfg.gain != gain {
+		d.hx711Cfg.gain = gain
+		d.settled = false
+	}
+}
+
+// PowerDown switches the chip to the power down mode by a high level of the clock line.
+func (d *HX711Driver) PowerDown() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.clockPin == nil {
+		return fmt.Errorf("'%s' is not started", d.driverCfg.name)
+	}
+
+	if err := d.clockPin.Write(1); err != nil {
+		return err
+	}
+	time.Sleep(hx711MaxClockHigh)
+
+	return nil
+}
+
+// PowerUp wakes the chip up from the power down mode. The chip starts with channel A and gain 128.
+func (d *HX711Driver) PowerUp() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.clockPin == nil {
+		return fmt.Errorf("'%s' is not started", d.driverCfg.name)
+	}
+
+	d.settled = d.hx711Cfg.gain == HX711ChannelA128
+	return d.clockPin.Write(0)
+}
+
+// initialize gets the pins and starts the cyclic reading, if configured
+func (d *HX711Driver) initialize() error {
+	provider, ok := d.connection.(gobot.DigitalPinnerProvider)
+	if !ok {
+		return fmt.Errorf("the adaptor of '%s' does not provide access to digital pins", d.driverCfg.name)
+	}
+
+	clockPin, err := provider.DigitalPin(d.clockPinID)
+	if err != nil {
+		return fmt.Errorf("error on get clock pin: %v", err)
+	}
+	if err := clockPin.ApplyOptions(system.WithPinDirectionOutput(0)); err != nil {
+		return fmt.Errorf("error on apply output for clock pin: %v", err)
+	}
+	d.clockPin = clockPin
+
+	// pins are inputs by default
+	dataPin, err := provider.DigitalPin(d.dataPinID)
+	if err != nil {
+		return fmt.Errorf("error on get data pin: %v", err)
+	}
+	d.dataPin = dataPin
+
+	if d.hx711Cfg.readInterval <= 0 {
+		return nil
+	}
+
+	d.AddEvent(Data)
+	d.AddEvent(Weight)
+	d.AddEvent(Error)
+
+	d.halt = make(chan struct{})
+	go d.cyclicRead(d.halt)
+
+	return nil
+}
+
+// shutdown stops the cyclic reading
+func (d *HX711Driver) shutdown() error {
+	if d.halt != nil {
+		close(d.halt)
+		d.halt = nil
+	}
+
+	// note: Unexport() of all pins will be done on adaptor.Finalize()
+
+	return nil
+}
+
+func (d *HX711Driver) cyclicRead(halt chan struct{}) {
+	oldRaw := 0
+	oldWeight := 0.0
+	timer := time.NewTimer(d.hx711Cfg.readInterval)
+	timer.Stop()
+
+	for {
+		d.mutex.Lock()
+		raw, err := d.readRaw()
+		weight := d.weight(raw)
+		d.mutex.Unlock()
+
+		if err != nil {
+			d.Publish(d.Event(Error), err)
+		} else {
+			if raw != oldRaw {
+				d.Publish(d.Event(Data), raw)
+				oldRaw = raw
+			}
+			if weight != oldWeight {
+				d.Publish(d.Event(Weight), weight)
+				oldWeight = weight
+			}
+		}
+
+		timer.Reset(d.hx711Cfg.readInterval)
+		select {
+		case <-timer.C:
+		case <-halt:
+			timer.Stop()
+			return
+		}
+	}
+}
+
+// readAverage reads the given count of samples and returns the average of the raw values
+func (d *HX711Driver) readAverage(samples int) (float64, error) {
+	if samples < 1 {
+		samples = 1
+	}
+
+	var sum float64
+	for i := 0; i < samples; i++ {
+		raw, err := d.readRaw()
+		if err != nil {
+			return 0, err
+		}
+		sum += float64(raw)
+	}
+
+	return sum / float64(samples), nil
+}
+
+// readRaw reads the next conversion with retries, the mutex needs to be locked before
+func (d *HX711Driver) readRaw() (int, error) {
+	if d.clockPin == nil || d.dataPin == nil {
+		return 0, fmt.Errorf("'%s' is not started", d.driverCfg.name)
+	}
+
+	if !d.settled {
+		// the current conversion was started with the former gain, so it is dropped
+		if _, err := d.readWithRetries(); err != nil {
+			return 0, err
+		}
+		d.settled = true
+	}
+
+	return d.readWithRetries()
+}
+
+func (d *HX711Driver) readWithRetries() (int, error) {
+	var err error
+	for try := 0; try <= d.hx711Cfg.retries; try++ {
+		var raw int
+		if raw, err = d.readOnce(); err == nil {
+			return raw, nil
+		}
+	}
+
+	return 0, fmt.Errorf("reading of '%s' failed after %d retries: %v", d.driverCfg.name, d.hx711Cfg.retries, err)
+}
+
+// readOnce waits until the conversion is ready and shifts out the 24 data bits, the additional clock pulses select
+// the gain for the next conversion
+func (d *HX711Driver) readOnce() (int, error) {
+	if err := d.waitReady(); err != nil {
+		return 0, err
+	}
+
+	// the clock needs to be kept short, so the scheduler should not move the go routine to another thread
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	var value uint32
+	var longestHigh time.Duration
+	for pulse := 0; pulse < d.pulses(); pulse++ {
+		start := time.Now()
+		if err := d.clockPin.Write(1); err != nil {
+			return 0, err
+		}
+		if err := d.clockPin.Write(0); err != nil {
+			return 0, err
+		}
+		if high := time.Since(start); high > longestHigh {
+			longestHigh = high
+		}
+
+		if pulse >= hx711DataBits {
+			continue
+		}
+
+		// the data bit is shifted out on the rising edge and is valid until the next rising edge
+		bit, err := d.dataPin.Read()
+		if err != nil {
+			return 0, err
+		}
+		value = value<<1 | uint32(bit&1)
+	}
+
+	if longestHigh > hx711MaxClockHigh {
+		return 0, fmt.Errorf("clock was high for %s, so the chip was powered down while reading", longestHigh)
+	}
+
+	// the data line is high after the last pulse until the next conversion is ready
+	ready, err := d.dataPin.Read()
+	if err != nil {
+		return 0, err
+	}
+	if ready != 1 {
+		return 0, fmt.Errorf("data line is low after the readout, so the clock pulses were not in sync")
+	}
+
+	// convert the 24 bit two's complement value
+	return int(int32(value<<8) >> 8), nil
+}
+
+// waitReady waits until the data line is low, which means the conversion is ready
+func (d *HX711Driver) waitReady() error {
+	timeout := time.Now().Add(hx711ReadyTimeout)
+	for {
+		val, err := d.dataPin.Read()
+		if err != nil {
+			return err
+		}
+		if val == 0 {
+			return nil
+		}
+		if time.Now().After(timeout) {
+			return fmt.Errorf("timeout %s reached while waiting for conversion on data pin %s", hx711ReadyTimeout,
+				d.dataPinID)
+		}
+		time.Sleep(hx711ReadyPollInterval)
+	}
+}
+
+// pulses returns the count of clock pulses for reading and selecting the gain of the next conversion
+func (d *HX711Driver) pulses() int {
+	switch d.hx711Cfg.gain {
+	case HX711ChannelB32:
+		return hx711DataBits + 2
+	case HX711ChannelA64:
+		return hx711DataBits + 3
+	default:
+		return hx711DataBits + 1
+	}
+}
+
+func (d *HX711Driver) weight(raw int) float64 {
+	return float64(raw-d.hx711Cfg.offset) / d.hx711Cfg.scale
+}
+
+func (o hx711GainOption) String() string {
+	return "gain option for HX711"
+}
+
+func (o hx711CalibrationOption) String() string {
+	return "calibration option for HX711"
+}
+
+func (o hx711RetriesOption) String() string {
+	return "retries option for HX711"
+}
+
+func (o hx711ReadIntervalOption) String() string {
+	return "read interval option for HX711"
+}
+
+func (o hx711GainOption) apply(cfg *hx711Configuration) {
+	cfg.gain = HX711Gain(o)
+}
+
+func (o hx711CalibrationOption) apply(cfg *hx711Configuration) {
+	cfg.offset = o.offset
+	cfg.scale = o.scale
+}
+
+func (o hx711RetriesOption) apply(cfg *hx711Configuration) {
+	cfg.retries = int(o)
+}
+
+func (o hx711ReadIntervalOption) apply(cfg *hx711Configuration) {
+	cfg.readInterval = time.Duration(o)
+}
@@ -0,0 +1,376 @@
+//nolint:forcetypeassert // ok here
+package gpio
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/drivers/aio"
+	"gobot.io/x/gobot/v2/system"
+)
+
+var _ gobot.Driver = (*HX711Driver)(nil)
+
+// hx711TestReadout creates the values of the data line for one readout: busy, ready, the 24 data bits and the high
+// level after the last clock pulse
+func hx711TestReadout(raw int) []int {
+	vals := []int{1, 0}
+	for i := 23; i >= 0; i-- {
+		vals = append(vals, (raw>>i)&1)
+	}
+	return append(vals, 1)
+}
+
+// hx711TestClock creates the written values of the clock line for the given count of readouts with the given pulses
+func hx711TestClock(readouts, pulses int) []int {
+	vals := []int{0} // initialization of the output
+	for i := 0; i < readouts*pulses; i++ {
+		vals = append(vals, 1, 0)
+	}
+	return vals
+}
+
+func initTestHX711DriverWithStubbedAdaptor(
+	opts ...interface{},
+) (*HX711Driver, *gpioTestWaveformPin, *gpioTestWaveformPin) {
+	a := newGpioTestAdaptor()
+	clockPin := a.addWaveformPin("5")
+	dataPin := a.addWaveformPin("6")
+	d := NewHX711Driver(a, "5", "6", opts...)
+	if err := d.Start(); err != nil {
+		panic(err)
+	}
+	return d, clockPin, dataPin
+}
+
+func TestNewHX711Driver(t *testing.T) {
+	// arrange
+	a := newGpioTestAdaptor()
+	// act
+	d := NewHX711Driver(a, "5", "6")
+	// assert
+	assert.IsType(t, &HX711Driver{}, d)
+	// assert: gpio.driver attributes
+	assert.NotNil(t, d.driver)
+	assert.True(t, strings.HasPrefix(d.driverCfg.name, "HX711"))
+	assert.Equal(t, a, d.connection)
+	assert.NotNil(t, d.Commander)
+	assert.NotNil(t, d.mutex)
+	assert.NotNil(t, d.Command("Read"))
+	assert.NotNil(t, d.Command("ReadRaw"))
+	assert.NotNil(t, d.Command("Tare"))
+	assert.Equal(t, map[string]string{"5": gobot.PinFunctionGpio, "6": gobot.PinFunctionGpio}, d.usedPins())
+	// assert: driver specific attributes
+	assert.Equal(t, "5", d.clockPinID)
+	assert.Equal(t, "6", d.dataPinID)
+	assert.Equal(t, HX711ChannelA128, d.hx711Cfg.gain)
+	assert.Equal(t, 0, d.hx711Cfg.offset)
+	assert.InDelta(t, 1.0, d.hx711Cfg.scale, 0.0)
+	assert.Equal(t, hx711DefaultRetries, d.hx711Cfg.retries)
+	assert.Equal(t, time.Duration(0), d.hx711Cfg.readInterval)
+	assert.True(t, d.settled)
+	assert.NotNil(t, d.Eventer)
+}
+
+func TestNewHX711Driver_options(t *testing.T) {
+	// This is a general test, that options are applied in constructor by using the common WithName() option, least one
+	// option of this driver and one of another driver (which should lead to panic). Further tests for options can also
+	// be done by call of "WithOption(val).apply(cfg)".
+	// arrange
+	const myName = "scale"
+	panicFunc := func() {
+		NewHX711Driver(newGpioTestAdaptor(), "1", "2", WithName("crazy"),
+			aio.WithActuatorScaler(func(float64) int { return 0 }))
+	}
+	// act
+	d := NewHX711Driver(newGpioTestAdaptor(), "1", "2", WithName(myName), WithHX711Gain(HX711ChannelB32),
+		WithHX711Calibration(-1200, 420.5), WithHX711Retries(1), WithHX711CyclicRead(time.Second))
+	// assert
+	assert.Equal(t, myName, d.Name())
+	assert.Equal(t, HX711ChannelB32, d.hx711Cfg.gain)
+	assert.Equal(t, -1200, d.Offset())
+	assert.InDelta(t, 420.5, d.Scale(), 0.0)
+	assert.Equal(t, 1, d.hx711Cfg.retries)
+	assert.Equal(t, time.Second, d.hx711Cfg.readInterval)
+	assert.False(t, d.settled)
+	assert.PanicsWithValue(t, "'scaler option for analog actuators' can not be applied on 'crazy'", panicFunc)
+}
+
+func TestHX711Start(t *testing.T) {
+	tests := map[string]struct {
+		adaptor gobot.Adaptor
+		wantErr string
+	}{
+		"ok": {
+			adaptor: func() gobot.Adaptor {
+				a := newGpioTestAdaptor()
+				a.addWaveformPin("5")
+				a.addWaveformPin("6")
+				return a
+			}(),
+		},
+		"error_no_pin_provider": {
+			adaptor: &gpioTestBareAdaptor{},
+			wantErr: "does not provide access to digital pins",
+		},
+		"error_clock_pin_not_found": {
+			adaptor: func() gobot.Adaptor { a := newGpioTestAdaptor(); a.addWaveformPin("6"); return a }(),
+			wantErr: "error on get clock pin: pin '5' not found",
+		},
+		"error_data_pin_not_found": {
+			adaptor: func() gobot.Adaptor { a := newGpioTestAdaptor(); a.addWaveformPin("5"); return a }(),
+			wantErr: "error on get data pin: pin '6' not found",
+		},
+		"error_apply_output": {
+			adaptor: func() gobot.Adaptor {
+				a := newGpioTestAdaptor()
+				a.addWaveformPin("5").applyErr = errors.New("apply error")
+				return a
+			}(),
+			wantErr: "error on apply output for clock pin: apply error",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d := NewHX711Driver(tc.adaptor, "5", "6")
+			// act
+			err := d.Start()
+			// assert
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, system.OUT, d.clockPin.(*gpioTestWaveformPin).currentDirection())
+			assert.Equal(t, system.IN, d.dataPin.(*gpioTestWaveformPin).currentDirection())
+			require.NoError(t, d.Halt())
+		})
+	}
+}
+
+func TestHX711ReadRaw(t *testing.T) {
+	tests := map[string]struct {
+		opts      []interface{}
+		reads     []int
+		readErr   error
+		wantClock []int
+		want      int
+		wantErr   string
+	}{
+		"positive": {
+			reads:     hx711TestReadout(0x123456),
+			wantClock: hx711TestClock(1, 25),
+			want:      0x123456,
+		},
+		"negative": {
+			reads:     hx711TestReadout(-1000),
+			wantClock: hx711TestClock(1, 25),
+			want:      -1000,
+		},
+		"minimum": {
+			reads:     hx711TestReadout(-0x800000),
+			wantClock: hx711TestClock(1, 25),
+			want:      -8388608,
+		},
+		"maximum": {
+			reads:     hx711TestReadout(0x7FFFFF),
+			wantClock: hx711TestClock(1, 25),
+			want:      8388607,
+		},
+		"gain_64_drops_first_conversion": {
+			opts:      []interface{}{WithHX711Gain(HX711ChannelA64)},
+			reads:     append(hx711TestReadout(5), hx711TestReadout(0x0ABCDE)...),
+			wantClock: hx711TestClock(2, 27),
+			want:      0x0ABCDE,
+		},
+		"channel_b_drops_first_conversion": {
+			opts:      []interface{}{WithHX711Gain(HX711ChannelB32)},
+			reads:     append(hx711TestReadout(5), hx711TestReadout(-77)...),
+			wantClock: hx711TestClock(2, 26),
+			want:      -77,
+		},
+		"retry_out_of_sync": {
+			reads:     append(append(hx711TestReadout(0x10)[:26], 0), hx711TestReadout(0x20)...),
+			wantClock: hx711TestClock(2, 25),
+			want:      0x20,
+		},
+		"error_out_of_sync": {
+			opts:    []interface{}{WithHX711Retries(0)},
+			reads:   append(hx711TestReadout(0x10)[:26], 0),
+			wantErr: "failed after 0 retries: data line is low after the readout",
+		},
+		"error_read": {
+			readErr: errors.New("read error"),
+			wantErr: "failed after 3 retries: read error",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, clockPin, dataPin := initTestHX711DriverWithStubbedAdaptor(tc.opts...)
+			dataPin.scriptReads(tc.reads...)
+			dataPin.readErr = tc.readErr
+			// act
+			got, err := d.ReadRaw()
+			// assert
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+			assert.Equal(t, tc.wantClock, clockPin.writtenValues())
+			assert.Equal(t, 0, dataPin.pendingReads())
+		})
+	}
+}
+
+func TestHX711ReadRaw_notStarted(t *testing.T) {
+	// arrange
+	d := NewHX711Driver(newGpioTestAdaptor(), "5", "6")
+	// act
+	_, err := d.ReadRaw()
+	// assert
+	require.ErrorContains(t, err, "is not started")
+}
+
+func TestHX711ReadRaw_timeout(t *testing.T) {
+	// arrange
+	d, _, dataPin := initTestHX711DriverWithStubbedAdaptor(WithHX711Retries(0))
+	dataPin.scriptReads(1)
+	// act
+	_, err := d.ReadRaw()
+	// assert
+	require.ErrorContains(t, err, "timeout 1s reached while waiting for conversion on data pin 6")
+}
+
+func TestHX711TareCalibrateRead(t *testing.T) {
+	// arrange
+	d, _, dataPin := initTestHX711DriverWithStubbedAdaptor()
+	for _, raw := range []int{-1190, -1210, -1200} {
+		dataPin.scriptReads(hx711TestReadout(raw)...)
+	}
+	// act: tare without load
+	err := d.Tare(3)
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, -1200, d.Offset())
+	// arrange
+	for _, raw := range []int{40800, 40850, 40750} {
+		dataPin.scriptReads(hx711TestReadout(raw)...)
+	}
+	// act: calibrate with 100 g
+	err = d.Calibrate(100, 3)
+	// assert
+	require.NoError(t, err)
+	assert.InDelta(t, 420.0, d.Scale(), 1e-9)
+	// arrange
+	dataPin.scriptReads(hx711TestReadout(19800)...)
+	// act
+	got, err := d.Read()
+	// assert
+	require.NoError(t, err)
+	assert.InDelta(t, 50.0, got, 1e-9)
+}
+
+func TestHX711Calibrate_error(t *testing.T) {
+	// arrange
+	d, _, dataPin := initTestHX711DriverWithStubbedAdaptor()
+	dataPin.scriptReads(hx711TestReadout(0)...)
+	// act & assert
+	require.ErrorContains(t, d.Calibrate(0, 1), "known weight for calibration")
+	require.ErrorContains(t, d.Calibrate(100, 1), "no change of the value detected")
+	assert.InDelta(t, 1.0, d.Scale(), 0.0)
+}
+
+func TestHX711SetGain(t *testing.T) {
+	// arrange
+	d, clockPin, dataPin := initTestHX711DriverWithStubbedAdaptor()
+	// act
+	d.SetGain(HX711ChannelA128)
+	// assert
+	assert.True(t, d.settled)
+	// act
+	d.SetGain(HX711ChannelA64)
+	dataPin.scriptReads(append(hx711TestReadout(1), hx711TestReadout(2)...)...)
+	got, err := d.ReadRaw()
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, 2, got)
+	assert.Equal(t, hx711TestClock(2, 27), clockPin.writtenValues())
+}
+
+func TestHX711PowerDownUp(t *testing.T) {
+	// arrange
+	d, clockPin, _ := initTestHX711DriverWithStubbedAdaptor(WithHX711Gain(HX711ChannelB32))
+	d.settled = true
+	// act
+	require.NoError(t, d.PowerDown())
+	require.NoError(t, d.PowerUp())
+	// assert
+	assert.Equal(t, []int{0, 1, 0}, clockPin.writtenValues())
+	assert.False(t, d.settled)
+	// arrange
+	d = NewHX711Driver(newGpioTestAdaptor(), "5", "6")
+	// act & assert
+	require.ErrorContains(t, d.PowerDown(), "is not started")
+	require.ErrorContains(t, d.PowerUp(), "is not started")
+}
+
+func TestHX711Commands(t *testing.T) {
+	// arrange
+	d, _, dataPin := initTestHX711DriverWithStubbedAdaptor(WithHX711Calibration(100, 2))
+	dataPin.scriptReads(hx711TestReadout(300)...)
+	dataPin.scriptReads(hx711TestReadout(300)...)
+	dataPin.scriptReads(hx711TestReadout(500)...)
+	// act & assert
+	result := d.Command("ReadRaw")(nil).(map[string]interface{})
+	assert.Nil(t, result["err"])
+	assert.Equal(t, 300, result["val"])
+	result = d.Command("Read")(nil).(map[string]interface{})
+	assert.Nil(t, result["err"])
+	assert.InDelta(t, 100.0, result["val"].(float64), 0.0)
+	assert.Nil(t, d.Command("Tare")(map[string]interface{}{"samples": 1}))
+	assert.Equal(t, 500, d.Offset())
+}
+
+func TestHX711CyclicRead(t *testing.T) {
+	// arrange
+	a := newGpioTestAdaptor()
+	a.addWaveformPin("5")
+	dataPin := a.addWaveformPin("6")
+	dataPin.scriptReads(hx711TestReadout(1500)...)
+	d := NewHX711Driver(a, "5", "6", WithHX711CyclicRead(10*time.Millisecond), WithHX711Calibration(500, 10))
+	data := make(chan int, 1)
+	weights := make(chan float64, 1)
+	_ = d.Once(Data, func(val interface{}) { data <- val.(int) })
+	_ = d.Once(Weight, func(val interface{}) { weights <- val.(float64) })
+	// act
+	require.NoError(t, d.Start())
+	defer func() {
+		dataPin.mtx.Lock()
+		dataPin.readErr = errors.New("stop reading") // prevent waiting for the next conversion
+		dataPin.mtx.Unlock()
+		_ = d.Halt()
+	}()
+	// assert
+	select {
+	case raw := <-data:
+		assert.Equal(t, 1500, raw)
+	case <-time.After(time.Second):
+		require.Fail(t, "data event was not published")
+	}
+	select {
+	case weight := <-weights:
+		assert.InDelta(t, 100.0, weight, 0.0)
+	case <-time.After(time.Second):
+		require.Fail(t, "weight event was not published")
+	}
+}
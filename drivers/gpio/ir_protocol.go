@@ -0,0 +1,335 @@
+package gpio
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// NEC: 9 ms header mark, 4.5 ms space, 32 bits with a mark of 562.5 us followed by a space of 562.5 us (0) or
+	// 1687.5 us (1), a stop mark, the repeat frame has a space of 2.25 ms after the header mark
+	irNECUnit        = 562500 * time.Nanosecond
+	irNECFramePeriod = 108 * time.Millisecond
+	// RC5: 14 manchester coded bits with 889 us for each half bit, 0 is mark-space and 1 is space-mark
+	irRC5HalfBit     = 889 * time.Microsecond
+	irRC5Bits        = 14
+	irRC5FramePeriod = 113778 * time.Microsecond
+	// Sony SIRC: 2.4 ms header mark, each bit is a mark of 600 us (0) or 1200 us (1) after a space of 600 us
+	irSonyUnit        = 600 * time.Microsecond
+	irSonyFramePeriod = 45 * time.Millisecond
+)
+
+// IRProtocol is the protocol of an infrared remote control
+type IRProtocol int
+
+const (
+	// IRProtocolNEC is the NEC protocol with 8 bit address and 8 bit command, both sent with their inverse
+	IRProtocolNEC IRProtocol = iota + 1
+	// IRProtocolNECExtended is the NEC protocol with 16 bit address and 8 bit command
+	IRProtocolNECExtended
+	// IRProtocolRC5 is the Philips RC5 protocol with 5 bit address, 7 bit command (RC5x) and toggle bit
+	IRProtocolRC5
+	// IRProtocolSony12 is the Sony SIRC protocol with 7 bit command and 5 bit address
+	IRProtocolSony12
+	// IRProtocolSony15 is the Sony SIRC protocol with 7 bit command and 8 bit address
+	IRProtocolSony15
+	// IRProtocolSony20 is the Sony SIRC protocol with 7 bit command and 13 bit address (5 bit device, 8 bit extended)
+	IRProtocolSony20
+)
+
+// IRCode is a decoded frame of an infrared remote control or a frame to send.
+type IRCode struct {
+	Protocol IRProtocol
+	Address  uint16
+	Command  uint8
+	// Toggle is changed by RC5 remotes on each new key press
+	Toggle bool
+	// Repeat is set for repeated frames of a held key. For NEC a repeat frame is sent, which contains no data.
+	Repeat bool
+}
+
+// String returns the name of the protocol.
+func (p IRProtocol) String() string {
+	switch p {
+	case IRProtocolNEC:
+		return "NEC"
+	case IRProtocolNECExtended:
+		return "NEC extended"
+	case IRProtocolRC5:
+		return "RC5"
+	case IRProtocolSony12:
+		return "Sony 12 bit"
+	case IRProtocolSony15:
+		return "Sony 15 bit"
+	case IRProtocolSony20:
+		return "Sony 20 bit"
+	default:
+		return fmt.Sprintf("unknown (%d)", int(p))
+	}
+}
+
+// framePeriod returns the time between the start of two frames of the protocol
+func (p IRProtocol) framePeriod() time.Duration {
+	switch p {
+	case IRProtocolRC5:
+		return irRC5FramePeriod
+	case IRProtocolSony12, IRProtocolSony15, IRProtocolSony20:
+		return irSonyFramePeriod
+	default:
+		return irNECFramePeriod
+	}
+}
+
+// IREncode creates the pulses of a frame for the given code. The pulses start with a mark (carrier on) and alternate
+// between mark and space.
+func IREncode(code IRCode) ([]time.Duration, error) {
+	var b irPulseBuilder
+
+	switch code.Protocol {
+	case IRProtocolNEC, IRProtocolNECExtended:
+		b.add(true, 16*irNECUnit)
+		if code.Repeat {
+			b.add(false, 4*irNECUnit)
+			b.add(true, irNECUnit)
+			return b.pulses, nil
+		}
+
+		address := uint32(code.Address)
+		if code.Protocol == IRProtocolNEC {
+			if code.Address > 0xFF {
+				return nil, fmt.Errorf("address 0x%X is too big for %s", code.Address, code.Protocol)
+			}
+			address |= uint32(^uint8(code.Address)) << 8
+		}
+		data := address | uint32(code.Command)<<16 | uint32(^code.Command)<<24
+
+		b.add(false, 8*irNECUnit)
+		for bit := 0; bit < 32; bit++ {
+			b.add(true, irNECUnit)
+			if data&(1<<bit) != 0 {
+				b.add(false, 3*irNECUnit)
+			} else {
+				b.add(false, irNECUnit)
+			}
+		}
+		b.add(true, irNECUnit)
+	case IRProtocolRC5:
+		if code.Address > 0x1F || code.Command > 0x7F {
+			return nil, fmt.Errorf("address 0x%X or command 0x%X is too big for %s", code.Address, code.Command,
+				code.Protocol)
+		}
+
+		data := uint16(1)<<13 | uint16(code.Address)<<6 | uint16(code.Command&0x3F)
+		if code.Command&0x40 == 0 {
+			data |= 1 << 12 // the field bit is the inverted bit 6 of the command
+		}
+		if code.Toggle {
+			data |= 1 << 11
+		}
+
+		for bit := irRC5Bits - 1; bit >= 0; bit-- {
+			one := data&(1<<bit) != 0
+			b.add(!one, irRC5HalfBit)
+			b.add(one, irRC5HalfBit)
+		}
+	case IRProtocolSony12, IRProtocolSony15, IRProtocolSony20:
+		bits, maxAddress := irSonyBits(code.Protocol)
+		if code.Address > maxAddress || code.Command > 0x7F {
+			return nil, fmt.Errorf("address 0x%X or command 0x%X is too big for %s", code.Address, code.Command,
+				code.Protocol)
+		}
+
+		data := uint32(code.Command) | uint32(code.Address)<<7
+		b.add(true, 4*irSonyUnit)
+		for bit := 0; bit < bits; bit++ {
+			b.add(false, irSonyUnit)
+			if data&(1<<bit) != 0 {
+				b.add(true, 2*irSonyUnit)
+			} else {
+				b.add(true, irSonyUnit)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unknown IR protocol %s", code.Protocol)
+	}
+
+	return b.finish(), nil
+}
+
+// IRDecode detects the protocol of the given pulses and decodes the frame. The pulses need to start with a mark
+// (carrier on) and alternate between mark and space. A tolerance of 30% is accepted for each pulse.
+func IRDecode(pulses []time.Duration) (IRCode, error) {
+	if len(pulses) == 0 {
+		return IRCode{}, fmt.Errorf("no pulses to decode")
+	}
+
+	switch {
+	case irMatch(pulses[0], 16*irNECUnit):
+		return irDecodeNEC(pulses)
+	case irMatch(pulses[0], 4*irSonyUnit) && len(pulses) > 1 && irMatch(pulses[1], irSonyUnit):
+		// the space is needed to distinguish from RC5 with a leading double mark
+		return irDecodeSony(pulses)
+	case irMatch(pulses[0], irRC5HalfBit), irMatch(pulses[0], 2*irRC5HalfBit):
+		return irDecodeRC5(pulses)
+	default:
+		return IRCode{}, fmt.Errorf("unknown IR protocol with first mark of %s", pulses[0])
+	}
+}
+
+func irDecodeNEC(pulses []time.Duration) (IRCode, error) {
+	if len(pulses) == 3 && irMatch(pulses[1], 4*irNECUnit) && irMatch(pulses[2], irNECUnit) {
+		return IRCode{Protocol: IRProtocolNEC, Repeat: true}, nil
+	}
+
+	if len(pulses) != 67 || !irMatch(pulses[1], 8*irNECUnit) {
+		return IRCode{}, fmt.Errorf("invalid NEC frame with %d pulses", len(pulses))
+	}
+
+	var data uint32
+	for bit := 0; bit < 32; bit++ {
+		mark, space := pulses[2+2*bit], pulses[3+2*bit]
+		if !irMatch(mark, irNECUnit) {
+			return IRCode{}, fmt.Errorf("invalid mark of %s for NEC bit %d", mark, bit)
+		}
+		switch {
+		case irMatch(space, 3*irNECUnit):
+			data |= 1 << bit
+		case !irMatch(space, irNECUnit):
+			return IRCode{}, fmt.Errorf("invalid space of %s for NEC bit %d", space, bit)
+		}
+	}
+
+	command := uint8(data >> 16)
+	if ^command != uint8(data>>24) {
+		return IRCode{}, fmt.Errorf("NEC command 0x%02X does not match its inverse 0x%02X", command, uint8(data>>24))
+	}
+
+	if address := uint8(data); ^address == uint8(data>>8) {
+		return IRCode{Protocol: IRProtocolNEC, Address: uint16(address), Command: command}, nil
+	}
+
+	return IRCode{Protocol: IRProtocolNECExtended, Address: uint16(data), Command: command}, nil
+}
+
+func irDecodeRC5(pulses []time.Duration) (IRCode, error) {
+	// the first half bit is always a space, which is not visible
+	halves := []bool{false}
+	for i, pulse := range pulses {
+		mark := i%2 == 0
+		switch {
+		case irMatch(pulse, irRC5HalfBit):
+			halves = append(halves, mark)
+		case irMatch(pulse, 2*irRC5HalfBit):
+			halves = append(halves, mark, mark)
+		default:
+			return IRCode{}, fmt.Errorf("invalid pulse of %s for RC5", pulse)
+		}
+	}
+	if len(halves) == 2*irRC5Bits-1 {
+		// the last half bit is a space, which is not visible
+		halves = append(halves, false)
+	}
+	if len(halves) != 2*irRC5Bits {
+		return IRCode{}, fmt.Errorf("invalid RC5 frame with %d half bits", len(halves))
+	}
+
+	var data uint16
+	for bit := 0; bit < irRC5Bits; bit++ {
+		first, second := halves[2*bit], halves[2*bit+1]
+		if first == second {
+			return IRCode{}, fmt.Errorf("invalid manchester code for RC5 bit %d", bit)
+		}
+		data <<= 1
+		if second {
+			data |= 1
+		}
+	}
+
+	command := uint8(data & 0x3F)
+	if data&(1<<12) == 0 {
+		command |= 0x40
+	}
+
+	return IRCode{
+		Protocol: IRProtocolRC5,
+		Address:  (data >> 6) & 0x1F,
+		Command:  command,
+		Toggle:   data&(1<<11) != 0,
+	}, nil
+}
+
+func irDecodeSony(pulses []time.Duration) (IRCode, error) {
+	var protocol IRProtocol
+	switch len(pulses) {
+	case 25:
+		protocol = IRProtocolSony12
+	case 31:
+		protocol = IRProtocolSony15
+	case 41:
+		protocol = IRProtocolSony20
+	default:
+		return IRCode{}, fmt.Errorf("invalid Sony frame with %d pulses", len(pulses))
+	}
+
+	var data uint32
+	for bit := 0; bit < len(pulses)/2; bit++ {
+		space, mark := pulses[1+2*bit], pulses[2+2*bit]
+		if !irMatch(space, irSonyUnit) {
+			return IRCode{}, fmt.Errorf("invalid space of %s for Sony bit %d", space, bit)
+		}
+		switch {
+		case irMatch(mark, 2*irSonyUnit):
+			data |= 1 << bit
+		case !irMatch(mark, irSonyUnit):
+			return IRCode{}, fmt.Errorf("invalid mark of %s for Sony bit %d", mark, bit)
+		}
+	}
+
+	return IRCode{Protocol: protocol, Address: uint16(data >> 7), Command: uint8(data & 0x7F)}, nil
+}
+
+// irSonyBits returns the count of bits and the maximum address of the Sony protocol
+func irSonyBits(protocol IRProtocol) (int, uint16) {
+	switch protocol {
+	case IRProtocolSony15:
+		return 15, 0xFF
+	case IRProtocolSony20:
+		return 20, 0x1FFF
+	default:
+		return 12, 0x1F
+	}
+}
+
+// irMatch returns true, if the pulse is within a tolerance of 30% of the wanted duration
+func irMatch(pulse, want time.Duration) bool {
+	return pulse >= want*7/10 && pulse <= want*13/10
+}
+
+// irPulseBuilder creates alternating pulses, which start with a mark, from marks and spaces
+type irPulseBuilder struct {
+	pulses []time.Duration
+	mark   bool // level of the last pulse
+}
+
+// add appends the duration to the last pulse, if the level is the same, a leading space is skipped
+func (b *irPulseBuilder) add(mark bool, duration time.Duration) {
+	if len(b.pulses) > 0 && b.mark == mark {
+		b.pulses[len(b.pulses)-1] += duration
+		return
+	}
+	if len(b.pulses) == 0 && !mark {
+		return
+	}
+
+	b.pulses = append(b.pulses, duration)
+	b.mark = mark
+}
+
+// finish returns the pulses without a trailing space
+func (b *irPulseBuilder) finish() []time.Duration {
+	if len(b.pulses) > 0 && !b.mark {
+		return b.pulses[:len(b.pulses)-1]
+	}
+
+	return b.pulses
+}
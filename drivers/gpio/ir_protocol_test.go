@@ -0,0 +1,175 @@
+package gpio
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// irTestPulses creates pulses from multiples of the given unit
+func irTestPulses(unit time.Duration, multiples ...int) []time.Duration {
+	pulses := make([]time.Duration, len(multiples))
+	for i, m := range multiples {
+		pulses[i] = time.Duration(m) * unit
+	}
+	return pulses
+}
+
+// irTestDistort simulates a typical receiver, which lengthens the marks and shortens the spaces
+func irTestDistort(pulses []time.Duration, offset time.Duration) []time.Duration {
+	distorted := make([]time.Duration, len(pulses))
+	for i, pulse := range pulses {
+		if i%2 == 0 {
+			distorted[i] = pulse + offset
+		} else {
+			distorted[i] = pulse - offset
+		}
+	}
+	return distorted
+}
+
+func TestIREncode(t *testing.T) {
+	tests := map[string]struct {
+		code    IRCode
+		want    []time.Duration
+		wantLen int
+		wantErr string
+	}{
+		"nec": {
+			code:    IRCode{Protocol: IRProtocolNEC, Address: 0x04, Command: 0x08},
+			wantLen: 67,
+		},
+		"nec_repeat": {
+			code: IRCode{Protocol: IRProtocolNEC, Address: 0x04, Command: 0x08, Repeat: true},
+			want: irTestPulses(irNECUnit, 16, 4, 1),
+		},
+		"rc5": {
+			code: IRCode{Protocol: IRProtocolRC5, Address: 0x05, Command: 0x23},
+			want: irTestPulses(irRC5HalfBit, 1, 1, 2, 1, 1, 1, 1, 2, 2, 2, 1, 1, 2, 1, 1, 1, 1, 2, 1, 1, 1),
+		},
+		"sony12": {
+			code: IRCode{Protocol: IRProtocolSony12, Address: 0x01, Command: 0x15},
+			want: irTestPulses(irSonyUnit, 4, 1, 2, 1, 1, 1, 2, 1, 1, 1, 2, 1, 1, 1, 1, 1, 2, 1, 1, 1, 1, 1, 1, 1, 1),
+		},
+		"error_nec_address": {
+			code:    IRCode{Protocol: IRProtocolNEC, Address: 0x100},
+			wantErr: "address 0x100 is too big for NEC",
+		},
+		"error_rc5_address": {
+			code:    IRCode{Protocol: IRProtocolRC5, Address: 0x20},
+			wantErr: "address 0x20 or command 0x0 is too big for RC5",
+		},
+		"error_sony_command": {
+			code:    IRCode{Protocol: IRProtocolSony15, Command: 0x80},
+			wantErr: "address 0x0 or command 0x80 is too big for Sony 15 bit",
+		},
+		"error_protocol": {
+			code:    IRCode{Address: 0x01},
+			wantErr: "unknown IR protocol unknown (0)",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// act
+			got, err := IREncode(tc.code)
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			if tc.want != nil {
+				assert.Equal(t, tc.want, got)
+			} else {
+				assert.Len(t, got, tc.wantLen)
+			}
+		})
+	}
+}
+
+func TestIRDecode(t *testing.T) {
+	tests := map[string]struct {
+		code IRCode
+	}{
+		"nec":          {code: IRCode{Protocol: IRProtocolNEC, Address: 0x04, Command: 0x08}},
+		"nec_repeat":   {code: IRCode{Protocol: IRProtocolNEC, Repeat: true}},
+		"nec_extended": {code: IRCode{Protocol: IRProtocolNECExtended, Address: 0x1234, Command: 0xFF}},
+		"rc5":          {code: IRCode{Protocol: IRProtocolRC5, Address: 0x05, Command: 0x23}},
+		"rc5_toggle":   {code: IRCode{Protocol: IRProtocolRC5, Address: 0x1F, Command: 0x00, Toggle: true}},
+		"rc5x":         {code: IRCode{Protocol: IRProtocolRC5, Address: 0x00, Command: 0x7F}},
+		"sony12":       {code: IRCode{Protocol: IRProtocolSony12, Address: 0x01, Command: 0x15}},
+		"sony15":       {code: IRCode{Protocol: IRProtocolSony15, Address: 0x97, Command: 0x7F}},
+		"sony20":       {code: IRCode{Protocol: IRProtocolSony20, Address: 0x1FFF, Command: 0x01}},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			pulses, err := IREncode(tc.code)
+			require.NoError(t, err)
+			// act
+			got, err := IRDecode(irTestDistort(pulses, 100*time.Microsecond))
+			// assert
+			require.NoError(t, err)
+			assert.Equal(t, tc.code, got)
+		})
+	}
+}
+
+func TestIRDecode_error(t *testing.T) {
+	necPulses := func(modify func([]time.Duration) []time.Duration) []time.Duration {
+		pulses, _ := IREncode(IRCode{Protocol: IRProtocolNEC, Address: 0x04, Command: 0x08})
+		return modify(pulses)
+	}
+	tests := map[string]struct {
+		pulses  []time.Duration
+		wantErr string
+	}{
+		"no_pulses": {
+			wantErr: "no pulses to decode",
+		},
+		"unknown_protocol": {
+			pulses:  []time.Duration{5 * time.Millisecond, time.Millisecond},
+			wantErr: "unknown IR protocol with first mark of 5ms",
+		},
+		"nec_pulse_count": {
+			pulses:  necPulses(func(p []time.Duration) []time.Duration { return p[:65] }),
+			wantErr: "invalid NEC frame with 65 pulses",
+		},
+		"nec_space": {
+			pulses: necPulses(func(p []time.Duration) []time.Duration {
+				p[5] = 1125 * time.Microsecond
+				return p
+			}),
+			wantErr: "invalid space of 1.125ms for NEC bit 1",
+		},
+		"nec_command_inverse": {
+			pulses: necPulses(func(p []time.Duration) []time.Duration {
+				p[3+2*16] = 3 * irNECUnit // bit 0 of the command
+				return p
+			}),
+			wantErr: "NEC command 0x09 does not match its inverse 0xF7",
+		},
+		"rc5_pulse": {
+			pulses:  irTestPulses(irRC5HalfBit, 1, 3),
+			wantErr: "invalid pulse of 2.667ms for RC5",
+		},
+		"rc5_half_bits": {
+			pulses:  irTestPulses(irRC5HalfBit, 1, 1, 1),
+			wantErr: "invalid RC5 frame with 4 half bits",
+		},
+		"sony_pulse_count": {
+			pulses:  irTestPulses(irSonyUnit, 4, 1, 1),
+			wantErr: "invalid Sony frame with 3 pulses",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// act
+			_, err := IRDecode(tc.pulses)
+			// assert
+			require.EqualError(t, err, tc.wantErr)
+		})
+	}
+}
@@ -0,0 +1,240 @@
+package gpio
+
+import (
+	"fmt"
+	"time"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/system"
+)
+
+const (
+	// the longest space within a frame is the 4.5 ms after the NEC header mark
+	irReceiverDefaultFrameGap = 5 * time.Millisecond
+	// a frame with the same code is a repetition, if received within this time after the previous frame
+	irReceiverRepeatWindow = 200 * time.Millisecond
+)
+
+// irReceiverOptionApplier needs to be implemented by each configurable option type
+type irReceiverOptionApplier interface {
+	apply(cfg *irReceiverConfiguration)
+}
+
+// irReceiverConfiguration contains all changeable attributes of the driver.
+type irReceiverConfiguration struct {
+	frameGap time.Duration
+}
+
+// irReceiverFrameGapOption is the type for applying another frame gap to the configuration
+type irReceiverFrameGapOption time.Duration
+
+// irReceiverEdge is a detected edge of the receiver output
+type irReceiverEdge struct {
+	timestamp time.Duration
+	falling   bool
+}
+
+// IRReceiverDriver is a driver for demodulating infrared receivers, e.g. TSOP38238 or VS1838B. The output of the
+// receiver is low, while the carrier is detected (mark). The frames of the protocols NEC (also extended), RC5 and Sony
+// SIRC are decoded.
+type IRReceiverDriver struct {
+	*driver
+	irCfg *irReceiverConfiguration
+	gobot.Eventer
+	pin  gobot.DigitalPinner
+	halt chan struct{}
+}
+
+// irReceiverState contains the state of the frame recognition, only used by the receiving go routine
+type irReceiverState struct {
+	pulses    []time.Duration
+	lastEdge  time.Duration
+	inFrame   bool
+	lastCode  IRCode
+	lastFrame time.Time
+}
+
+// NewIRReceiverDriver creates a new driver for an infrared receiver at the given pin. The adaptor needs to provide
+// access to the pin (interface gobot.DigitalPinnerProvider). The edge detection with timestamps of the "cdev" GPIO
+// character device is used for measuring the pulses, so sysfs is not supported.
+//
+// Supported options:
+//
+//	"WithName"
+//	"WithIRReceiverFrameGap"
+func NewIRReceiverDriver(a gobot.Adaptor, pin string, opts ...interface{}) *IRReceiverDriver {
+	d := &IRReceiverDriver{
+		driver: newDriver(a, "IRReceiver", withPin(pin)),
+		irCfg:  &irReceiverConfiguration{frameGap: irReceiverDefaultFrameGap},
+	}
+	d.afterStart = d.initialize
+	d.beforeHalt = d.shutdown
+
+	for _, opt := range opts {
+		switch o := opt.(type) {
+		case optionApplier:
+			o.apply(d.driverCfg)
+		case irReceiverOptionApplier:
+			o.apply(d.irCfg)
+		default:
+			panic(fmt.Sprintf("'%s' can not be applied on '%s'", opt, d.driverCfg.name))
+		}
+	}
+
+	return d
+}
+
+// WithIRReceiverFrameGap change the minimum space after the last mark, which ends a frame, from default 5 ms to the
+// given value.
+func WithIRReceiverFrameGap(gap time.Duration) irReceiverOptionApplier {
+	return irReceiverFrameGapOption(gap)
+}
+
+// initialize gets the pin, activates the edge detection and starts the receiving.
+//
+// Emits the Events:
+//
+//	Code IRCode - On a decoded frame of a new key press
+//	Repeat IRCode - On a decoded frame or NEC repeat frame of a held key, data is the code of the key
+//	RawPulses []time.Duration - On each received frame, also if it can not be decoded, the pulses start with a mark
+func (d *IRReceiverDriver) initialize() error {
+	if d.irCfg.frameGap <= 0 {
+		return fmt.Errorf("the frame gap for IR receiver needs to be greater than zero")
+	}
+
+	provider, ok := d.connection.(gobot.DigitalPinnerProvider)
+	if !ok {
+		return fmt.Errorf("the adaptor of '%s' does not provide access to digital pins", d.driverCfg.name)
+	}
+
+	pin, err := provider.DigitalPin(d.driverCfg.pin)
+	if err != nil {
+		return fmt.Errorf("error on get receiver pin: %v", err)
+	}
+
+	d.Eventer = gobot.NewEventer()
+	d.AddEvent(IRCodeReceived)
+	d.AddEvent(IRCodeRepeated)
+	d.AddEvent(IRRawPulses)
+
+	halt := make(chan struct{})
+	edges := make(chan irReceiverEdge, 256)
+	onEdge := func(_ int, timestamp time.Duration, detectedEdge string, _ uint32, _ uint32) {
+		select {
+		case edges <- irReceiverEdge{timestamp: timestamp, falling: detectedEdge == system.DigitalPinEventFallingEdge}:
+		case <-halt:
+		}
+	}
+
+	if err := pin.ApplyOptions(system.WithPinDirectionInput(), system.WithPinEventOnBothEdges(onEdge)); err != nil {
+		return fmt.Errorf("error on apply options for receiver pin: %v", err)
+	}
+
+	d.pin = pin
+	d.halt = halt
+
+	go d.run(edges, halt)
+
+	return nil
+}
+
+// shutdown stops the receiving
+func (d *IRReceiverDriver) shutdown() error {
+	if d.halt != nil {
+		close(d.halt)
+		d.halt = nil
+	}
+
+	// note: Unexport() of the pin will be done on adaptor.Finalize()
+
+	return nil
+}
+
+// run collects the edges and processes the frame, when no edge follows the last mark within the frame gap
+func (d *IRReceiverDriver) run(edges <-chan irReceiverEdge, halt <-chan struct{}) {
+	timer := time.NewTimer(time.Hour)
+	timer.Stop()
+	defer timer.Stop()
+
+	s := irReceiverState{}
+	for {
+		select {
+		case e := <-edges:
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+			d.edge(&s, e)
+			if s.inFrame && !e.falling {
+				timer.Reset(d.irCfg.frameGap)
+			}
+		case <-timer.C:
+			d.frameEnd(&s, time.Now())
+		case <-halt:
+			return
+		}
+	}
+}
+
+// edge adds the pulse, which ends with the given edge, to the current frame
+func (d *IRReceiverDriver) edge(s *irReceiverState, e irReceiverEdge) {
+	if !s.inFrame {
+		if e.falling {
+			s.inFrame = true
+			s.pulses = nil
+			s.lastEdge = e.timestamp
+		}
+		return
+	}
+
+	s.pulses = append(s.pulses, e.timestamp-s.lastEdge)
+	s.lastEdge = e.timestamp
+}
+
+// frameEnd publishes the pulses and the decoded code of the frame
+func (d *IRReceiverDriver) frameEnd(s *irReceiverState, now time.Time) {
+	if !s.inFrame {
+		return
+	}
+	s.inFrame = false
+
+	d.Publish(IRRawPulses, s.pulses)
+
+	code, err := IRDecode(s.pulses)
+	if err != nil {
+		return
+	}
+
+	withinWindow := s.lastCode.Protocol != 0 && now.Sub(s.lastFrame) <= irReceiverRepeatWindow
+	s.lastFrame = now
+
+	if code.Repeat {
+		if !withinWindow || (s.lastCode.Protocol != IRProtocolNEC && s.lastCode.Protocol != IRProtocolNECExtended) {
+			return
+		}
+		repeated := s.lastCode
+		repeated.Repeat = true
+		d.Publish(IRCodeRepeated, repeated)
+		return
+	}
+
+	code.Repeat = withinWindow && code == s.lastCode
+	if code.Repeat {
+		d.Publish(IRCodeRepeated, code)
+	} else {
+		d.Publish(IRCodeReceived, code)
+	}
+
+	code.Repeat = false
+	s.lastCode = code
+}
+
+func (o irReceiverFrameGapOption) String() string {
+	return "frame gap option for IR receivers"
+}
+
+func (o irReceiverFrameGapOption) apply(cfg *irReceiverConfiguration) {
+	cfg.frameGap = time.Duration(o)
+}
@@ -0,0 +1,312 @@
+//nolint:forcetypeassert // ok here
+package gpio
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/drivers/aio"
+	"gobot.io/x/gobot/v2/system"
+)
+
+var _ gobot.Driver = (*IRReceiverDriver)(nil)
+
+func initTestIRReceiverDriverWithStubbedAdaptor() (*IRReceiverDriver, *gpioTestWaveformPin) {
+	a := newGpioTestAdaptor()
+	pin := a.addWaveformPin("7")
+	d := NewIRReceiverDriver(a, "7")
+	if err := d.Start(); err != nil {
+		panic(err)
+	}
+	return d, pin
+}
+
+// irTestEdges creates the edges of an active low receiver for the given pulses, beginning at the given time
+func irTestEdges(start time.Duration, pulses []time.Duration) []irReceiverEdge {
+	edges := []irReceiverEdge{{timestamp: start, falling: true}}
+	for i, pulse := range pulses {
+		start += pulse
+		edges = append(edges, irReceiverEdge{timestamp: start, falling: i%2 != 0})
+	}
+	return edges
+}
+
+func TestNewIRReceiverDriver(t *testing.T) {
+	// arrange
+	a := newGpioTestAdaptor()
+	// act
+	d := NewIRReceiverDriver(a, "7")
+	// assert
+	assert.IsType(t, &IRReceiverDriver{}, d)
+	// assert: gpio.driver attributes
+	require.NotNil(t, d.driver)
+	assert.True(t, strings.HasPrefix(d.driverCfg.name, "IRReceiver"))
+	assert.Equal(t, "7", d.driverCfg.pin)
+	assert.Equal(t, a, d.connection)
+	assert.NotNil(t, d.afterStart)
+	assert.NotNil(t, d.beforeHalt)
+	assert.NotNil(t, d.Commander)
+	assert.NotNil(t, d.mutex)
+	// assert: driver specific attributes
+	assert.Nil(t, d.Eventer) // will be created on initialize
+	assert.Nil(t, d.halt)    // will be created on initialize
+	assert.Nil(t, d.pin)     // will be set on initialize
+	require.NotNil(t, d.irCfg)
+	assert.Equal(t, 5*time.Millisecond, d.irCfg.frameGap)
+}
+
+func TestNewIRReceiverDriver_options(t *testing.T) {
+	// This is a general test, that options are applied in constructor by using the common WithName() option, least one
+	// option of this driver and one of another driver (which should lead to panic). Further tests for options can also
+	// be done by call of "WithOption(val).apply(cfg)".
+	// arrange
+	const (
+		myName = "remote"
+	)
+	panicFunc := func() {
+		NewIRReceiverDriver(newGpioTestAdaptor(), "7", WithName("crazy"),
+			aio.WithActuatorScaler(func(float64) int { return 0 }))
+	}
+	// act
+	d := NewIRReceiverDriver(newGpioTestAdaptor(), "7", WithName(myName), WithIRReceiverFrameGap(8*time.Millisecond))
+	// assert
+	assert.Equal(t, myName, d.Name())
+	assert.Equal(t, 8*time.Millisecond, d.irCfg.frameGap)
+	assert.PanicsWithValue(t, "'scaler option for analog actuators' can not be applied on 'crazy'", panicFunc)
+}
+
+func TestIRReceiverStart(t *testing.T) {
+	// arrange & act
+	d, pin := initTestIRReceiverDriverWithStubbedAdaptor()
+	defer func() { _ = d.Halt() }()
+	// assert
+	assert.Equal(t, system.IN, pin.currentDirection())
+	pin.mtx.Lock()
+	assert.NotNil(t, pin.edgeHandler)
+	pin.mtx.Unlock()
+	assert.NotNil(t, d.Eventer)
+	assert.NotNil(t, d.halt)
+}
+
+func TestIRReceiverStart_error(t *testing.T) {
+	tests := map[string]struct {
+		adaptor func() gobot.Adaptor
+		opts    []interface{}
+		wantErr string
+	}{
+		"error_frame_gap": {
+			adaptor: func() gobot.Adaptor { return newGpioTestAdaptor() },
+			opts:    []interface{}{WithIRReceiverFrameGap(0)},
+			wantErr: "the frame gap for IR receiver needs to be greater than zero",
+		},
+		"error_no_pin_provider": {
+			adaptor: func() gobot.Adaptor { return &gpioTestBareAdaptor{} },
+			wantErr: "the adaptor of 'IRReceiver",
+		},
+		"error_pin": {
+			adaptor: func() gobot.Adaptor { return newGpioTestAdaptor() },
+			wantErr: "error on get receiver pin: pin '7' not found in 'gpio_test_adaptor'",
+		},
+		"error_apply": {
+			adaptor: func() gobot.Adaptor {
+				a := newGpioTestAdaptor()
+				a.addWaveformPin("7").applyErr = errors.New("apply error")
+				return a
+			},
+			wantErr: "error on apply options for receiver pin: apply error",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d := NewIRReceiverDriver(tc.adaptor(), "7", tc.opts...)
+			// act
+			err := d.Start()
+			// assert
+			require.ErrorContains(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestIRReceiverHalt(t *testing.T) {
+	// arrange
+	d, _ := initTestIRReceiverDriverWithStubbedAdaptor()
+	halt := d.halt
+	// act
+	err := d.Halt()
+	// assert
+	require.NoError(t, err)
+	_, open := <-halt
+	assert.False(t, open)
+	assert.Nil(t, d.halt)
+	require.NoError(t, d.Halt())
+}
+
+func TestIRReceiverReceive(t *testing.T) {
+	// arrange
+	d, pin := initTestIRReceiverDriverWithStubbedAdaptor()
+	defer func() { _ = d.Halt() }()
+	events := d.Subscribe()
+	pin.mtx.Lock()
+	handler := pin.edgeHandler
+	pin.mtx.Unlock()
+	frame, _ := IREncode(IRCode{Protocol: IRProtocolNEC, Address: 0x04, Command: 0x08})
+	repeat, _ := IREncode(IRCode{Protocol: IRProtocolNEC, Repeat: true})
+	want := []struct {
+		name string
+		data interface{}
+	}{
+		{name: IRRawPulses, data: frame},
+		{name: IRCodeReceived, data: IRCode{Protocol: IRProtocolNEC, Address: 0x04, Command: 0x08}},
+		{name: IRRawPulses, data: repeat},
+		{name: IRCodeRepeated, data: IRCode{Protocol: IRProtocolNEC, Address: 0x04, Command: 0x08, Repeat: true}},
+	}
+	// act: the frame gap is detected by the real time
+	for i, pulses := range [][]time.Duration{frame, repeat} {
+		for _, e := range irTestEdges(time.Duration(i)*irNECFramePeriod, pulses) {
+			detectedEdge := system.DigitalPinEventRisingEdge
+			if e.falling {
+				detectedEdge = system.DigitalPinEventFallingEdge
+			}
+			handler(0, e.timestamp, detectedEdge, 0, 0)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	// assert
+	for _, w := range want {
+		select {
+		case evt := <-events:
+			assert.Equal(t, w.name, evt.Name)
+			assert.Equal(t, w.data, evt.Data)
+		case <-time.After(time.Second):
+			t.Fatalf("event '%s' was not published", w.name)
+		}
+	}
+}
+
+func TestIRReceiverFrameEnd(t *testing.T) {
+	type frame struct {
+		at   time.Duration
+		code IRCode
+	}
+	type event struct {
+		name string
+		code IRCode
+	}
+	const ms = time.Millisecond
+	nec := IRCode{Protocol: IRProtocolNEC, Address: 0x04, Command: 0x08}
+	necRepeat := IRCode{Protocol: IRProtocolNEC, Repeat: true}
+	rc5 := IRCode{Protocol: IRProtocolRC5, Address: 0x05, Command: 0x23}
+	rc5Toggled := IRCode{Protocol: IRProtocolRC5, Address: 0x05, Command: 0x23, Toggle: true}
+	sony := IRCode{Protocol: IRProtocolSony12, Address: 0x01, Command: 0x15}
+	tests := map[string]struct {
+		frames []frame
+		want   []event
+	}{
+		"nec_with_repeats": {
+			frames: []frame{{0, nec}, {108 * ms, necRepeat}, {216 * ms, necRepeat}},
+			want: []event{
+				{IRCodeReceived, nec},
+				{IRCodeRepeated, IRCode{Protocol: IRProtocolNEC, Address: 0x04, Command: 0x08, Repeat: true}},
+				{IRCodeRepeated, IRCode{Protocol: IRProtocolNEC, Address: 0x04, Command: 0x08, Repeat: true}},
+			},
+		},
+		"nec_repeat_without_code": {
+			frames: []frame{{0, necRepeat}},
+		},
+		"nec_repeat_after_window": {
+			frames: []frame{{0, nec}, {500 * ms, necRepeat}},
+			want:   []event{{IRCodeReceived, nec}},
+		},
+		"nec_repeat_after_other_protocol": {
+			frames: []frame{{0, sony}, {45 * ms, necRepeat}},
+			want:   []event{{IRCodeReceived, sony}},
+		},
+		"rc5_held_and_pressed_again": {
+			frames: []frame{{0, rc5}, {114 * ms, rc5}, {228 * ms, rc5Toggled}},
+			want: []event{
+				{IRCodeReceived, rc5},
+				{IRCodeRepeated, IRCode{Protocol: IRProtocolRC5, Address: 0x05, Command: 0x23, Repeat: true}},
+				{IRCodeReceived, rc5Toggled},
+			},
+		},
+		"sony_pressed_twice": {
+			frames: []frame{{0, sony}, {45 * ms, sony}, {1000 * ms, sony}},
+			want: []event{
+				{IRCodeReceived, sony},
+				{IRCodeRepeated, IRCode{Protocol: IRProtocolSony12, Address: 0x01, Command: 0x15, Repeat: true}},
+				{IRCodeReceived, sony},
+			},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d := NewIRReceiverDriver(newGpioTestAdaptor(), "7")
+			d.Eventer = gobot.NewEventer()
+			events := d.Subscribe()
+			s := irReceiverState{}
+			start := time.Now()
+			// act: like the receiving go routine
+			for _, f := range tc.frames {
+				pulses, err := IREncode(f.code)
+				require.NoError(t, err)
+				for _, e := range irTestEdges(f.at, pulses) {
+					d.edge(&s, e)
+				}
+				d.frameEnd(&s, start.Add(f.at))
+			}
+			// assert: each frame publishes the raw pulses
+			var got []event
+			var rawCount int
+			for {
+				var evt *gobot.Event
+				select {
+				case evt = <-events:
+				case <-time.After(10 * time.Millisecond):
+				}
+				if evt == nil {
+					break
+				}
+				if evt.Name == IRRawPulses {
+					rawCount++
+					continue
+				}
+				got = append(got, event{name: evt.Name, code: evt.Data.(IRCode)})
+			}
+			assert.Len(t, tc.frames, rawCount)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestIRReceiverFrameEnd_undecodable(t *testing.T) {
+	// arrange
+	d := NewIRReceiverDriver(newGpioTestAdaptor(), "7")
+	d.Eventer = gobot.NewEventer()
+	events := d.Subscribe()
+	s := irReceiverState{}
+	pulses := []time.Duration{300 * time.Microsecond, 300 * time.Microsecond, 300 * time.Microsecond}
+	// act: the leading rising edge is ignored
+	d.edge(&s, irReceiverEdge{timestamp: 0, falling: false})
+	for _, e := range irTestEdges(time.Millisecond, pulses) {
+		d.edge(&s, e)
+	}
+	d.frameEnd(&s, time.Now())
+	d.frameEnd(&s, time.Now())
+	// assert
+	evt := <-events
+	assert.Equal(t, IRRawPulses, evt.Name)
+	assert.Equal(t, pulses, evt.Data)
+	select {
+	case evt := <-events:
+		assert.Fail(t, "unexpected event", "%s: %v", evt.Name, evt.Data)
+	case <-time.After(10 * time.Millisecond):
+	}
+}
@@ -0,0 +1,209 @@
+package gpio
+
+import (
+	"fmt"
+	"time"
+
+	"gobot.io/x/gobot/v2"
+)
+
+const (
+	irTransmitterDefaultCarrier = 38000 // [Hz]
+	// the duty cycle of the carrier is a third of the period, which is common for IR LEDs
+	irTransmitterDutyDivisor = 3
+)
+
+// irTransmitterOptionApplier needs to be implemented by each configurable option type
+type irTransmitterOptionApplier interface {
+	apply(cfg *irTransmitterConfiguration)
+}
+
+// irTransmitterConfiguration contains all changeable attributes of the driver.
+type irTransmitterConfiguration struct {
+	carrier uint32
+}
+
+// irTransmitterCarrierOption is the type for applying another carrier frequency to the configuration
+type irTransmitterCarrierOption uint32
+
+// IRTransmitterDriver is a driver for an infrared LED, which is modulated with the carrier by a PWM pin. The LED
+// needs a transistor for the typical currents of 50..100 mA. The frames of the protocols NEC (also extended), RC5 and
+// Sony SIRC are encoded.
+type IRTransmitterDriver struct {
+	*driver
+	irCfg  *irTransmitterConfiguration
+	pin    gobot.PWMPinner
+	period uint32 // [ns]
+}
+
+// NewIRTransmitterDriver creates a new driver for an infrared LED at the given PWM pin. The adaptor needs to provide
+// access to the pin (interface gobot.PWMPinnerProvider). The marks are created by switching the duty cycle of the
+// carrier, so the timing depends on the scheduling of the system, which is usually sufficient for the tolerances of
+// the receivers.
+//
+// Supported options:
+//
+//	"WithName"
+//	"WithIRTransmitterCarrier"
+func NewIRTransmitterDriver(a gobot.Adaptor, pin string, opts ...interface{}) *IRTransmitterDriver {
+	d := &IRTransmitterDriver{
+		driver: newDriver(a, "IRTransmitter", withPin(pin)),
+		irCfg:  &irTransmitterConfiguration{carrier: irTransmitterDefaultCarrier},
+	}
+	d.afterStart = d.initialize
+	d.beforeHalt = d.shutdown
+	d.usedPins = func() map[string]string { return map[string]string{d.driverCfg.pin: gobot.PinFunctionPwm} }
+
+	for _, opt := range opts {
+		switch o := opt.(type) {
+		case optionApplier:
+			o.apply(d.driverCfg)
+		case irTransmitterOptionApplier:
+			o.apply(d.irCfg)
+		default:
+			panic(fmt.Sprintf("'%s' can not be applied on '%s'", opt, d.driverCfg.name))
+		}
+	}
+
+	return d
+}
+
+// WithIRTransmitterCarrier change the frequency of the carrier from default 38 kHz to the given value in Hz, e.g.
+// 36 kHz for RC5 or 40 kHz for Sony.
+func WithIRTransmitterCarrier(frequency uint32) irTransmitterOptionApplier {
+	return irTransmitterCarrierOption(frequency)
+}
+
+// Send transmits the frame of the given code followed by the given count of repetitions, like a held key. For NEC the
+// repetitions are sent as repeat frames. Sony devices usually need at least 2 repetitions.
+func (d *IRTransmitterDriver) Send(code IRCode, repeats int) error {
+	frame, err := IREncode(code)
+	if err != nil {
+		return err
+	}
+
+	frames := [][]time.Duration{frame}
+	if repeats > 0 {
+		repeat := frame
+		if code.Protocol == IRProtocolNEC || code.Protocol == IRProtocolNECExtended {
+			code.Repeat = true
+			if repeat, err = IREncode(code); err != nil {
+				return err
+			}
+		}
+		for i := 0; i < repeats; i++ {
+			frames = append(frames, repeat)
+		}
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	start := time.Now()
+	for i, pulses := range frames {
+		irSleepUntil(start.Add(time.Duration(i) * code.Protocol.framePeriod()))
+		if err := d.sendPulses(pulses); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SendRaw transmits the given pulses, which start with a mark (carrier on) and alternate between mark and space,
+// e.g. recorded by the IRReceiverDriver for an unsupported protocol.
+func (d *IRTransmitterDriver) SendRaw(pulses []time.Duration) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.sendPulses(pulses)
+}
+
+// initialize gets the pin and starts the carrier with a duty cycle of zero
+func (d *IRTransmitterDriver) initialize() error {
+	if d.irCfg.carrier == 0 {
+		return fmt.Errorf("the carrier frequency for IR transmitter needs to be greater than zero")
+	}
+
+	provider, ok := d.connection.(gobot.PWMPinnerProvider)
+	if !ok {
+		return fmt.Errorf("the adaptor of '%s' does not provide access to PWM pins", d.driverCfg.name)
+	}
+
+	pin, err := provider.PWMPin(d.driverCfg.pin)
+	if err != nil {
+		return fmt.Errorf("error on get LED pin: %v", err)
+	}
+
+	period := uint32(time.Second) / d.irCfg.carrier
+	if err := pin.SetDutyCycle(0); err != nil {
+		return err
+	}
+	if err := pin.SetPeriod(period); err != nil {
+		return err
+	}
+	if err := pin.SetEnabled(true); err != nil {
+		return err
+	}
+
+	d.pin = pin
+	d.period = period
+
+	return nil
+}
+
+// shutdown switches off the LED and stops the carrier
+func (d *IRTransmitterDriver) shutdown() error {
+	if d.pin == nil {
+		return nil
+	}
+
+	pin := d.pin
+	d.pin = nil
+	if err := pin.SetDutyCycle(0); err != nil {
+		return err
+	}
+
+	return pin.SetEnabled(false)
+}
+
+// sendPulses switches the carrier on for the marks and off for the spaces, the mutex needs to be locked before
+func (d *IRTransmitterDriver) sendPulses(pulses []time.Duration) error {
+	if d.pin == nil {
+		return fmt.Errorf("'%s' is not started", d.driverCfg.name)
+	}
+
+	// the LED is switched off in any case, e.g. on error or an odd count of pulses
+	defer func() { _ = d.pin.SetDutyCycle(0) }()
+
+	next := time.Now()
+	for i, pulse := range pulses {
+		duty := uint32(0)
+		if i%2 == 0 {
+			duty = d.period / irTransmitterDutyDivisor
+		}
+		if err := d.pin.SetDutyCycle(duty); err != nil {
+			return err
+		}
+		// the deadline is calculated from the start, so the delays of the system do not sum up
+		next = next.Add(pulse)
+		irSleepUntil(next)
+	}
+
+	return nil
+}
+
+// irSleepUntil waits until the given time
+func irSleepUntil(t time.Time) {
+	if wait := time.Until(t); wait > 0 {
+		time.Sleep(wait)
+	}
+}
+
+func (o irTransmitterCarrierOption) String() string {
+	return "carrier option for IR transmitters"
+}
+
+func (o irTransmitterCarrierOption) apply(cfg *irTransmitterConfiguration) {
+	cfg.carrier = uint32(o)
+}
@@ -0,0 +1,209 @@
+package gpio
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/drivers/aio"
+)
+
+var _ gobot.Driver = (*IRTransmitterDriver)(nil)
+
+func initTestIRTransmitterDriverWithStubbedAdaptor(opts ...interface{}) (*IRTransmitterDriver, *gpioTestPwmPin) {
+	a := newGpioTestPwmAdaptor()
+	pin := a.addPwmPin("12")
+	d := NewIRTransmitterDriver(a, "12", opts...)
+	if err := d.Start(); err != nil {
+		panic(err)
+	}
+	return d, pin
+}
+
+func TestNewIRTransmitterDriver(t *testing.T) {
+	// arrange
+	a := newGpioTestPwmAdaptor()
+	// act
+	d := NewIRTransmitterDriver(a, "12")
+	// assert
+	assert.IsType(t, &IRTransmitterDriver{}, d)
+	// assert: gpio.driver attributes
+	require.NotNil(t, d.driver)
+	assert.True(t, strings.HasPrefix(d.driverCfg.name, "IRTransmitter"))
+	assert.Equal(t, "12", d.driverCfg.pin)
+	assert.Equal(t, a, d.connection)
+	assert.NotNil(t, d.afterStart)
+	assert.NotNil(t, d.beforeHalt)
+	assert.NotNil(t, d.Commander)
+	assert.NotNil(t, d.mutex)
+	assert.Equal(t, map[string]string{"12": "pwm"}, d.usedPins())
+	// assert: driver specific attributes
+	assert.Nil(t, d.pin) // will be set on initialize
+	require.NotNil(t, d.irCfg)
+	assert.Equal(t, uint32(38000), d.irCfg.carrier)
+}
+
+func TestNewIRTransmitterDriver_options(t *testing.T) {
+	// This is a general test, that options are applied in constructor by using the common WithName() option, least one
+	// option of this driver and one of another driver (which should lead to panic). Further tests for options can also
+	// be done by call of "WithOption(val).apply(cfg)".
+	// arrange
+	const (
+		myName = "tv remote"
+	)
+	panicFunc := func() {
+		NewIRTransmitterDriver(newGpioTestPwmAdaptor(), "12", WithName("crazy"),
+			aio.WithActuatorScaler(func(float64) int { return 0 }))
+	}
+	// act
+	d := NewIRTransmitterDriver(newGpioTestPwmAdaptor(), "12", WithName(myName), WithIRTransmitterCarrier(36000))
+	// assert
+	assert.Equal(t, myName, d.Name())
+	assert.Equal(t, uint32(36000), d.irCfg.carrier)
+	assert.PanicsWithValue(t, "'scaler option for analog actuators' can not be applied on 'crazy'", panicFunc)
+}
+
+func TestIRTransmitterStart(t *testing.T) {
+	// arrange & act
+	d, pin := initTestIRTransmitterDriverWithStubbedAdaptor(WithIRTransmitterCarrier(40000))
+	// assert
+	assert.Equal(t, pin, d.pin)
+	assert.Equal(t, uint32(25000), d.period)
+	assert.Equal(t, uint32(25000), pin.period)
+	assert.True(t, pin.enabled)
+	assert.Equal(t, []uint32{0}, pin.writtenDutyCycles())
+}
+
+func TestIRTransmitterStart_error(t *testing.T) {
+	tests := map[string]struct {
+		adaptor func() gobot.Adaptor
+		opts    []interface{}
+		wantErr string
+	}{
+		"error_carrier": {
+			adaptor: func() gobot.Adaptor { return newGpioTestPwmAdaptor() },
+			opts:    []interface{}{WithIRTransmitterCarrier(0)},
+			wantErr: "the carrier frequency for IR transmitter needs to be greater than zero",
+		},
+		"error_no_pwm_provider": {
+			adaptor: func() gobot.Adaptor { return newGpioTestAdaptor() },
+			wantErr: "does not provide access to PWM pins",
+		},
+		"error_pin": {
+			adaptor: func() gobot.Adaptor { return newGpioTestPwmAdaptor() },
+			wantErr: "error on get LED pin: PWM pin '12' not found in 'gpio_test_adaptor'",
+		},
+		"error_duty_cycle": {
+			adaptor: func() gobot.Adaptor {
+				a := newGpioTestPwmAdaptor()
+				a.addPwmPin("12").dutyErr = errors.New("duty error")
+				return a
+			},
+			wantErr: "duty error",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d := NewIRTransmitterDriver(tc.adaptor(), "12", tc.opts...)
+			// act
+			err := d.Start()
+			// assert
+			require.ErrorContains(t, err, tc.wantErr)
+			assert.Nil(t, d.pin)
+		})
+	}
+}
+
+func TestIRTransmitterHalt(t *testing.T) {
+	// arrange
+	d, pin := initTestIRTransmitterDriverWithStubbedAdaptor()
+	// act
+	err := d.Halt()
+	// assert
+	require.NoError(t, err)
+	assert.False(t, pin.enabled)
+	assert.Equal(t, []uint32{0, 0}, pin.writtenDutyCycles())
+	require.EqualError(t, d.SendRaw([]time.Duration{time.Millisecond}), "'"+d.Name()+"' is not started")
+	require.NoError(t, d.Halt())
+}
+
+func TestIRTransmitterSend(t *testing.T) {
+	const (
+		on  = 26315 / 3 // 38 kHz with a third of the period
+		off = 0
+	)
+	tests := map[string]struct {
+		code      IRCode
+		repeats   int
+		wantDuty  []uint32
+		wantCount int
+		minTime   time.Duration
+		wantErr   string
+	}{
+		"rc5": {
+			code: IRCode{Protocol: IRProtocolRC5, Address: 0x05, Command: 0x23},
+			wantDuty: []uint32{
+				on, off, on, off, on, off, on, off, on, off, on,
+				off, on, off, on, off, on, off, on, off, on, off,
+			},
+			minTime: 27 * irRC5HalfBit,
+		},
+		"sony_with_repeats": {
+			code:      IRCode{Protocol: IRProtocolSony12, Address: 0x01, Command: 0x15},
+			repeats:   2,
+			wantCount: 3 * (25 + 1),
+			minTime:   2*irSonyFramePeriod + 24*irSonyUnit,
+		},
+		"nec_with_repeat": {
+			code:      IRCode{Protocol: IRProtocolNEC, Address: 0x04, Command: 0x08},
+			repeats:   1,
+			wantCount: 67 + 1 + 3 + 1,
+			minTime:   irNECFramePeriod + 21*irNECUnit,
+		},
+		"error_code": {
+			code:    IRCode{Protocol: IRProtocolRC5, Address: 0x20},
+			wantErr: "address 0x20 or command 0x0 is too big for RC5",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, pin := initTestIRTransmitterDriverWithStubbedAdaptor()
+			start := time.Now()
+			// act
+			err := d.Send(tc.code, tc.repeats)
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.GreaterOrEqual(t, time.Since(start), tc.minTime)
+			got := pin.writtenDutyCycles()[1:] // skip initialization
+			if tc.wantDuty != nil {
+				assert.Equal(t, tc.wantDuty, got)
+			} else {
+				assert.Len(t, got, tc.wantCount)
+			}
+			assert.Equal(t, uint32(off), got[len(got)-1])
+		})
+	}
+}
+
+func TestIRTransmitterSendRaw_error(t *testing.T) {
+	// arrange
+	d, pin := initTestIRTransmitterDriverWithStubbedAdaptor()
+	pin.mtx.Lock()
+	pin.dutyErr = errors.New("duty error")
+	pin.mtx.Unlock()
+	// act
+	err := d.SendRaw([]time.Duration{time.Millisecond})
+	// assert
+	require.EqualError(t, err, "duty error")
+}
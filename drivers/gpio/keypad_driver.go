@@ -0,0 +1,605 @@
+package gpio
+
+import (
+	"fmt"
+	"time"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/system"
+)
+
+var (
+	// KeypadKeymap3x4 is the keymap of the common 3x4 membrane keypad, one string for each row.
+	KeypadKeymap3x4 = []string{"123", "456", "789", "*0#"}
+	// KeypadKeymap4x4 is the keymap of the common 4x4 membrane keypad, one string for each row.
+	KeypadKeymap4x4 = []string{"123A", "456B", "789C", "*0#D"}
+)
+
+// keypadOptionApplier needs to be implemented by each configurable option type
+type keypadOptionApplier interface {
+	apply(cfg *keypadConfiguration)
+}
+
+// keypadConfiguration contains all changeable attributes of the driver.
+type keypadConfiguration struct {
+	keymap       []string
+	readInterval time.Duration
+	debounce     time.Duration
+	hold         time.Duration
+	activeHigh   bool
+	columnReader DigitalReader
+	entry        bool
+	entryTimeout time.Duration
+	entrySubmit  string
+	entryClear   string
+}
+
+// keypadKeymapOption is the type for applying another keymap to the configuration
+type keypadKeymapOption []string
+
+// keypadReadIntervalOption is the type for applying another read interval to the configuration
+type keypadReadIntervalOption time.Duration
+
+// keypadDebounceOption is the type for applying another debounce period to the configuration
+type keypadDebounceOption time.Duration
+
+// keypadHoldOption is the type for applying a hold duration to the configuration
+type keypadHoldOption time.Duration
+
+// keypadActiveHighOption is the type for applying the inverted levels of rows and columns to the configuration
+type keypadActiveHighOption bool
+
+// keypadColumnReaderOption is the type for applying another connection for the columns to the configuration
+type keypadColumnReaderOption struct {
+	reader DigitalReader
+}
+
+// keypadEntryOption is the type for applying the entry mode to the configuration
+type keypadEntryOption time.Duration
+
+// keypadEntryKeysOption is the type for applying other keys of the entry mode to the configuration
+type keypadEntryKeysOption struct {
+	submit string
+	clear  string
+}
+
+// KeypadDriver is a driver for matrix keypads, e.g. the common 3x4 or 4x4 membrane keypads. The rows are driven one
+// after another and the columns are read. By default the rows are active low and the columns need pull up resistors,
+// so a pressed key reads 0.
+//
+// If the connection provides access to the row pins (interface gobot.DigitalPinnerProvider), only the active row is
+// an output and the inactive rows are switched to input, so they float. Otherwise, e.g. for pin expanders, the
+// inactive rows are driven to the inactive level, so pressing two keys of the same column connects two outputs. Series
+// resistors of some hundred ohms (or diodes) in the row lines are needed to prevent damage in this case.
+//
+// Keypads without diodes can not distinguish three pressed keys at the corners of a rectangle from four pressed keys.
+// Such scans are ignored and the "ghosting" event is published instead.
+type KeypadDriver struct {
+	*driver
+	keypadCfg *keypadConfiguration
+	gobot.Eventer
+	rowPins []string
+	rows    []gobot.DigitalPinner // nil, if the rows are written by the connection
+	colPins []string
+	pressed []string // currently pressed keys after debouncing
+	halt    chan struct{}
+}
+
+// keypadState contains the state of the key recognition, only used by the reading go routine
+type keypadState struct {
+	raw           []bool    // last scanned keys without ghosting, the index is row * columns + column
+	rawSince      time.Time // time of the last change of the scanned keys
+	ghosting      bool      // the last scan was ignored because of ghosting
+	stable        []bool    // debounced keys
+	pressedAt     []time.Time
+	held          []bool
+	entry         string
+	entryDeadline time.Time
+}
+
+// NewKeypadDriver returns a driver for a matrix keypad with the given row and column pins. The keys are scanned every
+// 10 milliseconds and debounced for 20 milliseconds. For 4 rows and 3 or 4 columns the keymap defaults to
+// KeypadKeymap3x4 or KeypadKeymap4x4, for all other sizes a keymap is needed. The rows are written and the columns are
+// read by the given connection, so pin expanders which implement DigitalWriter and DigitalReader can be used as well.
+//
+// Supported options:
+//
+//	"WithName"
+//	"WithKeypadKeymap"
+//	"WithKeypadPollInterval"
+//	"WithKeypadDebounce"
+//	"WithKeypadHold"
+//	"WithKeypadActiveHigh"
+//	"WithKeypadColumnReader"
+//	"WithKeypadEntry"
+//	"WithKeypadEntryKeys"
+func NewKeypadDriver(a gobot.Connection, rowPins, colPins []string, opts ...interface{}) *KeypadDriver {
+	d := &KeypadDriver{
+		driver: newDriver(a, "Keypad"),
+		keypadCfg: &keypadConfiguration{
+			keymap:       defaultKeypadKeymap(len(rowPins), len(colPins)),
+			readInterval: 10 * time.Millisecond,
+			debounce:     20 * time.Millisecond,
+			entrySubmit:  "#",
+			entryClear:   "*",
+		},
+		rowPins: rowPins,
+		colPins: colPins,
+	}
+	d.afterStart = d.initialize
+	d.beforeHalt = d.shutdown
+	d.usedPins = func() map[string]string {
+		pins := make(map[string]string)
+		for _, pin := range d.rowPins {
+			pins[pin] = gobot.PinFunctionGpio
+		}
+		if d.keypadCfg.columnReader == nil {
+			for _, pin := range d.colPins {
+				pins[pin] = gobot.PinFunctionGpio
+			}
+		}
+		return pins
+	}
+
+	for _, opt := range opts {
+		switch o := opt.(type) {
+		case optionApplier:
+			o.apply(d.driverCfg)
+		case keypadOptionApplier:
+			o.apply(d.keypadCfg)
+		default:
+			panic(fmt.Sprintf("'%s' can not be applied on '%s'", opt, d.driverCfg.name))
+		}
+	}
+
+	d.AddCommand("PressedKeys", func(params map[string]interface{}) interface{} {
+		return d.PressedKeys()
+	})
+
+	return d
+}
+
+// WithKeypadKeymap sets the keys of the keypad, one string for each row with one character for each column.
+func WithKeypadKeymap(keymap ...string) keypadOptionApplier {
+	return keypadKeymapOption(keymap)
+}
+
+// WithKeypadPollInterval change the asynchronous cyclic scan interval from default 10ms to the given value.
+func WithKeypadPollInterval(interval time.Duration) keypadOptionApplier {
+	return keypadReadIntervalOption(interval)
+}
+
+// WithKeypadDebounce change the period, for which the scanned keys needs to be stable before they are accepted, from
+// default 20ms to the given value. Zero means no debouncing.
+func WithKeypadDebounce(period time.Duration) keypadOptionApplier {
+	return keypadDebounceOption(period)
+}
+
+// WithKeypadHold activates the "key-hold" event, which is published once, when a key is held for the given duration.
+func WithKeypadHold(duration time.Duration) keypadOptionApplier {
+	return keypadHoldOption(duration)
+}
+
+// WithKeypadActiveHigh is used for keypads with pull down resistors at the columns, so the rows are driven high and
+// a pressed key reads 1.
+func WithKeypadActiveHigh() keypadOptionApplier {
+	return keypadActiveHighOption(true)
+}
+
+// WithKeypadColumnReader is used to read the columns by another connection than the rows are written, e.g. the rows
+// are connected to a 74HC595 and the columns to a 74HC165.
+func WithKeypadColumnReader(reader DigitalReader) keypadOptionApplier {
+	return keypadColumnReaderOption{reader: reader}
+}
+
+// WithKeypadEntry activates the entry mode, which assembles the pressed keys to a code, e.g. a PIN. The code is
+// published by the "entry" event, when the submit key (default "#") is pressed. The clear key (default "*") discards
+// the keys entered so far. If no key is pressed within the given timeout, the keys entered so far are discarded and
+// published by the "entry-timeout" event. A timeout of zero means no timeout.
+func WithKeypadEntry(timeout time.Duration) keypadOptionApplier {
+	return keypadEntryOption(timeout)
+}
+
+// WithKeypadEntryKeys change the submit and clear keys of the entry mode from default "#" and "*" to the given keys.
+// An empty clear key means, that there is no clear key.
+func WithKeypadEntryKeys(submitKey, clearKey string) keypadOptionApplier {
+	return keypadEntryKeysOption{submit: submitKey, clear: clearKey}
+}
+
+// PressedKeys returns the currently pressed keys after debouncing in the order of the keymap.
+func (d *KeypadDriver) PressedKeys() []string {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return append([]string(nil), d.pressed...)
+}
+
+// initialize the driver, sets all rows inactive and starts the cyclic scan.
+//
+// Emits the Events:
+//
+//	KeyDown string - On key press, data is the key
+//	KeyUp string - On key release, data is the key
+//	KeyHold string - When the key is held for the hold duration, data is the key
+//	Ghosting []string - When the scanned keys are ambiguous, data are the scanned keys
+//	Entry string - In entry mode on the submit key, data is the entered code
+//	EntryTimeout string - In entry mode on timeout, data are the keys entered so far
+//	Error error - On scan error
+func (d *KeypadDriver) initialize() error {
+	if d.keypadCfg.readInterval <= 0 {
+		return fmt.Errorf("the read interval for keypad needs to be greater than zero")
+	}
+	if len(d.rowPins) == 0 || len(d.colPins) == 0 {
+		return fmt.Errorf("at least one row and one column is needed for '%s'", d.driverCfg.name)
+	}
+	if err := d.validateKeymap(); err != nil {
+		return err
+	}
+
+	d.rows = nil
+	if provider, ok := d.connection.(gobot.DigitalPinnerProvider); ok {
+		for _, pin := range d.rowPins {
+			row, err := provider.DigitalPin(pin)
+			if err != nil {
+				return fmt.Errorf("error on get row pin: %v", err)
+			}
+			d.rows = append(d.rows, row)
+		}
+	}
+
+	for r := range d.rowPins {
+		if err := d.setRow(r, false); err != nil {
+			return err
+		}
+	}
+
+	d.Eventer = gobot.NewEventer()
+	d.AddEvent(KeypadKeyDown)
+	d.AddEvent(KeypadKeyUp)
+	d.AddEvent(KeypadKeyHold)
+	d.AddEvent(KeypadGhosting)
+	d.AddEvent(KeypadEntry)
+	d.AddEvent(KeypadEntryTimeout)
+	d.AddEvent(Error)
+
+	d.pressed = nil
+	halt := make(chan struct{})
+	d.halt = halt
+
+	go d.run(halt)
+
+	return nil
+}
+
+func (d *KeypadDriver) shutdown() error {
+	if d.halt == nil {
+		return nil
+	}
+
+	close(d.halt)
+	d.halt = nil
+
+	return nil
+}
+
+// run scans the keys at the read interval and processes them until halt
+func (d *KeypadDriver) run(halt <-chan struct{}) {
+	ticker := time.NewTicker(d.keypadCfg.readInterval)
+	defer ticker.Stop()
+
+	s := d.newState()
+	for {
+		select {
+		case <-ticker.C:
+			now := time.Now()
+			keys, err := d.scan()
+			if err != nil {
+				d.Publish(Error, err)
+			} else {
+				d.input(s, keys, now)
+			}
+			d.elapse(s, now)
+		case <-halt:
+			return
+		}
+	}
+}
+
+// scan activates one row after another and reads the columns, the index of the keys is row * columns + column
+func (d *KeypadDriver) scan() ([]bool, error) {
+	read := d.digitalRead
+	if d.keypadCfg.columnReader != nil {
+		read = d.keypadCfg.columnReader.DigitalRead
+	}
+
+	keys := make([]bool, len(d.rowPins)*len(d.colPins))
+	for r := range d.rowPins {
+		if err := d.setRow(r, true); err != nil {
+			return nil, err
+		}
+		for c, colPin := range d.colPins {
+			val, err := read(colPin)
+			if err != nil {
+				_ = d.setRow(r, false)
+				return nil, err
+			}
+			keys[r*len(d.colPins)+c] = byte(val) == d.level(true)
+		}
+		if err := d.setRow(r, false); err != nil {
+			return nil, err
+		}
+	}
+
+	return keys, nil
+}
+
+// setRow drives the row to the active level or deactivates it, an inactive row floats if the pin is accessible,
+// otherwise it is driven to the inactive level
+func (d *KeypadDriver) setRow(r int, active bool) error {
+	if d.rows == nil {
+		return d.digitalWrite(d.rowPins[r], d.level(active))
+	}
+
+	if active {
+		return d.rows[r].ApplyOptions(system.WithPinDirectionOutput(int(d.level(true))))
+	}
+	return d.rows[r].ApplyOptions(system.WithPinDirectionInput())
+}
+
+// newState creates the state with all keys released
+func (d *KeypadDriver) newState() *keypadState {
+	count := len(d.rowPins) * len(d.colPins)
+	return &keypadState{
+		raw:       make([]bool, count),
+		stable:    make([]bool, count),
+		pressedAt: make([]time.Time, count),
+		held:      make([]bool, count),
+	}
+}
+
+// input processes the scanned keys, ambiguous scans are ignored
+func (d *KeypadDriver) input(s *keypadState, keys []bool, now time.Time) {
+	if d.isGhosting(keys) {
+		if !s.ghosting {
+			s.ghosting = true
+			d.Publish(KeypadGhosting, d.keyNames(keys))
+		}
+		return
+	}
+	s.ghosting = false
+
+	if equalKeys(keys, s.raw) {
+		return
+	}
+
+	copy(s.raw, keys)
+	s.rawSince = now
+}
+
+// elapse accepts the scanned keys after the debounce period and processes the timed events
+func (d *KeypadDriver) elapse(s *keypadState, now time.Time) {
+	if !equalKeys(s.raw, s.stable) && !now.Before(s.rawSince.Add(d.keypadCfg.debounce)) {
+		d.change(s, now)
+	}
+
+	if d.keypadCfg.hold > 0 {
+		for i, pressed := range s.stable {
+			if pressed && !s.held[i] && !now.Before(s.pressedAt[i].Add(d.keypadCfg.hold)) {
+				s.held[i] = true
+				d.Publish(KeypadKeyHold, d.keyName(i))
+			}
+		}
+	}
+
+	if s.entry != "" && d.keypadCfg.entryTimeout > 0 && !now.Before(s.entryDeadline) {
+		entry := s.entry
+		s.entry = ""
+		d.Publish(KeypadEntryTimeout, entry)
+	}
+}
+
+// change accepts the scanned keys, the released keys are published before the pressed ones
+func (d *KeypadDriver) change(s *keypadState, now time.Time) {
+	var pressed []string
+	for i := range s.stable {
+		if s.stable[i] && !s.raw[i] {
+			s.stable[i] = false
+			d.Publish(KeypadKeyUp, d.keyName(i))
+		}
+		if s.raw[i] {
+			pressed = append(pressed, d.keyName(i))
+		}
+	}
+
+	d.mutex.Lock()
+	d.pressed = pressed
+	d.mutex.Unlock()
+
+	for i := range s.stable {
+		if !s.stable[i] && s.raw[i] {
+			s.stable[i] = true
+			s.pressedAt[i] = now
+			s.held[i] = false
+			key := d.keyName(i)
+			d.Publish(KeypadKeyDown, key)
+			d.enter(s, key, now)
+		}
+	}
+}
+
+// enter processes the pressed key in entry mode
+func (d *KeypadDriver) enter(s *keypadState, key string, now time.Time) {
+	if !d.keypadCfg.entry {
+		return
+	}
+
+	switch key {
+	case d.keypadCfg.entrySubmit:
+		entry := s.entry
+		s.entry = ""
+		d.Publish(KeypadEntry, entry)
+	case d.keypadCfg.entryClear:
+		s.entry = ""
+	default:
+		s.entry += key
+		s.entryDeadline = now.Add(d.keypadCfg.entryTimeout)
+	}
+}
+
+// isGhosting returns true, if the pressed keys contain the corners of a rectangle
+func (d *KeypadDriver) isGhosting(keys []bool) bool {
+	cols := len(d.colPins)
+	for r1 := 0; r1 < len(d.rowPins); r1++ {
+		for r2 := r1 + 1; r2 < len(d.rowPins); r2++ {
+			shared := 0
+			for c := 0; c < cols; c++ {
+				if keys[r1*cols+c] && keys[r2*cols+c] {
+					shared++
+				}
+			}
+			if shared > 1 {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// keyName returns the key of the keymap for the given index
+func (d *KeypadDriver) keyName(index int) string {
+	row := []rune(d.keypadCfg.keymap[index/len(d.colPins)])
+	return string(row[index%len(d.colPins)])
+}
+
+// keyNames returns the keys of the keymap for all pressed keys
+func (d *KeypadDriver) keyNames(keys []bool) []string {
+	var names []string
+	for i, pressed := range keys {
+		if pressed {
+			names = append(names, d.keyName(i))
+		}
+	}
+
+	return names
+}
+
+// validateKeymap checks, that there is exactly one key for each row and column
+func (d *KeypadDriver) validateKeymap() error {
+	if d.keypadCfg.keymap == nil {
+		return fmt.Errorf("a keymap is needed for %d rows and %d columns of '%s'", len(d.rowPins), len(d.colPins),
+			d.driverCfg.name)
+	}
+	if len(d.keypadCfg.keymap) != len(d.rowPins) {
+		return fmt.Errorf("the keymap of '%s' has %d rows, but %d are needed", d.driverCfg.name,
+			len(d.keypadCfg.keymap), len(d.rowPins))
+	}
+	for i, row := range d.keypadCfg.keymap {
+		if len([]rune(row)) != len(d.colPins) {
+			return fmt.Errorf("the keymap row %d ('%s') of '%s' has not %d columns", i, row, d.driverCfg.name,
+				len(d.colPins))
+		}
+	}
+
+	return nil
+}
+
+// level returns the level of an active or inactive row and of the column of a pressed or released key
+func (d *KeypadDriver) level(active bool) byte {
+	if active == d.keypadCfg.activeHigh {
+		return 1
+	}
+	return 0
+}
+
+// defaultKeypadKeymap returns the keymap of the common membrane keypads for the given size, if any
+func defaultKeypadKeymap(rows, cols int) []string {
+	if rows != 4 {
+		return nil
+	}
+
+	switch cols {
+	case 3:
+		return KeypadKeymap3x4
+	case 4:
+		return KeypadKeymap4x4
+	default:
+		return nil
+	}
+}
+
+func equalKeys(a, b []bool) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (o keypadKeymapOption) String() string {
+	return "keymap option for keypads"
+}
+
+func (o keypadReadIntervalOption) String() string {
+	return "read interval option for keypads"
+}
+
+func (o keypadDebounceOption) String() string {
+	return "debounce option for keypads"
+}
+
+func (o keypadHoldOption) String() string {
+	return "hold option for keypads"
+}
+
+func (o keypadActiveHighOption) String() string {
+	return "active high option for keypads"
+}
+
+func (o keypadColumnReaderOption) String() string {
+	return "column reader option for keypads"
+}
+
+func (o keypadEntryOption) String() string {
+	return "entry option for keypads"
+}
+
+func (o keypadEntryKeysOption) String() string {
+	return "entry keys option for keypads"
+}
+
+func (o keypadKeymapOption) apply(cfg *keypadConfiguration) {
+	cfg.keymap = o
+}
+
+func (o keypadReadIntervalOption) apply(cfg *keypadConfiguration) {
+	cfg.readInterval = time.Duration(o)
+}
+
+func (o keypadDebounceOption) apply(cfg *keypadConfiguration) {
+	cfg.debounce = time.Duration(o)
+}
+
+func (o keypadHoldOption) apply(cfg *keypadConfiguration) {
+	cfg.hold = time.Duration(o)
+}
+
+func (o keypadActiveHighOption) apply(cfg *keypadConfiguration) {
+	cfg.activeHigh = bool(o)
+}
+
+func (o keypadColumnReaderOption) apply(cfg *keypadConfiguration) {
+	cfg.columnReader = o.reader
+}
+
+func (o keypadEntryOption) apply(cfg *keypadConfiguration) {
+	cfg.entry = true
+	cfg.entryTimeout = time.Duration(o)
+}
+
+func (o keypadEntryKeysOption) apply(cfg *keypadConfiguration) {
+	cfg.entrySubmit = o.submit
+	cfg.entryClear = o.clear
+}
@@ -0,0 +1,519 @@
+//nolint:forcetypeassert // ok here
+package gpio
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/drivers/aio"
+	"gobot.io/x/gobot/v2/system"
+)
+
+var _ gobot.Driver = (*KeypadDriver)(nil)
+
+// keypadTestMatrix simulates a keypad without diodes with the rows at pins "r0".."r3" and the columns at pins
+// "c0".."c2", the rows are active low
+type keypadTestMatrix struct {
+	pressed map[string]bool // pressed keys, named by row and column, e.g. "r1c2"
+	rows    map[string]byte
+}
+
+func newKeypadTestMatrix(pressed ...string) *keypadTestMatrix {
+	m := &keypadTestMatrix{pressed: make(map[string]bool), rows: make(map[string]byte)}
+	for _, key := range pressed {
+		m.pressed[key] = true
+	}
+	return m
+}
+
+func (m *keypadTestMatrix) write(pin string, val byte) error {
+	m.rows[pin] = val
+	return nil
+}
+
+func (m *keypadTestMatrix) read(pin string) (int, error) {
+	for row, val := range m.rows {
+		if val == 0 && m.pressed[row+pin] {
+			return 0, nil
+		}
+	}
+	return 1, nil
+}
+
+// keypadTestWriteAdaptor provides no access to the pins, like pin expanders, so the inactive rows are driven
+type keypadTestWriteAdaptor struct {
+	*gpioTestBareAdaptor
+	pins *gpioTestAdaptor
+}
+
+func (a *keypadTestWriteAdaptor) DigitalRead(pin string) (int, error) { return a.pins.DigitalRead(pin) }
+func (a *keypadTestWriteAdaptor) DigitalWrite(pin string, val byte) error {
+	return a.pins.DigitalWrite(pin, val)
+}
+
+func initTestKeypadDriverWithStubbedAdaptor(
+	m *keypadTestMatrix,
+	opts ...interface{},
+) (*KeypadDriver, *gpioTestAdaptor) {
+	a := newGpioTestAdaptor()
+	a.digitalWriteFunc = m.write
+	a.digitalReadFunc = m.read
+	d := NewKeypadDriver(&keypadTestWriteAdaptor{pins: a}, []string{"r0", "r1", "r2", "r3"},
+		[]string{"c0", "c1", "c2"}, opts...)
+	return d, a
+}
+
+func TestNewKeypadDriver(t *testing.T) {
+	// arrange
+	a := newGpioTestAdaptor()
+	// act
+	d := NewKeypadDriver(a, []string{"1", "2", "3", "4"}, []string{"5", "6", "7", "8"})
+	// assert
+	assert.IsType(t, &KeypadDriver{}, d)
+	// assert: gpio.driver attributes
+	require.NotNil(t, d.driver)
+	assert.True(t, strings.HasPrefix(d.driverCfg.name, "Keypad"))
+	assert.Equal(t, a, d.connection)
+	assert.NotNil(t, d.afterStart)
+	assert.NotNil(t, d.beforeHalt)
+	assert.NotNil(t, d.Commander)
+	assert.NotNil(t, d.mutex)
+	// assert: driver specific attributes
+	assert.Nil(t, d.Eventer) // will be created on initialize
+	assert.Nil(t, d.halt)    // will be created on initialize
+	assert.Equal(t, []string{"1", "2", "3", "4"}, d.rowPins)
+	assert.Equal(t, []string{"5", "6", "7", "8"}, d.colPins)
+	require.NotNil(t, d.keypadCfg)
+	assert.Equal(t, KeypadKeymap4x4, d.keypadCfg.keymap)
+	assert.Equal(t, 10*time.Millisecond, d.keypadCfg.readInterval)
+	assert.Equal(t, 20*time.Millisecond, d.keypadCfg.debounce)
+	assert.Equal(t, time.Duration(0), d.keypadCfg.hold)
+	assert.False(t, d.keypadCfg.activeHigh)
+	assert.Nil(t, d.keypadCfg.columnReader)
+	assert.False(t, d.keypadCfg.entry)
+	assert.Equal(t, "#", d.keypadCfg.entrySubmit)
+	assert.Equal(t, "*", d.keypadCfg.entryClear)
+}
+
+func TestNewKeypadDriver_options(t *testing.T) {
+	// This is a general test, that options are applied in constructor by using the common WithName() option, least one
+	// option of this driver and one of another driver (which should lead to panic). Further tests for options can also
+	// be done by call of "WithOption(val).apply(cfg)".
+	// arrange
+	const (
+		myName = "door"
+	)
+	panicFunc := func() {
+		NewKeypadDriver(newGpioTestAdaptor(), []string{"1"}, []string{"2"}, WithName("crazy"),
+			aio.WithActuatorScaler(func(float64) int { return 0 }))
+	}
+	// act
+	d := NewKeypadDriver(newGpioTestAdaptor(), []string{"1"}, []string{"2"}, WithName(myName),
+		WithKeypadKeymap("X"))
+	// assert
+	assert.Equal(t, myName, d.Name())
+	assert.Equal(t, []string{"X"}, d.keypadCfg.keymap)
+	assert.PanicsWithValue(t, "'scaler option for analog actuators' can not be applied on 'crazy'", panicFunc)
+}
+
+func TestKeypad_options(t *testing.T) {
+	// arrange
+	cfg := keypadConfiguration{}
+	reader := newGpioTestAdaptor()
+	// act
+	WithKeypadPollInterval(5 * time.Millisecond).apply(&cfg)
+	WithKeypadDebounce(30 * time.Millisecond).apply(&cfg)
+	WithKeypadHold(time.Second).apply(&cfg)
+	WithKeypadActiveHigh().apply(&cfg)
+	WithKeypadColumnReader(reader).apply(&cfg)
+	WithKeypadEntry(5 * time.Second).apply(&cfg)
+	WithKeypadEntryKeys("D", "").apply(&cfg)
+	// assert
+	assert.Equal(t, 5*time.Millisecond, cfg.readInterval)
+	assert.Equal(t, 30*time.Millisecond, cfg.debounce)
+	assert.Equal(t, time.Second, cfg.hold)
+	assert.True(t, cfg.activeHigh)
+	assert.Equal(t, reader, cfg.columnReader)
+	assert.True(t, cfg.entry)
+	assert.Equal(t, 5*time.Second, cfg.entryTimeout)
+	assert.Equal(t, "D", cfg.entrySubmit)
+	assert.Equal(t, "", cfg.entryClear)
+}
+
+func TestKeypadStart(t *testing.T) {
+	// arrange
+	m := newKeypadTestMatrix()
+	d, a := initTestKeypadDriverWithStubbedAdaptor(m, WithKeypadPollInterval(time.Millisecond),
+		WithKeypadDebounce(0))
+	// act
+	err := d.Start()
+	// assert
+	require.NoError(t, err)
+	defer func() { _ = d.Halt() }()
+	a.mtx.Lock()
+	assert.Equal(t, []gpioTestWritten{{"r0", 1}, {"r1", 1}, {"r2", 1}, {"r3", 1}}, a.written[:4])
+	a.mtx.Unlock()
+	// assert: key is detected by the scan
+	events := d.Subscribe()
+	a.mtx.Lock()
+	m.pressed["r2c1"] = true
+	a.mtx.Unlock()
+	select {
+	case evt := <-events:
+		assert.Equal(t, KeypadKeyDown, evt.Name)
+		assert.Equal(t, "8", evt.Data)
+		assert.Equal(t, []string{"8"}, d.PressedKeys())
+		assert.Equal(t, []string{"8"}, d.Command("PressedKeys")(nil))
+	case <-time.After(time.Second):
+		t.Errorf("key down not detected")
+	}
+}
+
+func TestKeypadStart_error(t *testing.T) {
+	tests := map[string]struct {
+		rows    []string
+		cols    []string
+		opts    []interface{}
+		wantErr string
+	}{
+		"error_read_interval": {
+			rows:    []string{"1", "2", "3", "4"},
+			cols:    []string{"5", "6", "7"},
+			opts:    []interface{}{WithKeypadPollInterval(0)},
+			wantErr: "the read interval for keypad needs to be greater than zero",
+		},
+		"error_no_columns": {
+			rows:    []string{"1"},
+			wantErr: "at least one row and one column is needed for 'Keypad",
+		},
+		"error_no_keymap": {
+			rows:    []string{"1", "2"},
+			cols:    []string{"3", "4"},
+			wantErr: "a keymap is needed for 2 rows and 2 columns of 'Keypad",
+		},
+		"error_keymap_rows": {
+			rows:    []string{"1", "2"},
+			cols:    []string{"3", "4"},
+			opts:    []interface{}{WithKeypadKeymap("12")},
+			wantErr: "has 1 rows, but 2 are needed",
+		},
+		"error_keymap_columns": {
+			rows:    []string{"1", "2"},
+			cols:    []string{"3", "4"},
+			opts:    []interface{}{WithKeypadKeymap("12", "345")},
+			wantErr: "the keymap row 1 ('345') of 'Keypad",
+		},
+		"error_row_pin": {
+			rows:    []string{"1"},
+			cols:    []string{"2"},
+			opts:    []interface{}{WithKeypadKeymap("1")},
+			wantErr: "error on get row pin: pin '1' not found in 'gpio_test_adaptor'",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d := NewKeypadDriver(newGpioTestAdaptor(), tc.rows, tc.cols, tc.opts...)
+			// act
+			err := d.Start()
+			// assert
+			require.ErrorContains(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestKeypadHalt(t *testing.T) {
+	// arrange
+	d, _ := initTestKeypadDriverWithStubbedAdaptor(newKeypadTestMatrix())
+	require.NoError(t, d.Start())
+	halt := d.halt
+	// act
+	err := d.Halt()
+	// assert
+	require.NoError(t, err)
+	_, open := <-halt
+	assert.False(t, open)
+	require.NoError(t, d.Halt())
+}
+
+func TestKeypadScan(t *testing.T) {
+	tests := map[string]struct {
+		pressed []string
+		want    []string
+	}{
+		"no_key": {},
+		"one_key": {
+			pressed: []string{"r0c0"},
+			want:    []string{"1"},
+		},
+		"two_keys": {
+			pressed: []string{"r1c2", "r3c0"},
+			want:    []string{"6", "*"},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, a := initTestKeypadDriverWithStubbedAdaptor(newKeypadTestMatrix(tc.pressed...))
+			// act
+			got, err := d.scan()
+			// assert
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, d.keyNames(got))
+			assert.Len(t, a.written, 8) // each row activated and deactivated
+		})
+	}
+}
+
+func TestKeypadScan_floatingRows(t *testing.T) {
+	// arrange: the pins are accessible, so only the active row is an output
+	a := newGpioTestAdaptor()
+	rows := []*gpioTestWaveformPin{a.addWaveformPin("r0"), a.addWaveformPin("r1")}
+	var outputs []string
+	a.digitalReadFunc = func(pin string) (int, error) {
+		var active []string
+		for i, row := range rows {
+			if row.currentDirection() == system.OUT {
+				active = append(active, fmt.Sprintf("r%d", i))
+			}
+		}
+		outputs = append(outputs, strings.Join(active, ","))
+		if len(active) == 1 && active[0] == "r1" && pin == "c0" {
+			return 0, nil
+		}
+		return 1, nil
+	}
+	d := NewKeypadDriver(a, []string{"r0", "r1"}, []string{"c0", "c1"}, WithKeypadKeymap("ab", "cd"),
+		WithKeypadPollInterval(time.Hour))
+	require.NoError(t, d.Start())
+	defer func() { _ = d.Halt() }()
+	// act
+	got, err := d.scan()
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c"}, d.keyNames(got))
+	assert.Contains(t, outputs, "r0")
+	assert.Contains(t, outputs, "r1")
+	assert.NotContains(t, outputs, "r0,r1")
+	assert.Equal(t, system.IN, rows[0].currentDirection())
+	assert.Equal(t, system.IN, rows[1].currentDirection())
+	assert.Empty(t, a.written)
+}
+
+func TestKeypadScan_activeHigh(t *testing.T) {
+	// arrange
+	a := newGpioTestAdaptor()
+	activeRow := ""
+	a.digitalWriteFunc = func(pin string, val byte) error {
+		if val == 1 {
+			activeRow = pin
+		}
+		return nil
+	}
+	a.digitalReadFunc = func(pin string) (int, error) {
+		if activeRow == "r1" && pin == "c1" {
+			return 1, nil
+		}
+		return 0, nil
+	}
+	d := NewKeypadDriver(a, []string{"r0", "r1"}, []string{"c0", "c1"}, WithKeypadKeymap("ab", "cd"),
+		WithKeypadActiveHigh())
+	// act
+	got, err := d.scan()
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"d"}, d.keyNames(got))
+}
+
+func TestKeypadScan_columnReader(t *testing.T) {
+	// arrange
+	m := newKeypadTestMatrix("r3c2")
+	rows := newGpioTestAdaptor()
+	rows.digitalWriteFunc = m.write
+	rows.digitalReadFunc = func(string) (int, error) { return 0, errors.New("not connected") }
+	cols := newGpioTestAdaptor()
+	cols.digitalReadFunc = m.read
+	d := NewKeypadDriver(rows, []string{"r0", "r1", "r2", "r3"}, []string{"c0", "c1", "c2"},
+		WithKeypadColumnReader(cols))
+	// act
+	got, err := d.scan()
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"#"}, d.keyNames(got))
+	assert.Equal(t, map[string]string{"r0": "gpio", "r1": "gpio", "r2": "gpio", "r3": "gpio"}, d.usedPins())
+}
+
+func TestKeypadScan_error(t *testing.T) {
+	// arrange
+	d, a := initTestKeypadDriverWithStubbedAdaptor(newKeypadTestMatrix())
+	a.digitalReadFunc = func(string) (int, error) { return 0, errors.New("read error") }
+	// act
+	_, err := d.scan()
+	// assert: the row is deactivated again
+	require.EqualError(t, err, "read error")
+	assert.Equal(t, []gpioTestWritten{{"r0", 0}, {"r0", 1}}, a.written)
+}
+
+func TestKeypadScan_withExpanders(t *testing.T) {
+	// arrange: rows at a 74HC595, the columns at a 74HC165
+	a := newGpioTestAdaptor()
+	rows := NewHC595Driver(a, "1", "2", "3", 1)
+	cols := NewHC165Driver(a, "4", "5", "6", 1)
+	require.NoError(t, rows.Start())
+	require.NoError(t, cols.Start())
+	var shifted []byte
+	a.digitalWriteFunc = func(pin string, val byte) error {
+		// load the columns, the key "5" connects row 1 (output Q1) to column 1 (input B), which is pulled up
+		if pin == "6" && val == 0 {
+			shifted = []byte{1, 1, 1, 1, 1, 1, 1, 1}
+			if rows.outputs[0]&0x02 == 0 {
+				shifted[6] = 0
+			}
+		}
+		if pin == "5" && val == 1 {
+			shifted = shifted[1:]
+		}
+		return nil
+	}
+	a.digitalReadFunc = func(string) (int, error) { return int(shifted[0]), nil }
+	require.NoError(t, rows.WriteOutputs([]byte{0xFF}))
+	d := NewKeypadDriver(rows, []string{"0", "1", "2", "3"}, []string{"0", "1", "2"}, WithKeypadColumnReader(cols))
+	// act
+	got, err := d.scan()
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"5"}, d.keyNames(got))
+}
+
+func TestKeypadEvents(t *testing.T) {
+	type scan struct {
+		at   time.Duration
+		keys []string
+	}
+	type event struct {
+		name string
+		data interface{}
+	}
+	const ms = time.Millisecond
+	tests := map[string]struct {
+		opts  []interface{}
+		scans []scan
+		want  []event
+	}{
+		"down_up": {
+			scans: []scan{{0, []string{"r0c0"}}, {20 * ms, []string{"r0c0"}}, {50 * ms, nil}, {70 * ms, nil}},
+			want:  []event{{KeypadKeyDown, "1"}, {KeypadKeyUp, "1"}},
+		},
+		"debounce": {
+			scans: []scan{
+				{0, []string{"r0c0"}}, {10 * ms, nil}, {20 * ms, []string{"r0c0"}}, {30 * ms, []string{"r0c0"}},
+				{40 * ms, []string{"r0c0"}}, {100 * ms, nil}, {110 * ms, []string{"r0c0"}}, {200 * ms, []string{"r0c0"}},
+			},
+			want: []event{{KeypadKeyDown, "1"}},
+		},
+		"no_debounce": {
+			opts:  []interface{}{WithKeypadDebounce(0)},
+			scans: []scan{{0, []string{"r0c0"}}, {10 * ms, nil}},
+			want:  []event{{KeypadKeyDown, "1"}, {KeypadKeyUp, "1"}},
+		},
+		"up_before_down": {
+			scans: []scan{
+				{0, []string{"r0c0"}}, {20 * ms, []string{"r0c0"}}, {50 * ms, []string{"r1c1"}},
+				{100 * ms, []string{"r1c1"}},
+			},
+			want: []event{{KeypadKeyDown, "1"}, {KeypadKeyUp, "1"}, {KeypadKeyDown, "5"}},
+		},
+		"hold": {
+			opts: []interface{}{WithKeypadHold(500 * ms)},
+			scans: []scan{
+				{0, []string{"r3c2"}}, {20 * ms, []string{"r3c2"}}, {600 * ms, []string{"r3c2"}}, {900 * ms, nil},
+				{950 * ms, nil},
+			},
+			want: []event{{KeypadKeyDown, "#"}, {KeypadKeyHold, "#"}, {KeypadKeyUp, "#"}},
+		},
+		"ghosting": {
+			scans: []scan{
+				{0, []string{"r0c0", "r0c1", "r1c0"}}, {20 * ms, []string{"r0c0", "r0c1", "r1c0"}},
+				{50 * ms, []string{"r0c0", "r0c1", "r1c0", "r1c1"}}, {100 * ms, []string{"r0c0", "r0c1", "r1c0", "r1c1"}},
+			},
+			want: []event{
+				{KeypadKeyDown, "1"}, {KeypadKeyDown, "2"}, {KeypadKeyDown, "4"},
+				{KeypadGhosting, []string{"1", "2", "4", "5"}},
+			},
+		},
+		"entry": {
+			opts: []interface{}{WithKeypadEntry(0), WithKeypadDebounce(0)},
+			scans: []scan{
+				{0, []string{"r0c0"}}, {10 * ms, nil}, {20 * ms, []string{"r3c1"}}, {30 * ms, nil},
+				{40 * ms, []string{"r3c2"}},
+			},
+			want: []event{
+				{KeypadKeyDown, "1"}, {KeypadKeyUp, "1"}, {KeypadKeyDown, "0"}, {KeypadKeyUp, "0"},
+				{KeypadKeyDown, "#"}, {KeypadEntry, "10"},
+			},
+		},
+		"entry_clear": {
+			opts: []interface{}{WithKeypadEntry(0), WithKeypadDebounce(0)},
+			scans: []scan{
+				{0, []string{"r0c0"}}, {10 * ms, []string{"r3c0"}}, {20 * ms, []string{"r0c1"}},
+				{30 * ms, []string{"r3c2"}},
+			},
+			want: []event{
+				{KeypadKeyDown, "1"}, {KeypadKeyUp, "1"}, {KeypadKeyDown, "*"}, {KeypadKeyUp, "*"},
+				{KeypadKeyDown, "2"}, {KeypadKeyUp, "2"}, {KeypadKeyDown, "#"}, {KeypadEntry, "2"},
+			},
+		},
+		"entry_timeout": {
+			opts: []interface{}{WithKeypadEntry(time.Second), WithKeypadDebounce(0)},
+			scans: []scan{
+				{0, []string{"r0c0"}}, {10 * ms, nil}, {900 * ms, []string{"r0c1"}}, {910 * ms, nil},
+				{1800 * ms, nil}, {1910 * ms, nil}, {2000 * ms, []string{"r3c2"}},
+			},
+			want: []event{
+				{KeypadKeyDown, "1"}, {KeypadKeyUp, "1"}, {KeypadKeyDown, "2"}, {KeypadKeyUp, "2"},
+				{KeypadEntryTimeout, "12"}, {KeypadKeyDown, "#"}, {KeypadEntry, ""},
+			},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, _ := initTestKeypadDriverWithStubbedAdaptor(newKeypadTestMatrix(), tc.opts...)
+			d.Eventer = gobot.NewEventer()
+			events := d.Subscribe()
+			s := d.newState()
+			start := time.Now()
+			// act: like the reading go routine
+			for _, sc := range tc.scans {
+				keys := make([]bool, 12)
+				for _, key := range sc.keys {
+					keys[int(key[1]-'0')*3+int(key[3]-'0')] = true
+				}
+				d.input(s, keys, start.Add(sc.at))
+				d.elapse(s, start.Add(sc.at))
+			}
+			// assert
+			var got []event
+			for range tc.want {
+				select {
+				case evt := <-events:
+					got = append(got, event{name: evt.Name, data: evt.Data})
+				case <-time.After(buttonTestDelay * time.Millisecond):
+				}
+			}
+			assert.Equal(t, tc.want, got)
+			select {
+			case evt := <-events:
+				assert.Fail(t, "unexpected event", "%s: %v", evt.Name, evt.Data)
+			case <-time.After(10 * time.Millisecond):
+			}
+		})
+	}
+}
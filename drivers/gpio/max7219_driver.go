@@ -54,6 +54,7 @@ func NewMAX7219Driver(
 		count:    count,
 	}
 	d.afterStart = d.initialize
+	d.usedPins = func() map[string]string { return directPinsUsage(d.pinClock, d.pinData, d.pinCS) }
 
 	/* TODO : Add commands */
 
@@ -135,6 +136,54 @@ func (d *MAX7219Driver) One(which uint, address byte, data byte) error {
 	return d.pinCS.On()
 }
 
+// Digits returns the count of digits of all chained modules (interface font.SegmentDisplayer).
+func (d *MAX7219Driver) Digits() int {
+	return int(d.count) * 8
+}
+
+// WriteSegments writes the segments of the digits, beginning with the left digit of module 0 (interface
+// font.SegmentDisplayer). The left digit of each module is connected to "DIG7", like it is done on the common 8 digit
+// modules. The display has no colon, so this parameter is ignored.
+func (d *MAX7219Driver) WriteSegments(digits []byte, _ bool) error {
+	for i := 0; i < d.Digits(); i++ {
+		var data byte
+		if i < len(digits) {
+			data = max7219SegmentOrder(digits[i])
+		}
+		if err := d.One(uint(i/8), MAX7219Digit7-byte(i%8), data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Columns returns the count of columns of all chained 8x8 matrix modules (interface font.MatrixDisplayer).
+func (d *MAX7219Driver) Columns() int {
+	return int(d.count) * 8
+}
+
+// WriteColumns writes the columns, beginning with the left column of module 0 (interface font.MatrixDisplayer). The
+// lowest bit of each column is the top row. The top row of each module is connected to "DIG0" and the left column to
+// "SEG DP", like it is done on the common "FC-16" modules.
+func (d *MAX7219Driver) WriteColumns(columns []byte) error {
+	for i := 0; i < d.Columns(); i += 8 {
+		for row := 0; row < 8; row++ {
+			var data byte
+			for x := 0; x < 8 && i+x < len(columns); x++ {
+				if columns[i+x]&(1<<row) > 0 {
+					data |= 0x80 >> x
+				}
+			}
+			if err := d.One(uint(i/8), MAX7219Digit0+byte(row), data); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
 // initialize initializes the max7219, it uses a SPI-like communication protocol
 func (d *MAX7219Driver) initialize() error {
 	if err := d.pinData.On(); err != nil {
@@ -190,3 +239,15 @@ func (d *MAX7219Driver) send(data byte) error {
 
 	return nil
 }
+
+// max7219SegmentOrder converts the segments of a digit from the order of font.SevenSegmentFont to the order of the
+// MAX7219, which is "DP A B C D E F G" beginning with the highest bit
+func max7219SegmentOrder(segments byte) byte {
+	converted := segments & 0x80
+	for i := 0; i < 7; i++ {
+		if segments&(1<<i) > 0 {
+			converted |= 0x40 >> i
+		}
+	}
+	return converted
+}
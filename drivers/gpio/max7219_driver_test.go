@@ -70,3 +70,53 @@ func TestMAX7219Start(t *testing.T) {
 	// act & assert: tests also initialize()
 	require.NoError(t, d.Start())
 }
+
+func TestMAX7219WriteSegments(t *testing.T) {
+	// arrange
+	a := newGpioTestAdaptor()
+	d := NewMAX7219Driver(a, "1", "2", "3", 2)
+	// act
+	err := d.WriteSegments([]byte{0x06 | 0x80, 0x5B}, false)
+	// assert
+	require.NoError(t, err)
+	frames := gpioTestSerialFrames(a.written, "1", "2", "3", false)
+	require.Len(t, frames, 16)
+	assert.Equal(t, 16, d.Digits())
+	// first digit of module 0 is "DIG7", module 0 is the first sent to the chain
+	assert.Equal(t, []byte{MAX7219Digit7, 0xB0, 0x00, 0x00}, frames[0])
+	assert.Equal(t, []byte{MAX7219Digit6, 0x6D, 0x00, 0x00}, frames[1])
+	assert.Equal(t, []byte{0x00, 0x00, MAX7219Digit7, 0x00}, frames[8])
+}
+
+func TestMAX7219WriteColumns(t *testing.T) {
+	// arrange
+	a := newGpioTestAdaptor()
+	d := NewMAX7219Driver(a, "1", "2", "3", 1)
+	// act: left column fully on, second column only top row
+	err := d.WriteColumns([]byte{0xFF, 0x01})
+	// assert
+	require.NoError(t, err)
+	frames := gpioTestSerialFrames(a.written, "1", "2", "3", false)
+	require.Len(t, frames, 8)
+	assert.Equal(t, 8, d.Columns())
+	assert.Equal(t, []byte{MAX7219Digit0, 0xC0}, frames[0])
+	assert.Equal(t, []byte{MAX7219Digit1, 0x80}, frames[1])
+	assert.Equal(t, []byte{MAX7219Digit7, 0x80}, frames[7])
+}
+
+func TestMAX7219SegmentOrder(t *testing.T) {
+	tests := map[string]struct {
+		segments byte
+		want     byte
+	}{
+		"a":     {segments: 0x01, want: 0x40},
+		"g":     {segments: 0x40, want: 0x01},
+		"dp":    {segments: 0x80, want: 0x80},
+		"digit": {segments: 0x3F, want: 0x7E},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, max7219SegmentOrder(tc.segments))
+		})
+	}
+}
@@ -61,6 +61,7 @@ func NewMotorDriver(a DigitalWriter, speedPin string, opts ...interface{}) *Moto
 		motorCfg:         &motorConfiguration{},
 		currentDirection: "forward",
 	}
+	d.usedPins = d.motorPins
 
 	for _, opt := range opts {
 		switch o := opt.(type) {
@@ -76,6 +77,21 @@ func NewMotorDriver(a DigitalWriter, speedPin string, opts ...interface{}) *Moto
 	return d
 }
 
+// motorPins returns the used pins with the function, the speed pin is used for PWM in analog mode
+func (d *MotorDriver) motorPins() map[string]string {
+	speedFunction := gobot.PinFunctionGpio
+	if d.motorCfg.modeIsAnalog {
+		speedFunction = gobot.PinFunctionPwm
+	}
+	pins := map[string]string{
+		d.motorCfg.directionPin: gobot.PinFunctionGpio,
+		d.motorCfg.forwardPin:   gobot.PinFunctionGpio,
+		d.motorCfg.backwardPin:  gobot.PinFunctionGpio,
+	}
+	pins[d.driverCfg.pin] = speedFunction
+	return pins
+}
+
 // WithMotorAnalog change the default mode "digital" to analog for the motor.
 func WithMotorAnalog() motorOptionApplier {
 	return motorModeIsAnalogOption(true)
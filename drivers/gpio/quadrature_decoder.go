@@ -0,0 +1,127 @@
+package gpio
+
+import "sync"
+
+// QuadratureResolution defines, how many steps are counted for one cycle of the A/B signals of an incremental encoder.
+type QuadratureResolution int
+
+const (
+	// QuadratureX1 counts one step for each full cycle, this is typically one detent of a KY-040 rotary encoder
+	QuadratureX1 QuadratureResolution = 1
+	// QuadratureX2 counts one step for each half cycle (each edge of signal A)
+	QuadratureX2 QuadratureResolution = 2
+	// QuadratureX4 counts one step for each edge of signal A and B
+	QuadratureX4 QuadratureResolution = 4
+)
+
+// quadratureTransitions contains the direction for each transition of the signal state (A<<1 | B), the index is
+// previous state << 2 | current state. The clockwise sequence (A leads B) is 00, 10, 11, 01. Both signals changed
+// is an invalid transition and marked by 2.
+var quadratureTransitions = [16]int8{
+	0, -1, 1, 2,
+	1, 0, 2, -1,
+	-1, 2, 0, 1,
+	2, 1, -1, 0,
+}
+
+// QuadratureDecoder decodes the A/B signals of an incremental encoder to a position. It can be used by all drivers,
+// which needs to track the position of an axis, e.g. for a closed-loop control of a motor. The position wraps around
+// on overflow, so the difference of two positions is still valid. The decoder can be used concurrently.
+type QuadratureDecoder struct {
+	resolution QuadratureResolution
+	mutex      sync.Mutex
+	state      int // last state of the signals, -1 before the first update
+	quarters   int // count of edges (quarter cycles) in the current direction, which are not counted as steps yet
+	position   int64
+	errors     uint64
+}
+
+// NewQuadratureDecoder creates a new decoder with the given resolution. For an invalid resolution QuadratureX4 is used.
+func NewQuadratureDecoder(resolution QuadratureResolution) *QuadratureDecoder {
+	if resolution != QuadratureX1 && resolution != QuadratureX2 {
+		resolution = QuadratureX4
+	}
+
+	return &QuadratureDecoder{resolution: resolution, state: -1}
+}
+
+// Update processes the current values of signal A and B and returns the counted steps, which is 1 for clockwise, -1
+// for counter-clockwise or zero. The first update after creation or Reset() only stores the state.
+func (q *QuadratureDecoder) Update(a, b int) int {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	state := quadratureBit(a)<<1 | quadratureBit(b)
+	if q.state < 0 {
+		q.state = state
+		return 0
+	}
+
+	direction := quadratureTransitions[q.state<<2|state]
+	q.state = state
+	switch direction {
+	case 0:
+		return 0
+	case 2:
+		// a missed edge, the direction is unknown
+		q.errors++
+		return 0
+	}
+
+	quartersPerStep := 4 / int(q.resolution)
+	q.quarters += int(direction)
+	switch {
+	case q.quarters >= quartersPerStep:
+		q.quarters -= quartersPerStep
+		q.position++
+		return 1
+	case q.quarters <= -quartersPerStep:
+		q.quarters += quartersPerStep
+		q.position--
+		return -1
+	}
+
+	return 0
+}
+
+// Position returns the current position in steps.
+func (q *QuadratureDecoder) Position() int64 {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	return q.position
+}
+
+// SetPosition sets the current position, e.g. after homing, without changing the state of the signals.
+func (q *QuadratureDecoder) SetPosition(position int64) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	q.position = position
+	q.quarters = 0
+}
+
+// Errors returns the count of invalid transitions, where both signals have changed. This happens, if edges are
+// missed, e.g. the encoder is too fast for the polling interval.
+func (q *QuadratureDecoder) Errors() uint64 {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	return q.errors
+}
+
+// Reset forgets the state of the signals, so the next update only stores the state. The position is not changed.
+func (q *QuadratureDecoder) Reset() {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	q.state = -1
+	q.quarters = 0
+}
+
+func quadratureBit(val int) int {
+	if val > 0 {
+		return 1
+	}
+	return 0
+}
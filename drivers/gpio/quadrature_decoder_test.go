@@ -0,0 +1,146 @@
+package gpio
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// quadratureTestCycle is one clockwise cycle of the signals A and B, starting and ending in the detent 11
+var quadratureTestCycle = [][2]int{{0, 1}, {0, 0}, {1, 0}, {1, 1}}
+
+func TestNewQuadratureDecoder(t *testing.T) {
+	tests := map[string]struct {
+		resolution QuadratureResolution
+		want       QuadratureResolution
+	}{
+		"x1":      {resolution: QuadratureX1, want: QuadratureX1},
+		"x2":      {resolution: QuadratureX2, want: QuadratureX2},
+		"x4":      {resolution: QuadratureX4, want: QuadratureX4},
+		"invalid": {resolution: 3, want: QuadratureX4},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// act
+			q := NewQuadratureDecoder(tc.resolution)
+			// assert
+			assert.Equal(t, tc.want, q.resolution)
+			assert.Equal(t, -1, q.state)
+		})
+	}
+}
+
+func TestQuadratureDecoderUpdate(t *testing.T) {
+	tests := map[string]struct {
+		resolution    QuadratureResolution
+		reverse       bool
+		wantSteps     []int
+		wantPosition  int64
+		wantRemaining int
+	}{
+		"x1_clockwise": {
+			resolution:   QuadratureX1,
+			wantSteps:    []int{0, 0, 0, 1, 0, 0, 0, 1},
+			wantPosition: 2,
+		},
+		"x1_counter_clockwise": {
+			resolution:   QuadratureX1,
+			reverse:      true,
+			wantSteps:    []int{0, 0, 0, -1, 0, 0, 0, -1},
+			wantPosition: -2,
+		},
+		"x2_clockwise": {
+			resolution:   QuadratureX2,
+			wantSteps:    []int{0, 1, 0, 1, 0, 1, 0, 1},
+			wantPosition: 4,
+		},
+		"x4_clockwise": {
+			resolution:   QuadratureX4,
+			wantSteps:    []int{1, 1, 1, 1, 1, 1, 1, 1},
+			wantPosition: 8,
+		},
+		"x4_counter_clockwise": {
+			resolution:   QuadratureX4,
+			reverse:      true,
+			wantSteps:    []int{-1, -1, -1, -1, -1, -1, -1, -1},
+			wantPosition: -8,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			q := NewQuadratureDecoder(tc.resolution)
+			assert.Equal(t, 0, q.Update(1, 1))
+			var signals [][2]int
+			for i := 0; i < 2; i++ {
+				signals = append(signals, quadratureTestCycle...)
+			}
+			// act
+			var got []int
+			for i := range signals {
+				s := signals[i]
+				if tc.reverse {
+					// the counter-clockwise sequence is the clockwise one backwards
+					s = signals[len(signals)-1-((i+1)%len(signals))]
+				}
+				got = append(got, q.Update(s[0], s[1]))
+			}
+			// assert
+			assert.Equal(t, tc.wantSteps, got)
+			assert.Equal(t, tc.wantPosition, q.Position())
+			assert.Equal(t, uint64(0), q.Errors())
+		})
+	}
+}
+
+func TestQuadratureDecoderBouncing(t *testing.T) {
+	// arrange
+	q := NewQuadratureDecoder(QuadratureX1)
+	q.Update(1, 1)
+	// act: a bouncing signal B around the detent is not counted
+	for i := 0; i < 5; i++ {
+		q.Update(1, 0)
+		q.Update(1, 1)
+	}
+	// assert
+	assert.Equal(t, int64(0), q.Position())
+}
+
+func TestQuadratureDecoderErrors(t *testing.T) {
+	// arrange
+	q := NewQuadratureDecoder(QuadratureX4)
+	q.Update(1, 1)
+	// act: both signals changed
+	got := q.Update(0, 0)
+	// assert
+	assert.Equal(t, 0, got)
+	assert.Equal(t, int64(0), q.Position())
+	assert.Equal(t, uint64(1), q.Errors())
+}
+
+func TestQuadratureDecoderOverflow(t *testing.T) {
+	// arrange
+	q := NewQuadratureDecoder(QuadratureX4)
+	q.SetPosition(math.MaxInt64)
+	q.Update(1, 1)
+	before := q.Position()
+	// act
+	q.Update(0, 1)
+	// assert: the position wraps around, but the difference is still valid
+	assert.Equal(t, int64(math.MinInt64), q.Position())
+	assert.Equal(t, int64(1), q.Position()-before)
+}
+
+func TestQuadratureDecoderReset(t *testing.T) {
+	// arrange
+	q := NewQuadratureDecoder(QuadratureX4)
+	q.Update(1, 1)
+	q.Update(0, 1)
+	// act
+	q.Reset()
+	got := q.Update(0, 0)
+	// assert
+	assert.Equal(t, 0, got)
+	assert.Equal(t, int64(1), q.Position())
+}
@@ -36,6 +36,11 @@ func NewRgbLedDriver(a PwmWriter, redPin string, greenPin string, bluePin string
 		pinGreen: greenPin,
 		pinBlue:  bluePin,
 	}
+	d.usedPins = func() map[string]string {
+		return map[string]string{
+			d.pinRed: gobot.PinFunctionPwm, d.pinGreen: gobot.PinFunctionPwm, d.pinBlue: gobot.PinFunctionPwm,
+		}
+	}
 
 	//nolint:forcetypeassert // ok here
 	d.AddCommand("SetRGB", func(params map[string]interface{}) interface{} {
@@ -0,0 +1,394 @@
+package gpio
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"gobot.io/x/gobot/v2"
+)
+
+// rotaryEncoderOptionApplier needs to be implemented by each configurable option type
+type rotaryEncoderOptionApplier interface {
+	apply(cfg *rotaryEncoderConfiguration)
+}
+
+// rotaryEncoderConfiguration contains all changeable attributes of the driver.
+type rotaryEncoderConfiguration struct {
+	resolution         QuadratureResolution
+	readInterval       time.Duration
+	velocityWindow     time.Duration
+	buttonPin          string
+	buttonDefaultState int
+}
+
+// rotaryEncoderResolutionOption is the type for applying another resolution to the configuration
+type rotaryEncoderResolutionOption QuadratureResolution
+
+// rotaryEncoderReadIntervalOption is the type for applying another read interval to the configuration
+type rotaryEncoderReadIntervalOption time.Duration
+
+// rotaryEncoderVelocityWindowOption is the type for applying another velocity window to the configuration
+type rotaryEncoderVelocityWindowOption time.Duration
+
+// rotaryEncoderButtonOption is the type for applying a push button to the configuration
+type rotaryEncoderButtonOption struct {
+	pin          string
+	defaultState int
+}
+
+// rotaryEncoderInput is a changed value of one of the pins, reported by the edge notification
+type rotaryEncoderInput struct {
+	pin string
+	val int
+}
+
+// RotaryEncoderDriver is a driver for incremental rotary encoders with A/B quadrature signals, e.g. KY-040 or motor
+// encoders, with an optional push button.
+type RotaryEncoderDriver struct {
+	*driver
+	encoderCfg *rotaryEncoderConfiguration
+	gobot.Eventer
+	pinA    string
+	pinB    string
+	decoder *QuadratureDecoder
+	halt    chan struct{}
+	inputs  chan rotaryEncoderInput // nil, if the pins are polled
+
+	velocityMutex  sync.Mutex
+	velocity       float64 // [steps/s]
+	windowStart    time.Time
+	windowPosition int64
+	lastStep       time.Time
+}
+
+// NewRotaryEncoderDriver creates a new driver for a rotary encoder with the signals A and B connected to the given
+// pins. The pins are polled every millisecond. If the adaptor implements the DigitalEdgeNotifier interface, the edge
+// notification is used instead of polling. If this fails, e.g. for sysfs access, the driver falls back to polling.
+// By default each edge of both signals is counted as one step (x4), for a KY-040 use QuadratureX1 to count the
+// detents. Clockwise means, that signal A leads signal B, swap the pins to change the direction.
+//
+// Supported options:
+//
+//	"WithName"
+//	"WithRotaryEncoderResolution"
+//	"WithRotaryEncoderPollInterval"
+//	"WithRotaryEncoderVelocityWindow"
+//	"WithRotaryEncoderButton"
+func NewRotaryEncoderDriver(a DigitalReader, pinA, pinB string, opts ...interface{}) *RotaryEncoderDriver {
+	//nolint:forcetypeassert // no error return value, so there is no better way
+	d := &RotaryEncoderDriver{
+		driver: newDriver(a.(gobot.Connection), "RotaryEncoder"),
+		encoderCfg: &rotaryEncoderConfiguration{
+			resolution:     QuadratureX4,
+			readInterval:   time.Millisecond,
+			velocityWindow: 100 * time.Millisecond,
+		},
+		Eventer: gobot.NewEventer(),
+		pinA:    pinA,
+		pinB:    pinB,
+	}
+	d.afterStart = d.initialize
+	d.beforeHalt = d.shutdown
+	d.usedPins = func() map[string]string {
+		return map[string]string{
+			d.pinA:                 gobot.PinFunctionGpio,
+			d.pinB:                 gobot.PinFunctionGpio,
+			d.encoderCfg.buttonPin: gobot.PinFunctionGpio,
+		}
+	}
+
+	for _, opt := range opts {
+		switch o := opt.(type) {
+		case optionApplier:
+			o.apply(d.driverCfg)
+		case rotaryEncoderOptionApplier:
+			o.apply(d.encoderCfg)
+		default:
+			panic(fmt.Sprintf("'%s' can not be applied on '%s'", opt, d.driverCfg.name))
+		}
+	}
+
+	d.decoder = NewQuadratureDecoder(d.encoderCfg.resolution)
+
+	d.AddEvent(RotaryEncoderClockwise)
+	d.AddEvent(RotaryEncoderCounterClockwise)
+	d.AddEvent(RotaryEncoderPositionChanged)
+	d.AddEvent(ButtonPush)
+	d.AddEvent(ButtonRelease)
+	d.AddEvent(Error)
+
+	d.AddCommand("CurrentPosition", func(params map[string]interface{}) interface{} {
+		return d.CurrentPosition()
+	})
+	d.AddCommand("Velocity", func(params map[string]interface{}) interface{} {
+		return d.Velocity()
+	})
+
+	return d
+}
+
+// WithRotaryEncoderResolution change the count of steps for each cycle of the signals from default QuadratureX4 to
+// the given value.
+func WithRotaryEncoderResolution(resolution QuadratureResolution) rotaryEncoderOptionApplier {
+	return rotaryEncoderResolutionOption(resolution)
+}
+
+// WithRotaryEncoderPollInterval change the asynchronous cyclic reading interval from default 1ms to the given value.
+// This is only used, if no edge notification is available.
+func WithRotaryEncoderPollInterval(interval time.Duration) rotaryEncoderOptionApplier {
+	return rotaryEncoderReadIntervalOption(interval)
+}
+
+// WithRotaryEncoderVelocityWindow change the time window for the calculation of the velocity from default 100ms to
+// the given value.
+func WithRotaryEncoderVelocityWindow(window time.Duration) rotaryEncoderOptionApplier {
+	return rotaryEncoderVelocityWindowOption(window)
+}
+
+// WithRotaryEncoderButton adds the push button of the encoder at the given pin. The default state is the value of the
+// released button, e.g. 1 for the KY-040, which has a pull up resistor.
+func WithRotaryEncoderButton(pin string, defaultState int) rotaryEncoderOptionApplier {
+	return rotaryEncoderButtonOption{pin: pin, defaultState: defaultState}
+}
+
+// CurrentPosition returns the position in steps. This implements the PositionReader interface.
+func (d *RotaryEncoderDriver) CurrentPosition() int {
+	return int(d.decoder.Position())
+}
+
+// SetCurrentPosition sets the position in steps, e.g. to zero after homing.
+func (d *RotaryEncoderDriver) SetCurrentPosition(position int) {
+	d.decoder.SetPosition(int64(position))
+
+	d.velocityMutex.Lock()
+	defer d.velocityMutex.Unlock()
+
+	d.windowPosition = int64(position)
+}
+
+// Velocity returns the velocity in steps per second, positive for clockwise. The velocity is averaged over the
+// configured window and is zero, if no step was counted within the window.
+func (d *RotaryEncoderDriver) Velocity() float64 {
+	d.velocityMutex.Lock()
+	defer d.velocityMutex.Unlock()
+
+	if time.Since(d.lastStep) > d.encoderCfg.velocityWindow {
+		return 0
+	}
+
+	return d.velocity
+}
+
+// Decoder returns the used quadrature decoder, e.g. to read the count of errors.
+func (d *RotaryEncoderDriver) Decoder() *QuadratureDecoder {
+	return d.decoder
+}
+
+// initialize the driver and starts the notification by edges or polls the pins at the given interval.
+//
+// Emits the Events:
+//
+//	Clockwise int - On a step in clockwise direction, data is the position
+//	CounterClockwise int - On a step in counter-clockwise direction, data is the position
+//	PositionChanged int - On each step, data is the position
+//	Push int - On button push
+//	Release int - On button release
+//	Error error - On read error
+func (d *RotaryEncoderDriver) initialize() error {
+	if d.encoderCfg.readInterval <= 0 {
+		return fmt.Errorf("the read interval for rotary encoder needs to be greater than zero")
+	}
+
+	pins := d.inputPins()
+	values := make(map[string]int, len(pins))
+	for _, pin := range pins {
+		val, err := d.digitalRead(pin)
+		if err != nil {
+			return err
+		}
+		values[pin] = val
+	}
+	d.decoder.Reset()
+	d.decoder.Update(values[d.pinA], values[d.pinB])
+
+	halt := make(chan struct{})
+	d.halt = halt
+	d.inputs = nil
+
+	if notifier, ok := d.connection.(DigitalEdgeNotifier); ok {
+		inputs := make(chan rotaryEncoderInput, 64)
+		var err error
+		var notified []string
+		for _, pin := range pins {
+			pin := pin
+			err = notifier.NotifyDigitalEdges(pin, func(val int) {
+				select {
+				case inputs <- rotaryEncoderInput{pin: pin, val: val}:
+				case <-halt:
+				}
+			})
+			if err != nil {
+				break
+			}
+			notified = append(notified, pin)
+		}
+		if err == nil {
+			d.inputs = inputs
+		} else {
+			// fall back to polling
+			for _, pin := range notified {
+				_ = notifier.NotifyDigitalEdges(pin, nil)
+			}
+		}
+	}
+
+	go d.run(values, d.inputs, halt)
+
+	return nil
+}
+
+func (d *RotaryEncoderDriver) shutdown() error {
+	if d.halt == nil {
+		return nil
+	}
+
+	var err error
+	if d.inputs != nil {
+		//nolint:forcetypeassert // ok here, the inputs are only used for notifiers
+		notifier := d.connection.(DigitalEdgeNotifier)
+		for _, pin := range d.inputPins() {
+			if e := notifier.NotifyDigitalEdges(pin, nil); e != nil {
+				err = e
+			}
+		}
+	}
+
+	close(d.halt)
+	return err
+}
+
+// run processes the values from the edge notification or by polling until halt
+func (d *RotaryEncoderDriver) run(values map[string]int, inputs <-chan rotaryEncoderInput, halt <-chan struct{}) {
+	var poll <-chan time.Time
+	if inputs == nil {
+		ticker := time.NewTicker(d.encoderCfg.readInterval)
+		defer ticker.Stop()
+		poll = ticker.C
+	}
+
+	for {
+		select {
+		case <-poll:
+			for _, pin := range d.inputPins() {
+				val, err := d.digitalRead(pin)
+				if err != nil {
+					d.Publish(Error, err)
+					continue
+				}
+				d.process(values, pin, val)
+			}
+		case in := <-inputs:
+			d.process(values, in.pin, in.val)
+		case <-halt:
+			return
+		}
+	}
+}
+
+// process handles a new value of the given pin and publishes the events
+func (d *RotaryEncoderDriver) process(values map[string]int, pin string, val int) {
+	if val == -1 || values[pin] == val {
+		return
+	}
+	values[pin] = val
+
+	if pin == d.encoderCfg.buttonPin {
+		if val != d.encoderCfg.buttonDefaultState {
+			d.Publish(ButtonPush, val)
+		} else {
+			d.Publish(ButtonRelease, val)
+		}
+		return
+	}
+
+	steps := d.decoder.Update(values[d.pinA], values[d.pinB])
+	if steps == 0 {
+		return
+	}
+
+	position := d.decoder.Position()
+	d.updateVelocity(position, time.Now())
+
+	if steps > 0 {
+		d.Publish(RotaryEncoderClockwise, int(position))
+	} else {
+		d.Publish(RotaryEncoderCounterClockwise, int(position))
+	}
+	d.Publish(RotaryEncoderPositionChanged, int(position))
+}
+
+// updateVelocity calculates the velocity at the end of each window, the difference of the positions is valid also
+// after an overflow of the position
+func (d *RotaryEncoderDriver) updateVelocity(position int64, now time.Time) {
+	d.velocityMutex.Lock()
+	defer d.velocityMutex.Unlock()
+
+	if now.Sub(d.lastStep) > d.encoderCfg.velocityWindow {
+		// the encoder was stopped, so start a new window at this step
+		d.velocity = 0
+		d.windowStart = now
+		d.windowPosition = position
+	}
+	d.lastStep = now
+
+	elapsed := now.Sub(d.windowStart)
+	if elapsed < d.encoderCfg.velocityWindow {
+		return
+	}
+
+	d.velocity = float64(position-d.windowPosition) / elapsed.Seconds()
+	d.windowStart = now
+	d.windowPosition = position
+}
+
+func (d *RotaryEncoderDriver) inputPins() []string {
+	if d.encoderCfg.buttonPin == "" {
+		return []string{d.pinA, d.pinB}
+	}
+
+	return []string{d.pinA, d.pinB, d.encoderCfg.buttonPin}
+}
+
+func (o rotaryEncoderResolutionOption) String() string {
+	return "resolution option for rotary encoders"
+}
+
+func (o rotaryEncoderReadIntervalOption) String() string {
+	return "read interval option for rotary encoders"
+}
+
+func (o rotaryEncoderVelocityWindowOption) String() string {
+	return "velocity window option for rotary encoders"
+}
+
+func (o rotaryEncoderButtonOption) String() string {
+	return "button option for rotary encoders"
+}
+
+func (o rotaryEncoderResolutionOption) apply(cfg *rotaryEncoderConfiguration) {
+	cfg.resolution = QuadratureResolution(o)
+}
+
+func (o rotaryEncoderReadIntervalOption) apply(cfg *rotaryEncoderConfiguration) {
+	cfg.readInterval = time.Duration(o)
+}
+
+func (o rotaryEncoderVelocityWindowOption) apply(cfg *rotaryEncoderConfiguration) {
+	cfg.velocityWindow = time.Duration(o)
+}
+
+func (o rotaryEncoderButtonOption) apply(cfg *rotaryEncoderConfiguration) {
+	cfg.buttonPin = o.pin
+	cfg.buttonDefaultState = o.defaultState
+}
@@ -0,0 +1,206 @@
+package gpio
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/drivers/aio"
+)
+
+var (
+	_ gobot.Driver   = (*RotaryEncoderDriver)(nil)
+	_ PositionReader = (*RotaryEncoderDriver)(nil)
+	_ PositionReader = (*StepperDriver)(nil)
+)
+
+func TestNewRotaryEncoderDriver(t *testing.T) {
+	// arrange
+	a := newGpioTestAdaptor()
+	// act
+	d := NewRotaryEncoderDriver(a, "1", "2")
+	// assert
+	assert.IsType(t, &RotaryEncoderDriver{}, d)
+	// assert: gpio.driver attributes
+	require.NotNil(t, d.driver)
+	assert.True(t, strings.HasPrefix(d.driverCfg.name, "RotaryEncoder"))
+	assert.Equal(t, a, d.connection)
+	assert.NotNil(t, d.afterStart)
+	assert.NotNil(t, d.beforeHalt)
+	assert.NotNil(t, d.Commander)
+	assert.NotNil(t, d.mutex)
+	// assert: driver specific attributes
+	assert.Equal(t, "1", d.pinA)
+	assert.Equal(t, "2", d.pinB)
+	assert.NotNil(t, d.Eventer)
+	require.NotNil(t, d.decoder)
+	assert.Equal(t, QuadratureX4, d.decoder.resolution)
+	require.NotNil(t, d.encoderCfg)
+	assert.Equal(t, time.Millisecond, d.encoderCfg.readInterval)
+	assert.Equal(t, 100*time.Millisecond, d.encoderCfg.velocityWindow)
+	assert.Empty(t, d.encoderCfg.buttonPin)
+	assert.Equal(t, map[string]string{"1": "gpio", "2": "gpio", "": "gpio"}, d.usedPins())
+}
+
+func TestNewRotaryEncoderDriver_options(t *testing.T) {
+	// arrange
+	panicFunc := func() {
+		NewRotaryEncoderDriver(newGpioTestAdaptor(), "1", "2", WithName("crazy"),
+			aio.WithActuatorScaler(func(float64) int { return 0 }))
+	}
+	// act
+	d := NewRotaryEncoderDriver(newGpioTestAdaptor(), "1", "2",
+		WithName("knob"),
+		WithRotaryEncoderResolution(QuadratureX1),
+		WithRotaryEncoderPollInterval(5*time.Millisecond),
+		WithRotaryEncoderVelocityWindow(time.Second),
+		WithRotaryEncoderButton("3", 1),
+	)
+	// assert
+	assert.Equal(t, "knob", d.Name())
+	assert.Equal(t, QuadratureX1, d.decoder.resolution)
+	assert.Equal(t, 5*time.Millisecond, d.encoderCfg.readInterval)
+	assert.Equal(t, time.Second, d.encoderCfg.velocityWindow)
+	assert.Equal(t, "3", d.encoderCfg.buttonPin)
+	assert.Equal(t, 1, d.encoderCfg.buttonDefaultState)
+	assert.Equal(t, []string{"1", "2", "3"}, d.inputPins())
+	assert.PanicsWithValue(t, "'scaler option for analog actuators' can not be applied on 'crazy'", panicFunc)
+}
+
+func TestRotaryEncoderStart_Polling(t *testing.T) {
+	// arrange: the signals are simulated by a sequence, which is switched after both pins are read
+	a := newGpioTestAdaptor()
+	var mtx sync.Mutex
+	sequence := append([][2]int{{1, 1}}, quadratureTestCycle...)
+	index := 0
+	a.digitalReadFunc = func(pin string) (int, error) {
+		mtx.Lock()
+		defer mtx.Unlock()
+
+		if pin == "1" {
+			return sequence[index][0], nil
+		}
+		val := sequence[index][1]
+		if index < len(sequence)-1 {
+			index++
+		}
+		return val, nil
+	}
+	d := NewRotaryEncoderDriver(a, "1", "2")
+	positions := make(chan interface{}, 10)
+	_ = d.On(RotaryEncoderPositionChanged, func(data interface{}) { positions <- data })
+	clockwise := make(chan interface{}, 10)
+	_ = d.On(RotaryEncoderClockwise, func(data interface{}) { clockwise <- data })
+	// act
+	require.NoError(t, d.Start())
+	// assert
+	for want := 1; want <= 4; want++ {
+		select {
+		case data := <-positions:
+			assert.Equal(t, want, data)
+			assert.Equal(t, want, <-clockwise)
+		case <-time.After(time.Second):
+			require.Fail(t, "position changed event was not published", "position %d", want)
+		}
+	}
+	assert.Equal(t, 4, d.CurrentPosition())
+	require.NoError(t, d.Halt())
+}
+
+func TestRotaryEncoderStart_EdgeNotification(t *testing.T) {
+	// arrange
+	a := newGpioTestEdgeAdaptor()
+	a.digitalReadFunc = func(pin string) (int, error) { return 1, nil }
+	d := NewRotaryEncoderDriver(a, "1", "2", WithRotaryEncoderResolution(QuadratureX1),
+		WithRotaryEncoderButton("3", 1))
+	counterClockwise := make(chan interface{}, 1)
+	_ = d.On(RotaryEncoderCounterClockwise, func(data interface{}) { counterClockwise <- data })
+	pushed := make(chan interface{}, 1)
+	_ = d.On(ButtonPush, func(data interface{}) { pushed <- data })
+	// act
+	require.NoError(t, d.Start())
+	a.digitalReadFunc = func(pin string) (int, error) {
+		assert.Fail(t, "the pins should not be polled")
+		return 0, nil
+	}
+	// counter-clockwise: B leads A, a repeated value is ignored
+	require.True(t, a.edge("2", 0))
+	require.True(t, a.edge("2", 0))
+	require.True(t, a.edge("1", 0))
+	require.True(t, a.edge("2", 1))
+	require.True(t, a.edge("1", 1))
+	require.True(t, a.edge("3", 0))
+	// assert
+	select {
+	case data := <-counterClockwise:
+		assert.Equal(t, -1, data)
+	case <-time.After(buttonTestDelay * time.Millisecond):
+		assert.Fail(t, "counter-clockwise event was not published")
+	}
+	select {
+	case data := <-pushed:
+		assert.Equal(t, 0, data)
+	case <-time.After(buttonTestDelay * time.Millisecond):
+		assert.Fail(t, "push event was not published")
+	}
+	assert.Equal(t, -1, d.CurrentPosition())
+	// act & assert: the notification is stopped on halt
+	require.NoError(t, d.Halt())
+	assert.False(t, a.notified("1"))
+	assert.False(t, a.notified("2"))
+	assert.False(t, a.notified("3"))
+}
+
+func TestRotaryEncoderStart_FallbackToPolling(t *testing.T) {
+	// arrange
+	a := newGpioTestEdgeAdaptor()
+	a.notifyErr = fmt.Errorf("not supported")
+	d := NewRotaryEncoderDriver(a, "1", "2")
+	// act
+	require.NoError(t, d.Start())
+	// assert
+	assert.Nil(t, d.inputs)
+	require.NoError(t, d.Halt())
+}
+
+func TestRotaryEncoderStart_ReadError(t *testing.T) {
+	// arrange
+	a := newGpioTestAdaptor()
+	a.digitalReadFunc = func(pin string) (int, error) { return 0, fmt.Errorf("read error") }
+	d := NewRotaryEncoderDriver(a, "1", "2")
+	// act
+	err := d.Start()
+	// assert
+	require.ErrorContains(t, err, "read error")
+}
+
+func TestRotaryEncoderSetCurrentPosition(t *testing.T) {
+	// arrange
+	d := NewRotaryEncoderDriver(newGpioTestAdaptor(), "1", "2")
+	// act
+	d.SetCurrentPosition(-300)
+	// assert
+	assert.Equal(t, -300, d.CurrentPosition())
+	assert.Equal(t, -300, d.Command("CurrentPosition")(nil))
+}
+
+func TestRotaryEncoderVelocity(t *testing.T) {
+	// arrange
+	d := NewRotaryEncoderDriver(newGpioTestAdaptor(), "1", "2")
+	now := time.Now()
+	// act: 10 steps within 100ms
+	for i := int64(1); i <= 11; i++ {
+		d.updateVelocity(i, now.Add(time.Duration(i-1)*10*time.Millisecond))
+	}
+	// assert
+	assert.InDelta(t, 100.0, d.velocity, 1e-9)
+	// act & assert: after the window without a step the encoder is stopped
+	d.lastStep = time.Now().Add(-200 * time.Millisecond)
+	assert.InDelta(t, 0.0, d.Velocity(), 0.0)
+}
@@ -2,14 +2,73 @@ package gpio
 
 import (
 	"fmt"
+	"math"
+	"sync"
+	"time"
 
 	"gobot.io/x/gobot/v2"
 )
 
+const (
+	// servoUpdateInterval is the interval for writing the angle while moving, servos are refreshed with 50Hz, so a
+	// faster update makes no sense
+	servoUpdateInterval = 20 * time.Millisecond
+	// servoDefaultMinPulse and servoDefaultMaxPulse is the pulse range, which is assumed for the servo scale of
+	// adaptors without access to the PWM pins
+	servoDefaultMinPulse = 500 * time.Microsecond
+	servoDefaultMaxPulse = 2500 * time.Microsecond
+)
+
+// ServoEasing maps the progress of a move in time (0..1) to the progress of the angle (0..1). The function should
+// return 0 for 0 and 1 for 1.
+type ServoEasing func(t float64) float64
+
+// servoOptionApplier needs to be implemented by each configurable option type
+type servoOptionApplier interface {
+	apply(cfg *servoConfiguration)
+}
+
+// servoConfiguration contains all changeable attributes of the driver.
+type servoConfiguration struct {
+	speed    float64 // [°/s], zero for jumping to the target
+	easing   ServoEasing
+	minPulse time.Duration
+	maxPulse time.Duration
+	offset   float64 // [°]
+}
+
+// servoSpeedOption is the type for applying a speed to the configuration
+type servoSpeedOption float64
+
+// servoEasingOption is the type for applying an easing curve to the configuration
+type servoEasingOption ServoEasing
+
+// servoPulseRangeOption is the type for applying the calibrated pulse range to the configuration
+type servoPulseRangeOption struct {
+	min time.Duration
+	max time.Duration
+}
+
+// servoOffsetOption is the type for applying an angle offset to the configuration
+type servoOffsetOption float64
+
 // ServoDriver Represents a Servo
 type ServoDriver struct {
 	*driver
+	servoCfg *servoConfiguration
+	gobot.Eventer
 	currentAngle byte
+	valueMutex   *sync.Mutex // to ensure that read and write of the angle do not interfere
+	motionMutex  *sync.Mutex // to ensure that only one motion is running
+	stopMotion   chan struct{}
+	motion       *servoMotion
+}
+
+// servoMotion contains the result of a move, the error is written by the moving go routine before done is closed, so
+// the go routine never needs the motion mutex
+type servoMotion struct {
+	done chan struct{} // closed, when the motion is finished
+	err  error
 }
 
 // NewServoDriver returns a new ServoDriver given a ServoWriter and pin.
@@ -17,6 +76,10 @@ type ServoDriver struct {
 // Supported options:
 //
 //	"WithName"
+//	"WithServoSpeed"
+//	"WithServoEasing"
+//	"WithServoPulseRange"
+//	"WithServoOffset"
 //
 // Adds the following API Commands:
 //
@@ -24,12 +87,33 @@ type ServoDriver struct {
 //	"Min" - See ServoDriver.ToMin
 //	"Center" - See ServoDriver.ToCenter
 //	"Max" - See ServoDriver.ToMax
+//	"Stop" - See ServoDriver.Stop
 func NewServoDriver(a ServoWriter, pin string, opts ...interface{}) *ServoDriver {
 	//nolint:forcetypeassert // no error return value, so there is no better way
 	d := &ServoDriver{
-		driver: newDriver(a.(gobot.Connection), "Servo", append(opts, withPin(pin))...),
+		driver:      newDriver(a.(gobot.Connection), "Servo", withPin(pin)),
+		servoCfg:    &servoConfiguration{easing: ServoEasingLinear},
+		Eventer:     gobot.NewEventer(),
+		valueMutex:  &sync.Mutex{},
+		motionMutex: &sync.Mutex{},
+	}
+	d.usedPins = func() map[string]string { return map[string]string{d.driverCfg.pin: gobot.PinFunctionPwm} }
+	d.beforeHalt = d.Stop
+
+	for _, opt := range opts {
+		switch o := opt.(type) {
+		case optionApplier:
+			o.apply(d.driverCfg)
+		case servoOptionApplier:
+			o.apply(d.servoCfg)
+		default:
+			panic(fmt.Sprintf("'%s' can not be applied on '%s'", opt, d.driverCfg.name))
+		}
 	}
 
+	d.AddEvent(ServoReached)
+	d.AddEvent(Error)
+
 	d.AddCommand("Move", func(params map[string]interface{}) interface{} {
 		angle := byte(params["angle"].(float64)) //nolint:forcetypeassert // ok here
 		return d.Move(angle)
@@ -43,17 +127,139 @@ func NewServoDriver(a ServoWriter, pin string, opts ...interface{}) *ServoDriver
 	d.AddCommand("ToMax", func(params map[string]interface{}) interface{} {
 		return d.ToMax()
 	})
+	d.AddCommand("Stop", func(params map[string]interface{}) interface{} {
+		return d.Stop()
+	})
 
 	return d
 }
 
-// Move sets the servo to the specified angle. Acceptable angles are 0-180
+// WithServoSpeed configures the average speed in degrees per second for all moves. The default is zero, which means
+// the servo is set to the target angle immediately.
+func WithServoSpeed(degreesPerSecond float64) servoOptionApplier {
+	return servoSpeedOption(degreesPerSecond)
+}
+
+// WithServoEasing configures the easing curve for all moves, the default is ServoEasingLinear. The easing is only
+// used, if a speed is configured.
+func WithServoEasing(easing ServoEasing) servoOptionApplier {
+	return servoEasingOption(easing)
+}
+
+// WithServoPulseRange calibrates the servo by the pulse width for the angle 0° and 180°. If the adaptor provides
+// access to the PWM pins (interface gobot.PWMPinnerProvider), the pulse is written directly as duty cycle, so the
+// servo scale of the adaptor is not used. Otherwise the angle written to the adaptor is adjusted, which assumes the
+// pulse range 0.5 ms..2.5 ms for 0..180° and is limited to the resolution of 1°. This is not true for all adaptors,
+// e.g. firmata uses 0.544 ms..2.4 ms, and not for a changed duty cycle range of the adaptor. In this case a pulse
+// range outside of 0.5 ms..2.5 ms leads to an error on write.
+func WithServoPulseRange(min, max time.Duration) servoOptionApplier {
+	return servoPulseRangeOption{min: min, max: max}
+}
+
+// WithServoOffset calibrates the servo by an offset in degrees, which is added to each angle before it is written.
+// Use this, so mechanically different servos reach the same position for the same angle.
+func WithServoOffset(degrees float64) servoOptionApplier {
+	return servoOffsetOption(degrees)
+}
+
+// ServoEasingLinear moves with a constant speed.
+func ServoEasingLinear(t float64) float64 {
+	return t
+}
+
+// ServoEasingInOutSine accelerates and decelerates with a sine curve.
+func ServoEasingInOutSine(t float64) float64 {
+	return -(math.Cos(math.Pi*t) - 1) / 2
+}
+
+// ServoEasingInOutQuad accelerates and decelerates with a quadratic curve.
+func ServoEasingInOutQuad(t float64) float64 {
+	if t < 0.5 {
+		return 2 * t * t
+	}
+	return 1 - math.Pow(-2*t+2, 2)/2
+}
+
+// ServoEasingInOutCubic accelerates and decelerates with a cubic curve, which is smoother than the quadratic one.
+func ServoEasingInOutCubic(t float64) float64 {
+	if t < 0.5 {
+		return 4 * t * t * t
+	}
+	return 1 - math.Pow(-2*t+2, 3)/2
+}
+
+// Move sets the servo to the specified angle. Acceptable angles are 0-180. If a speed is configured, the servo moves
+// to the angle with this speed and the function blocks until the angle is reached.
 func (d *ServoDriver) Move(angle uint8) error {
-	if angle > 180 {
-		return fmt.Errorf("servo angle (%d) must be between 0-180", angle)
+	if err := d.MoveAsync(angle); err != nil {
+		return err
+	}
+
+	return d.Wait()
+}
+
+// MoveAsync starts the move to the specified angle and returns immediately. The event ServoReached is published,
+// when the angle is reached. A running move is stopped before.
+func (d *ServoDriver) MoveAsync(angle uint8) error {
+	d.valueMutex.Lock()
+	speed := d.servoCfg.speed
+	easing := d.servoCfg.easing
+	d.valueMutex.Unlock()
+
+	var duration time.Duration
+	if speed > 0 {
+		duration = time.Duration(math.Abs(float64(angle)-float64(d.Angle())) / speed * float64(time.Second))
+	}
+
+	return d.moveWithin(angle, duration, easing)
+}
+
+// Wait blocks until the current move is finished and returns the error of the move, if any.
+func (d *ServoDriver) Wait() error {
+	d.motionMutex.Lock()
+	motion := d.motion
+	d.motionMutex.Unlock()
+
+	if motion == nil {
+		return nil
+	}
+	<-motion.done
+
+	return motion.err
+}
+
+// Stop stops the current move at the current angle, the event ServoReached is not published.
+func (d *ServoDriver) Stop() error {
+	d.motionMutex.Lock()
+	defer d.motionMutex.Unlock()
+
+	d.stopMotionLocked()
+	return nil
+}
+
+// SetSpeed sets the average speed in degrees per second for the next moves, zero means no smooth motion.
+func (d *ServoDriver) SetSpeed(degreesPerSecond float64) {
+	d.valueMutex.Lock()
+	defer d.valueMutex.Unlock()
+
+	d.servoCfg.speed = degreesPerSecond
+}
+
+// IsMoving returns true, if the servo is currently moving.
+func (d *ServoDriver) IsMoving() bool {
+	d.motionMutex.Lock()
+	defer d.motionMutex.Unlock()
+
+	if d.motion == nil {
+		return false
+	}
+
+	select {
+	case <-d.motion.done:
+		return false
+	default:
+		return true
 	}
-	d.currentAngle = angle
-	return d.servoWrite(d.driverCfg.pin, angle)
 }
 
 // Min sets the servo to it's minimum position
@@ -73,5 +279,165 @@ func (d *ServoDriver) ToMax() error {
 
 // Angle returns the current angle
 func (d *ServoDriver) Angle() uint8 {
+	d.valueMutex.Lock()
+	defer d.valueMutex.Unlock()
+
 	return d.currentAngle
 }
+
+// moveWithin moves the servo to the given angle within the given duration. For a duration of zero the angle is
+// written immediately.
+func (d *ServoDriver) moveWithin(angle uint8, duration time.Duration, easing ServoEasing) error {
+	if angle > 180 {
+		return fmt.Errorf("servo angle (%d) must be between 0-180", angle)
+	}
+
+	d.motionMutex.Lock()
+	defer d.motionMutex.Unlock()
+
+	d.stopMotionLocked()
+
+	start := d.Angle()
+	if duration <= 0 || start == angle {
+		d.setAngle(angle)
+		err := d.writeAngle(float64(angle))
+		if err == nil {
+			d.Publish(ServoReached, angle)
+		}
+		return err
+	}
+
+	if easing == nil {
+		easing = ServoEasingLinear
+	}
+
+	stop := make(chan struct{})
+	motion := &servoMotion{done: make(chan struct{})}
+	d.stopMotion = stop
+	d.motion = motion
+
+	go func() {
+		defer close(motion.done)
+
+		ticker := time.NewTicker(servoUpdateInterval)
+		defer ticker.Stop()
+
+		begin := time.Now()
+		for {
+			select {
+			case <-stop:
+				return
+			case now := <-ticker.C:
+				progress := math.Min(1, float64(now.Sub(begin))/float64(duration))
+				current := float64(start) + (float64(angle)-float64(start))*easing(progress)
+				d.setAngle(uint8(math.Round(current)))
+				if err := d.writeAngle(current); err != nil {
+					motion.err = err
+					d.Publish(Error, err)
+					return
+				}
+				if progress >= 1 {
+					d.Publish(ServoReached, angle)
+					return
+				}
+			}
+		}
+	}()
+
+	return nil
+}
+
+// stopMotionLocked stops the running motion and waits until it is finished, the motion mutex needs to be locked
+func (d *ServoDriver) stopMotionLocked() {
+	if d.stopMotion == nil {
+		return
+	}
+
+	close(d.stopMotion)
+	d.stopMotion = nil
+	<-d.motion.done
+}
+
+func (d *ServoDriver) setAngle(angle uint8) {
+	d.valueMutex.Lock()
+	defer d.valueMutex.Unlock()
+
+	d.currentAngle = angle
+}
+
+// writeAngle applies the calibration and writes the angle to the adaptor
+func (d *ServoDriver) writeAngle(angle float64) error {
+	d.valueMutex.Lock()
+	cfg := *d.servoCfg
+	d.valueMutex.Unlock()
+
+	angle = math.Max(0, math.Min(180, angle+cfg.offset))
+	if cfg.minPulse == 0 && cfg.maxPulse == 0 {
+		return d.servoWrite(d.driverCfg.pin, byte(math.Round(angle)))
+	}
+
+	pulse := float64(cfg.minPulse) + float64(cfg.maxPulse-cfg.minPulse)*angle/180
+	if provider, ok := d.connection.(gobot.PWMPinnerProvider); ok {
+		return d.writePulse(provider, time.Duration(math.Round(pulse)))
+	}
+
+	if cfg.minPulse < servoDefaultMinPulse || cfg.maxPulse > servoDefaultMaxPulse {
+		return fmt.Errorf("pulse range %v..%v of '%s' exceeds the servo range %v..%v of the adaptor",
+			cfg.minPulse, cfg.maxPulse, d.driverCfg.name, servoDefaultMinPulse, servoDefaultMaxPulse)
+	}
+	angle = gobot.ToScale(gobot.FromScale(pulse, float64(servoDefaultMinPulse), float64(servoDefaultMaxPulse)), 0, 180)
+
+	return d.servoWrite(d.driverCfg.pin, byte(math.Round(angle)))
+}
+
+// writePulse writes the calibrated pulse as duty cycle to the PWM pin
+func (d *ServoDriver) writePulse(provider gobot.PWMPinnerProvider, pulse time.Duration) error {
+	pin, err := provider.PWMPin(d.driverCfg.pin)
+	if err != nil {
+		return err
+	}
+
+	period, err := pin.Period()
+	if err != nil {
+		return err
+	}
+	if period > 0 && uint32(pulse) > period {
+		return fmt.Errorf("pulse %v of '%s' exceeds the PWM period %v", pulse, d.driverCfg.name,
+			time.Duration(period))
+	}
+
+	return pin.SetDutyCycle(uint32(pulse))
+}
+
+func (o servoSpeedOption) String() string {
+	return "speed option for servos"
+}
+
+func (o servoEasingOption) String() string {
+	return "easing option for servos"
+}
+
+func (o servoPulseRangeOption) String() string {
+	return "pulse range option for servos"
+}
+
+func (o servoOffsetOption) String() string {
+	return "offset option for servos"
+}
+
+func (o servoSpeedOption) apply(cfg *servoConfiguration) {
+	cfg.speed = float64(o)
+}
+
+func (o servoEasingOption) apply(cfg *servoConfiguration) {
+	cfg.easing = ServoEasing(o)
+}
+
+func (o servoPulseRangeOption) apply(cfg *servoConfiguration) {
+	cfg.minPulse = o.min
+	cfg.maxPulse = o.max
+}
+
+func (o servoOffsetOption) apply(cfg *servoConfiguration) {
+	cfg.offset = float64(o)
+}
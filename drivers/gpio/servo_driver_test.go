@@ -3,8 +3,11 @@ package gpio
 
 import (
 	"errors"
+	"math"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -37,6 +40,13 @@ func TestNewServoDriver(t *testing.T) {
 	assert.NotNil(t, d.mutex)
 	// assert: driver specific attributes
 	assert.Equal(t, uint8(0), d.currentAngle)
+	assert.NotNil(t, d.Eventer)
+	require.NotNil(t, d.servoCfg)
+	assert.InDelta(t, 0.0, d.servoCfg.speed, 0.0)
+	assert.NotNil(t, d.servoCfg.easing)
+	assert.Equal(t, time.Duration(0), d.servoCfg.minPulse)
+	assert.Equal(t, time.Duration(0), d.servoCfg.maxPulse)
+	assert.InDelta(t, 0.0, d.servoCfg.offset, 0.0)
 }
 
 func TestNewServoDriver_options(t *testing.T) {
@@ -58,6 +68,18 @@ func TestNewServoDriver_options(t *testing.T) {
 	assert.PanicsWithValue(t, "'scaler option for analog actuators' can not be applied on 'crazy'", panicFunc)
 }
 
+func TestNewServoDriver_motionOptions(t *testing.T) {
+	// arrange & act
+	d := NewServoDriver(newGpioTestAdaptor(), "1", WithServoSpeed(60), WithServoEasing(ServoEasingInOutSine),
+		WithServoPulseRange(time.Millisecond, 2*time.Millisecond), WithServoOffset(-3.5))
+	// assert
+	assert.InDelta(t, 60.0, d.servoCfg.speed, 0.0)
+	assert.InDelta(t, ServoEasingInOutSine(0.25), d.servoCfg.easing(0.25), 0.0)
+	assert.Equal(t, time.Millisecond, d.servoCfg.minPulse)
+	assert.Equal(t, 2*time.Millisecond, d.servoCfg.maxPulse)
+	assert.InDelta(t, -3.5, d.servoCfg.offset, 0.0)
+}
+
 func TestServo_Commands(t *testing.T) {
 	var err interface{}
 
@@ -107,3 +129,266 @@ func TestServoCenter(t *testing.T) {
 	_ = d.ToCenter()
 	assert.Equal(t, uint8(90), d.currentAngle)
 }
+
+func TestServoEasing(t *testing.T) {
+	tests := map[string]struct {
+		easing ServoEasing
+		want   float64 // at 0.25
+	}{
+		"linear":       {easing: ServoEasingLinear, want: 0.25},
+		"in_out_sine":  {easing: ServoEasingInOutSine, want: (1 - math.Sqrt2/2) / 2},
+		"in_out_quad":  {easing: ServoEasingInOutQuad, want: 0.125},
+		"in_out_cubic": {easing: ServoEasingInOutCubic, want: 0.0625},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// act & assert
+			assert.InDelta(t, 0.0, tc.easing(0), 1e-9)
+			assert.InDelta(t, 0.5, tc.easing(0.5), 1e-9)
+			assert.InDelta(t, 1.0, tc.easing(1), 1e-9)
+			assert.InDelta(t, tc.want, tc.easing(0.25), 1e-9)
+			// the curves are point symmetric
+			assert.InDelta(t, 1-tc.want, tc.easing(0.75), 1e-9)
+		})
+	}
+}
+
+func TestServoWriteCalibration(t *testing.T) {
+	tests := map[string]struct {
+		opts  []interface{}
+		angle uint8
+		want  byte
+	}{
+		"no_calibration": {angle: 45, want: 45},
+		"offset":         {opts: []interface{}{WithServoOffset(-4.6)}, angle: 45, want: 40},
+		"offset_limited": {opts: []interface{}{WithServoOffset(10)}, angle: 175, want: 180},
+		"pulse_range_min": {
+			opts: []interface{}{WithServoPulseRange(time.Millisecond, 2*time.Millisecond)},
+			want: 45,
+		},
+		"pulse_range_max": {
+			opts:  []interface{}{WithServoPulseRange(time.Millisecond, 2*time.Millisecond)},
+			angle: 180,
+			want:  135,
+		},
+		"pulse_range_offset": {
+			opts:  []interface{}{WithServoPulseRange(time.Millisecond, 2*time.Millisecond), WithServoOffset(20)},
+			angle: 70,
+			want:  90,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := newGpioTestAdaptor()
+			var written []byte
+			a.servoWriteFunc = func(pin string, val byte) error {
+				written = append(written, val)
+				return nil
+			}
+			d := NewServoDriver(a, "1", tc.opts...)
+			// act
+			err := d.Move(tc.angle)
+			// assert
+			require.NoError(t, err)
+			assert.Equal(t, []byte{tc.want}, written)
+			assert.Equal(t, tc.angle, d.Angle())
+		})
+	}
+}
+
+func TestServoWriteCalibration_pulseRangeExceeded(t *testing.T) {
+	// arrange
+	a := newGpioTestAdaptor()
+	a.servoWriteFunc = func(string, byte) error {
+		require.Fail(t, "servo write should not be called")
+		return nil
+	}
+	d := NewServoDriver(a, "1", WithServoPulseRange(400*time.Microsecond, 2400*time.Microsecond))
+	// act
+	err := d.Move(90)
+	// assert
+	require.EqualError(t, err, "pulse range 400µs..2.4ms of '"+d.Name()+"' exceeds the servo range 500µs..2.5ms "+
+		"of the adaptor")
+}
+
+func TestServoWriteCalibration_pwmPin(t *testing.T) {
+	tests := map[string]struct {
+		opts    []interface{}
+		angle   uint8
+		period  uint32
+		want    []uint32
+		wantErr string
+	}{
+		"pulse_range_min": {
+			opts: []interface{}{WithServoPulseRange(544*time.Microsecond, 2400*time.Microsecond)},
+			want: []uint32{544000},
+		},
+		"pulse_range_fine": {
+			opts:  []interface{}{WithServoPulseRange(544*time.Microsecond, 2400*time.Microsecond)},
+			angle: 1,
+			want:  []uint32{554311},
+		},
+		"pulse_range_wide": {
+			opts:   []interface{}{WithServoPulseRange(400*time.Microsecond, 2600*time.Microsecond)},
+			angle:  180,
+			period: 20000000,
+			want:   []uint32{2600000},
+		},
+		"pulse_range_offset": {
+			opts:  []interface{}{WithServoPulseRange(time.Millisecond, 2*time.Millisecond), WithServoOffset(20)},
+			angle: 70,
+			want:  []uint32{1500000},
+		},
+		"error_period_exceeded": {
+			opts:    []interface{}{WithServoPulseRange(time.Millisecond, 2*time.Millisecond)},
+			angle:   180,
+			period:  1500000,
+			wantErr: "pulse 2ms of 'servo' exceeds the PWM period 1.5ms",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := newGpioTestPwmAdaptor()
+			a.servoWriteFunc = func(string, byte) error {
+				require.Fail(t, "servo write should not be called")
+				return nil
+			}
+			pin := a.addPwmPin("1")
+			pin.period = tc.period
+			d := NewServoDriver(a, "1", append(tc.opts, WithName("servo"))...)
+			// act
+			err := d.Move(tc.angle)
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.want, pin.writtenDutyCycles())
+		})
+	}
+}
+
+func TestServoMoveAsync(t *testing.T) {
+	// arrange
+	a := newGpioTestAdaptor()
+	var mtx sync.Mutex
+	var written []byte
+	a.servoWriteFunc = func(pin string, val byte) error {
+		mtx.Lock()
+		defer mtx.Unlock()
+		written = append(written, val)
+		return nil
+	}
+	d := NewServoDriver(a, "1", WithServoSpeed(900))
+	reached := make(chan interface{}, 1)
+	_ = d.Once(ServoReached, func(data interface{}) { reached <- data })
+	begin := time.Now()
+	// act
+	err := d.MoveAsync(90)
+	// assert
+	require.NoError(t, err)
+	assert.True(t, d.IsMoving())
+	require.NoError(t, d.Wait())
+	assert.False(t, d.IsMoving())
+	assert.GreaterOrEqual(t, time.Since(begin), 100*time.Millisecond)
+	assert.Equal(t, uint8(90), d.Angle())
+	select {
+	case data := <-reached:
+		assert.Equal(t, uint8(90), data)
+	case <-time.After(100 * time.Millisecond):
+		assert.Fail(t, "reached event was not published")
+	}
+	mtx.Lock()
+	defer mtx.Unlock()
+	require.Greater(t, len(written), 3)
+	assert.Equal(t, byte(90), written[len(written)-1])
+	for i := 1; i < len(written); i++ {
+		assert.GreaterOrEqual(t, written[i], written[i-1])
+	}
+}
+
+func TestServoStop(t *testing.T) {
+	// arrange
+	d := NewServoDriver(newGpioTestAdaptor(), "1", WithServoSpeed(100))
+	reached := make(chan interface{}, 1)
+	_ = d.Once(ServoReached, func(data interface{}) { reached <- data })
+	require.NoError(t, d.MoveAsync(180))
+	time.Sleep(100 * time.Millisecond)
+	// act
+	err := d.Stop()
+	// assert
+	require.NoError(t, err)
+	assert.False(t, d.IsMoving())
+	assert.Greater(t, d.Angle(), uint8(0))
+	assert.Less(t, d.Angle(), uint8(180))
+	select {
+	case <-reached:
+		assert.Fail(t, "reached event should not be published")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestServoMoveAsync_WriteError(t *testing.T) {
+	// arrange
+	a := newGpioTestAdaptor()
+	d := NewServoDriver(a, "1", WithServoSpeed(1000))
+	a.servoWriteFunc = func(string, byte) error { return errors.New("pwm error") }
+	errorEvent := make(chan interface{}, 1)
+	_ = d.Once(Error, func(data interface{}) { errorEvent <- data })
+	// act
+	require.NoError(t, d.MoveAsync(90))
+	err := d.Wait()
+	// assert
+	require.EqualError(t, err, "pwm error")
+	select {
+	case data := <-errorEvent:
+		require.EqualError(t, data.(error), "pwm error")
+	case <-time.After(100 * time.Millisecond):
+		assert.Fail(t, "error event was not published")
+	}
+}
+
+func TestServoStop_WriteError(t *testing.T) {
+	// arrange: the write blocks until released, so the stop overlaps the failing write
+	a := newGpioTestAdaptor()
+	d := NewServoDriver(a, "1", WithServoSpeed(100))
+	writing := make(chan struct{})
+	release := make(chan struct{})
+	var once sync.Once
+	a.servoWriteFunc = func(string, byte) error {
+		once.Do(func() { close(writing) })
+		<-release
+		return errors.New("pwm error")
+	}
+	require.NoError(t, d.MoveAsync(180))
+	<-writing
+	stopped := make(chan error)
+	// act
+	go func() { stopped <- d.Stop() }()
+	time.Sleep(10 * time.Millisecond) // let the stop wait for the end of the motion
+	close(release)
+	// assert
+	select {
+	case err := <-stopped:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		require.Fail(t, "stop is blocked by the failing motion")
+	}
+	require.EqualError(t, d.Wait(), "pwm error")
+}
+
+func TestServoSetSpeed(t *testing.T) {
+	// arrange
+	d := initTestServoDriver()
+	d.SetSpeed(120)
+	// act
+	begin := time.Now()
+	err := d.Move(12)
+	// assert
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(begin), 100*time.Millisecond)
+	assert.Equal(t, uint8(12), d.Angle())
+}
@@ -0,0 +1,215 @@
+package gpio
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+// ServoKeyframe is a pose of all servos of a sequence together with the timing to reach it.
+type ServoKeyframe struct {
+	// Angles contains the target angle for each servo in the order of the sequence.
+	Angles []uint8
+	// Duration is the time to move all servos from the previous pose to this pose.
+	Duration time.Duration
+	// Easing is the easing curve for all servos, if nil the easing of each servo is used.
+	Easing ServoEasing
+}
+
+// ServoSequence plays keyframes (poses with timing) across several servos, e.g. for a robot arm or a pan/tilt head.
+// All servos start each keyframe together and reach the pose at the same time. The configured speed of the servos
+// is not used, the timing is given by the keyframes.
+type ServoSequence struct {
+	servos    []*ServoDriver
+	keyframes []ServoKeyframe
+	mutex     sync.Mutex
+	stop      chan struct{}
+	done      chan struct{} // closed, when the sequence is finished
+	err       error
+}
+
+// NewServoSequence creates a sequence for the given servos, keyframes can be added by AddKeyframe().
+func NewServoSequence(servos ...*ServoDriver) *ServoSequence {
+	return &ServoSequence{servos: servos}
+}
+
+// AddKeyframe adds a pose, which is reached after the given duration. The count of angles needs to match the count
+// of servos, which is checked on play.
+func (s *ServoSequence) AddKeyframe(duration time.Duration, angles ...uint8) *ServoSequence {
+	return s.Add(ServoKeyframe{Angles: angles, Duration: duration})
+}
+
+// Add adds the given keyframe, e.g. with a special easing.
+func (s *ServoSequence) Add(keyframe ServoKeyframe) *ServoSequence {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.keyframes = append(s.keyframes, keyframe)
+	return s
+}
+
+// Keyframes returns a copy of all keyframes of the sequence.
+func (s *ServoSequence) Keyframes() []ServoKeyframe {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return append([]ServoKeyframe(nil), s.keyframes...)
+}
+
+// Play plays all keyframes and waits until the last pose is reached.
+func (s *ServoSequence) Play() error {
+	if err := s.PlayAsync(); err != nil {
+		return err
+	}
+
+	return s.Wait()
+}
+
+// PlayAsync starts to play all keyframes and returns immediately. An error is returned, if a keyframe is invalid or
+// the sequence is already playing.
+func (s *ServoSequence) PlayAsync() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.isPlayingLocked() {
+		return fmt.Errorf("the sequence is already playing")
+	}
+
+	for i, kf := range s.keyframes {
+		if len(kf.Angles) != len(s.servos) {
+			return fmt.Errorf("keyframe %d: %d angles given for %d servos", i, len(kf.Angles), len(s.servos))
+		}
+		for _, angle := range kf.Angles {
+			if angle > 180 {
+				return fmt.Errorf("keyframe %d: servo angle (%d) must be between 0-180", i, angle)
+			}
+		}
+	}
+
+	keyframes := append([]ServoKeyframe(nil), s.keyframes...)
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	s.stop = stop
+	s.done = done
+	s.err = nil
+
+	go func() {
+		defer close(done)
+
+		for _, kf := range keyframes {
+			err := s.playKeyframe(kf, stop)
+
+			s.mutex.Lock()
+			s.err = err
+			s.mutex.Unlock()
+
+			if err != nil {
+				return
+			}
+			select {
+			case <-stop:
+				return
+			default:
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Wait blocks until the sequence is finished and returns the errors of the servos, if any.
+func (s *ServoSequence) Wait() error {
+	s.mutex.Lock()
+	done := s.done
+	s.mutex.Unlock()
+
+	if done == nil {
+		return nil
+	}
+	<-done
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.err
+}
+
+// Stop stops the sequence and all servos at their current angle.
+func (s *ServoSequence) Stop() error {
+	s.mutex.Lock()
+	if !s.isPlayingLocked() || s.stop == nil {
+		s.mutex.Unlock()
+		return nil
+	}
+	close(s.stop)
+	s.stop = nil
+	var err error
+	for _, servo := range s.servos {
+		if e := servo.Stop(); e != nil {
+			err = multierror.Append(err, e)
+		}
+	}
+	done := s.done
+	s.mutex.Unlock()
+
+	<-done
+
+	return err
+}
+
+// playKeyframe moves all servos to the pose of the keyframe and waits until all servos are finished
+func (s *ServoSequence) playKeyframe(kf ServoKeyframe, stop <-chan struct{}) error {
+	// the moves are started with locked mutex, so a stop can not interfere
+	s.mutex.Lock()
+	select {
+	case <-stop:
+		s.mutex.Unlock()
+		return nil
+	default:
+	}
+
+	var err error
+	for i, servo := range s.servos {
+		easing := kf.Easing
+		if easing == nil {
+			servo.valueMutex.Lock()
+			easing = servo.servoCfg.easing
+			servo.valueMutex.Unlock()
+		}
+		if e := servo.moveWithin(kf.Angles[i], kf.Duration, easing); e != nil {
+			err = multierror.Append(err, e)
+		}
+	}
+	s.mutex.Unlock()
+
+	if err == nil && kf.Duration > 0 {
+		// wait the whole duration, so a keyframe without any change of the angles is a pause
+		select {
+		case <-time.After(kf.Duration):
+		case <-stop:
+		}
+	}
+
+	for _, servo := range s.servos {
+		if e := servo.Wait(); e != nil {
+			err = multierror.Append(err, e)
+		}
+	}
+
+	return err
+}
+
+func (s *ServoSequence) isPlayingLocked() bool {
+	if s.done == nil {
+		return false
+	}
+
+	select {
+	case <-s.done:
+		return false
+	default:
+		return true
+	}
+}
@@ -0,0 +1,85 @@
+package gpio
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServoSequenceAddKeyframe(t *testing.T) {
+	// arrange
+	s := NewServoSequence(initTestServoDriver(), initTestServoDriver())
+	// act
+	s.AddKeyframe(time.Second, 10, 20).
+		Add(ServoKeyframe{Angles: []uint8{30, 40}, Duration: 2 * time.Second, Easing: ServoEasingInOutQuad})
+	// assert
+	got := s.Keyframes()
+	require.Len(t, got, 2)
+	assert.Equal(t, []uint8{10, 20}, got[0].Angles)
+	assert.Equal(t, time.Second, got[0].Duration)
+	assert.Nil(t, got[0].Easing)
+	assert.Equal(t, []uint8{30, 40}, got[1].Angles)
+	assert.NotNil(t, got[1].Easing)
+}
+
+func TestServoSequencePlay(t *testing.T) {
+	// arrange
+	pan := initTestServoDriver()
+	tilt := initTestServoDriver()
+	s := NewServoSequence(pan, tilt).
+		AddKeyframe(60*time.Millisecond, 90, 45).
+		AddKeyframe(0, 100, 40).
+		AddKeyframe(50*time.Millisecond, 100, 40) // pause
+	begin := time.Now()
+	// act
+	err := s.Play()
+	// assert
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(begin), 110*time.Millisecond)
+	assert.Equal(t, uint8(100), pan.Angle())
+	assert.Equal(t, uint8(40), tilt.Angle())
+}
+
+func TestServoSequencePlayAsync(t *testing.T) {
+	tests := map[string]struct {
+		angles  []uint8
+		wantErr string
+	}{
+		"count_mismatch": {angles: []uint8{10}, wantErr: "keyframe 0: 1 angles given for 2 servos"},
+		"invalid_angle":  {angles: []uint8{10, 190}, wantErr: "keyframe 0: servo angle (190) must be between 0-180"},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			s := NewServoSequence(initTestServoDriver(), initTestServoDriver()).AddKeyframe(time.Second, tc.angles...)
+			// act
+			err := s.PlayAsync()
+			// assert
+			require.EqualError(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestServoSequenceStop(t *testing.T) {
+	// arrange
+	servo := initTestServoDriver()
+	s := NewServoSequence(servo).
+		AddKeyframe(time.Second, 180).
+		AddKeyframe(time.Second, 0)
+	require.NoError(t, s.PlayAsync())
+	require.EqualError(t, s.PlayAsync(), "the sequence is already playing")
+	time.Sleep(100 * time.Millisecond)
+	// act
+	begin := time.Now()
+	err := s.Stop()
+	// assert
+	require.NoError(t, err)
+	assert.Less(t, time.Since(begin), 500*time.Millisecond)
+	assert.False(t, servo.IsMoving())
+	assert.Greater(t, servo.Angle(), uint8(0))
+	assert.Less(t, servo.Angle(), uint8(180))
+	require.NoError(t, s.Wait())
+	require.NoError(t, s.Stop())
+}
@@ -58,9 +58,56 @@ var StepperModes = struct {
 	},
 }
 
+// StepperProfile is the velocity profile, which is used to accelerate and decelerate the motor
+type StepperProfile int
+
+const (
+	// StepperProfileTrapezoidal accelerates with a constant acceleration, so the speed rises and falls linearly
+	StepperProfileTrapezoidal StepperProfile = iota
+	// StepperProfileSCurve accelerates smoothly (limited jerk), the speed follows a half cosine curve and the configured
+	// acceleration is reached only in the middle of the ramp
+	StepperProfileSCurve
+)
+
+// stepperOptionApplier needs to be implemented by each configurable option type
+type stepperOptionApplier interface {
+	apply(cfg *stepperConfiguration)
+}
+
+// stepperConfiguration contains all changeable attributes of the driver.
+type stepperConfiguration struct {
+	acceleration  uint // [rpm/s], zero for constant speed
+	profile       StepperProfile
+	maxSpeed      uint // [rpm], zero for the default, see MaxSpeed()
+	softLimits    bool
+	minPosition   int
+	maxPosition   int
+	homeSwitchPin string
+}
+
+// stepperAccelerationOption is the type for applying the acceleration and deceleration
+type stepperAccelerationOption uint
+
+// stepperProfileOption is the type for applying the velocity profile
+type stepperProfileOption StepperProfile
+
+// stepperMaxSpeedOption is the type for applying another maximum speed
+type stepperMaxSpeedOption uint
+
+// stepperSoftLimitsOption is the type for applying the allowed range of the absolute position
+type stepperSoftLimitsOption struct {
+	minPosition int
+	maxPosition int
+}
+
+// stepperHomeSwitchPinOption is the type for applying the pin of the limit switch used for homing
+type stepperHomeSwitchPinOption string
+
 // StepperDriver is a common driver for stepper motors. It supports 3 different stepping modes.
 type StepperDriver struct {
 	*driver
+	stepperCfg stepperConfiguration
+	gobot.Eventer
 
 	pins        [4]string
 	phase       phase
@@ -76,8 +123,13 @@ type StepperDriver struct {
 
 	stepFunc          func() error
 	sleepFunc         func() error
+	directionFunc     func(direction string) error
 	stepNum           int
+	position          int           // absolute position, not reset after each revolution
+	rampDelay         time.Duration // delay of the current step while accelerating or decelerating
 	stopAsynchRunFunc func(bool) error
+	runDone           chan struct{} // closed, when the asynchronous stepping is finished
+	runEndless        bool
 }
 
 // NewStepperDriver returns a new StepperDriver given a DigitalWriter
@@ -88,6 +140,11 @@ type StepperDriver struct {
 // Supported options:
 //
 //	"WithName"
+//	"WithStepperAcceleration"
+//	"WithStepperProfile"
+//	"WithStepperMaxSpeed"
+//	"WithStepperSoftLimits"
+//	"WithStepperHomeSwitch"
 func NewStepperDriver(
 	a DigitalWriter,
 	pins [4]string,
@@ -100,7 +157,8 @@ func NewStepperDriver(
 	}
 	//nolint:forcetypeassert // no error return value, so there is no better way
 	d := &StepperDriver{
-		driver:         newDriver(a.(gobot.Connection), "Stepper", opts...),
+		driver:         newDriver(a.(gobot.Connection), "Stepper"),
+		Eventer:        gobot.NewEventer(),
 		pins:           pins,
 		phase:          phase,
 		stepsPerRev:    float32(stepsPerRev),
@@ -112,10 +170,36 @@ func NewStepperDriver(
 		speedRpm:       1,
 		valueMutex:     &sync.Mutex{},
 	}
-	d.speedRpm = d.MaxSpeed()
 	d.stepFunc = d.phasedStepping
 	d.sleepFunc = d.sleepOuputs
+	d.directionFunc = d.SetDirection
 	d.beforeHalt = d.shutdown
+	d.usedPins = func() map[string]string {
+		pins := make(map[string]string, len(d.pins)+1)
+		for _, pin := range d.pins {
+			pins[pin] = gobot.PinFunctionGpio
+		}
+		if d.stepperCfg.homeSwitchPin != "" {
+			pins[d.stepperCfg.homeSwitchPin] = gobot.PinFunctionGpio
+		}
+		return pins
+	}
+
+	for _, opt := range opts {
+		switch o := opt.(type) {
+		case optionApplier:
+			o.apply(d.driverCfg)
+		case stepperOptionApplier:
+			o.apply(&d.stepperCfg)
+		default:
+			panic(fmt.Sprintf("'%s' can not be applied on '%s'", opt, d.driverCfg.name))
+		}
+	}
+
+	d.speedRpm = d.MaxSpeed()
+
+	d.AddEvent(StepperMoveDone)
+	d.AddEvent(Error)
 
 	d.AddCommand("MoveDeg", func(params map[string]interface{}) interface{} {
 		degs, _ := strconv.Atoi(params["degs"].(string))
@@ -125,6 +209,10 @@ func NewStepperDriver(
 		steps, _ := strconv.Atoi(params["steps"].(string))
 		return d.Move(steps)
 	})
+	d.AddCommand("MoveTo", func(params map[string]interface{}) interface{} {
+		position, _ := strconv.Atoi(params["position"].(string))
+		return d.MoveTo(position)
+	})
 	d.AddCommand("Step", func(params map[string]interface{}) interface{} {
 		return d.Move(1)
 	})
@@ -144,6 +232,36 @@ func NewStepperDriver(
 	return d
 }
 
+// WithStepperAcceleration configures the acceleration and deceleration in RPM per second for all moves and runs.
+// The default is zero, which means the motor steps with a constant speed from the first step.
+func WithStepperAcceleration(rpmPerSecond uint) stepperOptionApplier {
+	return stepperAccelerationOption(rpmPerSecond)
+}
+
+// WithStepperProfile configures the velocity profile for acceleration and deceleration, the default is
+// StepperProfileTrapezoidal. The profile is only used, if an acceleration is configured.
+func WithStepperProfile(profile StepperProfile) stepperOptionApplier {
+	return stepperProfileOption(profile)
+}
+
+// WithStepperMaxSpeed configures the maximum speed in RPM, which is also used as the initial speed. Use this to
+// exceed the default value, e.g. when the speed is ramped up by an acceleration.
+func WithStepperMaxSpeed(rpm uint) stepperOptionApplier {
+	return stepperMaxSpeedOption(rpm)
+}
+
+// WithStepperSoftLimits configures the allowed range of the absolute position. Moves with a target outside of the
+// range are refused and a continuous run stops at the limit.
+func WithStepperSoftLimits(minPosition, maxPosition int) stepperOptionApplier {
+	return stepperSoftLimitsOption{minPosition: minPosition, maxPosition: maxPosition}
+}
+
+// WithStepperHomeSwitch configures the pin of the limit switch, which is used by Home(). The switch is active for a
+// read value of 1, use the options of the adaptor to invert the pin if needed.
+func WithStepperHomeSwitch(pin string) stepperOptionApplier {
+	return stepperHomeSwitchPinOption(pin)
+}
+
 // Move moves the motor for given number of steps.
 func (d *StepperDriver) Move(stepsToMove int) error {
 	d.mutex.Lock()
@@ -154,10 +272,7 @@ func (d *StepperDriver) Move(stepsToMove int) error {
 		return err
 	}
 
-	err := d.stopAsynchRunFunc(false) // wait to finish with err or nil
-	d.stopAsynchRunFunc = nil
-
-	return err
+	return d.finishRun(false) // wait to finish with err or nil
 }
 
 // MoveDeg moves the motor given number of degrees at current speed. Negative values cause to move backward.
@@ -172,10 +287,111 @@ func (d *StepperDriver) MoveDeg(degs int) error {
 		return err
 	}
 
-	err := d.stopAsynchRunFunc(false) // wait to finish with err or nil
-	d.stopAsynchRunFunc = nil
+	return d.finishRun(false) // wait to finish with err or nil
+}
 
-	return err
+// MoveTo moves the motor to the given absolute position. Nothing is done, if the motor is already at this position.
+func (d *StepperDriver) MoveTo(position int) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	stepsToMove := position - d.CurrentPosition()
+	if stepsToMove == 0 {
+		return nil
+	}
+
+	if err := d.stepAsynch(float64(stepsToMove)); err != nil {
+		return err
+	}
+
+	return d.finishRun(false)
+}
+
+// MoveAsync starts to move the motor for given number of steps and returns immediately. The event StepperMoveDone
+// is published with the reached position, when the move is finished. Use Wait() to block until the move is finished.
+func (d *StepperDriver) MoveAsync(stepsToMove int) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.stepAsynch(float64(stepsToMove))
+}
+
+// MoveToAsync starts to move the motor to the given absolute position and returns immediately, see MoveAsync().
+func (d *StepperDriver) MoveToAsync(position int) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	stepsToMove := position - d.CurrentPosition()
+	if stepsToMove == 0 {
+		d.Publish(StepperMoveDone, position)
+		return nil
+	}
+
+	return d.stepAsynch(float64(stepsToMove))
+}
+
+// Wait blocks until the current move is finished and returns the error of the move, if any. This is useful after a
+// call of MoveAsync() or MoveToAsync(). A continuous run needs to be stopped by Stop() instead.
+func (d *StepperDriver) Wait() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.stopAsynchRunFunc == nil {
+		return nil
+	}
+
+	if d.runEndless {
+		return fmt.Errorf("'%s' is running continuously, use Stop() instead", d.driverCfg.name)
+	}
+
+	return d.finishRun(false)
+}
+
+// Home moves the motor in the given direction until the home switch is reached, but not more than the given count of
+// steps. The reached position is set to zero afterwards. The move is done with the current speed and without
+// acceleration, so a low speed should be set before. Soft limits are not applied.
+func (d *StepperDriver) Home(direction string, maxSteps uint) error {
+	pin := d.stepperCfg.homeSwitchPin
+	if pin == "" {
+		return fmt.Errorf("home switch pin is not set for '%s'", d.driverCfg.name)
+	}
+
+	direction = strings.ToLower(direction)
+	if direction != StepperDriverForward && direction != StepperDriverBackward {
+		return fmt.Errorf("Invalid direction '%s'. Value should be '%s' or '%s'",
+			direction, StepperDriverForward, StepperDriverBackward)
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	var reached bool
+	untilFunc := func() (bool, error) {
+		val, err := d.digitalRead(pin)
+		reached = err == nil && val == 1
+		return reached, err
+	}
+
+	stepsToMove := float64(maxSteps)
+	if direction == StepperDriverBackward {
+		stepsToMove = -stepsToMove
+	}
+
+	if err := d.startStepping(stepsToMove, nil, untilFunc); err != nil {
+		return err
+	}
+
+	if err := d.finishRun(false); err != nil {
+		return err
+	}
+
+	if !reached {
+		return fmt.Errorf("home switch of '%s' not reached within %d steps", d.driverCfg.name, maxSteps)
+	}
+
+	d.SetCurrentPosition(0)
+
+	return nil
 }
 
 // Run runs the stepper continuously. Stop needs to be done with call Stop().
@@ -188,19 +404,20 @@ func (d *StepperDriver) Run() error {
 
 // IsMoving returns a bool stating whether motor is currently in motion
 func (d *StepperDriver) IsMoving() bool {
-	return d.stopAsynchRunFunc != nil
+	if d.stopAsynchRunFunc == nil {
+		return false
+	}
+
+	return !d.isRunFinished()
 }
 
-// Stop running the stepper
+// Stop running the stepper. If an acceleration is configured, the motor is decelerated to standstill before.
 func (d *StepperDriver) Stop() error {
 	if d.stopAsynchRunFunc == nil {
 		return fmt.Errorf("'%s' is not yet started", d.driverCfg.name)
 	}
 
-	err := d.stopAsynchRunFunc(true)
-	d.stopAsynchRunFunc = nil
-
-	return err
+	return d.finishRun(true)
 }
 
 // Sleep release all pins to the same output level, so no current is consumed anymore.
@@ -230,11 +447,15 @@ func (d *StepperDriver) SetDirection(direction string) error {
 // * motor friction, inertia and inductance, load inertia
 // * full step rate is normally below 1000 per second (1kHz), typically not more than ~400 per second
 // * mostly not more than 1000-2000rpm (20-40 revolutions per second) are possible
-// * higher values can be achieved only by ramp-up the velocity
+// * higher values can be achieved only by ramp-up the velocity, see WithStepperAcceleration() and WithStepperMaxSpeed()
 // * duration of GPIO write (PI1 can reach up to 70kHz, typically 20kHz, so this is most likely not the limiting factor)
 // * the hardware driver, to force the high current transitions for the max. speed
 // * there are CNC steppers with 1000..20.000 steps per revolution, which works with faster step rates (e.g. 200kHz)
 func (d *StepperDriver) MaxSpeed() uint {
+	if d.stepperCfg.maxSpeed > 0 {
+		return d.stepperCfg.maxSpeed
+	}
+
 	const maxStepsPerSecond = 700 // a typical value for a normal, lightly loaded motor
 	return uint(float32(60*maxStepsPerSecond) / d.stepsPerRev)
 }
@@ -261,6 +482,15 @@ func (d *StepperDriver) SetSpeed(rpm uint) error {
 	return err
 }
 
+// SetAcceleration sets the acceleration and deceleration in RPM per second for the next move or run. Zero means
+// constant speed without acceleration.
+func (d *StepperDriver) SetAcceleration(rpmPerSecond uint) {
+	d.valueMutex.Lock()
+	defer d.valueMutex.Unlock()
+
+	WithStepperAcceleration(rpmPerSecond).apply(&d.stepperCfg)
+}
+
 // CurrentStep gives the current step of motor
 func (d *StepperDriver) CurrentStep() int {
 	// ensure that read can not interfere with write in step()
@@ -270,6 +500,23 @@ func (d *StepperDriver) CurrentStep() int {
 	return d.stepNum
 }
 
+// CurrentPosition gives the absolute position of the motor in steps. In contrast to CurrentStep() the value is not
+// reset after each revolution.
+func (d *StepperDriver) CurrentPosition() int {
+	d.valueMutex.Lock()
+	defer d.valueMutex.Unlock()
+
+	return d.position
+}
+
+// SetCurrentPosition sets the absolute position of the motor without moving, e.g. after a manual homing.
+func (d *StepperDriver) SetCurrentPosition(position int) {
+	d.valueMutex.Lock()
+	defer d.valueMutex.Unlock()
+
+	d.position = position
+}
+
 // SetHaltIfRunning with the given value. Normally a call of Run() returns an error if already running. If set this
 // to true, the next call of Run() cause a automatic stop before.
 func (d *StepperDriver) SetHaltIfRunning(val bool) {
@@ -282,20 +529,33 @@ func (d *StepperDriver) shutdown() error {
 	return d.stopIfRunning()
 }
 
+// stepAsynch starts the asynchronous stepping with the current speed and the configured acceleration
 func (d *StepperDriver) stepAsynch(stepsToMove float64) error {
+	return d.startStepping(stepsToMove, d.newRamp(stepsToMove), nil)
+}
+
+// startStepping starts the asynchronous stepping. Without a ramp the motor steps with the current speed, which can
+// be changed while moving. The optional until function is called before each step to finish the move early.
+func (d *StepperDriver) startStepping(stepsToMove float64, ramp *stepperRamp, untilFunc func() (bool, error)) error {
 	if d.disabled {
 		return fmt.Errorf("'%s' is disabled and can not be running or moving", d.driverCfg.name)
 	}
 
 	// if running, return error or stop automatically
 	if d.stopAsynchRunFunc != nil {
-		if !d.haltIfRunning {
-			return fmt.Errorf("'%s' already running or moving", d.driverCfg.name)
-		}
-		d.debug("stop former run forcefully")
-		if err := d.stopAsynchRunFunc(true); err != nil {
+		if d.isRunFinished() {
+			// the result of a finished asynchronous move was already published
+			d.debug("cleanup former asynchronous move")
 			d.stopAsynchRunFunc = nil
-			return err
+			d.runDone = nil
+		} else {
+			if !d.haltIfRunning {
+				return fmt.Errorf("'%s' already running or moving", d.driverCfg.name)
+			}
+			d.debug("stop former run forcefully")
+			if err := d.finishRun(true); err != nil {
+				return err
+			}
 		}
 	}
 
@@ -305,84 +565,94 @@ func (d *StepperDriver) stepAsynch(stepsToMove float64) error {
 		return fmt.Errorf("no steps to do for '%s'", d.driverCfg.name)
 	}
 
+	endlessMovement := stepsLeft > math.MaxInt
+
 	// t [min] = steps [st] / (steps_per_revolution [st/u] * speed [u/min]) or
 	// t [min] = steps [st] * delay_per_step [min/st], use safety factor 2 and a small offset of 100 ms
 	// prepare this timeout outside of stop function to prevent data race with stepsLeft
-	stopTimeout := time.Duration(2*stepsLeft)*d.getDelayPerStep() + 100*time.Millisecond
-	endlessMovement := false
+	var moveTimeout time.Duration
+	stopTimeout := 2*d.getDelayPerStep() + 100*time.Millisecond
+	if ramp != nil {
+		stopTimeout = 2*ramp.stopDuration() + 100*time.Millisecond
+	}
 
-	if stepsLeft > math.MaxInt {
-		stopTimeout = 100 * time.Millisecond
-		endlessMovement = true
-	} else {
-		d.direction = "forward"
+	if !endlessMovement {
+		moveTimeout = time.Duration(2*stepsLeft)*d.getDelayPerStep() + 100*time.Millisecond
+		if ramp != nil {
+			moveTimeout = 2*ramp.duration() + 100*time.Millisecond
+		}
+
+		if untilFunc == nil {
+			target := d.CurrentPosition() + int(stepsToMove)
+			if err := d.checkSoftLimits(target); err != nil {
+				return err
+			}
+		}
+
+		direction := StepperDriverForward
 		if stepsToMove < 0 {
-			d.direction = "backward"
+			direction = StepperDriverBackward
+		}
+		if err := d.directionFunc(direction); err != nil {
+			return err
 		}
 	}
 
 	// prepare new asynchronous stepping
-	onceDoneChan := make(chan struct{})
 	runStopChan := make(chan struct{})
-	runErrChan := make(chan error)
+	runDone := make(chan struct{})
+	var runErr error
+	var stopOnce sync.Once
 
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, os.Interrupt)
 
 	d.stopAsynchRunFunc = func(forceStop bool) error {
-		defer func() {
-			d.debug("RUN: cleanup stop channel")
-			if runStopChan != nil {
-				close(runStopChan)
-			}
-			runStopChan = nil
-			d.debug("STOP: cleanup err channel")
-			if runErrChan != nil {
-				close(runErrChan)
-			}
-			runErrChan = nil
-			d.debug("STOP: cleanup done")
-		}()
-
-		d.debug("STOP: wait for once done")
-		<-onceDoneChan // wait for the first step was called
-
+		timeout := moveTimeout
 		// send stop for endless movement or a forceful stop happen
 		if endlessMovement || forceStop {
-			d.debug("STOP: send stop channel")
-			runStopChan <- struct{}{}
-		}
-
-		if !endlessMovement && forceStop {
-			// do not wait if an normal movement was stopped forcefully
-			log.Printf("'%s' was forcefully stopped\n", d.driverCfg.name)
-			return nil
+			d.debug("STOP: close stop channel")
+			stopOnce.Do(func() { close(runStopChan) })
+			timeout = stopTimeout
 		}
 
-		// wait for go routine is finished and cleanup
-		d.debug(fmt.Sprintf("STOP: wait %s for err channel", stopTimeout))
+		// wait for go routine is finished
+		d.debug(fmt.Sprintf("STOP: wait %s for done channel", timeout))
 		select {
-		case err := <-runErrChan:
-			return err
-		case <-time.After(stopTimeout):
-			return fmt.Errorf("'%s' was not finished in %s", d.driverCfg.name, stopTimeout)
+		case <-runDone:
+			return runErr
+		case <-time.After(timeout):
+			return fmt.Errorf("'%s' was not finished in %s", d.driverCfg.name, timeout)
 		}
 	}
+	d.runDone = runDone
+	d.runEndless = endlessMovement
 
 	d.debug(fmt.Sprintf("going to start go routine - endless=%t, steps=%d", endlessMovement, stepsLeft))
 	go func(name string) {
 		var err error
-		var onceDone bool
+		var stepsDone uint64
+		endless := endlessMovement
+		stopChan := runStopChan
 		defer func() {
 			// some cases here:
-			// * stop by stop channel: error should be send as nil
-			// * count of steps reached: error should be send as nil
-			// * write error occurred
-			//    * for Run(): caller needs to send stop channel and read the error
-			//    * for Move(): caller waits for the error, but don't send stop channel
-			//
-			d.debug(fmt.Sprintf("RUN: write '%v' to err channel", err))
-			runErrChan <- err
+			// * stop by stop channel: error is nil
+			// * count of steps reached or until function reached: error is nil
+			// * write error occurred or soft limit reached
+			// the error is read by the stop function, the event is published for asynchronous moves
+			signal.Stop(sigChan)
+			d.valueMutex.Lock()
+			d.rampDelay = 0
+			d.valueMutex.Unlock()
+
+			d.debug(fmt.Sprintf("RUN: finished with '%v'", err))
+			if err != nil {
+				d.Publish(Error, err)
+			} else {
+				d.Publish(StepperMoveDone, d.CurrentPosition())
+			}
+			runErr = err
+			close(runDone)
 		}()
 		for stepsLeft > 0 {
 			select {
@@ -390,31 +660,47 @@ func (d *StepperDriver) stepAsynch(stepsToMove float64) error {
 				d.debug("RUN: OS signal received")
 				err = fmt.Errorf("OS signal received")
 				return
-			case <-runStopChan:
+			case <-stopChan:
 				d.debug("RUN: stop channel received")
-				return
+				if !endless {
+					log.Printf("'%s' was forcefully stopped\n", name)
+				}
+				if ramp == nil || !ramp.accelerated() {
+					return
+				}
+				// decelerate to standstill, further stop requests are ignored
+				ramp = ramp.stopping(stepsDone)
+				stepsLeft = uint64(ramp.steps) - stepsDone
+				endless = false
+				stopChan = nil
 			default:
-				if err == nil {
-					err = d.stepFunc()
-					if err != nil {
-						if d.skipStepErrors {
-							fmt.Printf("step skipped for '%s': %v\n", name, err)
-							err = nil
-						} else {
-							d.debug("RUN: write error occurred")
-						}
-					}
-					if !onceDone {
-						close(onceDoneChan) // to inform that we are ready for stop now
-						onceDone = true
-						d.debug("RUN: once done")
+				if untilFunc != nil {
+					var reached bool
+					if reached, err = untilFunc(); err != nil || reached {
+						return
 					}
-					if !endlessMovement {
-						if err != nil {
-							return
-						}
-						stepsLeft--
+				} else if err = d.checkSoftLimits(d.nextPosition()); err != nil {
+					return
+				}
+
+				if ramp != nil {
+					d.valueMutex.Lock()
+					d.rampDelay = ramp.delay(stepsDone + 1)
+					d.valueMutex.Unlock()
+				}
+
+				if err = d.stepFunc(); err != nil {
+					if !d.skipStepErrors {
+						d.debug("RUN: write error occurred")
+						return
 					}
+					fmt.Printf("step skipped for '%s': %v\n", name, err)
+					err = nil
+				}
+
+				stepsDone++
+				if !endless {
+					stepsLeft--
 				}
 			}
 		}
@@ -423,6 +709,79 @@ func (d *StepperDriver) stepAsynch(stepsToMove float64) error {
 	return nil
 }
 
+// finishRun waits for the asynchronous stepping is finished or stops it forcefully and cleanup
+func (d *StepperDriver) finishRun(forceStop bool) error {
+	err := d.stopAsynchRunFunc(forceStop)
+	d.stopAsynchRunFunc = nil
+	d.runDone = nil
+
+	return err
+}
+
+// isRunFinished returns true, if the asynchronous stepping is already finished
+func (d *StepperDriver) isRunFinished() bool {
+	if d.runDone == nil {
+		return false
+	}
+
+	select {
+	case <-d.runDone:
+		return true
+	default:
+		return false
+	}
+}
+
+// newRamp creates the ramp for the given steps with the current speed and the configured acceleration, nil is
+// returned without acceleration
+func (d *StepperDriver) newRamp(stepsToMove float64) *stepperRamp {
+	d.valueMutex.Lock()
+	defer d.valueMutex.Unlock()
+
+	if d.stepperCfg.acceleration == 0 {
+		return nil
+	}
+
+	steps := math.Abs(stepsToMove)
+	if steps > math.MaxInt {
+		steps = math.Inf(1)
+	}
+
+	return newStepperRamp(d.stepperCfg.profile, steps, d.stepsPerSecond(float64(d.speedRpm)),
+		d.stepsPerSecond(float64(d.stepperCfg.acceleration)))
+}
+
+// stepsPerSecond converts the given speed in RPM to steps per second, this works also for the acceleration
+func (d *StepperDriver) stepsPerSecond(rpm float64) float64 {
+	return rpm * float64(d.stepsPerRev) / 60
+}
+
+// checkSoftLimits returns an error, if the given position is outside of the configured soft limits
+func (d *StepperDriver) checkSoftLimits(position int) error {
+	if !d.stepperCfg.softLimits {
+		return nil
+	}
+
+	if position < d.stepperCfg.minPosition || position > d.stepperCfg.maxPosition {
+		return fmt.Errorf("position %d is out of the soft limits [%d, %d] of '%s'", position,
+			d.stepperCfg.minPosition, d.stepperCfg.maxPosition, d.driverCfg.name)
+	}
+
+	return nil
+}
+
+// nextPosition gives the absolute position after the next step
+func (d *StepperDriver) nextPosition() int {
+	d.valueMutex.Lock()
+	defer d.valueMutex.Unlock()
+
+	if d.direction == StepperDriverForward {
+		return d.position + 1
+	}
+
+	return d.position - 1
+}
+
 // getDelayPerStep gives the delay per step
 // formula: delay_per_step [min] = 1/(steps_per_revolution * speed [rpm])
 func (d *StepperDriver) getDelayPerStep() time.Duration {
@@ -431,6 +790,16 @@ func (d *StepperDriver) getDelayPerStep() time.Duration {
 	return time.Duration(60*1000*1000/(d.stepsPerRev*float32(d.speedRpm))) * time.Microsecond
 }
 
+// currentDelayPerStep gives the delay of the current step, which differs from getDelayPerStep() while accelerating
+// or decelerating
+func (d *StepperDriver) currentDelayPerStep() time.Duration {
+	if d.rampDelay > 0 {
+		return d.rampDelay
+	}
+
+	return d.getDelayPerStep()
+}
+
 // phasedStepping moves the motor one step with the configured speed and direction. The speed can be adjusted
 // by SetSpeed() and the direction can be changed by SetDirection() asynchronously.
 func (d *StepperDriver) phasedStepping() error {
@@ -439,11 +808,14 @@ func (d *StepperDriver) phasedStepping() error {
 	defer d.valueMutex.Unlock()
 
 	oldStepNum := d.stepNum
+	oldPosition := d.position
 
 	if d.direction == StepperDriverForward {
 		d.stepNum++
+		d.position++
 	} else {
 		d.stepNum--
+		d.position--
 	}
 
 	if d.stepNum >= int(d.stepsPerRev) {
@@ -457,11 +829,12 @@ func (d *StepperDriver) phasedStepping() error {
 	for i, v := range d.phase[r] {
 		if err := d.digitalWrite(d.pins[i], v); err != nil {
 			d.stepNum = oldStepNum
+			d.position = oldPosition
 			return err
 		}
 	}
 
-	delay := d.getDelayPerStep()
+	delay := d.currentDelayPerStep()
 	time.Sleep(delay)
 
 	return nil
@@ -483,10 +856,7 @@ func (d *StepperDriver) stopIfRunning() error {
 		return nil
 	}
 
-	err := d.stopAsynchRunFunc(true)
-	d.stopAsynchRunFunc = nil
-
-	return err
+	return d.finishRun(true)
 }
 
 func (d *StepperDriver) debug(text string) {
@@ -494,3 +864,45 @@ func (d *StepperDriver) debug(text string) {
 		fmt.Println(text)
 	}
 }
+
+func (o stepperAccelerationOption) String() string {
+	return "acceleration option for stepper"
+}
+
+func (o stepperProfileOption) String() string {
+	return "velocity profile option for stepper"
+}
+
+func (o stepperMaxSpeedOption) String() string {
+	return "maximum speed option for stepper"
+}
+
+func (o stepperSoftLimitsOption) String() string {
+	return "soft limits option for stepper"
+}
+
+func (o stepperHomeSwitchPinOption) String() string {
+	return "home switch pin option for stepper"
+}
+
+func (o stepperAccelerationOption) apply(cfg *stepperConfiguration) {
+	cfg.acceleration = uint(o)
+}
+
+func (o stepperProfileOption) apply(cfg *stepperConfiguration) {
+	cfg.profile = StepperProfile(o)
+}
+
+func (o stepperMaxSpeedOption) apply(cfg *stepperConfiguration) {
+	cfg.maxSpeed = uint(o)
+}
+
+func (o stepperSoftLimitsOption) apply(cfg *stepperConfiguration) {
+	cfg.softLimits = true
+	cfg.minPosition = o.minPosition
+	cfg.maxPosition = o.maxPosition
+}
+
+func (o stepperHomeSwitchPinOption) apply(cfg *stepperConfiguration) {
+	cfg.homeSwitchPin = string(o)
+}
@@ -1,9 +1,11 @@
+//nolint:forcetypeassert // ok here
 package gpio
 
 import (
 	"fmt"
 	"log"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -451,3 +453,298 @@ func TestStepperSetSpeed(t *testing.T) {
 		})
 	}
 }
+
+func TestStepperOptions(t *testing.T) {
+	// arrange
+	cfg := stepperConfiguration{}
+	// act
+	WithStepperAcceleration(120).apply(&cfg)
+	WithStepperProfile(StepperProfileSCurve).apply(&cfg)
+	WithStepperMaxSpeed(2000).apply(&cfg)
+	WithStepperSoftLimits(-10, 100).apply(&cfg)
+	WithStepperHomeSwitch("16").apply(&cfg)
+	// assert
+	assert.Equal(t, uint(120), cfg.acceleration)
+	assert.Equal(t, StepperProfileSCurve, cfg.profile)
+	assert.Equal(t, uint(2000), cfg.maxSpeed)
+	assert.True(t, cfg.softLimits)
+	assert.Equal(t, -10, cfg.minPosition)
+	assert.Equal(t, 100, cfg.maxPosition)
+	assert.Equal(t, "16", cfg.homeSwitchPin)
+}
+
+func TestNewStepperDriver_maxSpeedOption(t *testing.T) {
+	// act
+	d := NewStepperDriver(newGpioTestAdaptor(), [4]string{"7", "11", "13", "15"}, StepperModes.DualPhaseStepping, 32,
+		WithStepperMaxSpeed(2000), WithStepperHomeSwitch("16"))
+	// assert
+	assert.Equal(t, uint(2000), d.MaxSpeed())
+	assert.Equal(t, uint(2000), d.speedRpm)
+	assert.Contains(t, d.usedPins(), "16")
+}
+
+func TestStepperMoveTo_CurrentPosition(t *testing.T) {
+	const stepsPerRev = 32
+
+	tests := map[string]struct {
+		startPosition int
+		position      int
+		softLimits    bool
+		wantWrites    int
+		wantPosition  int
+		wantStep      int
+		wantErr       string
+	}{
+		"move_forward": {
+			position:     40,
+			wantWrites:   160,
+			wantPosition: 40,
+			wantStep:     8,
+		},
+		"move_backward": {
+			startPosition: 5,
+			position:      -3,
+			wantWrites:    32,
+			wantPosition:  -3,
+			wantStep:      stepsPerRev - 8,
+		},
+		"already_there": {
+			startPosition: 7,
+			position:      7,
+			wantPosition:  7,
+		},
+		"move_in_soft_limits": {
+			position:     10,
+			softLimits:   true,
+			wantWrites:   40,
+			wantPosition: 10,
+			wantStep:     10,
+		},
+		"error_soft_limits": {
+			position:     11,
+			softLimits:   true,
+			wantPosition: 0,
+			wantErr:      "position 11 is out of the soft limits [-10, 10]",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, a := initTestStepperDriverWithStubbedAdaptor()
+			d.SetCurrentPosition(tc.startPosition)
+			if tc.softLimits {
+				WithStepperSoftLimits(-10, 10).apply(&d.stepperCfg)
+			}
+			a.written = nil
+			// act
+			err := d.MoveTo(tc.position)
+			// assert
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.wantPosition, d.CurrentPosition())
+			assert.Equal(t, tc.wantStep, d.CurrentStep())
+			assert.Len(t, a.written, tc.wantWrites)
+			assert.False(t, d.IsMoving())
+		})
+	}
+}
+
+func TestStepperMoveAsync_Wait(t *testing.T) {
+	// arrange
+	d, _ := initTestStepperDriverWithStubbedAdaptor()
+	d.SetHaltIfRunning(false)
+	sem := make(chan interface{}, 1)
+	_ = d.Once(d.Event(StepperMoveDone), func(data interface{}) {
+		sem <- data
+	})
+	// act
+	err := d.MoveAsync(20)
+	// assert
+	require.NoError(t, err)
+	assert.True(t, d.IsMoving())
+	select {
+	case data := <-sem:
+		assert.Equal(t, 20, data)
+	case <-time.After(time.Second):
+		t.Errorf("move done event was not published")
+	}
+	assert.False(t, d.IsMoving())
+	require.NoError(t, d.Wait())
+	// act & assert: the next move is possible without wait
+	require.NoError(t, d.MoveToAsync(10))
+	require.ErrorContains(t, d.MoveToAsync(0), "already running or moving")
+	require.NoError(t, d.Wait())
+	assert.Equal(t, 10, d.CurrentPosition())
+	require.NoError(t, d.MoveToAsync(0))
+	require.NoError(t, d.Wait())
+	assert.Equal(t, 0, d.CurrentPosition())
+}
+
+func TestStepperMoveAsync_errorEvent(t *testing.T) {
+	// arrange
+	d, a := initTestStepperDriverWithStubbedAdaptor()
+	a.simulateWriteError = true
+	sem := make(chan error, 1)
+	_ = d.Once(d.Event(Error), func(data interface{}) {
+		sem <- data.(error)
+	})
+	// act
+	err := d.MoveAsync(5)
+	// assert
+	require.NoError(t, err)
+	select {
+	case err := <-sem:
+		require.EqualError(t, err, "write error")
+	case <-time.After(time.Second):
+		t.Errorf("error event was not published")
+	}
+	require.EqualError(t, d.Wait(), "write error")
+}
+
+func TestStepperWait_errorRunning(t *testing.T) {
+	// arrange
+	d, _ := initTestStepperDriverWithStubbedAdaptor()
+	require.NoError(t, d.Run())
+	// act
+	err := d.Wait()
+	// assert
+	require.ErrorContains(t, err, "is running continuously, use Stop() instead")
+	require.NoError(t, d.Stop())
+}
+
+func TestStepperRun_softLimits(t *testing.T) {
+	// arrange
+	d, _ := initTestStepperDriverWithStubbedAdaptor()
+	WithStepperSoftLimits(-5, 5).apply(&d.stepperCfg)
+	// act
+	require.NoError(t, d.Run())
+	// assert
+	assert.Eventually(t, func() bool { return !d.IsMoving() }, time.Second, time.Millisecond)
+	assert.Equal(t, 5, d.CurrentPosition())
+	require.ErrorContains(t, d.Stop(), "position 6 is out of the soft limits [-5, 5]")
+}
+
+func TestStepperMove_acceleration(t *testing.T) {
+	// arrange
+	d, a := initTestStepperDriverWithStubbedAdaptor()
+	d.SetAcceleration(6000) // 3200 steps/s²
+	var mtx sync.Mutex
+	var stamps []time.Time
+	a.digitalWriteFunc = func(pin string, _ byte) error {
+		if pin == "7" {
+			mtx.Lock()
+			stamps = append(stamps, time.Now())
+			mtx.Unlock()
+		}
+		return nil
+	}
+	// act
+	err := d.Move(100)
+	// assert: the first and last step needs much longer than the steps in the middle
+	require.NoError(t, err)
+	require.Len(t, stamps, 100)
+	first := stamps[1].Sub(stamps[0])
+	middle := stamps[51].Sub(stamps[50])
+	last := stamps[99].Sub(stamps[98])
+	assert.Greater(t, first, 5*middle)
+	assert.Greater(t, last, 2*middle)
+	assert.Equal(t, time.Duration(0), d.rampDelay)
+}
+
+func TestStepperStop_deceleration(t *testing.T) {
+	// arrange
+	d, _ := initTestStepperDriverWithStubbedAdaptor()
+	WithStepperAcceleration(6000).apply(&d.stepperCfg) // 3200 steps/s², ~77 steps to stop from 700 steps/s
+	require.NoError(t, d.Run())
+	time.Sleep(300 * time.Millisecond)
+	// act
+	positionBeforeStop := d.CurrentPosition()
+	err := d.Stop()
+	// assert
+	require.NoError(t, err)
+	assert.False(t, d.IsMoving())
+	assert.Greater(t, d.CurrentPosition(), positionBeforeStop+50)
+}
+
+func TestStepperHome(t *testing.T) {
+	tests := map[string]struct {
+		homeSwitchPin string
+		direction     string
+		activeAfter   int
+		readErr       error
+		wantPosition  int
+		wantErr       string
+	}{
+		"home_backward": {
+			homeSwitchPin: "16",
+			direction:     "backward",
+			activeAfter:   5,
+			wantPosition:  0,
+		},
+		"home_forward_already_active": {
+			homeSwitchPin: "16",
+			direction:     "Forward",
+			activeAfter:   0,
+			wantPosition:  0,
+		},
+		"error_not_reached": {
+			homeSwitchPin: "16",
+			direction:     "backward",
+			activeAfter:   20,
+			wantPosition:  90,
+			wantErr:       "not reached within 10 steps",
+		},
+		"error_read": {
+			homeSwitchPin: "16",
+			direction:     "backward",
+			readErr:       fmt.Errorf("read error"),
+			wantPosition:  100,
+			wantErr:       "read error",
+		},
+		"error_no_pin": {
+			direction:    "backward",
+			wantPosition: 100,
+			wantErr:      "home switch pin is not set",
+		},
+		"error_direction": {
+			homeSwitchPin: "16",
+			direction:     "left",
+			wantPosition:  100,
+			wantErr:       "Invalid direction 'left'",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, a := initTestStepperDriverWithStubbedAdaptor()
+			WithStepperHomeSwitch(tc.homeSwitchPin).apply(&d.stepperCfg)
+			WithStepperSoftLimits(200, 300).apply(&d.stepperCfg) // must be ignored
+			d.SetCurrentPosition(100)
+			var reads int
+			a.digitalReadFunc = func(pin string) (int, error) {
+				assert.Equal(t, "16", pin)
+				if tc.readErr != nil {
+					return 0, tc.readErr
+				}
+				reads++
+				if reads > tc.activeAfter {
+					return 1, nil
+				}
+				return 0, nil
+			}
+			// act
+			err := d.Home(tc.direction, 10)
+			// assert
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.wantPosition, d.CurrentPosition())
+			assert.False(t, d.IsMoving())
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package gpio
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+// StepperGroup moves some steppers (axes) coordinated, so all axes start together and reach their target positions
+// at the same time. Each axis uses its own speed, acceleration and profile, but the move of the faster axes is
+// slowed down to the duration of the slowest axis. For an EasyDriver use its embedded StepperDriver.
+type StepperGroup struct {
+	steppers []*StepperDriver
+}
+
+// NewStepperGroup creates a group of the given steppers for coordinated moves.
+func NewStepperGroup(steppers ...*StepperDriver) *StepperGroup {
+	return &StepperGroup{steppers: steppers}
+}
+
+// MoveTo moves all axes to the given absolute positions and waits until all targets are reached. The count of
+// positions needs to match the count of axes.
+func (g *StepperGroup) MoveTo(positions ...int) error {
+	if err := g.MoveToAsync(positions...); err != nil {
+		return err
+	}
+
+	return g.Wait()
+}
+
+// MoveToAsync starts the coordinated move to the given absolute positions and returns immediately. The event
+// StepperMoveDone is published by each moved axis, when its target is reached. No axis is moved, if a target is
+// outside of the soft limits of the axis.
+func (g *StepperGroup) MoveToAsync(positions ...int) error {
+	if len(positions) != len(g.steppers) {
+		return fmt.Errorf("%d positions given for %d axes", len(positions), len(g.steppers))
+	}
+
+	steps := make([]int, len(g.steppers))
+	ramps := make([]*stepperRamp, len(g.steppers))
+	var seconds float64
+	for i, d := range g.steppers {
+		if err := d.checkSoftLimits(positions[i]); err != nil {
+			return err
+		}
+
+		steps[i] = positions[i] - d.CurrentPosition()
+		if steps[i] == 0 {
+			continue
+		}
+
+		ramps[i] = d.newGroupRamp(math.Abs(float64(steps[i])))
+		seconds = math.Max(seconds, ramps[i].seconds())
+	}
+
+	for i, d := range g.steppers {
+		if ramps[i] == nil {
+			continue
+		}
+
+		d.mutex.Lock()
+		err := d.startStepping(float64(steps[i]), ramps[i].stretched(seconds), nil)
+		d.mutex.Unlock()
+		if err != nil {
+			_ = g.Stop() // drop errors of other axes
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Wait blocks until all axes are finished and returns the errors of the moves, if any.
+func (g *StepperGroup) Wait() error {
+	var err error
+	for _, d := range g.steppers {
+		if e := d.Wait(); e != nil {
+			err = multierror.Append(err, e)
+		}
+	}
+
+	return err
+}
+
+// Stop stops all moving axes.
+func (g *StepperGroup) Stop() error {
+	var err error
+	for _, d := range g.steppers {
+		if d.stopAsynchRunFunc == nil {
+			continue
+		}
+		if e := d.Stop(); e != nil {
+			err = multierror.Append(err, e)
+		}
+	}
+
+	return err
+}
+
+// newGroupRamp creates the ramp for the given steps with the current speed and the configured acceleration, in
+// contrast to newRamp() a constant speed ramp is returned without acceleration
+func (d *StepperDriver) newGroupRamp(steps float64) *stepperRamp {
+	d.valueMutex.Lock()
+	defer d.valueMutex.Unlock()
+
+	return newStepperRamp(d.stepperCfg.profile, steps, d.stepsPerSecond(float64(d.speedRpm)),
+		d.stepsPerSecond(float64(d.stepperCfg.acceleration)))
+}
@@ -0,0 +1,110 @@
+package gpio
+
+import (
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStepperGroupMoveTo(t *testing.T) {
+	// arrange
+	x, _ := initTestStepperDriverWithStubbedAdaptor()
+	y, _ := initTestStepperDriverWithStubbedAdaptor()
+	z, _ := initTestStepperDriverWithStubbedAdaptor()
+	WithStepperAcceleration(6000).apply(&y.stepperCfg)
+	y.SetCurrentPosition(10)
+	z.SetCurrentPosition(-4)
+	var mtx sync.Mutex
+	finished := make(map[*StepperDriver]bool)
+	for _, d := range []*StepperDriver{x, y} {
+		d := d
+		_ = d.On(d.Event(StepperMoveDone), func(interface{}) {
+			mtx.Lock()
+			defer mtx.Unlock()
+			finished[d] = true
+		})
+	}
+	g := NewStepperGroup(x, y, z)
+	// act
+	err := g.MoveTo(100, -20, -4)
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, 100, x.CurrentPosition())
+	assert.Equal(t, -20, y.CurrentPosition())
+	assert.Equal(t, -4, z.CurrentPosition())
+	assert.Eventually(t, func() bool {
+		mtx.Lock()
+		defer mtx.Unlock()
+		return len(finished) == 2
+	}, time.Second, time.Millisecond)
+}
+
+func TestStepperGroupRamps(t *testing.T) {
+	// arrange: the axis with the longer distance determines the duration
+	x, _ := initTestStepperDriverWithStubbedAdaptor()
+	y, _ := initTestStepperDriverWithStubbedAdaptor()
+	rx := x.newGroupRamp(100)
+	ry := y.newGroupRamp(30)
+	seconds := math.Max(rx.seconds(), ry.seconds())
+	require.Greater(t, rx.seconds(), ry.seconds())
+	// act
+	sx := rx.stretched(seconds)
+	sy := ry.stretched(seconds)
+	// assert: both axes finish at the same time
+	assert.Equal(t, rx, sx)
+	assert.InDelta(t, sx.duration().Seconds(), sy.duration().Seconds(), 1e-9)
+	assert.InDelta(t, 30.0, sy.steps, 0.0)
+}
+
+func TestStepperGroupMoveTo_error(t *testing.T) {
+	tests := map[string]struct {
+		positions []int
+		wantErr   string
+	}{
+		"error_count": {
+			positions: []int{1},
+			wantErr:   "1 positions given for 2 axes",
+		},
+		"error_soft_limits": {
+			positions: []int{1, 21},
+			wantErr:   "position 21 is out of the soft limits [-20, 20]",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			x, _ := initTestStepperDriverWithStubbedAdaptor()
+			y, _ := initTestStepperDriverWithStubbedAdaptor()
+			WithStepperSoftLimits(-20, 20).apply(&y.stepperCfg)
+			g := NewStepperGroup(x, y)
+			// act
+			err := g.MoveTo(tc.positions...)
+			// assert
+			require.ErrorContains(t, err, tc.wantErr)
+			assert.False(t, x.IsMoving())
+			assert.Equal(t, 0, x.CurrentPosition())
+		})
+	}
+}
+
+func TestStepperGroupStop(t *testing.T) {
+	// arrange
+	x, _ := initTestStepperDriverWithStubbedAdaptor()
+	y, _ := initTestStepperDriverWithStubbedAdaptor()
+	g := NewStepperGroup(x, y)
+	require.NoError(t, g.MoveToAsync(1000, 2000))
+	require.True(t, x.IsMoving())
+	require.True(t, y.IsMoving())
+	// act
+	err := g.Stop()
+	// assert
+	require.NoError(t, err)
+	assert.False(t, x.IsMoving())
+	assert.False(t, y.IsMoving())
+	assert.Less(t, x.CurrentPosition(), 1000)
+	assert.Less(t, y.CurrentPosition(), 2000)
+}
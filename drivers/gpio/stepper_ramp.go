@@ -0,0 +1,145 @@
+package gpio
+
+import (
+	"math"
+	"time"
+)
+
+// stepperRamp calculates the delay of each step for a move with acceleration and deceleration. All values are
+// given in steps and seconds. The deceleration is always the mirrored acceleration.
+type stepperRamp struct {
+	profile   StepperProfile
+	steps     float64 // count of steps to move, +Inf for a continuous run
+	speed     float64 // peak speed [steps/s], can be lower than the maximum speed for short moves
+	accel     float64 // maximum acceleration [steps/s²], zero for constant speed
+	rampSteps float64 // count of steps needed to accelerate from standstill to the peak speed
+	rampTime  float64 // duration of the acceleration [s]
+}
+
+// newStepperRamp creates a ramp for the given count of steps. For short moves the given maximum speed is reduced, so
+// the deceleration starts immediately after the acceleration (triangle instead of trapezoid).
+func newStepperRamp(profile StepperProfile, steps, maxSpeed, accel float64) *stepperRamp {
+	r := stepperRamp{profile: profile, steps: steps, speed: maxSpeed}
+	if accel <= 0 {
+		return &r
+	}
+
+	r.accel = accel
+	factor := r.rampFactor()
+	r.rampSteps = factor * maxSpeed * maxSpeed / accel
+	if 2*r.rampSteps > steps {
+		r.rampSteps = steps / 2
+		r.speed = math.Sqrt(r.rampSteps * accel / factor)
+	}
+	r.rampTime = 2 * r.rampSteps / r.speed
+
+	return &r
+}
+
+// accelerated returns true, if the ramp has an acceleration and deceleration phase
+func (r *stepperRamp) accelerated() bool {
+	return r.accel > 0
+}
+
+// seconds gives the overall duration of the move, +Inf for a continuous run
+func (r *stepperRamp) seconds() float64 {
+	return 2*r.rampTime + (r.steps-2*r.rampSteps)/r.speed
+}
+
+// duration gives the overall duration of the move
+func (r *stepperRamp) duration() time.Duration {
+	return time.Duration(r.seconds() * float64(time.Second))
+}
+
+// stopDuration gives the longest possible duration to stop the motor, which is the deceleration from peak speed
+func (r *stepperRamp) stopDuration() time.Duration {
+	return time.Duration((r.rampTime + 1/r.speed) * float64(time.Second))
+}
+
+// delay gives the delay of the given step, starting with 1 for the first step of the move
+func (r *stepperRamp) delay(step uint64) time.Duration {
+	k := float64(step)
+	seconds := r.timeAt(k) - r.timeAt(k-1)
+
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// timeAt gives the time, when the given count of steps is done. The second half of the move is the mirrored first
+// half, which contains the deceleration.
+func (r *stepperRamp) timeAt(steps float64) float64 {
+	if steps <= r.steps/2 {
+		return r.timeFromRest(steps)
+	}
+
+	return 2*r.timeFromRest(r.steps/2) - r.timeFromRest(r.steps-steps)
+}
+
+// stopping gives the ramp to decelerate to standstill as fast as possible after the given count of steps is done. If
+// the motor is still accelerating, the already done acceleration is mirrored.
+func (r *stepperRamp) stopping(stepsDone uint64) *stepperRamp {
+	done := float64(stepsDone)
+	stopped := *r
+	stopped.steps = math.Min(r.steps, done+math.Ceil(math.Min(done, r.rampSteps)))
+
+	return &stopped
+}
+
+// stretched gives a ramp with the same count of steps, which needs the given duration. This is done by reducing the
+// speed and the acceleration, so the shape of the profile is not changed.
+func (r *stepperRamp) stretched(seconds float64) *stepperRamp {
+	k := seconds / r.seconds()
+	if k <= 1 {
+		return r
+	}
+
+	return newStepperRamp(r.profile, r.steps, r.speed/k, r.accel/(k*k))
+}
+
+// rampFactor gives the relation between the steps of the acceleration and speed²/acceleration
+func (r *stepperRamp) rampFactor() float64 {
+	if r.profile == StepperProfileSCurve {
+		// the average acceleration of the half cosine speed curve is 2/π of the maximum acceleration
+		return math.Pi / 4
+	}
+
+	return 0.5
+}
+
+// timeFromRest gives the time needed to move the given steps from standstill, including the acceleration
+func (r *stepperRamp) timeFromRest(steps float64) float64 {
+	if steps <= 0 {
+		return 0
+	}
+
+	if steps >= r.rampSteps {
+		return r.rampTime + (steps-r.rampSteps)/r.speed
+	}
+
+	if r.profile == StepperProfileSCurve {
+		return r.sCurveTime(steps)
+	}
+
+	// constant acceleration: steps = speed/rampTime * t²/2
+	return math.Sqrt(2 * steps * r.rampTime / r.speed)
+}
+
+// sCurveTime gives the time within the acceleration phase of the S-curve, when the given steps are done. The speed
+// follows v(t) = speed/2 * (1 - cos(π*t/rampTime)), the position is the integral of it.
+func (r *stepperRamp) sCurveTime(steps float64) float64 {
+	position := func(t float64) float64 {
+		return r.speed / 2 * (t - r.rampTime/math.Pi*math.Sin(math.Pi*t/r.rampTime))
+	}
+
+	// the position is monotonic, so a bisection is sufficient
+	low, high := 0.0, r.rampTime
+	for i := 0; i < 50; i++ {
+		t := (low + high) / 2
+		if position(t) < steps {
+			low = t
+		} else {
+			high = t
+		}
+	}
+
+	return (low + high) / 2
+}
@@ -0,0 +1,184 @@
+package gpio
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewStepperRamp(t *testing.T) {
+	tests := map[string]struct {
+		profile       StepperProfile
+		steps         float64
+		maxSpeed      float64
+		accel         float64
+		wantSpeed     float64
+		wantRampSteps float64
+		wantRampTime  float64
+		wantSeconds   float64
+	}{
+		"constant_speed": {
+			steps:       100,
+			maxSpeed:    200,
+			wantSpeed:   200,
+			wantSeconds: 0.5,
+		},
+		"trapezoidal": {
+			profile:       StepperProfileTrapezoidal,
+			steps:         1000,
+			maxSpeed:      200,
+			accel:         400,
+			wantSpeed:     200,
+			wantRampSteps: 50,
+			wantRampTime:  0.5,
+			wantSeconds:   5.5,
+		},
+		"trapezoidal_triangle": {
+			profile:       StepperProfileTrapezoidal,
+			steps:         50,
+			maxSpeed:      200,
+			accel:         400,
+			wantSpeed:     100 * math.Sqrt2,
+			wantRampSteps: 25,
+			wantRampTime:  0.25 * math.Sqrt2,
+			wantSeconds:   0.5 * math.Sqrt2,
+		},
+		"s_curve": {
+			profile:       StepperProfileSCurve,
+			steps:         1000,
+			maxSpeed:      200,
+			accel:         400,
+			wantSpeed:     200,
+			wantRampSteps: 25 * math.Pi,
+			wantRampTime:  0.25 * math.Pi,
+			wantSeconds:   5 + 0.25*math.Pi,
+		},
+		"continuous_run": {
+			steps:         math.Inf(1),
+			maxSpeed:      200,
+			accel:         400,
+			wantSpeed:     200,
+			wantRampSteps: 50,
+			wantRampTime:  0.5,
+			wantSeconds:   math.Inf(1),
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// act
+			r := newStepperRamp(tc.profile, tc.steps, tc.maxSpeed, tc.accel)
+			// assert
+			assert.InDelta(t, tc.wantSpeed, r.speed, 1e-9)
+			assert.InDelta(t, tc.wantRampSteps, r.rampSteps, 1e-9)
+			assert.InDelta(t, tc.wantRampTime, r.rampTime, 1e-9)
+			if math.IsInf(tc.wantSeconds, 1) {
+				assert.True(t, math.IsInf(r.seconds(), 1))
+			} else {
+				assert.InDelta(t, tc.wantSeconds, r.seconds(), 1e-9)
+			}
+			assert.Equal(t, tc.accel > 0, r.accelerated())
+		})
+	}
+}
+
+func TestStepperRampDelay(t *testing.T) {
+	tests := map[string]struct {
+		profile StepperProfile
+		steps   uint64
+		accel   float64
+	}{
+		"constant_speed":       {steps: 100},
+		"trapezoidal":          {profile: StepperProfileTrapezoidal, steps: 200, accel: 400},
+		"trapezoidal_triangle": {profile: StepperProfileTrapezoidal, steps: 51, accel: 400},
+		"s_curve":              {profile: StepperProfileSCurve, steps: 200, accel: 400},
+		"s_curve_triangle":     {profile: StepperProfileSCurve, steps: 30, accel: 400},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			r := newStepperRamp(tc.profile, float64(tc.steps), 200, tc.accel)
+			cruiseDelay := time.Duration(float64(time.Second) / r.speed)
+			// act
+			var delays []time.Duration
+			var sum time.Duration
+			for step := uint64(1); step <= tc.steps; step++ {
+				delay := r.delay(step)
+				delays = append(delays, delay)
+				sum += delay
+			}
+			// assert: the sum of all delays is the duration of the move
+			assert.InDelta(t, r.duration(), sum, float64(tc.steps)) // rounding of each delay to nanoseconds
+			// assert: the profile is symmetric and no step is faster than the peak speed
+			for i := range delays {
+				assert.InDelta(t, delays[i], delays[len(delays)-1-i], 1, "step %d", i+1)
+				assert.GreaterOrEqual(t, delays[i], cruiseDelay-1, "step %d", i+1)
+			}
+			if !r.accelerated() {
+				assert.Equal(t, cruiseDelay, delays[0])
+				return
+			}
+			// assert: the motor accelerates in the first half of the move
+			for i := 1; i < len(delays)/2; i++ {
+				assert.LessOrEqual(t, delays[i], delays[i-1]+1, "step %d", i+1)
+			}
+			assert.Greater(t, delays[0], 3*cruiseDelay)
+		})
+	}
+}
+
+func TestStepperRampSCurveStartsSmoother(t *testing.T) {
+	// arrange
+	trapezoidal := newStepperRamp(StepperProfileTrapezoidal, 1000, 200, 400)
+	sCurve := newStepperRamp(StepperProfileSCurve, 1000, 200, 400)
+	// act & assert: the first step of the S-curve is slower, because the acceleration starts with zero
+	assert.Greater(t, sCurve.delay(1), trapezoidal.delay(1))
+	// act & assert: both reach the peak speed
+	assert.Equal(t, trapezoidal.delay(500), sCurve.delay(500))
+}
+
+func TestStepperRampStopping(t *testing.T) {
+	tests := map[string]struct {
+		steps     float64
+		stepsDone uint64
+		wantSteps float64
+	}{
+		"run_cruising":       {steps: math.Inf(1), stepsDone: 300, wantSteps: 350},
+		"run_accelerating":   {steps: math.Inf(1), stepsDone: 20, wantSteps: 40},
+		"move_cruising":      {steps: 1000, stepsDone: 500, wantSteps: 550},
+		"move_decelerating":  {steps: 1000, stepsDone: 980, wantSteps: 1000},
+		"move_not_yet_moved": {steps: 1000, stepsDone: 0, wantSteps: 0},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			r := newStepperRamp(StepperProfileTrapezoidal, tc.steps, 200, 400)
+			// act
+			got := r.stopping(tc.stepsDone)
+			// assert
+			assert.InDelta(t, tc.wantSteps, got.steps, 0.0)
+			if tc.stepsDone == 0 || tc.stepsDone >= uint64(got.steps) {
+				return
+			}
+			// the speed is continuous and the last step is the slowest
+			assert.InEpsilon(t, r.delay(tc.stepsDone), got.delay(tc.stepsDone+1), 0.05)
+			assert.Equal(t, r.delay(1), got.delay(uint64(got.steps)))
+		})
+	}
+}
+
+func TestStepperRampStretched(t *testing.T) {
+	// arrange
+	r := newStepperRamp(StepperProfileSCurve, 100, 200, 400)
+	// act
+	got := r.stretched(3 * r.seconds())
+	same := r.stretched(r.seconds() / 2)
+	// assert
+	require.NotSame(t, r, got)
+	assert.InDelta(t, 3*r.seconds(), got.seconds(), 1e-9)
+	assert.InDelta(t, r.speed/3, got.speed, 1e-9)
+	assert.InDelta(t, r.rampSteps, got.rampSteps, 1e-9)
+	assert.Same(t, r, same)
+}
@@ -0,0 +1,312 @@
+package gpio
+
+import (
+	"fmt"
+	"time"
+
+	"gobot.io/x/gobot/v2"
+)
+
+const sysLedTriggerNone = "none"
+
+// sysLedOptionApplier needs to be implemented by each configurable option type
+type sysLedOptionApplier interface {
+	apply(cfg *sysLedConfiguration)
+}
+
+// sysLedConfiguration contains all changeable attributes of the driver.
+type sysLedConfiguration struct {
+	trigger string
+}
+
+// sysLedTriggerOption is the type for applying a trigger on start to the configuration
+type sysLedTriggerOption string
+
+// SysLedDriver represents a LED of the board, which is controlled by the Linux LED class, e.g. the "ACT" LED of a
+// Raspberry Pi or the "usr0" LED of a Beaglebone. In contrast to the LedDriver the LED is not connected to a header
+// pin, but the kernel provides functions like dimming and triggers (e.g. "heartbeat", "timer" or "mmc0").
+type SysLedDriver struct {
+	*driver
+	sysLedCfg         *sysLedConfiguration
+	ledName           string
+	led               gobot.LedSystemDevicer
+	maxBrightness     int
+	initialTrigger    string
+	initialBrightness int
+	high              bool
+}
+
+// NewSysLedDriver return a new SysLedDriver given a SysLedOpener and the name of the LED, e.g. "ACT". All available
+// names are listed in "/sys/class/leds". On Halt() the trigger and brightness found on Start() are restored.
+//
+// Supported options:
+//
+//	"WithName"
+//	"WithSysLedTrigger"
+//
+// Adds the following API Commands:
+//
+//	"On" - See SysLedDriver.On
+//	"Off" - See SysLedDriver.Off
+//	"Toggle" - See SysLedDriver.Toggle
+//	"Brightness" - See SysLedDriver.Brightness
+//	"SetBrightness" - See SysLedDriver.SetBrightness
+//	"Trigger" - See SysLedDriver.Trigger
+//	"SetTrigger" - See SysLedDriver.SetTrigger
+//	"Blink" - See SysLedDriver.Blink
+func NewSysLedDriver(a SysLedOpener, ledName string, opts ...interface{}) *SysLedDriver {
+	//nolint:forcetypeassert // no error return value, so there is no better way
+	d := &SysLedDriver{
+		driver:    newDriver(a.(gobot.Connection), "SysLED"),
+		sysLedCfg: &sysLedConfiguration{},
+		ledName:   ledName,
+	}
+	d.afterStart = d.initialize
+	d.beforeHalt = d.shutdown
+	// the LED is not connected to a header pin
+	d.usedPins = func() map[string]string { return nil }
+
+	for _, opt := range opts {
+		switch o := opt.(type) {
+		case optionApplier:
+			o.apply(d.driverCfg)
+		case sysLedOptionApplier:
+			o.apply(d.sysLedCfg)
+		default:
+			panic(fmt.Sprintf("'%s' can not be applied on '%s'", opt, d.driverCfg.name))
+		}
+	}
+
+	d.AddCommand("On", func(params map[string]interface{}) interface{} {
+		return d.On()
+	})
+
+	d.AddCommand("Off", func(params map[string]interface{}) interface{} {
+		return d.Off()
+	})
+
+	d.AddCommand("Toggle", func(params map[string]interface{}) interface{} {
+		return d.Toggle()
+	})
+
+	d.AddCommand("Brightness", func(params map[string]interface{}) interface{} {
+		val, err := d.Brightness()
+		return map[string]interface{}{"val": val, "err": err}
+	})
+
+	d.AddCommand("SetBrightness", func(params map[string]interface{}) interface{} {
+		level := int(params["level"].(float64)) //nolint:forcetypeassert // ok here
+		return d.SetBrightness(level)
+	})
+
+	d.AddCommand("Trigger", func(params map[string]interface{}) interface{} {
+		val, err := d.Trigger()
+		return map[string]interface{}{"val": val, "err": err}
+	})
+
+	d.AddCommand("SetTrigger", func(params map[string]interface{}) interface{} {
+		trigger := params["trigger"].(string) //nolint:forcetypeassert // ok here
+		return d.SetTrigger(trigger)
+	})
+
+	d.AddCommand("Blink", func(params map[string]interface{}) interface{} {
+		on := time.Duration(params["on"].(float64)) * time.Millisecond   //nolint:forcetypeassert // ok here
+		off := time.Duration(params["off"].(float64)) * time.Millisecond //nolint:forcetypeassert // ok here
+		return d.Blink(on, off)
+	})
+
+	return d
+}
+
+// WithSysLedTrigger activates the given trigger on start, e.g. "heartbeat" or "none".
+func WithSysLedTrigger(trigger string) sysLedOptionApplier {
+	return sysLedTriggerOption(trigger)
+}
+
+// LedName returns the name of the LED in the system.
+func (d *SysLedDriver) LedName() string {
+	return d.ledName
+}
+
+// MaxBrightness returns the maximum brightness, which is 1 for LEDs without dimming. The value is available after
+// Start().
+func (d *SysLedDriver) MaxBrightness() int {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.maxBrightness
+}
+
+// State return true if the LED was switched on by the driver and false if switched off. Changes caused by a trigger
+// are not recognized.
+func (d *SysLedDriver) State() bool {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.high
+}
+
+// On deactivates the trigger and switches the LED on with maximum brightness.
+func (d *SysLedDriver) On() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.writeBrightness(d.maxBrightness)
+}
+
+// Off deactivates the trigger and switches the LED off.
+func (d *SysLedDriver) Off() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.writeBrightness(0)
+}
+
+// Toggle sets the LED to the opposite of it's current state
+func (d *SysLedDriver) Toggle() error {
+	if d.State() {
+		return d.Off()
+	}
+	return d.On()
+}
+
+// Brightness reads the current brightness of the LED, which can be changed by an active trigger.
+func (d *SysLedDriver) Brightness() (int, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.led == nil {
+		return 0, fmt.Errorf("LED '%s' not started", d.ledName)
+	}
+	return d.led.Brightness()
+}
+
+// SetBrightness deactivates the trigger and sets the LED to the given brightness. Values above the maximum
+// brightness are limited by the kernel.
+func (d *SysLedDriver) SetBrightness(level int) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.writeBrightness(level)
+}
+
+// Trigger reads the current trigger of the LED, e.g. "none" or "heartbeat".
+func (d *SysLedDriver) Trigger() (string, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.led == nil {
+		return "", fmt.Errorf("LED '%s' not started", d.ledName)
+	}
+	return d.led.Trigger()
+}
+
+// Triggers reads all available triggers of the LED.
+func (d *SysLedDriver) Triggers() ([]string, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.led == nil {
+		return nil, fmt.Errorf("LED '%s' not started", d.ledName)
+	}
+	return d.led.Triggers()
+}
+
+// SetTrigger activates the given trigger, e.g. "heartbeat", "mmc0" or "netdev". Use "none" to deactivate the
+// trigger.
+func (d *SysLedDriver) SetTrigger(trigger string) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.led == nil {
+		return fmt.Errorf("LED '%s' not started", d.ledName)
+	}
+	return d.led.SetTrigger(trigger)
+}
+
+// Blink activates the trigger "timer" with the given on and off time, the resolution is one millisecond.
+func (d *SysLedDriver) Blink(on, off time.Duration) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.led == nil {
+		return fmt.Errorf("LED '%s' not started", d.ledName)
+	}
+	if err := d.led.SetTrigger("timer"); err != nil {
+		return err
+	}
+	return d.led.SetTimerDelays(on, off)
+}
+
+// initialize opens the LED, stores the current state for restore on halt and activates the configured trigger
+func (d *SysLedDriver) initialize() error {
+	opener, ok := d.connection.(SysLedOpener)
+	if !ok {
+		return fmt.Errorf("LEDs of the system are not supported by the platform '%s'", d.Connection().Name())
+	}
+
+	led, err := opener.OpenLed(d.ledName)
+	if err != nil {
+		return err
+	}
+
+	if d.maxBrightness, err = led.MaxBrightness(); err != nil {
+		return err
+	}
+	if d.initialTrigger, err = led.Trigger(); err != nil {
+		return err
+	}
+	if d.initialBrightness, err = led.Brightness(); err != nil {
+		return err
+	}
+	d.high = d.initialBrightness > 0
+
+	if d.sysLedCfg.trigger != "" {
+		if err := led.SetTrigger(d.sysLedCfg.trigger); err != nil {
+			return err
+		}
+	}
+
+	d.led = led
+	return nil
+}
+
+// shutdown restores the initial state of the LED
+func (d *SysLedDriver) shutdown() error {
+	if d.led == nil {
+		return nil
+	}
+
+	led := d.led
+	d.led = nil
+	if d.initialTrigger != sysLedTriggerNone {
+		return led.SetTrigger(d.initialTrigger)
+	}
+	if err := led.SetTrigger(sysLedTriggerNone); err != nil {
+		return err
+	}
+	return led.SetBrightness(d.initialBrightness)
+}
+
+// writeBrightness needs to be called with locked mutex
+func (d *SysLedDriver) writeBrightness(level int) error {
+	if d.led == nil {
+		return fmt.Errorf("LED '%s' not started", d.ledName)
+	}
+	if err := d.led.SetTrigger(sysLedTriggerNone); err != nil {
+		return err
+	}
+	if err := d.led.SetBrightness(level); err != nil {
+		return err
+	}
+	d.high = level > 0
+	return nil
+}
+
+func (o sysLedTriggerOption) String() string {
+	return "trigger option for system LEDs"
+}
+
+func (o sysLedTriggerOption) apply(cfg *sysLedConfiguration) {
+	cfg.trigger = string(o)
+}
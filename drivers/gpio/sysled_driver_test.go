@@ -0,0 +1,236 @@
+//nolint:forcetypeassert // ok here
+package gpio
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+)
+
+var _ gobot.Driver = (*SysLedDriver)(nil)
+
+type sysLedTestLed struct {
+	name          string
+	brightness    int
+	maxBrightness int
+	trigger       string
+	delayOn       time.Duration
+	delayOff      time.Duration
+	writeErr      error
+}
+
+func (l *sysLedTestLed) Name() string                { return l.name }
+func (l *sysLedTestLed) Brightness() (int, error)    { return l.brightness, nil }
+func (l *sysLedTestLed) MaxBrightness() (int, error) { return l.maxBrightness, nil }
+func (l *sysLedTestLed) Trigger() (string, error)    { return l.trigger, nil }
+func (l *sysLedTestLed) Triggers() ([]string, error) { return []string{"none", "timer", "heartbeat"}, nil }
+
+func (l *sysLedTestLed) SetBrightness(val int) error {
+	if l.writeErr != nil {
+		return l.writeErr
+	}
+	l.brightness = val
+	if val == 0 {
+		l.trigger = "none"
+	}
+	return nil
+}
+
+func (l *sysLedTestLed) SetTrigger(trigger string) error {
+	if l.writeErr != nil {
+		return l.writeErr
+	}
+	l.trigger = trigger
+	return nil
+}
+
+func (l *sysLedTestLed) SetTimerDelays(on, off time.Duration) error {
+	l.delayOn = on
+	l.delayOff = off
+	return nil
+}
+
+type sysLedTestAdaptor struct {
+	gpioTestBareAdaptor
+	leds map[string]*sysLedTestLed
+}
+
+func (a *sysLedTestAdaptor) OpenLed(name string) (gobot.LedSystemDevicer, error) {
+	led, ok := a.leds[name]
+	if !ok {
+		return nil, errors.New("LED not found")
+	}
+	return led, nil
+}
+
+func initTestSysLedDriver(opts ...interface{}) (*SysLedDriver, *sysLedTestLed) {
+	led := &sysLedTestLed{name: "ACT", brightness: 1, maxBrightness: 255, trigger: "mmc0"}
+	a := &sysLedTestAdaptor{leds: map[string]*sysLedTestLed{"ACT": led}}
+	return NewSysLedDriver(a, "ACT", opts...), led
+}
+
+func TestNewSysLedDriver(t *testing.T) {
+	// arrange
+	a := &sysLedTestAdaptor{}
+	// act
+	d := NewSysLedDriver(a, "ACT")
+	// assert
+	assert.IsType(t, &SysLedDriver{}, d)
+	// assert: gpio.driver attributes
+	require.NotNil(t, d.driver)
+	assert.True(t, strings.HasPrefix(d.driverCfg.name, "SysLED"))
+	assert.Equal(t, "", d.driverCfg.pin)
+	assert.Equal(t, a, d.connection)
+	assert.NotNil(t, d.afterStart)
+	assert.NotNil(t, d.beforeHalt)
+	assert.NotNil(t, d.Commander)
+	assert.NotNil(t, d.mutex)
+	assert.Empty(t, d.usedPins())
+	// assert: driver specific attributes
+	assert.Equal(t, "ACT", d.LedName())
+	assert.Equal(t, &sysLedConfiguration{}, d.sysLedCfg)
+	assert.Nil(t, d.led)
+}
+
+func TestNewSysLedDriver_options(t *testing.T) {
+	// arrange
+	panicFunc := func() {
+		NewSysLedDriver(&sysLedTestAdaptor{}, "ACT", WithName("crazy"), WithButtonPollInterval(time.Second))
+	}
+	// act
+	d := NewSysLedDriver(&sysLedTestAdaptor{}, "ACT", WithName("status"), WithSysLedTrigger("heartbeat"))
+	// assert
+	assert.Equal(t, "status", d.Name())
+	assert.Equal(t, "heartbeat", d.sysLedCfg.trigger)
+	assert.PanicsWithValue(t, "'read interval option for buttons' can not be applied on 'crazy'", panicFunc)
+}
+
+func TestSysLedStartHalt(t *testing.T) {
+	tests := map[string]struct {
+		opts              []interface{}
+		initialTrigger    string
+		initialBrightness int
+		wantStartTrigger  string
+	}{
+		"restore_trigger": {
+			initialTrigger:    "mmc0",
+			initialBrightness: 1,
+			wantStartTrigger:  "mmc0",
+		},
+		"restore_brightness": {
+			initialTrigger:    "none",
+			initialBrightness: 128,
+			wantStartTrigger:  "none",
+		},
+		"with_trigger": {
+			opts:              []interface{}{WithSysLedTrigger("heartbeat")},
+			initialTrigger:    "mmc0",
+			initialBrightness: 0,
+			wantStartTrigger:  "heartbeat",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, led := initTestSysLedDriver(tc.opts...)
+			led.trigger = tc.initialTrigger
+			led.brightness = tc.initialBrightness
+			// act
+			require.NoError(t, d.Start())
+			// assert
+			assert.Equal(t, tc.wantStartTrigger, led.trigger)
+			assert.Equal(t, 255, d.MaxBrightness())
+			assert.Equal(t, tc.initialBrightness > 0, d.State())
+			// act
+			require.NoError(t, d.Off())
+			require.NoError(t, d.Halt())
+			// assert
+			assert.Equal(t, tc.initialTrigger, led.trigger)
+			if tc.initialTrigger == "none" {
+				assert.Equal(t, tc.initialBrightness, led.brightness)
+			}
+		})
+	}
+}
+
+func TestSysLedStartError(t *testing.T) {
+	// arrange
+	d := NewSysLedDriver(&sysLedTestAdaptor{}, "PWR")
+	// act
+	err := d.Start()
+	// assert
+	require.EqualError(t, err, "LED not found")
+	require.EqualError(t, d.On(), "LED 'PWR' not started")
+}
+
+func TestSysLedOnOffToggle(t *testing.T) {
+	// arrange
+	d, led := initTestSysLedDriver()
+	require.NoError(t, d.Start())
+	// act & assert
+	require.NoError(t, d.Off())
+	assert.False(t, d.State())
+	assert.Equal(t, 0, led.brightness)
+	require.NoError(t, d.Toggle())
+	assert.True(t, d.State())
+	assert.Equal(t, 255, led.brightness)
+	assert.Equal(t, "none", led.trigger)
+	require.NoError(t, d.Toggle())
+	assert.False(t, d.State())
+	require.NoError(t, d.SetBrightness(20))
+	assert.True(t, d.State())
+	val, err := d.Brightness()
+	require.NoError(t, err)
+	assert.Equal(t, 20, val)
+	// arrange
+	led.writeErr = errors.New("write error")
+	// act & assert
+	require.EqualError(t, d.On(), "write error")
+	assert.True(t, d.State())
+}
+
+func TestSysLedTriggerAndBlink(t *testing.T) {
+	// arrange
+	d, led := initTestSysLedDriver()
+	require.NoError(t, d.Start())
+	// act & assert
+	triggers, err := d.Triggers()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"none", "timer", "heartbeat"}, triggers)
+	require.NoError(t, d.SetTrigger("heartbeat"))
+	trigger, err := d.Trigger()
+	require.NoError(t, err)
+	assert.Equal(t, "heartbeat", trigger)
+	require.NoError(t, d.Blink(50*time.Millisecond, 950*time.Millisecond))
+	assert.Equal(t, "timer", led.trigger)
+	assert.Equal(t, 50*time.Millisecond, led.delayOn)
+	assert.Equal(t, 950*time.Millisecond, led.delayOff)
+}
+
+func TestSysLedCommands(t *testing.T) {
+	// arrange
+	d, led := initTestSysLedDriver()
+	require.NoError(t, d.Start())
+	// act & assert
+	assert.Nil(t, d.Command("On")(nil))
+	assert.Equal(t, 255, led.brightness)
+	assert.Nil(t, d.Command("Off")(nil))
+	assert.Equal(t, 0, led.brightness)
+	assert.Nil(t, d.Command("Toggle")(nil))
+	assert.Equal(t, 255, led.brightness)
+	assert.Nil(t, d.Command("SetBrightness")(map[string]interface{}{"level": 100.0}))
+	ret := d.Command("Brightness")(nil).(map[string]interface{})
+	assert.Equal(t, 100, ret["val"])
+	assert.Nil(t, ret["err"])
+	assert.Nil(t, d.Command("SetTrigger")(map[string]interface{}{"trigger": "heartbeat"}))
+	ret = d.Command("Trigger")(nil).(map[string]interface{})
+	assert.Equal(t, "heartbeat", ret["val"])
+	assert.Nil(t, d.Command("Blink")(map[string]interface{}{"on": 100.0, "off": 200.0}))
+	assert.Equal(t, 200*time.Millisecond, led.delayOff)
+}
@@ -0,0 +1,304 @@
+package gpio
+
+import (
+	"fmt"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/drivers/common/font"
+)
+
+// Commands of the driver
+const (
+	TM1637DataCmd   = 0x40
+	TM1637DispCtrl  = 0x80
+	TM1637AddrCmd   = 0xC0
+	TM1637DisplayOn = 0x08
+
+	tm1637DefaultDigits = 4
+	tm1637MaxDigits     = 6
+	tm1637MaxBrightness = 7
+	// the colon of the common 4 digit clock displays is connected to the decimal point of the second digit
+	tm1637ColonDigit = 1
+)
+
+// tm1637OptionApplier needs to be implemented by each configurable option type
+type tm1637OptionApplier interface {
+	apply(cfg *tm1637Configuration)
+}
+
+// tm1637Configuration contains all changeable attributes of the driver.
+type tm1637Configuration struct {
+	digits     int
+	brightness byte
+}
+
+// tm1637DigitsOption is the type for applying another count of digits to the configuration
+type tm1637DigitsOption int
+
+// tm1637BrightnessOption is the type for applying another initial brightness to the configuration
+type tm1637BrightnessOption byte
+
+// TM1637Driver is the driver for modules based on the TM1637, which are mostly 4 digit 7-segment displays with a
+// colon, used for clocks. Key scanning is not supported yet by this driver.
+//
+// Datasheet EN: https://www.mcielectronics.cl/website_MCI/static/documents/Datasheet_TM1637.pdf
+type TM1637Driver struct {
+	*driver
+	tm1637Cfg *tm1637Configuration
+	pinClock  *DirectPinDriver
+	pinData   *DirectPinDriver
+	displayOn bool
+	fonts     font.SevenSegmentFont
+}
+
+// NewTM1637Driver return a new TM1637Driver given a gobot.Connection and the clock and data pins. The TM1637 uses a
+// 2-wire protocol, which is similar to i2c, but without an address.
+//
+// Supported options:
+//
+//	"WithName"
+//	"WithTM1637Digits"
+//	"WithTM1637Brightness"
+//
+// Adds the following API Commands:
+//
+//	"SetDisplayText" - See TM1637Driver.SetDisplayText
+//	"SetBrightness" - See TM1637Driver.SetBrightness
+//	"Clear" - See TM1637Driver.Clear
+func NewTM1637Driver(a gobot.Connection, clockPin, dataPin string, opts ...interface{}) *TM1637Driver {
+	d := &TM1637Driver{
+		driver:    newDriver(a, "TM1637"),
+		tm1637Cfg: &tm1637Configuration{digits: tm1637DefaultDigits, brightness: tm1637MaxBrightness},
+		pinClock:  NewDirectPinDriver(a, clockPin),
+		pinData:   NewDirectPinDriver(a, dataPin),
+		displayOn: true,
+		fonts:     font.NewSevenSegmentFont(),
+	}
+	d.afterStart = d.initialize
+	d.usedPins = func() map[string]string { return directPinsUsage(d.pinClock, d.pinData) }
+
+	for _, opt := range opts {
+		switch o := opt.(type) {
+		case optionApplier:
+			o.apply(d.driverCfg)
+		case tm1637OptionApplier:
+			o.apply(d.tm1637Cfg)
+		default:
+			panic(fmt.Sprintf("'%s' can not be applied on '%s'", opt, d.driverCfg.name))
+		}
+	}
+
+	//nolint:forcetypeassert // ok here
+	d.AddCommand("SetDisplayText", func(params map[string]interface{}) interface{} {
+		text := params["text"].(string)
+		return d.SetDisplayText(text)
+	})
+	//nolint:forcetypeassert // ok here
+	d.AddCommand("SetBrightness", func(params map[string]interface{}) interface{} {
+		level := params["level"].(byte)
+		return d.SetBrightness(level)
+	})
+	d.AddCommand("Clear", func(params map[string]interface{}) interface{} {
+		return d.Clear()
+	})
+
+	return d
+}
+
+// WithTM1637Digits changes the count of digits from default 4 to the given value (1..6).
+func WithTM1637Digits(digits int) tm1637OptionApplier {
+	return tm1637DigitsOption(digits)
+}
+
+// WithTM1637Brightness changes the initial brightness from default 7 (maximum) to the given value (0..7).
+func WithTM1637Brightness(level byte) tm1637OptionApplier {
+	return tm1637BrightnessOption(level)
+}
+
+// Digits returns the count of digits of the display (interface font.SegmentDisplayer).
+func (d *TM1637Driver) Digits() int {
+	return d.tm1637Cfg.digits
+}
+
+// WriteSegments writes the segments of the digits, beginning with the left digit (interface font.SegmentDisplayer).
+// The colon is mapped to the decimal point of the second digit, like it is wired on the common clock displays.
+func (d *TM1637Driver) WriteSegments(digits []byte, colon bool) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.writeSegments(digits, colon)
+}
+
+// SetDisplayText cuts and sends a string to the display. Dots are merged into the previous digit and a colon is
+// shown, see font.SevenSegmentFont.Encode().
+func (d *TM1637Driver) SetDisplayText(text string) error {
+	digits, colon := d.fonts.Encode(text)
+	return d.WriteSegments(digits, colon)
+}
+
+// SetBrightness changes the brightness of the display (0..7). Values above 7 are used as 7.
+func (d *TM1637Driver) SetBrightness(level byte) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if level > tm1637MaxBrightness {
+		level = tm1637MaxBrightness
+	}
+	d.tm1637Cfg.brightness = level
+
+	return d.sendCommand(d.displayControl())
+}
+
+// SetDisplayOn switches the display on or off, the content of the display is not changed.
+func (d *TM1637Driver) SetDisplayOn(on bool) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.displayOn = on
+
+	return d.sendCommand(d.displayControl())
+}
+
+// Clear switches off all segments of the display.
+func (d *TM1637Driver) Clear() error {
+	return d.WriteSegments(nil, false)
+}
+
+// initialize initializes the tm1637, it uses a i2c-like communication protocol
+func (d *TM1637Driver) initialize() error {
+	if d.tm1637Cfg.digits < 1 || d.tm1637Cfg.digits > tm1637MaxDigits {
+		return fmt.Errorf("count of digits must be between 1 and %d, got %d", tm1637MaxDigits, d.tm1637Cfg.digits)
+	}
+	if d.tm1637Cfg.brightness > tm1637MaxBrightness {
+		d.tm1637Cfg.brightness = tm1637MaxBrightness
+	}
+
+	if err := d.pinClock.On(); err != nil {
+		return err
+	}
+	if err := d.pinData.On(); err != nil {
+		return err
+	}
+
+	return d.writeSegments(nil, false)
+}
+
+// writeSegments writes the data and display control command to the module
+func (d *TM1637Driver) writeSegments(digits []byte, colon bool) error {
+	data := make([]byte, d.tm1637Cfg.digits)
+	copy(data, digits)
+	if colon && len(data) > tm1637ColonDigit {
+		data[tm1637ColonDigit] |= font.SegmentDP
+	}
+
+	if err := d.sendCommand(TM1637DataCmd); err != nil {
+		return err
+	}
+	if err := d.start(); err != nil {
+		return err
+	}
+	if err := d.send(TM1637AddrCmd); err != nil {
+		return err
+	}
+	for _, b := range data {
+		if err := d.send(b); err != nil {
+			return err
+		}
+	}
+	if err := d.stop(); err != nil {
+		return err
+	}
+
+	return d.sendCommand(d.displayControl())
+}
+
+// displayControl returns the command for the current brightness and state of the display
+func (d *TM1637Driver) displayControl() byte {
+	cmd := byte(TM1637DispCtrl) | d.tm1637Cfg.brightness
+	if d.displayOn {
+		cmd |= TM1637DisplayOn
+	}
+	return cmd
+}
+
+// sendCommand is an auxiliary function to send a single command byte to the TM1637 module
+func (d *TM1637Driver) sendCommand(cmd byte) error {
+	if err := d.start(); err != nil {
+		return err
+	}
+	if err := d.send(cmd); err != nil {
+		return err
+	}
+	return d.stop()
+}
+
+// start begins a transmission by a falling edge of the data line, while the clock is high
+func (d *TM1637Driver) start() error {
+	return d.pinData.Off()
+}
+
+// stop ends a transmission by a rising edge of the data line, while the clock is high
+func (d *TM1637Driver) stop() error {
+	if err := d.pinClock.Off(); err != nil {
+		return err
+	}
+	if err := d.pinData.Off(); err != nil {
+		return err
+	}
+	if err := d.pinClock.On(); err != nil {
+		return err
+	}
+	return d.pinData.On()
+}
+
+// send writes the data, beginning with the lowest bit, and clocks the acknowledge of the module
+func (d *TM1637Driver) send(data byte) error {
+	for i := 0; i < 8; i++ {
+		if err := d.pinClock.Off(); err != nil {
+			return err
+		}
+
+		if (data & 1) > 0 {
+			if err := d.pinData.On(); err != nil {
+				return err
+			}
+		} else {
+			if err := d.pinData.Off(); err != nil {
+				return err
+			}
+		}
+		data >>= 1
+
+		if err := d.pinClock.On(); err != nil {
+			return err
+		}
+	}
+
+	// the module pulls the data line low for acknowledge, so we drive the same level to prevent a short circuit
+	if err := d.pinClock.Off(); err != nil {
+		return err
+	}
+	if err := d.pinData.Off(); err != nil {
+		return err
+	}
+	if err := d.pinClock.On(); err != nil {
+		return err
+	}
+	return d.pinClock.Off()
+}
+
+func (o tm1637DigitsOption) String() string {
+	return "digits option for TM1637"
+}
+
+func (o tm1637BrightnessOption) String() string {
+	return "brightness option for TM1637"
+}
+
+func (o tm1637DigitsOption) apply(cfg *tm1637Configuration) {
+	cfg.digits = int(o)
+}
+
+func (o tm1637BrightnessOption) apply(cfg *tm1637Configuration) {
+	cfg.brightness = byte(o)
+}
@@ -0,0 +1,257 @@
+package gpio
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/drivers/aio"
+	"gobot.io/x/gobot/v2/drivers/common/font"
+)
+
+var (
+	_ gobot.Driver          = (*TM1637Driver)(nil)
+	_ font.SegmentDisplayer = (*TM1637Driver)(nil)
+)
+
+func initTestTM1637DriverWithStubbedAdaptor(opts ...interface{}) (*TM1637Driver, *gpioTestAdaptor) {
+	a := newGpioTestAdaptor()
+	d := NewTM1637Driver(a, "1", "2", opts...)
+	if err := d.Start(); err != nil {
+		panic(err)
+	}
+	a.written = nil
+	return d, a
+}
+
+// tm1637TestFrames decodes the written values of clock pin "1" and data pin "2" to the transmitted frames
+func tm1637TestFrames(written []gpioTestWritten) [][]byte {
+	var frames [][]byte
+	clock, data := byte(1), byte(1) // idle state of the bus
+	var bits []byte
+	var frame []byte
+	for _, w := range written {
+		switch w.pin {
+		case "1":
+			if clock == 0 && w.val == 1 && frame != nil {
+				bits = append(bits, data)
+				if len(bits) == 9 {
+					var b byte
+					for i := 7; i >= 0; i-- {
+						b = b<<1 | bits[i]
+					}
+					frame = append(frame, b)
+					bits = nil
+				}
+			}
+			clock = w.val
+		case "2":
+			if clock == 1 && data == 1 && w.val == 0 {
+				frame = []byte{}
+				bits = nil
+			}
+			if clock == 1 && data == 0 && w.val == 1 && frame != nil {
+				frames = append(frames, frame)
+				frame = nil
+			}
+			data = w.val
+		}
+	}
+	return frames
+}
+
+func TestNewTM1637Driver(t *testing.T) {
+	// arrange
+	a := newGpioTestAdaptor()
+	// act
+	d := NewTM1637Driver(a, "10", "20")
+	// assert
+	assert.IsType(t, &TM1637Driver{}, d)
+	// assert: gpio.driver attributes
+	require.NotNil(t, d.driver)
+	assert.True(t, strings.HasPrefix(d.driverCfg.name, "TM1637"))
+	assert.Equal(t, a, d.connection)
+	assert.NotNil(t, d.afterStart)
+	assert.NotNil(t, d.beforeHalt)
+	assert.NotNil(t, d.Commander)
+	assert.NotNil(t, d.mutex)
+	// assert: driver specific attributes
+	assert.NotNil(t, d.pinClock)
+	assert.NotNil(t, d.pinData)
+	assert.NotNil(t, d.fonts)
+	assert.Equal(t, 4, d.Digits())
+	assert.Equal(t, byte(7), d.tm1637Cfg.brightness)
+	assert.True(t, d.displayOn)
+}
+
+func TestNewTM1637Driver_options(t *testing.T) {
+	// This is a general test, that options are applied in constructor by using the common WithName() option, least one
+	// option of this driver and one of another driver (which should lead to panic). Further tests for options can also
+	// be done by call of "WithOption(val).apply(cfg)".
+	// arrange
+	const (
+		myName = "clock"
+	)
+	panicFunc := func() {
+		NewTM1637Driver(newGpioTestAdaptor(), "1", "2", WithName("crazy"),
+			aio.WithActuatorScaler(func(float64) int { return 0 }))
+	}
+	// act
+	d := NewTM1637Driver(newGpioTestAdaptor(), "1", "2", WithName(myName), WithTM1637Digits(6),
+		WithTM1637Brightness(2))
+	// assert
+	assert.Equal(t, myName, d.Name())
+	assert.Equal(t, 6, d.Digits())
+	assert.Equal(t, byte(2), d.tm1637Cfg.brightness)
+	assert.PanicsWithValue(t, "'scaler option for analog actuators' can not be applied on 'crazy'", panicFunc)
+}
+
+func TestTM1637Start(t *testing.T) {
+	tests := map[string]struct {
+		opts       []interface{}
+		wantFrames [][]byte
+		wantErr    string
+	}{
+		"default": {
+			wantFrames: [][]byte{{0x40}, {0xC0, 0x00, 0x00, 0x00, 0x00}, {0x8F}},
+		},
+		"brightness_limited": {
+			opts:       []interface{}{WithTM1637Digits(1), WithTM1637Brightness(9)},
+			wantFrames: [][]byte{{0x40}, {0xC0, 0x00}, {0x8F}},
+		},
+		"error_no_digits": {
+			opts:    []interface{}{WithTM1637Digits(0)},
+			wantErr: "count of digits must be between 1 and 6, got 0",
+		},
+		"error_too_many_digits": {
+			opts:    []interface{}{WithTM1637Digits(7)},
+			wantErr: "count of digits must be between 1 and 6, got 7",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := newGpioTestAdaptor()
+			d := NewTM1637Driver(a, "1", "2", tc.opts...)
+			// act
+			err := d.Start()
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantFrames, tm1637TestFrames(a.written))
+		})
+	}
+}
+
+func TestTM1637WriteSegments(t *testing.T) {
+	tests := map[string]struct {
+		digits    []byte
+		colon     bool
+		wantFrame []byte
+	}{
+		"filled": {
+			digits:    []byte{0x06},
+			wantFrame: []byte{0xC0, 0x06, 0x00, 0x00, 0x00},
+		},
+		"cut": {
+			digits:    []byte{0x01, 0x02, 0x03, 0x04, 0x05},
+			wantFrame: []byte{0xC0, 0x01, 0x02, 0x03, 0x04},
+		},
+		"colon": {
+			digits:    []byte{0x01, 0x02, 0x03, 0x04},
+			colon:     true,
+			wantFrame: []byte{0xC0, 0x01, 0x82, 0x03, 0x04},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, a := initTestTM1637DriverWithStubbedAdaptor()
+			// act
+			err := d.WriteSegments(tc.digits, tc.colon)
+			// assert
+			require.NoError(t, err)
+			assert.Equal(t, [][]byte{{0x40}, tc.wantFrame, {0x8F}}, tm1637TestFrames(a.written))
+		})
+	}
+}
+
+func TestTM1637WriteSegments_error(t *testing.T) {
+	// arrange
+	d, a := initTestTM1637DriverWithStubbedAdaptor()
+	a.simulateWriteError = true
+	// act
+	err := d.WriteSegments([]byte{0x01}, false)
+	// assert
+	require.EqualError(t, err, "write error")
+}
+
+func TestTM1637SetDisplayText(t *testing.T) {
+	// arrange
+	d, a := initTestTM1637DriverWithStubbedAdaptor()
+	// act
+	err := d.SetDisplayText("12:3.4")
+	// assert
+	require.NoError(t, err)
+	want := [][]byte{{0x40}, {0xC0, 0x06, 0x5B | 0x80, 0x4F | 0x80, 0x66}, {0x8F}}
+	assert.Equal(t, want, tm1637TestFrames(a.written))
+}
+
+func TestTM1637SetBrightness(t *testing.T) {
+	// arrange
+	d, a := initTestTM1637DriverWithStubbedAdaptor()
+	// act
+	err := d.SetBrightness(3)
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{{0x8B}}, tm1637TestFrames(a.written))
+}
+
+func TestTM1637SetDisplayOn(t *testing.T) {
+	// arrange
+	d, a := initTestTM1637DriverWithStubbedAdaptor(WithTM1637Brightness(1))
+	// act
+	errOff := d.SetDisplayOn(false)
+	errOn := d.SetDisplayOn(true)
+	// assert
+	require.NoError(t, errOff)
+	require.NoError(t, errOn)
+	assert.Equal(t, [][]byte{{0x81}, {0x89}}, tm1637TestFrames(a.written))
+}
+
+func TestTM1637Commands(t *testing.T) {
+	// arrange
+	d, a := initTestTM1637DriverWithStubbedAdaptor(WithTM1637Digits(2))
+	// act
+	errText := d.Command("SetDisplayText")(map[string]interface{}{"text": "-1"})
+	errBrightness := d.Command("SetBrightness")(map[string]interface{}{"level": byte(0)})
+	errClear := d.Command("Clear")(nil)
+	// assert
+	assert.Nil(t, errText)
+	assert.Nil(t, errBrightness)
+	assert.Nil(t, errClear)
+	want := [][]byte{
+		{0x40}, {0xC0, 0x40, 0x06}, {0x8F},
+		{0x88},
+		{0x40}, {0xC0, 0x00, 0x00}, {0x88},
+	}
+	assert.Equal(t, want, tm1637TestFrames(a.written))
+}
+
+func TestTM1637TextDisplay(t *testing.T) {
+	// arrange
+	d, a := initTestTM1637DriverWithStubbedAdaptor()
+	td := font.NewSegmentTextDisplay(d, nil)
+	// act
+	err := td.ShowNumber(-2.5, 1)
+	// assert
+	require.NoError(t, err)
+	want := [][]byte{{0x40}, {0xC0, 0x00, 0x40, 0x5B | 0x80, 0x6D}, {0x8F}}
+	assert.Equal(t, want, tm1637TestFrames(a.written))
+}
@@ -2,9 +2,10 @@ package gpio
 
 import (
 	"math"
-	"strings"
+	"unicode/utf8"
 
 	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/drivers/common/font"
 )
 
 // Colors of the display
@@ -23,6 +24,8 @@ const (
 	TM1638WriteDisp = 0x00
 	TM1638ReadKeys  = 0x02
 	TM1638FixedAddr = 0x04
+
+	tm1638Digits = 8
 )
 
 // TM1638Driver is the driver for modules based on the TM1638, which has 8 7-segment displays, 8 LEDs and 8 buttons.
@@ -37,7 +40,7 @@ type TM1638Driver struct {
 	pinClock  *DirectPinDriver
 	pinData   *DirectPinDriver
 	pinStrobe *DirectPinDriver
-	fonts     map[string]byte
+	fonts     font.SevenSegmentFont
 }
 
 // NewTM1638Driver return a new TM1638Driver given a gobot.Connection and the clock, data and strobe pins
@@ -51,9 +54,10 @@ func NewTM1638Driver(a gobot.Connection, clockPin, dataPin, strobePin string, op
 		pinClock:  NewDirectPinDriver(a, clockPin),
 		pinData:   NewDirectPinDriver(a, dataPin),
 		pinStrobe: NewDirectPinDriver(a, strobePin),
-		fonts:     NewTM1638Fonts(),
+		fonts:     font.NewSevenSegmentFont(),
 	}
 	d.afterStart = d.initialize
+	d.usedPins = func() map[string]string { return directPinsUsage(d.pinClock, d.pinData, d.pinStrobe) }
 
 	/* TODO : Add commands */
 
@@ -79,16 +83,30 @@ func (d *TM1638Driver) SetDisplay(data []byte) error {
 	return nil
 }
 
-// SetDisplayText cuts and sends a string to the display (without dots)
+// SetDisplayText cuts and sends a string to the display (without dots). Each character needs one digit, also a
+// "." and a ":", which is shown as blank digit.
 func (d *TM1638Driver) SetDisplayText(text string) error {
-	data := d.fromStringToByteArray(text)
-	minLength := int(math.Min(8, float64(len(data))))
-	for i := 0; i < minLength; i++ {
-		if err := d.SendChar(byte(i), data[i], false); err != nil {
-			return err
-		}
-	}
-	return nil
+	return d.SetDisplay(d.fromStringToByteArray(text))
+}
+
+// SetDisplayTextMergedDots cuts and sends a string to the display, a "." is merged into the decimal point of the
+// previous digit, so "12.34" needs only 4 digits. The display has no colon, so a ":" is ignored.
+func (d *TM1638Driver) SetDisplayTextMergedDots(text string) error {
+	data, _ := d.fonts.Encode(text)
+	return d.SetDisplay(data)
+}
+
+// Digits returns the count of digits of the display (interface font.SegmentDisplayer).
+func (d *TM1638Driver) Digits() int {
+	return tm1638Digits
+}
+
+// WriteSegments cuts and sends the segments of the digits to the display, beginning with the left digit (interface
+// font.SegmentDisplayer). The display has no colon, so this parameter is ignored.
+func (d *TM1638Driver) WriteSegments(digits []byte, _ bool) error {
+	data := make([]byte, tm1638Digits)
+	copy(data, digits)
+	return d.SetDisplay(data)
 }
 
 // SendChar sends one byte to the specific position in the display
@@ -106,13 +124,14 @@ func (d *TM1638Driver) SendChar(pos byte, data byte, dot bool) error {
 // AddFonts adds new custom fonts or modify the representation of existing ones
 func (d *TM1638Driver) AddFonts(fonts map[string]byte) {
 	for k, v := range fonts {
-		d.fonts[k] = v
+		r, _ := utf8.DecodeRuneInString(k)
+		d.fonts[r] = v
 	}
 }
 
 // ClearFonts removes all the fonts from the driver
 func (d *TM1638Driver) ClearFonts() {
-	d.fonts = make(map[string]byte)
+	d.fonts = font.SevenSegmentFont{}
 }
 
 // initialize initializes the tm1638, it uses a SPI-like communication protocol
@@ -148,13 +167,9 @@ func (d *TM1638Driver) initialize() error {
 // fromStringToByteArray translates a string to a byte array with the corresponding representation
 // for the 7-segment LCD, return and empty character if the font is not available
 func (d *TM1638Driver) fromStringToByteArray(str string) []byte {
-	chars := strings.Split(str, "")
-	data := make([]byte, len(chars))
-
-	for index, char := range chars {
-		if val, ok := d.fonts[char]; ok {
-			data[index] = val
-		}
+	data := make([]byte, 0, len(str))
+	for _, r := range str {
+		data = append(data, d.fonts[r])
 	}
 	return data
 }
@@ -214,102 +229,12 @@ func (d *TM1638Driver) send(data byte) error {
 }
 
 // NewTM1638Fonts returns a map with fonts and their corresponding byte for proper representation on the 7-segment LCD
+//
+// Deprecated: Please use font.NewSevenSegmentFont() instead.
 func NewTM1638Fonts() map[string]byte {
-	return map[string]byte{
-		" ":  0x00,
-		"!":  0x86,
-		"'":  0x22,
-		"#":  0x7E,
-		"$":  0x6D,
-		"%":  0x00,
-		"&":  0x00,
-		"\"": 0x02,
-		"(":  0x30,
-		")":  0x06,
-		"*":  0x63,
-		"+":  0x00,
-		",":  0x04,
-		"-":  0x40,
-		".":  0x80,
-		"/":  0x52,
-		"0":  0x3F,
-		"1":  0x06,
-		"2":  0x5B,
-		"3":  0x4F,
-		"4":  0x66,
-		"5":  0x6D,
-		"6":  0x7D,
-		"7":  0x27,
-		"8":  0x7F,
-		"9":  0x6F,
-		":":  0x00,
-		";":  0x00,
-		"<":  0x00,
-		"=":  0x48,
-		">":  0x00,
-		"?":  0x53,
-		"@":  0x5F,
-		"A":  0x77,
-		"B":  0x7F,
-		"C":  0x39,
-		"D":  0x3F,
-		"E":  0x79,
-		"F":  0x71,
-		"G":  0x3D,
-		"H":  0x76,
-		"I":  0x06,
-		"J":  0x1F,
-		"K":  0x69,
-		"L":  0x38,
-		"M":  0x15,
-		"N":  0x37,
-		"O":  0x3F,
-		"P":  0x73,
-		"Q":  0x67,
-		"R":  0x31,
-		"S":  0x6D,
-		"T":  0x78,
-		"U":  0x3E,
-		"V":  0x2A,
-		"W":  0x1D,
-		"X":  0x76,
-		"Y":  0x6E,
-		"Z":  0x5B,
-		"[":  0x39,
-		"\\": 0x64, // (this can't be the last char on a line, even in comment or it'll concat)
-		"]":  0x0F,
-		"^":  0x00,
-		"_":  0x08,
-		"`":  0x20,
-		"a":  0x5F,
-		"b":  0x7C,
-		"c":  0x58,
-		"d":  0x5E,
-		"e":  0x7B,
-		"f":  0x31,
-		"g":  0x6F,
-		"h":  0x74,
-		"i":  0x04,
-		"j":  0x0E,
-		"k":  0x75,
-		"l":  0x30,
-		"m":  0x55,
-		"n":  0x54,
-		"o":  0x5C,
-		"p":  0x73,
-		"q":  0x67,
-		"r":  0x50,
-		"s":  0x6D,
-		"t":  0x78,
-		"u":  0x1C,
-		"v":  0x2A,
-		"w":  0x1D,
-		"x":  0x76,
-		"y":  0x6E,
-		"z":  0x47,
-		"{":  0x46,
-		"|":  0x06,
-		"}":  0x70,
-		"~":  0x01,
+	fonts := make(map[string]byte)
+	for k, v := range font.NewSevenSegmentFont() {
+		fonts[string(k)] = v
 	}
+	return fonts
 }
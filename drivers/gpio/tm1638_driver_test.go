@@ -90,3 +90,52 @@ func TestTM1638ClearFonts(t *testing.T) {
 	data := d.fromStringToByteArray("Hello World")
 	assert.Equal(t, []byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}, data)
 }
+
+func TestTM1638FromStringToByteArray_dots(t *testing.T) {
+	d := initTestTM1638Driver()
+	data := d.fromStringToByteArray("1.2:")
+	assert.Equal(t, []byte{0x06, 0x80, 0x5B, 0x00}, data)
+}
+
+func TestTM1638SetDisplayTextMergedDots(t *testing.T) {
+	// arrange
+	d, a := initTestTM1638DriverWithStubbedAdaptor()
+	require.NoError(t, d.Start())
+	a.written = nil
+	// act
+	err := d.SetDisplayTextMergedDots("1.2:3")
+	// assert
+	require.NoError(t, err)
+	frames := gpioTestSerialFrames(a.written, "1", "2", "3", true)
+	require.Len(t, frames, 6)
+	assert.Equal(t, []byte{0xC0, 0x86}, frames[1])
+	assert.Equal(t, []byte{0xC2, 0x5B}, frames[3])
+	assert.Equal(t, []byte{0xC4, 0x4F}, frames[5])
+}
+
+func TestTM1638WriteSegments(t *testing.T) {
+	// arrange
+	d, a := initTestTM1638DriverWithStubbedAdaptor()
+	require.NoError(t, d.Start())
+	a.written = nil
+	// act
+	err := d.WriteSegments([]byte{0x06, 0x5B}, true)
+	// assert
+	require.NoError(t, err)
+	frames := gpioTestSerialFrames(a.written, "1", "2", "3", true)
+	require.Len(t, frames, 16)
+	assert.Equal(t, []byte{0x44}, frames[0])
+	assert.Equal(t, []byte{0xC0, 0x06}, frames[1])
+	assert.Equal(t, []byte{0xC2, 0x5B}, frames[3])
+	assert.Equal(t, []byte{0xCE, 0x00}, frames[15])
+	assert.Equal(t, 8, d.Digits())
+}
+
+func TestNewTM1638Fonts(t *testing.T) {
+	// act
+	fonts := NewTM1638Fonts()
+	// assert
+	assert.Len(t, fonts, 95)
+	assert.Equal(t, byte(0x3F), fonts["0"])
+	assert.Equal(t, byte(0x64), fonts["\\"])
+}
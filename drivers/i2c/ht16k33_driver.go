@@ -0,0 +1,202 @@
+package i2c
+
+// default address for HT16K33, can be changed by the address pins (A0..A2) up to 0x77
+const ht16k33DefaultAddress = 0x70
+
+const (
+	ht16k33SystemSetup     = 0x20
+	ht16k33OscillatorOn    = 0x01
+	ht16k33DisplaySetup    = 0x80
+	ht16k33DisplayOn       = 0x01
+	ht16k33DimmingSet      = 0xE0
+	ht16k33MaxBrightness   = 15
+	ht16k33RAMSize         = 16
+	ht16k33SegmentDigits   = 4
+	ht16k33SegmentColonCom = 2
+	ht16k33SegmentColonBit = 0x02
+	ht16k33MatrixColumns   = 8
+)
+
+// HT16K33BlinkRate is the type for the blink rate of the display
+type HT16K33BlinkRate uint8
+
+// Blink rates of the display
+const (
+	HT16K33BlinkOff    HT16K33BlinkRate = 0
+	HT16K33Blink2Hz    HT16K33BlinkRate = 1
+	HT16K33Blink1Hz    HT16K33BlinkRate = 2
+	HT16K33BlinkHalfHz HT16K33BlinkRate = 3
+)
+
+// ht16k33SegmentComs are the common lines of the 4 digits of the 7-segment backpack, the colon is between
+var ht16k33SegmentComs = [ht16k33SegmentDigits]int{0, 1, 3, 4}
+
+// HT16K33Driver is a driver for the HT16K33 LED controller with 16x8 outputs, used e.g. on the Adafruit backpacks
+// for 4 digit 7-segment displays with colon and 8x8 LED matrix displays.
+//
+// Datasheet: https://www.holtek.com/documents/10179/116711/HT16K33v120.pdf
+type HT16K33Driver struct {
+	*Driver
+	brightness byte
+	blinkRate  HT16K33BlinkRate
+	ram        [ht16k33RAMSize]byte
+}
+
+// NewHT16K33Driver creates a new driver with specified i2c interface
+// Params:
+//
+//	c Connector - the Adaptor to use with this Driver
+//
+// Optional params:
+//
+//	i2c.WithBus(int):	bus to use with this driver
+//	i2c.WithAddress(int):	address to use with this driver
+//	i2c.WithHT16K33Brightness(byte):	initial brightness 0..15, default is 15
+//	i2c.WithHT16K33BlinkRate(HT16K33BlinkRate):	initial blink rate, default is off
+func NewHT16K33Driver(c Connector, options ...func(Config)) *HT16K33Driver {
+	d := &HT16K33Driver{
+		Driver:     NewDriver(c, "HT16K33", ht16k33DefaultAddress),
+		brightness: ht16k33MaxBrightness,
+	}
+	d.afterStart = d.initialize
+
+	for _, option := range options {
+		option(d)
+	}
+
+	//nolint:forcetypeassert // ok here
+	d.AddCommand("SetBrightness", func(params map[string]interface{}) interface{} {
+		level := params["level"].(byte)
+		return d.SetBrightness(level)
+	})
+	d.AddCommand("Clear", func(params map[string]interface{}) interface{} {
+		return d.Clear()
+	})
+
+	return d
+}
+
+// WithHT16K33Brightness sets the initial brightness (0..15) of the display.
+func WithHT16K33Brightness(level byte) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*HT16K33Driver)
+		if ok {
+			d.brightness = level
+		}
+	}
+}
+
+// WithHT16K33BlinkRate sets the initial blink rate of the display.
+func WithHT16K33BlinkRate(rate HT16K33BlinkRate) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*HT16K33Driver)
+		if ok {
+			d.blinkRate = rate
+		}
+	}
+}
+
+// SetBrightness changes the brightness of the display (0..15). Values above 15 are used as 15.
+func (d *HT16K33Driver) SetBrightness(level byte) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if level > ht16k33MaxBrightness {
+		level = ht16k33MaxBrightness
+	}
+	d.brightness = level
+
+	return d.connection.WriteByte(ht16k33DimmingSet | d.brightness)
+}
+
+// SetBlinkRate changes the blink rate of the display.
+func (d *HT16K33Driver) SetBlinkRate(rate HT16K33BlinkRate) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.blinkRate = rate & HT16K33BlinkHalfHz
+
+	return d.connection.WriteByte(ht16k33DisplaySetup | ht16k33DisplayOn | byte(d.blinkRate)<<1)
+}
+
+// WriteRAM replaces the display RAM. Each common line uses 2 bytes, one for the rows 0..7 and one for the rows 8..15.
+func (d *HT16K33Driver) WriteRAM(data [ht16k33RAMSize]byte) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.ram = data
+
+	return d.writeRAM()
+}
+
+// Clear switches off all LEDs of the display.
+func (d *HT16K33Driver) Clear() error {
+	return d.WriteRAM([ht16k33RAMSize]byte{})
+}
+
+// Digits returns the count of digits of the 7-segment backpack (interface font.SegmentDisplayer).
+func (d *HT16K33Driver) Digits() int {
+	return ht16k33SegmentDigits
+}
+
+// WriteSegments writes the segments of the digits, beginning with the left digit, for the 4 digit 7-segment backpack
+// with colon (interface font.SegmentDisplayer).
+func (d *HT16K33Driver) WriteSegments(digits []byte, colon bool) error {
+	var data [ht16k33RAMSize]byte
+	for i, com := range ht16k33SegmentComs {
+		if i < len(digits) {
+			data[com*2] = digits[i]
+		}
+	}
+	if colon {
+		data[ht16k33SegmentColonCom*2] = ht16k33SegmentColonBit
+	}
+
+	return d.WriteRAM(data)
+}
+
+// Columns returns the count of columns of the 8x8 matrix backpack (interface font.MatrixDisplayer).
+func (d *HT16K33Driver) Columns() int {
+	return ht16k33MatrixColumns
+}
+
+// WriteColumns writes the columns, beginning with the left column, for the 8x8 matrix backpack (interface
+// font.MatrixDisplayer). The lowest bit of each column is the top row. The rows are connected to the common lines and
+// the columns to the row outputs 0..7 of the HT16K33.
+func (d *HT16K33Driver) WriteColumns(columns []byte) error {
+	var data [ht16k33RAMSize]byte
+	for x := 0; x < len(columns) && x < ht16k33MatrixColumns; x++ {
+		for y := 0; y < 8; y++ {
+			if columns[x]&(1<<y) > 0 {
+				data[y*2] |= 1 << x
+			}
+		}
+	}
+
+	return d.WriteRAM(data)
+}
+
+func (d *HT16K33Driver) initialize() error {
+	if d.brightness > ht16k33MaxBrightness {
+		d.brightness = ht16k33MaxBrightness
+	}
+	d.blinkRate &= HT16K33BlinkHalfHz
+
+	if err := d.connection.WriteByte(ht16k33SystemSetup | ht16k33OscillatorOn); err != nil {
+		return err
+	}
+	d.ram = [ht16k33RAMSize]byte{}
+	if err := d.writeRAM(); err != nil {
+		return err
+	}
+	if err := d.connection.WriteByte(ht16k33DimmingSet | d.brightness); err != nil {
+		return err
+	}
+
+	return d.connection.WriteByte(ht16k33DisplaySetup | ht16k33DisplayOn | byte(d.blinkRate)<<1)
+}
+
+// writeRAM sends the RAM buffer to the device, beginning with address 0
+func (d *HT16K33Driver) writeRAM() error {
+	return d.connection.WriteBlockData(0x00, d.ram[:])
+}
@@ -0,0 +1,188 @@
+package i2c
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/drivers/common/font"
+)
+
+// this ensures that the implementation is based on i2c.Driver, which implements the gobot.Driver
+// and tests all implementations, so no further tests needed here for gobot.Driver interface
+var (
+	_ gobot.Driver          = (*HT16K33Driver)(nil)
+	_ font.SegmentDisplayer = (*HT16K33Driver)(nil)
+	_ font.MatrixDisplayer  = (*HT16K33Driver)(nil)
+)
+
+func initTestHT16K33WithStubbedAdaptor() (*HT16K33Driver, *i2cTestAdaptor) {
+	a := newI2cTestAdaptor()
+	d := NewHT16K33Driver(a)
+	if err := d.Start(); err != nil {
+		panic(err)
+	}
+	a.written = nil
+	return d, a
+}
+
+// ht16k33TestRAMWrite returns the expected written bytes for the given RAM content
+func ht16k33TestRAMWrite(ram [16]byte) []byte {
+	return append([]byte{0x00}, ram[:]...)
+}
+
+func TestNewHT16K33Driver(t *testing.T) {
+	// arrange, act
+	var di interface{} = NewHT16K33Driver(newI2cTestAdaptor())
+	// assert
+	d, ok := di.(*HT16K33Driver)
+	if !ok {
+		t.Errorf("NewHT16K33Driver() should have returned a *HT16K33Driver")
+	}
+	assert.NotNil(t, d.Driver)
+	assert.True(t, strings.HasPrefix(d.Name(), "HT16K33"))
+	assert.Equal(t, 0x70, d.defaultAddress)
+	assert.Equal(t, byte(15), d.brightness)
+	assert.Equal(t, HT16K33BlinkOff, d.blinkRate)
+	assert.Equal(t, 4, d.Digits())
+	assert.Equal(t, 8, d.Columns())
+}
+
+func TestHT16K33Options(t *testing.T) {
+	// This is a general test, that options are applied in constructor by using the common WithBus() option and
+	// least one of this driver. Further tests for options can also be done by call of "WithOption(val)(d)".
+	d := NewHT16K33Driver(newI2cTestAdaptor(), WithBus(2), WithHT16K33Brightness(3),
+		WithHT16K33BlinkRate(HT16K33Blink1Hz))
+	assert.Equal(t, 2, d.GetBusOrDefault(1))
+	assert.Equal(t, byte(3), d.brightness)
+	assert.Equal(t, HT16K33Blink1Hz, d.blinkRate)
+}
+
+func TestHT16K33Start(t *testing.T) {
+	tests := map[string]struct {
+		options []func(Config)
+		want    []byte
+	}{
+		"default": {
+			want: append(append([]byte{0x21}, ht16k33TestRAMWrite([16]byte{})...), 0xEF, 0x81),
+		},
+		"brightness_limited_blink": {
+			options: []func(Config){WithHT16K33Brightness(20), WithHT16K33BlinkRate(HT16K33Blink2Hz)},
+			want:    append(append([]byte{0x21}, ht16k33TestRAMWrite([16]byte{})...), 0xEF, 0x83),
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := newI2cTestAdaptor()
+			d := NewHT16K33Driver(a, tc.options...)
+			// act
+			err := d.Start()
+			// assert
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, a.written)
+		})
+	}
+}
+
+func TestHT16K33Start_error(t *testing.T) {
+	// arrange
+	a := newI2cTestAdaptor()
+	a.i2cWriteImpl = func([]byte) (int, error) {
+		return 0, errors.New("write error")
+	}
+	d := NewHT16K33Driver(a)
+	// act
+	err := d.Start()
+	// assert
+	require.EqualError(t, err, "write error")
+}
+
+func TestHT16K33SetBrightness(t *testing.T) {
+	// arrange
+	d, a := initTestHT16K33WithStubbedAdaptor()
+	// act
+	err1 := d.SetBrightness(7)
+	err2 := d.SetBrightness(16)
+	// assert
+	require.NoError(t, err1)
+	require.NoError(t, err2)
+	assert.Equal(t, []byte{0xE7, 0xEF}, a.written)
+}
+
+func TestHT16K33SetBlinkRate(t *testing.T) {
+	// arrange
+	d, a := initTestHT16K33WithStubbedAdaptor()
+	// act
+	err := d.SetBlinkRate(HT16K33BlinkHalfHz)
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x87}, a.written)
+}
+
+func TestHT16K33WriteSegments(t *testing.T) {
+	tests := map[string]struct {
+		digits []byte
+		colon  bool
+		want   [16]byte
+	}{
+		"digits": {
+			digits: []byte{0x01, 0x02, 0x03, 0x04, 0x05},
+			want:   [16]byte{0x01, 0x00, 0x02, 0x00, 0x00, 0x00, 0x03, 0x00, 0x04},
+		},
+		"colon": {
+			digits: []byte{0x06},
+			colon:  true,
+			want:   [16]byte{0x06, 0x00, 0x00, 0x00, 0x02},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, a := initTestHT16K33WithStubbedAdaptor()
+			// act
+			err := d.WriteSegments(tc.digits, tc.colon)
+			// assert
+			require.NoError(t, err)
+			assert.Equal(t, ht16k33TestRAMWrite(tc.want), a.written)
+		})
+	}
+}
+
+func TestHT16K33WriteColumns(t *testing.T) {
+	// arrange
+	d, a := initTestHT16K33WithStubbedAdaptor()
+	// act: left column fully on, second column only top row
+	err := d.WriteColumns([]byte{0xFF, 0x01})
+	// assert
+	require.NoError(t, err)
+	want := [16]byte{0x03, 0x00, 0x01, 0x00, 0x01, 0x00, 0x01, 0x00, 0x01, 0x00, 0x01, 0x00, 0x01, 0x00, 0x01}
+	assert.Equal(t, ht16k33TestRAMWrite(want), a.written)
+}
+
+func TestHT16K33Commands(t *testing.T) {
+	// arrange
+	d, a := initTestHT16K33WithStubbedAdaptor()
+	// act
+	errBrightness := d.Command("SetBrightness")(map[string]interface{}{"level": byte(1)})
+	errClear := d.Command("Clear")(nil)
+	// assert
+	assert.Nil(t, errBrightness)
+	assert.Nil(t, errClear)
+	assert.Equal(t, append([]byte{0xE1}, ht16k33TestRAMWrite([16]byte{})...), a.written)
+}
+
+func TestHT16K33TextDisplay(t *testing.T) {
+	// arrange
+	d, a := initTestHT16K33WithStubbedAdaptor()
+	td := font.NewSegmentTextDisplay(d, nil)
+	// act
+	err := td.Show("12:34")
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, ht16k33TestRAMWrite([16]byte{0x06, 0x00, 0x5B, 0x00, 0x02, 0x00, 0x4F, 0x00, 0x66}), a.written)
+}
@@ -40,6 +40,12 @@ const (
 // Provided by an Adaptor by implementing the I2cConnector interface.
 type Connection gobot.I2cOperations
 
+// registerNamerSetter is implemented by connections, which can forward the names of registers to the bus, e.g. for
+// tracing.
+type registerNamerSetter interface {
+	SetRegisterNamer(namer func(reg uint8) string)
+}
+
 type i2cConnection struct {
 	bus     gobot.I2cSystemDevicer
 	address int
@@ -65,6 +71,13 @@ func (c *i2cConnection) Close() error {
 	return nil
 }
 
+// SetRegisterNamer forwards the function to get the names of registers to the bus, if the bus is able to use it.
+func (c *i2cConnection) SetRegisterNamer(namer func(reg uint8) string) {
+	if n, ok := c.bus.(gobot.BusRegisterNamer); ok {
+		n.SetRegisterNamer(c.address, namer)
+	}
+}
+
 // ReadByte reads a single byte from the i2c device.
 func (c *i2cConnection) ReadByte() (byte, error) {
 	return c.bus.ReadByte(c.address)
@@ -78,6 +78,18 @@ func TestI2CAddress(t *testing.T) {
 	assert.Equal(t, 0x66, c.address)
 }
 
+func TestI2CSetRegisterNamer(t *testing.T) {
+	// arrange
+	tracer := system.NewBusTracer(0)
+	bus := system.NewTracedI2cDevice(initI2CDevice(), dev, tracer)
+	c := NewConnection(bus, 0x06)
+	// act
+	c.SetRegisterNamer(func(reg uint8) string { return "REG" })
+	// assert
+	require.NoError(t, c.WriteByteData(0x01, 0x02))
+	assert.Equal(t, "REG", tracer.Transactions()[0].RegisterName)
+}
+
 func TestI2CClose(t *testing.T) {
 	c := NewConnection(initI2CDevice(), 0x06)
 	require.NoError(t, c.Close())
@@ -45,6 +45,7 @@ type Driver struct {
 	connection     Connection
 	afterStart     func() error
 	beforeHalt     func() error
+	registerNamer  func(reg uint8) string // optional, used e.g. for tracing of the bus
 	Config
 	gobot.Commander
 	mutex *sync.Mutex // mutex often needed to ensure that write-read sequences are not interrupted
@@ -103,6 +104,12 @@ func (d *Driver) Start() error {
 		return err
 	}
 
+	if d.registerNamer != nil {
+		if n, ok := d.connection.(registerNamerSetter); ok {
+			n.SetRegisterNamer(d.registerNamer)
+		}
+	}
+
 	return d.afterStart()
 }
 
@@ -0,0 +1,245 @@
+package i2c
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// ScanFirstAddress is the first address probed by the scan, the lower addresses are reserved.
+	ScanFirstAddress = 0x03
+	// ScanLastAddress is the last address probed by the scan, the higher addresses are reserved.
+	ScanLastAddress = 0x77
+)
+
+// Prober lets adaptors (platforms) provide the probing for devices on an i2c bus, which is needed to scan the bus.
+type Prober interface {
+	// ProbeI2cAddress returns true, if a device acknowledges the given address on the given bus.
+	ProbeI2cAddress(address int, busNr int) (bool, error)
+}
+
+// ScanConnector is the interface needed to scan a bus and identify the devices.
+type ScanConnector interface {
+	Connector
+	Prober
+}
+
+// ScanCandidate is a driver, which can most likely be used for the device found at an address.
+type ScanCandidate struct {
+	Chip       string // the name of the chip, e.g. "BME280"
+	Driver     string // the constructor of the driver, e.g. "NewBME280Driver"
+	Identified bool   // true, if the chip was identified by an identification register
+}
+
+// ScanReport contains the found device and the likely drivers for one address.
+type ScanReport struct {
+	Address    int
+	Candidates []ScanCandidate
+}
+
+// scanIdentity describes a supported chip and how to identify it.
+type scanIdentity struct {
+	chip      string
+	driver    string
+	addresses []int
+	hasID     bool    // the chip has an identification register
+	idReg     uint8   // the identification register
+	idMask    uint8   // mask for the relevant bits of the identification register, 0 means 0xFF
+	idValues  []uint8 // the possible values of the identification register
+}
+
+// scanIdentities is the list of chips supported by gobot drivers. The chips with identification register are listed
+// first.
+var scanIdentities = []scanIdentity{
+	{chip: "BMP180", driver: "NewBMP180Driver", addresses: []int{0x77}, hasID: true, idReg: 0xD0, idValues: []uint8{0x55}},
+	{
+		chip: "BMP280", driver: "NewBMP280Driver", addresses: []int{0x76, 0x77}, hasID: true, idReg: 0xD0,
+		idValues: []uint8{0x56, 0x57, 0x58},
+	},
+	{
+		chip: "BME280", driver: "NewBME280Driver", addresses: []int{0x76, 0x77}, hasID: true,
+		idReg: 0xD0, idValues: []uint8{0x60},
+	},
+	{
+		chip: "BMP388", driver: "NewBMP388Driver", addresses: []int{0x76, 0x77}, hasID: true,
+		idReg: 0x00, idValues: []uint8{0x50},
+	},
+	{
+		chip: "MPU6050", driver: "NewMPU6050Driver", addresses: []int{0x68, 0x69}, hasID: true,
+		idReg: 0x75, idValues: []uint8{0x68},
+	},
+	{
+		chip: "ADXL345", driver: "NewADXL345Driver", addresses: []int{0x1D, 0x53}, hasID: true,
+		idReg: 0x00, idValues: []uint8{0xE5},
+	},
+	{
+		chip: "CCS811", driver: "NewCCS811Driver", addresses: []int{0x5A, 0x5B}, hasID: true,
+		idReg: 0x20, idValues: []uint8{0x81},
+	},
+	{
+		chip: "DRV2605L", driver: "NewDRV2605LDriver", addresses: []int{0x5A}, hasID: true, idReg: 0x00, idMask: 0xE0,
+		idValues: []uint8{0x60, 0xE0},
+	},
+	{
+		chip: "HMC5883L", driver: "NewHMC5883LDriver", addresses: []int{0x1E}, hasID: true,
+		idReg: 0x0A, idValues: []uint8{'H'},
+	},
+	{
+		chip: "L3GD20H", driver: "NewL3GD20HDriver", addresses: []int{0x6A, 0x6B}, hasID: true, idReg: 0x0F,
+		idValues: []uint8{0xD4, 0xD7},
+	},
+	{
+		chip: "INA3221", driver: "NewINA3221Driver", addresses: []int{0x40, 0x41, 0x42, 0x43}, hasID: true, idReg: 0xFE,
+		idValues: []uint8{0x54}, // "TI"
+	},
+	{
+		chip: "TH02", driver: "NewTH02Driver", addresses: []int{0x40}, hasID: true,
+		idReg: 0x11, idMask: 0xF0, idValues: []uint8{0x50},
+	},
+	{
+		chip: "TSL2561", driver: "NewTSL2561Driver", addresses: []int{0x29, 0x39, 0x49}, hasID: true,
+		idReg: 0x8A, idMask: 0xF0, idValues: []uint8{0x10, 0x50}, // the register is read with command bit
+	},
+	{chip: "BlinkM", driver: "NewBlinkMDriver", addresses: []int{0x09}},
+	{chip: "GrovePi", driver: "NewGrovePiDriver", addresses: []int{0x04}},
+	{chip: "HMC6352", driver: "NewHMC6352Driver", addresses: []int{0x21}},
+	{chip: "BH1750", driver: "NewBH1750Driver", addresses: []int{0x23, 0x5C}},
+	{
+		chip: "MCP23017", driver: "NewMCP23017Driver",
+		addresses: []int{0x20, 0x21, 0x22, 0x23, 0x24, 0x25, 0x26, 0x27},
+	},
+	{
+		chip: "MCP23017 (Adafruit 1109 LCD)", driver: "NewAdafruit1109Driver",
+		addresses: []int{0x20, 0x21, 0x22, 0x23, 0x24, 0x25, 0x26, 0x27},
+	},
+	{chip: "SSD1306", driver: "NewSSD1306Driver", addresses: []int{0x3C, 0x3D}},
+	{chip: "JHD1313M1", driver: "NewJHD1313M1Driver", addresses: []int{0x3E}},
+	{chip: "PCA9501", driver: "NewPCA9501Driver", addresses: []int{0x3F}},
+	{chip: "SHT2x", driver: "NewSHT2xDriver", addresses: []int{0x40}},
+	{chip: "PCA9685", driver: "NewPCA9685Driver", addresses: []int{0x40, 0x41, 0x42, 0x43, 0x44, 0x45, 0x46, 0x47}},
+	{chip: "PCA9685 (Adafruit 2327 servo HAT)", driver: "NewAdafruit2327Driver", addresses: []int{0x40}},
+	{chip: "SHT3x", driver: "NewSHT3xDriver", addresses: []int{0x44, 0x45}},
+	{chip: "ADS1015", driver: "NewADS1015Driver", addresses: []int{0x48, 0x49, 0x4A, 0x4B}},
+	{chip: "ADS1115", driver: "NewADS1115Driver", addresses: []int{0x48, 0x49, 0x4A, 0x4B}},
+	{
+		chip: "PCF8591", driver: "NewPCF8591Driver",
+		addresses: []int{0x48, 0x49, 0x4A, 0x4B, 0x4C, 0x4D, 0x4E, 0x4F},
+	},
+	{chip: "PCF8591 (YL-40)", driver: "NewYL40Driver", addresses: []int{0x48}},
+	{chip: "MMA7660", driver: "NewMMA7660Driver", addresses: []int{0x4C}},
+	{chip: "PCF8583", driver: "NewPCF8583Driver", addresses: []int{0x50, 0x51}},
+	{chip: "Wiichuck", driver: "NewWiichuckDriver", addresses: []int{0x52}},
+	{chip: "MPL115A2", driver: "NewMPL115A2Driver", addresses: []int{0x60}},
+	{chip: "PCA9685 (Adafruit 2348 motor HAT)", driver: "NewAdafruit2348Driver", addresses: []int{0x60}},
+	{chip: "LIDAR-Lite", driver: "NewLIDARLiteDriver", addresses: []int{0x62}},
+	{chip: "PCA953x", driver: "NewPCA953xDriver", addresses: []int{0x60, 0x61, 0x62, 0x63}},
+}
+
+// ScanBus probes all 7-bit addresses from ScanFirstAddress to ScanLastAddress on the given bus and returns the
+// addresses, which are acknowledged. Like "i2cdetect" the "quick write" is used, but "read byte" for the address
+// ranges of EEPROMs.
+func ScanBus(p Prober, busNr int) ([]int, error) {
+	var found []int
+	for address := ScanFirstAddress; address <= ScanLastAddress; address++ {
+		present, err := p.ProbeI2cAddress(address, busNr)
+		if err != nil {
+			return nil, fmt.Errorf("scan of i2c bus %d failed at address 0x%02X: %w", busNr, address, err)
+		}
+		if present {
+			found = append(found, address)
+		}
+	}
+	return found, nil
+}
+
+// IdentifyDevices scans the given bus and creates a report of likely drivers for each found address. The chips with
+// an identification register (e.g. WHO_AM_I, chip ID) are identified by reading this register. If at least one chip
+// was identified, only the identified chips are reported for this address, otherwise all chips without
+// identification register, which are known to use this address. An address without candidates is reported with an
+// empty list.
+func IdentifyDevices(c ScanConnector, busNr int) ([]ScanReport, error) {
+	addresses, err := ScanBus(c, busNr)
+	if err != nil {
+		return nil, err
+	}
+
+	reports := make([]ScanReport, 0, len(addresses))
+	for _, address := range addresses {
+		candidates, err := identifyAddress(c, address, busNr)
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, ScanReport{Address: address, Candidates: candidates})
+	}
+	return reports, nil
+}
+
+// String returns the report as human readable text, e.g. "0x77: BME280 (NewBME280Driver, identified)".
+func (r ScanReport) String() string {
+	if len(r.Candidates) == 0 {
+		return fmt.Sprintf("0x%02X: unknown device", r.Address)
+	}
+
+	parts := make([]string, len(r.Candidates))
+	for i, c := range r.Candidates {
+		if c.Identified {
+			parts[i] = fmt.Sprintf("%s (%s, identified)", c.Chip, c.Driver)
+		} else {
+			parts[i] = fmt.Sprintf("%s (%s)", c.Chip, c.Driver)
+		}
+	}
+	return fmt.Sprintf("0x%02X: %s", r.Address, strings.Join(parts, ", "))
+}
+
+func identifyAddress(c Connector, address int, busNr int) ([]ScanCandidate, error) {
+	var con Connection
+	var identified, unverified []ScanCandidate
+	for _, id := range scanIdentities {
+		if !id.usesAddress(address) {
+			continue
+		}
+
+		if !id.hasID {
+			unverified = append(unverified, ScanCandidate{Chip: id.chip, Driver: id.driver})
+			continue
+		}
+
+		if con == nil {
+			var err error
+			if con, err = c.GetI2cConnection(address, busNr); err != nil {
+				return nil, err
+			}
+		}
+		// a failed read is not an error, because the device at the address is most likely another chip
+		if val, err := con.ReadByteData(id.idReg); err == nil && id.matches(val) {
+			identified = append(identified, ScanCandidate{Chip: id.chip, Driver: id.driver, Identified: true})
+		}
+	}
+
+	if len(identified) > 0 {
+		return identified, nil
+	}
+	return unverified, nil
+}
+
+func (id scanIdentity) usesAddress(address int) bool {
+	for _, a := range id.addresses {
+		if a == address {
+			return true
+		}
+	}
+	return false
+}
+
+func (id scanIdentity) matches(val uint8) bool {
+	mask := id.idMask
+	if mask == 0 {
+		mask = 0xFF
+	}
+	for _, v := range id.idValues {
+		if val&mask == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,166 @@
+package i2c
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// scanTestConnector simulates a bus with some devices, each device responds with the content of its registers
+type scanTestConnector struct {
+	devices  map[int]map[uint8]uint8
+	probeErr error
+	probed   []int
+}
+
+func (c *scanTestConnector) ProbeI2cAddress(address int, busNr int) (bool, error) {
+	c.probed = append(c.probed, address)
+	if c.probeErr != nil {
+		return false, c.probeErr
+	}
+	_, ok := c.devices[address]
+	return ok, nil
+}
+
+func (c *scanTestConnector) GetI2cConnection(address int, busNr int) (Connection, error) {
+	a := newI2cTestAdaptor()
+	var reg uint8
+	a.i2cWriteImpl = func(b []byte) (int, error) {
+		reg = b[0]
+		return len(b), nil
+	}
+	a.i2cReadImpl = func(b []byte) (int, error) {
+		b[0] = c.devices[address][reg]
+		return len(b), nil
+	}
+	return a, nil
+}
+
+func (c *scanTestConnector) DefaultI2cBus() int { return 1 }
+
+func TestScanBus(t *testing.T) {
+	// arrange
+	c := &scanTestConnector{devices: map[int]map[uint8]uint8{0x03: {}, 0x48: {}, 0x77: {}, 0x78: {}}}
+	// act
+	got, err := ScanBus(c, 1)
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, []int{0x03, 0x48, 0x77}, got)
+	assert.Len(t, c.probed, ScanLastAddress-ScanFirstAddress+1)
+}
+
+func TestScanBusError(t *testing.T) {
+	// arrange
+	c := &scanTestConnector{probeErr: errors.New("probe error")}
+	// act
+	got, err := ScanBus(c, 1)
+	// assert
+	require.EqualError(t, err, "scan of i2c bus 1 failed at address 0x03: probe error")
+	assert.Nil(t, got)
+}
+
+func TestIdentifyDevices(t *testing.T) {
+	tests := map[string]struct {
+		address int
+		regs    map[uint8]uint8
+		want    []ScanCandidate
+	}{
+		"bme280": {
+			address: 0x76,
+			regs:    map[uint8]uint8{0xD0: 0x60},
+			want:    []ScanCandidate{{Chip: "BME280", Driver: "NewBME280Driver", Identified: true}},
+		},
+		"bmp280": {
+			address: 0x77,
+			regs:    map[uint8]uint8{0xD0: 0x58},
+			want:    []ScanCandidate{{Chip: "BMP280", Driver: "NewBMP280Driver", Identified: true}},
+		},
+		"mpu6050": {
+			address: 0x69,
+			regs:    map[uint8]uint8{0x75: 0x68},
+			want:    []ScanCandidate{{Chip: "MPU6050", Driver: "NewMPU6050Driver", Identified: true}},
+		},
+		"adxl345": {
+			address: 0x53,
+			regs:    map[uint8]uint8{0x00: 0xE5},
+			want:    []ScanCandidate{{Chip: "ADXL345", Driver: "NewADXL345Driver", Identified: true}},
+		},
+		"ccs811": {
+			address: 0x5A,
+			regs:    map[uint8]uint8{0x20: 0x81},
+			want:    []ScanCandidate{{Chip: "CCS811", Driver: "NewCCS811Driver", Identified: true}},
+		},
+		"drv2605l_masked": {
+			address: 0x5A,
+			regs:    map[uint8]uint8{0x00: 0xE0 | 0x01},
+			want:    []ScanCandidate{{Chip: "DRV2605L", Driver: "NewDRV2605LDriver", Identified: true}},
+		},
+		"th02_instead_of_not_identifiable": {
+			address: 0x40,
+			regs:    map[uint8]uint8{0x11: 0x50},
+			want:    []ScanCandidate{{Chip: "TH02", Driver: "NewTH02Driver", Identified: true}},
+		},
+		"not_identifiable": {
+			address: 0x5C,
+			want:    []ScanCandidate{{Chip: "BH1750", Driver: "NewBH1750Driver"}},
+		},
+		"id_not_matching_falls_back": {
+			address: 0x40,
+			regs:    map[uint8]uint8{0x11: 0x20, 0xFE: 0x12},
+			want: []ScanCandidate{
+				{Chip: "SHT2x", Driver: "NewSHT2xDriver"},
+				{Chip: "PCA9685", Driver: "NewPCA9685Driver"},
+				{Chip: "PCA9685 (Adafruit 2327 servo HAT)", Driver: "NewAdafruit2327Driver"},
+			},
+		},
+		"unknown": {
+			address: 0x10,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			c := &scanTestConnector{devices: map[int]map[uint8]uint8{tc.address: tc.regs}}
+			// act
+			got, err := IdentifyDevices(c, 1)
+			// assert
+			require.NoError(t, err)
+			require.Len(t, got, 1)
+			assert.Equal(t, tc.address, got[0].Address)
+			assert.Equal(t, tc.want, got[0].Candidates)
+		})
+	}
+}
+
+func TestScanReportString(t *testing.T) {
+	tests := map[string]struct {
+		report ScanReport
+		want   string
+	}{
+		"identified": {
+			report: ScanReport{Address: 0x77, Candidates: []ScanCandidate{
+				{Chip: "BME280", Driver: "NewBME280Driver", Identified: true},
+			}},
+			want: "0x77: BME280 (NewBME280Driver, identified)",
+		},
+		"candidates": {
+			report: ScanReport{Address: 0x48, Candidates: []ScanCandidate{
+				{Chip: "ADS1015", Driver: "NewADS1015Driver"},
+				{Chip: "ADS1115", Driver: "NewADS1115Driver"},
+			}},
+			want: "0x48: ADS1015 (NewADS1015Driver), ADS1115 (NewADS1115Driver)",
+		},
+		"unknown": {
+			report: ScanReport{Address: 0x10},
+			want:   "0x10: unknown device",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// act & assert
+			assert.Equal(t, tc.want, tc.report.String())
+		})
+	}
+}
@@ -86,6 +86,7 @@ func NewMCP23017Driver(c Connector, options ...func(Config)) *MCP23017Driver {
 		Eventer: gobot.NewEventer(),
 	}
 	d.afterStart = d.initialize
+	d.registerNamer = d.getRegName
 
 	for _, option := range options {
 		option(d)
@@ -0,0 +1,11 @@
+/*
+Package onewire provides Gobot drivers for 1-wire devices.
+
+Installing:
+
+	Please refer to the main [README.md](https://github.com/hybridgroup/gobot/blob/release/README.md)
+
+For further information refer to onewire README:
+https://github.com/hybridgroup/gobot/blob/master/drivers/onewire/README.md
+*/
+package onewire // import "gobot.io/x/gobot/v2/drivers/onewire"
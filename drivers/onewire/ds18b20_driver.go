@@ -0,0 +1,334 @@
+package onewire
+
+import (
+	"fmt"
+	"time"
+
+	"gobot.io/x/gobot/v2"
+)
+
+const (
+	ds18b20FamilyCode      = 0x28
+	ds18b20DefaultName     = "DS18B20"
+	ds18b20MinResolution   = 9
+	ds18b20MaxResolution   = 12
+	ds18b20TemperatureFile = "temperature"
+	ds18b20ResolutionFile  = "resolution"
+	ds18b20ConvTimeFile    = "conv_time"
+	ds18b20ExtPowerFile    = "ext_power"
+)
+
+// ds18b20OptionApplier needs to be implemented by each configurable option type
+type ds18b20OptionApplier interface {
+	apply(cfg *ds18b20Configuration)
+}
+
+// ds18b20Configuration contains all changeable attributes of the driver.
+type ds18b20Configuration struct {
+	scaleUnit      func(int) float32
+	resolution     uint8
+	conversionTime uint16
+	readInterval   time.Duration
+}
+
+// ds18b20UnitFahrenheitOption is the type to change the unit of the temperature to Fahrenheit.
+type ds18b20UnitFahrenheitOption struct{}
+
+// ds18b20ResolutionOption is the type to change the sensor resolution.
+type ds18b20ResolutionOption uint8
+
+// ds18b20ConversionTimeOption is the type to change the conversion time.
+type ds18b20ConversionTimeOption uint16
+
+// ds18b20ReadIntervalOption is the type for applying a read interval to activate the cyclic reading.
+type ds18b20ReadIntervalOption time.Duration
+
+// DS18B20Driver is a driver for the DS18B20 1-wire temperature sensor.
+type DS18B20Driver struct {
+	*driver
+	ds18b20Cfg *ds18b20Configuration
+	halt       chan struct{}
+	gobot.Eventer
+	lastValue float32
+}
+
+// NewDS18B20Driver creates a new Gobot Driver for DS18B20 1-wire temperature sensor.
+//
+// Params:
+//
+//	a *Adaptor - the Adaptor to use with this Driver
+//	serialNumber uint64 - the serial number of the device, without the family code
+//
+// Supported options:
+//
+//	"WithName"
+//	"WithFahrenheit"
+//	"WithResolution"
+//	"WithConversionTime"
+//	"WithDS18B20CyclicRead"
+//
+// Adds the following API Commands:
+//
+//	"Temperature" - See DS18B20Driver.Temperature
+func NewDS18B20Driver(a Connector, serialNumber uint64, opts ...interface{}) *DS18B20Driver {
+	d := &DS18B20Driver{
+		driver: newDriver(a, ds18b20DefaultName, ds18b20FamilyCode, serialNumber),
+		ds18b20Cfg: &ds18b20Configuration{
+			scaleUnit: func(input int) float32 { return float32(input) / 1000 }, // 1000:1 in °C
+		},
+		Eventer: gobot.NewEventer(),
+	}
+	d.afterStart = d.initialize
+	d.beforeHalt = d.shutdown
+	d.AddEvent(Value)
+	d.AddEvent(Error)
+
+	for _, opt := range opts {
+		switch o := opt.(type) {
+		case optionApplier:
+			o.apply(d.driverCfg)
+		case ds18b20OptionApplier:
+			o.apply(d.ds18b20Cfg)
+		default:
+			panic(fmt.Sprintf("'%s' can not be applied on '%s'", opt, d.driverCfg.name))
+		}
+	}
+
+	d.AddCommand("Temperature", func(params map[string]interface{}) interface{} {
+		val, err := d.Temperature()
+		return map[string]interface{}{"val": val, "err": err}
+	})
+
+	return d
+}
+
+// NewDS18B20Drivers creates a new driver for each DS18B20 device, which is found on the 1-wire buses. The given
+// options are applied to each driver, a name given by WithName() is extended by the ID of the device, so each
+// driver has a unique name, e.g. "room-28-000000001234". The adaptor needs to implement the onewire.DeviceFinder
+// interface.
+func NewDS18B20Drivers(a Connector, opts ...interface{}) ([]*DS18B20Driver, error) {
+	finder, ok := a.(DeviceFinder)
+	if !ok {
+		return nil, fmt.Errorf("the adaptor is not able to find 1-wire devices")
+	}
+
+	serialNumbers, err := finder.FindOneWireDevices(ds18b20FamilyCode)
+	if err != nil {
+		return nil, err
+	}
+
+	var named bool
+	for _, opt := range opts {
+		if _, ok := opt.(nameOption); ok {
+			named = true
+		}
+	}
+
+	drivers := make([]*DS18B20Driver, len(serialNumbers))
+	for i, serialNumber := range serialNumbers {
+		drivers[i] = NewDS18B20Driver(a, serialNumber, opts...)
+		if named {
+			drivers[i].SetName(drivers[i].Name() + "-" + drivers[i].ID())
+		}
+	}
+	return drivers, nil
+}
+
+// WithFahrenheit substitute the default °C scaler by a scaler for °F
+func WithFahrenheit() ds18b20OptionApplier {
+	return ds18b20UnitFahrenheitOption{}
+}
+
+// WithResolution substitute the default 12 bit resolution by the given one (9, 10, 11). The device will adjust
+// the conversion time automatically. Each smaller resolution will decrease the conversion time by a factor of 2.
+func WithResolution(resolution uint8) ds18b20OptionApplier {
+	return ds18b20ResolutionOption(resolution)
+}
+
+// WithConversionTime substitute the default conversion time (depends on resolution) by the given value in ms.
+// Please note, that a value smaller than the default will lead to wrong values.
+func WithConversionTime(conversionTime uint16) ds18b20OptionApplier {
+	return ds18b20ConversionTimeOption(conversionTime)
+}
+
+// WithDS18B20CyclicRead add a asynchronous cyclic reading functionality to the sensor with the given read interval.
+func WithDS18B20CyclicRead(interval time.Duration) ds18b20OptionApplier {
+	return ds18b20ReadIntervalOption(interval)
+}
+
+// ID returns the device id in the form "family code"-"serial number".
+func (d *DS18B20Driver) ID() string {
+	return fmt.Sprintf("%02x-%012x", d.driverCfg.familyCode, d.driverCfg.serialNumber)
+}
+
+// Temperature returns the current temperature, in celsius degrees, if the default unit is used.
+func (d *DS18B20Driver) Temperature() (float32, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	val, err := d.connection.ReadInteger(ds18b20TemperatureFile)
+	if err != nil {
+		return 0, err
+	}
+
+	d.lastValue = d.ds18b20Cfg.scaleUnit(val)
+	return d.lastValue, nil
+}
+
+// Value returns the last read temperature.
+func (d *DS18B20Driver) Value() float32 {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.lastValue
+}
+
+// Resolution returns the current resolution in bits (9, 10, 11, 12)
+func (d *DS18B20Driver) Resolution() (uint8, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	val, err := d.connection.ReadInteger(ds18b20ResolutionFile)
+	if err != nil {
+		return 0, err
+	}
+
+	if val < ds18b20MinResolution || val > ds18b20MaxResolution {
+		return 0, fmt.Errorf("the read value '%d' is out of range (%d..%d)", val, ds18b20MinResolution,
+			ds18b20MaxResolution)
+	}
+
+	return uint8(val), nil
+}
+
+// IsExternalPowered returns whether the device is external or parasitic powered
+func (d *DS18B20Driver) IsExternalPowered() (bool, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	val, err := d.connection.ReadInteger(ds18b20ExtPowerFile)
+	if err != nil {
+		return false, err
+	}
+
+	return val == 1, nil
+}
+
+// ConversionTime returns the conversion time in ms (93..750)
+func (d *DS18B20Driver) ConversionTime() (uint16, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	val, err := d.connection.ReadInteger(ds18b20ConvTimeFile)
+	if err != nil {
+		return 0, err
+	}
+
+	return uint16(val), nil
+}
+
+// initialize writes the configured resolution and conversion time to the device and if the cyclic reading is
+// active, reads the sensor at the given interval.
+// Emits the Events:
+//
+//	Value float32 - Event is emitted on change and represents the current temperature.
+//	Error error - Event is emitted on error reading from the sensor.
+func (d *DS18B20Driver) initialize() error {
+	if d.ds18b20Cfg.resolution != 0 {
+		if d.ds18b20Cfg.resolution < ds18b20MinResolution || d.ds18b20Cfg.resolution > ds18b20MaxResolution {
+			return fmt.Errorf("resolution '%d' is not supported by '%s', use 9, 10, 11 or 12",
+				d.ds18b20Cfg.resolution, d.driverCfg.name)
+		}
+		if err := d.connection.WriteInteger(ds18b20ResolutionFile, int(d.ds18b20Cfg.resolution)); err != nil {
+			return err
+		}
+	}
+
+	if d.ds18b20Cfg.conversionTime != 0 {
+		if err := d.connection.WriteInteger(ds18b20ConvTimeFile, int(d.ds18b20Cfg.conversionTime)); err != nil {
+			return err
+		}
+	}
+
+	if d.ds18b20Cfg.readInterval == 0 {
+		// cyclic reading deactivated
+		return nil
+	}
+
+	halt := make(chan struct{})
+	d.halt = halt
+
+	go func() {
+		timer := time.NewTimer(d.ds18b20Cfg.readInterval)
+		timer.Stop()
+
+		var oldValue float32
+		for {
+			// the first read is done immediately
+			value, err := d.Temperature()
+			if err != nil {
+				d.Publish(d.Event(Error), err)
+			} else if value != oldValue {
+				d.Publish(d.Event(Value), value)
+				oldValue = value
+			}
+
+			timer.Reset(d.ds18b20Cfg.readInterval) // ensure that after each read is a wait, independent of duration
+			select {
+			case <-timer.C:
+			case <-halt:
+				timer.Stop()
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+// shutdown stops the cyclic reading and sets the conversion time back to default, if changed.
+func (d *DS18B20Driver) shutdown() error {
+	if d.halt != nil {
+		close(d.halt) // broadcast halt, also to the test
+		d.halt = nil
+	}
+
+	if d.ds18b20Cfg.conversionTime != 0 && d.connection != nil {
+		// a value of 0 restores the default conversion time of the kernel driver
+		return d.connection.WriteInteger(ds18b20ConvTimeFile, 0)
+	}
+
+	return nil
+}
+
+func (o ds18b20UnitFahrenheitOption) String() string {
+	return "unit option (°F) for DS18B20"
+}
+
+func (o ds18b20ResolutionOption) String() string {
+	return "resolution option for DS18B20"
+}
+
+func (o ds18b20ConversionTimeOption) String() string {
+	return "conversion time option for DS18B20"
+}
+
+func (o ds18b20ReadIntervalOption) String() string {
+	return "read interval option for DS18B20"
+}
+
+func (o ds18b20UnitFahrenheitOption) apply(cfg *ds18b20Configuration) {
+	cfg.scaleUnit = func(input int) float32 { return float32(input)/1000*9.0/5.0 + 32.0 } // 1000:1 in °C => °F
+}
+
+func (o ds18b20ResolutionOption) apply(cfg *ds18b20Configuration) {
+	cfg.resolution = uint8(o)
+}
+
+func (o ds18b20ConversionTimeOption) apply(cfg *ds18b20Configuration) {
+	cfg.conversionTime = uint16(o)
+}
+
+func (o ds18b20ReadIntervalOption) apply(cfg *ds18b20Configuration) {
+	cfg.readInterval = time.Duration(o)
+}
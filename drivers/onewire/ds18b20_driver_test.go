@@ -0,0 +1,264 @@
+package onewire
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+)
+
+var _ gobot.Driver = (*DS18B20Driver)(nil)
+
+var ds18b20TestFiles = []string{
+	onewireTestDevicePath + "temperature",
+	onewireTestDevicePath + "resolution",
+	onewireTestDevicePath + "conv_time",
+	onewireTestDevicePath + "ext_power",
+}
+
+func initTestDS18B20DriverWithStubbedAdaptor(opts ...interface{}) (*DS18B20Driver, *onewireTestAdaptor) {
+	a := newOneWireTestAdaptor(ds18b20TestFiles)
+	d := NewDS18B20Driver(a, 0x75a1b2c, opts...)
+	if err := d.Start(); err != nil {
+		panic(err)
+	}
+	return d, a
+}
+
+func TestNewDS18B20Driver(t *testing.T) {
+	// arrange
+	a := newOneWireTestAdaptor(nil)
+	// act
+	d := NewDS18B20Driver(a, 0x75a1b2c)
+	// assert
+	assert.IsType(t, &DS18B20Driver{}, d)
+	assert.NotNil(t, d.driver)
+	assert.True(t, len(d.Name()) > 7 && d.Name()[:7] == "DS18B20")
+	assert.Equal(t, "28-0000075a1b2c", d.ID())
+	assert.NotNil(t, d.ds18b20Cfg.scaleUnit)
+	assert.Equal(t, uint8(0), d.ds18b20Cfg.resolution)
+	assert.Equal(t, uint16(0), d.ds18b20Cfg.conversionTime)
+	assert.Equal(t, time.Duration(0), d.ds18b20Cfg.readInterval)
+	assert.NotNil(t, d.Eventer)
+}
+
+func TestNewDS18B20Driver_options(t *testing.T) {
+	// arrange
+	a := newOneWireTestAdaptor(nil)
+	// act
+	d := NewDS18B20Driver(a, 0x1234, WithName("outdoor"), WithResolution(10), WithConversionTime(200),
+		WithDS18B20CyclicRead(2*time.Second), WithFahrenheit())
+	// assert
+	assert.Equal(t, "outdoor", d.Name())
+	assert.Equal(t, uint8(10), d.ds18b20Cfg.resolution)
+	assert.Equal(t, uint16(200), d.ds18b20Cfg.conversionTime)
+	assert.Equal(t, 2*time.Second, d.ds18b20Cfg.readInterval)
+	assert.InDelta(t, float32(32), d.ds18b20Cfg.scaleUnit(0), 0.0)
+	assert.InDelta(t, float32(212), d.ds18b20Cfg.scaleUnit(100000), 0.0)
+	assert.PanicsWithValue(t, "'unknown' can not be applied on 'outdoor'", func() {
+		_ = NewDS18B20Driver(a, 0x1234, WithName("outdoor"), "unknown")
+	})
+}
+
+func TestNewDS18B20Drivers(t *testing.T) {
+	// arrange
+	a := newOneWireTestAdaptor([]string{
+		"/sys/bus/w1/devices/28-000000001234/temperature",
+		"/sys/bus/w1/devices/28-000000005678/temperature",
+		"/sys/bus/w1/devices/10-000000009abc/temperature",
+	})
+	// act
+	got, err := NewDS18B20Drivers(a, WithResolution(9))
+	// assert
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, "28-000000001234", got[0].ID())
+	assert.Equal(t, "28-000000005678", got[1].ID())
+	assert.Equal(t, uint8(9), got[1].ds18b20Cfg.resolution)
+	assert.NotEqual(t, got[0].Name(), got[1].Name())
+}
+
+func TestNewDS18B20Drivers_name(t *testing.T) {
+	// arrange
+	a := newOneWireTestAdaptor([]string{
+		"/sys/bus/w1/devices/28-000000001234/temperature",
+		"/sys/bus/w1/devices/28-000000005678/temperature",
+	})
+	// act
+	got, err := NewDS18B20Drivers(a, WithName("room"))
+	// assert
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, "room-28-000000001234", got[0].Name())
+	assert.Equal(t, "room-28-000000005678", got[1].Name())
+}
+
+func TestDS18B20Start(t *testing.T) {
+	tests := map[string]struct {
+		opts           []interface{}
+		wantResolution string
+		wantConvTime   string
+		wantErr        string
+	}{
+		"no_settings": {},
+		"with_settings": {
+			opts:           []interface{}{WithResolution(11), WithConversionTime(400)},
+			wantResolution: "11",
+			wantConvTime:   "400",
+		},
+		"error_resolution": {
+			opts:    []interface{}{WithName("sensor"), WithResolution(8)},
+			wantErr: "resolution '8' is not supported by 'sensor', use 9, 10, 11 or 12",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := newOneWireTestAdaptor(ds18b20TestFiles)
+			d := NewDS18B20Driver(a, 0x75a1b2c, tc.opts...)
+			// act
+			err := d.Start()
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantResolution, a.fs.Files[onewireTestDevicePath+"resolution"].Contents)
+			assert.Equal(t, tc.wantConvTime, a.fs.Files[onewireTestDevicePath+"conv_time"].Contents)
+		})
+	}
+}
+
+func TestDS18B20Halt(t *testing.T) {
+	// arrange
+	d, a := initTestDS18B20DriverWithStubbedAdaptor(WithConversionTime(300))
+	// act
+	err := d.Halt()
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, "0", a.fs.Files[onewireTestDevicePath+"conv_time"].Contents)
+}
+
+func TestDS18B20Temperature(t *testing.T) {
+	tests := map[string]struct {
+		opts    []interface{}
+		content string
+		simErr  bool
+		want    float32
+		wantErr string
+	}{
+		"celsius": {
+			content: "23187\n",
+			want:    23.187,
+		},
+		"celsius_negative": {
+			content: "-10500\n",
+			want:    -10.5,
+		},
+		"fahrenheit": {
+			opts:    []interface{}{WithFahrenheit()},
+			content: "25000\n",
+			want:    77,
+		},
+		"error_read": {
+			simErr:  true,
+			wantErr: "read error",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, a := initTestDS18B20DriverWithStubbedAdaptor(tc.opts...)
+			a.fs.Files[onewireTestDevicePath+"temperature"].Contents = tc.content
+			a.fs.WithReadError = tc.simErr
+			// act
+			got, err := d.Temperature()
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.InDelta(t, tc.want, got, 0.0001)
+			assert.InDelta(t, tc.want, d.Value(), 0.0001)
+		})
+	}
+}
+
+func TestDS18B20Resolution(t *testing.T) {
+	// arrange
+	d, a := initTestDS18B20DriverWithStubbedAdaptor()
+	a.fs.Files[onewireTestDevicePath+"resolution"].Contents = "10\n"
+	// act
+	got, err := d.Resolution()
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, uint8(10), got)
+	// arrange for error
+	a.fs.Files[onewireTestDevicePath+"resolution"].Contents = "13\n"
+	// act
+	_, err = d.Resolution()
+	// assert
+	require.EqualError(t, err, "the read value '13' is out of range (9..12)")
+}
+
+func TestDS18B20ConversionTimeAndPower(t *testing.T) {
+	// arrange
+	d, a := initTestDS18B20DriverWithStubbedAdaptor()
+	a.fs.Files[onewireTestDevicePath+"conv_time"].Contents = "750\n"
+	a.fs.Files[onewireTestDevicePath+"ext_power"].Contents = "1\n"
+	// act
+	convTime, errC := d.ConversionTime()
+	extPower, errP := d.IsExternalPowered()
+	// assert
+	require.NoError(t, errC)
+	require.NoError(t, errP)
+	assert.Equal(t, uint16(750), convTime)
+	assert.True(t, extPower)
+}
+
+func TestDS18B20CyclicRead(t *testing.T) {
+	// arrange
+	a := newOneWireTestAdaptor(ds18b20TestFiles)
+	a.fs.Files[onewireTestDevicePath+"temperature"].Contents = "21500\n"
+	d := NewDS18B20Driver(a, 0x75a1b2c, WithDS18B20CyclicRead(10*time.Millisecond))
+	sem := make(chan float32, 1)
+	_ = d.Once(d.Event(Value), func(data interface{}) {
+		sem <- data.(float32) //nolint:forcetypeassert // ok here
+	})
+	// act
+	require.NoError(t, d.Start())
+	// assert
+	select {
+	case got := <-sem:
+		assert.InDelta(t, float32(21.5), got, 0.0001)
+	case <-time.After(time.Second):
+		t.Errorf("DS18B20 event \"value\" was not published")
+	}
+	require.NoError(t, d.Halt())
+}
+
+func TestDS18B20CyclicRead_error(t *testing.T) {
+	// arrange
+	a := newOneWireTestAdaptor(ds18b20TestFiles)
+	a.fs.WithReadError = true
+	d := NewDS18B20Driver(a, 0x75a1b2c, WithDS18B20CyclicRead(10*time.Millisecond))
+	sem := make(chan error, 1)
+	_ = d.Once(d.Event(Error), func(data interface{}) {
+		sem <- data.(error) //nolint:forcetypeassert // ok here
+	})
+	// act
+	require.NoError(t, d.Start())
+	// assert
+	select {
+	case err := <-sem:
+		require.EqualError(t, err, "read error")
+	case <-time.After(time.Second):
+		t.Errorf("DS18B20 event \"error\" was not published")
+	}
+	require.NoError(t, d.Halt())
+}
@@ -0,0 +1,61 @@
+package onewire
+
+import (
+	"fmt"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/system"
+)
+
+// make sure that this adaptor fulfills all the required interfaces
+var (
+	_ Connector        = (*onewireTestAdaptor)(nil)
+	_ DeviceFinder     = (*onewireTestAdaptor)(nil)
+	_ gobot.Connection = (*onewireTestAdaptor)(nil)
+)
+
+const onewireTestDevicePath = "/sys/bus/w1/devices/28-0000075a1b2c/"
+
+type onewireTestAdaptor struct {
+	sys              *system.Accesser
+	fs               *system.MockFilesystem
+	connectErr       bool
+	connections      map[string]Connection
+	lastSerialNumber uint64
+}
+
+func newOneWireTestAdaptor(files []string) *onewireTestAdaptor {
+	sys := system.NewAccesser()
+	fs := sys.UseMockFilesystem(files)
+	return &onewireTestAdaptor{sys: sys, fs: fs, connections: make(map[string]Connection)}
+}
+
+// onewire.Connector interfaces
+func (a *onewireTestAdaptor) GetOneWireConnection(familyCode byte, serialNumber uint64) (Connection, error) {
+	if a.connectErr {
+		return nil, fmt.Errorf("invalid 1-wire connection in helper")
+	}
+	a.lastSerialNumber = serialNumber
+	id := fmt.Sprintf("%d_%d", familyCode, serialNumber)
+	if con, ok := a.connections[id]; ok {
+		return con, nil
+	}
+	dev, err := a.sys.NewOneWireDevice(familyCode, serialNumber)
+	if err != nil {
+		return nil, err
+	}
+	con := NewConnection(dev)
+	a.connections[id] = con
+	return con, nil
+}
+
+// onewire.DeviceFinder interfaces
+func (a *onewireTestAdaptor) FindOneWireDevices(familyCode byte) ([]uint64, error) {
+	return a.sys.FindOneWireDevices(familyCode)
+}
+
+// gobot.Connection interfaces
+func (a *onewireTestAdaptor) Connect() error  { return nil }
+func (a *onewireTestAdaptor) Finalize() error { return nil }
+func (a *onewireTestAdaptor) Name() string    { return "board name" }
+func (a *onewireTestAdaptor) SetName(string)  {}
@@ -0,0 +1,66 @@
+package onewire
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/system"
+)
+
+var _ gobot.OneWireOperations = (*onewireConnection)(nil)
+
+func initTestConnectionWithMockedSystem(files []string) (Connection, *system.MockFilesystem) {
+	a := system.NewAccesser()
+	fs := a.UseMockFilesystem(files)
+	dev, err := a.NewOneWireDevice(0x28, 0x75a1b2c)
+	if err != nil {
+		panic(err)
+	}
+	return NewConnection(dev), fs
+}
+
+func TestOneWireConnectionID(t *testing.T) {
+	// arrange
+	c, _ := initTestConnectionWithMockedSystem([]string{onewireTestDevicePath + "temperature"})
+	// act & assert
+	assert.Equal(t, "28-0000075a1b2c", c.ID())
+}
+
+func TestOneWireConnectionReadWriteData(t *testing.T) {
+	// arrange
+	const file = onewireTestDevicePath + "w1_slave"
+	c, fs := initTestConnectionWithMockedSystem([]string{file})
+	data := make([]byte, 3)
+	// act
+	errW := c.WriteData("w1_slave", []byte("abc"))
+	errR := c.ReadData("w1_slave", data)
+	// assert
+	require.NoError(t, errW)
+	require.NoError(t, errR)
+	assert.Equal(t, "abc", fs.Files[file].Contents)
+	assert.Equal(t, []byte("abc"), data)
+}
+
+func TestOneWireConnectionReadWriteInteger(t *testing.T) {
+	// arrange
+	const file = onewireTestDevicePath + "resolution"
+	c, fs := initTestConnectionWithMockedSystem([]string{file})
+	// act
+	errW := c.WriteInteger("resolution", 11)
+	got, errR := c.ReadInteger("resolution")
+	// assert
+	require.NoError(t, errW)
+	require.NoError(t, errR)
+	assert.Equal(t, "11", fs.Files[file].Contents)
+	assert.Equal(t, 11, got)
+}
+
+func TestOneWireConnectionClose(t *testing.T) {
+	// arrange
+	c, _ := initTestConnectionWithMockedSystem([]string{onewireTestDevicePath + "temperature"})
+	// act & assert
+	require.NoError(t, c.Close())
+}
@@ -0,0 +1,84 @@
+package onewire
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+)
+
+var _ gobot.Driver = (*driver)(nil)
+
+func initTestDriver() *driver {
+	a := newOneWireTestAdaptor([]string{onewireTestDevicePath + "temperature"})
+	return newDriver(a, "OneWire_TEST", 0x28, 0x75a1b2c)
+}
+
+func TestNewDriver(t *testing.T) {
+	// arrange
+	a := newOneWireTestAdaptor(nil)
+	// act
+	d := newDriver(a, "OneWire_TEST", 0x28, 0x75a1b2c)
+	// assert
+	assert.IsType(t, &driver{}, d)
+	assert.Contains(t, d.driverCfg.name, "OneWire_TEST")
+	assert.Equal(t, byte(0x28), d.driverCfg.familyCode)
+	assert.Equal(t, uint64(0x75a1b2c), d.driverCfg.serialNumber)
+	assert.Equal(t, a, d.connector)
+	require.NoError(t, d.afterStart())
+	require.NoError(t, d.beforeHalt())
+	assert.NotNil(t, d.Commander)
+	assert.NotNil(t, d.mutex)
+}
+
+func TestNewDriver_options(t *testing.T) {
+	// This is a general test, that options are applied by using the WithName() option.
+	// All other configuration options can also be tested by With..(val).apply(cfg).
+	// arrange
+	a := newOneWireTestAdaptor(nil)
+	// act
+	d := newDriver(a, "OneWire_TEST", 0x28, 0x75a1b2c, WithName("my driver name"))
+	// assert
+	assert.Equal(t, "my driver name", d.Name())
+	assert.PanicsWithValue(t, "'unknown option' can not be applied on 'my driver name'", func() {
+		_ = newDriver(a, "OneWire_TEST", 0x28, 0x75a1b2c, WithName("my driver name"), "unknown option")
+	})
+}
+
+func TestSetName(t *testing.T) {
+	// arrange
+	d := initTestDriver()
+	// act
+	d.SetName("TESTME")
+	// assert
+	assert.Equal(t, "TESTME", d.Name())
+}
+
+func TestConnection(t *testing.T) {
+	// arrange
+	d := initTestDriver()
+	// act, assert
+	assert.NotNil(t, d.Connection())
+}
+
+func TestStart(t *testing.T) {
+	// arrange
+	d := initTestDriver()
+	// act, assert
+	require.NoError(t, d.Start())
+	assert.Equal(t, "28-0000075a1b2c", d.connection.ID())
+	// arrange for error
+	a := newOneWireTestAdaptor(nil)
+	d = newDriver(a, "OneWire_TEST", 0x28, 0x75a1b2c)
+	// act, assert
+	require.ErrorContains(t, d.Start(), "1-wire device '28-0000075a1b2c' not found")
+}
+
+func TestHalt(t *testing.T) {
+	// arrange
+	d := initTestDriver()
+	// act, assert
+	require.NoError(t, d.Halt())
+}
@@ -35,6 +35,29 @@ func (c *spiConnection) ReadCommandData(command []byte, data []byte) error {
 	return c.txRxAndCheckReadLength(command, data)
 }
 
+// Transfer sends/receives all given transfers as one message. Implements gobot.SpiOperations.
+// The chip select is hold active for the whole message, except CSChange is set for a transfer. Speed and bits per
+// word can be changed for each transfer, if supported by the underlying SPI device.
+func (c *spiConnection) Transfer(xfers []gobot.SpiXfer) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	t, ok := c.spiSystem.(gobot.SpiSystemTransferer)
+	if !ok {
+		return fmt.Errorf("the SPI system device does not support messages of multiple transfers")
+	}
+	for i, xfer := range xfers {
+		if xfer.Tx == nil && xfer.Rx == nil {
+			return fmt.Errorf("neither tx nor rx given for transfer %d", i)
+		}
+		if xfer.Tx != nil && xfer.Rx != nil && len(xfer.Tx) != len(xfer.Rx) {
+			return fmt.Errorf("length of tx (%d) differ to length of rx (%d) for transfer %d", len(xfer.Tx),
+				len(xfer.Rx), i)
+		}
+	}
+	return t.Transfer(xfers)
+}
+
 // Close connection to underlying SPI device.
 func (c *spiConnection) Close() error {
 	c.mutex.Lock()
@@ -125,3 +125,47 @@ func TestWriteBytes(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, want, sysdev.Written())
 }
+
+func TestTransfer(t *testing.T) {
+	tests := map[string]struct {
+		xfers       []gobot.SpiXfer
+		simRead     []byte
+		wantWritten []byte
+		wantRx      []byte
+		wantErr     string
+	}{
+		"write_and_read": {
+			xfers:       []gobot.SpiXfer{{Tx: []byte{0x01, 0x02}}, {Rx: make([]byte, 2), SpeedHz: 1000}},
+			simRead:     []byte{0x11, 0x12},
+			wantWritten: []byte{0x01, 0x02},
+			wantRx:      []byte{0x11, 0x12},
+		},
+		"error_no_data": {
+			xfers:   []gobot.SpiXfer{{Tx: []byte{0x01}}, {Delay: 1}},
+			wantErr: "neither tx nor rx given for transfer 1",
+		},
+		"error_length": {
+			xfers:   []gobot.SpiXfer{{Tx: []byte{0x01}, Rx: []byte{}}},
+			wantErr: "length of tx (1) differ to length of rx (0) for transfer 0",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			c, sysdev := initTestConnectionWithMockedSystem()
+			sysdev.SetSimRead(tc.simRead)
+			// act
+			err := c.Transfer(tc.xfers)
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				assert.Empty(t, sysdev.Transfers())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantWritten, sysdev.Written())
+			assert.Equal(t, tc.wantRx, tc.xfers[1].Rx)
+			assert.Equal(t, tc.xfers, sysdev.Transfers())
+		})
+	}
+}
@@ -291,30 +291,13 @@ func (s *SSD1306Driver) SetBufferAndDisplay(buf []byte) error {
 
 // SetContrast sets the display contrast (0-255).
 func (s *SSD1306Driver) SetContrast(contrast byte) error {
-	if err := s.command(ssd1306SetContrast); err != nil {
-		return err
-	}
-	return s.command(contrast)
+	return s.commands(ssd1306SetContrast, contrast)
 }
 
 // Display sends the memory buffer to the display.
 func (s *SSD1306Driver) Display() error {
-	if err := s.command(ssd1306ColumnAddr); err != nil {
-		return err
-	}
-	if err := s.command(0); err != nil {
-		return err
-	}
-	if err := s.command(uint8(s.DisplayWidth) - 1); err != nil {
-		return err
-	}
-	if err := s.command(ssd1306PageAddr); err != nil {
-		return err
-	}
-	if err := s.command(0); err != nil {
-		return err
-	}
-	if err := s.command(uint8(s.pageSize) - 1); err != nil {
+	if err := s.commands(ssd1306ColumnAddr, 0, uint8(s.DisplayWidth)-1, ssd1306PageAddr, 0,
+		uint8(s.pageSize)-1); err != nil {
 		return err
 	}
 	if err := s.dcDriver.DigitalWrite(1); err != nil {
@@ -345,132 +328,63 @@ func (s *SSD1306Driver) ShowImage(img image.Image) error {
 
 // command sends a unique command
 func (s *SSD1306Driver) command(b byte) error {
+	return s.commands(b)
+}
+
+// commands sends the given command bytes as one SPI message, the D/C pin is set to command mode before and the
+// chip select is hold active until the last byte was transferred
+func (s *SSD1306Driver) commands(cmds ...byte) error {
 	if err := s.dcDriver.DigitalWrite(0); err != nil {
 		return err
 	}
-	return s.connection.WriteByte(b)
+	xfers := make([]gobot.SpiXfer, len(cmds))
+	for i, cmd := range cmds {
+		xfers[i] = gobot.SpiXfer{Tx: []byte{cmd}}
+	}
+	return s.connection.Transfer(xfers)
 }
 
 // initialize configures the ssd1306 based on the options passed in when the driver was created
 func (s *SSD1306Driver) initialize() error {
-	if err := s.command(ssd1306SetDisplayOff); err != nil {
-		return err
-	}
-	if err := s.command(ssd1306SetDisplayClock); err != nil {
-		return err
-	}
+	clockDivider := byte(0x80)
 	if s.DisplayHeight == 16 {
-		if err := s.command(0x60); err != nil {
-			return err
-		}
-	} else {
-		if err := s.command(0x80); err != nil {
-			return err
-		}
-	}
-	if err := s.command(ssd1306SetMultiplexRatio); err != nil {
-		return err
-	}
-	if err := s.command(uint8(s.DisplayHeight) - 1); err != nil {
-		return err
-	}
-	if err := s.command(ssd1306SetDisplayOffset); err != nil {
-		return err
-	}
-	if err := s.command(0x0); err != nil {
-		return err
-	}
-	if err := s.command(ssd1306SetStartLine); err != nil {
-		return err
-	}
-	if err := s.command(0x0); err != nil {
-		return err
-	}
-	if err := s.command(ssd1306ChargePumpSetting); err != nil {
-		return err
+		clockDivider = 0x60
 	}
+	chargePump := byte(0x14)
+	precharge := byte(0xF1)
 	if s.ExternalVcc {
-		if err := s.command(0x10); err != nil {
-			return err
-		}
-	} else {
-		if err := s.command(0x14); err != nil {
-			return err
-		}
-	}
-	if err := s.command(ssd1306SetMemoryAddressingMode); err != nil {
-		return err
-	}
-	if err := s.command(0x00); err != nil {
-		return err
-	}
-	if err := s.command(ssd1306SetSegmentRemap0); err != nil {
-		return err
-	}
-	if err := s.command(0x01); err != nil {
-		return err
-	}
-	if err := s.command(ssd1306ComScanInc); err != nil {
-		return err
-	}
-	if err := s.command(ssd1306SetComPins); err != nil {
-		return err
-	}
-	if s.DisplayHeight == 64 {
-		if err := s.command(0x12); err != nil {
-			return err
-		}
-	} else {
-		if err := s.command(0x02); err != nil {
-			return err
-		}
-	}
-	if err := s.command(ssd1306SetContrast); err != nil {
-		return err
+		chargePump = 0x10
+		precharge = 0x22
 	}
+	comPins := byte(0x02)
+	contrast := byte(0x8F)
 	if s.DisplayHeight == 64 {
+		comPins = 0x12
+		contrast = 0xCF
 		if s.ExternalVcc {
-			if err := s.command(0x9F); err != nil {
-				return err
-			}
-		} else {
-			if err := s.command(0xCF); err != nil {
-				return err
-			}
+			contrast = 0x9F
 		}
-	} else {
-		if err := s.command(0x8F); err != nil {
-			return err
-		}
-	}
-	if err := s.command(ssd1306SetPrechargePeriod); err != nil {
-		return err
-	}
-	if s.ExternalVcc {
-		if err := s.command(0x22); err != nil {
-			return err
-		}
-	} else {
-		if err := s.command(0xF1); err != nil {
-			return err
-		}
-	}
-	if err := s.command(ssd1306SetVComDeselectLevel); err != nil {
-		return err
 	}
-	if err := s.command(0x40); err != nil {
-		return err
-	}
-	if err := s.command(ssd1306DisplayOnResumeToRAM); err != nil {
-		return err
-	}
-	if err := s.command(ssd1306SetDisplayNormal); err != nil {
-		return err
-	}
-	if err := s.command(ssd1306DeactivateScroll); err != nil {
-		return err
-	}
-	return s.command(ssd1306SetDisplayOn)
+
+	return s.commands(
+		ssd1306SetDisplayOff,
+		ssd1306SetDisplayClock, clockDivider,
+		ssd1306SetMultiplexRatio, uint8(s.DisplayHeight)-1,
+		ssd1306SetDisplayOffset, 0x0,
+		ssd1306SetStartLine, 0x0,
+		ssd1306ChargePumpSetting, chargePump,
+		ssd1306SetMemoryAddressingMode, 0x00,
+		ssd1306SetSegmentRemap0, 0x01,
+		ssd1306ComScanInc,
+		ssd1306SetComPins, comPins,
+		ssd1306SetContrast, contrast,
+		ssd1306SetPrechargePeriod, precharge,
+		ssd1306SetVComDeselectLevel, 0x40,
+		ssd1306DisplayOnResumeToRAM,
+		ssd1306SetDisplayNormal,
+		ssd1306DeactivateScroll,
+		ssd1306SetDisplayOn,
+	)
 }
 
 func (s *SSD1306Driver) shutdown() error {
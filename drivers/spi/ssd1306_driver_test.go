@@ -5,6 +5,7 @@ import (
 	"sync"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"gobot.io/x/gobot/v2"
@@ -35,6 +36,21 @@ func TestDriverSSDDisplay(t *testing.T) {
 	require.NoError(t, d.Display())
 }
 
+func TestSSD1306DriverSetContrast(t *testing.T) {
+	// arrange
+	a := newGpioTestAdaptor()
+	d := NewSSD1306Driver(a)
+	require.NoError(t, d.Start())
+	sa := a.Connector.(*spiTestAdaptor) //nolint:forcetypeassert // ok here
+	sa.spi.Reset()
+	// act
+	err := d.SetContrast(0x7F)
+	// assert
+	require.NoError(t, err)
+	want := []gobot.SpiXfer{{Tx: []byte{ssd1306SetContrast}}, {Tx: []byte{0x7F}}}
+	assert.Equal(t, want, sa.spi.Transfers())
+}
+
 func TestSSD1306DriverShowImage(t *testing.T) {
 	d := initTestSSDDriver()
 	_ = d.Start()
@@ -0,0 +1,12 @@
+/*
+Package watchdog provides a Gobot driver for the hardware watchdog of the board, which resets the system, if the robot
+stops working. The watchdog is only kept alive, while all registered devices report healthy.
+
+Installing:
+
+	Please refer to the main [README.md](https://github.com/hybridgroup/gobot/blob/release/README.md)
+
+For further information refer to watchdog README:
+https://github.com/hybridgroup/gobot/blob/master/drivers/watchdog/README.md
+*/
+package watchdog // import "gobot.io/x/gobot/v2/drivers/watchdog"
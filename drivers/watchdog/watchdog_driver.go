@@ -0,0 +1,546 @@
+package watchdog
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"gobot.io/x/gobot/v2"
+)
+
+const (
+	// Unhealthy event
+	Unhealthy = "unhealthy"
+	// Error event
+	Error = "error"
+)
+
+// Flags of the boot status, which describe the reason of the last reboot, see "linux/watchdog.h".
+const (
+	BootStatusOverheat   = 0x0001 // WDIOF_OVERHEAT, reset due to CPU overheat
+	BootStatusFanFault   = 0x0002 // WDIOF_FANFAULT, fan failed
+	BootStatusExtern1    = 0x0004 // WDIOF_EXTERN1, external relay 1
+	BootStatusExtern2    = 0x0008 // WDIOF_EXTERN2, external relay 2
+	BootStatusPowerUnder = 0x0010 // WDIOF_POWERUNDER, power bad/power fault
+	BootStatusCardReset  = 0x0020 // WDIOF_CARDRESET, card previously reset the CPU
+	BootStatusPowerOver  = 0x0040 // WDIOF_POWEROVER, power over voltage
+)
+
+const defaultDevicePath = "/dev/watchdog"
+
+// ErrUnhealthy is matched by the errors of Pet(), which are caused by a failed health check, see errors.Is().
+var ErrUnhealthy = errors.New("health check failed")
+
+// healthError wraps the error of a failed health check, the message is kept
+type healthError struct {
+	err error
+}
+
+// Opener is the interface for adaptors, which provide the access to the hardware watchdog of the board.
+type Opener interface {
+	// OpenWatchdog opens the watchdog with the given character device, e.g. "/dev/watchdog", which starts the watchdog.
+	OpenWatchdog(devicePath string) (gobot.WatchdogSystemDevicer, error)
+}
+
+// HealthReporter is the interface for drivers and adaptors, which are able to report their health.
+type HealthReporter interface {
+	// Healthy returns nil, if the device is working properly, otherwise the reason.
+	Healthy() error
+}
+
+// optionApplier needs to be implemented by each configurable option type
+type optionApplier interface {
+	apply(cfg *configuration)
+}
+
+// configuration contains all changeable attributes of the driver.
+type configuration struct {
+	name         string
+	devicePath   string
+	timeout      time.Duration
+	interval     time.Duration
+	healthChecks []func() error
+	devices      []gobot.Device
+}
+
+// nameOption is the type for applying another name to the configuration
+type nameOption string
+
+// devicePathOption is the type for applying another character device to the configuration
+type devicePathOption string
+
+// timeoutOption is the type for applying the timeout of the watchdog to the configuration
+type timeoutOption time.Duration
+
+// keepaliveIntervalOption is the type for applying an automatic keepalive to the configuration
+type keepaliveIntervalOption time.Duration
+
+// healthCheckOption is the type for adding health checks to the configuration
+type healthCheckOption []func() error
+
+// devicesOption is the type for adding devices to the configuration, which are monitored by health checks
+type devicesOption []gobot.Device
+
+// deviceMonitor provides the health check of a device by its health report, its error events and the state of its
+// connection
+type deviceMonitor struct {
+	device   gobot.Device
+	reporter HealthReporter
+	eventer  gobot.Eventer
+	mutex    sync.Mutex
+	lastErr  interface{} // data of the last error event since the last consuming check
+	halt     chan struct{}
+}
+
+// WatchdogDriver represents the hardware watchdog of the board, which resets the system, if it is not kept alive
+// within the timeout. The watchdog is kept alive by Pet() only while all health checks are passed, so a hanging work
+// loop or a failed device leads to a reset of the system.
+type WatchdogDriver struct {
+	cfg        *configuration
+	connection Opener
+	mutex      sync.Mutex
+	device     gobot.WatchdogSystemDevicer
+	timeout    time.Duration
+	halt       chan struct{}
+	monitors   []*deviceMonitor
+	gobot.Commander
+	gobot.Eventer
+}
+
+// NewWatchdogDriver returns a new driver for the hardware watchdog, by default "/dev/watchdog" is used. The watchdog
+// is started on Start() and stopped by the magic close on Halt().
+//
+// Supported options:
+//
+//	"WithName"
+//	"WithDevicePath"
+//	"WithTimeout"
+//	"WithKeepaliveInterval"
+//	"WithHealthCheck"
+//	"WithDevices"
+//
+// Adds the following API Commands:
+//
+//	"Pet" - See WatchdogDriver.Pet
+//	"TimeLeft" - See WatchdogDriver.TimeLeft
+//	"BootStatus" - See WatchdogDriver.BootStatus
+func NewWatchdogDriver(a Opener, opts ...optionApplier) *WatchdogDriver {
+	d := &WatchdogDriver{
+		cfg:        &configuration{name: gobot.DefaultName("Watchdog"), devicePath: defaultDevicePath},
+		connection: a,
+		Commander:  gobot.NewCommander(),
+		Eventer:    gobot.NewEventer(),
+	}
+	d.AddEvent(Unhealthy)
+	d.AddEvent(Error)
+
+	for _, o := range opts {
+		o.apply(d.cfg)
+	}
+
+	d.AddCommand("Pet", func(params map[string]interface{}) interface{} {
+		return d.Pet()
+	})
+
+	d.AddCommand("TimeLeft", func(params map[string]interface{}) interface{} {
+		val, err := d.TimeLeft()
+		return map[string]interface{}{"val": val.Seconds(), "err": err}
+	})
+
+	d.AddCommand("BootStatus", func(params map[string]interface{}) interface{} {
+		val, err := d.BootStatus()
+		return map[string]interface{}{"val": val, "err": err}
+	})
+
+	return d
+}
+
+// WithName is used to replace the default name of the driver.
+func WithName(name string) optionApplier {
+	return nameOption(name)
+}
+
+// WithDevicePath is used to replace the default character device "/dev/watchdog", e.g. by "/dev/watchdog1".
+func WithDevicePath(devicePath string) optionApplier {
+	return devicePathOption(devicePath)
+}
+
+// WithTimeout sets the timeout of the watchdog on start, the resolution is one second. By default the timeout of the
+// kernel driver is used.
+func WithTimeout(timeout time.Duration) optionApplier {
+	return timeoutOption(timeout)
+}
+
+// WithKeepaliveInterval activates the call of Pet() at the given interval, which needs to be shorter than the
+// timeout. By default Pet() needs to be called, e.g. by the work loop of the robot.
+func WithKeepaliveInterval(interval time.Duration) optionApplier {
+	return keepaliveIntervalOption(interval)
+}
+
+// WithHealthCheck adds the given check, which needs to return nil to keep the watchdog alive.
+func WithHealthCheck(check func() error) optionApplier {
+	return healthCheckOption{check}
+}
+
+// WithDevices adds a health check for each given device. A device is unhealthy, if its Healthy() returns an error
+// (interface HealthReporter), if it has published an "error" event since the last check or if its connection reports
+// to be not connected by IsConnected(). Start() fails for devices, which neither implement HealthReporter nor
+// gobot.Eventer, because they can not be monitored.
+func WithDevices(devices ...gobot.Device) optionApplier {
+	return devicesOption(devices)
+}
+
+// Name returns the name of the driver.
+func (d *WatchdogDriver) Name() string {
+	return d.cfg.name
+}
+
+// SetName sets the name of the driver.
+func (d *WatchdogDriver) SetName(name string) {
+	d.cfg.name = name
+}
+
+// Connection returns the connection of the driver.
+func (d *WatchdogDriver) Connection() gobot.Connection {
+	if conn, ok := d.connection.(gobot.Connection); ok {
+		return conn
+	}
+
+	log.Printf("%s has no gobot connection\n", d.cfg.name)
+	return nil
+}
+
+// DevicePath returns the character device of the watchdog.
+func (d *WatchdogDriver) DevicePath() string {
+	return d.cfg.devicePath
+}
+
+// Start opens the device, which starts the watchdog, and applies the timeout. If configured, the automatic keepalive
+// is started.
+// Emits the Events:
+//
+//	Unhealthy error - Event is emitted, if a health check fails on Pet().
+//	Error error - Event is emitted on error while the automatic keepalive.
+func (d *WatchdogDriver) Start() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	monitors, err := d.monitorDevices()
+	if err != nil {
+		return err
+	}
+
+	device, err := d.connection.OpenWatchdog(d.cfg.devicePath)
+	if err != nil {
+		stopMonitors(monitors)
+		return err
+	}
+
+	timeout, err := d.initialize(device)
+	if err != nil {
+		// stop the watchdog, because nobody will keep it alive
+		_ = device.MagicClose()
+		stopMonitors(monitors)
+		return err
+	}
+
+	d.device = device
+	d.timeout = timeout
+	d.monitors = monitors
+
+	if d.cfg.interval > 0 {
+		d.halt = make(chan struct{})
+		go d.keepalive(d.halt)
+	}
+	return nil
+}
+
+// Halt stops the automatic keepalive and the watchdog by the magic close.
+func (d *WatchdogDriver) Halt() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.halt != nil {
+		close(d.halt)
+		d.halt = nil
+	}
+	stopMonitors(d.monitors)
+	d.monitors = nil
+	if d.device == nil {
+		return nil
+	}
+
+	err := d.device.MagicClose()
+	d.device = nil
+	return err
+}
+
+// Timeout returns the timeout of the watchdog, which is available after Start().
+func (d *WatchdogDriver) Timeout() time.Duration {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.timeout
+}
+
+// Healthy runs all health checks and returns the first error. The error events of the monitored devices are kept
+// until the next call of Pet().
+func (d *WatchdogDriver) Healthy() error {
+	return d.health(false)
+}
+
+// Pet keeps the watchdog alive, if all health checks are passed. Otherwise the Unhealthy event is published and the
+// error is returned, which matches ErrUnhealthy. The error events of the monitored devices are reported only once.
+func (d *WatchdogDriver) Pet() error {
+	if err := d.health(true); err != nil {
+		d.Publish(d.Event(Unhealthy), err)
+		return healthError{err: err}
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.device == nil {
+		return fmt.Errorf("watchdog '%s' not started", d.cfg.devicePath)
+	}
+	return d.device.Keepalive()
+}
+
+// health runs all health checks and returns the first error, the error events of the monitored devices are
+// consumed, if requested
+func (d *WatchdogDriver) health(consume bool) error {
+	for _, check := range d.cfg.healthChecks {
+		if err := check(); err != nil {
+			return err
+		}
+	}
+
+	d.mutex.Lock()
+	monitors := d.monitors
+	d.mutex.Unlock()
+
+	var firstErr error
+	for _, m := range monitors {
+		// all monitors are checked, so the errors of the others are consumed also
+		if err := m.check(consume); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// TimeLeft reads the time left before the system is reset, not supported by all kernel drivers.
+func (d *WatchdogDriver) TimeLeft() (time.Duration, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.device == nil {
+		return 0, fmt.Errorf("watchdog '%s' not started", d.cfg.devicePath)
+	}
+	return d.device.TimeLeft()
+}
+
+// BootStatus reads the flags for the reason of the last reboot, e.g. BootStatusCardReset, if the last reboot was
+// caused by the watchdog.
+func (d *WatchdogDriver) BootStatus() (uint32, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.device == nil {
+		return 0, fmt.Errorf("watchdog '%s' not started", d.cfg.devicePath)
+	}
+	return d.device.BootStatus()
+}
+
+// initialize applies the configured timeout and keeps the watchdog alive
+func (d *WatchdogDriver) initialize(device gobot.WatchdogSystemDevicer) (time.Duration, error) {
+	var timeout time.Duration
+	var err error
+	if d.cfg.timeout > 0 {
+		timeout, err = device.SetTimeout(d.cfg.timeout)
+	} else {
+		timeout, err = device.Timeout()
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	if d.cfg.interval >= timeout {
+		return 0, fmt.Errorf("keepalive interval %s is not shorter than the timeout %s of watchdog '%s'",
+			d.cfg.interval, timeout, d.cfg.devicePath)
+	}
+
+	return timeout, device.Keepalive()
+}
+
+// monitorDevices creates and starts the monitors of the configured devices
+func (d *WatchdogDriver) monitorDevices() ([]*deviceMonitor, error) {
+	var monitors []*deviceMonitor
+	for _, device := range d.cfg.devices {
+		m := &deviceMonitor{device: device}
+		m.reporter, _ = device.(HealthReporter)
+		m.eventer, _ = device.(gobot.Eventer)
+		if m.reporter == nil && m.eventer == nil {
+			stopMonitors(monitors)
+			return nil, fmt.Errorf("device '%s' can not be monitored by watchdog '%s', because it neither reports its "+
+				"health nor publishes events", device.Name(), d.cfg.devicePath)
+		}
+		if m.eventer != nil {
+			m.halt = make(chan struct{})
+			go m.watch(m.eventer.Subscribe(), m.halt)
+		}
+		monitors = append(monitors, m)
+	}
+	return monitors, nil
+}
+
+func stopMonitors(monitors []*deviceMonitor) {
+	for _, m := range monitors {
+		if m.halt != nil {
+			close(m.halt)
+		}
+	}
+}
+
+// check returns an error, if the device is not healthy or has published an error since the last consuming check
+func (m *deviceMonitor) check(consume bool) error {
+	m.mutex.Lock()
+	lastErr := m.lastErr
+	if consume {
+		m.lastErr = nil
+	}
+	m.mutex.Unlock()
+
+	if c, ok := m.device.Connection().(interface{ IsConnected() bool }); ok && !c.IsConnected() {
+		return fmt.Errorf("connection of device '%s' is not connected", m.device.Name())
+	}
+
+	if m.reporter != nil {
+		if err := m.reporter.Healthy(); err != nil {
+			return err
+		}
+	}
+
+	if lastErr != nil {
+		return fmt.Errorf("device '%s' reported an error: %v", m.device.Name(), lastErr)
+	}
+
+	return nil
+}
+
+// watch stores the data of the last error event of the device
+func (m *deviceMonitor) watch(events chan *gobot.Event, halt chan struct{}) {
+	for {
+		select {
+		case evt := <-events:
+			if evt.Name == Error {
+				m.mutex.Lock()
+				m.lastErr = evt.Data
+				m.mutex.Unlock()
+			}
+		case <-halt:
+			// the events needs to be consumed until unsubscribed, otherwise the publisher of the device is blocked
+			unsubscribed := make(chan struct{})
+			go func() {
+				m.eventer.Unsubscribe(events)
+				close(unsubscribed)
+			}()
+			for {
+				select {
+				case <-events:
+				case <-unsubscribed:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (d *WatchdogDriver) keepalive(halt chan struct{}) {
+	ticker := time.NewTicker(d.cfg.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-halt:
+			return
+		}
+
+		err := d.Pet()
+
+		select {
+		case <-halt:
+			// the device was closed while waiting for the mutex
+			return
+		default:
+		}
+
+		if err != nil && !errors.Is(err, ErrUnhealthy) {
+			// errors of health checks are already published by the Unhealthy event
+			d.Publish(d.Event(Error), err)
+		}
+	}
+}
+
+func (e healthError) Error() string {
+	return e.err.Error()
+}
+
+func (e healthError) Unwrap() error {
+	return e.err
+}
+
+func (e healthError) Is(target error) bool {
+	return errors.Is(target, ErrUnhealthy)
+}
+
+func (o nameOption) String() string {
+	return "name option for watchdog"
+}
+
+func (o devicePathOption) String() string {
+	return "device path option for watchdog"
+}
+
+func (o timeoutOption) String() string {
+	return "timeout option for watchdog"
+}
+
+func (o keepaliveIntervalOption) String() string {
+	return "keepalive interval option for watchdog"
+}
+
+func (o healthCheckOption) String() string {
+	return "health check option for watchdog"
+}
+
+func (o devicesOption) String() string {
+	return "devices option for watchdog"
+}
+
+func (o nameOption) apply(cfg *configuration) {
+	cfg.name = string(o)
+}
+
+func (o devicePathOption) apply(cfg *configuration) {
+	cfg.devicePath = string(o)
+}
+
+func (o timeoutOption) apply(cfg *configuration) {
+	cfg.timeout = time.Duration(o)
+}
+
+func (o keepaliveIntervalOption) apply(cfg *configuration) {
+	cfg.interval = time.Duration(o)
+}
+
+func (o healthCheckOption) apply(cfg *configuration) {
+	cfg.healthChecks = append(cfg.healthChecks, o...)
+}
+
+func (o devicesOption) apply(cfg *configuration) {
+	cfg.devices = append(cfg.devices, o...)
+}
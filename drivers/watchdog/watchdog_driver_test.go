@@ -0,0 +1,354 @@
+//nolint:forcetypeassert // ok here
+package watchdog
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+)
+
+// make sure that this driver fulfills all the required interfaces
+var (
+	_ gobot.Driver   = (*WatchdogDriver)(nil)
+	_ HealthReporter = (*WatchdogDriver)(nil)
+)
+
+type watchdogTestDevice struct {
+	mtx          sync.Mutex
+	timeout      time.Duration
+	keepalives   int
+	magicClosed  bool
+	closed       bool
+	keepaliveErr error
+	timeoutErr   error
+}
+
+func (d *watchdogTestDevice) Keepalive() error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	if d.keepaliveErr != nil {
+		return d.keepaliveErr
+	}
+	d.keepalives++
+	return nil
+}
+
+func (d *watchdogTestDevice) SetTimeout(timeout time.Duration) (time.Duration, error) {
+	if d.timeoutErr != nil {
+		return 0, d.timeoutErr
+	}
+	d.timeout = (timeout + time.Second - 1).Truncate(time.Second)
+	return d.timeout, nil
+}
+
+func (d *watchdogTestDevice) Timeout() (time.Duration, error) {
+	return d.timeout, d.timeoutErr
+}
+
+func (d *watchdogTestDevice) TimeLeft() (time.Duration, error) {
+	return d.timeout - time.Second, nil
+}
+
+func (d *watchdogTestDevice) BootStatus() (uint32, error) {
+	return BootStatusCardReset, nil
+}
+
+func (d *watchdogTestDevice) MagicClose() error {
+	d.magicClosed = true
+	return nil
+}
+
+func (d *watchdogTestDevice) Close() error {
+	d.closed = true
+	return nil
+}
+
+func (d *watchdogTestDevice) keepaliveCount() int {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	return d.keepalives
+}
+
+type watchdogTestOpener struct {
+	device  *watchdogTestDevice
+	path    string
+	openErr error
+}
+
+func (a *watchdogTestOpener) OpenWatchdog(devicePath string) (gobot.WatchdogSystemDevicer, error) {
+	a.path = devicePath
+	if a.openErr != nil {
+		return nil, a.openErr
+	}
+	return a.device, nil
+}
+
+// plainTestDevice is a device, which can not be monitored
+type plainTestDevice struct{}
+
+func (d *plainTestDevice) Name() string                 { return "plain" }
+func (d *plainTestDevice) SetName(string)               {}
+func (d *plainTestDevice) Start() error                 { return nil }
+func (d *plainTestDevice) Halt() error                  { return nil }
+func (d *plainTestDevice) Connection() gobot.Connection { return nil }
+
+type healthTestDevice struct {
+	plainTestDevice
+	err error
+}
+
+func (d *healthTestDevice) Name() string   { return "health" }
+func (d *healthTestDevice) Healthy() error { return d.err }
+
+// eventTestDevice is a device without health report, which publishes errors
+type eventTestDevice struct {
+	plainTestDevice
+	gobot.Eventer
+	conn *connectionTestAdaptor
+}
+
+func (d *eventTestDevice) Name() string                 { return "event" }
+func (d *eventTestDevice) Connection() gobot.Connection { return d.conn }
+
+type connectionTestAdaptor struct {
+	mtx       sync.Mutex
+	connected bool
+}
+
+func (a *connectionTestAdaptor) Name() string    { return "connection" }
+func (a *connectionTestAdaptor) SetName(string)  {}
+func (a *connectionTestAdaptor) Connect() error  { return nil }
+func (a *connectionTestAdaptor) Finalize() error { return nil }
+func (a *connectionTestAdaptor) IsConnected() bool {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	return a.connected
+}
+
+func initTestWatchdogDriver(opts ...optionApplier) (*WatchdogDriver, *watchdogTestOpener) {
+	a := &watchdogTestOpener{device: &watchdogTestDevice{timeout: 16 * time.Second}}
+	return NewWatchdogDriver(a, opts...), a
+}
+
+func TestNewWatchdogDriver(t *testing.T) {
+	// act
+	d, _ := initTestWatchdogDriver()
+	// assert
+	assert.IsType(t, &WatchdogDriver{}, d)
+	assert.True(t, strings.HasPrefix(d.Name(), "Watchdog"))
+	assert.Equal(t, "/dev/watchdog", d.DevicePath())
+	assert.Nil(t, d.Connection())
+	assert.NotNil(t, d.Command("Pet"))
+	assert.NotNil(t, d.Command("TimeLeft"))
+	assert.NotNil(t, d.Command("BootStatus"))
+}
+
+func TestWatchdogDriverOptions(t *testing.T) {
+	// act
+	d, _ := initTestWatchdogDriver(WithName("wd"), WithDevicePath("/dev/watchdog1"), WithTimeout(10*time.Second),
+		WithKeepaliveInterval(time.Second), WithHealthCheck(func() error { return nil }),
+		WithDevices(&healthTestDevice{}, &healthTestDevice{}))
+	// assert
+	assert.Equal(t, "wd", d.Name())
+	assert.Equal(t, "/dev/watchdog1", d.DevicePath())
+	assert.Equal(t, 10*time.Second, d.cfg.timeout)
+	assert.Equal(t, time.Second, d.cfg.interval)
+	assert.Len(t, d.cfg.healthChecks, 1)
+	assert.Len(t, d.cfg.devices, 2)
+}
+
+func TestWatchdogDriverStart(t *testing.T) {
+	tests := map[string]struct {
+		opts        []optionApplier
+		openErr     error
+		timeoutErr  error
+		wantTimeout time.Duration
+		wantErr     string
+	}{
+		"default_timeout": {wantTimeout: 16 * time.Second},
+		"set_timeout":     {opts: []optionApplier{WithTimeout(4500 * time.Millisecond)}, wantTimeout: 5 * time.Second},
+		"error_open":      {openErr: errors.New("open error"), wantErr: "open error"},
+		"error_timeout":   {timeoutErr: errors.New("timeout error"), wantErr: "timeout error"},
+		"error_interval": {
+			opts:    []optionApplier{WithTimeout(2 * time.Second), WithKeepaliveInterval(2 * time.Second)},
+			wantErr: "keepalive interval 2s is not shorter than the timeout 2s of watchdog '/dev/watchdog'",
+		},
+		"error_device": {
+			opts: []optionApplier{WithDevices(&healthTestDevice{}, &plainTestDevice{})},
+			wantErr: "device 'plain' can not be monitored by watchdog '/dev/watchdog', because it neither reports " +
+				"its health nor publishes events",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, a := initTestWatchdogDriver(tc.opts...)
+			a.openErr = tc.openErr
+			a.device.timeoutErr = tc.timeoutErr
+			// act
+			err := d.Start()
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				// the watchdog must be stopped, if it was opened
+				assert.Equal(t, a.path != "" && tc.openErr == nil, a.device.magicClosed)
+				return
+			}
+			assert.Equal(t, "/dev/watchdog", a.path)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantTimeout, d.Timeout())
+			assert.Equal(t, 1, a.device.keepalives)
+		})
+	}
+}
+
+func TestWatchdogDriverHalt(t *testing.T) {
+	// arrange
+	d, a := initTestWatchdogDriver()
+	require.NoError(t, d.Start())
+	// act
+	err := d.Halt()
+	// assert
+	require.NoError(t, err)
+	assert.True(t, a.device.magicClosed)
+	assert.False(t, a.device.closed)
+	require.NoError(t, d.Halt())
+	require.EqualError(t, d.Pet(), "watchdog '/dev/watchdog' not started")
+}
+
+func TestWatchdogDriverPet(t *testing.T) {
+	// arrange
+	healthy := &healthTestDevice{}
+	d, a := initTestWatchdogDriver(WithDevices(healthy, &healthTestDevice{}))
+	require.NoError(t, d.Start())
+	sem := make(chan error, 1)
+	_ = d.Once(d.Event(Unhealthy), func(data interface{}) {
+		sem <- data.(error)
+	})
+	// act & assert
+	require.NoError(t, d.Pet())
+	assert.Equal(t, 2, a.device.keepalives)
+	// arrange
+	healthy.err = errors.New("motor stalled")
+	// act
+	err := d.Pet()
+	// assert
+	require.EqualError(t, err, "motor stalled")
+	require.ErrorIs(t, err, ErrUnhealthy)
+	assert.Equal(t, 2, a.device.keepalives)
+	select {
+	case err := <-sem:
+		require.EqualError(t, err, "motor stalled")
+	case <-time.After(time.Second):
+		t.Errorf("unhealthy event was not published")
+	}
+}
+
+func TestWatchdogDriverPet_deviceMonitor(t *testing.T) {
+	// arrange
+	device := &eventTestDevice{Eventer: gobot.NewEventer(), conn: &connectionTestAdaptor{connected: true}}
+	device.AddEvent("error")
+	d, a := initTestWatchdogDriver(WithDevices(device))
+	require.NoError(t, d.Start())
+	// act & assert
+	require.NoError(t, d.Pet())
+	// arrange
+	device.Publish("error", errors.New("read error"))
+	// act & assert: the error is kept by Healthy() and reported once by Pet()
+	assert.Eventually(t, func() bool { return d.Healthy() != nil }, time.Second, 5*time.Millisecond)
+	require.EqualError(t, d.Healthy(), "device 'event' reported an error: read error")
+	require.EqualError(t, d.Pet(), "device 'event' reported an error: read error")
+	require.NoError(t, d.Healthy())
+	require.NoError(t, d.Pet())
+	// arrange
+	device.conn.mtx.Lock()
+	device.conn.connected = false
+	device.conn.mtx.Unlock()
+	// act & assert
+	require.EqualError(t, d.Pet(), "connection of device 'event' is not connected")
+	assert.Equal(t, 3, a.device.keepaliveCount())
+	// the publisher must not be blocked after halt
+	require.NoError(t, d.Halt())
+	for i := 0; i < 20; i++ {
+		device.Publish("error", errors.New("read error"))
+	}
+}
+
+func TestWatchdogDriverKeepaliveInterval(t *testing.T) {
+	// arrange
+	d, a := initTestWatchdogDriver(WithKeepaliveInterval(5 * time.Millisecond))
+	require.NoError(t, d.Start())
+	a.device.mtx.Lock()
+	a.device.keepaliveErr = errors.New("keepalive error")
+	a.device.mtx.Unlock()
+	sem := make(chan error, 1)
+	_ = d.Once(d.Event(Error), func(data interface{}) {
+		sem <- data.(error)
+	})
+	// act & assert
+	select {
+	case err := <-sem:
+		require.EqualError(t, err, "keepalive error")
+	case <-time.After(time.Second):
+		t.Errorf("error event was not published")
+	}
+	// arrange
+	a.device.mtx.Lock()
+	a.device.keepaliveErr = nil
+	a.device.mtx.Unlock()
+	// act & assert
+	assert.Eventually(t, func() bool { return a.device.keepaliveCount() > 2 }, time.Second, 5*time.Millisecond)
+	require.NoError(t, d.Halt())
+	assert.True(t, a.device.magicClosed)
+}
+
+func TestWatchdogDriverKeepaliveInterval_unhealthy(t *testing.T) {
+	// arrange
+	healthy := &healthTestDevice{err: errors.New("motor stalled")}
+	d, a := initTestWatchdogDriver(WithKeepaliveInterval(5*time.Millisecond), WithDevices(healthy))
+	unhealthy := make(chan error, 1)
+	_ = d.Once(d.Event(Unhealthy), func(data interface{}) {
+		unhealthy <- data.(error)
+	})
+	errs := make(chan error, 1)
+	_ = d.Once(d.Event(Error), func(data interface{}) {
+		errs <- data.(error)
+	})
+	// act
+	require.NoError(t, d.Start())
+	// assert: the failed health check is published only by the Unhealthy event
+	select {
+	case err := <-unhealthy:
+		require.EqualError(t, err, "motor stalled")
+	case <-time.After(time.Second):
+		t.Errorf("unhealthy event was not published")
+	}
+	select {
+	case err := <-errs:
+		t.Errorf("unexpected error event: %v", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+	require.NoError(t, d.Halt())
+	assert.Equal(t, 1, a.device.keepaliveCount())
+}
+
+func TestWatchdogDriverCommands(t *testing.T) {
+	// arrange
+	d, _ := initTestWatchdogDriver()
+	require.NoError(t, d.Start())
+	// act & assert
+	assert.Nil(t, d.Command("Pet")(nil))
+	got := d.Command("TimeLeft")(nil).(map[string]interface{})
+	assert.InDelta(t, 15.0, got["val"], 0.0)
+	assert.Nil(t, got["err"])
+	got = d.Command("BootStatus")(nil).(map[string]interface{})
+	assert.Equal(t, uint32(BootStatusCardReset), got["val"])
+	assert.Nil(t, got["err"])
+}
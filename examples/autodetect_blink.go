@@ -0,0 +1,47 @@
+//go:build example
+// +build example
+
+//
+// Do not build by default.
+
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/drivers/gpio"
+	"gobot.io/x/gobot/v2/platforms/autodetect"
+)
+
+// Blinks a LED at header pin 7 of any supported board, e.g. a Raspberry Pi, Tinker Board or Jetson Nano, so the same
+// binary (for the same architecture) can be used for the whole fleet.
+func main() {
+	board, err := autodetect.Detect()
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("detected %s (model: '%s', variant: '%s')\n", board.Name, board.Model, board.Variant)
+
+	r := board.NewAdaptor()
+	led := gpio.NewLedDriver(r, "7")
+
+	work := func() {
+		gobot.Every(1*time.Second, func() {
+			if err := led.Toggle(); err != nil {
+				fmt.Println(err)
+			}
+		})
+	}
+
+	robot := gobot.NewRobot("blinkBot",
+		[]gobot.Connection{r},
+		[]gobot.Device{led},
+		work,
+	)
+
+	if err := robot.Start(); err != nil {
+		panic(err)
+	}
+}
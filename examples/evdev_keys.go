@@ -0,0 +1,57 @@
+//go:build example
+// +build example
+
+//
+// Do not build by default.
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/platforms/evdev"
+)
+
+// Prints all events of keys, rotary encoders and touch panels of the given input device.
+//
+// usage: go run examples/evdev_keys.go "gpio-keys"
+func main() {
+	if len(os.Args) < 2 {
+		devices, err := evdev.FindDevices()
+		if err != nil {
+			panic(err)
+		}
+		fmt.Println("please give the name of one of the devices:")
+		for _, d := range devices {
+			fmt.Printf("%s: '%s' (%s)\n", d.Path, d.Name, d.Phys)
+		}
+		return
+	}
+
+	adaptor := evdev.NewAdaptor(evdev.WithDeviceName(os.Args[1]), evdev.WithGrab())
+	input := evdev.NewDriver(adaptor)
+
+	work := func() {
+		for _, name := range []string{evdev.Key, evdev.Rel, evdev.Abs, evdev.Switch} {
+			_ = input.On(name, func(data interface{}) {
+				evt := data.(gobot.InputEvent)
+				fmt.Println(evdev.TypeName(evt.Type), evdev.CodeName(evt.Type, evt.Code), evt.Value)
+			})
+		}
+		_ = input.On(evdev.Error, func(data interface{}) {
+			fmt.Println("error:", data)
+		})
+	}
+
+	robot := gobot.NewRobot("evdevBot",
+		[]gobot.Connection{adaptor},
+		[]gobot.Device{input},
+		work,
+	)
+
+	if err := robot.Start(); err != nil {
+		panic(err)
+	}
+}
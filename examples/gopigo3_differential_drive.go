@@ -0,0 +1,79 @@
+//go:build example
+// +build example
+
+//
+// Do not build by default.
+
+package main
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/drivers/drive"
+	"gobot.io/x/gobot/v2/platforms/dexter/gopigo3"
+	"gobot.io/x/gobot/v2/platforms/raspi"
+)
+
+const (
+	wheelDiameter = 0.0665 // [m]
+	wheelBase     = 0.117  // [m]
+	maxDps        = 1000   // [°/s]
+)
+
+func main() {
+	raspiAdaptor := raspi.NewAdaptor()
+	gpg3 := gopigo3.NewDriver(raspiAdaptor)
+
+	wheelCircumference := math.Pi * wheelDiameter
+	base := drive.NewDifferentialDrive(
+		gopigo3.DriveMotor(gpg3, gopigo3.MOTOR_LEFT, maxDps),
+		gopigo3.DriveMotor(gpg3, gopigo3.MOTOR_RIGHT, maxDps),
+		wheelBase, maxDps/360.0*wheelCircumference,
+		drive.WithAccelerationLimits(0.5, 4),
+		drive.WithCommandTimeout(500*time.Millisecond),
+		drive.WithEncoders(gopigo3.DriveEncoder(gpg3, gopigo3.MOTOR_LEFT),
+			gopigo3.DriveEncoder(gpg3, gopigo3.MOTOR_RIGHT), 360/wheelCircumference),
+	)
+
+	work := func() {
+		_ = base.On(drive.OdometryChanged, func(data interface{}) {
+			o := data.(drive.Odometry)
+			fmt.Printf("x: %.3fm, y: %.3fm, heading: %.1f°\n", o.X, o.Y, o.Heading*180/math.Pi)
+		})
+		_ = base.On(drive.Timeout, func(data interface{}) {
+			fmt.Println("stopped, no command since", data)
+		})
+
+		// the commands need to be repeated within the timeout, otherwise the robot stops
+		start := time.Now()
+		gobot.Every(100*time.Millisecond, func() {
+			var err error
+			switch elapsed := time.Since(start); {
+			case elapsed < 3*time.Second:
+				err = base.Drive(0.2, 0)
+			case elapsed < 6*time.Second:
+				err = base.Arc(0.15, 0.3)
+			case elapsed < 8*time.Second:
+				err = base.Drive(0, -1.5)
+			default:
+				err = base.Stop()
+			}
+			if err != nil {
+				fmt.Println(err)
+			}
+		})
+	}
+
+	robot := gobot.NewRobot("gopigo3differentialDrive",
+		[]gobot.Connection{raspiAdaptor},
+		[]gobot.Device{gpg3, base},
+		work,
+	)
+
+	if err := robot.Start(); err != nil {
+		panic(err)
+	}
+}
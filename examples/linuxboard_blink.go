@@ -0,0 +1,55 @@
+//go:build example
+// +build example
+
+//
+// Do not build by default.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/drivers/gpio"
+	"gobot.io/x/gobot/v2/platforms/linuxboard"
+)
+
+// Blinks a LED at header pin 7 of the board. The board is given by the ID of a shipped description, e.g.
+// "tinker-board", or by the path of an own description file, e.g. "myboard.yaml".
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Println("usage: linuxboard_blink <board ID or description file>")
+		fmt.Println("known boards:", linuxboard.Boards())
+		os.Exit(1)
+	}
+
+	board, err := linuxboard.NewAdaptorForBoard(os.Args[1])
+	if err != nil {
+		board, err = linuxboard.NewAdaptorFromFile(os.Args[1])
+	}
+	if err != nil {
+		panic(err)
+	}
+
+	led := gpio.NewLedDriver(board, "7")
+
+	work := func() {
+		gobot.Every(1*time.Second, func() {
+			if err := led.Toggle(); err != nil {
+				fmt.Println(err)
+			}
+		})
+	}
+
+	robot := gobot.NewRobot("blinkBot",
+		[]gobot.Connection{board},
+		[]gobot.Device{led},
+		work,
+	)
+
+	if err := robot.Start(); err != nil {
+		panic(err)
+	}
+}
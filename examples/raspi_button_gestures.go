@@ -0,0 +1,63 @@
+//go:build example
+// +build example
+
+//
+// Do not build by default.
+
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/drivers/gpio"
+	"gobot.io/x/gobot/v2/platforms/adaptors"
+	"gobot.io/x/gobot/v2/platforms/raspi"
+)
+
+// Wiring
+// PWR  Raspi: 1 (+3.3V, VCC), 6 (GND)
+// GPIO Raspi: header pin 11 is connected over a push button to VCC, use a pull down resistor
+func main() {
+	// the gpiod access is needed for edge events, with sysfs the button is polled
+	r := raspi.NewAdaptor(adaptors.WithGpiodAccess())
+	button := gpio.NewButtonDriver(r, "11",
+		gpio.WithButtonDebounce(20*time.Millisecond),
+		gpio.WithButtonMultiClick(300*time.Millisecond),
+		gpio.WithButtonLongPress(time.Second),
+		gpio.WithButtonHoldRepeat(200*time.Millisecond),
+	)
+
+	work := func() {
+		_ = button.On(gpio.ButtonClick, func(data interface{}) {
+			fmt.Println("click")
+		})
+
+		_ = button.On(gpio.ButtonDoubleClick, func(data interface{}) {
+			fmt.Println("double click")
+		})
+
+		_ = button.On(gpio.ButtonMultiClick, func(data interface{}) {
+			fmt.Printf("%d clicks\n", data)
+		})
+
+		_ = button.On(gpio.ButtonLongPress, func(data interface{}) {
+			fmt.Println("long press")
+		})
+
+		_ = button.On(gpio.ButtonHoldRepeat, func(data interface{}) {
+			fmt.Printf("hold repeat %d\n", data)
+		})
+	}
+
+	robot := gobot.NewRobot("buttonGesturesBot",
+		[]gobot.Connection{r},
+		[]gobot.Device{button},
+		work,
+	)
+
+	if err := robot.Start(); err != nil {
+		panic(err)
+	}
+}
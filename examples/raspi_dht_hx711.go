@@ -0,0 +1,83 @@
+//go:build example
+// +build example
+
+//
+// Do not build by default.
+
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/drivers/gpio"
+	"gobot.io/x/gobot/v2/platforms/raspi"
+)
+
+// Wiring
+// PWR  Raspi: 1 (+3.3V, VCC), 2(+5V), 6, 9, 14, 20 (GND)
+// DHT22: VCC, GND, DATA to GPIO 4 (pin 7), a pull up resistor of 10k is needed, if not on the module
+// HX711: VCC, GND, SCK to GPIO 5 (pin 29), DT to GPIO 6 (pin 31)
+//
+// The edge detection of the "cdev" GPIO character device is needed for the DHT, so the Kernel needs to be new enough.
+func main() {
+	const (
+		dhtData    = "7"
+		hx711Clock = "29"
+		hx711Data  = "31"
+		// known weight in gram for calibration, e.g. a full 0.5 l water bottle
+		calibrationWeight = 500.0
+	)
+
+	a := raspi.NewAdaptor()
+	dht := gpio.NewDHTDriver(a, dhtData, gpio.DHT22, gpio.WithDHTCyclicRead(5*time.Second))
+	hx711 := gpio.NewHX711Driver(a, hx711Clock, hx711Data)
+
+	work := func() {
+		_ = dht.On(gpio.Temperature, func(data interface{}) {
+			fmt.Printf("temperature: %.1f °C\n", data)
+		})
+		_ = dht.On(gpio.Humidity, func(data interface{}) {
+			fmt.Printf("humidity: %.1f %%\n", data)
+		})
+		_ = dht.On(gpio.Error, func(data interface{}) {
+			fmt.Println("DHT error:", data)
+		})
+
+		fmt.Println("remove all load from the scale...")
+		time.Sleep(3 * time.Second)
+		if err := hx711.Tare(10); err != nil {
+			fmt.Println(err)
+			return
+		}
+
+		fmt.Printf("put %.0f g on the scale...\n", calibrationWeight)
+		time.Sleep(5 * time.Second)
+		if err := hx711.Calibrate(calibrationWeight, 10); err != nil {
+			fmt.Println(err)
+			return
+		}
+		// the calibration can be stored and restored by gpio.WithHX711Calibration()
+		fmt.Printf("calibration: offset %d, scale %.3f\n", hx711.Offset(), hx711.Scale())
+
+		gobot.Every(time.Second, func() {
+			weight, err := hx711.Read()
+			if err != nil {
+				fmt.Println(err)
+				return
+			}
+			fmt.Printf("weight: %.1f g\n", weight)
+		})
+	}
+
+	robot := gobot.NewRobot("dhtHx711Bot",
+		[]gobot.Connection{a},
+		[]gobot.Device{dht, hx711},
+		work,
+	)
+
+	if err := robot.Start(); err != nil {
+		panic(err)
+	}
+}
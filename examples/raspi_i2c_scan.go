@@ -0,0 +1,38 @@
+//go:build example
+// +build example
+
+//
+// Do not build by default.
+
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"gobot.io/x/gobot/v2/drivers/i2c"
+	"gobot.io/x/gobot/v2/platforms/raspi"
+)
+
+// Scans the i2c bus 1 of a Raspberry Pi and prints the likely drivers for each found device, e.g.:
+// 0x20: MCP23017 (NewMCP23017Driver), MCP23017 (Adafruit 1109 LCD) (NewAdafruit1109Driver)
+// 0x77: BME280 (NewBME280Driver, identified)
+func main() {
+	r := raspi.NewAdaptor()
+	if err := r.Connect(); err != nil {
+		log.Fatal(err)
+	}
+	defer func() { _ = r.Finalize() }()
+
+	reports, err := i2c.IdentifyDevices(r, 1)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if len(reports) == 0 {
+		fmt.Println("no device found")
+	}
+	for _, report := range reports {
+		fmt.Println(report)
+	}
+}
@@ -0,0 +1,59 @@
+//go:build example
+// +build example
+
+//
+// Do not build by default.
+
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/drivers/gpio"
+	"gobot.io/x/gobot/v2/platforms/raspi"
+)
+
+// Wiring
+// PWR  Raspi: 1 (+3.3V, VCC), 2(+5V), 6, 9, 14, 20 (GND)
+// Receiver (e.g. TSOP38238 or VS1838B): OUT to header pin 11 (GPIO17), VS to +3.3V, GND to GND
+// Transmitter: header pin 12 (GPIO18-PWM0) drives the base of a NPN transistor by a resistor of 1 kOhm, the IR LED is
+// wired with a resistor (e.g. 33 Ohm) between +5V and the collector, the emitter to GND. Please refer to the README.md,
+// located in the folder of raspi platform, on how to activate the pwm support.
+//
+// The edge detection of the "cdev" GPIO character device is needed for the receiver, so the Kernel needs to be new
+// enough. Each received code is printed and the NEC code 0x04/0x08 is sent every 5 seconds.
+func main() {
+	a := raspi.NewAdaptor()
+	receiver := gpio.NewIRReceiverDriver(a, "11")
+	transmitter := gpio.NewIRTransmitterDriver(a, "pwm0")
+
+	work := func() {
+		_ = receiver.On(gpio.IRCodeReceived, func(data interface{}) {
+			code := data.(gpio.IRCode)
+			fmt.Printf("received %s: address 0x%02X, command 0x%02X\n", code.Protocol, code.Address, code.Command)
+		})
+		_ = receiver.On(gpio.IRCodeRepeated, func(data interface{}) {
+			code := data.(gpio.IRCode)
+			fmt.Printf("repeated %s: address 0x%02X, command 0x%02X\n", code.Protocol, code.Address, code.Command)
+		})
+
+		gobot.Every(5*time.Second, func() {
+			code := gpio.IRCode{Protocol: gpio.IRProtocolNEC, Address: 0x04, Command: 0x08}
+			if err := transmitter.Send(code, 1); err != nil {
+				fmt.Println("error on send:", err)
+			}
+		})
+	}
+
+	robot := gobot.NewRobot("irBot",
+		[]gobot.Connection{a},
+		[]gobot.Device{receiver, transmitter},
+		work,
+	)
+
+	if err := robot.Start(); err != nil {
+		panic(err)
+	}
+}
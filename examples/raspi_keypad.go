@@ -0,0 +1,67 @@
+//go:build example
+// +build example
+
+//
+// Do not build by default.
+
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/drivers/gpio"
+	"gobot.io/x/gobot/v2/platforms/raspi"
+)
+
+// Wiring
+// 3x4 membrane keypad, from left to right: row 1..4, column 1..3
+// Rows Raspi: GPIO 5 (pin 29), GPIO 6 (pin 31), GPIO 13 (pin 33), GPIO 19 (pin 35), the inactive rows are switched
+// to input, so the optional series resistors (e.g. 330 Ohm) are only needed to protect against wiring errors
+// Columns Raspi: GPIO 17 (pin 11), GPIO 27 (pin 13), GPIO 22 (pin 15), each with a pull up resistor of 10 kOhm to
+// +3.3V (pin 1)
+//
+// The PIN "1234" followed by "#" is accepted, "*" clears the entered keys.
+func main() {
+	const pin = "1234"
+
+	a := raspi.NewAdaptor()
+	keypad := gpio.NewKeypadDriver(a, []string{"29", "31", "33", "35"}, []string{"11", "13", "15"},
+		gpio.WithKeypadHold(2*time.Second), gpio.WithKeypadEntry(10*time.Second))
+
+	work := func() {
+		_ = keypad.On(gpio.KeypadKeyDown, func(data interface{}) {
+			fmt.Println("key down:", data)
+		})
+		_ = keypad.On(gpio.KeypadKeyHold, func(data interface{}) {
+			fmt.Println("key hold:", data)
+		})
+		_ = keypad.On(gpio.KeypadGhosting, func(data interface{}) {
+			fmt.Println("too many keys pressed:", data)
+		})
+		_ = keypad.On(gpio.KeypadEntryTimeout, func(interface{}) {
+			fmt.Println("entry timed out")
+		})
+		_ = keypad.On(gpio.KeypadEntry, func(data interface{}) {
+			if data != pin {
+				fmt.Println("wrong PIN")
+				return
+			}
+			fmt.Println("PIN accepted")
+		})
+		_ = keypad.On(gpio.Error, func(data interface{}) {
+			fmt.Println("error:", data)
+		})
+	}
+
+	robot := gobot.NewRobot("keypadBot",
+		[]gobot.Connection{a},
+		[]gobot.Device{keypad},
+		work,
+	)
+
+	if err := robot.Start(); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,53 @@
+//go:build example
+// +build example
+
+//
+// Do not build by default.
+
+package main
+
+import (
+	"fmt"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/drivers/gpio"
+	"gobot.io/x/gobot/v2/platforms/adaptors"
+	"gobot.io/x/gobot/v2/platforms/raspi"
+)
+
+// Wiring of a KY-040
+// PWR  Raspi: 1 (+3.3V, VCC), 6 (GND)
+// GPIO Raspi: header pin 11 is connected to CLK (A), header pin 13 to DT (B) and header pin 15 to SW (button)
+func main() {
+	// the gpiod access is needed for edge events, with sysfs the pins are polled
+	r := raspi.NewAdaptor(adaptors.WithGpiodAccess())
+	encoder := gpio.NewRotaryEncoderDriver(r, "11", "13",
+		gpio.WithRotaryEncoderResolution(gpio.QuadratureX1),
+		gpio.WithRotaryEncoderButton("15", 1),
+	)
+
+	work := func() {
+		_ = encoder.On(gpio.RotaryEncoderClockwise, func(data interface{}) {
+			fmt.Println("clockwise, position:", data)
+		})
+
+		_ = encoder.On(gpio.RotaryEncoderCounterClockwise, func(data interface{}) {
+			fmt.Println("counter-clockwise, position:", data)
+		})
+
+		_ = encoder.On(gpio.ButtonPush, func(data interface{}) {
+			fmt.Printf("reset position, velocity was %.1f steps/s\n", encoder.Velocity())
+			encoder.SetCurrentPosition(0)
+		})
+	}
+
+	robot := gobot.NewRobot("encoderBot",
+		[]gobot.Connection{r},
+		[]gobot.Device{encoder},
+		work,
+	)
+
+	if err := robot.Start(); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,85 @@
+//go:build example
+// +build example
+
+//
+// Do not build by default.
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/drivers/gpio"
+	"gobot.io/x/gobot/v2/platforms/adaptors"
+	"gobot.io/x/gobot/v2/platforms/raspi"
+)
+
+// Wiring
+// PWM Raspi: header pin 12 (GPIO18-PWM0) for pan, header pin 35 (GPIO19-PWM1) for tilt, please refer to the
+// README.md, located in the folder of raspi platform, on how to activate the pwm support.
+// Servo: orange (PWM), black (GND), red (VCC) 4-6V (please read the manual of your device)
+func main() {
+	const fiftyHzNanos = 20 * 1000 * 1000 // 50Hz = 0.02 sec = 20 ms
+
+	adaptor := raspi.NewAdaptor(
+		adaptors.WithPWMDefaultPeriodForPin("pwm0", fiftyHzNanos),
+		adaptors.WithPWMDefaultPeriodForPin("pwm1", fiftyHzNanos),
+	)
+	// the servos are calibrated, so both are at the same logical angle in the same mechanical position
+	pan := gpio.NewServoDriver(adaptor, "pwm0",
+		gpio.WithServoSpeed(90),
+		gpio.WithServoEasing(gpio.ServoEasingInOutSine),
+		gpio.WithServoPulseRange(600*time.Microsecond, 2400*time.Microsecond),
+	)
+	tilt := gpio.NewServoDriver(adaptor, "pwm1",
+		gpio.WithServoSpeed(60),
+		gpio.WithServoOffset(-5),
+	)
+
+	nod := gpio.NewServoSequence(pan, tilt).
+		AddKeyframe(time.Second, 90, 90).
+		AddKeyframe(300*time.Millisecond, 90, 60).
+		AddKeyframe(300*time.Millisecond, 90, 120).
+		AddKeyframe(300*time.Millisecond, 90, 90).
+		AddKeyframe(500*time.Millisecond, 90, 90) // pause
+	look := gpio.NewServoSequence(pan, tilt).
+		AddKeyframe(time.Second, 30, 80).
+		AddKeyframe(2*time.Second, 150, 80).
+		AddKeyframe(time.Second, 90, 90)
+
+	work := func() {
+		_ = pan.On(gpio.ServoReached, func(data interface{}) {
+			fmt.Println("pan reached", data)
+		})
+
+		// non blocking move with the configured speed
+		if err := pan.MoveAsync(0); err != nil {
+			log.Println(err)
+		}
+		if err := pan.Wait(); err != nil {
+			log.Println(err)
+		}
+
+		gobot.Every(8*time.Second, func() {
+			if err := nod.Play(); err != nil {
+				log.Println(err)
+			}
+			if err := look.Play(); err != nil {
+				log.Println(err)
+			}
+		})
+	}
+
+	robot := gobot.NewRobot("panTiltBot",
+		[]gobot.Connection{adaptor},
+		[]gobot.Device{pan, tilt},
+		work,
+	)
+
+	if err := robot.Start(); err != nil {
+		panic(err)
+	}
+}
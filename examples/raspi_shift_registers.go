@@ -0,0 +1,89 @@
+//go:build example
+// +build example
+
+//
+// Do not build by default.
+
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/drivers/gpio"
+	"gobot.io/x/gobot/v2/platforms/raspi"
+)
+
+// Wiring
+// PWR  Raspi: 1 (+3.3V, VCC), 6, 9, 14, 20 (GND)
+// 74HC595 (outputs): VCC, MR to VCC, GND, OE to GND, DS to GPIO 17 (pin 11), SHCP to GPIO 27 (pin 13),
+// STCP to GPIO 22 (pin 15), Q0..Q7 with LEDs and resistors to GND
+// 74HC165 (inputs): VCC, GND, CLK INH to GND, QH to GPIO 5 (pin 29), CLK to GPIO 6 (pin 31), SH/LD to GPIO 13
+// (pin 33), A..H with pull down resistors and buttons to VCC
+//
+// Each button switches the LED with the same number, all LEDs are switched off at once by the last button.
+func main() {
+	const (
+		outData  = "11"
+		outClock = "13"
+		outLatch = "15"
+		inData   = "29"
+		inClock  = "31"
+		inLoad   = "33"
+	)
+
+	a := raspi.NewAdaptor()
+	outputs := gpio.NewHC595Driver(a, outData, outClock, outLatch, 1)
+	inputs := gpio.NewHC165Driver(a, inData, inClock, inLoad, 1, gpio.WithHC165MaxAge(5*time.Millisecond))
+
+	// the expanders are used as connections for the usual drivers
+	var leds []*gpio.LedDriver
+	var buttons []*gpio.ButtonDriver
+	devices := []gobot.Device{}
+	for i := 0; i < 8; i++ {
+		pin := fmt.Sprintf("%d", i)
+		led := gpio.NewLedDriver(outputs, pin)
+		button := gpio.NewButtonDriver(inputs, pin, gpio.WithButtonPollInterval(10*time.Millisecond))
+		leds = append(leds, led)
+		buttons = append(buttons, button)
+		devices = append(devices, led, button)
+	}
+
+	work := func() {
+		for i := 0; i < 7; i++ {
+			led := leds[i]
+			_ = buttons[i].On(gpio.ButtonPush, func(interface{}) {
+				if err := led.Toggle(); err != nil {
+					fmt.Println(err)
+				}
+			})
+		}
+
+		_ = buttons[7].On(gpio.ButtonPush, func(interface{}) {
+			// one shift for all changes
+			err := outputs.Batch(func() error {
+				for _, led := range leds {
+					if err := led.Off(); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				fmt.Println(err)
+			}
+		})
+	}
+
+	// the expanders need to be connected after the adaptor of their pins
+	robot := gobot.NewRobot("shiftRegisterBot",
+		[]gobot.Connection{a, outputs, inputs},
+		devices,
+		work,
+	)
+
+	if err := robot.Start(); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,79 @@
+//go:build example
+// +build example
+
+//
+// Do not build by default.
+
+package main
+
+import (
+	"log"
+	"time"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/drivers/gpio"
+	"gobot.io/x/gobot/v2/platforms/raspi"
+)
+
+// Wiring
+// PWR  Raspi: 2, 4 (+5V), 6, 9, 14, 20 (GND)
+// GPIO Raspi: header pins 7, 11, 13, 15 are used for the coils, header pin 16 for the limit switch (active high)
+func main() {
+	const (
+		coilA1     = "7"
+		coilA2     = "13"
+		coilB1     = "11"
+		coilB2     = "15"
+		homeSwitch = "16"
+
+		stepsPerRevision = 200
+	)
+
+	r := raspi.NewAdaptor()
+	stepper := gpio.NewStepperDriver(r, [4]string{coilA1, coilB1, coilA2, coilB2}, gpio.StepperModes.DualPhaseStepping,
+		stepsPerRevision,
+		gpio.WithStepperMaxSpeed(300),
+		gpio.WithStepperAcceleration(600),
+		gpio.WithStepperProfile(gpio.StepperProfileSCurve),
+		gpio.WithStepperSoftLimits(0, 10*stepsPerRevision),
+		gpio.WithStepperHomeSwitch(homeSwitch))
+
+	work := func() {
+		_ = stepper.On(stepper.Event(gpio.StepperMoveDone), func(data interface{}) {
+			log.Println("position reached:", data)
+		})
+
+		// find the reference position with a low speed
+		if err := stepper.SetSpeed(30); err != nil {
+			log.Println("set speed", err)
+		}
+		if err := stepper.Home(gpio.StepperDriverBackward, 20*stepsPerRevision); err != nil {
+			log.Println("homing", err)
+			return
+		}
+
+		if err := stepper.SetSpeed(300); err != nil {
+			log.Println("set speed", err)
+		}
+
+		gobot.Every(5*time.Second, func() {
+			target := 8 * stepsPerRevision
+			if stepper.CurrentPosition() > 0 {
+				target = 0
+			}
+			if err := stepper.MoveToAsync(target); err != nil {
+				log.Println("move to", err)
+			}
+		})
+	}
+
+	robot := gobot.NewRobot("stepperBot",
+		[]gobot.Connection{r},
+		[]gobot.Device{stepper},
+		work,
+	)
+
+	if err := robot.Start(); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,50 @@
+//go:build example
+// +build example
+
+//
+// Do not build by default.
+
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/drivers/aio"
+	"gobot.io/x/gobot/v2/drivers/gpio"
+	"gobot.io/x/gobot/v2/platforms/raspi"
+)
+
+// Blinks the on-board activity LED faster, if the CPU temperature rises. The original trigger of the LED (normally
+// "mmc0") is restored on halt.
+func main() {
+	r := raspi.NewAdaptor()
+	led := gpio.NewSysLedDriver(r, "ACT")
+	cpu := aio.NewHwmonDriver(r, "cpu_thermal", aio.WithHwmonCyclicRead(2*time.Second))
+
+	work := func() {
+		_ = cpu.On(aio.Value, func(data interface{}) {
+			val := data.(aio.HwmonValue)
+			fmt.Printf("%s: %.1f %s\n", val.Name, val.Value, val.Unit)
+
+			period := 2 * time.Second
+			if val.Value > 60 {
+				period = 200 * time.Millisecond
+			}
+			if err := led.Blink(period/2, period/2); err != nil {
+				fmt.Println(err)
+			}
+		})
+	}
+
+	robot := gobot.NewRobot("sysLedBot",
+		[]gobot.Connection{r},
+		[]gobot.Device{led, cpu},
+		work,
+	)
+
+	if err := robot.Start(); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,71 @@
+//go:build example
+// +build example
+
+//
+// Do not build by default.
+
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/drivers/common/font"
+	"gobot.io/x/gobot/v2/drivers/gpio"
+	"gobot.io/x/gobot/v2/drivers/i2c"
+	"gobot.io/x/gobot/v2/platforms/raspi"
+)
+
+// Wiring
+// PWR  Raspi: 1 (+3.3V, VCC), 2(+5V), 6, 9, 14, 20 (GND)
+// I2C1 Raspi: 3 (SDA), 5 (SCL)
+// TM1637: VCC, GND, CLK to GPIO 23 (pin 16), DIO to GPIO 24 (pin 18)
+// HT16K33 8x8 matrix backpack: VCC, GND, SDA, SCL
+//
+// The TM1637 shows a clock with blinking colon, the matrix scrolls a text and the time.
+func main() {
+	const (
+		tm1637Clock = "16"
+		tm1637Data  = "18"
+	)
+
+	a := raspi.NewAdaptor()
+	clock := gpio.NewTM1637Driver(a, tm1637Clock, tm1637Data, gpio.WithTM1637Brightness(3))
+	matrix := i2c.NewHT16K33Driver(a, i2c.WithHT16K33Brightness(2))
+
+	work := func() {
+		var colon bool
+		gobot.Every(500*time.Millisecond, func() {
+			colon = !colon
+			separator := " "
+			if colon {
+				separator = ":"
+			}
+			if err := clock.SetDisplayText(time.Now().Format("15" + separator + "04")); err != nil {
+				fmt.Println(err)
+			}
+		})
+
+		text := font.NewMatrixTextDisplay(matrix, nil)
+		go func() {
+			for {
+				msg := fmt.Sprintf("gobot.io - %s", time.Now().Format("15:04"))
+				if err := text.Scroll(msg, 80*time.Millisecond); err != nil {
+					fmt.Println(err)
+					return
+				}
+			}
+		}()
+	}
+
+	robot := gobot.NewRobot("displayBot",
+		[]gobot.Connection{a},
+		[]gobot.Device{clock, matrix},
+		work,
+	)
+
+	if err := robot.Start(); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,53 @@
+//go:build example
+// +build example
+
+//
+// Do not build by default.
+
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/drivers/watchdog"
+	"gobot.io/x/gobot/v2/platforms/raspi"
+)
+
+// Keeps the hardware watchdog alive from the work loop. If the work loop hangs or the robot is killed, the Raspberry Pi
+// is reset after 10 seconds. On halt the watchdog is stopped.
+func main() {
+	r := raspi.NewAdaptor()
+	wd := watchdog.NewWatchdogDriver(r, watchdog.WithTimeout(10*time.Second))
+
+	work := func() {
+		if status, err := wd.BootStatus(); err == nil && status&watchdog.BootStatusCardReset != 0 {
+			fmt.Println("last reboot was caused by the watchdog")
+		}
+
+		_ = wd.On(watchdog.Unhealthy, func(data interface{}) {
+			fmt.Println("keepalive skipped:", data)
+		})
+
+		gobot.Every(2*time.Second, func() {
+			if err := wd.Pet(); err != nil {
+				fmt.Println(err)
+				return
+			}
+			if left, err := wd.TimeLeft(); err == nil {
+				fmt.Println("time left:", left)
+			}
+		})
+	}
+
+	robot := gobot.NewRobot("watchdogBot",
+		[]gobot.Connection{r},
+		[]gobot.Device{wd},
+		work,
+	)
+
+	if err := robot.Start(); err != nil {
+		panic(err)
+	}
+}
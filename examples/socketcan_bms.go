@@ -0,0 +1,76 @@
+//go:build example
+// +build example
+
+//
+// Do not build by default.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/drivers/can"
+	"gobot.io/x/gobot/v2/platforms/socketcan"
+)
+
+// Wiring: MCP2515 connected by SPI and configured as "can0" by the device-tree overlay "mcp2515-can0", or any other
+// CAN interface of the kernel, e.g. a USB adaptor.
+// Prepare: "ip link set can0 up type can bitrate 500000" and a DBC file, which describes the messages of the BMS.
+// Expected behavior: all decoded signals of known messages are printed, error frames (e.g. bus off) are reported and
+// every second the message "Heartbeat" is sent, if defined in the DBC file.
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Println("usage: socketcan_bms <file.dbc> [interface]")
+		os.Exit(1)
+	}
+	iface := "can0"
+	if len(os.Args) > 2 {
+		iface = os.Args[2]
+	}
+
+	db, err := can.LoadDBC(os.Args[1])
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	adaptor := socketcan.NewAdaptor(iface)
+	bms := can.NewFrameDriver(adaptor, can.WithDatabase(db), can.WithErrorFrames(0x1FFFFFFF))
+
+	work := func() {
+		_ = bms.On(can.Signals, func(data interface{}) {
+			msg := data.(can.DecodedMessage)
+			fmt.Printf("%s (0x%X): %v\n", msg.Name, msg.Frame.ID, msg.Signals)
+		})
+
+		_ = bms.On(can.ErrorFrame, func(data interface{}) {
+			frame := data.(gobot.CanFrame)
+			fmt.Printf("error frame, class 0x%X, data % X\n", frame.ID, frame.Data)
+		})
+
+		_ = bms.On(can.Error, func(data interface{}) {
+			fmt.Println("error:", data)
+		})
+
+		if db.MessageByName("Heartbeat") != nil {
+			gobot.Every(time.Second, func() {
+				if err := bms.SendMessage("Heartbeat", nil); err != nil {
+					fmt.Println(err)
+				}
+			})
+		}
+	}
+
+	robot := gobot.NewRobot("canBot",
+		[]gobot.Connection{adaptor},
+		[]gobot.Device{bms},
+		work,
+	)
+
+	if err := robot.Start(); err != nil {
+		panic(err)
+	}
+}
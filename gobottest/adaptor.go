@@ -0,0 +1,287 @@
+package gobottest
+
+import (
+	"fmt"
+	"sync"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/drivers/i2c"
+	"gobot.io/x/gobot/v2/drivers/spi"
+)
+
+const (
+	defaultI2cBusNumber   = 0
+	defaultSpiBusNumber   = 0
+	defaultSpiChipNumber  = 0
+	defaultSpiMode        = 0
+	defaultSpiBitsNumber  = 8
+	defaultSpiMaxSpeed    = 500000
+	defaultAdaptorPortStr = "simulated"
+)
+
+type busAddress struct {
+	bus     int
+	address int
+}
+
+// Adaptor is a simulated board for tests without hardware. It implements the interfaces used by the gpio, aio, i2c
+// and spi drivers. Digital, PWM and analog pins are created on first usage, i2c and spi devices needs to be added
+// before usage.
+type Adaptor struct {
+	name         string
+	mutex        sync.Mutex
+	connected    bool
+	connectErr   error
+	finalizeErr  error
+	digitalPins  map[string]*DigitalPin
+	pwmPins      map[string]*PWMPin
+	analogPins   map[string]*AnalogPin
+	i2cDevices   map[busAddress]*I2cDevice
+	spiDevices   map[busAddress]*SpiDevice
+	i2cBusNumber int
+}
+
+// NewAdaptor creates a new simulated board.
+func NewAdaptor() *Adaptor {
+	return &Adaptor{
+		name:         gobot.DefaultName("Simulated"),
+		digitalPins:  make(map[string]*DigitalPin),
+		pwmPins:      make(map[string]*PWMPin),
+		analogPins:   make(map[string]*AnalogPin),
+		i2cDevices:   make(map[busAddress]*I2cDevice),
+		spiDevices:   make(map[busAddress]*SpiDevice),
+		i2cBusNumber: defaultI2cBusNumber,
+	}
+}
+
+// Name returns the name of the adaptor.
+func (a *Adaptor) Name() string {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	return a.name
+}
+
+// SetName sets the name of the adaptor.
+func (a *Adaptor) SetName(name string) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.name = name
+}
+
+// Port returns a simulated port.
+func (a *Adaptor) Port() string { return defaultAdaptorPortStr }
+
+// Connect simulates the connection to the board.
+func (a *Adaptor) Connect() error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.connectErr != nil {
+		return a.connectErr
+	}
+	a.connected = true
+	return nil
+}
+
+// Finalize closes all i2c and spi devices and simulates the disconnection of the board.
+func (a *Adaptor) Finalize() error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	for _, dev := range a.i2cDevices {
+		_ = dev.Close()
+	}
+	for _, dev := range a.spiDevices {
+		_ = dev.Close()
+	}
+	a.connected = false
+	return a.finalizeErr
+}
+
+// IsConnected returns whether Connect() was called successfully and Finalize() was not called afterwards.
+func (a *Adaptor) IsConnected() bool {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	return a.connected
+}
+
+// SetConnectError simulates an error on each call of Connect(), nil deactivates the simulation.
+func (a *Adaptor) SetConnectError(err error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.connectErr = err
+}
+
+// SetFinalizeError simulates an error on each call of Finalize(), nil deactivates the simulation.
+func (a *Adaptor) SetFinalizeError(err error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.finalizeErr = err
+}
+
+// Digital returns the simulated digital pin with the given id, which is created if not already there.
+func (a *Adaptor) Digital(id string) *DigitalPin {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	pin, ok := a.digitalPins[id]
+	if !ok {
+		pin = NewDigitalPin(id)
+		a.digitalPins[id] = pin
+	}
+	return pin
+}
+
+// PWM returns the simulated PWM pin with the given id, which is created if not already there.
+func (a *Adaptor) PWM(id string) *PWMPin {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	pin, ok := a.pwmPins[id]
+	if !ok {
+		pin = NewPWMPin(id)
+		a.pwmPins[id] = pin
+	}
+	return pin
+}
+
+// Analog returns the simulated analog pin with the given id, which is created if not already there.
+func (a *Adaptor) Analog(id string) *AnalogPin {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	pin, ok := a.analogPins[id]
+	if !ok {
+		pin = NewAnalogPin(id)
+		a.analogPins[id] = pin
+	}
+	return pin
+}
+
+// DigitalPin (interface gobot.DigitalPinnerProvider) returns the simulated digital pin with the given id.
+func (a *Adaptor) DigitalPin(id string) (gobot.DigitalPinner, error) {
+	return a.Digital(id), nil
+}
+
+// DigitalRead (interface gpio.DigitalReader) reads the simulated digital pin with the given id.
+func (a *Adaptor) DigitalRead(id string) (int, error) {
+	return a.Digital(id).Read()
+}
+
+// DigitalWrite (interface gpio.DigitalWriter) writes to the simulated digital pin with the given id.
+func (a *Adaptor) DigitalWrite(id string, val byte) error {
+	return a.Digital(id).Write(int(val))
+}
+
+// PWMPin (interface gobot.PWMPinnerProvider) returns the simulated PWM pin with the given id.
+func (a *Adaptor) PWMPin(id string) (gobot.PWMPinner, error) {
+	return a.PWM(id), nil
+}
+
+// PwmWrite (interface gpio.PwmWriter) records the value for the simulated PWM pin with the given id.
+func (a *Adaptor) PwmWrite(id string, val byte) error {
+	return a.PWM(id).pwmWrite(val)
+}
+
+// ServoWrite (interface gpio.ServoWriter) records the angle for the simulated PWM pin with the given id.
+func (a *Adaptor) ServoWrite(id string, angle byte) error {
+	return a.PWM(id).servoWrite(angle)
+}
+
+// AnalogRead (interface aio.AnalogReader) reads the simulated analog pin with the given id.
+func (a *Adaptor) AnalogRead(id string) (int, error) {
+	return a.Analog(id).Read()
+}
+
+// AnalogWrite (interface aio.AnalogWriter) writes to the simulated analog pin with the given id.
+func (a *Adaptor) AnalogWrite(id string, val int) error {
+	return a.Analog(id).Write(val)
+}
+
+// AddI2cDevice adds the simulated device on the given bus and address, an existing device is replaced.
+func (a *Adaptor) AddI2cDevice(bus int, address int, dev *I2cDevice) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.i2cDevices[busAddress{bus: bus, address: address}] = dev
+}
+
+// SetDefaultI2cBus substitutes the default bus number 0.
+func (a *Adaptor) SetDefaultI2cBus(bus int) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.i2cBusNumber = bus
+}
+
+// GetI2cConnection (interface i2c.Connector) returns the simulated device on the given bus and address.
+func (a *Adaptor) GetI2cConnection(address int, bus int) (i2c.Connection, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	dev, ok := a.i2cDevices[busAddress{bus: bus, address: address}]
+	if !ok {
+		return nil, fmt.Errorf("no i2c device at address 0x%02X on bus %d of '%s'", address, bus, a.name)
+	}
+	dev.open()
+	return dev, nil
+}
+
+// ProbeI2cAddress (interface i2c.Prober) returns true, if a simulated device was added on the given bus and address.
+func (a *Adaptor) ProbeI2cAddress(address int, bus int) (bool, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	_, ok := a.i2cDevices[busAddress{bus: bus, address: address}]
+	return ok, nil
+}
+
+// DefaultI2cBus (interface i2c.Connector) returns the default i2c bus number.
+func (a *Adaptor) DefaultI2cBus() int {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	return a.i2cBusNumber
+}
+
+// AddSpiDevice adds the simulated device on the given bus and chip, an existing device is replaced.
+func (a *Adaptor) AddSpiDevice(bus int, chip int, dev *SpiDevice) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.spiDevices[busAddress{bus: bus, address: chip}] = dev
+}
+
+// GetSpiConnection (interface spi.Connector) returns a connection to the simulated device on the given bus and
+// chip. The settings are stored in the simulated device.
+func (a *Adaptor) GetSpiConnection(bus, chip, mode, bits int, maxSpeed int64) (spi.Connection, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	dev, ok := a.spiDevices[busAddress{bus: bus, address: chip}]
+	if !ok {
+		return nil, fmt.Errorf("no SPI device at chip %d on bus %d of '%s'", chip, bus, a.name)
+	}
+	dev.open(mode, bits, maxSpeed)
+	return spi.NewConnection(dev), nil
+}
+
+// SpiDefaultBusNumber (interface spi.Connector) returns the default SPI bus number.
+func (a *Adaptor) SpiDefaultBusNumber() int { return defaultSpiBusNumber }
+
+// SpiDefaultChipNumber (interface spi.Connector) returns the default SPI chip number.
+func (a *Adaptor) SpiDefaultChipNumber() int { return defaultSpiChipNumber }
+
+// SpiDefaultMode (interface spi.Connector) returns the default SPI mode.
+func (a *Adaptor) SpiDefaultMode() int { return defaultSpiMode }
+
+// SpiDefaultBitCount (interface spi.Connector) returns the default number of bits per word.
+func (a *Adaptor) SpiDefaultBitCount() int { return defaultSpiBitsNumber }
+
+// SpiDefaultMaxSpeed (interface spi.Connector) returns the default maximum speed in Hz.
+func (a *Adaptor) SpiDefaultMaxSpeed() int64 { return defaultSpiMaxSpeed }
@@ -0,0 +1,155 @@
+package gobottest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/drivers/aio"
+	"gobot.io/x/gobot/v2/drivers/gpio"
+	"gobot.io/x/gobot/v2/drivers/i2c"
+	"gobot.io/x/gobot/v2/drivers/spi"
+)
+
+// make sure that this adaptor fulfills all the required interfaces
+var (
+	_ gobot.Adaptor               = (*Adaptor)(nil)
+	_ gobot.Porter                = (*Adaptor)(nil)
+	_ gobot.DigitalPinnerProvider = (*Adaptor)(nil)
+	_ gobot.PWMPinnerProvider     = (*Adaptor)(nil)
+	_ gpio.DigitalReader          = (*Adaptor)(nil)
+	_ gpio.DigitalWriter          = (*Adaptor)(nil)
+	_ gpio.PwmWriter              = (*Adaptor)(nil)
+	_ gpio.ServoWriter            = (*Adaptor)(nil)
+	_ aio.AnalogReader            = (*Adaptor)(nil)
+	_ aio.AnalogWriter            = (*Adaptor)(nil)
+	_ i2c.Connector               = (*Adaptor)(nil)
+	_ i2c.Prober                  = (*Adaptor)(nil)
+	_ spi.Connector               = (*Adaptor)(nil)
+)
+
+func TestNewAdaptor(t *testing.T) {
+	// act
+	a := NewAdaptor()
+	// assert
+	assert.True(t, strings.HasPrefix(a.Name(), "Simulated"))
+	assert.Equal(t, "simulated", a.Port())
+	assert.Equal(t, 0, a.DefaultI2cBus())
+	assert.Equal(t, 0, a.SpiDefaultBusNumber())
+	assert.Equal(t, 0, a.SpiDefaultChipNumber())
+	assert.Equal(t, 0, a.SpiDefaultMode())
+	assert.Equal(t, 8, a.SpiDefaultBitCount())
+	assert.Equal(t, int64(500000), a.SpiDefaultMaxSpeed())
+	a.SetName("board")
+	assert.Equal(t, "board", a.Name())
+	a.SetDefaultI2cBus(2)
+	assert.Equal(t, 2, a.DefaultI2cBus())
+}
+
+func TestAdaptorConnectFinalize(t *testing.T) {
+	// arrange
+	a := NewAdaptor()
+	i2cDev := NewI2cDevice()
+	spiDev := NewSpiDevice()
+	a.AddI2cDevice(1, 0x40, i2cDev)
+	a.AddSpiDevice(0, 1, spiDev)
+	// act & assert
+	require.NoError(t, a.Connect())
+	assert.True(t, a.IsConnected())
+	require.NoError(t, a.Finalize())
+	assert.False(t, a.IsConnected())
+	assert.True(t, i2cDev.IsClosed())
+	assert.True(t, spiDev.IsClosed())
+	// act & assert errors
+	a.SetConnectError(fmt.Errorf("connect error"))
+	a.SetFinalizeError(fmt.Errorf("finalize error"))
+	require.EqualError(t, a.Connect(), "connect error")
+	assert.False(t, a.IsConnected())
+	require.EqualError(t, a.Finalize(), "finalize error")
+}
+
+func TestAdaptorPins(t *testing.T) {
+	// arrange
+	a := NewAdaptor()
+	a.Digital("1").ScriptReads(1)
+	a.Analog("A0").SetValue(512)
+	// act
+	dval, derr := a.DigitalRead("1")
+	aval, aerr := a.AnalogRead("A0")
+	require.NoError(t, a.DigitalWrite("2", 1))
+	require.NoError(t, a.AnalogWrite("A1", 100))
+	require.NoError(t, a.PwmWrite("3", 128))
+	require.NoError(t, a.ServoWrite("4", 90))
+	dp, _ := a.DigitalPin("1")
+	pp, _ := a.PWMPin("3")
+	// assert
+	require.NoError(t, derr)
+	require.NoError(t, aerr)
+	assert.Equal(t, 1, dval)
+	assert.Equal(t, 512, aval)
+	assert.Same(t, a.Digital("1"), dp)
+	assert.Same(t, a.PWM("3"), pp)
+	assert.True(t, a.Digital("2").AssertWritten(t, 1))
+	assert.True(t, a.Analog("A1").AssertWritten(t, 100))
+	assert.True(t, a.PWM("3").AssertPwmWritten(t, 128))
+	assert.True(t, a.PWM("4").AssertServoWritten(t, 90))
+	assert.Nil(t, a.PWM("3").ServoWritten())
+}
+
+func TestAdaptorGetI2cConnection(t *testing.T) {
+	// arrange
+	a := NewAdaptor()
+	dev := NewI2cDevice()
+	a.AddI2cDevice(1, 0x40, dev)
+	_ = dev.Close()
+	// act
+	con, err := a.GetI2cConnection(0x40, 1)
+	// assert
+	require.NoError(t, err)
+	assert.Same(t, dev, con)
+	assert.False(t, dev.IsClosed())
+	_, err = a.GetI2cConnection(0x41, 1)
+	require.ErrorContains(t, err, "no i2c device at address 0x41 on bus 1 of 'Simulated")
+}
+
+func TestAdaptorIdentifyI2cDevices(t *testing.T) {
+	// arrange
+	a := NewAdaptor()
+	bme := NewI2cDevice()
+	bme.SetRegisters(0xD0, 0x60)
+	a.AddI2cDevice(1, 0x76, bme)
+	a.AddI2cDevice(1, 0x48, NewI2cDevice())
+	a.AddI2cDevice(2, 0x20, NewI2cDevice())
+	// act
+	got, err := i2c.IdentifyDevices(a, 1)
+	// assert
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, 0x48, got[0].Address)
+	assert.Len(t, got[0].Candidates, 4)
+	assert.Equal(t, "0x76: BME280 (NewBME280Driver, identified)", got[1].String())
+}
+
+func TestAdaptorGetSpiConnection(t *testing.T) {
+	// arrange
+	a := NewAdaptor()
+	dev := NewSpiDevice()
+	dev.ScriptResponses([]byte{0x00, 0x55})
+	a.AddSpiDevice(0, 1, dev)
+	// act
+	con, err := a.GetSpiConnection(0, 1, 3, 8, 1000000)
+	// assert
+	require.NoError(t, err)
+	val, err := con.ReadByteData(0x80)
+	require.NoError(t, err)
+	assert.Equal(t, uint8(0x55), val)
+	assert.Equal(t, 3, dev.Mode())
+	assert.Equal(t, 8, dev.Bits())
+	assert.Equal(t, int64(1000000), dev.MaxSpeed())
+	_, err = a.GetSpiConnection(0, 0, 0, 8, 0)
+	require.ErrorContains(t, err, "no SPI device at chip 0 on bus 0 of 'Simulated")
+}
@@ -0,0 +1,122 @@
+package gobottest
+
+import (
+	"fmt"
+	"sync"
+)
+
+// AnalogPin is a simulated analog input or output, which implements gobot.AnalogPinner. The written values are
+// recorded and the values to read can be scripted.
+type AnalogPin struct {
+	id       string
+	mutex    sync.Mutex
+	value    int
+	reads    []int
+	written  []int
+	readErr  error
+	writeErr error
+}
+
+// NewAnalogPin creates a new simulated analog pin with the given id, normally created by the Adaptor on first usage.
+func NewAnalogPin(id string) *AnalogPin {
+	return &AnalogPin{id: id}
+}
+
+// Read (interface gobot.AnalogPinner) returns the next scripted value or the current value of the pin.
+func (p *AnalogPin) Read() (int, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.readErr != nil {
+		return 0, p.readErr
+	}
+
+	if len(p.reads) > 0 {
+		p.value = p.reads[0]
+		p.reads = p.reads[1:]
+	}
+	return p.value, nil
+}
+
+// Write (interface gobot.AnalogPinner) records the given value and sets it as the current value of the pin.
+func (p *AnalogPin) Write(val int) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.writeErr != nil {
+		return p.writeErr
+	}
+
+	p.written = append(p.written, val)
+	p.value = val
+	return nil
+}
+
+// ID returns the id of the pin.
+func (p *AnalogPin) ID() string {
+	return p.id
+}
+
+// Value returns the current value of the pin.
+func (p *AnalogPin) Value() int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.value
+}
+
+// SetValue sets the current value of the pin, which is returned by Read() when no scripted value is left.
+func (p *AnalogPin) SetValue(val int) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.value = val
+}
+
+// ScriptReads adds values, which will be returned by the next calls of Read(). The last value will stay as the
+// current value of the pin.
+func (p *AnalogPin) ScriptReads(vals ...int) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.reads = append(p.reads, vals...)
+}
+
+// Written returns all values written to the pin, since creation or the last call of Reset().
+func (p *AnalogPin) Written() []int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return append([]int(nil), p.written...)
+}
+
+// AssertWritten checks that exactly the given values were written to the pin.
+func (p *AnalogPin) AssertWritten(t TestingT, want ...int) bool {
+	t.Helper()
+	return assertEqual(t, fmt.Sprintf("written values of analog pin '%s'", p.id), want, p.Written())
+}
+
+// SetReadError simulates an error on each call of Read(), nil deactivates the simulation.
+func (p *AnalogPin) SetReadError(err error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.readErr = err
+}
+
+// SetWriteError simulates an error on each call of Write(), nil deactivates the simulation.
+func (p *AnalogPin) SetWriteError(err error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.writeErr = err
+}
+
+// Reset clears all recorded values and scripted reads.
+func (p *AnalogPin) Reset() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.written = nil
+	p.reads = nil
+}
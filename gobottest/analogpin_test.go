@@ -0,0 +1,40 @@
+package gobottest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+)
+
+// make sure that this implementation fulfills all the required interfaces
+var _ gobot.AnalogPinner = (*AnalogPin)(nil)
+
+func TestAnalogPin(t *testing.T) {
+	// arrange
+	p := NewAnalogPin("A0")
+	p.SetValue(100)
+	p.ScriptReads(200, 300)
+	// act & assert reads
+	for _, want := range []int{200, 300, 300} {
+		got, err := p.Read()
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+	// act & assert writes
+	require.NoError(t, p.Write(50))
+	assert.Equal(t, 50, p.Value())
+	assert.True(t, p.AssertWritten(t, 50))
+	// act & assert errors
+	p.SetReadError(fmt.Errorf("read error"))
+	p.SetWriteError(fmt.Errorf("write error"))
+	_, err := p.Read()
+	require.EqualError(t, err, "read error")
+	require.EqualError(t, p.Write(1), "write error")
+	p.Reset()
+	assert.Nil(t, p.Written())
+	assert.Equal(t, "A0", p.ID())
+}
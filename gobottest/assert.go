@@ -0,0 +1,46 @@
+package gobottest
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// TestingT is the part of testing.T, which is used by the assertion helpers. It is fulfilled by *testing.T and
+// *testing.B and allows the usage together with other test frameworks.
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+// assertEqual reports an error to the given test, if the values are not deeply equal.
+func assertEqual(t TestingT, what string, want, got interface{}) bool {
+	t.Helper()
+
+	if reflect.DeepEqual(want, got) {
+		return true
+	}
+
+	t.Errorf("%s not as expected:\n\twant: %s\n\tgot:  %s", what, format(want), format(got))
+	return false
+}
+
+func format(v interface{}) string {
+	switch val := v.(type) {
+	case []byte:
+		if len(val) == 0 {
+			return "[]"
+		}
+		return fmt.Sprintf("[% 02X]", val)
+	case [][]byte:
+		s := "["
+		for i, b := range val {
+			if i > 0 {
+				s += ", "
+			}
+			s += format(b)
+		}
+		return s + "]"
+	default:
+		return fmt.Sprintf("%v", val)
+	}
+}
@@ -0,0 +1,63 @@
+package gobottest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testingTRecorder struct {
+	errors []string
+}
+
+func (r *testingTRecorder) Helper() {}
+
+func (r *testingTRecorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestAssertEqual(t *testing.T) {
+	tests := map[string]struct {
+		want       interface{}
+		got        interface{}
+		wantResult bool
+		wantErrMsg string
+	}{
+		"equal": {
+			want:       []int{1, 0},
+			got:        []int{1, 0},
+			wantResult: true,
+		},
+		"not_equal_ints": {
+			want:       []int{1, 0},
+			got:        []int{1},
+			wantErrMsg: "values not as expected:\n\twant: [1 0]\n\tgot:  [1]",
+		},
+		"not_equal_bytes": {
+			want:       []byte{0x0A, 0xFF},
+			got:        []byte(nil),
+			wantErrMsg: "values not as expected:\n\twant: [0A FF]\n\tgot:  []",
+		},
+		"not_equal_blocks": {
+			want:       [][]byte{{0x01}, {0x02, 0x03}},
+			got:        [][]byte{{0x01}},
+			wantErrMsg: "values not as expected:\n\twant: [[01], [02 03]]\n\tgot:  [[01]]",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			r := &testingTRecorder{}
+			// act
+			got := assertEqual(r, "values", tc.want, tc.got)
+			// assert
+			assert.Equal(t, tc.wantResult, got)
+			if tc.wantResult {
+				assert.Empty(t, r.errors)
+				return
+			}
+			assert.Equal(t, []string{tc.wantErrMsg}, r.errors)
+		})
+	}
+}
@@ -0,0 +1,360 @@
+package gobottest
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/system"
+)
+
+const (
+	digitalPinEdgeFalling = 1 // same value as used by system.WithPinEventOnFallingEdge()
+	digitalPinEdgeRising  = 2 // same value as used by system.WithPinEventOnRisingEdge()
+	digitalPinEdgeBoth    = 3 // same value as used by system.WithPinEventOnBothEdges()
+)
+
+// DigitalPin is a simulated GPIO, which implements gobot.DigitalPinner. The written values are recorded and the
+// values to read can be scripted. An edge event handler, given by the pin options, is called on each change of the
+// simulated input value.
+type DigitalPin struct {
+	id           string
+	mutex        sync.Mutex
+	exported     bool
+	value        int
+	reads        []int
+	written      []int
+	readErr      error
+	writeErr     error
+	exportErr    error
+	label        string
+	direction    string
+	activeLow    bool
+	bias         int
+	drive        int
+	debounce     time.Duration
+	edge         int
+	edgeHandler  func(lineOffset int, timestamp time.Duration, detectedEdge string, seqno uint32, lseqno uint32)
+	pollInterval time.Duration
+	seqno        uint32
+}
+
+// NewDigitalPin creates a new simulated pin with the given id, normally created by the Adaptor on first usage.
+func NewDigitalPin(id string) *DigitalPin {
+	return &DigitalPin{id: id, direction: system.IN}
+}
+
+// Export (interface gobot.DigitalPinner) simulates the export of the pin.
+func (p *DigitalPin) Export() error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.exportErr != nil {
+		return p.exportErr
+	}
+	p.exported = true
+	return nil
+}
+
+// Unexport (interface gobot.DigitalPinner) simulates the release of the pin.
+func (p *DigitalPin) Unexport() error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.exported = false
+	return nil
+}
+
+// Read (interface gobot.DigitalPinner) returns the next scripted value or the current value of the pin.
+func (p *DigitalPin) Read() (int, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.readErr != nil {
+		return 0, p.readErr
+	}
+
+	if len(p.reads) > 0 {
+		p.value = p.reads[0]
+		p.reads = p.reads[1:]
+	}
+	return p.value, nil
+}
+
+// Write (interface gobot.DigitalPinner) records the given value and sets it as the current value of the pin.
+func (p *DigitalPin) Write(val int) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.writeErr != nil {
+		return p.writeErr
+	}
+
+	p.written = append(p.written, val)
+	p.value = val
+	return nil
+}
+
+// ApplyOptions (interface gobot.DigitalPinOptionApplier) applies all given options to the simulated pin.
+func (p *DigitalPin) ApplyOptions(options ...func(gobot.DigitalPinOptioner) bool) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for _, option := range options {
+		option((*digitalPinOptions)(p))
+	}
+	return nil
+}
+
+// DirectionBehavior (interface gobot.DigitalPinValuer) returns the direction of the pin.
+func (p *DigitalPin) DirectionBehavior() string {
+	return p.Direction()
+}
+
+// ID returns the id of the pin.
+func (p *DigitalPin) ID() string {
+	return p.id
+}
+
+// IsExported returns whether the pin was exported and not unexported afterwards.
+func (p *DigitalPin) IsExported() bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.exported
+}
+
+// Value returns the current value of the pin.
+func (p *DigitalPin) Value() int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.value
+}
+
+// SetValue simulates an input change of the pin. On change, the edge event handler is called, if the edge matches.
+func (p *DigitalPin) SetValue(val int) {
+	p.mutex.Lock()
+	old := p.value
+	p.value = val
+	handler, edge := p.edgeHandler, p.edge
+	p.mutex.Unlock()
+
+	if handler == nil || old == val {
+		return
+	}
+
+	detectedEdge := system.DigitalPinEventRisingEdge
+	if val == 0 {
+		detectedEdge = system.DigitalPinEventFallingEdge
+	}
+
+	if edge == digitalPinEdgeBoth ||
+		(edge == digitalPinEdgeRising && val != 0) ||
+		(edge == digitalPinEdgeFalling && val == 0) {
+		p.mutex.Lock()
+		p.seqno++
+		seqno := p.seqno
+		p.mutex.Unlock()
+		handler(0, time.Duration(time.Now().UnixNano()), detectedEdge, seqno, seqno)
+	}
+}
+
+// ScriptReads adds values, which will be returned by the next calls of Read(). The last value will stay as the
+// current value of the pin.
+func (p *DigitalPin) ScriptReads(vals ...int) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.reads = append(p.reads, vals...)
+}
+
+// Written returns all values written to the pin, since creation or the last call of Reset().
+func (p *DigitalPin) Written() []int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return append([]int(nil), p.written...)
+}
+
+// AssertWritten checks that exactly the given values were written to the pin.
+func (p *DigitalPin) AssertWritten(t TestingT, want ...int) bool {
+	t.Helper()
+	return assertEqual(t, fmt.Sprintf("written values of digital pin '%s'", p.id), want, p.Written())
+}
+
+// SetReadError simulates an error on each call of Read(), nil deactivates the simulation.
+func (p *DigitalPin) SetReadError(err error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.readErr = err
+}
+
+// SetWriteError simulates an error on each call of Write(), nil deactivates the simulation.
+func (p *DigitalPin) SetWriteError(err error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.writeErr = err
+}
+
+// SetExportError simulates an error on each call of Export(), nil deactivates the simulation.
+func (p *DigitalPin) SetExportError(err error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.exportErr = err
+}
+
+// Label returns the label, given by an option.
+func (p *DigitalPin) Label() string {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.label
+}
+
+// Direction returns the direction of the pin, given by an option ("in" by default).
+func (p *DigitalPin) Direction() string {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.direction
+}
+
+// IsActiveLow returns whether the pin was configured to active low by an option.
+func (p *DigitalPin) IsActiveLow() bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.activeLow
+}
+
+// Bias returns the bias, given by an option.
+func (p *DigitalPin) Bias() int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.bias
+}
+
+// Drive returns the drive, given by an option.
+func (p *DigitalPin) Drive() int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.drive
+}
+
+// Debounce returns the debounce period, given by an option.
+func (p *DigitalPin) Debounce() time.Duration {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.debounce
+}
+
+// PollInterval returns the interval for edge detection by polling, given by an option.
+func (p *DigitalPin) PollInterval() time.Duration {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.pollInterval
+}
+
+// HasEdgeHandler returns whether an edge event handler was given by an option.
+func (p *DigitalPin) HasEdgeHandler() bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.edgeHandler != nil
+}
+
+// Reset clears all recorded values and scripted reads.
+func (p *DigitalPin) Reset() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.written = nil
+	p.reads = nil
+}
+
+// digitalPinOptions implements gobot.DigitalPinOptioner for the simulated pin. The mutex is already locked by
+// ApplyOptions().
+type digitalPinOptions DigitalPin
+
+func (o *digitalPinOptions) SetLabel(label string) bool {
+	if o.label == label {
+		return false
+	}
+	o.label = label
+	return true
+}
+
+func (o *digitalPinOptions) SetDirectionOutput(initialState int) bool {
+	if o.direction == system.OUT && o.value == initialState {
+		return false
+	}
+	o.direction = system.OUT
+	o.value = initialState
+	return true
+}
+
+func (o *digitalPinOptions) SetDirectionInput() bool {
+	if o.direction == system.IN {
+		return false
+	}
+	o.direction = system.IN
+	return true
+}
+
+func (o *digitalPinOptions) SetActiveLow() bool {
+	if o.activeLow {
+		return false
+	}
+	o.activeLow = true
+	return true
+}
+
+func (o *digitalPinOptions) SetBias(bias int) bool {
+	if o.bias == bias {
+		return false
+	}
+	o.bias = bias
+	return true
+}
+
+func (o *digitalPinOptions) SetDrive(drive int) bool {
+	if o.drive == drive {
+		return false
+	}
+	o.drive = drive
+	return true
+}
+
+func (o *digitalPinOptions) SetDebounce(period time.Duration) bool {
+	if o.debounce == period {
+		return false
+	}
+	o.debounce = period
+	return true
+}
+
+func (o *digitalPinOptions) SetEventHandlerForEdge(
+	handler func(lineOffset int, timestamp time.Duration, detectedEdge string, seqno uint32, lseqno uint32),
+	edge int,
+) bool {
+	o.edgeHandler = handler
+	o.edge = edge
+	return true
+}
+
+func (o *digitalPinOptions) SetPollForEdgeDetection(pollInterval time.Duration, pollQuitChan chan struct{}) bool {
+	if o.pollInterval == pollInterval {
+		return false
+	}
+	o.pollInterval = pollInterval
+	return true
+}
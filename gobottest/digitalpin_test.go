@@ -0,0 +1,132 @@
+package gobottest
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/system"
+)
+
+// make sure that this implementation fulfills all the required interfaces
+var (
+	_ gobot.DigitalPinner      = (*DigitalPin)(nil)
+	_ gobot.DigitalPinValuer   = (*DigitalPin)(nil)
+	_ gobot.DigitalPinOptioner = (*digitalPinOptions)(nil)
+)
+
+func TestDigitalPinReadWrite(t *testing.T) {
+	// arrange
+	p := NewDigitalPin("7")
+	p.ScriptReads(1, 0, 1)
+	// act & assert reads
+	for _, want := range []int{1, 0, 1, 1} {
+		got, err := p.Read()
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+	// act & assert writes
+	require.NoError(t, p.Write(0))
+	require.NoError(t, p.Write(1))
+	assert.Equal(t, []int{0, 1}, p.Written())
+	assert.Equal(t, 1, p.Value())
+	assert.True(t, p.AssertWritten(t, 0, 1))
+	p.Reset()
+	assert.True(t, p.AssertWritten(t))
+}
+
+func TestDigitalPinErrors(t *testing.T) {
+	// arrange
+	p := NewDigitalPin("7")
+	p.SetReadError(fmt.Errorf("read error"))
+	p.SetWriteError(fmt.Errorf("write error"))
+	p.SetExportError(fmt.Errorf("export error"))
+	// act & assert
+	_, err := p.Read()
+	require.EqualError(t, err, "read error")
+	require.EqualError(t, p.Write(1), "write error")
+	require.EqualError(t, p.Export(), "export error")
+	assert.Nil(t, p.Written())
+	assert.False(t, p.IsExported())
+}
+
+func TestDigitalPinApplyOptions(t *testing.T) {
+	// arrange
+	p := NewDigitalPin("7")
+	require.NoError(t, p.Export())
+	// act
+	err := p.ApplyOptions(
+		system.WithPinLabel("button"),
+		system.WithPinDirectionOutput(1),
+		system.WithPinActiveLow(),
+		system.WithPinPullUp(),
+		system.WithPinOpenDrain(),
+		system.WithPinDebounce(5*time.Millisecond),
+		system.WithPinPollForEdgeDetection(10*time.Millisecond, nil),
+	)
+	// assert
+	require.NoError(t, err)
+	assert.True(t, p.IsExported())
+	assert.Equal(t, "7", p.ID())
+	assert.Equal(t, "button", p.Label())
+	assert.Equal(t, system.OUT, p.Direction())
+	assert.Equal(t, system.OUT, p.DirectionBehavior())
+	assert.Equal(t, 1, p.Value())
+	assert.True(t, p.IsActiveLow())
+	assert.Equal(t, 3, p.Bias())
+	assert.Equal(t, 1, p.Drive())
+	assert.Equal(t, 5*time.Millisecond, p.Debounce())
+	assert.Equal(t, 10*time.Millisecond, p.PollInterval())
+	assert.False(t, p.HasEdgeHandler())
+	require.NoError(t, p.Unexport())
+	assert.False(t, p.IsExported())
+}
+
+func TestDigitalPinSetValueEdges(t *testing.T) {
+	tests := map[string]struct {
+		option    func(func(int, time.Duration, string, uint32, uint32)) func(gobot.DigitalPinOptioner) bool
+		wantEdges []string
+	}{
+		"rising": {
+			option:    system.WithPinEventOnRisingEdge,
+			wantEdges: []string{system.DigitalPinEventRisingEdge, system.DigitalPinEventRisingEdge},
+		},
+		"falling": {
+			option:    system.WithPinEventOnFallingEdge,
+			wantEdges: []string{system.DigitalPinEventFallingEdge},
+		},
+		"both": {
+			option: system.WithPinEventOnBothEdges,
+			wantEdges: []string{
+				system.DigitalPinEventRisingEdge, system.DigitalPinEventFallingEdge,
+				system.DigitalPinEventRisingEdge,
+			},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			p := NewDigitalPin("7")
+			var gotEdges []string
+			var gotSeqnos []uint32
+			handler := func(_ int, _ time.Duration, edge string, seqno uint32, _ uint32) {
+				gotEdges = append(gotEdges, edge)
+				gotSeqnos = append(gotSeqnos, seqno)
+			}
+			require.NoError(t, p.ApplyOptions(tc.option(handler)))
+			// act
+			for _, val := range []int{1, 1, 0, 1} {
+				p.SetValue(val)
+			}
+			// assert
+			assert.True(t, p.HasEdgeHandler())
+			assert.Equal(t, tc.wantEdges, gotEdges)
+			assert.Len(t, gotSeqnos, len(tc.wantEdges))
+			assert.Equal(t, uint32(1), gotSeqnos[0])
+		})
+	}
+}
@@ -0,0 +1,20 @@
+/*
+Package gobottest provides a simulated board with simulated devices, to test drivers and robots without hardware.
+
+The Adaptor implements the interfaces of the gpio, aio, i2c and spi drivers. Digital, PWM and analog pins are created
+on first usage, i2c and spi devices needs to be added before the driver is started. All simulated devices can be
+scripted to respond with given values or errors and record the interactions for the later assertion, e.g.
+
+	a := gobottest.NewAdaptor()
+	led := gpio.NewLedDriver(a, "7")
+	_ = led.Start()
+	_ = led.Toggle()
+	a.Digital("7").AssertWritten(t, 1)
+
+	dev := gobottest.NewI2cDevice()
+	dev.SetRegisters(0x0F, 0x58) // chip id
+	a.AddI2cDevice(1, 0x77, dev)
+
+The simulated devices are safe for concurrent use, so cyclic reading drivers can be tested as well.
+*/
+package gobottest // import "gobot.io/x/gobot/v2/gobottest"
@@ -0,0 +1,92 @@
+package gobottest_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2/drivers/aio"
+	"gobot.io/x/gobot/v2/drivers/gpio"
+	"gobot.io/x/gobot/v2/drivers/i2c"
+	"gobot.io/x/gobot/v2/drivers/spi"
+	"gobot.io/x/gobot/v2/gobottest"
+)
+
+// The tests in this file shows the usage of the test kit together with some drivers of gobot.
+
+func TestWithLedDriver(t *testing.T) {
+	// arrange
+	a := gobottest.NewAdaptor()
+	d := gpio.NewLedDriver(a, "7")
+	require.NoError(t, d.Start())
+	// act
+	require.NoError(t, d.Toggle())
+	require.NoError(t, d.Toggle())
+	// assert
+	a.Digital("7").AssertWritten(t, 1, 0)
+}
+
+func TestWithButtonDriver(t *testing.T) {
+	// arrange
+	a := gobottest.NewAdaptor()
+	d := gpio.NewButtonDriver(a, "3", gpio.WithButtonPollInterval(time.Millisecond))
+	require.NoError(t, d.Start())
+	pushed := make(chan struct{})
+	_ = d.Once(gpio.ButtonPush, func(interface{}) { close(pushed) })
+	// act
+	a.Digital("3").SetValue(1)
+	// assert
+	select {
+	case <-pushed:
+	case <-time.After(time.Second):
+		require.Fail(t, "button push was not detected")
+	}
+	assert.True(t, d.Active())
+	require.NoError(t, d.Halt())
+}
+
+func TestWithAnalogSensorDriver(t *testing.T) {
+	// arrange
+	a := gobottest.NewAdaptor()
+	a.Analog("A0").SetValue(512)
+	d := aio.NewAnalogSensorDriver(a, "A0")
+	// act
+	got, err := d.Read()
+	// assert
+	require.NoError(t, err)
+	assert.InDelta(t, 512.0, got, 0.0)
+}
+
+func TestWithI2cDriver(t *testing.T) {
+	// arrange
+	a := gobottest.NewAdaptor()
+	dev := gobottest.NewI2cDevice()
+	dev.SetRegisters(0x10, 0x01, 0x2C) // raw value 300 for the continuous high resolution mode
+	a.AddI2cDevice(0, 0x23, dev)
+	d := i2c.NewBH1750Driver(a)
+	require.NoError(t, d.Start())
+	// act
+	lux, err := d.Lux()
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, 250, lux)
+	dev.AssertWritten(t, []byte{0x10}) // mode written on start
+}
+
+func TestWithSpiDriver(t *testing.T) {
+	// arrange
+	a := gobottest.NewAdaptor()
+	dev := gobottest.NewSpiDevice()
+	dev.SetResponder(func(tx []byte) []byte { return []byte{0x00, 0x02, 0x34} })
+	a.AddSpiDevice(0, 0, dev)
+	d := spi.NewMCP3008Driver(a)
+	require.NoError(t, d.Start())
+	// act
+	val, err := d.Read(1)
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, 0x234, val)
+	dev.AssertTransmitted(t, []byte{0x01, 0x90, 0x00})
+}
@@ -0,0 +1,293 @@
+package gobottest
+
+import (
+	"fmt"
+	"sync"
+)
+
+// I2cDevice is a simulated i2c device with 256 registers of 8 bit, which implements gobot.I2cOperations. The first
+// byte of each write sets the register pointer, further bytes are written to the registers. Reads start at the
+// register pointer. The register pointer is auto-incremented on each byte written or read, like most i2c devices do.
+//
+// Each write transaction is recorded. Values to read can be scripted per register and hooks can simulate the
+// reaction of the device on writes, e.g. to set a "ready" flag after a measurement was started.
+type I2cDevice struct {
+	mutex     sync.Mutex
+	registers [256]byte
+	pointer   uint8
+	reads     map[uint8][]byte
+	hooks     map[uint8]func(val byte)
+	written   [][]byte
+	readErr   error
+	writeErr  error
+	closed    bool
+}
+
+// NewI2cDevice creates a new simulated i2c device with all registers set to zero.
+func NewI2cDevice() *I2cDevice {
+	return &I2cDevice{
+		reads: make(map[uint8][]byte),
+		hooks: make(map[uint8]func(val byte)),
+	}
+}
+
+// Read (interface io.Reader) reads from the registers, starting at the register pointer.
+func (d *I2cDevice) Read(b []byte) (int, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if err := d.readError(); err != nil {
+		return 0, err
+	}
+
+	for i := range b {
+		b[i] = d.readRegister()
+	}
+	return len(b), nil
+}
+
+// Write (interface io.Writer) sets the register pointer by the first byte and writes the other bytes to the
+// registers.
+func (d *I2cDevice) Write(b []byte) (int, error) {
+	if err := d.write(b); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+// Close (interface io.Closer) marks the device as closed.
+func (d *I2cDevice) Close() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.closed = true
+	return nil
+}
+
+// ReadByte (interface gobot.I2cOperations) reads a byte from the current register.
+func (d *I2cDevice) ReadByte() (byte, error) {
+	b := []byte{0}
+	if _, err := d.Read(b); err != nil {
+		return 0, err
+	}
+	return b[0], nil
+}
+
+// ReadByteData (interface gobot.BusOperations) reads a byte from the given register.
+func (d *I2cDevice) ReadByteData(reg uint8) (uint8, error) {
+	if err := d.write([]byte{reg}); err != nil {
+		return 0, err
+	}
+	return d.ReadByte()
+}
+
+// ReadWordData (interface gobot.I2cOperations) reads a 16 bit value from the given register, low byte first.
+func (d *I2cDevice) ReadWordData(reg uint8) (uint16, error) {
+	b := []byte{0, 0}
+	if err := d.ReadBlockData(reg, b); err != nil {
+		return 0, err
+	}
+	return uint16(b[1])<<8 | uint16(b[0]), nil
+}
+
+// ReadBlockData (interface gobot.BusOperations) fills the given buffer, starting from the given register.
+func (d *I2cDevice) ReadBlockData(reg uint8, b []byte) error {
+	if err := d.write([]byte{reg}); err != nil {
+		return err
+	}
+	_, err := d.Read(b)
+	return err
+}
+
+// WriteByte (interface gobot.BusOperations) writes a single byte, which sets the register pointer.
+func (d *I2cDevice) WriteByte(val byte) error {
+	return d.write([]byte{val})
+}
+
+// WriteByteData (interface gobot.BusOperations) writes the value to the given register.
+func (d *I2cDevice) WriteByteData(reg uint8, val uint8) error {
+	return d.write([]byte{reg, val})
+}
+
+// WriteWordData (interface gobot.I2cOperations) writes the 16 bit value to the given register, low byte first.
+func (d *I2cDevice) WriteWordData(reg uint8, val uint16) error {
+	return d.write([]byte{reg, byte(val), byte(val >> 8)})
+}
+
+// WriteBlockData (interface gobot.BusOperations) writes the data, starting from the given register.
+func (d *I2cDevice) WriteBlockData(reg uint8, data []byte) error {
+	return d.write(append([]byte{reg}, data...))
+}
+
+// WriteBytes (interface gobot.BusOperations) writes the data, the first byte sets the register pointer.
+func (d *I2cDevice) WriteBytes(data []byte) error {
+	return d.write(data)
+}
+
+// SetRegisters sets the content of the registers, starting at the given register.
+func (d *I2cDevice) SetRegisters(reg uint8, vals ...byte) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	for i, val := range vals {
+		d.registers[reg+uint8(i)] = val
+	}
+}
+
+// Registers returns the content of the given count of registers, starting at the given register.
+func (d *I2cDevice) Registers(reg uint8, count int) []byte {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	vals := make([]byte, count)
+	for i := range vals {
+		vals[i] = d.registers[reg+uint8(i)]
+	}
+	return vals
+}
+
+// Register returns the content of the given register.
+func (d *I2cDevice) Register(reg uint8) byte {
+	return d.Registers(reg, 1)[0]
+}
+
+// ScriptReads adds values, which will be returned by the next reads of the given register, before the register
+// content is returned again. The last value read stays as content of the register. This is useful e.g. to simulate
+// a "busy" flag, which is cleared after some polls.
+func (d *I2cDevice) ScriptReads(reg uint8, vals ...byte) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.reads[reg] = append(d.reads[reg], vals...)
+}
+
+// OnWrite registers a hook, which is called after each write of the given register with the written value. The
+// hook is called without locking the device, so the content of the registers can be changed by the hook.
+func (d *I2cDevice) OnWrite(reg uint8, hook func(val byte)) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.hooks[reg] = hook
+}
+
+// Written returns all write transactions, since creation or the last call of Reset(). This includes writes of the
+// register pointer before a read.
+func (d *I2cDevice) Written() [][]byte {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	var written [][]byte
+	for _, w := range d.written {
+		written = append(written, append([]byte(nil), w...))
+	}
+	return written
+}
+
+// AssertWritten checks that exactly the given write transactions were done.
+func (d *I2cDevice) AssertWritten(t TestingT, want ...[]byte) bool {
+	t.Helper()
+	return assertEqual(t, "written data of i2c device", want, d.Written())
+}
+
+// AssertRegisters checks the content of the registers, starting at the given register.
+func (d *I2cDevice) AssertRegisters(t TestingT, reg uint8, want ...byte) bool {
+	t.Helper()
+	return assertEqual(t, fmt.Sprintf("content of i2c registers starting at 0x%02X", reg), want,
+		d.Registers(reg, len(want)))
+}
+
+// IsClosed returns whether the device was closed.
+func (d *I2cDevice) IsClosed() bool {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.closed
+}
+
+// SetReadError simulates an error on each read, nil deactivates the simulation.
+func (d *I2cDevice) SetReadError(err error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.readErr = err
+}
+
+// SetWriteError simulates an error on each write, nil deactivates the simulation.
+func (d *I2cDevice) SetWriteError(err error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.writeErr = err
+}
+
+// Reset clears all recorded transactions and scripted reads, the content of the registers is not changed.
+func (d *I2cDevice) Reset() {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.written = nil
+	d.reads = make(map[uint8][]byte)
+}
+
+func (d *I2cDevice) open() {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.closed = false
+}
+
+func (d *I2cDevice) write(b []byte) error {
+	type hookCall struct {
+		hook func(val byte)
+		val  byte
+	}
+
+	d.mutex.Lock()
+	if d.closed {
+		d.mutex.Unlock()
+		return fmt.Errorf("the i2c device is closed")
+	}
+	if d.writeErr != nil {
+		d.mutex.Unlock()
+		return d.writeErr
+	}
+
+	d.written = append(d.written, append([]byte(nil), b...))
+
+	var calls []hookCall
+	if len(b) > 0 {
+		d.pointer = b[0]
+		for _, val := range b[1:] {
+			d.registers[d.pointer] = val
+			if hook, ok := d.hooks[d.pointer]; ok {
+				calls = append(calls, hookCall{hook: hook, val: val})
+			}
+			d.pointer++
+		}
+	}
+	d.mutex.Unlock()
+
+	for _, c := range calls {
+		c.hook(c.val)
+	}
+	return nil
+}
+
+// readError needs to be called with locked mutex.
+func (d *I2cDevice) readError() error {
+	if d.closed {
+		return fmt.Errorf("the i2c device is closed")
+	}
+	return d.readErr
+}
+
+// readRegister needs to be called with locked mutex.
+func (d *I2cDevice) readRegister() byte {
+	reg := d.pointer
+	if vals := d.reads[reg]; len(vals) > 0 {
+		d.registers[reg] = vals[0]
+		d.reads[reg] = vals[1:]
+	}
+	d.pointer++
+	return d.registers[reg]
+}
@@ -0,0 +1,96 @@
+package gobottest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+)
+
+// make sure that this implementation fulfills all the required interfaces
+var _ gobot.I2cOperations = (*I2cDevice)(nil)
+
+func TestI2cDeviceRead(t *testing.T) {
+	// arrange
+	d := NewI2cDevice()
+	d.SetRegisters(0x10, 0x01, 0x02, 0x03)
+	// act & assert
+	val, err := d.ReadByteData(0x10)
+	require.NoError(t, err)
+	assert.Equal(t, uint8(0x01), val)
+	val, err = d.ReadByte() // auto increment
+	require.NoError(t, err)
+	assert.Equal(t, uint8(0x02), val)
+	word, err := d.ReadWordData(0x11)
+	require.NoError(t, err)
+	assert.Equal(t, uint16(0x0302), word)
+	block := make([]byte, 3)
+	require.NoError(t, d.ReadBlockData(0x10, block))
+	assert.Equal(t, []byte{0x01, 0x02, 0x03}, block)
+	assert.True(t, d.AssertWritten(t, []byte{0x10}, []byte{0x11}, []byte{0x10}))
+}
+
+func TestI2cDeviceWrite(t *testing.T) {
+	// arrange
+	d := NewI2cDevice()
+	// act
+	require.NoError(t, d.WriteByteData(0x20, 0xAA))
+	require.NoError(t, d.WriteWordData(0x21, 0x1234))
+	require.NoError(t, d.WriteBlockData(0x30, []byte{0x01, 0x02}))
+	require.NoError(t, d.WriteBytes([]byte{0x40, 0x05}))
+	require.NoError(t, d.WriteByte(0x20))
+	n, err := d.Write([]byte{0xFF, 0x01, 0x02}) // register pointer wraps around
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+	assert.True(t, d.AssertRegisters(t, 0x20, 0xAA, 0x34, 0x12))
+	assert.True(t, d.AssertRegisters(t, 0x30, 0x01, 0x02))
+	assert.Equal(t, byte(0x05), d.Register(0x40))
+	assert.Equal(t, []byte{0x01}, d.Registers(0xFF, 1))
+	assert.Equal(t, byte(0x02), d.Register(0x00))
+	assert.Len(t, d.Written(), 6)
+	d.Reset()
+	assert.Nil(t, d.Written())
+}
+
+func TestI2cDeviceScriptAndHook(t *testing.T) {
+	// arrange
+	d := NewI2cDevice()
+	d.ScriptReads(0x01, 0x80, 0x80) // busy for two polls
+	d.OnWrite(0x00, func(val byte) {
+		// simulate a measurement started by the command
+		d.SetRegisters(0x02, val+1)
+	})
+	// act
+	require.NoError(t, d.WriteByteData(0x00, 0x41))
+	var polls []byte
+	for i := 0; i < 3; i++ {
+		val, err := d.ReadByteData(0x01)
+		require.NoError(t, err)
+		polls = append(polls, val)
+	}
+	// assert
+	assert.Equal(t, []byte{0x80, 0x80, 0x80}, polls) // last scripted value stays
+	assert.Equal(t, byte(0x42), d.Register(0x02))
+}
+
+func TestI2cDeviceErrors(t *testing.T) {
+	// arrange
+	d := NewI2cDevice()
+	d.SetReadError(fmt.Errorf("read error"))
+	d.SetWriteError(fmt.Errorf("write error"))
+	// act & assert
+	_, err := d.Read(make([]byte, 1))
+	require.EqualError(t, err, "read error")
+	_, err = d.ReadByteData(0x01)
+	require.EqualError(t, err, "write error")
+	d.SetWriteError(nil)
+	require.NoError(t, d.Close())
+	assert.True(t, d.IsClosed())
+	require.EqualError(t, d.WriteByte(0x01), "the i2c device is closed")
+	_, err = d.ReadByte()
+	require.EqualError(t, err, "the i2c device is closed")
+}
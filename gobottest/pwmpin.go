@@ -0,0 +1,228 @@
+package gobottest
+
+import (
+	"fmt"
+	"sync"
+)
+
+// PWMPin is a simulated PWM output, which implements gobot.PWMPinner. All set duty cycles are recorded. Values
+// written by PwmWrite() and ServoWrite() of the Adaptor are recorded separately.
+type PWMPin struct {
+	id          string
+	mutex       sync.Mutex
+	exported    bool
+	enabled     bool
+	normal      bool
+	period      uint32
+	dutyCycle   uint32
+	dutyCycles  []uint32
+	pwmWrites   []byte
+	servoWrites []byte
+	writeErr    error
+}
+
+// NewPWMPin creates a new simulated PWM pin with the given id, normally created by the Adaptor on first usage.
+func NewPWMPin(id string) *PWMPin {
+	return &PWMPin{id: id, normal: true}
+}
+
+// Export (interface gobot.PWMPinner) simulates the export of the pin.
+func (p *PWMPin) Export() error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.exported = true
+	return nil
+}
+
+// Unexport (interface gobot.PWMPinner) simulates the release of the pin.
+func (p *PWMPin) Unexport() error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.exported = false
+	return nil
+}
+
+// Enabled (interface gobot.PWMPinner) returns the enabled state of the pin.
+func (p *PWMPin) Enabled() (bool, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.enabled, nil
+}
+
+// SetEnabled (interface gobot.PWMPinner) sets the enabled state of the pin.
+func (p *PWMPin) SetEnabled(val bool) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.writeErr != nil {
+		return p.writeErr
+	}
+	p.enabled = val
+	return nil
+}
+
+// Polarity (interface gobot.PWMPinner) returns true for normal polarity.
+func (p *PWMPin) Polarity() (bool, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.normal, nil
+}
+
+// SetPolarity (interface gobot.PWMPinner) sets the polarity of the pin.
+func (p *PWMPin) SetPolarity(normal bool) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.writeErr != nil {
+		return p.writeErr
+	}
+	p.normal = normal
+	return nil
+}
+
+// Period (interface gobot.PWMPinner) returns the period in nanoseconds.
+func (p *PWMPin) Period() (uint32, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.period, nil
+}
+
+// SetPeriod (interface gobot.PWMPinner) sets the period in nanoseconds.
+func (p *PWMPin) SetPeriod(period uint32) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.writeErr != nil {
+		return p.writeErr
+	}
+	if period < p.dutyCycle {
+		return fmt.Errorf("period %d of PWM pin '%s' is smaller than the duty cycle %d", period, p.id, p.dutyCycle)
+	}
+	p.period = period
+	return nil
+}
+
+// DutyCycle (interface gobot.PWMPinner) returns the duty cycle in nanoseconds.
+func (p *PWMPin) DutyCycle() (uint32, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.dutyCycle, nil
+}
+
+// SetDutyCycle (interface gobot.PWMPinner) sets and records the duty cycle in nanoseconds.
+func (p *PWMPin) SetDutyCycle(duty uint32) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.writeErr != nil {
+		return p.writeErr
+	}
+	if duty > p.period {
+		return fmt.Errorf("duty cycle %d of PWM pin '%s' exceeds the period %d", duty, p.id, p.period)
+	}
+	p.dutyCycle = duty
+	p.dutyCycles = append(p.dutyCycles, duty)
+	return nil
+}
+
+// ID returns the id of the pin.
+func (p *PWMPin) ID() string {
+	return p.id
+}
+
+// IsExported returns whether the pin was exported and not unexported afterwards.
+func (p *PWMPin) IsExported() bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.exported
+}
+
+// DutyCycles returns all duty cycles set, since creation or the last call of Reset().
+func (p *PWMPin) DutyCycles() []uint32 {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return append([]uint32(nil), p.dutyCycles...)
+}
+
+// PwmWritten returns all values written by the PwmWrite() function of the adaptor.
+func (p *PWMPin) PwmWritten() []byte {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return append([]byte(nil), p.pwmWrites...)
+}
+
+// ServoWritten returns all values written by the ServoWrite() function of the adaptor.
+func (p *PWMPin) ServoWritten() []byte {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return append([]byte(nil), p.servoWrites...)
+}
+
+// AssertDutyCycles checks that exactly the given duty cycles were set.
+func (p *PWMPin) AssertDutyCycles(t TestingT, want ...uint32) bool {
+	t.Helper()
+	return assertEqual(t, fmt.Sprintf("duty cycles of PWM pin '%s'", p.id), want, p.DutyCycles())
+}
+
+// AssertPwmWritten checks that exactly the given values were written by PwmWrite().
+func (p *PWMPin) AssertPwmWritten(t TestingT, want ...byte) bool {
+	t.Helper()
+	return assertEqual(t, fmt.Sprintf("PWM values of pin '%s'", p.id), want, p.PwmWritten())
+}
+
+// AssertServoWritten checks that exactly the given values were written by ServoWrite().
+func (p *PWMPin) AssertServoWritten(t TestingT, want ...byte) bool {
+	t.Helper()
+	return assertEqual(t, fmt.Sprintf("servo values of pin '%s'", p.id), want, p.ServoWritten())
+}
+
+// SetWriteError simulates an error on each call of a setter and the write functions of the adaptor, nil
+// deactivates the simulation.
+func (p *PWMPin) SetWriteError(err error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.writeErr = err
+}
+
+// Reset clears all recorded values.
+func (p *PWMPin) Reset() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.dutyCycles = nil
+	p.pwmWrites = nil
+	p.servoWrites = nil
+}
+
+func (p *PWMPin) pwmWrite(val byte) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.writeErr != nil {
+		return p.writeErr
+	}
+	p.pwmWrites = append(p.pwmWrites, val)
+	return nil
+}
+
+func (p *PWMPin) servoWrite(val byte) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.writeErr != nil {
+		return p.writeErr
+	}
+	p.servoWrites = append(p.servoWrites, val)
+	return nil
+}
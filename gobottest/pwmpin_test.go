@@ -0,0 +1,48 @@
+package gobottest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+)
+
+// make sure that this implementation fulfills all the required interfaces
+var _ gobot.PWMPinner = (*PWMPin)(nil)
+
+func TestPWMPin(t *testing.T) {
+	// arrange
+	p := NewPWMPin("33")
+	// act
+	require.NoError(t, p.Export())
+	require.NoError(t, p.SetPeriod(20000000))
+	require.NoError(t, p.SetDutyCycle(1500000))
+	require.NoError(t, p.SetDutyCycle(2000000))
+	require.NoError(t, p.SetPolarity(false))
+	require.NoError(t, p.SetEnabled(true))
+	// assert
+	assert.True(t, p.IsExported())
+	assert.Equal(t, "33", p.ID())
+	period, _ := p.Period()
+	assert.Equal(t, uint32(20000000), period)
+	duty, _ := p.DutyCycle()
+	assert.Equal(t, uint32(2000000), duty)
+	normal, _ := p.Polarity()
+	assert.False(t, normal)
+	enabled, _ := p.Enabled()
+	assert.True(t, enabled)
+	assert.True(t, p.AssertDutyCycles(t, 1500000, 2000000))
+	// assert errors
+	require.EqualError(t, p.SetDutyCycle(20000001), "duty cycle 20000001 of PWM pin '33' exceeds the period 20000000")
+	require.EqualError(t, p.SetPeriod(100), "period 100 of PWM pin '33' is smaller than the duty cycle 2000000")
+	p.SetWriteError(fmt.Errorf("write error"))
+	require.EqualError(t, p.SetEnabled(false), "write error")
+	// assert unexport and reset
+	require.NoError(t, p.Unexport())
+	assert.False(t, p.IsExported())
+	p.Reset()
+	assert.Nil(t, p.DutyCycles())
+}
@@ -0,0 +1,194 @@
+package gobottest
+
+import (
+	"fmt"
+	"sync"
+
+	"gobot.io/x/gobot/v2"
+)
+
+// SpiDevice is a simulated SPI device, which implements gobot.SpiSystemDevicer and gobot.SpiSystemTransferer. The
+// Adaptor wraps the device with the common SPI connection of gobot, so the drivers use the same functions like on
+// real hardware.
+//
+// Each transmitted data block is recorded. The received data are taken from the scripted responses, or created by
+// the responder function. Without both, zeros are received.
+type SpiDevice struct {
+	mutex       sync.Mutex
+	responses   [][]byte
+	responder   func(tx []byte) []byte
+	transmitted [][]byte
+	txRxErr     error
+	closed      bool
+	mode        int
+	bits        int
+	maxSpeed    int64
+}
+
+// NewSpiDevice creates a new simulated SPI device.
+func NewSpiDevice() *SpiDevice {
+	return &SpiDevice{}
+}
+
+// TxRx (interface gobot.SpiSystemDevicer) records the transmitted data and fills rx with the response.
+func (d *SpiDevice) TxRx(tx []byte, rx []byte) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.closed {
+		return fmt.Errorf("the SPI device is closed")
+	}
+	if d.txRxErr != nil {
+		return d.txRxErr
+	}
+
+	d.txRx(tx, rx)
+	return nil
+}
+
+// Transfer (interface gobot.SpiSystemTransferer) handles each transfer of the message like a call of TxRx().
+func (d *SpiDevice) Transfer(xfers []gobot.SpiXfer) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.closed {
+		return fmt.Errorf("the SPI device is closed")
+	}
+	if d.txRxErr != nil {
+		return d.txRxErr
+	}
+
+	for _, xfer := range xfers {
+		tx := xfer.Tx
+		if tx == nil {
+			tx = make([]byte, len(xfer.Rx))
+		}
+		d.txRx(tx, xfer.Rx)
+	}
+	return nil
+}
+
+// Close (interface gobot.SpiSystemDevicer) marks the device as closed.
+func (d *SpiDevice) Close() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.closed = true
+	return nil
+}
+
+// ScriptResponses adds responses, which will be received by the next transmissions, one response per transmission.
+// A shorter response is filled with zeros.
+func (d *SpiDevice) ScriptResponses(rx ...[]byte) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.responses = append(d.responses, rx...)
+}
+
+// SetResponder sets a function, which creates the response for each transmission, when no scripted response is
+// left. This is useful to simulate register based devices.
+func (d *SpiDevice) SetResponder(responder func(tx []byte) []byte) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.responder = responder
+}
+
+// Transmitted returns all transmitted data blocks, since creation or the last call of Reset().
+func (d *SpiDevice) Transmitted() [][]byte {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	var transmitted [][]byte
+	for _, tx := range d.transmitted {
+		transmitted = append(transmitted, append([]byte(nil), tx...))
+	}
+	return transmitted
+}
+
+// AssertTransmitted checks that exactly the given data blocks were transmitted.
+func (d *SpiDevice) AssertTransmitted(t TestingT, want ...[]byte) bool {
+	t.Helper()
+	return assertEqual(t, "transmitted data of SPI device", want, d.Transmitted())
+}
+
+// Mode returns the SPI mode, requested by the driver.
+func (d *SpiDevice) Mode() int {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.mode
+}
+
+// Bits returns the number of bits per word, requested by the driver.
+func (d *SpiDevice) Bits() int {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.bits
+}
+
+// MaxSpeed returns the maximum speed in Hz, requested by the driver.
+func (d *SpiDevice) MaxSpeed() int64 {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.maxSpeed
+}
+
+// IsClosed returns whether the device was closed.
+func (d *SpiDevice) IsClosed() bool {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.closed
+}
+
+// SetTxRxError simulates an error on each transmission, nil deactivates the simulation.
+func (d *SpiDevice) SetTxRxError(err error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.txRxErr = err
+}
+
+// Reset clears all recorded transmissions and scripted responses.
+func (d *SpiDevice) Reset() {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.transmitted = nil
+	d.responses = nil
+}
+
+func (d *SpiDevice) open(mode, bits int, maxSpeed int64) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.closed = false
+	d.mode = mode
+	d.bits = bits
+	d.maxSpeed = maxSpeed
+}
+
+// txRx needs to be called with locked mutex.
+func (d *SpiDevice) txRx(tx []byte, rx []byte) {
+	d.transmitted = append(d.transmitted, append([]byte(nil), tx...))
+
+	var response []byte
+	switch {
+	case len(d.responses) > 0:
+		response = d.responses[0]
+		d.responses = d.responses[1:]
+	case d.responder != nil:
+		response = d.responder(tx)
+	}
+
+	for i := range rx {
+		rx[i] = 0
+		if i < len(response) {
+			rx[i] = response[i]
+		}
+	}
+}
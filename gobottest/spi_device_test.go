@@ -0,0 +1,72 @@
+package gobottest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+)
+
+// make sure that this implementation fulfills all the required interfaces
+var (
+	_ gobot.SpiSystemDevicer    = (*SpiDevice)(nil)
+	_ gobot.SpiSystemTransferer = (*SpiDevice)(nil)
+)
+
+func TestSpiDeviceTxRx(t *testing.T) {
+	// arrange
+	d := NewSpiDevice()
+	d.ScriptResponses([]byte{0x01, 0x02, 0x03}, []byte{0x04})
+	d.SetResponder(func(tx []byte) []byte { return []byte{tx[0] + 1} })
+	rx1 := make([]byte, 3)
+	rx2 := make([]byte, 2)
+	rx3 := make([]byte, 1)
+	// act
+	require.NoError(t, d.TxRx([]byte{0xA0, 0x00, 0x00}, rx1))
+	require.NoError(t, d.TxRx([]byte{0xA1, 0x00}, rx2))
+	require.NoError(t, d.TxRx([]byte{0x10}, rx3))
+	require.NoError(t, d.TxRx([]byte{0x20}, nil))
+	// assert
+	assert.Equal(t, []byte{0x01, 0x02, 0x03}, rx1)
+	assert.Equal(t, []byte{0x04, 0x00}, rx2)
+	assert.Equal(t, []byte{0x11}, rx3)
+	assert.True(t, d.AssertTransmitted(t, []byte{0xA0, 0x00, 0x00}, []byte{0xA1, 0x00}, []byte{0x10}, []byte{0x20}))
+	d.Reset()
+	assert.Nil(t, d.Transmitted())
+}
+
+func TestSpiDeviceTransfer(t *testing.T) {
+	// arrange
+	d := NewSpiDevice()
+	d.ScriptResponses(nil, []byte{0xAB, 0xCD})
+	rx := make([]byte, 2)
+	xfers := []gobot.SpiXfer{{Tx: []byte{0x03}}, {Rx: rx}}
+	// act
+	err := d.Transfer(xfers)
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0xAB, 0xCD}, rx)
+	assert.True(t, d.AssertTransmitted(t, []byte{0x03}, []byte{0x00, 0x00}))
+}
+
+func TestSpiDeviceErrors(t *testing.T) {
+	// arrange
+	d := NewSpiDevice()
+	d.SetTxRxError(fmt.Errorf("txrx error"))
+	// act & assert
+	require.EqualError(t, d.TxRx([]byte{0x01}, nil), "txrx error")
+	require.EqualError(t, d.Transfer([]gobot.SpiXfer{{Tx: []byte{0x01}}}), "txrx error")
+	require.NoError(t, d.Close())
+	assert.True(t, d.IsClosed())
+	require.EqualError(t, d.TxRx([]byte{0x01}, nil), "the SPI device is closed")
+	require.EqualError(t, d.Transfer(nil), "the SPI device is closed")
+	// act & assert reopen
+	d.open(1, 16, 1000)
+	assert.False(t, d.IsClosed())
+	assert.Equal(t, 1, d.Mode())
+	assert.Equal(t, 16, d.Bits())
+	assert.Equal(t, int64(1000), d.MaxSpeed())
+}
@@ -2,20 +2,28 @@ package adaptors
 
 import (
 	"fmt"
+	"path"
+	"regexp"
 	"sync"
 
+	multierror "github.com/hashicorp/go-multierror"
+
 	"gobot.io/x/gobot/v2"
 	"gobot.io/x/gobot/v2/system"
 )
 
 type analogPinTranslator func(pin string) (path string, r, w bool, bufLen uint16, err error)
 
+// analogBufferChannelPattern matches the raw value file of an IIO channel, e.g. "in_voltage0_raw"
+var analogBufferChannelPattern = regexp.MustCompile(`^(in_[a-z]+[0-9]*)_raw$`)
+
 // AnalogPinsAdaptor is a adaptor for analog pins, normally used for composition in platforms.
 // It is also usable for general sysfs access.
 type AnalogPinsAdaptor struct {
 	sys       *system.Accesser
 	translate analogPinTranslator
 	pins      map[string]gobot.AnalogPinner
+	buffers   []gobot.AnalogBufferSystemDevicer
 	mutex     sync.Mutex
 }
 
@@ -39,13 +47,20 @@ func (a *AnalogPinsAdaptor) Connect() error {
 	return nil
 }
 
-// Finalize closes connection to analog pins
+// Finalize closes connection to analog pins and all opened analog buffers
 func (a *AnalogPinsAdaptor) Finalize() error {
 	a.mutex.Lock()
 	defer a.mutex.Unlock()
 
+	var err error
+	for _, buffer := range a.buffers {
+		if e := buffer.Close(); e != nil {
+			err = multierror.Append(err, e)
+		}
+	}
+	a.buffers = nil
 	a.pins = nil
-	return nil
+	return err
 }
 
 // AnalogRead returns an analog value from specified pin or identifier, defined by the translation function.
@@ -74,6 +89,50 @@ func (a *AnalogPinsAdaptor) AnalogWrite(id string, val int) error {
 	return pin.Write(val)
 }
 
+// OpenAnalogBuffer starts the buffered sampling of the given pins. This is only possible for pins of the same IIO
+// device (e.g. "/sys/bus/iio/devices/iio:device0/in_voltage1_raw"). The values of each scan are in the order of the
+// given pins. The buffer is closed on Finalize(), if not closed before.
+func (a *AnalogPinsAdaptor) OpenAnalogBuffer(
+	ids []string,
+	options ...func(gobot.AnalogBufferOptioner) bool,
+) (gobot.AnalogBufferSystemDevicer, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.pins == nil {
+		return nil, fmt.Errorf("not connected for analog buffer of pins %v", ids)
+	}
+
+	var devicePath string
+	channels := make([]string, len(ids))
+	for i, id := range ids {
+		pinPath, r, _, _, err := a.translate(id)
+		if err != nil {
+			return nil, err
+		}
+
+		m := analogBufferChannelPattern.FindStringSubmatch(path.Base(pinPath))
+		if !r || m == nil {
+			return nil, fmt.Errorf("the pin '%s' is not an IIO input channel", id)
+		}
+
+		dir := path.Dir(pinPath)
+		if devicePath != "" && dir != devicePath {
+			return nil, fmt.Errorf("the pin '%s' belongs to '%s', but all pins needs to be on '%s'", id, dir, devicePath)
+		}
+		devicePath = dir
+		channels[i] = m[1]
+	}
+
+	buffer, err := a.sys.NewAnalogBuffer(devicePath, channels, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	a.buffers = append(a.buffers, buffer)
+	return buffer, nil
+}
+
 // analogPin initializes the pin for analog access and returns matched pin for specified identifier.
 func (a *AnalogPinsAdaptor) analogPin(id string) (gobot.AnalogPinner, error) {
 	if a.pins == nil {
@@ -247,3 +247,82 @@ func TestAnalogRead(t *testing.T) {
 		})
 	}
 }
+
+func TestOpenAnalogBuffer(t *testing.T) {
+	const iioDevice0 = "/sys/bus/iio/devices/iio:device0"
+	translate := func(id string) (string, bool, bool, uint16, error) {
+		switch id {
+		case "A0", "A1":
+			return iioDevice0 + "/in_voltage" + id[1:] + "_raw", true, false, 10, nil
+		case "other_device":
+			return "/sys/bus/iio/devices/iio:device1/in_voltage0_raw", true, false, 10, nil
+		case "no_channel":
+			return iioDevice0 + "/in_voltage_scale", true, false, 10, nil
+		}
+		return "", false, false, 0, fmt.Errorf("'%s' is not a valid id of a analog pin", id)
+	}
+	tests := map[string]struct {
+		pins         []string
+		wantChannels []string
+		wantErr      string
+	}{
+		"two_pins": {
+			pins:         []string{"A1", "A0"},
+			wantChannels: []string{"in_voltage1", "in_voltage0"},
+		},
+		"error_other_device": {
+			pins: []string{"A0", "other_device"},
+			wantErr: "the pin 'other_device' belongs to '/sys/bus/iio/devices/iio:device1', but all pins needs to be " +
+				"on '/sys/bus/iio/devices/iio:device0'",
+		},
+		"error_no_channel": {
+			pins:    []string{"no_channel"},
+			wantErr: "the pin 'no_channel' is not an IIO input channel",
+		},
+		"error_unknown_pin": {
+			pins:    []string{"A2"},
+			wantErr: "'A2' is not a valid id of a analog pin",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			sys := system.NewAccesser()
+			fs := sys.UseMockFilesystem([]string{
+				iioDevice0 + "/scan_elements/in_voltage0_en",
+				iioDevice0 + "/scan_elements/in_voltage0_index",
+				iioDevice0 + "/scan_elements/in_voltage0_type",
+				iioDevice0 + "/scan_elements/in_voltage1_en",
+				iioDevice0 + "/scan_elements/in_voltage1_index",
+				iioDevice0 + "/scan_elements/in_voltage1_type",
+				iioDevice0 + "/buffer/length",
+				iioDevice0 + "/buffer/enable",
+				"/dev/iio:device0",
+			})
+			fs.Files[iioDevice0+"/scan_elements/in_voltage0_type"].Contents = "le:u12/16>>0"
+			fs.Files[iioDevice0+"/scan_elements/in_voltage1_index"].Contents = "1"
+			fs.Files[iioDevice0+"/scan_elements/in_voltage1_type"].Contents = "le:u12/16>>0"
+			a := NewAnalogPinsAdaptor(sys, translate)
+			_, err := a.OpenAnalogBuffer(tc.pins)
+			require.ErrorContains(t, err, "not connected")
+			require.NoError(t, a.Connect())
+			// act
+			buffer, err := a.OpenAnalogBuffer(tc.pins)
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				assert.Nil(t, buffer)
+				assert.Empty(t, a.buffers)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantChannels, buffer.Channels())
+			assert.Len(t, a.buffers, 1)
+			assert.Equal(t, "1", fs.Files[iioDevice0+"/buffer/enable"].Contents)
+			// assert the buffer is closed on finalize
+			require.NoError(t, a.Finalize())
+			assert.Empty(t, a.buffers)
+			assert.Equal(t, "0", fs.Files[iioDevice0+"/buffer/enable"].Contents)
+		})
+	}
+}
@@ -219,6 +219,37 @@ func (a *DigitalPinsAdaptor) DigitalWrite(id string, val byte) error {
 	return pin.Write(int(val))
 }
 
+// NotifyDigitalEdges calls the given handler with the new value on each rising and falling edge of the input pin. A
+// nil handler stops the notification. This needs the gpiod access, for sysfs an error is returned, so the caller can
+// fall back to polling.
+func (a *DigitalPinsAdaptor) NotifyDigitalEdges(id string, handler func(val int)) error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.sys.IsSysfsDigitalPinAccess() {
+		return fmt.Errorf("edge notification is not supported for pin %s with sysfs access", id)
+	}
+
+	// the handler is only replaced, if the edge changes, so reset it first
+	opts := []func(gobot.DigitalPinOptioner) bool{
+		func(o gobot.DigitalPinOptioner) bool { return o.SetEventHandlerForEdge(nil, 0) },
+	}
+	if handler != nil {
+		edgeHandler := func(_ int, _ time.Duration, detectedEdge string, _ uint32, _ uint32) {
+			switch detectedEdge {
+			case system.DigitalPinEventRisingEdge:
+				handler(1)
+			case system.DigitalPinEventFallingEdge:
+				handler(0)
+			}
+		}
+		opts = append(opts, system.WithPinDirectionInput(), system.WithPinEventOnBothEdges(edgeHandler))
+	}
+
+	_, err := a.digitalPin(id, opts...)
+	return err
+}
+
 func (a *DigitalPinsAdaptor) digitalPin(
 	id string,
 	opts ...func(gobot.DigitalPinOptioner) bool,
@@ -21,6 +21,7 @@ var (
 	_ gobot.DigitalPinnerProvider = (*DigitalPinsAdaptor)(nil)
 	_ gpio.DigitalReader          = (*DigitalPinsAdaptor)(nil)
 	_ gpio.DigitalWriter          = (*DigitalPinsAdaptor)(nil)
+	_ gpio.DigitalEdgeNotifier    = (*DigitalPinsAdaptor)(nil)
 )
 
 func initTestDigitalPinsAdaptorWithMockedFilesystem(mockPaths []string) (*DigitalPinsAdaptor, *system.MockFilesystem) {
@@ -157,6 +158,37 @@ func TestDigitalRead(t *testing.T) {
 	require.ErrorContains(t, err, "write error")
 }
 
+func TestNotifyDigitalEdges(t *testing.T) {
+	tests := map[string]struct {
+		access  string
+		handler func(int)
+		wantErr string
+	}{
+		"gpiod_register":   {handler: func(int) {}},
+		"gpiod_unregister": {},
+		"sysfs":            {access: "sysfs", handler: func(int) {}, wantErr: "not supported for pin 13 with sysfs"},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			sys := system.NewAccesser()
+			_ = sys.UseDigitalPinAccessWithMockFs(tc.access, []string{})
+			a := NewDigitalPinsAdaptor(sys, testDigitalPinTranslator)
+			require.NoError(t, a.Connect())
+			// act
+			err := a.NotifyDigitalEdges("13", tc.handler)
+			// assert
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				assert.Empty(t, a.pins)
+				return
+			}
+			require.NoError(t, err)
+			assert.Contains(t, a.pins, "13")
+		})
+	}
+}
+
 func TestDigitalPinConcurrency(t *testing.T) {
 	oldProcs := runtime.GOMAXPROCS(0)
 	runtime.GOMAXPROCS(8)
@@ -13,26 +13,95 @@ import (
 
 type i2cBusNumberValidator func(busNumber int) error
 
+// i2cBusGpioPins contains the pin id's of an i2c bus, driven by GPIO's.
+type i2cBusGpioPins struct {
+	sdaPinID string
+	sclPinID string
+}
+
+// i2cBusConfiguration contains all changeable attributes of the adaptor.
+type i2cBusConfiguration struct {
+	gpioBuses    map[int]i2cBusGpioPins // the key is the bus number
+	gpioMaxSpeed int64
+	pinProvider  gobot.DigitalPinnerProvider
+	tracer       *system.BusTracer
+	pinRegistry  *PinRegistry
+}
+
 // I2cBusAdaptor is a adaptor for i2c bus, normally used for composition in platforms.
 type I2cBusAdaptor struct {
 	sys              *system.Accesser
 	validateNumber   i2cBusNumberValidator
 	defaultBusNumber int
+	i2cBusCfg        *i2cBusConfiguration
 	mutex            sync.Mutex
 	buses            map[int]gobot.I2cSystemDevicer
 }
 
 // NewI2cBusAdaptor provides the access to i2c buses of the board. The validator is used to check the bus number,
 // which is given by user, to the abilities of the board.
-func NewI2cBusAdaptor(sys *system.Accesser, v i2cBusNumberValidator, defaultBusNr int) *I2cBusAdaptor {
+//
+// Options:
+//
+//	"WithI2cGpioAccess"
+//	"WithI2cGpioMaxSpeed"
+//	"WithI2cDigitalPinnerProvider"
+//	"WithI2cBusTracer"
+//	"WithI2cPinRegistry"
+func NewI2cBusAdaptor(
+	sys *system.Accesser,
+	v i2cBusNumberValidator,
+	defaultBusNr int,
+	opts ...I2cBusOptionApplier,
+) *I2cBusAdaptor {
 	a := &I2cBusAdaptor{
 		sys:              sys,
 		validateNumber:   v,
 		defaultBusNumber: defaultBusNr,
+		i2cBusCfg:        &i2cBusConfiguration{gpioBuses: make(map[int]i2cBusGpioPins)},
 	}
+
+	for _, o := range opts {
+		o.apply(a.i2cBusCfg)
+	}
+
 	return a
 }
 
+// WithI2cGpioAccess adds an i2c bus with the given number, which is driven by the given GPIO's (bit banging). This
+// makes a further bus available, e.g. on boards with only one hardware bus. The bus number must not be used by a
+// hardware bus of the board, because it will be not validated. External pull-up resistors are needed for both lines.
+// The platform needs to provide the access to the digital pins, see "WithI2cDigitalPinnerProvider".
+func WithI2cGpioAccess(busNum int, sdaPin, sclPin string) i2cBusGpioAccessOption {
+	return i2cBusGpioAccessOption{busNum: busNum, sdaPinID: sdaPin, sclPinID: sclPin}
+}
+
+// WithI2cGpioMaxSpeed substitute the default speed of 100 kHz for all i2c buses driven by GPIO's. The speed is given
+// in Hz and will be limited to 400 kHz.
+func WithI2cGpioMaxSpeed(speed int64) i2cBusGpioMaxSpeedOption {
+	return i2cBusGpioMaxSpeedOption(speed)
+}
+
+// WithI2cDigitalPinnerProvider sets the provider of the digital pins, which are used for i2c buses driven by GPIO's.
+// This option is normally applied by the platform and not by the user.
+func WithI2cDigitalPinnerProvider(p gobot.DigitalPinnerProvider) i2cBusDigitalPinnerProviderOption {
+	return i2cBusDigitalPinnerProviderOption{provider: p}
+}
+
+// WithI2cBusTracer activates the recording of all transactions on all i2c buses of the board by the given tracer.
+// The location of the buses in the recording is the character device, e.g. "/dev/i2c-1", or "i2c-gpio-<number>"
+// for buses driven by GPIO's.
+func WithI2cBusTracer(tracer *system.BusTracer) i2cBusTracerOption {
+	return i2cBusTracerOption{tracer: tracer}
+}
+
+// WithI2cPinRegistry sets the registry, which records the pins used by the i2c buses. The pins of a bus driven by
+// GPIO's, or the pins defined by the platform for a hardware bus, are claimed when the bus is used for the first
+// time. This option is normally applied by the platform and not by the user.
+func WithI2cPinRegistry(r *PinRegistry) i2cBusPinRegistryOption {
+	return i2cBusPinRegistryOption{registry: r}
+}
+
 // Connect prepares the connection to i2c buses.
 func (a *I2cBusAdaptor) Connect() error {
 	a.mutex.Lock()
@@ -48,12 +117,15 @@ func (a *I2cBusAdaptor) Finalize() error {
 	defer a.mutex.Unlock()
 
 	var err error
-	for _, bus := range a.buses {
+	for busNum, bus := range a.buses {
 		if bus != nil {
 			if e := bus.Close(); e != nil {
 				err = multierror.Append(err, e)
 			}
 		}
+		if a.i2cBusCfg.pinRegistry != nil {
+			a.i2cBusCfg.pinRegistry.ReleasePins(i2cBusPinOwner(busNum))
+		}
 	}
 	a.buses = nil
 	return err
@@ -64,26 +136,94 @@ func (a *I2cBusAdaptor) GetI2cConnection(address int, busNum int) (i2c.Connectio
 	a.mutex.Lock()
 	defer a.mutex.Unlock()
 
+	bus, err := a.bus(busNum)
+	if err != nil {
+		return nil, err
+	}
+	return i2c.NewConnection(bus, address), nil
+}
+
+// ProbeI2cAddress returns true, if a device acknowledges the given address on the specified i2c bus. This implements
+// the interface i2c.Prober, which is used to scan the bus.
+func (a *I2cBusAdaptor) ProbeI2cAddress(address int, busNum int) (bool, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	bus, err := a.bus(busNum)
+	if err != nil {
+		return false, err
+	}
+	prober, ok := bus.(gobot.I2cProber)
+	if !ok {
+		return false, fmt.Errorf("the i2c bus %d does not support probing of addresses", busNum)
+	}
+	return prober.ProbeAddress(address)
+}
+
+// DefaultI2cBus returns the default i2c bus number for this platform.
+func (a *I2cBusAdaptor) DefaultI2cBus() int {
+	return a.defaultBusNumber
+}
+
+// bus returns the cached bus or creates a new one, needs to be called with locked mutex.
+func (a *I2cBusAdaptor) bus(busNum int) (gobot.I2cSystemDevicer, error) {
 	if a.buses == nil {
 		return nil, fmt.Errorf("not connected")
 	}
 
 	bus := a.buses[busNum]
 	if bus == nil {
-		err := a.validateNumber(busNum)
-		if err != nil {
+		var err error
+		if bus, err = a.createBus(busNum); err != nil {
 			return nil, err
 		}
-		bus, err = a.sys.NewI2cDevice(fmt.Sprintf("/dev/i2c-%d", busNum))
-		if err != nil {
+		a.buses[busNum] = bus
+	}
+	return bus, nil
+}
+
+func (a *I2cBusAdaptor) createBus(busNum int) (gobot.I2cSystemDevicer, error) {
+	var bus gobot.I2cSystemDevicer
+	var location string
+	var err error
+
+	registry := a.i2cBusCfg.pinRegistry
+	if pins, ok := a.i2cBusCfg.gpioBuses[busNum]; ok {
+		if registry != nil {
+			if err := registry.ClaimPins(gobot.PinFunctionI2c, i2cBusPinOwner(busNum), pins.sdaPinID,
+				pins.sclPinID); err != nil {
+				return nil, err
+			}
+		}
+		location = fmt.Sprintf("i2c-gpio-%d", busNum)
+		bus, err = a.sys.NewI2cGpioDevice(a.i2cBusCfg.pinProvider, pins.sdaPinID, pins.sclPinID,
+			a.i2cBusCfg.gpioMaxSpeed)
+	} else {
+		if err := a.validateNumber(busNum); err != nil {
 			return nil, err
 		}
-		a.buses[busNum] = bus
+		if registry != nil {
+			pins := registry.BusPins(gobot.PinFunctionI2c, busNum)
+			if err := registry.ClaimPins(gobot.PinFunctionI2c, i2cBusPinOwner(busNum), pins...); err != nil {
+				return nil, err
+			}
+		}
+		location = fmt.Sprintf("/dev/i2c-%d", busNum)
+		bus, err = a.sys.NewI2cDevice(location)
 	}
-	return i2c.NewConnection(bus, address), nil
+	if err != nil {
+		if registry != nil {
+			registry.ReleasePins(i2cBusPinOwner(busNum))
+		}
+		return nil, err
+	}
+
+	if a.i2cBusCfg.tracer != nil {
+		bus = system.NewTracedI2cDevice(bus, location, a.i2cBusCfg.tracer)
+	}
+	return bus, nil
 }
 
-// DefaultI2cBus returns the default i2c bus number for this platform.
-func (a *I2cBusAdaptor) DefaultI2cBus() int {
-	return a.defaultBusNumber
+func i2cBusPinOwner(busNum int) busPinOwner {
+	return busPinOwner{function: gobot.PinFunctionI2c, busNum: busNum}
 }
@@ -3,16 +3,21 @@ package adaptors
 import (
 	"fmt"
 	"testing"
+	"unsafe"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"gobot.io/x/gobot/v2"
 	"gobot.io/x/gobot/v2/drivers/i2c"
 	"gobot.io/x/gobot/v2/system"
 )
 
 // make sure that this Adaptor fulfills all the required interfaces
-var _ i2c.Connector = (*I2cBusAdaptor)(nil)
+var (
+	_ i2c.Connector = (*I2cBusAdaptor)(nil)
+	_ i2c.Prober    = (*I2cBusAdaptor)(nil)
+)
 
 const i2cBus1 = "/dev/i2c-1"
 
@@ -74,6 +79,39 @@ func TestI2cGetI2cConnection(t *testing.T) {
 	assert.Empty(t, a.buses)
 }
 
+func TestI2cProbeI2cAddress(t *testing.T) {
+	// arrange
+	sys := system.NewAccesser()
+	msc := sys.UseMockSyscall()
+	msc.Impl = func(trap, a1, a2 uintptr, a3 unsafe.Pointer) (uintptr, uintptr, system.SyscallErrno) {
+		if a2 == system.I2C_FUNCS {
+			*(*uint64)(a3) = system.I2C_FUNC_SMBUS_QUICK
+		}
+		return 0, 0, 0
+	}
+	sys.UseMockFilesystem([]string{i2cBus1})
+	a := NewI2cBusAdaptor(sys, func(busNr int) error {
+		if busNr > 1 {
+			return fmt.Errorf("%d not valid", busNr)
+		}
+		return nil
+	}, 1)
+	require.NoError(t, a.Connect())
+	// act
+	got, err := a.ProbeI2cAddress(0x20, 1)
+	// assert
+	require.NoError(t, err)
+	assert.True(t, got)
+	assert.Len(t, a.buses, 1)
+	// assert invalid bus gets error
+	_, err = a.ProbeI2cAddress(0x20, 99)
+	require.ErrorContains(t, err, "99 not valid")
+	// assert unconnected gets error
+	require.NoError(t, a.Finalize())
+	_, err = a.ProbeI2cAddress(0x20, 1)
+	require.ErrorContains(t, err, "not connected")
+}
+
 func TestI2cFinalize(t *testing.T) {
 	// arrange
 	a, fs := initTestI2cAdaptorWithMockedFilesystem([]string{i2cBus1})
@@ -113,3 +151,69 @@ func TestI2cGetDefaultBus(t *testing.T) {
 	a := NewI2cBusAdaptor(nil, nil, 2)
 	assert.Equal(t, 2, a.DefaultI2cBus())
 }
+
+func TestI2cGetI2cConnection_gpioBus(t *testing.T) {
+	// arrange
+	sys := system.NewAccesser()
+	_ = sys.UseDigitalPinAccessWithMockFs("mock", []string{})
+	dpa := NewDigitalPinsAdaptor(sys, func(pin string) (string, int, error) { return "", 0, nil })
+	require.NoError(t, dpa.Connect())
+	validator := func(busNr int) error { return fmt.Errorf("%d not valid", busNr) }
+	a := NewI2cBusAdaptor(sys, validator, 1, WithI2cDigitalPinnerProvider(dpa), WithI2cGpioAccess(3, "7", "11"))
+	require.NoError(t, a.Connect())
+	// act
+	con, err := a.GetI2cConnection(0x10, 3)
+	// assert: the gpio bus is not validated and created
+	require.NoError(t, err)
+	assert.Len(t, a.buses, 1)
+	// assert: the mocked pins are always low, so the bus is blocked
+	_, err = con.Write([]byte{0x01})
+	require.ErrorContains(t, err, "timeout of 35ms reached while waiting for SCL high (sda: 7, scl: 11)")
+	// assert: hardware bus is still validated
+	_, err = a.GetI2cConnection(0x10, 1)
+	require.ErrorContains(t, err, "1 not valid")
+}
+
+func TestI2cGetI2cConnection_gpioBusWithoutProvider(t *testing.T) {
+	// arrange
+	a := NewI2cBusAdaptor(system.NewAccesser(), nil, 1, WithI2cGpioAccess(3, "7", "11"))
+	require.NoError(t, a.Connect())
+	// act
+	con, err := a.GetI2cConnection(0x10, 3)
+	// assert
+	require.ErrorContains(t, err, "a digital pin provider is needed for the i2c bus on GPIO's")
+	assert.Nil(t, con)
+	assert.Empty(t, a.buses)
+}
+
+func TestI2cGetI2cConnection_pinRegistry(t *testing.T) {
+	// arrange
+	sys := system.NewAccesser()
+	sys.UseMockSyscall()
+	sys.UseMockFilesystem([]string{i2cBus1})
+	_ = sys.UseDigitalPinAccessWithMockFs("mock", []string{})
+	dpa := NewDigitalPinsAdaptor(sys, func(pin string) (string, int, error) { return "", 0, nil })
+	require.NoError(t, dpa.Connect())
+	r := NewPinRegistry()
+	r.SetBusPins(gobot.PinFunctionI2c, 1, "3", "5")
+	require.NoError(t, r.ClaimPin("11", gobot.PinFunctionGpio, &pinTestOwner{name: "LED-1"}))
+	a := NewI2cBusAdaptor(sys, func(int) error { return nil }, 1, WithI2cDigitalPinnerProvider(dpa),
+		WithI2cGpioAccess(3, "7", "11"), WithI2cPinRegistry(r))
+	require.NoError(t, a.Connect())
+	// act & assert: the pins of the hardware bus are claimed
+	_, err := a.GetI2cConnection(0x10, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []gobot.PinAllocation{
+		{Pin: "3", Function: gobot.PinFunctionI2c, Owner: "i2c bus 1"},
+		{Pin: "5", Function: gobot.PinFunctionI2c, Owner: "i2c bus 1"},
+		{Pin: "11", Function: gobot.PinFunctionGpio, Owner: "LED-1"},
+	}, r.PinAllocations())
+	// act & assert: the pins of the gpio bus are in use
+	_, err = a.GetI2cConnection(0x10, 3)
+	require.EqualError(t, err,
+		"pin '11' can not be used by 'i2c bus 3' as i2c, because it is already used by 'LED-1' as gpio")
+	assert.Len(t, a.buses, 1)
+	// act & assert: the pins are released on finalize
+	require.NoError(t, a.Finalize())
+	assert.Len(t, r.PinAllocations(), 1)
+}
@@ -0,0 +1,78 @@
+package adaptors
+
+import (
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/system"
+)
+
+// I2cBusOptionApplier needs to be implemented by each configurable option type
+type I2cBusOptionApplier interface {
+	apply(cfg *i2cBusConfiguration)
+}
+
+// i2cBusGpioAccessOption is the type for applying an additional i2c bus, driven by the given GPIO's (bit banging).
+type i2cBusGpioAccessOption struct {
+	busNum   int
+	sdaPinID string
+	sclPinID string
+}
+
+// i2cBusGpioMaxSpeedOption is the type for applying another than the default speed of 100 kHz for i2c buses
+// driven by GPIO's.
+type i2cBusGpioMaxSpeedOption int64
+
+// i2cBusDigitalPinnerProviderOption is the type for applying the provider of digital pins, used for i2c buses
+// driven by GPIO's.
+type i2cBusDigitalPinnerProviderOption struct {
+	provider gobot.DigitalPinnerProvider
+}
+
+// i2cBusTracerOption is the type for applying a tracer, which records all transactions of the i2c buses.
+type i2cBusTracerOption struct {
+	tracer *system.BusTracer
+}
+
+// i2cBusPinRegistryOption is the type for applying a registry, which records the pins used by the i2c buses.
+type i2cBusPinRegistryOption struct {
+	registry *PinRegistry
+}
+
+func (o i2cBusGpioAccessOption) String() string {
+	return "i2c bus on GPIO's option"
+}
+
+func (o i2cBusGpioMaxSpeedOption) String() string {
+	return "max speed option for i2c buses on GPIO's"
+}
+
+func (o i2cBusDigitalPinnerProviderOption) String() string {
+	return "digital pin provider option for i2c buses on GPIO's"
+}
+
+func (o i2cBusTracerOption) String() string {
+	return "tracer option for i2c buses"
+}
+
+func (o i2cBusPinRegistryOption) String() string {
+	return "pin registry option for i2c buses"
+}
+
+func (o i2cBusGpioAccessOption) apply(cfg *i2cBusConfiguration) {
+	cfg.gpioBuses[o.busNum] = i2cBusGpioPins{sdaPinID: o.sdaPinID, sclPinID: o.sclPinID}
+}
+
+func (o i2cBusGpioMaxSpeedOption) apply(cfg *i2cBusConfiguration) {
+	cfg.gpioMaxSpeed = int64(o)
+}
+
+func (o i2cBusDigitalPinnerProviderOption) apply(cfg *i2cBusConfiguration) {
+	cfg.pinProvider = o.provider
+}
+
+func (o i2cBusTracerOption) apply(cfg *i2cBusConfiguration) {
+	cfg.tracer = o.tracer
+}
+
+func (o i2cBusPinRegistryOption) apply(cfg *i2cBusConfiguration) {
+	cfg.pinRegistry = o.registry
+}
@@ -0,0 +1,75 @@
+package adaptors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2/system"
+)
+
+func TestWithI2cGpioAccess(t *testing.T) {
+	// This is a general test, that options are applied by using the WithI2cGpioAccess() option.
+	// All other configuration options can also be tested by With..(val).apply(cfg).
+	// arrange & act
+	a := NewI2cBusAdaptor(nil, nil, 1, WithI2cGpioAccess(2, "3", "5"), WithI2cGpioAccess(4, "7", "11"))
+	// assert
+	assert.Equal(t, map[int]i2cBusGpioPins{
+		2: {sdaPinID: "3", sclPinID: "5"},
+		4: {sdaPinID: "7", sclPinID: "11"},
+	}, a.i2cBusCfg.gpioBuses)
+}
+
+func TestWithI2cGpioMaxSpeed(t *testing.T) {
+	// arrange
+	const newSpeed = int64(10000)
+	cfg := &i2cBusConfiguration{gpioMaxSpeed: 123}
+	// act
+	WithI2cGpioMaxSpeed(newSpeed).apply(cfg)
+	// assert
+	assert.Equal(t, newSpeed, cfg.gpioMaxSpeed)
+}
+
+func TestWithI2cDigitalPinnerProvider(t *testing.T) {
+	// arrange
+	p := NewDigitalPinsAdaptor(nil, nil)
+	cfg := &i2cBusConfiguration{}
+	// act
+	WithI2cDigitalPinnerProvider(p).apply(cfg)
+	// assert
+	assert.Equal(t, p, cfg.pinProvider)
+}
+
+func TestWithI2cBusTracer(t *testing.T) {
+	// arrange
+	tracer := system.NewBusTracer(0)
+	sys := system.NewAccesser()
+	sys.UseMockSyscall()
+	sys.UseMockFilesystem([]string{i2cBus1})
+	a := NewI2cBusAdaptor(sys, func(int) error { return nil }, 1, WithI2cBusTracer(tracer))
+	require.NoError(t, a.Connect())
+	con, err := a.GetI2cConnection(0x20, 1)
+	require.NoError(t, err)
+	// act
+	_, err = con.Write([]byte{0x12, 0x34})
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, tracer, a.i2cBusCfg.tracer)
+	got := tracer.Transactions()
+	require.Len(t, got, 1)
+	assert.Equal(t, "/dev/i2c-1", got[0].Location)
+	assert.Equal(t, 0x20, got[0].Address)
+	assert.Equal(t, system.BusTraceWrite, got[0].Direction)
+	assert.Equal(t, []byte{0x12, 0x34}, got[0].Data)
+}
+
+func TestWithI2cPinRegistry(t *testing.T) {
+	// arrange
+	r := NewPinRegistry()
+	cfg := &i2cBusConfiguration{}
+	// act
+	WithI2cPinRegistry(r).apply(cfg)
+	// assert
+	assert.Equal(t, r, cfg.pinRegistry)
+}
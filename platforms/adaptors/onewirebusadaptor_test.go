@@ -0,0 +1,97 @@
+package adaptors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2/drivers/onewire"
+	"gobot.io/x/gobot/v2/system"
+)
+
+// make sure that this OneWireBusAdaptor fulfills all the required interfaces
+var (
+	_ onewire.Connector    = (*OneWireBusAdaptor)(nil)
+	_ onewire.DeviceFinder = (*OneWireBusAdaptor)(nil)
+)
+
+func initTestOneWireBusAdaptorWithMockedFilesystem(files []string) (*OneWireBusAdaptor, *system.MockFilesystem) {
+	sys := system.NewAccesser()
+	fs := sys.UseMockFilesystem(files)
+	a := NewOneWireBusAdaptor(sys)
+	if err := a.Connect(); err != nil {
+		panic(err)
+	}
+	return a, fs
+}
+
+func TestNewOneWireBusAdaptor(t *testing.T) {
+	// arrange
+	a := NewOneWireBusAdaptor(nil)
+	// act & assert
+	_, err := a.GetOneWireConnection(0x28, 0x1234)
+	require.ErrorContains(t, err, "not connected")
+}
+
+func TestGetOneWireConnection(t *testing.T) {
+	// arrange
+	a, _ := initTestOneWireBusAdaptorWithMockedFilesystem([]string{
+		"/sys/bus/w1/devices/28-000000001234/temperature",
+		"/sys/bus/w1/devices/28-000000005678/temperature",
+	})
+	assert.Empty(t, a.connections)
+	// act
+	con1, err1 := a.GetOneWireConnection(0x28, 0x1234)
+	// assert
+	require.NoError(t, err1)
+	assert.Equal(t, "28-000000001234", con1.ID())
+	assert.Len(t, a.connections, 1)
+	// assert cached connection
+	con1a, err2 := a.GetOneWireConnection(0x28, 0x1234)
+	require.NoError(t, err2)
+	assert.Equal(t, con1, con1a)
+	assert.Len(t, a.connections, 1)
+	// assert second connection
+	con2, err3 := a.GetOneWireConnection(0x28, 0x5678)
+	require.NoError(t, err3)
+	assert.NotEqual(t, con1, con2)
+	assert.Len(t, a.connections, 2)
+	// assert not existing device
+	con, err := a.GetOneWireConnection(0x28, 0x9abc)
+	require.ErrorContains(t, err, "1-wire device '28-000000009abc' not found")
+	assert.Nil(t, con)
+}
+
+func TestOneWireFinalize(t *testing.T) {
+	// arrange
+	a, _ := initTestOneWireBusAdaptorWithMockedFilesystem([]string{"/sys/bus/w1/devices/28-000000001234/temperature"})
+	_, err := a.GetOneWireConnection(0x28, 0x1234)
+	require.NoError(t, err)
+	assert.Len(t, a.connections, 1)
+	// act
+	err = a.Finalize()
+	// assert
+	require.NoError(t, err)
+	assert.Empty(t, a.connections)
+	// assert finalize after finalize is working
+	require.NoError(t, a.Finalize())
+	// assert reconnect is working
+	require.NoError(t, a.Connect())
+	_, err = a.GetOneWireConnection(0x28, 0x1234)
+	require.NoError(t, err)
+	assert.Len(t, a.connections, 1)
+}
+
+func TestOneWireFindOneWireDevices(t *testing.T) {
+	// arrange
+	a, _ := initTestOneWireBusAdaptorWithMockedFilesystem([]string{
+		"/sys/bus/w1/devices/28-000000001234/temperature",
+		"/sys/bus/w1/devices/10-000000005678/temperature",
+	})
+	// act
+	got, err := a.FindOneWireDevices(0x28)
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{0x1234}, got)
+}
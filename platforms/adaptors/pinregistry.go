@@ -0,0 +1,169 @@
+package adaptors
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+
+	"gobot.io/x/gobot/v2"
+)
+
+type busPinsKey struct {
+	function string
+	busNum   int
+}
+
+// busPinOwner is the owner of the pins of a hardware bus, all values for the same bus are the same owner
+type busPinOwner busPinsKey
+
+// pinRecord is the usage of a pin by an owner
+type pinRecord struct {
+	function string
+	owner    gobot.PinOwner
+}
+
+// PinRegistry records which driver or bus uses which header pin of the board, in which function. It is normally
+// used for composition in platforms and implements the interfaces gobot.PinClaimer and gobot.PinAllocationLister.
+type PinRegistry struct {
+	mutex       sync.Mutex
+	allocations map[string]pinRecord // the key is the pin
+	busPins     map[busPinsKey][]string
+}
+
+// NewPinRegistry creates a new and empty registry of pins.
+func NewPinRegistry() *PinRegistry {
+	return &PinRegistry{
+		allocations: make(map[string]pinRecord),
+		busPins:     make(map[busPinsKey][]string),
+	}
+}
+
+// SetBusPins defines the header pins, which are muxed to the hardware bus with the given number. The function is
+// gobot.PinFunctionI2c or gobot.PinFunctionSpi. The pins are claimed, when the bus is used for the first time. This
+// is normally done by the platform and not by the user.
+func (r *PinRegistry) SetBusPins(function string, busNum int, pins ...string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.busPins[busPinsKey{function: function, busNum: busNum}] = pins
+}
+
+// SetBusPinDefinitions replaces all header pins of the hardware buses by the given definitions, the key of the outer
+// map is the function, the key of the inner map is the bus number, see SetBusPins(). This is normally done by the
+// platform and not by the user.
+func (r *PinRegistry) SetBusPinDefinitions(definitions map[string]map[int][]string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.busPins = make(map[busPinsKey][]string)
+	for function, buses := range definitions {
+		for busNum, pins := range buses {
+			r.busPins[busPinsKey{function: function, busNum: busNum}] = pins
+		}
+	}
+}
+
+// BusPins returns the header pins, which are defined for the hardware bus with the given function and number.
+func (r *PinRegistry) BusPins(function string, busNum int) []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.busPins[busPinsKey{function: function, busNum: busNum}]
+}
+
+// ClaimPin records the usage of the pin in the given function by the given owner. An error is returned, if the pin
+// is already used by another owner, also if this owner has the same name. Implements the interface gobot.PinClaimer.
+func (r *PinRegistry) ClaimPin(pin string, function string, owner gobot.PinOwner) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.claimPin(pin, function, owner)
+}
+
+// ClaimPins records the usage of all given pins in the given function by the given owner. If one pin is already used
+// by another owner, an error is returned and none of the pins is claimed.
+func (r *PinRegistry) ClaimPins(function string, owner gobot.PinOwner, pins ...string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, pin := range pins {
+		if err := r.checkPin(pin, function, owner); err != nil {
+			return err
+		}
+	}
+	for _, pin := range pins {
+		if err := r.claimPin(pin, function, owner); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReleasePins removes all records of the given owner. Implements the interface gobot.PinClaimer.
+func (r *PinRegistry) ReleasePins(owner gobot.PinOwner) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for pin, rec := range r.allocations {
+		if rec.owner == owner {
+			delete(r.allocations, pin)
+		}
+	}
+}
+
+// PinAllocations returns all currently used pins, sorted by the pin. Implements the interface
+// gobot.PinAllocationLister.
+func (r *PinRegistry) PinAllocations() []gobot.PinAllocation {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	allocs := make([]gobot.PinAllocation, 0, len(r.allocations))
+	for pin, rec := range r.allocations {
+		allocs = append(allocs, gobot.PinAllocation{Pin: pin, Function: rec.function, Owner: rec.owner.Name()})
+	}
+	sort.Slice(allocs, func(i, j int) bool { return pinLess(allocs[i].Pin, allocs[j].Pin) })
+	return allocs
+}
+
+// claimPin needs to be called with locked mutex.
+func (r *PinRegistry) claimPin(pin string, function string, owner gobot.PinOwner) error {
+	if err := r.checkPin(pin, function, owner); err != nil {
+		return err
+	}
+	if _, ok := r.allocations[pin]; !ok {
+		r.allocations[pin] = pinRecord{function: function, owner: owner}
+	}
+	return nil
+}
+
+// checkPin needs to be called with locked mutex.
+func (r *PinRegistry) checkPin(pin string, function string, owner gobot.PinOwner) error {
+	rec, ok := r.allocations[pin]
+	if !ok || rec.owner == owner {
+		return nil
+	}
+	return fmt.Errorf("pin '%s' can not be used by '%s' as %s, because it is already used by '%s' as %s", pin,
+		owner.Name(), function, rec.owner.Name(), rec.function)
+}
+
+// pinLess sorts numeric pins by value before all other pins, which are sorted alphabetically.
+func pinLess(a, b string) bool {
+	na, errA := strconv.Atoi(a)
+	nb, errB := strconv.Atoi(b)
+	switch {
+	case errA == nil && errB == nil:
+		return na < nb
+	case errA == nil:
+		return true
+	case errB == nil:
+		return false
+	default:
+		return a < b
+	}
+}
+
+// Name returns the name of the bus, e.g. "i2c bus 1".
+func (o busPinOwner) Name() string {
+	return fmt.Sprintf("%s bus %d", o.function, o.busNum)
+}
@@ -0,0 +1,161 @@
+package adaptors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+)
+
+// make sure that this registry fulfills all the required interfaces
+var (
+	_ gobot.PinClaimer          = (*PinRegistry)(nil)
+	_ gobot.PinAllocationLister = (*PinRegistry)(nil)
+)
+
+// pinTestOwner is identified by its pointer, like a driver
+type pinTestOwner struct {
+	name string
+}
+
+func (o *pinTestOwner) Name() string { return o.name }
+
+func TestPinRegistryClaimPin(t *testing.T) {
+	servo := &pinTestOwner{name: "Servo-1"}
+	tests := map[string]struct {
+		pin      string
+		function string
+		owner    gobot.PinOwner
+		wantErr  string
+	}{
+		"free_pin": {
+			pin:      "7",
+			function: gobot.PinFunctionGpio,
+			owner:    &pinTestOwner{name: "LED-1"},
+		},
+		"same_owner": {
+			pin:      "12",
+			function: gobot.PinFunctionPwm,
+			owner:    servo,
+		},
+		"same_bus": {
+			pin:      "3",
+			function: gobot.PinFunctionI2c,
+			owner:    i2cBusPinOwner(1),
+		},
+		"other_owner": {
+			pin:      "12",
+			function: gobot.PinFunctionGpio,
+			owner:    &pinTestOwner{name: "LED-1"},
+			wantErr:  "pin '12' can not be used by 'LED-1' as gpio, because it is already used by 'Servo-1' as pwm",
+		},
+		"other_owner_with_same_name": {
+			pin:      "12",
+			function: gobot.PinFunctionPwm,
+			owner:    &pinTestOwner{name: "Servo-1"},
+			wantErr:  "pin '12' can not be used by 'Servo-1' as pwm, because it is already used by 'Servo-1' as pwm",
+		},
+		"bus_pin": {
+			pin:      "3",
+			function: gobot.PinFunctionGpio,
+			owner:    &pinTestOwner{name: "Button-1"},
+			wantErr: "pin '3' can not be used by 'Button-1' as gpio, because it is already used by 'i2c bus 1' " +
+				"as i2c",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			r := NewPinRegistry()
+			require.NoError(t, r.ClaimPin("12", gobot.PinFunctionPwm, servo))
+			require.NoError(t, r.ClaimPins(gobot.PinFunctionI2c, i2cBusPinOwner(1), "3", "5"))
+			// act
+			err := r.ClaimPin(tc.pin, tc.function, tc.owner)
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				assert.Len(t, r.PinAllocations(), 3)
+			} else {
+				require.NoError(t, err)
+				assert.Contains(t, r.PinAllocations(),
+					gobot.PinAllocation{Pin: tc.pin, Function: tc.function, Owner: tc.owner.Name()})
+			}
+		})
+	}
+}
+
+func TestPinRegistryClaimPins(t *testing.T) {
+	// arrange
+	r := NewPinRegistry()
+	require.NoError(t, r.ClaimPin("21", gobot.PinFunctionGpio, &pinTestOwner{name: "LED-1"}))
+	// act
+	err := r.ClaimPins(gobot.PinFunctionSpi, spiBusPinOwner(0), "19", "21", "23")
+	// assert: all or nothing
+	require.EqualError(t, err,
+		"pin '21' can not be used by 'spi bus 0' as spi, because it is already used by 'LED-1' as gpio")
+	assert.Equal(t, []gobot.PinAllocation{{Pin: "21", Function: gobot.PinFunctionGpio, Owner: "LED-1"}}, r.PinAllocations())
+}
+
+func TestPinRegistryReleasePins(t *testing.T) {
+	// arrange
+	r := NewPinRegistry()
+	stepper := &pinTestOwner{name: "Stepper-1"}
+	require.NoError(t, r.ClaimPins(gobot.PinFunctionGpio, stepper, "7", "11", "13", "15"))
+	require.NoError(t, r.ClaimPin("12", gobot.PinFunctionPwm, &pinTestOwner{name: "Servo-1"}))
+	// act: an owner with the same name does not release the pins
+	r.ReleasePins(&pinTestOwner{name: "Stepper-1"})
+	// assert
+	assert.Len(t, r.PinAllocations(), 5)
+	// act
+	r.ReleasePins(stepper)
+	// assert
+	assert.Equal(t, []gobot.PinAllocation{{Pin: "12", Function: gobot.PinFunctionPwm, Owner: "Servo-1"}},
+		r.PinAllocations())
+	require.NoError(t, r.ClaimPin("7", gobot.PinFunctionGpio, &pinTestOwner{name: "LED-1"}))
+}
+
+func TestPinRegistryPinAllocations(t *testing.T) {
+	// arrange
+	r := NewPinRegistry()
+	require.NoError(t, r.ClaimPin("P9_12", gobot.PinFunctionGpio, &pinTestOwner{name: "LED-1"}))
+	require.NoError(t, r.ClaimPin("11", gobot.PinFunctionGpio, &pinTestOwner{name: "LED-2"}))
+	require.NoError(t, r.ClaimPin("P8_7", gobot.PinFunctionGpio, &pinTestOwner{name: "LED-3"}))
+	require.NoError(t, r.ClaimPin("3", gobot.PinFunctionGpio, &pinTestOwner{name: "LED-4"}))
+	// act
+	got := r.PinAllocations()
+	// assert
+	assert.Equal(t, []gobot.PinAllocation{
+		{Pin: "3", Function: gobot.PinFunctionGpio, Owner: "LED-4"},
+		{Pin: "11", Function: gobot.PinFunctionGpio, Owner: "LED-2"},
+		{Pin: "P8_7", Function: gobot.PinFunctionGpio, Owner: "LED-3"},
+		{Pin: "P9_12", Function: gobot.PinFunctionGpio, Owner: "LED-1"},
+	}, got)
+}
+
+func TestPinRegistryBusPins(t *testing.T) {
+	// arrange
+	r := NewPinRegistry()
+	// act
+	r.SetBusPins(gobot.PinFunctionI2c, 1, "3", "5")
+	// assert
+	assert.Equal(t, []string{"3", "5"}, r.BusPins(gobot.PinFunctionI2c, 1))
+	assert.Nil(t, r.BusPins(gobot.PinFunctionI2c, 0))
+	assert.Nil(t, r.BusPins(gobot.PinFunctionSpi, 1))
+}
+
+func TestPinRegistrySetBusPinDefinitions(t *testing.T) {
+	// arrange
+	r := NewPinRegistry()
+	r.SetBusPins(gobot.PinFunctionI2c, 1, "3", "5")
+	// act
+	r.SetBusPinDefinitions(map[string]map[int][]string{
+		gobot.PinFunctionI2c: {0: {"27", "28"}},
+		gobot.PinFunctionSpi: {0: {"19", "21", "23", "24"}},
+	})
+	// assert: all previous definitions are replaced
+	assert.Equal(t, []string{"27", "28"}, r.BusPins(gobot.PinFunctionI2c, 0))
+	assert.Nil(t, r.BusPins(gobot.PinFunctionI2c, 1))
+	assert.Equal(t, []string{"19", "21", "23", "24"}, r.BusPins(gobot.PinFunctionSpi, 0))
+}
@@ -6,6 +6,7 @@ import (
 
 	multierror "github.com/hashicorp/go-multierror"
 
+	"gobot.io/x/gobot/v2"
 	"gobot.io/x/gobot/v2/drivers/spi"
 	"gobot.io/x/gobot/v2/system"
 )
@@ -21,14 +22,21 @@ type SpiBusAdaptor struct {
 	defaultMode       int
 	defaultBitCount   int
 	defaultMaxSpeed   int64
+	spiBusCfg         *spiBusConfiguration
 	mutex             sync.Mutex
 	connections       map[string]spi.Connection
+	busNumbers        map[int]struct{} // the buses in use, needed to release the claimed pins
 }
 
 // NewSpiBusAdaptor provides the access to SPI buses of the board. The validator is used to check the
 // bus number (given by user) to the abilities of the board.
+//
+// Options:
+//
+//	"WithSpiBusTracer"
+//	"WithSpiPinRegistry"
 func NewSpiBusAdaptor(sys *system.Accesser, v spiBusNumberValidator, busNum, chipNum, mode, bits int,
-	maxSpeed int64,
+	maxSpeed int64, opts ...SpiBusOptionApplier,
 ) *SpiBusAdaptor {
 	a := &SpiBusAdaptor{
 		sys:               sys,
@@ -38,7 +46,13 @@ func NewSpiBusAdaptor(sys *system.Accesser, v spiBusNumberValidator, busNum, chi
 		defaultMode:       mode,
 		defaultBitCount:   bits,
 		defaultMaxSpeed:   maxSpeed,
+		spiBusCfg:         &spiBusConfiguration{},
 	}
+
+	for _, o := range opts {
+		o.apply(a.spiBusCfg)
+	}
+
 	return a
 }
 
@@ -48,6 +62,7 @@ func (a *SpiBusAdaptor) Connect() error {
 	defer a.mutex.Unlock()
 
 	a.connections = make(map[string]spi.Connection)
+	a.busNumbers = make(map[int]struct{})
 	return nil
 }
 
@@ -64,7 +79,13 @@ func (a *SpiBusAdaptor) Finalize() error {
 			}
 		}
 	}
+	if a.spiBusCfg.pinRegistry != nil {
+		for busNum := range a.busNumbers {
+			a.spiBusCfg.pinRegistry.ReleasePins(spiBusPinOwner(busNum))
+		}
+	}
 	a.connections = nil
+	a.busNumbers = nil
 	return err
 }
 
@@ -85,11 +106,17 @@ func (a *SpiBusAdaptor) GetSpiConnection(busNum, chipNum, mode, bits int, maxSpe
 		if err := a.validateBusNumber(busNum); err != nil {
 			return nil, err
 		}
-		var err error
+		if err := a.claimPins(busNum); err != nil {
+			return nil, err
+		}
 		bus, err := a.sys.NewSpiDevice(busNum, chipNum, mode, bits, maxSpeed)
 		if err != nil {
 			return nil, err
 		}
+		if a.spiBusCfg.tracer != nil {
+			location := fmt.Sprintf("/dev/spidev%d.%d", busNum, chipNum)
+			bus = system.NewTracedSpiDevice(bus, location, a.spiBusCfg.tracer)
+		}
 		con = spi.NewConnection(bus)
 		a.connections[id] = con
 	}
@@ -97,6 +124,24 @@ func (a *SpiBusAdaptor) GetSpiConnection(busNum, chipNum, mode, bits int, maxSpe
 	return con, nil
 }
 
+// claimPins claims the pins of the bus in the pin registry, if any, needs to be called with locked mutex.
+func (a *SpiBusAdaptor) claimPins(busNum int) error {
+	registry := a.spiBusCfg.pinRegistry
+	if registry == nil {
+		return nil
+	}
+
+	pins := a.sys.SpiGpioPins()
+	if pins == nil {
+		pins = registry.BusPins(gobot.PinFunctionSpi, busNum)
+	}
+	if err := registry.ClaimPins(gobot.PinFunctionSpi, spiBusPinOwner(busNum), pins...); err != nil {
+		return err
+	}
+	a.busNumbers[busNum] = struct{}{}
+	return nil
+}
+
 // SpiDefaultBusNumber returns the default bus number for this platform.
 func (a *SpiBusAdaptor) SpiDefaultBusNumber() int {
 	return a.defaultBusNumber
@@ -121,3 +166,7 @@ func (a *SpiBusAdaptor) SpiDefaultBitCount() int {
 func (a *SpiBusAdaptor) SpiDefaultMaxSpeed() int64 {
 	return a.defaultMaxSpeed
 }
+
+func spiBusPinOwner(busNum int) busPinOwner {
+	return busPinOwner{function: gobot.PinFunctionSpi, busNum: busNum}
+}
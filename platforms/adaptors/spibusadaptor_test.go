@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"gobot.io/x/gobot/v2"
 	"gobot.io/x/gobot/v2/drivers/spi"
 	"gobot.io/x/gobot/v2/system"
 )
@@ -119,3 +120,29 @@ func TestSpiReConnect(t *testing.T) {
 	assert.NotNil(t, a.connections)
 	assert.Empty(t, a.connections)
 }
+
+func TestGetSpiConnection_pinRegistry(t *testing.T) {
+	// arrange
+	sys := system.NewAccesser()
+	_ = sys.UseMockSpi()
+	r := NewPinRegistry()
+	r.SetBusPins(gobot.PinFunctionSpi, 0, "19", "21", "23", "24", "26")
+	r.SetBusPins(gobot.PinFunctionSpi, 1, "35", "38", "40", "12")
+	require.NoError(t, r.ClaimPin("12", gobot.PinFunctionPwm, &pinTestOwner{name: "Servo-1"}))
+	a := NewSpiBusAdaptor(sys, func(int) error { return nil }, 0, 0, 0, 8, 500000, WithSpiPinRegistry(r))
+	require.NoError(t, a.Connect())
+	// act & assert: the pins of the bus are claimed once for all chips
+	_, err := a.GetSpiConnection(0, 0, 0, 8, 500000)
+	require.NoError(t, err)
+	_, err = a.GetSpiConnection(0, 1, 0, 8, 500000)
+	require.NoError(t, err)
+	assert.Len(t, r.PinAllocations(), 6)
+	// act & assert: a pin of the bus is in use
+	_, err = a.GetSpiConnection(1, 0, 0, 8, 500000)
+	require.EqualError(t, err,
+		"pin '12' can not be used by 'spi bus 1' as spi, because it is already used by 'Servo-1' as pwm")
+	// act & assert: the pins are released on finalize
+	require.NoError(t, a.Finalize())
+	assert.Equal(t, []gobot.PinAllocation{{Pin: "12", Function: gobot.PinFunctionPwm, Owner: "Servo-1"}},
+		r.PinAllocations())
+}
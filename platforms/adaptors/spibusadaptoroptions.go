@@ -0,0 +1,53 @@
+package adaptors
+
+import "gobot.io/x/gobot/v2/system"
+
+// SpiBusOptionApplier needs to be implemented by each configurable option type
+type SpiBusOptionApplier interface {
+	apply(cfg *spiBusConfiguration)
+}
+
+// spiBusConfiguration contains all changeable attributes of the adaptor.
+type spiBusConfiguration struct {
+	tracer      *system.BusTracer
+	pinRegistry *PinRegistry
+}
+
+// spiBusTracerOption is the type for applying a tracer, which records all transactions of the SPI buses.
+type spiBusTracerOption struct {
+	tracer *system.BusTracer
+}
+
+// spiBusPinRegistryOption is the type for applying a registry, which records the pins used by the SPI buses.
+type spiBusPinRegistryOption struct {
+	registry *PinRegistry
+}
+
+// WithSpiBusTracer activates the recording of all transactions on all SPI buses of the board by the given tracer.
+// The location of the buses in the recording is the name of the character device, e.g. "/dev/spidev0.1".
+func WithSpiBusTracer(tracer *system.BusTracer) spiBusTracerOption {
+	return spiBusTracerOption{tracer: tracer}
+}
+
+func (o spiBusTracerOption) String() string {
+	return "tracer option for SPI buses"
+}
+
+func (o spiBusTracerOption) apply(cfg *spiBusConfiguration) {
+	cfg.tracer = o.tracer
+}
+
+// WithSpiPinRegistry sets the registry, which records the pins used by the SPI buses. The pins of a SPI bus driven
+// by GPIO's, or the pins defined by the platform for a hardware bus, are claimed when the bus is used for the first
+// time. This option is normally applied by the platform and not by the user.
+func WithSpiPinRegistry(r *PinRegistry) spiBusPinRegistryOption {
+	return spiBusPinRegistryOption{registry: r}
+}
+
+func (o spiBusPinRegistryOption) String() string {
+	return "pin registry option for SPI buses"
+}
+
+func (o spiBusPinRegistryOption) apply(cfg *spiBusConfiguration) {
+	cfg.pinRegistry = o.registry
+}
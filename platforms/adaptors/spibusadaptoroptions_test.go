@@ -0,0 +1,42 @@
+package adaptors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2/system"
+)
+
+func TestWithSpiBusTracer(t *testing.T) {
+	// arrange
+	tracer := system.NewBusTracer(0)
+	sys := system.NewAccesser()
+	sys.UseMockSpi()
+	a := NewSpiBusAdaptor(sys, func(int) error { return nil }, 1, 2, 3, 4, 5, WithSpiBusTracer(tracer))
+	require.NoError(t, a.Connect())
+	con, err := a.GetSpiConnection(0, 1, 0, 8, 500000)
+	require.NoError(t, err)
+	// act
+	err = con.WriteBytes([]byte{0x05, 0x06})
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, tracer, a.spiBusCfg.tracer)
+	got := tracer.Transactions()
+	require.Len(t, got, 1)
+	assert.Equal(t, system.BusTraceSpi, got[0].Bus)
+	assert.Equal(t, "/dev/spidev0.1", got[0].Location)
+	assert.Equal(t, system.BusTraceTransfer, got[0].Direction)
+	assert.Equal(t, []byte{0x05, 0x06}, got[0].Data)
+}
+
+func TestWithSpiPinRegistry(t *testing.T) {
+	// arrange
+	r := NewPinRegistry()
+	cfg := &spiBusConfiguration{}
+	// act
+	WithSpiPinRegistry(r).apply(cfg)
+	// assert
+	assert.Equal(t, r, cfg.pinRegistry)
+}
@@ -0,0 +1,44 @@
+package adaptors
+
+import (
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/system"
+)
+
+// SysClassAdaptor is a adaptor for the LEDs, the hardware monitoring devices and the watchdog of the board, which are
+// provided by the Linux device classes "leds", "hwmon" and "watchdog", normally used for composition in platforms.
+type SysClassAdaptor struct {
+	sys *system.Accesser
+}
+
+// NewSysClassAdaptor provides the access to LEDs, hardware monitoring devices and the watchdog of the board.
+func NewSysClassAdaptor(sys *system.Accesser) *SysClassAdaptor {
+	return &SysClassAdaptor{sys: sys}
+}
+
+// FindLeds returns the names of all LEDs of the board, e.g. "ACT" and "PWR" for a Raspberry Pi.
+func (a *SysClassAdaptor) FindLeds() ([]string, error) {
+	return a.sys.FindLeds()
+}
+
+// OpenLed returns the access to the LED with the given name, e.g. "ACT".
+func (a *SysClassAdaptor) OpenLed(name string) (gobot.LedSystemDevicer, error) {
+	return a.sys.NewLed(name)
+}
+
+// FindHwmonDevices returns all hardware monitoring devices of the board together with the name, e.g. "cpu_thermal".
+func (a *SysClassAdaptor) FindHwmonDevices() ([]system.HwmonDeviceInfo, error) {
+	return a.sys.FindHwmonDevices()
+}
+
+// OpenHwmon returns the access to the hardware monitoring device with the given name, e.g. "cpu_thermal", or the
+// given directory name, e.g. "hwmon0".
+func (a *SysClassAdaptor) OpenHwmon(name string) (gobot.HwmonSystemDevicer, error) {
+	return a.sys.NewHwmonDevice(name)
+}
+
+// OpenWatchdog returns the access to the watchdog with the given character device, e.g. "/dev/watchdog". Opening the
+// device starts the watchdog.
+func (a *SysClassAdaptor) OpenWatchdog(devicePath string) (gobot.WatchdogSystemDevicer, error) {
+	return a.sys.NewWatchdog(devicePath)
+}
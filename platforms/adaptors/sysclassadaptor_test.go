@@ -0,0 +1,101 @@
+package adaptors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/drivers/aio"
+	"gobot.io/x/gobot/v2/drivers/gpio"
+	"gobot.io/x/gobot/v2/drivers/watchdog"
+	"gobot.io/x/gobot/v2/system"
+)
+
+// make sure that this SysClassAdaptor fulfills all the required interfaces
+var (
+	_ gpio.SysLedOpener = (*SysClassAdaptor)(nil)
+	_ aio.HwmonOpener   = (*SysClassAdaptor)(nil)
+	_ watchdog.Opener   = (*SysClassAdaptor)(nil)
+)
+
+func initTestSysClassAdaptorWithMockedFilesystem() (*SysClassAdaptor, *system.MockFilesystem) {
+	sys := system.NewAccesser()
+	fs := sys.UseMockFilesystem([]string{
+		"/sys/class/leds/ACT/brightness",
+		"/sys/class/leds/ACT/trigger",
+		"/sys/class/leds/PWR/brightness",
+		"/sys/class/hwmon/hwmon0/name",
+		"/sys/class/hwmon/hwmon0/temp1_input",
+		"/sys/class/hwmon/hwmon1/name",
+		"/sys/class/hwmon/hwmon1/in0_input",
+	})
+	fs.Files["/sys/class/leds/ACT/trigger"].Contents = "none [mmc0] heartbeat\n"
+	fs.Files["/sys/class/hwmon/hwmon0/name"].Contents = "cpu_thermal\n"
+	fs.Files["/sys/class/hwmon/hwmon0/temp1_input"].Contents = "51540\n"
+	fs.Files["/sys/class/hwmon/hwmon1/name"].Contents = "rpi_volt\n"
+	return NewSysClassAdaptor(sys), fs
+}
+
+func TestSysClassAdaptorLeds(t *testing.T) {
+	// arrange
+	a, _ := initTestSysClassAdaptorWithMockedFilesystem()
+	// act
+	names, err := a.FindLeds()
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ACT", "PWR"}, names)
+	// act
+	led, err := a.OpenLed("ACT")
+	// assert
+	require.NoError(t, err)
+	trigger, err := led.Trigger()
+	require.NoError(t, err)
+	assert.Equal(t, "mmc0", trigger)
+	// act & assert
+	_, err = a.OpenLed("led0")
+	require.ErrorContains(t, err, "LED 'led0' not found")
+}
+
+func TestSysClassAdaptorHwmon(t *testing.T) {
+	// arrange
+	a, _ := initTestSysClassAdaptorWithMockedFilesystem()
+	// act
+	infos, err := a.FindHwmonDevices()
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, []system.HwmonDeviceInfo{
+		{Path: "/sys/class/hwmon/hwmon0", Name: "cpu_thermal"},
+		{Path: "/sys/class/hwmon/hwmon1", Name: "rpi_volt"},
+	}, infos)
+	// act
+	dev, err := a.OpenHwmon("cpu_thermal")
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, []gobot.HwmonChannel{{Name: "temp1", Unit: "°C"}}, dev.Channels())
+	val, err := dev.Read("temp1")
+	require.NoError(t, err)
+	assert.InDelta(t, 51.54, val, 0.000001)
+	// act & assert
+	_, err = a.OpenHwmon("hwmon5")
+	require.EqualError(t, err, "hwmon device 'hwmon5' not found")
+}
+
+func TestSysClassAdaptorWatchdog(t *testing.T) {
+	// arrange
+	sys := system.NewAccesser()
+	fs := sys.UseMockFilesystem([]string{"/dev/watchdog"})
+	_ = sys.UseMockSyscall()
+	a := NewSysClassAdaptor(sys)
+	// act
+	wd, err := a.OpenWatchdog("/dev/watchdog")
+	// assert
+	require.NoError(t, err)
+	require.NoError(t, wd.Keepalive())
+	require.NoError(t, wd.MagicClose())
+	assert.Equal(t, "V", fs.Files["/dev/watchdog"].Contents)
+	// act & assert
+	_, err = a.OpenWatchdog("/dev/watchdog1")
+	require.ErrorContains(t, err, "/dev/watchdog1: no such file")
+}
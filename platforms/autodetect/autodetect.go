@@ -0,0 +1,319 @@
+package autodetect
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/drivers/gpio"
+	"gobot.io/x/gobot/v2/drivers/i2c"
+	"gobot.io/x/gobot/v2/drivers/spi"
+	"gobot.io/x/gobot/v2/platforms/adaptors"
+	"gobot.io/x/gobot/v2/platforms/beaglebone"
+	"gobot.io/x/gobot/v2/platforms/jetson"
+	"gobot.io/x/gobot/v2/platforms/nanopi"
+	"gobot.io/x/gobot/v2/platforms/raspi"
+	"gobot.io/x/gobot/v2/platforms/rockpi"
+	"gobot.io/x/gobot/v2/platforms/tinkerboard"
+	"gobot.io/x/gobot/v2/platforms/upboard/up2"
+	"gobot.io/x/gobot/v2/system"
+)
+
+const (
+	deviceTreeModelFile      = "/proc/device-tree/model"
+	deviceTreeCompatibleFile = "/proc/device-tree/compatible"
+	cpuInfoFile              = "/proc/cpuinfo"
+	dmiBoardNameFile         = "/sys/class/dmi/id/board_name"
+)
+
+// Adaptor is the common interface of all adaptors, which can be created after the board was detected. Use a type
+// assertion for further interfaces, e.g. gpio.PwmWriter.
+type Adaptor interface {
+	gobot.Adaptor
+	gobot.DigitalPinnerProvider
+	gpio.DigitalReader
+	gpio.DigitalWriter
+	i2c.Connector
+	spi.Connector
+}
+
+// Board contains the information about the detected board.
+type Board struct {
+	// ID is the identifier of the board family, e.g. "raspi", "tinkerboard" or "pocketbeagle".
+	ID string
+	// Name is the human readable name of the board family, e.g. "Raspberry Pi".
+	Name string
+	// Variant is the pin map variant used by the adaptor, e.g. "3" for the revision of a Raspberry Pi or "4C+" for a
+	// Rock Pi. Empty, if the board family has no variants.
+	Variant string
+	// Model is the model read from the device tree, "/proc/cpuinfo" or the DMI board name.
+	Model string
+	// Compatible contains the compatible strings of the device tree, e.g. "asus,rk3288-tinker".
+	Compatible []string
+	// LinuxBoard is the ID of the shipped description for the generic "linuxboard" adaptor, if available. A description
+	// can not select the variant at runtime, so for board families with variants the ID of the description matching
+	// the detected variant is given, e.g. "raspberry-pi-rev2". Empty, if there is no description for the board or the
+	// variant is unknown.
+	LinuxBoard string
+	// NewAdaptor creates the matching gobot adaptor, for the options see the constructor of the platform package.
+	NewAdaptor func(opts ...interface{}) Adaptor
+}
+
+// boardInfo contains all information read from the system
+type boardInfo struct {
+	model      string
+	compatible []string
+	cpuInfo    map[string]string
+	dmiBoard   string
+}
+
+// boardDefinition describes how a board is detected and created
+type boardDefinition struct {
+	id         string
+	name       string
+	compatible []string // prefixes of compatible strings
+	models     []string // prefixes of model strings, checked for device tree model, cpuinfo "Model" and DMI
+	hardware   []string // values of cpuinfo "Hardware"
+	variant    func(info boardInfo) string
+	linuxBoard func(variant string) string
+	newAdaptor func(opts ...interface{}) Adaptor
+}
+
+var boardDefinitions = []boardDefinition{
+	{
+		id:         "raspi",
+		name:       "Raspberry Pi",
+		compatible: []string{"raspberrypi,"},
+		models:     []string{"Raspberry Pi"},
+		hardware:   []string{"BCM2708", "BCM2709", "BCM2710", "BCM2711", "BCM2835", "BCM2836", "BCM2837"},
+		variant:    raspiRevision,
+		linuxBoard: func(variant string) string {
+			if variant == "0" {
+				return ""
+			}
+			return "raspberry-pi-rev" + variant
+		},
+		newAdaptor: func(opts ...interface{}) Adaptor { return raspi.NewAdaptor(opts...) },
+	},
+	{
+		id:         "tinkerboard",
+		name:       "Tinker Board",
+		compatible: []string{"asus,rk3288-tinker"},
+		models:     []string{"ASUS Tinker Board", "Rockchip RK3288 Asus Tinker Board"},
+		linuxBoard: func(string) string { return "tinker-board" },
+		newAdaptor: func(opts ...interface{}) Adaptor { return tinkerboard.NewAdaptor(opts...) },
+	},
+	{
+		id:         "rockpi",
+		name:       "Rock Pi 4",
+		compatible: []string{"radxa,rockpi4", "radxa,rock-4"},
+		models:     []string{"Radxa ROCK 4", "Radxa ROCK Pi 4"},
+		variant:    rockpiVariant,
+		linuxBoard: func(variant string) string {
+			if variant == "4C+" {
+				return "rock-pi-4c-plus"
+			}
+			return "rock-pi-4"
+		},
+		newAdaptor: func(opts ...interface{}) Adaptor { return rockpi.NewAdaptor(digitalPinsOptions(opts)...) },
+	},
+	{
+		id:         "nanopi",
+		name:       "NanoPi NEO",
+		compatible: []string{"friendlyarm,nanopi-neo"},
+		models:     []string{"FriendlyARM NanoPi NEO", "FriendlyElec NanoPi-NEO"},
+		linuxBoard: func(string) string { return "nanopi-neo" },
+		newAdaptor: func(opts ...interface{}) Adaptor { return nanopi.NewNeoAdaptor(opts...) },
+	},
+	{
+		id:         "jetson",
+		name:       "Jetson Nano",
+		compatible: []string{"nvidia,p3450-0000", "nvidia,jetson-nano"},
+		models:     []string{"NVIDIA Jetson Nano"},
+		linuxBoard: func(string) string { return "jetson-nano" },
+		newAdaptor: func(opts ...interface{}) Adaptor { return jetson.NewAdaptor(opts...) },
+	},
+	{
+		id:         "up2",
+		name:       "UP2",
+		models:     []string{"UP-APL01"},
+		linuxBoard: func(string) string { return "up2" },
+		newAdaptor: func(opts ...interface{}) Adaptor { return up2.NewAdaptor(opts...) },
+	},
+	{
+		id:         "pocketbeagle",
+		name:       "PocketBeagle",
+		compatible: []string{"ti,am335x-pocketbeagle"},
+		models:     []string{"TI AM335x PocketBeagle"},
+		newAdaptor: func(opts ...interface{}) Adaptor { return beaglebone.NewPocketBeagleAdaptor(opts...) },
+	},
+	{
+		id:         "beaglebone",
+		name:       "BeagleBone Black",
+		compatible: []string{"ti,am335x-bone-black", "ti,am335x-bone-green"},
+		models:     []string{"TI AM335x BeagleBone Black", "TI AM335x BeagleBone Green"},
+		newAdaptor: func(opts ...interface{}) Adaptor { return beaglebone.NewAdaptor(opts...) },
+	},
+}
+
+// Detect reads the device tree, "/proc/cpuinfo" and the DMI board name to detect the board, which the program is
+// running on. An error is returned, if the board is not supported.
+func Detect() (*Board, error) {
+	return detect(system.NewAccesser())
+}
+
+// NewAdaptor detects the board and creates the matching adaptor with the given options.
+func NewAdaptor(opts ...interface{}) (Adaptor, error) {
+	board, err := Detect()
+	if err != nil {
+		return nil, err
+	}
+	return board.NewAdaptor(opts...), nil
+}
+
+func detect(sys *system.Accesser) (*Board, error) {
+	info := readBoardInfo(sys)
+
+	for _, def := range boardDefinitions {
+		if !def.matches(info) {
+			continue
+		}
+
+		board := Board{
+			ID:         def.id,
+			Name:       def.name,
+			Model:      info.bestModel(),
+			Compatible: info.compatible,
+			NewAdaptor: def.newAdaptor,
+		}
+		if def.variant != nil {
+			board.Variant = def.variant(info)
+		}
+		if def.linuxBoard != nil {
+			board.LinuxBoard = def.linuxBoard(board.Variant)
+		}
+		return &board, nil
+	}
+
+	return nil, fmt.Errorf("board not detected, model: '%s', compatible: %v", info.bestModel(), info.compatible)
+}
+
+func readBoardInfo(sys *system.Accesser) boardInfo {
+	var info boardInfo
+
+	if content, err := sys.ReadFile(deviceTreeModelFile); err == nil {
+		info.model = strings.TrimSpace(strings.TrimRight(string(content), "\x00"))
+	}
+
+	if content, err := sys.ReadFile(deviceTreeCompatibleFile); err == nil {
+		for _, c := range bytes.Split(content, []byte{0}) {
+			if s := strings.TrimSpace(string(c)); s != "" {
+				info.compatible = append(info.compatible, s)
+			}
+		}
+	}
+
+	info.cpuInfo = make(map[string]string)
+	if content, err := sys.ReadFile(cpuInfoFile); err == nil {
+		for _, line := range strings.Split(string(content), "\n") {
+			key, val, found := strings.Cut(line, ":")
+			if !found {
+				continue
+			}
+			// the first processor entry wins, the board information is placed at the end
+			key = strings.TrimSpace(key)
+			if _, ok := info.cpuInfo[key]; !ok {
+				info.cpuInfo[key] = strings.TrimSpace(val)
+			}
+		}
+	}
+
+	if content, err := sys.ReadFile(dmiBoardNameFile); err == nil {
+		info.dmiBoard = strings.TrimSpace(string(content))
+	}
+
+	return info
+}
+
+func (info boardInfo) bestModel() string {
+	switch {
+	case info.model != "":
+		return info.model
+	case info.cpuInfo["Model"] != "":
+		return info.cpuInfo["Model"]
+	default:
+		return info.dmiBoard
+	}
+}
+
+func (def boardDefinition) matches(info boardInfo) bool {
+	for _, prefix := range def.compatible {
+		for _, c := range info.compatible {
+			if strings.HasPrefix(c, prefix) {
+				return true
+			}
+		}
+	}
+
+	for _, prefix := range def.models {
+		for _, model := range []string{info.model, info.cpuInfo["Model"], info.dmiBoard} {
+			if model != "" && strings.HasPrefix(model, prefix) {
+				return true
+			}
+		}
+	}
+
+	for _, hardware := range def.hardware {
+		if info.cpuInfo["Hardware"] == hardware {
+			return true
+		}
+	}
+
+	return false
+}
+
+// raspiRevision evaluates the revision in the same way like the raspi adaptor
+func raspiRevision(info boardInfo) string {
+	revision, ok := info.cpuInfo["Revision"]
+	if !ok {
+		return "0"
+	}
+
+	version, _ := strconv.ParseInt("0x"+revision, 0, 64)
+	switch {
+	case version <= 3:
+		return "1"
+	case version <= 15:
+		return "2"
+	default:
+		return "3"
+	}
+}
+
+// rockpiVariant evaluates the variant in the same way like the rockpi adaptor
+func rockpiVariant(info boardInfo) string {
+	if info.model == "Radxa ROCK 4C+" {
+		return "4C+"
+	}
+	for _, c := range info.compatible {
+		if c == "radxa,rock-4c-plus" || c == "radxa,rockpi4c-plus" {
+			return "4C+"
+		}
+	}
+	return "4"
+}
+
+// digitalPinsOptions converts the options for adaptors, which only support digital pin options
+func digitalPinsOptions(opts []interface{}) []func(adaptors.DigitalPinsOptioner) {
+	var digitalPinsOpts []func(adaptors.DigitalPinsOptioner)
+	for _, opt := range opts {
+		o, ok := opt.(func(adaptors.DigitalPinsOptioner))
+		if !ok {
+			panic(fmt.Sprintf("'%s' can not be applied on the detected adaptor", opt))
+		}
+		digitalPinsOpts = append(digitalPinsOpts, o)
+	}
+	return digitalPinsOpts
+}
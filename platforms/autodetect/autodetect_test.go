@@ -0,0 +1,186 @@
+package autodetect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2/platforms/adaptors"
+	"gobot.io/x/gobot/v2/platforms/beaglebone"
+	"gobot.io/x/gobot/v2/platforms/raspi"
+	"gobot.io/x/gobot/v2/platforms/rockpi"
+	"gobot.io/x/gobot/v2/platforms/tinkerboard"
+	"gobot.io/x/gobot/v2/platforms/upboard/up2"
+	"gobot.io/x/gobot/v2/system"
+)
+
+func TestDetect(t *testing.T) {
+	tests := map[string]struct {
+		model          string
+		compatible     string
+		cpuInfo        string
+		dmiBoard       string
+		wantID         string
+		wantVariant    string
+		wantModel      string
+		wantCompatible []string
+		wantLinuxBoard string
+		wantAdaptor    interface{}
+	}{
+		"raspi_device_tree": {
+			model:      "Raspberry Pi 4 Model B Rev 1.4\x00",
+			compatible: "raspberrypi,4-model-b\x00brcm,bcm2711\x00",
+			cpuInfo: "processor\t: 0\nHardware\t: BCM2835\nRevision\t: c03114\n" +
+				"Model\t\t: Raspberry Pi 4 Model B Rev 1.4\n",
+			wantID:         "raspi",
+			wantVariant:    "3",
+			wantModel:      "Raspberry Pi 4 Model B Rev 1.4",
+			wantCompatible: []string{"raspberrypi,4-model-b", "brcm,bcm2711"},
+			wantLinuxBoard: "raspberry-pi-rev3",
+			wantAdaptor:    &raspi.Adaptor{},
+		},
+		"raspi_cpuinfo_only": {
+			cpuInfo:        "processor\t: 0\nHardware\t: BCM2708\nRevision\t: 0002\n",
+			wantID:         "raspi",
+			wantVariant:    "1",
+			wantLinuxBoard: "raspberry-pi-rev1",
+			wantAdaptor:    &raspi.Adaptor{},
+		},
+		"raspi_without_revision": {
+			cpuInfo:     "processor\t: 0\nHardware\t: BCM2835\n",
+			wantID:      "raspi",
+			wantVariant: "0",
+			wantAdaptor: &raspi.Adaptor{},
+		},
+		"tinkerboard": {
+			model:          "Rockchip RK3288 Asus Tinker Board\x00",
+			compatible:     "asus,rk3288-tinker\x00rockchip,rk3288\x00",
+			wantID:         "tinkerboard",
+			wantModel:      "Rockchip RK3288 Asus Tinker Board",
+			wantCompatible: []string{"asus,rk3288-tinker", "rockchip,rk3288"},
+			wantLinuxBoard: "tinker-board",
+			wantAdaptor:    &tinkerboard.Adaptor{},
+		},
+		"rockpi_4": {
+			model:          "Radxa ROCK 4",
+			wantID:         "rockpi",
+			wantVariant:    "4",
+			wantModel:      "Radxa ROCK 4",
+			wantLinuxBoard: "rock-pi-4",
+			wantAdaptor:    &rockpi.Adaptor{},
+		},
+		"rockpi_4c_plus": {
+			model:          "Radxa ROCK 4C+",
+			compatible:     "radxa,rock-4c-plus\x00rockchip,rk3399\x00",
+			wantID:         "rockpi",
+			wantVariant:    "4C+",
+			wantModel:      "Radxa ROCK 4C+",
+			wantCompatible: []string{"radxa,rock-4c-plus", "rockchip,rk3399"},
+			wantLinuxBoard: "rock-pi-4c-plus",
+			wantAdaptor:    &rockpi.Adaptor{},
+		},
+		"nanopi_neo": {
+			compatible:     "friendlyarm,nanopi-neo\x00allwinner,sun8i-h3\x00",
+			wantID:         "nanopi",
+			wantCompatible: []string{"friendlyarm,nanopi-neo", "allwinner,sun8i-h3"},
+			wantLinuxBoard: "nanopi-neo",
+		},
+		"jetson_nano": {
+			model:          "NVIDIA Jetson Nano Developer Kit",
+			compatible:     "nvidia,p3450-0000\x00nvidia,jetson-nano\x00nvidia,tegra210\x00",
+			wantID:         "jetson",
+			wantModel:      "NVIDIA Jetson Nano Developer Kit",
+			wantCompatible: []string{"nvidia,p3450-0000", "nvidia,jetson-nano", "nvidia,tegra210"},
+			wantLinuxBoard: "jetson-nano",
+		},
+		"up2_dmi": {
+			dmiBoard:       "UP-APL01\n",
+			wantID:         "up2",
+			wantModel:      "UP-APL01",
+			wantLinuxBoard: "up2",
+			wantAdaptor:    &up2.Adaptor{},
+		},
+		"beaglebone_black": {
+			model:          "TI AM335x BeagleBone Black",
+			compatible:     "ti,am335x-bone-black\x00ti,am335x-bone\x00ti,am33xx\x00",
+			wantID:         "beaglebone",
+			wantModel:      "TI AM335x BeagleBone Black",
+			wantCompatible: []string{"ti,am335x-bone-black", "ti,am335x-bone", "ti,am33xx"},
+			wantAdaptor:    &beaglebone.Adaptor{},
+		},
+		"pocketbeagle": {
+			model:          "TI AM335x PocketBeagle",
+			compatible:     "ti,am335x-pocketbeagle\x00ti,am335x-bone\x00ti,am33xx\x00",
+			wantID:         "pocketbeagle",
+			wantModel:      "TI AM335x PocketBeagle",
+			wantCompatible: []string{"ti,am335x-pocketbeagle", "ti,am335x-bone", "ti,am33xx"},
+			wantAdaptor:    &beaglebone.PocketBeagleAdaptor{},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			sys := initTestAccesser(tc.model, tc.compatible, tc.cpuInfo, tc.dmiBoard)
+			// act
+			got, err := detect(sys)
+			// assert
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantID, got.ID)
+			assert.NotEmpty(t, got.Name)
+			assert.Equal(t, tc.wantVariant, got.Variant)
+			if tc.wantModel != "" {
+				assert.Equal(t, tc.wantModel, got.Model)
+			}
+			assert.Equal(t, tc.wantCompatible, got.Compatible)
+			assert.Equal(t, tc.wantLinuxBoard, got.LinuxBoard)
+			if tc.wantAdaptor != nil {
+				assert.IsType(t, tc.wantAdaptor, got.NewAdaptor())
+			}
+		})
+	}
+}
+
+func TestDetect_error(t *testing.T) {
+	// arrange
+	sys := initTestAccesser("Banana Pi M2", "sinovoip,bpi-m2\x00allwinner,sun6i-a31s\x00", "", "")
+	// act
+	_, err := detect(sys)
+	// assert
+	require.EqualError(t, err,
+		"board not detected, model: 'Banana Pi M2', compatible: [sinovoip,bpi-m2 allwinner,sun6i-a31s]")
+}
+
+func TestBoardNewAdaptor_rockpiOptions(t *testing.T) {
+	// arrange
+	sys := initTestAccesser("Radxa ROCK 4", "", "", "")
+	board, err := detect(sys)
+	require.NoError(t, err)
+	// act & assert
+	assert.NotNil(t, board.NewAdaptor(adaptors.WithGpiosActiveLow("7")))
+	assert.PanicsWithValue(t, "'%!s(int=7)' can not be applied on the detected adaptor", func() {
+		_ = board.NewAdaptor(7)
+	})
+}
+
+func initTestAccesser(model, compatible, cpuInfo, dmiBoard string) *system.Accesser {
+	files := map[string]string{
+		deviceTreeModelFile:      model,
+		deviceTreeCompatibleFile: compatible,
+		cpuInfoFile:              cpuInfo,
+		dmiBoardNameFile:         dmiBoard,
+	}
+	var paths []string
+	for path, content := range files {
+		if content != "" {
+			paths = append(paths, path)
+		}
+	}
+
+	sys := system.NewAccesser()
+	fs := sys.UseMockFilesystem(paths)
+	for _, path := range paths {
+		fs.Files[path].Contents = files[path]
+	}
+	return sys
+}
@@ -0,0 +1,8 @@
+/*
+Package autodetect detects the Linux based board, which the program is running on, and creates the matching Gobot
+adaptor. So a single binary can run on different boards.
+
+For further information refer to autodetect README:
+https://github.com/hybridgroup/gobot/blob/master/platforms/autodetect/README.md
+*/
+package autodetect // import "gobot.io/x/gobot/v2/platforms/autodetect"
@@ -49,6 +49,8 @@ type Adaptor struct {
 	*adaptors.PWMPinsAdaptor
 	*adaptors.I2cBusAdaptor
 	*adaptors.SpiBusAdaptor
+	*adaptors.SysClassAdaptor
+	*adaptors.PinRegistry
 	usrLed       string
 	pinMap       map[string]int
 	pwmPinMap    map[string]pwmPinDefinition
@@ -61,6 +63,8 @@ type Adaptor struct {
 //
 //	adaptors.WithGpiodAccess():	use character device gpiod driver instead of sysfs
 //	adaptors.WithSpiGpioAccess(sclk, nss, mosi, miso):	use GPIO's instead of /dev/spidev#.#
+//	adaptors.WithI2cGpioAccess(busNum, sda, scl):	add a further i2c bus, which is driven by GPIO's
+//	adaptors.WithI2cBusTracer(tracer), adaptors.WithSpiBusTracer(tracer):	record all bus transactions
 //
 //	Optional parameters for PWM, see [adaptors.NewPWMPinsAdaptor]
 func NewAdaptor(opts ...interface{}) *Adaptor {
@@ -73,16 +77,27 @@ func NewAdaptor(opts ...interface{}) *Adaptor {
 		pwmPinMap:    bbbPwmPinMap,
 		analogPinMap: bbbAnalogPinMap,
 		usrLed:       "/sys/class/leds/beaglebone:green:",
+		PinRegistry:  adaptors.NewPinRegistry(),
 	}
+	a.SetBusPinDefinitions(bbbBusPinDefinitions)
 
 	var digitalPinsOpts []func(adaptors.DigitalPinsOptioner)
 	pwmPinsOpts := []adaptors.PwmPinsOptionApplier{adaptors.WithPWMDefaultPeriod(pwmPeriodDefault)}
+	i2cBusOpts := []adaptors.I2cBusOptionApplier{
+		adaptors.WithI2cDigitalPinnerProvider(a),
+		adaptors.WithI2cPinRegistry(a.PinRegistry),
+	}
+	spiBusOpts := []adaptors.SpiBusOptionApplier{adaptors.WithSpiPinRegistry(a.PinRegistry)}
 	for _, opt := range opts {
 		switch o := opt.(type) {
 		case func(adaptors.DigitalPinsOptioner):
 			digitalPinsOpts = append(digitalPinsOpts, o)
 		case adaptors.PwmPinsOptionApplier:
 			pwmPinsOpts = append(pwmPinsOpts, o)
+		case adaptors.I2cBusOptionApplier:
+			i2cBusOpts = append(i2cBusOpts, o)
+		case adaptors.SpiBusOptionApplier:
+			spiBusOpts = append(spiBusOpts, o)
 		default:
 			panic(fmt.Sprintf("'%s' can not be applied on adaptor '%s'", opt, a.name))
 		}
@@ -91,9 +106,10 @@ func NewAdaptor(opts ...interface{}) *Adaptor {
 	a.AnalogPinsAdaptor = adaptors.NewAnalogPinsAdaptor(sys, a.translateAnalogPin)
 	a.DigitalPinsAdaptor = adaptors.NewDigitalPinsAdaptor(sys, a.translateAndMuxDigitalPin, digitalPinsOpts...)
 	a.PWMPinsAdaptor = adaptors.NewPWMPinsAdaptor(sys, a.translateAndMuxPWMPin, pwmPinsOpts...)
-	a.I2cBusAdaptor = adaptors.NewI2cBusAdaptor(sys, a.validateI2cBusNumber, defaultI2cBusNumber)
+	a.I2cBusAdaptor = adaptors.NewI2cBusAdaptor(sys, a.validateI2cBusNumber, defaultI2cBusNumber, i2cBusOpts...)
 	a.SpiBusAdaptor = adaptors.NewSpiBusAdaptor(sys, a.validateSpiBusNumber, defaultSpiBusNumber, defaultSpiChipNumber,
-		defaultSpiMode, defaultSpiBitsNumber, defaultSpiMaxSpeed)
+		defaultSpiMode, defaultSpiBitsNumber, defaultSpiMaxSpeed, spiBusOpts...)
+	a.SysClassAdaptor = adaptors.NewSysClassAdaptor(sys)
 	return a
 }
 
@@ -25,7 +25,9 @@ var (
 	_ gobot.PWMPinnerProvider     = (*Adaptor)(nil)
 	_ gpio.DigitalReader          = (*Adaptor)(nil)
 	_ gpio.DigitalWriter          = (*Adaptor)(nil)
+	_ gpio.SysLedOpener           = (*Adaptor)(nil)
 	_ aio.AnalogReader            = (*Adaptor)(nil)
+	_ aio.HwmonOpener             = (*Adaptor)(nil)
 	_ gpio.PwmWriter              = (*Adaptor)(nil)
 	_ gpio.ServoWriter            = (*Adaptor)(nil)
 	_ i2c.Connector               = (*Adaptor)(nil)
@@ -92,6 +94,7 @@ func TestNewAdaptor(t *testing.T) {
 	assert.Equal(t, bbbPinMap, a.pinMap)
 	assert.Equal(t, bbbPwmPinMap, a.pwmPinMap)
 	assert.Equal(t, bbbAnalogPinMap, a.analogPinMap)
+	assert.Equal(t, []string{"P9_20", "P9_19"}, a.BusPins(gobot.PinFunctionI2c, 2))
 	assert.Equal(t, "/sys/class/leds/beaglebone:green:", a.usrLed)
 	// act & assert
 	a.SetName("NewName")
@@ -108,6 +111,7 @@ func TestNewPocketBeagleAdaptor(t *testing.T) {
 	assert.Equal(t, pocketBeaglePinMap, a.pinMap)
 	assert.Equal(t, pocketBeaglePwmPinMap, a.pwmPinMap)
 	assert.Equal(t, pocketBeagleAnalogPinMap, a.analogPinMap)
+	assert.Equal(t, []string{"P1_26", "P1_28"}, a.BusPins(gobot.PinFunctionI2c, 2))
 	assert.Equal(t, "/sys/class/leds/beaglebone:green:", a.usrLed)
 }
 
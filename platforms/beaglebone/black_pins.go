@@ -1,5 +1,7 @@
 package beaglebone
 
+import "gobot.io/x/gobot/v2"
+
 var bbbPinMap = map[string]int{
 	// P8_01 - P8_2 GND
 	// P8_03 - P8_6 EMCC
@@ -82,3 +84,14 @@ var bbbAnalogPinMap = map[string]analogPinDefinition{
 	"P9_36": {path: "/sys/bus/iio/devices/iio:device0/in_voltage5_raw", r: true, w: false, bufLen: 1024},
 	"P9_35": {path: "/sys/bus/iio/devices/iio:device0/in_voltage6_raw", r: true, w: false, bufLen: 1024},
 }
+
+// bbbBusPinDefinitions contains the header pins of the hardware buses, which are claimed in the pin registry
+var bbbBusPinDefinitions = map[string]map[int][]string{
+	gobot.PinFunctionI2c: {
+		2: {"P9_20", "P9_19"}, // I2C2 SDA, SCL
+	},
+	gobot.PinFunctionSpi: {
+		0: {"P9_18", "P9_21", "P9_22", "P9_17"}, // SPI0 D1, D0, SCLK, CS0
+		1: {"P9_30", "P9_29", "P9_31", "P9_28"}, // SPI1 D1, D0, SCLK, CS0
+	},
+}
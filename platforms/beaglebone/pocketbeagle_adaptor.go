@@ -25,6 +25,7 @@ func NewPocketBeagleAdaptor(opts ...interface{}) *PocketBeagleAdaptor {
 	a.pinMap = pocketBeaglePinMap
 	a.pwmPinMap = pocketBeaglePwmPinMap
 	a.analogPinMap = pocketBeagleAnalogPinMap
+	a.SetBusPinDefinitions(pocketBeagleBusPinDefinitions)
 
 	return &PocketBeagleAdaptor{
 		Adaptor: a,
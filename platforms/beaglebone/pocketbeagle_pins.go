@@ -1,5 +1,7 @@
 package beaglebone
 
+import "gobot.io/x/gobot/v2"
+
 var pocketBeaglePinMap = map[string]int{
 	// P1_01 - VIN
 	"P1_02": 87,
@@ -92,3 +94,14 @@ var pocketBeagleAnalogPinMap = map[string]analogPinDefinition{
 	"P1_27": {path: "/sys/bus/iio/devices/iio:device0/in_voltage4_raw", r: true, w: false, bufLen: 1024},
 	"P2_36": {path: "/sys/bus/iio/devices/iio:device0/in_voltage7_raw", r: true, w: false, bufLen: 1024},
 }
+
+// pocketBeagleBusPinDefinitions contains the header pins of the hardware buses, which are claimed in the pin registry
+var pocketBeagleBusPinDefinitions = map[string]map[int][]string{
+	gobot.PinFunctionI2c: {
+		2: {"P1_26", "P1_28"}, // I2C2 SDA, SCL
+	},
+	gobot.PinFunctionSpi: {
+		0: {"P1_12", "P1_10", "P1_08", "P1_06"}, // SPI0 MOSI, MISO, CLK, CS
+		1: {"P2_25", "P2_27", "P2_29", "P2_31"}, // SPI1 MOSI, MISO, CLK, CS
+	},
+}
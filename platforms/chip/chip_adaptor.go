@@ -34,6 +34,8 @@ type Adaptor struct {
 	*adaptors.DigitalPinsAdaptor
 	*adaptors.PWMPinsAdaptor
 	*adaptors.I2cBusAdaptor
+	*adaptors.SysClassAdaptor
+	*adaptors.PinRegistry
 }
 
 // NewAdaptor creates a C.H.I.P. Adaptor
@@ -42,14 +44,17 @@ type Adaptor struct {
 //
 //	adaptors.WithGpiodAccess():	use character device gpiod driver instead of sysfs
 //	adaptors.WithSpiGpioAccess(sclk, nss, mosi, miso):	use GPIO's instead of /dev/spidev#.#
+//	adaptors.WithI2cGpioAccess(busNum, sda, scl):	add a further i2c bus, which is driven by GPIO's
 //
 //	Optional parameters for PWM, see [adaptors.NewPWMPinsAdaptor]
 func NewAdaptor(opts ...interface{}) *Adaptor {
 	sys := system.NewAccesser()
 	a := &Adaptor{
-		name: gobot.DefaultName("CHIP"),
-		sys:  sys,
+		name:        gobot.DefaultName("CHIP"),
+		sys:         sys,
+		PinRegistry: adaptors.NewPinRegistry(),
 	}
+	a.SetBusPinDefinitions(chipBusPinDefinitions)
 
 	a.pinmap = chipPins
 	baseAddr, _ := getXIOBase()
@@ -60,12 +65,18 @@ func NewAdaptor(opts ...interface{}) *Adaptor {
 
 	var digitalPinsOpts []func(adaptors.DigitalPinsOptioner)
 	var pwmPinsOpts []adaptors.PwmPinsOptionApplier
+	i2cBusOpts := []adaptors.I2cBusOptionApplier{
+		adaptors.WithI2cDigitalPinnerProvider(a),
+		adaptors.WithI2cPinRegistry(a.PinRegistry),
+	}
 	for _, opt := range opts {
 		switch o := opt.(type) {
 		case func(adaptors.DigitalPinsOptioner):
 			digitalPinsOpts = append(digitalPinsOpts, o)
 		case adaptors.PwmPinsOptionApplier:
 			pwmPinsOpts = append(pwmPinsOpts, o)
+		case adaptors.I2cBusOptionApplier:
+			i2cBusOpts = append(i2cBusOpts, o)
 		default:
 			panic(fmt.Sprintf("'%s' can not be applied on adaptor '%s'", opt, a.name))
 		}
@@ -73,7 +84,8 @@ func NewAdaptor(opts ...interface{}) *Adaptor {
 
 	a.DigitalPinsAdaptor = adaptors.NewDigitalPinsAdaptor(sys, a.translateDigitalPin, digitalPinsOpts...)
 	a.PWMPinsAdaptor = adaptors.NewPWMPinsAdaptor(sys, a.translatePWMPin, pwmPinsOpts...)
-	a.I2cBusAdaptor = adaptors.NewI2cBusAdaptor(sys, a.validateI2cBusNumber, defaultI2cBusNumber)
+	a.I2cBusAdaptor = adaptors.NewI2cBusAdaptor(sys, a.validateI2cBusNumber, defaultI2cBusNumber, i2cBusOpts...)
+	a.SysClassAdaptor = adaptors.NewSysClassAdaptor(sys)
 	return a
 }
 
@@ -82,6 +94,7 @@ func NewProAdaptor() *Adaptor {
 	a := NewAdaptor()
 	a.name = gobot.DefaultName("CHIP Pro")
 	a.pinmap = chipProPins
+	a.SetBusPinDefinitions(chipProBusPinDefinitions)
 	return a
 }
 
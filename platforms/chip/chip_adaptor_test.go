@@ -23,6 +23,7 @@ var (
 	_ gobot.PWMPinnerProvider     = (*Adaptor)(nil)
 	_ gpio.DigitalReader          = (*Adaptor)(nil)
 	_ gpio.DigitalWriter          = (*Adaptor)(nil)
+	_ gpio.SysLedOpener           = (*Adaptor)(nil)
 	_ gpio.PwmWriter              = (*Adaptor)(nil)
 	_ gpio.ServoWriter            = (*Adaptor)(nil)
 	_ i2c.Connector               = (*Adaptor)(nil)
@@ -64,6 +65,7 @@ func initTestProAdaptorWithMockedFilesystem() (*Adaptor, *system.MockFilesystem)
 func TestName(t *testing.T) {
 	a := NewAdaptor()
 	assert.True(t, strings.HasPrefix(a.Name(), "CHIP"))
+	assert.Equal(t, []string{"TWI1-SDA", "TWI1-SCK"}, a.BusPins(gobot.PinFunctionI2c, 1))
 	a.SetName("NewName")
 	assert.Equal(t, "NewName", a.Name())
 }
@@ -71,6 +73,8 @@ func TestName(t *testing.T) {
 func TestNewProAdaptor(t *testing.T) {
 	a := NewProAdaptor()
 	assert.True(t, strings.HasPrefix(a.Name(), "CHIP Pro"))
+	assert.Nil(t, a.BusPins(gobot.PinFunctionI2c, 1))
+	assert.Equal(t, []string{"TWI2-SDA", "TWI2-SCK"}, a.BusPins(gobot.PinFunctionI2c, 2))
 }
 
 func TestFinalizeErrorAfterGPIO(t *testing.T) {
@@ -1,5 +1,7 @@
 package chip
 
+import "gobot.io/x/gobot/v2"
+
 var chipPins = map[string]sysfsPin{
 	"PWM0": {
 		pin:    34,
@@ -174,3 +176,11 @@ var chipPins = map[string]sysfsPin{
 		pwmPin: -1,
 	},
 }
+
+// chipBusPinDefinitions contains the header pins of the hardware buses, which are claimed in the pin registry
+var chipBusPinDefinitions = map[string]map[int][]string{
+	gobot.PinFunctionI2c: {
+		1: {"TWI1-SDA", "TWI1-SCK"},
+		2: {"TWI2-SDA", "TWI2-SCK"},
+	},
+}
@@ -1,5 +1,7 @@
 package chip
 
+import "gobot.io/x/gobot/v2"
+
 var chipProPins = map[string]sysfsPin{
 	"PWM0": {
 		pin:    34,
@@ -166,3 +168,10 @@ var chipProPins = map[string]sysfsPin{
 		pwmPin: -1,
 	},
 }
+
+// chipProBusPinDefinitions contains the header pins of the hardware buses, which are claimed in the pin registry
+var chipProBusPinDefinitions = map[string]map[int][]string{
+	gobot.PinFunctionI2c: {
+		2: {"TWI2-SDA", "TWI2-SCK"},
+	},
+}
@@ -0,0 +1,43 @@
+package gopigo3
+
+import (
+	"math"
+	"sync"
+
+	"gobot.io/x/gobot/v2/drivers/drive"
+	"gobot.io/x/gobot/v2/drivers/gpio"
+)
+
+// driveEncoder reads the encoder of a motor for the differential drive
+type driveEncoder struct {
+	driver *Driver
+	motor  Motor
+	mutex  sync.Mutex
+	last   int
+}
+
+// DriveMotor adapts a motor to the motor interface of the differential drive (package drivers/drive). The motor is
+// controlled by its target speed in degrees per second, the given maximum is used for the speed 1.
+func DriveMotor(d *Driver, motor Motor, maxDps int) drive.Motor {
+	return drive.MotorFunc(func(speed float64) error {
+		return d.SetMotorDps(motor, int(math.Round(math.Max(-1, math.Min(1, speed))*float64(maxDps))))
+	})
+}
+
+// DriveEncoder adapts the encoder of a motor to be used as wheel encoder of the differential drive, the position is
+// given in degrees. On read errors the last position is returned.
+func DriveEncoder(d *Driver, motor Motor) gpio.PositionReader {
+	return &driveEncoder{driver: d, motor: motor}
+}
+
+// CurrentPosition returns the position of the motor in degrees.
+func (e *driveEncoder) CurrentPosition() int {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if position, err := e.driver.GetMotorEncoder(e.motor); err == nil {
+		e.last = int(position)
+	}
+
+	return e.last
+}
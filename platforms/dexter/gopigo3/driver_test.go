@@ -157,6 +157,18 @@ func TestSetMotorDps(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestDriveMotor(t *testing.T) {
+	d := initTestDriver()
+	err := DriveMotor(d, MOTOR_LEFT, 1000).SetSpeed(-0.5)
+	require.NoError(t, err)
+}
+
+func TestDriveEncoder(t *testing.T) {
+	negativeEncoder = false
+	d := initTestDriver()
+	assert.Equal(t, 127, DriveEncoder(d, MOTOR_LEFT).CurrentPosition())
+}
+
 func TestOffsetMotorEncoder(t *testing.T) {
 	d := initTestDriver()
 	err := d.OffsetMotorEncoder(MOTOR_LEFT, 12.0)
@@ -225,6 +237,7 @@ func (c TestSpiDevice) WriteByte(byte) error              { return nil }
 func (c TestSpiDevice) WriteByteData(byte, byte) error    { return nil }
 func (c TestSpiDevice) WriteBlockData(byte, []byte) error { return nil }
 func (c TestSpiDevice) WriteBytes([]byte) error           { return nil }
+func (c TestSpiDevice) Transfer([]gobot.SpiXfer) error    { return nil }
 
 func (c TestSpiDevice) ReadCommandData(w, r []byte) error {
 	manName, _ := hex.DecodeString("ff0000a544657874657220496e6475737472696573000000")
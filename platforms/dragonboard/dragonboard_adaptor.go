@@ -21,6 +21,8 @@ type Adaptor struct {
 	pinMap map[string]int
 	*adaptors.DigitalPinsAdaptor
 	*adaptors.I2cBusAdaptor
+	*adaptors.SysClassAdaptor
+	*adaptors.PinRegistry
 }
 
 // Valid pins are the GPIO_A through GPIO_L pins from the
@@ -44,6 +46,15 @@ var fixedPins = map[string]int{
 	"LED_2": 120,
 }
 
+// busPinDefinitions contains the pins of the hardware buses at the low speed expansion header, which are claimed in
+// the pin registry
+var busPinDefinitions = map[string]map[int][]string{
+	gobot.PinFunctionI2c: {
+		0: {"GPIO_6", "GPIO_7"},   // I2C0 SDA, SCL (header pins 17, 15)
+		1: {"GPIO_22", "GPIO_23"}, // I2C1 SDA, SCL (header pins 21, 19)
+	},
+}
+
 // NewAdaptor creates a DragonBoard 410c Adaptor
 //
 // Optional parameters:
@@ -53,11 +64,15 @@ var fixedPins = map[string]int{
 func NewAdaptor(opts ...func(adaptors.DigitalPinsOptioner)) *Adaptor {
 	sys := system.NewAccesser()
 	c := &Adaptor{
-		name: gobot.DefaultName("DragonBoard"),
-		sys:  sys,
+		name:        gobot.DefaultName("DragonBoard"),
+		sys:         sys,
+		PinRegistry: adaptors.NewPinRegistry(),
 	}
+	c.SetBusPinDefinitions(busPinDefinitions)
 	c.DigitalPinsAdaptor = adaptors.NewDigitalPinsAdaptor(sys, c.translateDigitalPin, opts...)
-	c.I2cBusAdaptor = adaptors.NewI2cBusAdaptor(sys, c.validateI2cBusNumber, defaultI2cBusNumber)
+	c.I2cBusAdaptor = adaptors.NewI2cBusAdaptor(sys, c.validateI2cBusNumber, defaultI2cBusNumber,
+		adaptors.WithI2cPinRegistry(c.PinRegistry))
+	c.SysClassAdaptor = adaptors.NewSysClassAdaptor(sys)
 	c.pinMap = fixedPins
 	for i := 0; i < 122; i++ {
 		pin := fmt.Sprintf("GPIO_%d", i)
@@ -19,6 +19,7 @@ var (
 	_ gobot.DigitalPinnerProvider = (*Adaptor)(nil)
 	_ gpio.DigitalReader          = (*Adaptor)(nil)
 	_ gpio.DigitalWriter          = (*Adaptor)(nil)
+	_ gpio.SysLedOpener           = (*Adaptor)(nil)
 	_ i2c.Connector               = (*Adaptor)(nil)
 )
 
@@ -33,6 +34,7 @@ func initTestAdaptor() *Adaptor {
 func TestName(t *testing.T) {
 	a := initTestAdaptor()
 	assert.True(t, strings.HasPrefix(a.Name(), "DragonBoard"))
+	assert.Equal(t, []string{"GPIO_6", "GPIO_7"}, a.BusPins(gobot.PinFunctionI2c, 0))
 	a.SetName("NewName")
 	assert.Equal(t, "NewName", a.Name())
 }
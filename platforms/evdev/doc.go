@@ -0,0 +1,46 @@
+/*
+Package evdev contains the Gobot adaptor and driver for input devices of the Linux input subsystem, which provides the
+event interface "/dev/input/event*", e.g. keyboards, mice, touch panels, USB HID devices and also buttons or rotary
+encoders, which are connected to GPIO's and configured by the device-tree overlays "gpio-keys" or "rotary-encoder".
+
+Installing:
+
+	Please refer to the main [README.md](https://github.com/hybridgroup/gobot/blob/release/README.md)
+
+Example:
+
+	package main
+
+	import (
+		"fmt"
+
+		"gobot.io/x/gobot/v2"
+		"gobot.io/x/gobot/v2/platforms/evdev"
+	)
+
+	func main() {
+		adaptor := evdev.NewAdaptor(evdev.WithDeviceName("gpio-keys"), evdev.WithGrab())
+		keys := evdev.NewDriver(adaptor)
+
+		work := func() {
+			_ = keys.On(evdev.Key, func(data interface{}) {
+				evt := data.(gobot.InputEvent)
+				fmt.Println(evdev.CodeName(evt.Type, evt.Code), evt.Value)
+			})
+		}
+
+		robot := gobot.NewRobot("evdevBot",
+			[]gobot.Connection{adaptor},
+			[]gobot.Device{keys},
+			work,
+		)
+
+		if err := robot.Start(); err != nil {
+			panic(err)
+		}
+	}
+
+For further information refer to evdev README:
+https://github.com/hybridgroup/gobot/blob/master/platforms/evdev/README.md
+*/
+package evdev // import "gobot.io/x/gobot/v2/platforms/evdev"
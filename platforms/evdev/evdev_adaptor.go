@@ -0,0 +1,286 @@
+package evdev
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	multierror "github.com/hashicorp/go-multierror"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/system"
+)
+
+// optionApplier needs to be implemented by each configurable option type
+type optionApplier interface {
+	apply(cfg *configuration)
+}
+
+// configuration contains all changeable attributes of the adaptor.
+type configuration struct {
+	devicePath string
+	deviceName string
+	devicePhys string
+	grab       bool
+}
+
+// devicePathOption is the type for applying the path of the character device to the configuration
+type devicePathOption string
+
+// deviceNameOption is the type for applying the name of the device to the configuration
+type deviceNameOption string
+
+// devicePhysOption is the type for applying the physical location of the device to the configuration
+type devicePhysOption string
+
+// grabOption is the type for applying the exclusive access to the configuration
+type grabOption bool
+
+// Adaptor is the gobot adaptor for an input device of the Linux input subsystem, which provides the event interface
+// (evdev), e.g. keyboards, mice, touch panels and USB HID devices, but also buttons and rotary encoders connected to
+// GPIO's by the device-tree overlays "gpio-keys" and "rotary-encoder".
+type Adaptor struct {
+	name   string
+	sys    *system.Accesser
+	cfg    *configuration
+	mutex  sync.Mutex
+	info   system.InputDeviceInfo
+	device gobot.InputSystemDevicer
+}
+
+// NewAdaptor creates a new adaptor for an input device. The device needs to be selected by one of the options,
+// if more than one option is given, all needs to match.
+//
+// Supported options:
+//
+//	"WithDevicePath"
+//	"WithDeviceName"
+//	"WithDevicePhys"
+//	"WithGrab"
+func NewAdaptor(opts ...optionApplier) *Adaptor {
+	a := &Adaptor{
+		name: gobot.DefaultName("Evdev"),
+		sys:  system.NewAccesser(),
+		cfg:  &configuration{},
+	}
+
+	for _, o := range opts {
+		o.apply(a.cfg)
+	}
+
+	return a
+}
+
+// WithDevicePath selects the device by the path of the character device, e.g. "/dev/input/event0". A symbolic link
+// can be used also, e.g. "/dev/input/by-id/usb-Logitech_USB_Receiver-event-kbd".
+func WithDevicePath(path string) optionApplier {
+	return devicePathOption(path)
+}
+
+// WithDeviceName selects the device by its name, e.g. "gpio-keys". The names of all devices can be listed by
+// "cat /proc/bus/input/devices" or by FindDevices().
+func WithDeviceName(name string) optionApplier {
+	return deviceNameOption(name)
+}
+
+// WithDevicePhys selects the device by the beginning of its physical location, e.g. "usb-0000:01:00.0-1.3". This is
+// useful, if more than one device with the same name is connected.
+func WithDevicePhys(phys string) optionApplier {
+	return devicePhysOption(phys)
+}
+
+// WithGrab activates the exclusive access to the device, so the events are not delivered to other readers, e.g. the
+// console or the desktop.
+func WithGrab() optionApplier {
+	return grabOption(true)
+}
+
+// FindDevices returns all input devices with event interface of the system, e.g. to find the name of a device.
+func FindDevices() ([]system.InputDeviceInfo, error) {
+	return system.NewAccesser().FindInputDevices()
+}
+
+// Name returns the adaptors name
+func (a *Adaptor) Name() string {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	return a.name
+}
+
+// SetName sets the adaptors name
+func (a *Adaptor) SetName(n string) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.name = n
+}
+
+// Connect selects and opens the input device, the exclusive access is activated, if configured.
+func (a *Adaptor) Connect() error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	info, err := a.selectDevice()
+	if err != nil {
+		return err
+	}
+
+	device, err := a.sys.NewInputDevice(info.Path)
+	if err != nil {
+		return err
+	}
+
+	if a.cfg.grab {
+		if err := device.Grab(true); err != nil {
+			_ = device.Close()
+			return err
+		}
+	}
+
+	a.info = info
+	a.device = device
+	return nil
+}
+
+// Finalize releases the exclusive access, if configured, and closes the input device.
+func (a *Adaptor) Finalize() error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.device == nil {
+		return nil
+	}
+
+	var err error
+	if a.cfg.grab {
+		if e := a.device.Grab(false); e != nil {
+			err = multierror.Append(err, e)
+		}
+	}
+	if e := a.device.Close(); e != nil {
+		err = multierror.Append(err, e)
+	}
+	a.device = nil
+	return err
+}
+
+// DeviceInfo returns the path, name and physical location of the connected device.
+func (a *Adaptor) DeviceInfo() system.InputDeviceInfo {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	return a.info
+}
+
+// ReadEvents blocks until at least one event is available and returns all available events. Finalize() unblocks
+// the reading with an error. If the adaptor is connected again meanwhile, the reading continues with the new device.
+func (a *Adaptor) ReadEvents() ([]gobot.InputEvent, error) {
+	for {
+		a.mutex.Lock()
+		device := a.device
+		a.mutex.Unlock()
+
+		if device == nil {
+			return nil, fmt.Errorf("not connected")
+		}
+		// the mutex is not locked while waiting, otherwise Finalize() is blocked
+		events, err := device.ReadEvents()
+		if err == nil {
+			return events, nil
+		}
+
+		a.mutex.Lock()
+		reconnected := a.device != nil && a.device != device
+		a.mutex.Unlock()
+
+		if !reconnected {
+			return nil, err
+		}
+	}
+}
+
+// selectDevice needs to be called with locked mutex
+func (a *Adaptor) selectDevice() (system.InputDeviceInfo, error) {
+	cfg := a.cfg
+	if cfg.devicePath == "" && cfg.deviceName == "" && cfg.devicePhys == "" {
+		return system.InputDeviceInfo{}, fmt.Errorf("the input device needs to be selected by path, name or phys")
+	}
+
+	infos, err := a.sys.FindInputDevices()
+	if err != nil && (cfg.deviceName != "" || cfg.devicePhys != "") {
+		return system.InputDeviceInfo{}, err
+	}
+
+	for _, info := range infos {
+		if cfg.matches(info) {
+			return info, nil
+		}
+	}
+
+	if cfg.deviceName == "" && cfg.devicePhys == "" {
+		// e.g. a symbolic link, which is not part of the found devices
+		return system.InputDeviceInfo{Path: cfg.devicePath}, nil
+	}
+
+	return system.InputDeviceInfo{}, fmt.Errorf("no input device found with %s", cfg)
+}
+
+func (cfg *configuration) matches(info system.InputDeviceInfo) bool {
+	if cfg.devicePath != "" && cfg.devicePath != info.Path {
+		return false
+	}
+	if cfg.deviceName != "" && cfg.deviceName != info.Name {
+		return false
+	}
+	if cfg.devicePhys != "" && !strings.HasPrefix(info.Phys, cfg.devicePhys) {
+		return false
+	}
+	return true
+}
+
+func (cfg *configuration) String() string {
+	var criteria []string
+	if cfg.devicePath != "" {
+		criteria = append(criteria, fmt.Sprintf("path '%s'", cfg.devicePath))
+	}
+	if cfg.deviceName != "" {
+		criteria = append(criteria, fmt.Sprintf("name '%s'", cfg.deviceName))
+	}
+	if cfg.devicePhys != "" {
+		criteria = append(criteria, fmt.Sprintf("phys '%s*'", cfg.devicePhys))
+	}
+	return strings.Join(criteria, ", ")
+}
+
+func (o devicePathOption) String() string {
+	return "device path option for input devices"
+}
+
+func (o deviceNameOption) String() string {
+	return "device name option for input devices"
+}
+
+func (o devicePhysOption) String() string {
+	return "device phys option for input devices"
+}
+
+func (o grabOption) String() string {
+	return "grab option for input devices"
+}
+
+func (o devicePathOption) apply(cfg *configuration) {
+	cfg.devicePath = string(o)
+}
+
+func (o deviceNameOption) apply(cfg *configuration) {
+	cfg.deviceName = string(o)
+}
+
+func (o devicePhysOption) apply(cfg *configuration) {
+	cfg.devicePhys = string(o)
+}
+
+func (o grabOption) apply(cfg *configuration) {
+	cfg.grab = bool(o)
+}
@@ -0,0 +1,220 @@
+package evdev
+
+import (
+	"testing"
+	"time"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/system"
+)
+
+// make sure that this Adaptor fulfills all the required interfaces
+var _ gobot.Adaptor = (*Adaptor)(nil)
+
+const evdevTestIoctlGrab = 0x40044590
+
+var evdevTestMockPaths = []string{
+	"/sys/class/input/event0/device/name",
+	"/sys/class/input/event0/device/phys",
+	"/sys/class/input/event1/device/name",
+	"/sys/class/input/event1/device/phys",
+	"/sys/class/input/event2/device/name",
+	"/dev/input/event0",
+	"/dev/input/event1",
+	"/dev/input/event2",
+	"/dev/input/by-id/usb-Logitech_USB_Receiver-event-kbd",
+}
+
+func initTestAdaptorWithMockedFilesystem(opts ...optionApplier) (*Adaptor, *system.MockFilesystem) {
+	a := NewAdaptor(opts...)
+	fs := a.sys.UseMockFilesystem(evdevTestMockPaths)
+	fs.Files["/sys/class/input/event0/device/name"].Contents = "Logitech USB Receiver\n"
+	fs.Files["/sys/class/input/event0/device/phys"].Contents = "usb-0000:01:00.0-1.3/input0\n"
+	fs.Files["/sys/class/input/event1/device/name"].Contents = "Logitech USB Receiver\n"
+	fs.Files["/sys/class/input/event1/device/phys"].Contents = "usb-0000:01:00.0-1.4/input0\n"
+	fs.Files["/sys/class/input/event2/device/name"].Contents = "gpio-keys\n"
+	return a, fs
+}
+
+func TestNewAdaptor(t *testing.T) {
+	// arrange & act
+	a := NewAdaptor(WithDeviceName("gpio-keys"), WithDevicePhys("usb-1"), WithDevicePath("/dev/input/event3"),
+		WithGrab())
+	// assert
+	assert.Contains(t, a.Name(), "Evdev")
+	assert.Equal(t, &configuration{
+		devicePath: "/dev/input/event3",
+		deviceName: "gpio-keys",
+		devicePhys: "usb-1",
+		grab:       true,
+	}, a.cfg)
+	a.SetName("mykeys")
+	assert.Equal(t, "mykeys", a.Name())
+}
+
+func TestConnect(t *testing.T) {
+	tests := map[string]struct {
+		opts    []optionApplier
+		want    system.InputDeviceInfo
+		wantErr string
+	}{
+		"by_name": {
+			opts: []optionApplier{WithDeviceName("gpio-keys")},
+			want: system.InputDeviceInfo{Path: "/dev/input/event2", Name: "gpio-keys"},
+		},
+		"by_name_first_of_more": {
+			opts: []optionApplier{WithDeviceName("Logitech USB Receiver")},
+			want: system.InputDeviceInfo{
+				Path: "/dev/input/event0", Name: "Logitech USB Receiver", Phys: "usb-0000:01:00.0-1.3/input0",
+			},
+		},
+		"by_name_and_phys": {
+			opts: []optionApplier{WithDeviceName("Logitech USB Receiver"), WithDevicePhys("usb-0000:01:00.0-1.4")},
+			want: system.InputDeviceInfo{
+				Path: "/dev/input/event1", Name: "Logitech USB Receiver", Phys: "usb-0000:01:00.0-1.4/input0",
+			},
+		},
+		"by_path": {
+			opts: []optionApplier{WithDevicePath("/dev/input/event2")},
+			want: system.InputDeviceInfo{Path: "/dev/input/event2", Name: "gpio-keys"},
+		},
+		"by_symbolic_link": {
+			opts: []optionApplier{WithDevicePath("/dev/input/by-id/usb-Logitech_USB_Receiver-event-kbd")},
+			want: system.InputDeviceInfo{Path: "/dev/input/by-id/usb-Logitech_USB_Receiver-event-kbd"},
+		},
+		"error_no_selection": {
+			wantErr: "the input device needs to be selected by path, name or phys",
+		},
+		"error_not_found": {
+			opts:    []optionApplier{WithDeviceName("gpio-keys"), WithDevicePhys("usb")},
+			wantErr: "no input device found with name 'gpio-keys', phys 'usb*'",
+		},
+		"error_not_existing_path": {
+			opts:    []optionApplier{WithDevicePath("/dev/input/event3")},
+			wantErr: "/dev/input/event3: no such file",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a, fs := initTestAdaptorWithMockedFilesystem(tc.opts...)
+			// act
+			err := a.Connect()
+			// assert
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				assert.Nil(t, a.device)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, a.DeviceInfo())
+			assert.True(t, fs.Files[tc.want.Path].Opened)
+		})
+	}
+}
+
+func TestConnectFinalizeWithGrab(t *testing.T) {
+	// arrange
+	a, fs := initTestAdaptorWithMockedFilesystem(WithDeviceName("gpio-keys"), WithGrab())
+	msc := a.sys.UseMockSyscall()
+	var grabCalls int
+	msc.Impl = func(trap, a1, a2 uintptr, a3 unsafe.Pointer) (uintptr, uintptr, system.SyscallErrno) {
+		if a2 == evdevTestIoctlGrab {
+			grabCalls++
+		}
+		return 0, 0, 0
+	}
+	// act & assert
+	require.NoError(t, a.Connect())
+	assert.Equal(t, 1, grabCalls)
+	require.NoError(t, a.Finalize())
+	assert.Equal(t, 2, grabCalls)
+	assert.True(t, fs.Files["/dev/input/event2"].Closed)
+	require.NoError(t, a.Finalize())
+	assert.Equal(t, 2, grabCalls)
+}
+
+func TestConnectGrabError(t *testing.T) {
+	// arrange
+	a, fs := initTestAdaptorWithMockedFilesystem(WithDeviceName("gpio-keys"), WithGrab())
+	msc := a.sys.UseMockSyscall()
+	msc.Impl = func(trap, a1, a2 uintptr, a3 unsafe.Pointer) (uintptr, uintptr, system.SyscallErrno) {
+		return 0, 0, system.SyscallErrno(system.Syscall_EBUSY)
+	}
+	// act
+	err := a.Connect()
+	// assert
+	require.ErrorContains(t, err, "grab (true) of input device '/dev/input/event2' failed")
+	assert.True(t, fs.Files["/dev/input/event2"].Closed)
+	assert.Nil(t, a.device)
+}
+
+func TestReadEventsNotConnected(t *testing.T) {
+	// arrange
+	a := NewAdaptor(WithDeviceName("gpio-keys"))
+	// act
+	got, err := a.ReadEvents()
+	// assert
+	require.EqualError(t, err, "not connected")
+	assert.Nil(t, got)
+}
+
+func TestReadEventsReconnected(t *testing.T) {
+	// arrange
+	a := NewAdaptor(WithDeviceName("gpio-keys"))
+	closed := newEvdevTestDevice()
+	closed.blocked = make(chan struct{}, 1)
+	a.device = closed
+	want := []gobot.InputEvent{{Type: EV_KEY, Code: KEY_A, Value: 1}}
+	type result struct {
+		events []gobot.InputEvent
+		err    error
+	}
+	results := make(chan result)
+	go func() {
+		events, err := a.ReadEvents()
+		results <- result{events: events, err: err}
+	}()
+	select {
+	case <-closed.blocked:
+	case <-time.After(time.Second):
+		require.Fail(t, "read not blocked")
+	}
+	// act: finalize and connect again
+	a.mutex.Lock()
+	_ = closed.Close()
+	a.device = newEvdevTestDevice(want)
+	a.mutex.Unlock()
+	// assert
+	select {
+	case got := <-results:
+		require.NoError(t, got.err)
+		assert.Equal(t, want, got.events)
+	case <-time.After(time.Second):
+		require.Fail(t, "read not continued")
+	}
+}
+
+func TestReadEventsFinalized(t *testing.T) {
+	// arrange
+	a := NewAdaptor(WithDeviceName("gpio-keys"))
+	a.device = newEvdevTestDevice()
+	errs := make(chan error)
+	go func() {
+		_, err := a.ReadEvents()
+		errs <- err
+	}()
+	// act
+	require.NoError(t, a.Finalize())
+	// assert
+	select {
+	case err := <-errs:
+		require.Error(t, err)
+	case <-time.After(time.Second):
+		require.Fail(t, "read not unblocked")
+	}
+}
@@ -0,0 +1,473 @@
+package evdev
+
+import "fmt"
+
+// The types and codes of the events, according to "linux/input-event-codes.h". Only the most common codes are
+// contained, all other codes are published with the numeric value, see also CodeName().
+//
+//	https://docs.kernel.org/input/event-codes.html
+
+// Event types
+const (
+	EV_SYN = 0x00
+	EV_KEY = 0x01
+	EV_REL = 0x02
+	EV_ABS = 0x03
+	EV_MSC = 0x04
+	EV_SW  = 0x05
+	EV_LED = 0x11
+	EV_SND = 0x12
+	EV_REP = 0x14
+)
+
+// Synchronization events
+const (
+	SYN_REPORT    = 0
+	SYN_CONFIG    = 1
+	SYN_MT_REPORT = 2
+	SYN_DROPPED   = 3
+)
+
+// Keys, the buttons of a keyboard or of a remote control, e.g. connected by "gpio-keys"
+const (
+	KEY_RESERVED     = 0
+	KEY_ESC          = 1
+	KEY_1            = 2
+	KEY_2            = 3
+	KEY_3            = 4
+	KEY_4            = 5
+	KEY_5            = 6
+	KEY_6            = 7
+	KEY_7            = 8
+	KEY_8            = 9
+	KEY_9            = 10
+	KEY_0            = 11
+	KEY_MINUS        = 12
+	KEY_EQUAL        = 13
+	KEY_BACKSPACE    = 14
+	KEY_TAB          = 15
+	KEY_Q            = 16
+	KEY_W            = 17
+	KEY_E            = 18
+	KEY_R            = 19
+	KEY_T            = 20
+	KEY_Y            = 21
+	KEY_U            = 22
+	KEY_I            = 23
+	KEY_O            = 24
+	KEY_P            = 25
+	KEY_LEFTBRACE    = 26
+	KEY_RIGHTBRACE   = 27
+	KEY_ENTER        = 28
+	KEY_LEFTCTRL     = 29
+	KEY_A            = 30
+	KEY_S            = 31
+	KEY_D            = 32
+	KEY_F            = 33
+	KEY_G            = 34
+	KEY_H            = 35
+	KEY_J            = 36
+	KEY_K            = 37
+	KEY_L            = 38
+	KEY_SEMICOLON    = 39
+	KEY_APOSTROPHE   = 40
+	KEY_GRAVE        = 41
+	KEY_LEFTSHIFT    = 42
+	KEY_BACKSLASH    = 43
+	KEY_Z            = 44
+	KEY_X            = 45
+	KEY_C            = 46
+	KEY_V            = 47
+	KEY_B            = 48
+	KEY_N            = 49
+	KEY_M            = 50
+	KEY_COMMA        = 51
+	KEY_DOT          = 52
+	KEY_SLASH        = 53
+	KEY_RIGHTSHIFT   = 54
+	KEY_KPASTERISK   = 55
+	KEY_LEFTALT      = 56
+	KEY_SPACE        = 57
+	KEY_CAPSLOCK     = 58
+	KEY_F1           = 59
+	KEY_F2           = 60
+	KEY_F3           = 61
+	KEY_F4           = 62
+	KEY_F5           = 63
+	KEY_F6           = 64
+	KEY_F7           = 65
+	KEY_F8           = 66
+	KEY_F9           = 67
+	KEY_F10          = 68
+	KEY_NUMLOCK      = 69
+	KEY_SCROLLLOCK   = 70
+	KEY_F11          = 87
+	KEY_F12          = 88
+	KEY_KPENTER      = 96
+	KEY_RIGHTCTRL    = 97
+	KEY_RIGHTALT     = 100
+	KEY_HOME         = 102
+	KEY_UP           = 103
+	KEY_PAGEUP       = 104
+	KEY_LEFT         = 105
+	KEY_RIGHT        = 106
+	KEY_END          = 107
+	KEY_DOWN         = 108
+	KEY_PAGEDOWN     = 109
+	KEY_INSERT       = 110
+	KEY_DELETE       = 111
+	KEY_MUTE         = 113
+	KEY_VOLUMEDOWN   = 114
+	KEY_VOLUMEUP     = 115
+	KEY_POWER        = 116
+	KEY_PAUSE        = 119
+	KEY_LEFTMETA     = 125
+	KEY_RIGHTMETA    = 126
+	KEY_MENU         = 139
+	KEY_SLEEP        = 142
+	KEY_WAKEUP       = 143
+	KEY_BACK         = 158
+	KEY_PLAYPAUSE    = 164
+	KEY_NEXTSONG     = 163
+	KEY_PREVIOUSSONG = 165
+	KEY_STOPCD       = 166
+	KEY_HOMEPAGE     = 172
+	KEY_OK           = 352
+	KEY_SELECT       = 353
+)
+
+// Buttons of mice, joysticks, gamepads and touch devices
+const (
+	BTN_0           = 0x100
+	BTN_1           = 0x101
+	BTN_2           = 0x102
+	BTN_3           = 0x103
+	BTN_4           = 0x104
+	BTN_5           = 0x105
+	BTN_6           = 0x106
+	BTN_7           = 0x107
+	BTN_8           = 0x108
+	BTN_9           = 0x109
+	BTN_LEFT        = 0x110
+	BTN_RIGHT       = 0x111
+	BTN_MIDDLE      = 0x112
+	BTN_SIDE        = 0x113
+	BTN_EXTRA       = 0x114
+	BTN_SOUTH       = 0x130
+	BTN_EAST        = 0x131
+	BTN_NORTH       = 0x133
+	BTN_WEST        = 0x134
+	BTN_TL          = 0x136
+	BTN_TR          = 0x137
+	BTN_TL2         = 0x138
+	BTN_TR2         = 0x139
+	BTN_SELECT      = 0x13a
+	BTN_START       = 0x13b
+	BTN_MODE        = 0x13c
+	BTN_THUMBL      = 0x13d
+	BTN_THUMBR      = 0x13e
+	BTN_TOOL_PEN    = 0x140
+	BTN_TOOL_FINGER = 0x145
+	BTN_TOUCH       = 0x14a
+	BTN_STYLUS      = 0x14b
+	BTN_DPAD_UP     = 0x220
+	BTN_DPAD_DOWN   = 0x221
+	BTN_DPAD_LEFT   = 0x222
+	BTN_DPAD_RIGHT  = 0x223
+)
+
+// Relative axes, e.g. of a mouse or a rotary encoder connected by "rotary-encoder"
+const (
+	REL_X      = 0
+	REL_Y      = 1
+	REL_Z      = 2
+	REL_RX     = 3
+	REL_RY     = 4
+	REL_RZ     = 5
+	REL_HWHEEL = 6
+	REL_DIAL   = 7
+	REL_WHEEL  = 8
+	REL_MISC   = 9
+)
+
+// Absolute axes, e.g. of a joystick or a touch panel
+const (
+	ABS_X              = 0x00
+	ABS_Y              = 0x01
+	ABS_Z              = 0x02
+	ABS_RX             = 0x03
+	ABS_RY             = 0x04
+	ABS_RZ             = 0x05
+	ABS_THROTTLE       = 0x06
+	ABS_RUDDER         = 0x07
+	ABS_WHEEL          = 0x08
+	ABS_GAS            = 0x09
+	ABS_BRAKE          = 0x0a
+	ABS_HAT0X          = 0x10
+	ABS_HAT0Y          = 0x11
+	ABS_PRESSURE       = 0x18
+	ABS_DISTANCE       = 0x19
+	ABS_MISC           = 0x28
+	ABS_MT_SLOT        = 0x2f
+	ABS_MT_TOUCH_MAJOR = 0x30
+	ABS_MT_TOUCH_MINOR = 0x31
+	ABS_MT_POSITION_X  = 0x35
+	ABS_MT_POSITION_Y  = 0x36
+	ABS_MT_TRACKING_ID = 0x39
+	ABS_MT_PRESSURE    = 0x3a
+)
+
+// Switches
+const (
+	SW_LID               = 0
+	SW_TABLET_MODE       = 1
+	SW_HEADPHONE_INSERT  = 2
+	SW_RFKILL_ALL        = 3
+	SW_MICROPHONE_INSERT = 4
+	SW_DOCK              = 5
+	SW_LINEOUT_INSERT    = 6
+)
+
+// Miscellaneous events
+const (
+	MSC_SERIAL    = 0
+	MSC_PULSELED  = 1
+	MSC_GESTURE   = 2
+	MSC_RAW       = 3
+	MSC_SCAN      = 4
+	MSC_TIMESTAMP = 5
+)
+
+var typeNames = map[uint16]string{
+	EV_SYN: "EV_SYN",
+	EV_KEY: "EV_KEY",
+	EV_REL: "EV_REL",
+	EV_ABS: "EV_ABS",
+	EV_MSC: "EV_MSC",
+	EV_SW:  "EV_SW",
+	EV_LED: "EV_LED",
+	EV_SND: "EV_SND",
+	EV_REP: "EV_REP",
+}
+
+var codeNames = map[uint16]map[uint16]string{
+	EV_SYN: {
+		SYN_REPORT:    "SYN_REPORT",
+		SYN_CONFIG:    "SYN_CONFIG",
+		SYN_MT_REPORT: "SYN_MT_REPORT",
+		SYN_DROPPED:   "SYN_DROPPED",
+	},
+	EV_KEY: {
+		KEY_RESERVED:     "KEY_RESERVED",
+		KEY_ESC:          "KEY_ESC",
+		KEY_1:            "KEY_1",
+		KEY_2:            "KEY_2",
+		KEY_3:            "KEY_3",
+		KEY_4:            "KEY_4",
+		KEY_5:            "KEY_5",
+		KEY_6:            "KEY_6",
+		KEY_7:            "KEY_7",
+		KEY_8:            "KEY_8",
+		KEY_9:            "KEY_9",
+		KEY_0:            "KEY_0",
+		KEY_MINUS:        "KEY_MINUS",
+		KEY_EQUAL:        "KEY_EQUAL",
+		KEY_BACKSPACE:    "KEY_BACKSPACE",
+		KEY_TAB:          "KEY_TAB",
+		KEY_Q:            "KEY_Q",
+		KEY_W:            "KEY_W",
+		KEY_E:            "KEY_E",
+		KEY_R:            "KEY_R",
+		KEY_T:            "KEY_T",
+		KEY_Y:            "KEY_Y",
+		KEY_U:            "KEY_U",
+		KEY_I:            "KEY_I",
+		KEY_O:            "KEY_O",
+		KEY_P:            "KEY_P",
+		KEY_LEFTBRACE:    "KEY_LEFTBRACE",
+		KEY_RIGHTBRACE:   "KEY_RIGHTBRACE",
+		KEY_ENTER:        "KEY_ENTER",
+		KEY_LEFTCTRL:     "KEY_LEFTCTRL",
+		KEY_A:            "KEY_A",
+		KEY_S:            "KEY_S",
+		KEY_D:            "KEY_D",
+		KEY_F:            "KEY_F",
+		KEY_G:            "KEY_G",
+		KEY_H:            "KEY_H",
+		KEY_J:            "KEY_J",
+		KEY_K:            "KEY_K",
+		KEY_L:            "KEY_L",
+		KEY_SEMICOLON:    "KEY_SEMICOLON",
+		KEY_APOSTROPHE:   "KEY_APOSTROPHE",
+		KEY_GRAVE:        "KEY_GRAVE",
+		KEY_LEFTSHIFT:    "KEY_LEFTSHIFT",
+		KEY_BACKSLASH:    "KEY_BACKSLASH",
+		KEY_Z:            "KEY_Z",
+		KEY_X:            "KEY_X",
+		KEY_C:            "KEY_C",
+		KEY_V:            "KEY_V",
+		KEY_B:            "KEY_B",
+		KEY_N:            "KEY_N",
+		KEY_M:            "KEY_M",
+		KEY_COMMA:        "KEY_COMMA",
+		KEY_DOT:          "KEY_DOT",
+		KEY_SLASH:        "KEY_SLASH",
+		KEY_RIGHTSHIFT:   "KEY_RIGHTSHIFT",
+		KEY_KPASTERISK:   "KEY_KPASTERISK",
+		KEY_LEFTALT:      "KEY_LEFTALT",
+		KEY_SPACE:        "KEY_SPACE",
+		KEY_CAPSLOCK:     "KEY_CAPSLOCK",
+		KEY_F1:           "KEY_F1",
+		KEY_F2:           "KEY_F2",
+		KEY_F3:           "KEY_F3",
+		KEY_F4:           "KEY_F4",
+		KEY_F5:           "KEY_F5",
+		KEY_F6:           "KEY_F6",
+		KEY_F7:           "KEY_F7",
+		KEY_F8:           "KEY_F8",
+		KEY_F9:           "KEY_F9",
+		KEY_F10:          "KEY_F10",
+		KEY_NUMLOCK:      "KEY_NUMLOCK",
+		KEY_SCROLLLOCK:   "KEY_SCROLLLOCK",
+		KEY_F11:          "KEY_F11",
+		KEY_F12:          "KEY_F12",
+		KEY_KPENTER:      "KEY_KPENTER",
+		KEY_RIGHTCTRL:    "KEY_RIGHTCTRL",
+		KEY_RIGHTALT:     "KEY_RIGHTALT",
+		KEY_HOME:         "KEY_HOME",
+		KEY_UP:           "KEY_UP",
+		KEY_PAGEUP:       "KEY_PAGEUP",
+		KEY_LEFT:         "KEY_LEFT",
+		KEY_RIGHT:        "KEY_RIGHT",
+		KEY_END:          "KEY_END",
+		KEY_DOWN:         "KEY_DOWN",
+		KEY_PAGEDOWN:     "KEY_PAGEDOWN",
+		KEY_INSERT:       "KEY_INSERT",
+		KEY_DELETE:       "KEY_DELETE",
+		KEY_MUTE:         "KEY_MUTE",
+		KEY_VOLUMEDOWN:   "KEY_VOLUMEDOWN",
+		KEY_VOLUMEUP:     "KEY_VOLUMEUP",
+		KEY_POWER:        "KEY_POWER",
+		KEY_PAUSE:        "KEY_PAUSE",
+		KEY_LEFTMETA:     "KEY_LEFTMETA",
+		KEY_RIGHTMETA:    "KEY_RIGHTMETA",
+		KEY_MENU:         "KEY_MENU",
+		KEY_SLEEP:        "KEY_SLEEP",
+		KEY_WAKEUP:       "KEY_WAKEUP",
+		KEY_BACK:         "KEY_BACK",
+		KEY_PLAYPAUSE:    "KEY_PLAYPAUSE",
+		KEY_NEXTSONG:     "KEY_NEXTSONG",
+		KEY_PREVIOUSSONG: "KEY_PREVIOUSSONG",
+		KEY_STOPCD:       "KEY_STOPCD",
+		KEY_HOMEPAGE:     "KEY_HOMEPAGE",
+		KEY_OK:           "KEY_OK",
+		KEY_SELECT:       "KEY_SELECT",
+		BTN_0:            "BTN_0",
+		BTN_1:            "BTN_1",
+		BTN_2:            "BTN_2",
+		BTN_3:            "BTN_3",
+		BTN_4:            "BTN_4",
+		BTN_5:            "BTN_5",
+		BTN_6:            "BTN_6",
+		BTN_7:            "BTN_7",
+		BTN_8:            "BTN_8",
+		BTN_9:            "BTN_9",
+		BTN_LEFT:         "BTN_LEFT",
+		BTN_RIGHT:        "BTN_RIGHT",
+		BTN_MIDDLE:       "BTN_MIDDLE",
+		BTN_SIDE:         "BTN_SIDE",
+		BTN_EXTRA:        "BTN_EXTRA",
+		BTN_SOUTH:        "BTN_SOUTH",
+		BTN_EAST:         "BTN_EAST",
+		BTN_NORTH:        "BTN_NORTH",
+		BTN_WEST:         "BTN_WEST",
+		BTN_TL:           "BTN_TL",
+		BTN_TR:           "BTN_TR",
+		BTN_TL2:          "BTN_TL2",
+		BTN_TR2:          "BTN_TR2",
+		BTN_SELECT:       "BTN_SELECT",
+		BTN_START:        "BTN_START",
+		BTN_MODE:         "BTN_MODE",
+		BTN_THUMBL:       "BTN_THUMBL",
+		BTN_THUMBR:       "BTN_THUMBR",
+		BTN_TOOL_PEN:     "BTN_TOOL_PEN",
+		BTN_TOOL_FINGER:  "BTN_TOOL_FINGER",
+		BTN_TOUCH:        "BTN_TOUCH",
+		BTN_STYLUS:       "BTN_STYLUS",
+		BTN_DPAD_UP:      "BTN_DPAD_UP",
+		BTN_DPAD_DOWN:    "BTN_DPAD_DOWN",
+		BTN_DPAD_LEFT:    "BTN_DPAD_LEFT",
+		BTN_DPAD_RIGHT:   "BTN_DPAD_RIGHT",
+	},
+	EV_REL: {
+		REL_X:      "REL_X",
+		REL_Y:      "REL_Y",
+		REL_Z:      "REL_Z",
+		REL_RX:     "REL_RX",
+		REL_RY:     "REL_RY",
+		REL_RZ:     "REL_RZ",
+		REL_HWHEEL: "REL_HWHEEL",
+		REL_DIAL:   "REL_DIAL",
+		REL_WHEEL:  "REL_WHEEL",
+		REL_MISC:   "REL_MISC",
+	},
+	EV_ABS: {
+		ABS_X:              "ABS_X",
+		ABS_Y:              "ABS_Y",
+		ABS_Z:              "ABS_Z",
+		ABS_RX:             "ABS_RX",
+		ABS_RY:             "ABS_RY",
+		ABS_RZ:             "ABS_RZ",
+		ABS_THROTTLE:       "ABS_THROTTLE",
+		ABS_RUDDER:         "ABS_RUDDER",
+		ABS_WHEEL:          "ABS_WHEEL",
+		ABS_GAS:            "ABS_GAS",
+		ABS_BRAKE:          "ABS_BRAKE",
+		ABS_HAT0X:          "ABS_HAT0X",
+		ABS_HAT0Y:          "ABS_HAT0Y",
+		ABS_PRESSURE:       "ABS_PRESSURE",
+		ABS_DISTANCE:       "ABS_DISTANCE",
+		ABS_MISC:           "ABS_MISC",
+		ABS_MT_SLOT:        "ABS_MT_SLOT",
+		ABS_MT_TOUCH_MAJOR: "ABS_MT_TOUCH_MAJOR",
+		ABS_MT_TOUCH_MINOR: "ABS_MT_TOUCH_MINOR",
+		ABS_MT_POSITION_X:  "ABS_MT_POSITION_X",
+		ABS_MT_POSITION_Y:  "ABS_MT_POSITION_Y",
+		ABS_MT_TRACKING_ID: "ABS_MT_TRACKING_ID",
+		ABS_MT_PRESSURE:    "ABS_MT_PRESSURE",
+	},
+	EV_SW: {
+		SW_LID:               "SW_LID",
+		SW_TABLET_MODE:       "SW_TABLET_MODE",
+		SW_HEADPHONE_INSERT:  "SW_HEADPHONE_INSERT",
+		SW_RFKILL_ALL:        "SW_RFKILL_ALL",
+		SW_MICROPHONE_INSERT: "SW_MICROPHONE_INSERT",
+		SW_DOCK:              "SW_DOCK",
+		SW_LINEOUT_INSERT:    "SW_LINEOUT_INSERT",
+	},
+	EV_MSC: {
+		MSC_SERIAL:    "MSC_SERIAL",
+		MSC_PULSELED:  "MSC_PULSELED",
+		MSC_GESTURE:   "MSC_GESTURE",
+		MSC_RAW:       "MSC_RAW",
+		MSC_SCAN:      "MSC_SCAN",
+		MSC_TIMESTAMP: "MSC_TIMESTAMP",
+	},
+}
+
+// TypeName returns the name of the event type, e.g. "EV_KEY", or the numeric value for unknown types.
+func TypeName(evType uint16) string {
+	if name, ok := typeNames[evType]; ok {
+		return name
+	}
+	return fmt.Sprintf("0x%02x", evType)
+}
+
+// CodeName returns the name of the code for the given event type, e.g. "KEY_A", or the numeric value for unknown
+// codes.
+func CodeName(evType uint16, code uint16) string {
+	if name, ok := codeNames[evType][code]; ok {
+		return name
+	}
+	return fmt.Sprintf("0x%03x", code)
+}
@@ -0,0 +1,146 @@
+package evdev
+
+import (
+	"sync"
+
+	"gobot.io/x/gobot/v2"
+)
+
+const (
+	// Key event, for keys and buttons (EV_KEY), the value is 0 for release, 1 for press and 2 for auto repeat
+	Key = "key"
+	// Rel event, for relative axes (EV_REL), e.g. the movement of a mouse or the steps of a rotary encoder
+	Rel = "rel"
+	// Abs event, for absolute axes (EV_ABS), e.g. the position of a joystick or a touch
+	Abs = "abs"
+	// Switch event, for switches (EV_SW), the value is 0 for off and 1 for on
+	Switch = "switch"
+	// Sync event, for synchronization (EV_SYN), e.g. SYN_REPORT after all values of a touch are published
+	Sync = "sync"
+	// Error event
+	Error = "error"
+)
+
+// Driver is the gobot driver for an input device of the Linux input subsystem (evdev). All events are published with
+// the data of type gobot.InputEvent, containing the type and code according to "linux/input-event-codes.h".
+type Driver struct {
+	name       string
+	connection *Adaptor
+	mutex      sync.Mutex
+	started    bool
+	listening  bool // the reader is running, it is reused by the next start after halt
+	gobot.Eventer
+}
+
+// NewDriver creates a new driver for the input device of the given adaptor.
+func NewDriver(a *Adaptor) *Driver {
+	d := &Driver{
+		name:       gobot.DefaultName("Evdev"),
+		connection: a,
+		Eventer:    gobot.NewEventer(),
+	}
+
+	d.AddEvent(Key)
+	d.AddEvent(Rel)
+	d.AddEvent(Abs)
+	d.AddEvent(Switch)
+	d.AddEvent(Sync)
+	d.AddEvent(Error)
+
+	return d
+}
+
+// Name returns the drivers name
+func (d *Driver) Name() string {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.name
+}
+
+// SetName sets the drivers name
+func (d *Driver) SetName(n string) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.name = n
+}
+
+// Connection returns the drivers connection
+func (d *Driver) Connection() gobot.Connection { return d.connection }
+
+// Start starts the reading and publishing of the events. A reader, which is still running after halt, is reused.
+func (d *Driver) Start() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.started {
+		return nil
+	}
+
+	d.started = true
+	if !d.listening {
+		d.listening = true
+		go d.listen()
+	}
+
+	return nil
+}
+
+// Halt stops the publishing of events. The reader is blocked until the next events are available, so it stops not
+// before the adaptor is finalized. Meanwhile, the read events are dropped.
+func (d *Driver) Halt() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.started = false
+
+	return nil
+}
+
+func (d *Driver) listen() {
+	for {
+		events, err := d.connection.ReadEvents()
+
+		d.mutex.Lock()
+		started := d.started
+		if err != nil {
+			d.listening = false
+		}
+		d.mutex.Unlock()
+
+		if err != nil {
+			if started {
+				d.Publish(Error, err)
+			}
+			return
+		}
+
+		if !started {
+			continue
+		}
+
+		for _, event := range events {
+			if name := eventName(event.Type); name != "" {
+				d.Publish(name, event)
+			}
+		}
+	}
+}
+
+func eventName(evType uint16) string {
+	switch evType {
+	case EV_KEY:
+		return Key
+	case EV_REL:
+		return Rel
+	case EV_ABS:
+		return Abs
+	case EV_SW:
+		return Switch
+	case EV_SYN:
+		return Sync
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,196 @@
+package evdev
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+)
+
+var _ gobot.Driver = (*Driver)(nil)
+
+// evdevTestDevice returns the given reads one after another and blocks afterwards until closed, which is signaled
+// by the blocked channel, if set
+type evdevTestDevice struct {
+	reads   chan []gobot.InputEvent
+	closed  chan struct{}
+	blocked chan struct{}
+	err     error
+}
+
+func newEvdevTestDevice(reads ...[]gobot.InputEvent) *evdevTestDevice {
+	d := &evdevTestDevice{reads: make(chan []gobot.InputEvent, len(reads)), closed: make(chan struct{})}
+	for _, r := range reads {
+		d.reads <- r
+	}
+	return d
+}
+
+func (d *evdevTestDevice) ReadEvents() ([]gobot.InputEvent, error) {
+	if d.err != nil {
+		return nil, d.err
+	}
+	select {
+	case events := <-d.reads:
+		return events, nil
+	default:
+	}
+	if d.blocked != nil {
+		d.blocked <- struct{}{}
+	}
+	select {
+	case events := <-d.reads:
+		return events, nil
+	case <-d.closed:
+		return nil, errors.New("file already closed")
+	}
+}
+
+func (d *evdevTestDevice) Grab(bool) error { return nil }
+
+func (d *evdevTestDevice) Close() error {
+	close(d.closed)
+	return nil
+}
+
+func initTestDriver(device *evdevTestDevice) *Driver {
+	a := NewAdaptor(WithDevicePath("/dev/input/event0"))
+	a.device = device
+	return NewDriver(a)
+}
+
+func TestNewDriver(t *testing.T) {
+	// arrange
+	a := NewAdaptor()
+	// act
+	d := NewDriver(a)
+	// assert
+	assert.Contains(t, d.Name(), "Evdev")
+	assert.Equal(t, a, d.Connection())
+	for _, name := range []string{Key, Rel, Abs, Switch, Sync, Error} {
+		assert.Equal(t, name, d.Event(name))
+	}
+	d.SetName("mykeys")
+	assert.Equal(t, "mykeys", d.Name())
+}
+
+func TestDriverPublish(t *testing.T) {
+	// arrange: a rotary encoder step, a key press and a touch
+	ts := time.Unix(1700000000, 0)
+	reads := [][]gobot.InputEvent{
+		{
+			{Time: ts, Type: EV_REL, Code: REL_X, Value: -1},
+			{Time: ts, Type: EV_SYN, Code: SYN_REPORT},
+		},
+		{
+			{Time: ts, Type: EV_MSC, Code: MSC_SCAN, Value: 0x70004},
+			{Time: ts, Type: EV_KEY, Code: KEY_A, Value: 1},
+			{Time: ts, Type: EV_SYN, Code: SYN_REPORT},
+		},
+		{
+			{Time: ts, Type: EV_ABS, Code: ABS_MT_POSITION_X, Value: 120},
+			{Time: ts, Type: EV_SW, Code: SW_LID, Value: 1},
+		},
+	}
+	d := initTestDriver(newEvdevTestDevice(reads...))
+	events := d.Subscribe()
+	// act
+	require.NoError(t, d.Start())
+	// assert
+	want := []struct {
+		name  string
+		event gobot.InputEvent
+	}{
+		{name: Rel, event: reads[0][0]},
+		{name: Sync, event: reads[0][1]},
+		{name: Key, event: reads[1][1]},
+		{name: Sync, event: reads[1][2]},
+		{name: Abs, event: reads[2][0]},
+		{name: Switch, event: reads[2][1]},
+	}
+	for _, w := range want {
+		select {
+		case evt := <-events:
+			assert.Equal(t, w.name, evt.Name)
+			assert.Equal(t, w.event, evt.Data)
+		case <-time.After(time.Second):
+			require.Fail(t, "event not published", w.name)
+		}
+	}
+	require.NoError(t, d.Halt())
+	require.NoError(t, d.connection.Finalize())
+}
+
+func TestDriverPublishError(t *testing.T) {
+	// arrange
+	device := newEvdevTestDevice()
+	device.err = errors.New("read error")
+	d := initTestDriver(device)
+	events := d.Subscribe()
+	// act
+	require.NoError(t, d.Start())
+	// assert
+	select {
+	case evt := <-events:
+		assert.Equal(t, Error, evt.Name)
+		assert.Equal(t, device.err, evt.Data)
+	case <-time.After(time.Second):
+		require.Fail(t, "error not published")
+	}
+}
+
+func TestDriverHalt(t *testing.T) {
+	// arrange
+	device := newEvdevTestDevice()
+	d := initTestDriver(device)
+	events := d.Subscribe()
+	require.NoError(t, d.Start())
+	// act
+	require.NoError(t, d.Halt())
+	require.NoError(t, d.connection.Finalize())
+	// assert: no error is published after halt
+	select {
+	case evt := <-events:
+		require.Fail(t, "unexpected event", evt.Name)
+	case <-time.After(50 * time.Millisecond):
+	}
+	require.NoError(t, d.Halt())
+}
+
+func TestDriverRestart(t *testing.T) {
+	// arrange
+	device := newEvdevTestDevice()
+	d := initTestDriver(device)
+	events := d.Subscribe()
+	require.NoError(t, d.Start())
+	require.NoError(t, d.Start())
+	require.NoError(t, d.Halt())
+	// act
+	require.NoError(t, d.Start())
+	// assert: the reader is reused, so the next events are not dropped
+	want := gobot.InputEvent{Type: EV_KEY, Code: KEY_A, Value: 1}
+	device.reads <- []gobot.InputEvent{want}
+	select {
+	case evt := <-events:
+		assert.Equal(t, Key, evt.Name)
+		assert.Equal(t, want, evt.Data)
+	case <-time.After(time.Second):
+		require.Fail(t, "event not published")
+	}
+	require.NoError(t, d.Halt())
+	require.NoError(t, d.connection.Finalize())
+}
+
+func TestCodeName(t *testing.T) {
+	assert.Equal(t, "EV_KEY", TypeName(EV_KEY))
+	assert.Equal(t, "0x1f", TypeName(0x1f))
+	assert.Equal(t, "KEY_A", CodeName(EV_KEY, KEY_A))
+	assert.Equal(t, "BTN_TOUCH", CodeName(EV_KEY, BTN_TOUCH))
+	assert.Equal(t, "REL_WHEEL", CodeName(EV_REL, REL_WHEEL))
+	assert.Equal(t, "ABS_MT_POSITION_Y", CodeName(EV_ABS, ABS_MT_POSITION_Y))
+	assert.Equal(t, "0x2ff", CodeName(EV_KEY, 0x2ff))
+}
@@ -7,15 +7,18 @@ import (
 	"fmt"
 	"io"
 	"strconv"
+	"sync"
 	"time"
 
-	"go.bug.st/serial"
-
 	"gobot.io/x/gobot/v2"
 	"gobot.io/x/gobot/v2/drivers/i2c"
 	"gobot.io/x/gobot/v2/platforms/firmata/client"
+	"gobot.io/x/gobot/v2/platforms/serial"
+	"gobot.io/x/gobot/v2/system/serialport"
 )
 
+const defaultBaudRate = 57600
+
 type firmataBoard interface {
 	Connect(conn io.ReadWriteCloser) error
 	Disconnect() error
@@ -49,13 +52,18 @@ type Adaptor struct {
 	Board      firmataBoard
 	conn       io.ReadWriteCloser
 	PortOpener func(port string) (io.ReadWriteCloser, error)
+	sys        *serialport.Accesser
+	serialPort *serial.Adaptor
 	gobot.Eventer
+	edgeHandlers map[int]func(val int) // nil value for subscribed pins without notification
+	edgeMutex    sync.Mutex
 }
 
 // NewAdaptor returns a new Firmata Adaptor which optionally accepts:
 //
 //	string: port the Adaptor uses to connect to a serial port with a baude rate of 57600
 //	io.ReadWriteCloser: connection the Adaptor uses to communication with the hardware
+//	serial.OptionApplier: e.g. serial.WithBaudRate(), to change the settings of the serial port
 //
 // If an io.ReadWriteCloser is not supplied, the Adaptor will open a connection
 // to a serial port with a baude rate of 57600. If an io.ReadWriteCloser
@@ -63,25 +71,28 @@ type Adaptor struct {
 // string port as a label to be displayed in the log and api.
 func NewAdaptor(args ...interface{}) *Adaptor {
 	f := &Adaptor{
-		name:  gobot.DefaultName("Firmata"),
-		port:  "",
-		conn:  nil,
-		Board: client.New(),
-		PortOpener: func(port string) (io.ReadWriteCloser, error) {
-			return serial.Open(port, &serial.Mode{BaudRate: 57600})
-		},
+		name:    gobot.DefaultName("Firmata"),
+		port:    "",
+		conn:    nil,
+		Board:   client.New(),
+		sys:     serialport.NewAccesser(),
 		Eventer: gobot.NewEventer(),
 	}
+	f.PortOpener = f.openSerialPort
 
+	serialPortOpts := []serial.OptionApplier{serial.WithBaudRate(defaultBaudRate)}
 	for _, arg := range args {
 		switch a := arg.(type) {
 		case string:
 			f.port = a
 		case io.ReadWriteCloser:
 			f.conn = a
+		case serial.OptionApplier:
+			serialPortOpts = append(serialPortOpts, a)
 		}
 	}
 
+	f.serialPort = serial.NewAdaptor(f.sys, f.port, serialPortOpts...)
 	return f
 }
 
@@ -103,6 +114,15 @@ func (f *Adaptor) Connect() error {
 	})
 }
 
+// openSerialPort is the default port opener, closing the returned connection finalizes the serial port
+func (f *Adaptor) openSerialPort(port string) (io.ReadWriteCloser, error) {
+	f.serialPort.SetPort(port)
+	if err := f.serialPort.Connect(); err != nil {
+		return nil, err
+	}
+	return f.serialPort.SerialReadWriter(), nil
+}
+
 // Disconnect closes the io connection to the Board
 func (f *Adaptor) Disconnect() error {
 	if f.Board != nil {
@@ -206,6 +226,50 @@ func (f *Adaptor) DigitalRead(pin string) (int, error) {
 	return f.Board.Pins()[p].Value, nil
 }
 
+// NotifyDigitalEdges calls the given handler with the new value, when the board reports a change of the digital input
+// pin. Because the board reports all input pins of the port, the handler can be called with an unchanged value. A nil
+// handler stops the notification.
+func (f *Adaptor) NotifyDigitalEdges(pin string, handler func(val int)) error {
+	p, err := strconv.Atoi(pin)
+	if err != nil {
+		return err
+	}
+
+	f.edgeMutex.Lock()
+	defer f.edgeMutex.Unlock()
+
+	if handler == nil {
+		if _, ok := f.edgeHandlers[p]; ok {
+			f.edgeHandlers[p] = nil
+		}
+		return nil
+	}
+
+	if f.Board.Pins()[p].Mode != client.Input {
+		if err := f.Board.SetPinMode(p, client.Input); err != nil {
+			return err
+		}
+		if err := f.Board.ReportDigital(p, 1); err != nil {
+			return err
+		}
+	}
+
+	if f.edgeHandlers == nil {
+		f.edgeHandlers = make(map[int]func(val int))
+	}
+	if _, ok := f.edgeHandlers[p]; !ok {
+		// the eventer can not unsubscribe a single handler, so subscribe only once for each pin
+		if err := f.Board.On(fmt.Sprintf("DigitalRead%d", p), func(data interface{}) {
+			f.notifyDigitalEdge(p, data)
+		}); err != nil {
+			return err
+		}
+	}
+	f.edgeHandlers[p] = handler
+
+	return nil
+}
+
 // AnalogRead retrieves value from analog pin.
 // Returns -1 if the response from the board has timed out
 func (f *Adaptor) AnalogRead(pin string) (int, error) {
@@ -234,6 +298,21 @@ func (f *Adaptor) WriteSysex(data []byte) error {
 	return f.Board.WriteSysex(data)
 }
 
+func (f *Adaptor) notifyDigitalEdge(pin int, data interface{}) {
+	val, ok := data.(int)
+	if !ok {
+		return
+	}
+
+	f.edgeMutex.Lock()
+	handler := f.edgeHandlers[pin]
+	f.edgeMutex.Unlock()
+
+	if handler != nil {
+		handler(val)
+	}
+}
+
 // digitalPin converts pin number to digital mapping
 func (f *Adaptor) digitalPin(pin int) int {
 	return pin + 14
@@ -251,6 +330,18 @@ func (f *Adaptor) GetI2cConnection(address int, bus int) (i2c.Connection, error)
 	return NewFirmataI2cConnection(f, address), nil
 }
 
+// ProbeI2cAddress returns true, if a device acknowledges the given address. Only supports bus number 0. This
+// implements the interface i2c.Prober, which is used to scan the bus.
+func (f *Adaptor) ProbeI2cAddress(address int, bus int) (bool, error) {
+	if bus != 0 {
+		return false, fmt.Errorf("Invalid bus number %d, only 0 is supported", bus)
+	}
+	if err := f.Board.I2cConfig(0); err != nil {
+		return false, err
+	}
+	return NewFirmataI2cConnection(f, address).probe()
+}
+
 // DefaultI2cBus returns the default i2c bus for this platform
 func (f *Adaptor) DefaultI2cBus() int {
 	return 0
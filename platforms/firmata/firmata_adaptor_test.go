@@ -9,6 +9,7 @@ import (
 	"errors"
 	"io"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -17,17 +18,19 @@ import (
 	"gobot.io/x/gobot/v2/drivers/aio"
 	"gobot.io/x/gobot/v2/drivers/gpio"
 	"gobot.io/x/gobot/v2/platforms/firmata/client"
+	"gobot.io/x/gobot/v2/platforms/serial"
 )
 
 // make sure that this Adaptor fulfills all required analog and digital interfaces
 var (
-	_ gobot.Adaptor      = (*Adaptor)(nil)
-	_ gpio.DigitalReader = (*Adaptor)(nil)
-	_ gpio.DigitalWriter = (*Adaptor)(nil)
-	_ aio.AnalogReader   = (*Adaptor)(nil)
-	_ gpio.PwmWriter     = (*Adaptor)(nil)
-	_ gpio.ServoWriter   = (*Adaptor)(nil)
-	_ FirmataAdaptor     = (*Adaptor)(nil)
+	_ gobot.Adaptor            = (*Adaptor)(nil)
+	_ gpio.DigitalReader       = (*Adaptor)(nil)
+	_ gpio.DigitalWriter       = (*Adaptor)(nil)
+	_ gpio.DigitalEdgeNotifier = (*Adaptor)(nil)
+	_ aio.AnalogReader         = (*Adaptor)(nil)
+	_ gpio.PwmWriter           = (*Adaptor)(nil)
+	_ gpio.ServoWriter         = (*Adaptor)(nil)
+	_ FirmataAdaptor           = (*Adaptor)(nil)
 )
 
 type readWriteCloser struct{}
@@ -147,6 +150,34 @@ func TestAdaptorConnect(t *testing.T) {
 	require.NoError(t, a.Disconnect())
 }
 
+func TestAdaptorConnect_serialPort(t *testing.T) {
+	// arrange
+	a := NewAdaptor("/dev/ttyACM0")
+	spa := a.sys.UseMock()
+	a.Board = newMockFirmataBoard()
+	// act
+	err := a.Connect()
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, "/dev/ttyACM0", spa.PortName())
+	assert.Equal(t, 57600, spa.BaudRate())
+	// arrange: baud rate by option
+	a = NewAdaptor("/dev/ttyACM0", serial.WithBaudRate(115200))
+	spa = a.sys.UseMock()
+	a.Board = newMockFirmataBoard()
+	// act
+	err = a.Connect()
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, 115200, spa.BaudRate())
+	// arrange: open error
+	a = NewAdaptor("/dev/ttyACM0")
+	a.sys.UseMock().CreateError = true
+	a.Board = newMockFirmataBoard()
+	// act & assert
+	require.ErrorContains(t, a.Connect(), "error while open serial port in mock")
+}
+
 func TestAdaptorServoWrite(t *testing.T) {
 	a := initTestAdaptor()
 	require.NoError(t, a.ServoWrite("1", 50))
@@ -194,6 +225,37 @@ func TestAdaptorDigitalReadBadPin(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestAdaptorNotifyDigitalEdges(t *testing.T) {
+	// arrange
+	a := initTestAdaptor()
+	got := make(chan int, 1)
+	// act
+	err := a.NotifyDigitalEdges("5", func(val int) { got <- val })
+	a.Board.Publish("DigitalRead5", 1)
+	// assert
+	require.NoError(t, err)
+	select {
+	case val := <-got:
+		assert.Equal(t, 1, val)
+	case <-time.After(100 * time.Millisecond):
+		assert.Fail(t, "handler was not called")
+	}
+	// act: stop notification
+	require.NoError(t, a.NotifyDigitalEdges("5", nil))
+	a.Board.Publish("DigitalRead5", 0)
+	// assert
+	select {
+	case <-got:
+		assert.Fail(t, "handler should not be called after stop")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestAdaptorNotifyDigitalEdgesBadPin(t *testing.T) {
+	a := initTestAdaptor()
+	require.Error(t, a.NotifyDigitalEdges("xyz", func(int) {}))
+}
+
 func TestAdaptorAnalogRead(t *testing.T) {
 	a := initTestAdaptor()
 	val, err := a.AnalogRead("1")
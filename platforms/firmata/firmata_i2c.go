@@ -5,11 +5,16 @@ package firmata
 
 import (
 	"fmt"
+	"strings"
 	"sync"
+	"time"
 
 	"gobot.io/x/gobot/v2/platforms/firmata/client"
 )
 
+// firmataI2cProbeTimeout is the maximum time to wait for the reply of the board while probing an address
+const firmataI2cProbeTimeout = 500 * time.Millisecond
+
 // firmataI2cConnection implements the interface gobot.I2cOperations
 type firmataI2cConnection struct {
 	address int
@@ -173,6 +178,39 @@ func (c *firmataI2cConnection) WriteBytes(buf []byte) error {
 	return c.writeAndCheckCount(buf)
 }
 
+// probe returns true, if the i2c device acknowledges the address. StandardFirmata does not report a missing device in
+// the reply, but sends the message "I2C: Too few bytes received" before the reply.
+func (c *firmataI2cConnection) probe() (bool, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	board := c.adaptor.Board
+	events := board.Subscribe()
+	defer board.Unsubscribe(events)
+
+	if err := board.I2cRead(c.address, 1); err != nil {
+		return false, err
+	}
+
+	present := true
+	timeout := time.After(firmataI2cProbeTimeout)
+	for {
+		select {
+		case evt := <-events:
+			switch evt.Name {
+			case board.Event("StringData"):
+				if msg, ok := evt.Data.(string); ok && strings.Contains(msg, "Too few bytes") {
+					present = false
+				}
+			case board.Event("I2cReply"):
+				return present, nil
+			}
+		case <-timeout:
+			return false, fmt.Errorf("timeout while probing the i2c address 0x%02X by firmata", c.address)
+		}
+	}
+}
+
 func (c *firmataI2cConnection) readAndCheckCount(buf []byte) error {
 	countRead, err := c.readInternal(buf)
 	if err != nil {
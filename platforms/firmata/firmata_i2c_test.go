@@ -18,11 +18,15 @@ import (
 )
 
 // make sure that this Adaptor fulfills all required I2C interfaces
-var _ i2c.Connector = (*Adaptor)(nil)
+var (
+	_ i2c.Connector = (*Adaptor)(nil)
+	_ i2c.Prober    = (*Adaptor)(nil)
+)
 
 type i2cMockFirmataBoard struct {
 	gobot.Eventer
 	i2cDataForRead []byte
+	i2cReadMessage string // sent as "StringData" before the reply, if not empty
 	numBytesToRead int
 	i2cWritten     []byte
 }
@@ -31,8 +35,12 @@ type i2cMockFirmataBoard struct {
 func (t *i2cMockFirmataBoard) I2cRead(address int, numBytes int) error {
 	t.numBytesToRead = numBytes
 	i2cReply := client.I2cReply{Data: t.i2cDataForRead}
+	msg := t.i2cReadMessage
 	go func() {
 		<-time.After(10 * time.Millisecond)
+		if msg != "" {
+			t.Publish(t.Event("StringData"), msg)
+		}
 		t.Publish(t.Event("I2cReply"), i2cReply)
 	}()
 	return nil
@@ -63,6 +71,7 @@ func newI2cMockFirmataBoard() *i2cMockFirmataBoard {
 		Eventer: gobot.NewEventer(),
 	}
 	m.AddEvent("I2cReply")
+	m.AddEvent("StringData")
 	return m
 }
 
@@ -76,6 +85,51 @@ func initTestTestAdaptorWithI2cConnection() (i2c.Connection, *i2cMockFirmataBoar
 	return con, a.Board.(*i2cMockFirmataBoard)
 }
 
+func TestProbeI2cAddress(t *testing.T) {
+	tests := map[string]struct {
+		bus     int
+		message string
+		want    bool
+		wantErr string
+	}{
+		"present": {
+			want: true,
+		},
+		"absent": {
+			message: "I2C: Too few bytes received",
+			want:    false,
+		},
+		"other_message": {
+			message: "some info",
+			want:    true,
+		},
+		"error_bus": {
+			bus:     1,
+			wantErr: "Invalid bus number 1, only 0 is supported",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := NewAdaptor()
+			brd := newI2cMockFirmataBoard()
+			brd.i2cDataForRead = []byte{0}
+			brd.i2cReadMessage = tc.message
+			a.Board = brd
+			// act
+			got, err := a.ProbeI2cAddress(0x20, tc.bus)
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+			assert.Equal(t, 1, brd.numBytesToRead)
+		})
+	}
+}
+
 func TestClose(t *testing.T) {
 	i2c, _ := initTestTestAdaptorWithI2cConnection()
 	require.NoError(t, i2c.Close())
@@ -21,6 +21,7 @@ var (
 	_ gobot.PWMPinnerProvider     = (*Adaptor)(nil)
 	_ gpio.DigitalReader          = (*Adaptor)(nil)
 	_ gpio.DigitalWriter          = (*Adaptor)(nil)
+	_ gpio.SysLedOpener           = (*Adaptor)(nil)
 	_ gpio.PwmWriter              = (*Adaptor)(nil)
 	_ i2c.Connector               = (*Adaptor)(nil)
 )
@@ -103,6 +104,7 @@ func TestName(t *testing.T) {
 	a, _ := initTestAdaptorWithMockedFilesystem()
 
 	assert.True(t, strings.HasPrefix(a.Name(), "Joule"))
+	assert.Equal(t, []string{"J12_11", "J12_13"}, a.BusPins(gobot.PinFunctionI2c, 0))
 	a.SetName("NewName")
 	assert.Equal(t, "NewName", a.Name())
 }
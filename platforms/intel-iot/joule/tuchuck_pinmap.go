@@ -1,5 +1,7 @@
 package joule
 
+import "gobot.io/x/gobot/v2"
+
 var sysfsPinMap = map[string]sysfsPin{
 	// GPIO22
 	"J12_1": {
@@ -436,3 +438,12 @@ var sysfsPinMap = map[string]sysfsPin{
 		pwmPin: -1,
 	},
 }
+
+// busPinDefinitions contains the header pins of the hardware buses, which are claimed in the pin registry
+var busPinDefinitions = map[string]map[int][]string{
+	gobot.PinFunctionI2c: {
+		0: {"J12_11", "J12_13"}, // I2C0SDA, I2C0SCL
+		1: {"J13_31", "J13_33"}, // I2C1SDA, I2C1SCL
+		2: {"J13_35", "J13_37"}, // I2C2SDA, I2C2SCL
+	},
+}
@@ -34,6 +34,8 @@ type Adaptor struct {
 	*adaptors.PWMPinsAdaptor
 	*adaptors.I2cBusAdaptor
 	*adaptors.SpiBusAdaptor
+	*adaptors.SysClassAdaptor
+	*adaptors.PinRegistry
 }
 
 // NewAdaptor creates a Jetson Nano adaptor
@@ -42,15 +44,19 @@ type Adaptor struct {
 //
 //	adaptors.WithGpiodAccess():	use character device gpiod driver instead of sysfs
 //	adaptors.WithSpiGpioAccess(sclk, nss, mosi, miso):	use GPIO's instead of /dev/spidev#.#
+//	adaptors.WithI2cGpioAccess(busNum, sda, scl):	add a further i2c bus, which is driven by GPIO's
+//	adaptors.WithI2cBusTracer(tracer), adaptors.WithSpiBusTracer(tracer):	record all bus transactions
 //
 //	Optional parameters for PWM, see [adaptors.NewPWMPinsAdaptor]
 func NewAdaptor(opts ...interface{}) *Adaptor {
 	sys := system.NewAccesser()
 	a := &Adaptor{
-		name:  gobot.DefaultName("JetsonNano"),
-		sys:   sys,
-		mutex: &sync.Mutex{},
+		name:        gobot.DefaultName("JetsonNano"),
+		sys:         sys,
+		mutex:       &sync.Mutex{},
+		PinRegistry: adaptors.NewPinRegistry(),
 	}
+	a.SetBusPinDefinitions(busPinDefinitions)
 
 	var digitalPinsOpts []func(adaptors.DigitalPinsOptioner)
 	pwmPinsOpts := []adaptors.PwmPinsOptionApplier{
@@ -58,12 +64,21 @@ func NewAdaptor(opts ...interface{}) *Adaptor {
 		adaptors.WithPWMMinimumPeriod(pwmPeriodMinimum),
 		adaptors.WithPWMMinimumDutyRate(pwmDutyRateMinimum),
 	}
+	i2cBusOpts := []adaptors.I2cBusOptionApplier{
+		adaptors.WithI2cDigitalPinnerProvider(a),
+		adaptors.WithI2cPinRegistry(a.PinRegistry),
+	}
+	spiBusOpts := []adaptors.SpiBusOptionApplier{adaptors.WithSpiPinRegistry(a.PinRegistry)}
 	for _, opt := range opts {
 		switch o := opt.(type) {
 		case func(adaptors.DigitalPinsOptioner):
 			digitalPinsOpts = append(digitalPinsOpts, o)
 		case adaptors.PwmPinsOptionApplier:
 			pwmPinsOpts = append(pwmPinsOpts, o)
+		case adaptors.I2cBusOptionApplier:
+			i2cBusOpts = append(i2cBusOpts, o)
+		case adaptors.SpiBusOptionApplier:
+			spiBusOpts = append(spiBusOpts, o)
 		default:
 			panic(fmt.Sprintf("'%s' can not be applied on adaptor '%s'", opt, a.name))
 		}
@@ -71,9 +86,10 @@ func NewAdaptor(opts ...interface{}) *Adaptor {
 
 	a.DigitalPinsAdaptor = adaptors.NewDigitalPinsAdaptor(sys, a.translateDigitalPin, digitalPinsOpts...)
 	a.PWMPinsAdaptor = adaptors.NewPWMPinsAdaptor(sys, a.translatePWMPin, pwmPinsOpts...)
-	a.I2cBusAdaptor = adaptors.NewI2cBusAdaptor(sys, a.validateI2cBusNumber, defaultI2cBusNumber)
+	a.I2cBusAdaptor = adaptors.NewI2cBusAdaptor(sys, a.validateI2cBusNumber, defaultI2cBusNumber, i2cBusOpts...)
 	a.SpiBusAdaptor = adaptors.NewSpiBusAdaptor(sys, a.validateSpiBusNumber, defaultSpiBusNumber, defaultSpiChipNumber,
-		defaultSpiMode, defaultSpiBitsNumber, defaultSpiMaxSpeed)
+		defaultSpiMode, defaultSpiBitsNumber, defaultSpiMaxSpeed, spiBusOpts...)
+	a.SysClassAdaptor = adaptors.NewSysClassAdaptor(sys)
 	return a
 }
 
@@ -25,6 +25,7 @@ var (
 	_ gobot.PWMPinnerProvider     = (*Adaptor)(nil)
 	_ gpio.DigitalReader          = (*Adaptor)(nil)
 	_ gpio.DigitalWriter          = (*Adaptor)(nil)
+	_ gpio.SysLedOpener           = (*Adaptor)(nil)
 	_ i2c.Connector               = (*Adaptor)(nil)
 	_ spi.Connector               = (*Adaptor)(nil)
 )
@@ -42,6 +43,7 @@ func TestNewAdaptor(t *testing.T) {
 	a := NewAdaptor()
 
 	assert.True(t, strings.HasPrefix(a.Name(), "JetsonNano"))
+	assert.Equal(t, []string{"3", "5"}, a.BusPins(gobot.PinFunctionI2c, 1))
 
 	a.SetName("NewName")
 	assert.Equal(t, "NewName", a.Name())
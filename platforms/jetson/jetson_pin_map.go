@@ -1,5 +1,7 @@
 package jetson
 
+import "gobot.io/x/gobot/v2"
+
 var gpioPins = map[string]int{
 	"7":  216,
 	"11": 50,
@@ -30,3 +32,15 @@ var pwmPins = map[string]int{
 	"32": 0,
 	"33": 2,
 }
+
+// busPinDefinitions contains the header pins of the hardware buses, which are claimed in the pin registry
+var busPinDefinitions = map[string]map[int][]string{
+	gobot.PinFunctionI2c: {
+		0: {"27", "28"}, // I2C_1 SDA, SCL
+		1: {"3", "5"},   // I2C_2 SDA, SCL
+	},
+	gobot.PinFunctionSpi: {
+		0: {"19", "21", "23", "24", "26"}, // SPI_1 MOSI, MISO, SCK, CS0, CS1
+		1: {"37", "22", "13", "18", "16"}, // SPI_2 MOSI, MISO, SCK, CS0, CS1
+	},
+}
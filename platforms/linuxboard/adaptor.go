@@ -0,0 +1,290 @@
+package linuxboard
+
+import (
+	"fmt"
+	"sync"
+
+	multierror "github.com/hashicorp/go-multierror"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/platforms/adaptors"
+	"gobot.io/x/gobot/v2/system"
+)
+
+// Adaptor is a Gobot adaptor for Linux based single board computers, which is driven by a board description instead
+// of a hard-coded pin map.
+type Adaptor struct {
+	name  string
+	desc  *Description
+	sys   *system.Accesser
+	mutex sync.Mutex
+	*adaptors.AnalogPinsAdaptor
+	*adaptors.DigitalPinsAdaptor
+	*adaptors.PWMPinsAdaptor
+	*adaptors.I2cBusAdaptor
+	*adaptors.SpiBusAdaptor
+	*adaptors.SysClassAdaptor
+	*adaptors.PinRegistry
+}
+
+// NewAdaptor creates a board adaptor for the given description, which needs to be valid, see Description.Validate().
+//
+// Optional parameters:
+//
+//	adaptors.WithGpiodAccess():	use character device gpiod driver instead of sysfs (if not the default of the board)
+//	adaptors.WithSpiGpioAccess(sclk, nss, mosi, miso):	use GPIO's instead of /dev/spidev#.#
+//	adaptors.WithI2cGpioAccess(busNum, sda, scl):	add a further i2c bus, which is driven by GPIO's
+//	adaptors.WithI2cBusTracer(tracer), adaptors.WithSpiBusTracer(tracer):	record all bus transactions
+//	adaptors.WithGpiosActiveLow(pin's): invert the pin behavior
+//	adaptors.WithGpiosPullUp/Down(pin's): sets the internal pull resistor
+//	adaptors.WithGpiosOpenDrain/Source(pin's): sets the output behavior
+//	adaptors.WithGpioDebounce(pin, period): sets the input debouncer
+//	adaptors.WithGpioEventOnFallingEdge/RaisingEdge/BothEdges(pin, handler): activate edge detection
+//
+//	Optional parameters for PWM, see [adaptors.NewPWMPinsAdaptor], applied after the defaults of the description
+func NewAdaptor(desc *Description, opts ...interface{}) *Adaptor {
+	var sys *system.Accesser
+	if desc.DigitalAccess == digitalAccessGpiod {
+		sys = system.NewAccesser(system.WithDigitalPinGpiodAccess())
+	} else {
+		sys = system.NewAccesser()
+	}
+
+	a := &Adaptor{
+		name:        gobot.DefaultName(desc.Name),
+		desc:        desc,
+		sys:         sys,
+		PinRegistry: adaptors.NewPinRegistry(),
+	}
+	a.SetBusPinDefinitions(desc.busPinDefinitions())
+
+	var digitalPinsOpts []func(adaptors.DigitalPinsOptioner)
+	pwmPinsOpts := desc.pwmOptions()
+	i2cBusOpts := []adaptors.I2cBusOptionApplier{
+		adaptors.WithI2cDigitalPinnerProvider(a),
+		adaptors.WithI2cPinRegistry(a.PinRegistry),
+	}
+	spiBusOpts := []adaptors.SpiBusOptionApplier{adaptors.WithSpiPinRegistry(a.PinRegistry)}
+	for _, opt := range opts {
+		switch o := opt.(type) {
+		case func(adaptors.DigitalPinsOptioner):
+			digitalPinsOpts = append(digitalPinsOpts, o)
+		case adaptors.PwmPinsOptionApplier:
+			pwmPinsOpts = append(pwmPinsOpts, o)
+		case adaptors.I2cBusOptionApplier:
+			i2cBusOpts = append(i2cBusOpts, o)
+		case adaptors.SpiBusOptionApplier:
+			spiBusOpts = append(spiBusOpts, o)
+		default:
+			panic(fmt.Sprintf("'%s' can not be applied on adaptor '%s'", opt, a.name))
+		}
+	}
+
+	var i2cDefaultBus int
+	if desc.I2c != nil {
+		i2cDefaultBus = desc.I2c.DefaultBus
+	}
+
+	spiDefaults := SpiDescription{DefaultBits: defaultSpiBitsNumber, MaxSpeed: defaultSpiMaxSpeed}
+	if desc.Spi != nil {
+		spiDefaults.DefaultBus = desc.Spi.DefaultBus
+		spiDefaults.DefaultChip = desc.Spi.DefaultChip
+		spiDefaults.DefaultMode = desc.Spi.DefaultMode
+		if desc.Spi.DefaultBits > 0 {
+			spiDefaults.DefaultBits = desc.Spi.DefaultBits
+		}
+		if desc.Spi.MaxSpeed > 0 {
+			spiDefaults.MaxSpeed = desc.Spi.MaxSpeed
+		}
+	}
+
+	a.AnalogPinsAdaptor = adaptors.NewAnalogPinsAdaptor(sys, a.translateAnalogPin)
+	a.DigitalPinsAdaptor = adaptors.NewDigitalPinsAdaptor(sys, a.translateDigitalPin, digitalPinsOpts...)
+	a.PWMPinsAdaptor = adaptors.NewPWMPinsAdaptor(sys, a.translatePWMPin, pwmPinsOpts...)
+	a.I2cBusAdaptor = adaptors.NewI2cBusAdaptor(sys, a.validateI2cBusNumber, i2cDefaultBus, i2cBusOpts...)
+	a.SpiBusAdaptor = adaptors.NewSpiBusAdaptor(sys, a.validateSpiBusNumber, spiDefaults.DefaultBus,
+		spiDefaults.DefaultChip, spiDefaults.DefaultMode, spiDefaults.DefaultBits, spiDefaults.MaxSpeed, spiBusOpts...)
+	a.SysClassAdaptor = adaptors.NewSysClassAdaptor(sys)
+	return a
+}
+
+// NewAdaptorForBoard creates a board adaptor for the shipped description with the given ID, e.g. "nanopi-neo". For
+// optional parameters see NewAdaptor().
+func NewAdaptorForBoard(id string, opts ...interface{}) (*Adaptor, error) {
+	desc, err := BoardDescription(id)
+	if err != nil {
+		return nil, err
+	}
+	return NewAdaptor(desc, opts...), nil
+}
+
+// NewAdaptorFromFile creates a board adaptor for the description in the given JSON or YAML file. For optional
+// parameters see NewAdaptor().
+func NewAdaptorFromFile(path string, opts ...interface{}) (*Adaptor, error) {
+	desc, err := LoadDescription(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewAdaptor(desc, opts...), nil
+}
+
+// Name returns the name of the Adaptor
+func (a *Adaptor) Name() string { return a.name }
+
+// SetName sets the name of the Adaptor
+func (a *Adaptor) SetName(n string) { a.name = n }
+
+// Description returns the description of the board, which is used by the adaptor.
+func (a *Adaptor) Description() *Description { return a.desc }
+
+// Connect create new connection to board and pins.
+func (a *Adaptor) Connect() error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if err := a.SpiBusAdaptor.Connect(); err != nil {
+		return err
+	}
+
+	if err := a.I2cBusAdaptor.Connect(); err != nil {
+		return err
+	}
+
+	if err := a.AnalogPinsAdaptor.Connect(); err != nil {
+		return err
+	}
+
+	if err := a.PWMPinsAdaptor.Connect(); err != nil {
+		return err
+	}
+	return a.DigitalPinsAdaptor.Connect()
+}
+
+// Finalize closes connection to board, pins and bus
+func (a *Adaptor) Finalize() error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	err := a.DigitalPinsAdaptor.Finalize()
+
+	if e := a.PWMPinsAdaptor.Finalize(); e != nil {
+		err = multierror.Append(err, e)
+	}
+
+	if e := a.AnalogPinsAdaptor.Finalize(); e != nil {
+		err = multierror.Append(err, e)
+	}
+
+	if e := a.I2cBusAdaptor.Finalize(); e != nil {
+		err = multierror.Append(err, e)
+	}
+
+	if e := a.SpiBusAdaptor.Finalize(); e != nil {
+		err = multierror.Append(err, e)
+	}
+	return err
+}
+
+func (a *Adaptor) validateSpiBusNumber(busNr int) error {
+	if a.desc.Spi == nil || !containsBus(a.desc.Spi.Buses, busNr) {
+		return fmt.Errorf("SPI bus number %d not supported by board '%s'", busNr, a.desc.Name)
+	}
+	return nil
+}
+
+func (a *Adaptor) validateI2cBusNumber(busNr int) error {
+	if a.desc.I2c == nil || !containsBus(a.desc.I2c.Buses, busNr) {
+		return fmt.Errorf("I2C bus number %d not supported by board '%s'", busNr, a.desc.Name)
+	}
+	return nil
+}
+
+func (a *Adaptor) translateAnalogPin(id string) (string, bool, bool, uint16, error) {
+	pinInfo, ok := a.desc.AnalogPins[id]
+	if !ok {
+		return "", false, false, 0, fmt.Errorf("'%s' is not a valid id for a analog pin", id)
+	}
+
+	path := pinInfo.Path
+	info, err := a.sys.Stat(path)
+	if err != nil {
+		return "", false, false, 0, fmt.Errorf("Error (%v) on access '%s'", err, path)
+	}
+	if info.IsDir() {
+		return "", false, false, 0, fmt.Errorf("The item '%s' is a directory, which is not expected", path)
+	}
+
+	return path, pinInfo.Readable, pinInfo.Writable, pinInfo.BufLen, nil
+}
+
+func (a *Adaptor) translateDigitalPin(id string) (string, int, error) {
+	pindef, ok := a.desc.DigitalPins[id]
+	if !ok {
+		return "", -1, fmt.Errorf("'%s' is not a valid id for a digital pin", id)
+	}
+	if a.sys.IsSysfsDigitalPinAccess() {
+		return "", pindef.Sysfs, nil
+	}
+
+	var chip string
+	if pindef.Chip != nil {
+		chip = fmt.Sprintf("gpiochip%d", *pindef.Chip)
+	}
+	line := pindef.Sysfs
+	if pindef.Line != nil {
+		line = *pindef.Line
+	}
+	return chip, line, nil
+}
+
+func (a *Adaptor) translatePWMPin(id string) (string, int, error) {
+	pinInfo, ok := a.desc.PwmPins[id]
+	if !ok {
+		return "", -1, fmt.Errorf("'%s' is not a valid id for a PWM pin", id)
+	}
+	if pinInfo.Path != "" {
+		return pinInfo.Path, pinInfo.Channel, nil
+	}
+
+	path, err := a.findPWMDir(pinInfo)
+	if err != nil {
+		return "", -1, err
+	}
+	return path, pinInfo.Channel, nil
+}
+
+func (a *Adaptor) findPWMDir(p PwmPinDescription) (string, error) {
+	items, _ := a.sys.Find(p.Dir, p.DirRegexp)
+	if len(items) == 0 {
+		return "", fmt.Errorf("No path found for PWM directory pattern, '%s' in path '%s'. See README.md for activation",
+			p.DirRegexp, p.Dir)
+	}
+
+	dir := items[0]
+	info, err := a.sys.Stat(dir)
+	if err != nil {
+		return "", fmt.Errorf("Error (%v) on access '%s'", err, dir)
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("The item '%s' is not a directory, which is not expected", dir)
+	}
+
+	return dir, nil
+}
+
+func (d *Description) pwmOptions() []adaptors.PwmPinsOptionApplier {
+	var opts []adaptors.PwmPinsOptionApplier
+	if d.Pwm == nil {
+		return opts
+	}
+	if d.Pwm.DefaultPeriod > 0 {
+		opts = append(opts, adaptors.WithPWMDefaultPeriod(d.Pwm.DefaultPeriod))
+	}
+	if d.Pwm.MinimumPeriod > 0 {
+		opts = append(opts, adaptors.WithPWMMinimumPeriod(d.Pwm.MinimumPeriod))
+	}
+	if d.Pwm.MinimumDutyRate > 0 {
+		opts = append(opts, adaptors.WithPWMMinimumDutyRate(d.Pwm.MinimumDutyRate))
+	}
+	return opts
+}
@@ -0,0 +1,290 @@
+package linuxboard
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/drivers/aio"
+	"gobot.io/x/gobot/v2/drivers/gpio"
+	"gobot.io/x/gobot/v2/drivers/i2c"
+	"gobot.io/x/gobot/v2/drivers/spi"
+	"gobot.io/x/gobot/v2/drivers/watchdog"
+	"gobot.io/x/gobot/v2/platforms/adaptors"
+)
+
+const (
+	pwmDir           = "/sys/devices/platform/soc/1c21400.pwm/pwm/pwmchip0/" //nolint:gosec // false positive
+	pwmExportPath    = pwmDir + "export"
+	pwmUnexportPath  = pwmDir + "unexport"
+	pwmPwmDir        = pwmDir + "pwm0/"
+	pwmEnablePath    = pwmPwmDir + "enable"
+	pwmPeriodPath    = pwmPwmDir + "period"
+	pwmDutyCyclePath = pwmPwmDir + "duty_cycle"
+	pwmPolarityPath  = pwmPwmDir + "polarity"
+)
+
+// make sure that this Adaptor fulfills all the required interfaces
+var (
+	_ gobot.Adaptor               = (*Adaptor)(nil)
+	_ gobot.DigitalPinnerProvider = (*Adaptor)(nil)
+	_ gobot.PWMPinnerProvider     = (*Adaptor)(nil)
+	_ gpio.DigitalReader          = (*Adaptor)(nil)
+	_ gpio.DigitalWriter          = (*Adaptor)(nil)
+	_ gpio.SysLedOpener           = (*Adaptor)(nil)
+	_ gpio.PwmWriter              = (*Adaptor)(nil)
+	_ gpio.ServoWriter            = (*Adaptor)(nil)
+	_ aio.AnalogReader            = (*Adaptor)(nil)
+	_ aio.HwmonOpener             = (*Adaptor)(nil)
+	_ i2c.Connector               = (*Adaptor)(nil)
+	_ spi.Connector               = (*Adaptor)(nil)
+	_ watchdog.Opener             = (*Adaptor)(nil)
+)
+
+func initTestAdaptor(t *testing.T, opts ...interface{}) *Adaptor {
+	desc, err := ParseDescription([]byte(testDescriptionYAML))
+	require.NoError(t, err)
+	return NewAdaptor(desc, opts...)
+}
+
+func TestNewAdaptor(t *testing.T) {
+	// act
+	a := initTestAdaptor(t)
+	// assert
+	assert.True(t, strings.HasPrefix(a.Name(), "Test Board"))
+	assert.Equal(t, "Test Board", a.Description().Name)
+	assert.Equal(t, 1, a.DefaultI2cBus())
+	assert.Equal(t, 0, a.SpiDefaultBusNumber())
+	assert.Equal(t, 0, a.SpiDefaultChipNumber())
+	assert.Equal(t, 0, a.SpiDefaultMode())
+	assert.Equal(t, 8, a.SpiDefaultBitCount())
+	assert.Equal(t, int64(1000000), a.SpiDefaultMaxSpeed())
+	assert.Equal(t, []string{"3", "5"}, a.BusPins(gobot.PinFunctionI2c, 1))
+	assert.Nil(t, a.BusPins(gobot.PinFunctionI2c, 0))
+	assert.Equal(t, []string{"19", "21", "23", "24"}, a.BusPins(gobot.PinFunctionSpi, 0))
+	// act
+	a.SetName("NewName")
+	// assert
+	assert.Equal(t, "NewName", a.Name())
+}
+
+func TestNewAdaptor_sysfsAndWithoutBuses(t *testing.T) {
+	// act
+	a := NewAdaptor(&Description{Name: "Minimal"})
+	// assert
+	assert.True(t, a.sys.IsSysfsDigitalPinAccess())
+	assert.Equal(t, 0, a.DefaultI2cBus())
+	assert.Equal(t, int64(500000), a.SpiDefaultMaxSpeed())
+	require.EqualError(t, a.validateI2cBusNumber(0), "I2C bus number 0 not supported by board 'Minimal'")
+	require.EqualError(t, a.validateSpiBusNumber(0), "SPI bus number 0 not supported by board 'Minimal'")
+}
+
+func TestNewAdaptor_invalidOption(t *testing.T) {
+	// act & assert
+	assert.Panics(t, func() {
+		_ = NewAdaptor(&Description{Name: "Test Board"}, "7")
+	})
+}
+
+func TestNewAdaptorForBoard(t *testing.T) {
+	// act
+	a, err := NewAdaptorForBoard("tinker-board")
+	// assert
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(a.Name(), "Tinker Board"))
+	// act & assert
+	_, err = NewAdaptorForBoard("unknown")
+	require.ErrorContains(t, err, "board 'unknown' is unknown")
+}
+
+func TestNewAdaptorFromFile(t *testing.T) {
+	// arrange
+	path := filepath.Join(t.TempDir(), "board.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(testDescriptionYAML), 0o600))
+	// act
+	a, err := NewAdaptorFromFile(path, adaptors.WithGpiosActiveLow("7"))
+	// assert
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(a.Name(), "Test Board"))
+	// act & assert
+	_, err = NewAdaptorFromFile(filepath.Join(t.TempDir(), "missing.yaml"))
+	require.ErrorContains(t, err, "no such file")
+}
+
+func TestDigitalIO(t *testing.T) {
+	// only basic tests needed, further tests are done in "digitalpinsadaptor.go"
+	// arrange
+	a := initTestAdaptor(t)
+	a.sys.UseDigitalPinAccessWithMockFs("sysfs", []string{})
+	fs := a.sys.UseMockFilesystem([]string{
+		"/sys/class/gpio/export",
+		"/sys/class/gpio/unexport",
+		"/sys/class/gpio/gpio203/value",
+		"/sys/class/gpio/gpio203/direction",
+		"/sys/class/gpio/gpio17/value",
+		"/sys/class/gpio/gpio17/direction",
+	})
+	require.NoError(t, a.Connect())
+	// act & assert
+	require.NoError(t, a.DigitalWrite("7", 1))
+	assert.Equal(t, "1", fs.Files["/sys/class/gpio/gpio203/value"].Contents)
+	fs.Files["/sys/class/gpio/gpio17/value"].Contents = "1"
+	val, err := a.DigitalRead("11")
+	require.NoError(t, err)
+	assert.Equal(t, 1, val)
+	require.ErrorContains(t, a.DigitalWrite("99", 1), "'99' is not a valid id for a digital pin")
+	require.NoError(t, a.Finalize())
+}
+
+func TestAnalogRead(t *testing.T) {
+	// arrange
+	const path = "/sys/class/thermal/thermal_zone0/temp"
+	a := initTestAdaptor(t)
+	fs := a.sys.UseMockFilesystem([]string{path})
+	require.NoError(t, a.Connect())
+	fs.Files[path].Contents = "567\n"
+	// act
+	got, err := a.AnalogRead("thermal_zone0")
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, 567, got)
+	_, err = a.AnalogRead("thermal_zone10")
+	require.ErrorContains(t, err, "'thermal_zone10' is not a valid id for a analog pin")
+	require.NoError(t, a.Finalize())
+}
+
+func TestPwmWrite(t *testing.T) {
+	// arrange
+	a := initTestAdaptor(t)
+	fs := a.sys.UseMockFilesystem([]string{
+		pwmExportPath, pwmUnexportPath, pwmEnablePath, pwmPeriodPath, pwmDutyCyclePath, pwmPolarityPath,
+	})
+	fs.Files[pwmEnablePath].Contents = "0"
+	fs.Files[pwmPeriodPath].Contents = "0"
+	fs.Files[pwmDutyCyclePath].Contents = "0"
+	fs.Files[pwmPolarityPath].Contents = "inversed"
+	require.NoError(t, a.Connect())
+	// act
+	err := a.PwmWrite("PWM", 100)
+	// assert: default period of the description is used
+	require.NoError(t, err)
+	assert.Equal(t, "0", fs.Files[pwmExportPath].Contents)
+	assert.Equal(t, "1", fs.Files[pwmEnablePath].Contents)
+	assert.Equal(t, "3000000", fs.Files[pwmPeriodPath].Contents)
+	assert.Equal(t, "1176470", fs.Files[pwmDutyCyclePath].Contents)
+	require.ErrorContains(t, a.PwmWrite("7", 42), "'7' is not a valid id for a PWM pin")
+	require.NoError(t, a.Finalize())
+}
+
+func Test_translatePWMPin(t *testing.T) {
+	tests := map[string]struct {
+		pin         string
+		mockPaths   []string
+		wantPath    string
+		wantChannel int
+		wantErr     string
+	}{
+		"fixed_path": {
+			pin:         "12",
+			wantPath:    "/sys/class/pwm/pwmchip0",
+			wantChannel: 1,
+		},
+		"found_dir": {
+			pin:       "PWM",
+			mockPaths: []string{pwmExportPath},
+			wantPath:  "/sys/devices/platform/soc/1c21400.pwm/pwm/pwmchip0",
+		},
+		"error_no_dir": {
+			pin:         "PWM",
+			wantChannel: -1,
+			wantErr:     "No path found for PWM directory pattern, 'pwmchip[0]$'",
+		},
+		"error_unknown_pin": {
+			pin:         "7",
+			wantChannel: -1,
+			wantErr:     "'7' is not a valid id for a PWM pin",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := initTestAdaptor(t)
+			_ = a.sys.UseMockFilesystem(tc.mockPaths)
+			// act
+			path, channel, err := a.translatePWMPin(tc.pin)
+			// assert
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.wantPath, path)
+			assert.Equal(t, tc.wantChannel, channel)
+		})
+	}
+}
+
+func Test_translateDigitalPin(t *testing.T) {
+	tests := map[string]struct {
+		access   string
+		pin      string
+		wantChip string
+		wantLine int
+		wantErr  error
+	}{
+		"cdev_chip_and_line": {
+			access:   "cdev",
+			pin:      "7",
+			wantChip: "gpiochip1",
+			wantLine: 11,
+		},
+		"cdev_sysfs_number": {
+			access:   "cdev",
+			pin:      "11",
+			wantChip: "",
+			wantLine: 17,
+		},
+		"sysfs_ok": {
+			access:   "sysfs",
+			pin:      "7",
+			wantChip: "",
+			wantLine: 203,
+		},
+		"unknown_pin": {
+			pin:      "99",
+			wantChip: "",
+			wantLine: -1,
+			wantErr:  fmt.Errorf("'99' is not a valid id for a digital pin"),
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := initTestAdaptor(t)
+			a.sys.UseDigitalPinAccessWithMockFs(tc.access, []string{})
+			// act
+			chip, line, err := a.translateDigitalPin(tc.pin)
+			// assert
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantChip, chip)
+			assert.Equal(t, tc.wantLine, line)
+		})
+	}
+}
+
+func Test_validateBusNumbers(t *testing.T) {
+	// arrange
+	a := initTestAdaptor(t)
+	// act & assert
+	require.NoError(t, a.validateI2cBusNumber(0))
+	require.NoError(t, a.validateI2cBusNumber(1))
+	require.EqualError(t, a.validateI2cBusNumber(2), "I2C bus number 2 not supported by board 'Test Board'")
+	require.NoError(t, a.validateSpiBusNumber(0))
+	require.EqualError(t, a.validateSpiBusNumber(1), "SPI bus number 1 not supported by board 'Test Board'")
+}
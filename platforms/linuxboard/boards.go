@@ -0,0 +1,45 @@
+package linuxboard
+
+import (
+	"embed"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+// boardFiles contains the descriptions of all boards shipped with gobot
+//
+//go:embed boards/*.json
+var boardFiles embed.FS
+
+const boardsDir = "boards"
+
+// Boards returns the sorted IDs of all shipped board descriptions, e.g. "nanopi-neo".
+func Boards() []string {
+	entries, err := boardFiles.ReadDir(boardsDir)
+	if err != nil {
+		return nil
+	}
+
+	var ids []string
+	for _, entry := range entries {
+		ids = append(ids, strings.TrimSuffix(entry.Name(), ".json"))
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// BoardDescription returns the shipped description of the board with the given ID, e.g. "tinker-board".
+func BoardDescription(id string) (*Description, error) {
+	data, err := boardFiles.ReadFile(path.Join(boardsDir, id+".json"))
+	if err != nil {
+		return nil, fmt.Errorf("board '%s' is unknown, known boards: %s", id, strings.Join(Boards(), ", "))
+	}
+
+	desc, err := ParseDescription(data)
+	if err != nil {
+		return nil, fmt.Errorf("board '%s': %v", id, err)
+	}
+	return desc, nil
+}
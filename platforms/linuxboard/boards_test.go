@@ -0,0 +1,66 @@
+package linuxboard
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoards(t *testing.T) {
+	// act
+	got := Boards()
+	// assert
+	assert.Equal(t, []string{
+		"dragonboard-410c", "jetson-nano", "nanopi-neo", "raspberry-pi-rev1", "raspberry-pi-rev2", "raspberry-pi-rev3",
+		"rock-pi-4", "rock-pi-4c-plus", "tinker-board", "up2",
+	}, got)
+}
+
+func TestBoardDescription(t *testing.T) {
+	tests := map[string]struct {
+		wantName     string
+		wantPins     int
+		wantPwmPins  int
+		wantI2cBus   int
+		wantSpiSpeed int64
+		wantNoSpi    bool
+	}{
+		"dragonboard-410c":  {wantName: "DragonBoard 410c", wantPins: 136, wantI2cBus: 0, wantNoSpi: true},
+		"raspberry-pi-rev1": {wantName: "Raspberry Pi Revision 1", wantPins: 17, wantPwmPins: 1, wantI2cBus: 0},
+		"raspberry-pi-rev2": {wantName: "Raspberry Pi Revision 2", wantPins: 17, wantPwmPins: 1, wantI2cBus: 1},
+		"raspberry-pi-rev3": {wantName: "Raspberry Pi Revision 3", wantPins: 26, wantPwmPins: 2, wantI2cBus: 1},
+		"jetson-nano":       {wantName: "Jetson Nano", wantPins: 22, wantPwmPins: 2, wantI2cBus: 1, wantSpiSpeed: 10000000},
+		"nanopi-neo":        {wantName: "NanoPi NEO", wantPins: 14, wantPwmPins: 1, wantI2cBus: 0},
+		"rock-pi-4":         {wantName: "Rock Pi 4", wantPins: 27, wantI2cBus: 7},
+		"rock-pi-4c-plus":   {wantName: "Rock Pi 4C+", wantPins: 27, wantI2cBus: 7},
+		"tinker-board":      {wantName: "Tinker Board", wantPins: 28, wantPwmPins: 2, wantI2cBus: 1},
+		"up2":               {wantName: "UP2", wantPins: 11, wantPwmPins: 3, wantI2cBus: 5},
+	}
+	for id, tc := range tests {
+		t.Run(id, func(t *testing.T) {
+			// act
+			got, err := BoardDescription(id)
+			// assert
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantName, got.Name)
+			assert.Len(t, got.DigitalPins, tc.wantPins)
+			assert.Len(t, got.PwmPins, tc.wantPwmPins)
+			assert.Equal(t, tc.wantI2cBus, got.I2c.DefaultBus)
+			assert.NotEmpty(t, got.I2c.Pins[tc.wantI2cBus])
+			if tc.wantNoSpi {
+				assert.Nil(t, got.Spi)
+				return
+			}
+			assert.Equal(t, tc.wantSpiSpeed, got.Spi.MaxSpeed)
+		})
+	}
+}
+
+func TestBoardDescription_error(t *testing.T) {
+	// act
+	_, err := BoardDescription("raspi-6")
+	// assert
+	require.EqualError(t, err, "board 'raspi-6' is unknown, known boards: dragonboard-410c, jetson-nano, nanopi-neo, "+
+		"raspberry-pi-rev1, raspberry-pi-rev2, raspberry-pi-rev3, rock-pi-4, rock-pi-4c-plus, tinker-board, up2")
+}
@@ -0,0 +1,257 @@
+package linuxboard
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+
+	"gobot.io/x/gobot/v2"
+)
+
+const (
+	digitalAccessSysfs = "sysfs"
+	digitalAccessGpiod = "gpiod"
+
+	defaultSpiBitsNumber = 8
+	defaultSpiMaxSpeed   = 500000
+)
+
+// Description is the declarative description of a board, which is used to create the adaptor. It can be parsed from
+// a JSON or YAML file, see README.md for the format.
+type Description struct {
+	// Name is used as the name of the adaptor, e.g. "NanoPi NEO".
+	Name string `json:"name" yaml:"name"`
+	// DigitalAccess is the default system driver for digital pins, "sysfs" or "gpiod", "sysfs" if empty.
+	DigitalAccess string `json:"digitalAccess,omitempty" yaml:"digitalAccess,omitempty"`
+	// DigitalPins maps the header pin to the GPIO, e.g. "7" for the physical pin 7 of the 40 pin header.
+	DigitalPins map[string]DigitalPinDescription `json:"digitalPins,omitempty" yaml:"digitalPins,omitempty"`
+	// PwmPins maps the header pin to the PWM chip and channel.
+	PwmPins map[string]PwmPinDescription `json:"pwmPins,omitempty" yaml:"pwmPins,omitempty"`
+	// Pwm contains the default options for all PWM pins.
+	Pwm *PwmDescription `json:"pwm,omitempty" yaml:"pwm,omitempty"`
+	// AnalogPins maps the name of the analog pin to the sysfs file, e.g. "thermal_zone0".
+	AnalogPins map[string]AnalogPinDescription `json:"analogPins,omitempty" yaml:"analogPins,omitempty"`
+	// I2c contains the available I2C buses, no I2C bus can be used if nil.
+	I2c *I2cDescription `json:"i2c,omitempty" yaml:"i2c,omitempty"`
+	// Spi contains the available SPI buses and defaults, no SPI bus can be used if nil.
+	Spi *SpiDescription `json:"spi,omitempty" yaml:"spi,omitempty"`
+}
+
+// DigitalPinDescription describes the GPIO of a header pin. For the gpiod driver the chip and line is used. If chip
+// is not given, "gpiochip0" is used. If line is not given, the sysfs number is used as line of the chip.
+type DigitalPinDescription struct {
+	Sysfs int  `json:"sysfs" yaml:"sysfs"`
+	Chip  *int `json:"chip,omitempty" yaml:"chip,omitempty"`
+	Line  *int `json:"line,omitempty" yaml:"line,omitempty"`
+}
+
+// PwmPinDescription describes the PWM chip and channel of a header pin. The chip is given by the fixed path, e.g.
+// "/sys/class/pwm/pwmchip0", or if the number of the chip varies, by the directory and a regular expression for the
+// chip, e.g. "/sys/devices/platform/ff680020.pwm/pwm/" and "pwmchip[0|1|2]$".
+type PwmPinDescription struct {
+	Path      string `json:"path,omitempty" yaml:"path,omitempty"`
+	Dir       string `json:"dir,omitempty" yaml:"dir,omitempty"`
+	DirRegexp string `json:"dirRegexp,omitempty" yaml:"dirRegexp,omitempty"`
+	Channel   int    `json:"channel" yaml:"channel"`
+}
+
+// PwmDescription contains the default options for all PWM pins, unset values are not applied. Periods are given in
+// nanoseconds.
+type PwmDescription struct {
+	DefaultPeriod   uint32  `json:"defaultPeriod,omitempty" yaml:"defaultPeriod,omitempty"`
+	MinimumPeriod   uint32  `json:"minimumPeriod,omitempty" yaml:"minimumPeriod,omitempty"`
+	MinimumDutyRate float64 `json:"minimumDutyRate,omitempty" yaml:"minimumDutyRate,omitempty"`
+}
+
+// AnalogPinDescription describes a sysfs file, which is used as analog pin. The buffer length is the count of
+// characters to read.
+type AnalogPinDescription struct {
+	Path     string `json:"path" yaml:"path"`
+	Readable bool   `json:"readable,omitempty" yaml:"readable,omitempty"`
+	Writable bool   `json:"writable,omitempty" yaml:"writable,omitempty"`
+	BufLen   uint16 `json:"bufLen,omitempty" yaml:"bufLen,omitempty"`
+}
+
+// I2cDescription contains the numbers of the available I2C buses, e.g. 1 for "/dev/i2c-1", and the default bus. The
+// header pins of a bus, e.g. "3" and "5" for bus 1, are claimed in the pin registry, when the bus is used.
+type I2cDescription struct {
+	Buses      []int            `json:"buses" yaml:"buses"`
+	DefaultBus int              `json:"defaultBus" yaml:"defaultBus"`
+	Pins       map[int][]string `json:"pins,omitempty" yaml:"pins,omitempty"`
+}
+
+// SpiDescription contains the numbers of the available SPI buses, e.g. 0 for "/dev/spidev0.x", and the defaults.
+// Bits and the maximum speed default to 8 and 500kHz, if not given. The header pins of a bus are claimed in the pin
+// registry, when the bus is used.
+type SpiDescription struct {
+	Buses       []int            `json:"buses" yaml:"buses"`
+	DefaultBus  int              `json:"defaultBus" yaml:"defaultBus"`
+	DefaultChip int              `json:"defaultChip,omitempty" yaml:"defaultChip,omitempty"`
+	DefaultMode int              `json:"defaultMode,omitempty" yaml:"defaultMode,omitempty"`
+	DefaultBits int              `json:"defaultBits,omitempty" yaml:"defaultBits,omitempty"`
+	MaxSpeed    int64            `json:"maxSpeed,omitempty" yaml:"maxSpeed,omitempty"`
+	Pins        map[int][]string `json:"pins,omitempty" yaml:"pins,omitempty"`
+}
+
+// ParseDescription parses and validates the given board description. JSON is detected by the leading "{", all other
+// content is parsed as YAML. Unknown fields are treated as error.
+func ParseDescription(data []byte) (*Description, error) {
+	var desc Description
+	trimmed := bytes.TrimSpace(data)
+	if bytes.HasPrefix(trimmed, []byte("{")) {
+		decoder := json.NewDecoder(bytes.NewReader(trimmed))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&desc); err != nil {
+			return nil, fmt.Errorf("parse JSON board description failed: %v", err)
+		}
+	} else {
+		decoder := yaml.NewDecoder(bytes.NewReader(trimmed))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(&desc); err != nil {
+			return nil, fmt.Errorf("parse YAML board description failed: %v", err)
+		}
+	}
+
+	if err := desc.Validate(); err != nil {
+		return nil, err
+	}
+	return &desc, nil
+}
+
+// LoadDescription reads, parses and validates the board description from the given JSON or YAML file.
+func LoadDescription(path string) (*Description, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	desc, err := ParseDescription(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return desc, nil
+}
+
+// Validate checks the description for consistency.
+func (d *Description) Validate() error {
+	if d.Name == "" {
+		return fmt.Errorf("name of board is missing")
+	}
+
+	if d.DigitalAccess != "" && d.DigitalAccess != digitalAccessSysfs && d.DigitalAccess != digitalAccessGpiod {
+		return fmt.Errorf("digital access '%s' of board '%s' is not supported, use '%s' or '%s'", d.DigitalAccess,
+			d.Name, digitalAccessSysfs, digitalAccessGpiod)
+	}
+
+	for _, id := range sortedKeys(d.DigitalPins) {
+		pin := d.DigitalPins[id]
+		if pin.Sysfs < 0 || (pin.Chip != nil && *pin.Chip < 0) || (pin.Line != nil && *pin.Line < 0) {
+			return fmt.Errorf("digital pin '%s' of board '%s' has negative values", id, d.Name)
+		}
+	}
+
+	for _, id := range sortedKeys(d.PwmPins) {
+		pin := d.PwmPins[id]
+		if (pin.Path == "") == (pin.Dir == "") {
+			return fmt.Errorf("PWM pin '%s' of board '%s' needs either a path or a dir", id, d.Name)
+		}
+		if pin.Dir != "" {
+			if pin.DirRegexp == "" {
+				return fmt.Errorf("PWM pin '%s' of board '%s' needs a dirRegexp for the dir", id, d.Name)
+			}
+			if _, err := regexp.Compile(pin.DirRegexp); err != nil {
+				return fmt.Errorf("PWM pin '%s' of board '%s' has an invalid dirRegexp: %v", id, d.Name, err)
+			}
+		}
+		if pin.Channel < 0 {
+			return fmt.Errorf("PWM pin '%s' of board '%s' has a negative channel", id, d.Name)
+		}
+	}
+
+	for _, id := range sortedKeys(d.AnalogPins) {
+		pin := d.AnalogPins[id]
+		if pin.Path == "" {
+			return fmt.Errorf("analog pin '%s' of board '%s' needs a path", id, d.Name)
+		}
+		if !pin.Readable && !pin.Writable {
+			return fmt.Errorf("analog pin '%s' of board '%s' is neither readable nor writable", id, d.Name)
+		}
+	}
+
+	if d.I2c != nil && !containsBus(d.I2c.Buses, d.I2c.DefaultBus) {
+		return fmt.Errorf("default I2C bus %d of board '%s' is not in the list of buses %v", d.I2c.DefaultBus, d.Name,
+			d.I2c.Buses)
+	}
+
+	if d.Spi != nil && !containsBus(d.Spi.Buses, d.Spi.DefaultBus) {
+		return fmt.Errorf("default SPI bus %d of board '%s' is not in the list of buses %v", d.Spi.DefaultBus, d.Name,
+			d.Spi.Buses)
+	}
+
+	if d.I2c != nil {
+		if err := validateBusPins("I2C", d.Name, d.I2c.Buses, d.I2c.Pins); err != nil {
+			return err
+		}
+	}
+
+	if d.Spi != nil {
+		if err := validateBusPins("SPI", d.Name, d.Spi.Buses, d.Spi.Pins); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// busPinDefinitions returns the header pins of all buses, see adaptors.PinRegistry.SetBusPinDefinitions()
+func (d *Description) busPinDefinitions() map[string]map[int][]string {
+	definitions := make(map[string]map[int][]string)
+	if d.I2c != nil && len(d.I2c.Pins) > 0 {
+		definitions[gobot.PinFunctionI2c] = d.I2c.Pins
+	}
+	if d.Spi != nil && len(d.Spi.Pins) > 0 {
+		definitions[gobot.PinFunctionSpi] = d.Spi.Pins
+	}
+	return definitions
+}
+
+func validateBusPins(kind string, board string, buses []int, pins map[int][]string) error {
+	busNums := make([]int, 0, len(pins))
+	for busNr := range pins {
+		busNums = append(busNums, busNr)
+	}
+	sort.Ints(busNums)
+
+	for _, busNr := range busNums {
+		if !containsBus(buses, busNr) {
+			return fmt.Errorf("pins of %s bus %d of board '%s' are given, but the bus is not in the list of buses %v",
+				kind, busNr, board, buses)
+		}
+		if len(pins[busNr]) == 0 {
+			return fmt.Errorf("pins of %s bus %d of board '%s' are empty", kind, busNr, board)
+		}
+	}
+	return nil
+}
+
+func containsBus(buses []int, busNr int) bool {
+	for _, b := range buses {
+		if b == busNr {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
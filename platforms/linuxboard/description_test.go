@@ -0,0 +1,228 @@
+package linuxboard
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testDescriptionYAML = `
+name: Test Board
+digitalAccess: gpiod
+digitalPins:
+  "7": {sysfs: 203, chip: 1, line: 11}
+  "11": {sysfs: 17}
+pwmPins:
+  "12": {path: /sys/class/pwm/pwmchip0, channel: 1}
+  PWM: {dir: /sys/devices/platform/soc/1c21400.pwm/pwm/, dirRegexp: "pwmchip[0]$", channel: 0}
+pwm:
+  defaultPeriod: 3000000
+analogPins:
+  thermal_zone0: {path: /sys/class/thermal/thermal_zone0/temp, readable: true, bufLen: 7}
+i2c:
+  buses: [0, 1]
+  defaultBus: 1
+  pins: {1: ["3", "5"]}
+spi:
+  buses: [0]
+  defaultBus: 0
+  maxSpeed: 1000000
+  pins: {0: ["19", "21", "23", "24"]}
+`
+
+func TestParseDescription(t *testing.T) {
+	chip := 1
+	line := 11
+	want := &Description{
+		Name:          "Test Board",
+		DigitalAccess: "gpiod",
+		DigitalPins: map[string]DigitalPinDescription{
+			"7":  {Sysfs: 203, Chip: &chip, Line: &line},
+			"11": {Sysfs: 17},
+		},
+		PwmPins: map[string]PwmPinDescription{
+			"12":  {Path: "/sys/class/pwm/pwmchip0", Channel: 1},
+			"PWM": {Dir: "/sys/devices/platform/soc/1c21400.pwm/pwm/", DirRegexp: "pwmchip[0]$"},
+		},
+		Pwm: &PwmDescription{DefaultPeriod: 3000000},
+		AnalogPins: map[string]AnalogPinDescription{
+			"thermal_zone0": {Path: "/sys/class/thermal/thermal_zone0/temp", Readable: true, BufLen: 7},
+		},
+		I2c: &I2cDescription{Buses: []int{0, 1}, DefaultBus: 1, Pins: map[int][]string{1: {"3", "5"}}},
+		Spi: &SpiDescription{Buses: []int{0}, MaxSpeed: 1000000, Pins: map[int][]string{0: {"19", "21", "23", "24"}}},
+	}
+	tests := map[string]struct {
+		data string
+	}{
+		"yaml": {data: testDescriptionYAML},
+		"json": {
+			data: `{
+  "name": "Test Board",
+  "digitalAccess": "gpiod",
+  "digitalPins": {
+    "7":  {"sysfs": 203, "chip": 1, "line": 11},
+    "11": {"sysfs": 17}
+  },
+  "pwmPins": {
+    "12":  {"path": "/sys/class/pwm/pwmchip0", "channel": 1},
+    "PWM": {"dir": "/sys/devices/platform/soc/1c21400.pwm/pwm/", "dirRegexp": "pwmchip[0]$", "channel": 0}
+  },
+  "pwm": {"defaultPeriod": 3000000},
+  "analogPins": {
+    "thermal_zone0": {"path": "/sys/class/thermal/thermal_zone0/temp", "readable": true, "bufLen": 7}
+  },
+  "i2c": {"buses": [0, 1], "defaultBus": 1, "pins": {"1": ["3", "5"]}},
+  "spi": {"buses": [0], "defaultBus": 0, "maxSpeed": 1000000, "pins": {"0": ["19", "21", "23", "24"]}}
+}`,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// act
+			got, err := ParseDescription([]byte(tc.data))
+			// assert
+			require.NoError(t, err)
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func TestParseDescription_error(t *testing.T) {
+	tests := map[string]struct {
+		data    string
+		wantErr string
+	}{
+		"json_unknown_field": {
+			data:    `{"name": "X", "digitalPin": {}}`,
+			wantErr: "parse JSON board description failed: json: unknown field \"digitalPin\"",
+		},
+		"yaml_unknown_field": {
+			data:    "name: X\ndigitalPin: {}\n",
+			wantErr: "parse YAML board description failed: yaml: unmarshal errors:\n  line 2: field digitalPin not found",
+		},
+		"invalid": {
+			data:    `{"digitalPins": {}}`,
+			wantErr: "name of board is missing",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// act
+			_, err := ParseDescription([]byte(tc.data))
+			// assert
+			require.ErrorContains(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestLoadDescription(t *testing.T) {
+	// arrange
+	dir := t.TempDir()
+	path := filepath.Join(dir, "board.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(testDescriptionYAML), 0o600))
+	invalidPath := filepath.Join(dir, "invalid.yaml")
+	require.NoError(t, os.WriteFile(invalidPath, []byte("name: \"\"\n"), 0o600))
+	// act
+	got, err := LoadDescription(path)
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, "Test Board", got.Name)
+	// act & assert
+	_, err = LoadDescription(invalidPath)
+	require.EqualError(t, err, invalidPath+": name of board is missing")
+	_, err = LoadDescription(filepath.Join(dir, "missing.yaml"))
+	require.ErrorContains(t, err, "no such file")
+}
+
+func TestDescriptionValidate(t *testing.T) {
+	negative := -1
+	tests := map[string]struct {
+		desc    Description
+		wantErr string
+	}{
+		"ok_minimal": {
+			desc: Description{Name: "X"},
+		},
+		"error_name": {
+			desc:    Description{},
+			wantErr: "name of board is missing",
+		},
+		"error_digital_access": {
+			desc:    Description{Name: "X", DigitalAccess: "cdev"},
+			wantErr: "digital access 'cdev' of board 'X' is not supported, use 'sysfs' or 'gpiod'",
+		},
+		"error_digital_pin": {
+			desc:    Description{Name: "X", DigitalPins: map[string]DigitalPinDescription{"7": {Line: &negative}}},
+			wantErr: "digital pin '7' of board 'X' has negative values",
+		},
+		"error_pwm_path_and_dir": {
+			desc: Description{Name: "X", PwmPins: map[string]PwmPinDescription{
+				"12": {Path: "/sys/class/pwm/pwmchip0", Dir: "/sys/class/pwm/"},
+			}},
+			wantErr: "PWM pin '12' of board 'X' needs either a path or a dir",
+		},
+		"error_pwm_no_path": {
+			desc:    Description{Name: "X", PwmPins: map[string]PwmPinDescription{"12": {}}},
+			wantErr: "PWM pin '12' of board 'X' needs either a path or a dir",
+		},
+		"error_pwm_no_regexp": {
+			desc:    Description{Name: "X", PwmPins: map[string]PwmPinDescription{"12": {Dir: "/sys/class/pwm/"}}},
+			wantErr: "PWM pin '12' of board 'X' needs a dirRegexp for the dir",
+		},
+		"error_pwm_invalid_regexp": {
+			desc: Description{Name: "X", PwmPins: map[string]PwmPinDescription{
+				"12": {Dir: "/sys/class/pwm/", DirRegexp: "pwmchip[0"},
+			}},
+			wantErr: "PWM pin '12' of board 'X' has an invalid dirRegexp: error parsing regexp",
+		},
+		"error_pwm_channel": {
+			desc: Description{Name: "X", PwmPins: map[string]PwmPinDescription{
+				"12": {Path: "/sys/class/pwm/pwmchip0", Channel: -1},
+			}},
+			wantErr: "PWM pin '12' of board 'X' has a negative channel",
+		},
+		"error_analog_path": {
+			desc:    Description{Name: "X", AnalogPins: map[string]AnalogPinDescription{"t0": {Readable: true}}},
+			wantErr: "analog pin 't0' of board 'X' needs a path",
+		},
+		"error_analog_access": {
+			desc:    Description{Name: "X", AnalogPins: map[string]AnalogPinDescription{"t0": {Path: "/sys/t0"}}},
+			wantErr: "analog pin 't0' of board 'X' is neither readable nor writable",
+		},
+		"error_i2c_default": {
+			desc:    Description{Name: "X", I2c: &I2cDescription{Buses: []int{0, 1}, DefaultBus: 2}},
+			wantErr: "default I2C bus 2 of board 'X' is not in the list of buses [0 1]",
+		},
+		"error_spi_default": {
+			desc:    Description{Name: "X", Spi: &SpiDescription{DefaultBus: 0}},
+			wantErr: "default SPI bus 0 of board 'X' is not in the list of buses []",
+		},
+		"error_i2c_pins_bus": {
+			desc: Description{Name: "X", I2c: &I2cDescription{
+				Buses: []int{1}, DefaultBus: 1, Pins: map[int][]string{1: {"3", "5"}, 0: {"27", "28"}},
+			}},
+			wantErr: "pins of I2C bus 0 of board 'X' are given, but the bus is not in the list of buses [1]",
+		},
+		"error_spi_pins_empty": {
+			desc: Description{Name: "X", Spi: &SpiDescription{
+				Buses: []int{0}, DefaultBus: 0, Pins: map[int][]string{0: {}},
+			}},
+			wantErr: "pins of SPI bus 0 of board 'X' are empty",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// act
+			err := tc.desc.Validate()
+			// assert
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
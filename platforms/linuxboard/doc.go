@@ -0,0 +1,8 @@
+/*
+Package linuxboard contains a generic Gobot adaptor for Linux based single board computers, which is configured by a
+board description in JSON or YAML instead of a hard-coded pin map.
+
+For further information refer to linuxboard README:
+https://github.com/hybridgroup/gobot/blob/master/platforms/linuxboard/README.md
+*/
+package linuxboard // import "gobot.io/x/gobot/v2/platforms/linuxboard"
@@ -1,14 +1,17 @@
 package mavlink
 
 import (
+	"fmt"
 	"io"
 
-	"go.bug.st/serial"
-
 	"gobot.io/x/gobot/v2"
 	common "gobot.io/x/gobot/v2/platforms/mavlink/common"
+	"gobot.io/x/gobot/v2/platforms/serial"
+	"gobot.io/x/gobot/v2/system/serialport"
 )
 
+const defaultBaudRate = 57600
+
 // Adaptor is a Mavlink transport adaptor.
 type BaseAdaptor interface {
 	gobot.Connection
@@ -19,47 +22,45 @@ type BaseAdaptor interface {
 
 // Adaptor is a Mavlink-over-serial adaptor.
 type Adaptor struct {
-	name    string
-	port    string
-	sp      io.ReadWriteCloser
-	connect func(string) (io.ReadWriteCloser, error)
+	name string
+	sys  *serialport.Accesser
+	*serial.Adaptor
 }
 
-// NewAdaptor creates a new mavlink adaptor with specified port
-func NewAdaptor(port string) *Adaptor {
-	return &Adaptor{
+// NewAdaptor creates a new mavlink adaptor with specified port. The default baud rate of 57600 can be changed by
+// option.
+//
+// Optional parameters:
+//
+//	serial.OptionApplier: e.g. serial.WithBaudRate()
+func NewAdaptor(port string, opts ...interface{}) *Adaptor {
+	sys := serialport.NewAccesser()
+	a := &Adaptor{
 		name: "Mavlink",
-		port: port,
-		connect: func(port string) (io.ReadWriteCloser, error) {
-			return serial.Open(port, &serial.Mode{BaudRate: 57600})
-		},
+		sys:  sys,
 	}
-}
 
-func (m *Adaptor) Name() string     { return m.name }
-func (m *Adaptor) SetName(n string) { m.name = n }
-func (m *Adaptor) Port() string     { return m.port }
-
-// Connect returns true if connection to device is successful
-func (m *Adaptor) Connect() error {
-	sp, err := m.connect(m.Port())
-	if err != nil {
-		return err
+	serialPortOpts := []serial.OptionApplier{serial.WithBaudRate(defaultBaudRate)}
+	for _, opt := range opts {
+		switch o := opt.(type) {
+		case serial.OptionApplier:
+			serialPortOpts = append(serialPortOpts, o)
+		default:
+			panic(fmt.Sprintf("'%s' can not be applied on adaptor '%s'", opt, a.name))
+		}
 	}
-	m.sp = sp
 
-	return nil
+	a.Adaptor = serial.NewAdaptor(sys, port, serialPortOpts...)
+	return a
 }
 
-// Finalize returns true if connection to devices is closed successfully
-func (m *Adaptor) Finalize() error {
-	return m.sp.Close()
-}
+func (m *Adaptor) Name() string     { return m.name }
+func (m *Adaptor) SetName(n string) { m.name = n }
 
 func (m *Adaptor) ReadMAVLinkPacket() (*common.MAVLinkPacket, error) {
-	return common.ReadMAVLinkPacket(m.sp)
+	return common.ReadMAVLinkPacket(m.SerialReadWriter())
 }
 
 func (m *Adaptor) Write(b []byte) (int, error) {
-	return m.sp.Write(b)
+	return m.SerialWrite(b)
 }
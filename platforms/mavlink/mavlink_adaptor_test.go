@@ -1,8 +1,6 @@
 package mavlink
 
 import (
-	"errors"
-	"io"
 	"strings"
 	"testing"
 
@@ -10,78 +8,69 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/platforms/serial"
+	"gobot.io/x/gobot/v2/system/serialport"
 )
 
 var _ gobot.Adaptor = (*Adaptor)(nil)
 
-type nullReadWriteCloser struct{}
-
 var payload = []byte{
 	0xFE, 0x09, 0x4E, 0x01, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0x03, 0x51, 0x04, 0x03, 0x1C, 0x7F,
 }
 
-var testAdaptorRead = func(p []byte) (int, error) {
-	return len(p), nil
-}
-
-func (nullReadWriteCloser) Write(p []byte) (int, error) {
-	return testAdaptorRead(p)
-}
-
-var testAdaptorWrite = func(b []byte) (int, error) {
-	if len(payload) > 0 {
-		copy(b, payload[:len(b)])
-		payload = payload[len(b):]
-		return len(b), nil
-	}
-	return 0, errors.New("out of bytes")
-}
-
-func (nullReadWriteCloser) Read(b []byte) (int, error) {
-	return testAdaptorWrite(b)
-}
-
-var testAdaptorClose = func() error {
-	return nil
-}
-
-func (nullReadWriteCloser) Close() error {
-	return testAdaptorClose()
-}
-
-func initTestMavlinkAdaptor() *Adaptor {
+func initTestMavlinkAdaptor() (*Adaptor, *serialport.MockAccess) {
 	m := NewAdaptor("/dev/null")
-	m.sp = nullReadWriteCloser{}
-	m.connect = func(port string) (io.ReadWriteCloser, error) { return nil, nil }
-	return m
+	spa := m.sys.UseMock()
+	if err := m.Connect(); err != nil {
+		panic(err)
+	}
+	spa.SetSimRead(payload)
+	return m, spa
 }
 
 func TestMavlinkAdaptor(t *testing.T) {
-	a := initTestMavlinkAdaptor()
+	a, _ := initTestMavlinkAdaptor()
 	assert.Equal(t, "/dev/null", a.Port())
 }
 
 func TestMavlinkAdaptorName(t *testing.T) {
-	a := initTestMavlinkAdaptor()
+	a, _ := initTestMavlinkAdaptor()
 	assert.True(t, strings.HasPrefix(a.Name(), "Mavlink"))
 	a.SetName("NewName")
 	assert.Equal(t, "NewName", a.Name())
 }
 
 func TestMavlinkAdaptorConnect(t *testing.T) {
-	a := initTestMavlinkAdaptor()
+	a := NewAdaptor("/dev/null", serial.WithBaudRate(115200))
+	spa := a.sys.UseMock()
 	require.NoError(t, a.Connect())
+	assert.Equal(t, 115200, spa.BaudRate())
+	require.NoError(t, a.Finalize())
 
-	a.connect = func(port string) (io.ReadWriteCloser, error) { return nil, errors.New("connect error") }
-	require.ErrorContains(t, a.Connect(), "connect error")
+	spa.CreateError = true
+	require.ErrorContains(t, a.Connect(), "error while open serial port in mock")
+}
+
+func TestMavlinkAdaptorReadMAVLinkPacket(t *testing.T) {
+	a, _ := initTestMavlinkAdaptor()
+	p, err := a.ReadMAVLinkPacket()
+	require.NoError(t, err)
+	assert.Equal(t, uint8(0x4E), p.Sequence)
+}
+
+func TestMavlinkAdaptorWrite(t *testing.T) {
+	a, spa := initTestMavlinkAdaptor()
+	n, err := a.Write([]byte{0x01, 0x02})
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, []byte{0x01, 0x02}, spa.Written())
 }
 
 func TestMavlinkAdaptorFinalize(t *testing.T) {
-	a := initTestMavlinkAdaptor()
+	a, spa := initTestMavlinkAdaptor()
 	require.NoError(t, a.Finalize())
 
-	testAdaptorClose = func() error {
-		return errors.New("close error")
-	}
-	require.ErrorContains(t, a.Finalize(), "close error")
+	require.NoError(t, a.Connect())
+	spa.SetCloseError(true)
+	require.ErrorContains(t, a.Finalize(), "error while closing serial port in mock")
 }
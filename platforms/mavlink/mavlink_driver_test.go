@@ -2,7 +2,6 @@
 package mavlink
 
 import (
-	"io"
 	"strings"
 	"testing"
 	"time"
@@ -17,16 +16,12 @@ import (
 var _ gobot.Driver = (*Driver)(nil)
 
 func initTestMavlinkDriver() *Driver {
-	m := NewAdaptor("/dev/null")
-	m.connect = func(port string) (io.ReadWriteCloser, error) { return nil, nil }
-	m.sp = nullReadWriteCloser{}
+	m, _ := initTestMavlinkAdaptor()
 	return NewDriver(m)
 }
 
 func TestMavlinkDriver(t *testing.T) {
-	m := NewAdaptor("/dev/null")
-	m.sp = nullReadWriteCloser{}
-	m.connect = func(port string) (io.ReadWriteCloser, error) { return nil, nil }
+	m, _ := initTestMavlinkAdaptor()
 
 	d := NewDriver(m)
 	assert.NotNil(t, d.Connection())
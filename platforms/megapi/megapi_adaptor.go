@@ -1,37 +1,50 @@
 package megapi
 
 import (
-	"io"
+	"fmt"
 	"time"
 
-	"go.bug.st/serial"
-
 	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/platforms/serial"
+	"gobot.io/x/gobot/v2/system/serialport"
 )
 
+const defaultBaudRate = 115200
+
 var _ gobot.Adaptor = (*Adaptor)(nil)
 
 // Adaptor is the Gobot adaptor for the MakeBlock MegaPi board
 type Adaptor struct {
-	name              string
-	port              string
-	connection        io.ReadWriteCloser
-	serialMode        *serial.Mode
+	name string
+	*serial.Adaptor
 	writeBytesChannel chan []byte
 	finalizeChannel   chan struct{}
 }
 
 // NewAdaptor returns a new Adaptor with specified serial port used to talk to the MegaPi with a baud rate of 115200
-func NewAdaptor(device string) *Adaptor {
-	c := &serial.Mode{BaudRate: 115200}
-	return &Adaptor{
+//
+// Optional parameters:
+//
+//	serial.OptionApplier: e.g. serial.WithBaudRate()
+func NewAdaptor(device string, opts ...interface{}) *Adaptor {
+	a := &Adaptor{
 		name:              "MegaPi",
-		connection:        nil,
-		port:              device,
-		serialMode:        c,
 		writeBytesChannel: make(chan []byte),
 		finalizeChannel:   make(chan struct{}),
 	}
+
+	serialPortOpts := []serial.OptionApplier{serial.WithBaudRate(defaultBaudRate)}
+	for _, opt := range opts {
+		switch o := opt.(type) {
+		case serial.OptionApplier:
+			serialPortOpts = append(serialPortOpts, o)
+		default:
+			panic(fmt.Sprintf("'%s' can not be applied on adaptor '%s'", opt, a.name))
+		}
+	}
+
+	a.Adaptor = serial.NewAdaptor(serialport.NewAccesser(), device, serialPortOpts...)
+	return a
 }
 
 // Name returns the name of this adaptor
@@ -46,15 +59,13 @@ func (megaPi *Adaptor) SetName(n string) {
 
 // Connect starts a connection to the board
 func (megaPi *Adaptor) Connect() error {
-	if megaPi.connection == nil {
-		sp, err := serial.Open(megaPi.port, megaPi.serialMode)
-		if err != nil {
+	if !megaPi.IsConnected() {
+		if err := megaPi.Adaptor.Connect(); err != nil {
 			return err
 		}
 
 		// sleeping is required to give the board a chance to reset
 		time.Sleep(2 * time.Second)
-		megaPi.connection = sp
 	}
 
 	// kick off thread to send bytes to the board
@@ -62,7 +73,7 @@ func (megaPi *Adaptor) Connect() error {
 		for {
 			select {
 			case bytes := <-megaPi.writeBytesChannel:
-				if _, err := megaPi.connection.Write(bytes); err != nil {
+				if _, err := megaPi.SerialWrite(bytes); err != nil {
 					panic(err)
 				}
 				time.Sleep(10 * time.Millisecond)
@@ -81,8 +92,5 @@ func (megaPi *Adaptor) Connect() error {
 func (megaPi *Adaptor) Finalize() error {
 	megaPi.finalizeChannel <- struct{}{}
 	<-megaPi.finalizeChannel
-	if err := megaPi.connection.Close(); err != nil {
-		return err
-	}
-	return nil
+	return megaPi.Adaptor.Finalize()
 }
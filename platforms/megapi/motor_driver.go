@@ -3,9 +3,11 @@ package megapi
 import (
 	"bytes"
 	"encoding/binary"
+	"math"
 	"sync"
 
 	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/drivers/drive"
 )
 
 var _ gobot.Driver = (*MotorDriver)(nil)
@@ -71,6 +73,13 @@ func (d *MotorDriver) Speed(speed int16) error {
 	return d.speedHelper(speed)
 }
 
+// DriveMotor adapts the motor driver to the motor interface of the differential drive (package drivers/drive).
+func DriveMotor(d *MotorDriver) drive.Motor {
+	return drive.MotorFunc(func(speed float64) error {
+		return d.Speed(int16(math.Round(math.Max(-1, math.Min(1, speed)) * 255)))
+	})
+}
+
 // there is some sort of bug on the hardware such that you cannot
 // send the exact same speed to 2 different motors consecutively
 // hence we ensure we always alternate speeds
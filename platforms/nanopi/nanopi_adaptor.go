@@ -56,6 +56,8 @@ type Adaptor struct {
 	*adaptors.PWMPinsAdaptor
 	*adaptors.I2cBusAdaptor
 	*adaptors.SpiBusAdaptor
+	*adaptors.SysClassAdaptor
+	*adaptors.PinRegistry
 }
 
 // NewNeoAdaptor creates a board adaptor for NanoPi NEO
@@ -64,6 +66,8 @@ type Adaptor struct {
 //
 //	adaptors.WithGpiodAccess():	use character device gpiod driver instead of sysfs (still used by default)
 //	adaptors.WithSpiGpioAccess(sclk, nss, mosi, miso):	use GPIO's instead of /dev/spidev#.#
+//	adaptors.WithI2cGpioAccess(busNum, sda, scl):	add a further i2c bus, which is driven by GPIO's
+//	adaptors.WithI2cBusTracer(tracer), adaptors.WithSpiBusTracer(tracer):	record all bus transactions
 //	adaptors.WithGpiosActiveLow(pin's): invert the pin behavior
 //	adaptors.WithGpiosPullUp/Down(pin's): sets the internal pull resistor
 //	adaptors.WithGpiosOpenDrain/Source(pin's): sets the output behavior
@@ -74,20 +78,31 @@ type Adaptor struct {
 func NewNeoAdaptor(opts ...interface{}) *Adaptor {
 	sys := system.NewAccesser(system.WithDigitalPinGpiodAccess())
 	a := &Adaptor{
-		name:       gobot.DefaultName("NanoPi NEO Board"),
-		sys:        sys,
-		gpioPinMap: neoGpioPins,
-		pwmPinMap:  neoPwmPins,
+		name:        gobot.DefaultName("NanoPi NEO Board"),
+		sys:         sys,
+		gpioPinMap:  neoGpioPins,
+		pwmPinMap:   neoPwmPins,
+		PinRegistry: adaptors.NewPinRegistry(),
 	}
+	a.SetBusPinDefinitions(neoBusPinDefinitions)
 
 	var digitalPinsOpts []func(adaptors.DigitalPinsOptioner)
 	var pwmPinsOpts []adaptors.PwmPinsOptionApplier
+	i2cBusOpts := []adaptors.I2cBusOptionApplier{
+		adaptors.WithI2cDigitalPinnerProvider(a),
+		adaptors.WithI2cPinRegistry(a.PinRegistry),
+	}
+	spiBusOpts := []adaptors.SpiBusOptionApplier{adaptors.WithSpiPinRegistry(a.PinRegistry)}
 	for _, opt := range opts {
 		switch o := opt.(type) {
 		case func(adaptors.DigitalPinsOptioner):
 			digitalPinsOpts = append(digitalPinsOpts, o)
 		case adaptors.PwmPinsOptionApplier:
 			pwmPinsOpts = append(pwmPinsOpts, o)
+		case adaptors.I2cBusOptionApplier:
+			i2cBusOpts = append(i2cBusOpts, o)
+		case adaptors.SpiBusOptionApplier:
+			spiBusOpts = append(spiBusOpts, o)
 		default:
 			panic(fmt.Sprintf("'%s' can not be applied on adaptor '%s'", opt, a.name))
 		}
@@ -96,9 +111,10 @@ func NewNeoAdaptor(opts ...interface{}) *Adaptor {
 	a.AnalogPinsAdaptor = adaptors.NewAnalogPinsAdaptor(sys, a.translateAnalogPin)
 	a.DigitalPinsAdaptor = adaptors.NewDigitalPinsAdaptor(sys, a.translateDigitalPin, digitalPinsOpts...)
 	a.PWMPinsAdaptor = adaptors.NewPWMPinsAdaptor(sys, a.translatePWMPin, pwmPinsOpts...)
-	a.I2cBusAdaptor = adaptors.NewI2cBusAdaptor(sys, a.validateI2cBusNumber, defaultI2cBusNumber)
+	a.I2cBusAdaptor = adaptors.NewI2cBusAdaptor(sys, a.validateI2cBusNumber, defaultI2cBusNumber, i2cBusOpts...)
 	a.SpiBusAdaptor = adaptors.NewSpiBusAdaptor(sys, a.validateSpiBusNumber, defaultSpiBusNumber, defaultSpiChipNumber,
-		defaultSpiMode, defaultSpiBitsNumber, defaultSpiMaxSpeed)
+		defaultSpiMode, defaultSpiBitsNumber, defaultSpiMaxSpeed, spiBusOpts...)
+	a.SysClassAdaptor = adaptors.NewSysClassAdaptor(sys)
 	return a
 }
 
@@ -60,9 +60,11 @@ var (
 	_ gobot.PWMPinnerProvider     = (*Adaptor)(nil)
 	_ gpio.DigitalReader          = (*Adaptor)(nil)
 	_ gpio.DigitalWriter          = (*Adaptor)(nil)
+	_ gpio.SysLedOpener           = (*Adaptor)(nil)
 	_ gpio.PwmWriter              = (*Adaptor)(nil)
 	_ gpio.ServoWriter            = (*Adaptor)(nil)
 	_ aio.AnalogReader            = (*Adaptor)(nil)
+	_ aio.HwmonOpener             = (*Adaptor)(nil)
 	_ i2c.Connector               = (*Adaptor)(nil)
 )
 
@@ -85,6 +87,7 @@ func initTestAdaptorWithMockedFilesystem(mockPaths []string) (*Adaptor, *system.
 func TestName(t *testing.T) {
 	a := NewNeoAdaptor()
 	assert.True(t, strings.HasPrefix(a.Name(), "NanoPi NEO Board"))
+	assert.Equal(t, []string{"3", "5"}, a.BusPins(gobot.PinFunctionI2c, 0))
 	a.SetName("NewName")
 	assert.Equal(t, "NewName", a.Name())
 }
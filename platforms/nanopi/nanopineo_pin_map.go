@@ -1,5 +1,7 @@
 package nanopi
 
+import "gobot.io/x/gobot/v2"
+
 // pin definition for NanoPi NEO
 // pins: A=0+Nr, C=64+Nr, G=192+Nr
 var neoGpioPins = map[string]gpioPinDefinition{
@@ -28,3 +30,13 @@ var analogPinDefinitions = map[string]analogPinDefinition{
 	// +/-273.200 °C need >=7 characters to read: +/-273200 millidegree Celsius
 	"thermal_zone0": {path: "/sys/class/thermal/thermal_zone0/temp", r: true, w: false, bufLen: 7},
 }
+
+// neoBusPinDefinitions contains the header pins of the hardware buses, which are claimed in the pin registry
+var neoBusPinDefinitions = map[string]map[int][]string{
+	gobot.PinFunctionI2c: {
+		0: {"3", "5"}, // I2C0 SDA, SCL
+	},
+	gobot.PinFunctionSpi: {
+		0: {"19", "21", "23", "24"}, // SPI0 MOSI, MISO, CLK, CS
+	},
+}
@@ -2,28 +2,45 @@
 package neurosky
 
 import (
-	"io"
+	"fmt"
 
-	"go.bug.st/serial"
+	"gobot.io/x/gobot/v2/platforms/serial"
+	"gobot.io/x/gobot/v2/system/serialport"
 )
 
+const defaultBaudRate = 57600
+
 // Adaptor is the Gobot Adaptor for the Neurosky Mindwave
 type Adaptor struct {
-	name    string
-	port    string
-	sp      io.ReadWriteCloser
-	connect func(*Adaptor) (io.ReadWriteCloser, error)
+	name string
+	sys  *serialport.Accesser
+	*serial.Adaptor
 }
 
-// NewAdaptor creates a neurosky adaptor with specified port
-func NewAdaptor(port string) *Adaptor {
-	return &Adaptor{
+// NewAdaptor creates a neurosky adaptor with specified port. The default baud rate of 57600 can be changed by option.
+//
+// Optional parameters:
+//
+//	serial.OptionApplier: e.g. serial.WithBaudRate()
+func NewAdaptor(port string, opts ...interface{}) *Adaptor {
+	sys := serialport.NewAccesser()
+	a := &Adaptor{
 		name: "Neurosky",
-		port: port,
-		connect: func(n *Adaptor) (io.ReadWriteCloser, error) {
-			return serial.Open(n.Port(), &serial.Mode{BaudRate: 57600})
-		},
+		sys:  sys,
+	}
+
+	serialPortOpts := []serial.OptionApplier{serial.WithBaudRate(defaultBaudRate)}
+	for _, opt := range opts {
+		switch o := opt.(type) {
+		case serial.OptionApplier:
+			serialPortOpts = append(serialPortOpts, o)
+		default:
+			panic(fmt.Sprintf("'%s' can not be applied on adaptor '%s'", opt, a.name))
+		}
 	}
+
+	a.Adaptor = serial.NewAdaptor(sys, port, serialPortOpts...)
+	return a
 }
 
 // Name returns the Adaptor Name
@@ -31,22 +48,3 @@ func (n *Adaptor) Name() string { return n.name }
 
 // SetName sets the Adaptor Name
 func (n *Adaptor) SetName(name string) { n.name = name }
-
-// Port returns the Adaptor port
-func (n *Adaptor) Port() string { return n.port }
-
-// Connect returns true if connection to device is successful
-func (n *Adaptor) Connect() error {
-	sp, err := n.connect(n)
-	if err != nil {
-		return err
-	}
-
-	n.sp = sp
-	return nil
-}
-
-// Finalize returns true if device finalization is successful
-func (n *Adaptor) Finalize() error {
-	return n.sp.Close()
-}
@@ -1,60 +1,23 @@
 package neurosky
 
 import (
-	"errors"
-	"io"
 	"strings"
-	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/platforms/serial"
+	"gobot.io/x/gobot/v2/system/serialport"
 )
 
 var _ gobot.Adaptor = (*Adaptor)(nil)
 
-type NullReadWriteCloser struct {
-	mtx        sync.Mutex
-	readError  error
-	closeError error
-}
-
-func (n *NullReadWriteCloser) ReadError(e error) {
-	n.mtx.Lock()
-	defer n.mtx.Unlock()
-	n.readError = e
-}
-
-func (n *NullReadWriteCloser) CloseError(e error) {
-	n.mtx.Lock()
-	defer n.mtx.Unlock()
-	n.closeError = e
-}
-
-func (n *NullReadWriteCloser) Write(p []byte) (int, error) {
-	return len(p), nil
-}
-
-func (n *NullReadWriteCloser) Read(b []byte) (int, error) {
-	n.mtx.Lock()
-	defer n.mtx.Unlock()
-	return len(b), n.readError
-}
-
-func (n *NullReadWriteCloser) Close() error {
-	n.mtx.Lock()
-	defer n.mtx.Unlock()
-	return n.closeError
-}
-
-func initTestNeuroskyAdaptor() *Adaptor {
+func initTestNeuroskyAdaptorWithMockedSerialPort() (*Adaptor, *serialport.MockAccess) {
 	a := NewAdaptor("/dev/null")
-	a.connect = func(n *Adaptor) (io.ReadWriteCloser, error) {
-		return &NullReadWriteCloser{}, nil
-	}
-	return a
+	spa := a.sys.UseMock()
+	return a, spa
 }
 
 func TestNeuroskyAdaptor(t *testing.T) {
@@ -70,25 +33,28 @@ func TestNeuroskyAdaptorName(t *testing.T) {
 }
 
 func TestNeuroskyAdaptorConnect(t *testing.T) {
-	a := initTestNeuroskyAdaptor()
+	a, spa := initTestNeuroskyAdaptorWithMockedSerialPort()
 	require.NoError(t, a.Connect())
+	assert.Equal(t, 57600, spa.BaudRate())
+	require.NoError(t, a.Finalize())
 
-	a.connect = func(n *Adaptor) (io.ReadWriteCloser, error) {
-		return nil, errors.New("connection error")
-	}
-	require.ErrorContains(t, a.Connect(), "connection error")
+	spa.CreateError = true
+	require.ErrorContains(t, a.Connect(), "error while open serial port in mock")
+}
+
+func TestNeuroskyAdaptorConnectWithBaudRate(t *testing.T) {
+	a := NewAdaptor("/dev/null", serial.WithBaudRate(9600))
+	spa := a.sys.UseMock()
+	require.NoError(t, a.Connect())
+	assert.Equal(t, 9600, spa.BaudRate())
 }
 
 func TestNeuroskyAdaptorFinalize(t *testing.T) {
-	rwc := &NullReadWriteCloser{}
-	a := NewAdaptor("/dev/null")
-	a.connect = func(n *Adaptor) (io.ReadWriteCloser, error) {
-		return rwc, nil
-	}
+	a, spa := initTestNeuroskyAdaptorWithMockedSerialPort()
 	_ = a.Connect()
 	require.NoError(t, a.Finalize())
 
-	rwc.CloseError(errors.New("close error"))
 	_ = a.Connect()
-	require.ErrorContains(t, a.Finalize(), "close error")
+	spa.SetCloseError(true)
+	require.ErrorContains(t, a.Finalize(), "error while closing serial port in mock")
 }
@@ -125,7 +125,7 @@ func (n *Driver) Start() error {
 	go func() {
 		for {
 			buff := make([]byte, 1024)
-			_, err := n.adaptor().sp.Read(buff)
+			_, err := n.adaptor().SerialRead(buff)
 			if err != nil {
 				n.Publish(n.Event("error"), err)
 			} else {
@@ -3,8 +3,6 @@ package neurosky
 
 import (
 	"bytes"
-	"errors"
-	"io"
 	"strings"
 	"testing"
 	"time"
@@ -18,10 +16,7 @@ import (
 var _ gobot.Driver = (*Driver)(nil)
 
 func initTestNeuroskyDriver() *Driver {
-	a := NewAdaptor("/dev/null")
-	a.connect = func(n *Adaptor) (io.ReadWriteCloser, error) {
-		return &NullReadWriteCloser{}, nil
-	}
+	a, _ := initTestNeuroskyAdaptorWithMockedSerialPort()
 	_ = a.Connect()
 	return NewDriver(a)
 }
@@ -41,25 +36,18 @@ func TestNeuroskyDriverName(t *testing.T) {
 func TestNeuroskyDriverStart(t *testing.T) {
 	sem := make(chan bool)
 
-	rwc := &NullReadWriteCloser{}
-	a := NewAdaptor("/dev/null")
-	a.connect = func(n *Adaptor) (io.ReadWriteCloser, error) {
-		return rwc, nil
-	}
+	a, spa := initTestNeuroskyAdaptorWithMockedSerialPort()
 	_ = a.Connect()
+	spa.SetReadError(true)
 
 	d := NewDriver(a)
-	e := errors.New("read error")
 	_ = d.Once(d.Event(Error), func(data interface{}) {
-		assert.Equal(t, e, data.(error))
+		assert.EqualError(t, data.(error), "error while reading from serial port in mock")
 		sem <- true
 	})
 
 	require.NoError(t, d.Start())
 
-	time.Sleep(50 * time.Millisecond)
-	rwc.ReadError(e)
-
 	select {
 	case <-sem:
 	case <-time.After(100 * time.Millisecond):
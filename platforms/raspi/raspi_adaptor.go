@@ -41,6 +41,9 @@ type Adaptor struct {
 	*adaptors.PWMPinsAdaptor
 	*adaptors.I2cBusAdaptor
 	*adaptors.SpiBusAdaptor
+	*adaptors.OneWireBusAdaptor
+	*adaptors.SysClassAdaptor
+	*adaptors.PinRegistry
 }
 
 // NewAdaptor creates a Raspi Adaptor
@@ -49,26 +52,42 @@ type Adaptor struct {
 //
 //	adaptors.WithGpiodAccess():	use character device gpiod driver instead of sysfs (still used by default)
 //	adaptors.WithSpiGpioAccess(sclk, nss, mosi, miso):	use GPIO's instead of /dev/spidev#.#
+//	adaptors.WithI2cGpioAccess(busNum, sda, scl):	add a further i2c bus, which is driven by GPIO's
+//	adaptors.WithI2cBusTracer(tracer), adaptors.WithSpiBusTracer(tracer):	record all bus transactions
 //	adaptors.WithGpiosActiveLow(pin's): invert the pin behavior
 //	adaptors.WithGpiosPullUp/Down(pin's): sets the internal pull resistor
 //	adaptors.WithGpiosOpenDrain/Source(pin's): sets the output behavior
 //	adaptors.WithGpioDebounce(pin, period): sets the input debouncer
 //	adaptors.WithGpioEventOnFallingEdge/RaisingEdge/BothEdges(pin, handler): activate edge detection
+//
+// The usage of the header pins by drivers and buses is recorded, conflicting usages are rejected on start of the
+// driver, see "PinAllocations()".
 func NewAdaptor(opts ...interface{}) *Adaptor {
 	sys := system.NewAccesser(system.WithDigitalPinGpiodAccess())
 	a := &Adaptor{
-		name: gobot.DefaultName("RaspberryPi"),
-		sys:  sys,
+		name:        gobot.DefaultName("RaspberryPi"),
+		sys:         sys,
+		PinRegistry: adaptors.NewPinRegistry(),
 	}
+	a.SetBusPinDefinitions(busPinDefinitions)
 
 	var digitalPinsOpts []func(adaptors.DigitalPinsOptioner)
 	var pwmPinsOpts []adaptors.PwmPinsOptionApplier
+	i2cBusOpts := []adaptors.I2cBusOptionApplier{
+		adaptors.WithI2cDigitalPinnerProvider(a),
+		adaptors.WithI2cPinRegistry(a.PinRegistry),
+	}
+	spiBusOpts := []adaptors.SpiBusOptionApplier{adaptors.WithSpiPinRegistry(a.PinRegistry)}
 	for _, opt := range opts {
 		switch o := opt.(type) {
 		case func(adaptors.DigitalPinsOptioner):
 			digitalPinsOpts = append(digitalPinsOpts, o)
 		case adaptors.PwmPinsOptionApplier:
 			pwmPinsOpts = append(pwmPinsOpts, o)
+		case adaptors.I2cBusOptionApplier:
+			i2cBusOpts = append(i2cBusOpts, o)
+		case adaptors.SpiBusOptionApplier:
+			spiBusOpts = append(spiBusOpts, o)
 		default:
 			panic(fmt.Sprintf("'%s' can not be applied on adaptor '%s'", opt, a.name))
 		}
@@ -77,9 +96,11 @@ func NewAdaptor(opts ...interface{}) *Adaptor {
 	a.AnalogPinsAdaptor = adaptors.NewAnalogPinsAdaptor(sys, a.translateAnalogPin)
 	a.DigitalPinsAdaptor = adaptors.NewDigitalPinsAdaptor(sys, a.getPinTranslatorFunction(), digitalPinsOpts...)
 	a.PWMPinsAdaptor = adaptors.NewPWMPinsAdaptor(sys, a.getPinTranslatorFunction(), pwmPinsOpts...)
-	a.I2cBusAdaptor = adaptors.NewI2cBusAdaptor(sys, a.validateI2cBusNumber, 1)
+	a.I2cBusAdaptor = adaptors.NewI2cBusAdaptor(sys, a.validateI2cBusNumber, 1, i2cBusOpts...)
 	a.SpiBusAdaptor = adaptors.NewSpiBusAdaptor(sys, a.validateSpiBusNumber, defaultSpiBusNumber, defaultSpiChipNumber,
-		defaultSpiMode, defaultSpiBitsNumber, defaultSpiMaxSpeed)
+		defaultSpiMode, defaultSpiBitsNumber, defaultSpiMaxSpeed, spiBusOpts...)
+	a.OneWireBusAdaptor = adaptors.NewOneWireBusAdaptor(sys)
+	a.SysClassAdaptor = adaptors.NewSysClassAdaptor(sys)
 	return a
 }
 
@@ -104,6 +125,10 @@ func (a *Adaptor) Connect() error {
 	a.mutex.Lock()
 	defer a.mutex.Unlock()
 
+	if err := a.OneWireBusAdaptor.Connect(); err != nil {
+		return err
+	}
+
 	if err := a.SpiBusAdaptor.Connect(); err != nil {
 		return err
 	}
@@ -145,6 +170,10 @@ func (a *Adaptor) Finalize() error {
 	if e := a.SpiBusAdaptor.Finalize(); e != nil {
 		err = multierror.Append(err, e)
 	}
+
+	if e := a.OneWireBusAdaptor.Finalize(); e != nil {
+		err = multierror.Append(err, e)
+	}
 	return err
 }
 
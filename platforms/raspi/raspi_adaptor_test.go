@@ -15,6 +15,7 @@ import (
 	"gobot.io/x/gobot/v2/drivers/aio"
 	"gobot.io/x/gobot/v2/drivers/gpio"
 	"gobot.io/x/gobot/v2/drivers/i2c"
+	"gobot.io/x/gobot/v2/drivers/onewire"
 	"gobot.io/x/gobot/v2/drivers/spi"
 	"gobot.io/x/gobot/v2/platforms/adaptors"
 	"gobot.io/x/gobot/v2/system"
@@ -49,11 +50,14 @@ var (
 	_ gobot.PWMPinnerProvider     = (*Adaptor)(nil)
 	_ gpio.DigitalReader          = (*Adaptor)(nil)
 	_ gpio.DigitalWriter          = (*Adaptor)(nil)
+	_ gpio.SysLedOpener           = (*Adaptor)(nil)
 	_ gpio.PwmWriter              = (*Adaptor)(nil)
 	_ gpio.ServoWriter            = (*Adaptor)(nil)
 	_ aio.AnalogReader            = (*Adaptor)(nil)
+	_ aio.HwmonOpener             = (*Adaptor)(nil)
 	_ i2c.Connector               = (*Adaptor)(nil)
 	_ spi.Connector               = (*Adaptor)(nil)
+	_ onewire.Connector           = (*Adaptor)(nil)
 )
 
 func preparePwmFs(fs *system.MockFilesystem) {
@@ -345,6 +349,61 @@ func TestI2cFinalizeWithErrors(t *testing.T) {
 	require.ErrorContains(t, err, "close error")
 }
 
+func TestI2cGpioBus(t *testing.T) {
+	// arrange
+	a := NewAdaptor(adaptors.WithI2cGpioAccess(3, "7", "11"))
+	_ = a.sys.UseDigitalPinAccessWithMockFs("mock", []string{})
+	require.NoError(t, a.Connect())
+	// act
+	con, err := a.GetI2cConnection(0x10, 3)
+	// assert
+	require.NoError(t, err)
+	assert.NotNil(t, con)
+	require.NoError(t, a.Finalize())
+}
+
+func TestPinRegistry(t *testing.T) {
+	// arrange
+	a := NewAdaptor()
+	a.sys.UseMockSyscall()
+	_ = a.sys.UseMockFilesystem([]string{"/dev/i2c-1"})
+	require.NoError(t, a.Connect())
+	led := gpio.NewLedDriver(a, "3", gpio.WithName("led"))
+	button := gpio.NewButtonDriver(a, "7", gpio.WithName("button"))
+	// act
+	_, err := a.GetI2cConnection(0x10, 1)
+	require.NoError(t, err)
+	require.NoError(t, button.Start())
+	err = led.Start()
+	// assert
+	require.EqualError(t, err,
+		"pin '3' can not be used by 'led' as gpio, because it is already used by 'i2c bus 1' as i2c")
+	// a driver with the same name is another owner
+	require.EqualError(t, gpio.NewButtonDriver(a, "7", gpio.WithName("button")).Start(),
+		"pin '7' can not be used by 'button' as gpio, because it is already used by 'button' as gpio")
+	assert.Equal(t, []gobot.PinAllocation{
+		{Pin: "3", Function: gobot.PinFunctionI2c, Owner: "i2c bus 1"},
+		{Pin: "5", Function: gobot.PinFunctionI2c, Owner: "i2c bus 1"},
+		{Pin: "7", Function: gobot.PinFunctionGpio, Owner: "button"},
+	}, a.PinAllocations())
+	require.NoError(t, button.Halt())
+	require.NoError(t, a.Finalize())
+	assert.Empty(t, a.PinAllocations())
+}
+
+func TestOneWireConnection(t *testing.T) {
+	// arrange
+	a := NewAdaptor()
+	_ = a.sys.UseMockFilesystem([]string{"/sys/bus/w1/devices/28-0000075a1b2c/temperature"})
+	require.NoError(t, a.Connect())
+	// act
+	con, err := a.GetOneWireConnection(0x28, 0x75a1b2c)
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, "28-0000075a1b2c", con.ID())
+	require.NoError(t, a.Finalize())
+}
+
 func Test_validateSpiBusNumber(t *testing.T) {
 	tests := map[string]struct {
 		busNr   int
@@ -1,5 +1,7 @@
 package raspi
 
+import "gobot.io/x/gobot/v2"
+
 var pins = map[string]map[string]int{
 	"3": {
 		"1": 0,
@@ -97,3 +99,15 @@ var analogPinDefinitions = map[string]analogPinDefinition{
 	// +/-273.200 °C need >=7 characters to read: +/-273200 millidegree Celsius
 	"thermal_zone0": {path: "/sys/class/thermal/thermal_zone0/temp", r: true, w: false, bufLen: 7},
 }
+
+// busPinDefinitions contains the header pins of the hardware buses, which are claimed in the pin registry
+var busPinDefinitions = map[string]map[int][]string{
+	gobot.PinFunctionI2c: {
+		0: {"27", "28"}, // ID_SD, ID_SC
+		1: {"3", "5"},   // SDA, SCL
+	},
+	gobot.PinFunctionSpi: {
+		0: {"19", "21", "23", "24", "26"},       // MOSI, MISO, SCLK, CE0, CE1
+		1: {"38", "35", "40", "12", "11", "36"}, // MOSI, MISO, SCLK, CE0, CE1, CE2
+	},
+}
@@ -33,6 +33,8 @@ type Adaptor struct {
 	*adaptors.DigitalPinsAdaptor
 	*adaptors.I2cBusAdaptor
 	*adaptors.SpiBusAdaptor
+	*adaptors.SysClassAdaptor
+	*adaptors.PinRegistry
 }
 
 // NewAdaptor creates a RockPi Adaptor
@@ -47,13 +49,17 @@ type Adaptor struct {
 func NewAdaptor(opts ...func(adaptors.DigitalPinsOptioner)) *Adaptor {
 	sys := system.NewAccesser()
 	c := &Adaptor{
-		name: gobot.DefaultName("RockPi"),
-		sys:  sys,
+		name:        gobot.DefaultName("RockPi"),
+		sys:         sys,
+		PinRegistry: adaptors.NewPinRegistry(),
 	}
+	c.SetBusPinDefinitions(busPinDefinitions)
 	c.DigitalPinsAdaptor = adaptors.NewDigitalPinsAdaptor(sys, c.getPinTranslatorFunction(), opts...)
-	c.I2cBusAdaptor = adaptors.NewI2cBusAdaptor(sys, c.validateI2cBusNumber, defaultI2cBusNumber)
+	c.I2cBusAdaptor = adaptors.NewI2cBusAdaptor(sys, c.validateI2cBusNumber, defaultI2cBusNumber,
+		adaptors.WithI2cPinRegistry(c.PinRegistry))
 	c.SpiBusAdaptor = adaptors.NewSpiBusAdaptor(sys, c.validateSpiBusNumber, defaultSpiBusNumber, defaultSpiChipNumber,
-		defaultSpiMode, defaultSpiBitsNumber, defaultSpiMaxSpeed)
+		defaultSpiMode, defaultSpiBitsNumber, defaultSpiMaxSpeed, adaptors.WithSpiPinRegistry(c.PinRegistry))
+	c.SysClassAdaptor = adaptors.NewSysClassAdaptor(sys)
 	return c
 }
 
@@ -6,6 +6,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"gobot.io/x/gobot/v2"
 	"gobot.io/x/gobot/v2/system"
 )
 
@@ -19,6 +20,7 @@ func initTestAdaptorWithMockedFilesystem(mockPaths []string) (*Adaptor, *system.
 func TestDefaultI2cBus(t *testing.T) {
 	a, _ := initTestAdaptorWithMockedFilesystem([]string{})
 	assert.Equal(t, 7, a.DefaultI2cBus())
+	assert.Equal(t, []string{"3", "5"}, a.BusPins(gobot.PinFunctionI2c, 7))
 }
 
 func Test_getPinTranslatorFunction(t *testing.T) {
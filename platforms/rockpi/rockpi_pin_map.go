@@ -1,5 +1,7 @@
 package rockpi
 
+import "gobot.io/x/gobot/v2"
+
 // See https://wiki.radxa.com/Rock4/hardware/gpio.
 var pins = map[string]map[string]int{
 	"3": {
@@ -89,3 +91,16 @@ var pins = map[string]map[string]int{
 		"4C+": 52,
 	},
 }
+
+// busPinDefinitions contains the header pins of the hardware buses, which are claimed in the pin registry
+var busPinDefinitions = map[string]map[int][]string{
+	gobot.PinFunctionI2c: {
+		2: {"27", "28"}, // I2C2 SDA, SCL
+		6: {"31", "29"}, // I2C6 SDA, SCL
+		7: {"3", "5"},   // I2C7 SDA, SCL
+	},
+	gobot.PinFunctionSpi: {
+		1: {"19", "21", "23", "24"}, // SPI1 TXD, RXD, CLK, CSN
+		2: {"29", "31", "7", "33"},  // SPI2 TXD, RXD, CLK, CSN0
+	},
+}
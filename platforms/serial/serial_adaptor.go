@@ -0,0 +1,208 @@
+// Package serial provides the adaptor for serial ports (UART), normally used for composition in platforms. It has no
+// dependencies to Linux specific packages, so it can be used on all platforms supported by "system/serialport".
+package serial
+
+import (
+	"fmt"
+	"io"
+	"sync"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/system/serialport"
+)
+
+// Adaptor is an adaptor for a serial port (UART), normally used for composition in platforms.
+type Adaptor struct {
+	sys           *serialport.Accesser
+	portName      string
+	serialPortCfg *serialPortConfiguration
+	mutex         sync.Mutex
+	port          gobot.SerialPortSystemDevicer
+}
+
+// serialPortReadWriter provides the io.ReadWriteCloser interface for the adaptor
+type serialPortReadWriter struct {
+	a *Adaptor
+}
+
+// NewAdaptor provides the access to the serial port with the given name, e.g. "/dev/ttyUSB0" or "COM3".
+// The port is opened on Connect(). Without options 9600 baud, 8 data bits, no parity and 1 stop bit is used.
+// Options, which are applied later, substitute the former ones. This can be used by platforms to apply defaults
+// before the options given by the user.
+//
+// Options:
+//
+//	"WithBaudRate"
+//	"WithDataBits"
+//	"WithParity"
+//	"WithStopBits"
+//	"WithReadTimeout"
+//	"WithRS485"
+//	"WithRS485DirectionPin"
+//	"WithDigitalPinnerProvider"
+func NewAdaptor(
+	sys *serialport.Accesser,
+	portName string,
+	opts ...OptionApplier,
+) *Adaptor {
+	a := &Adaptor{
+		sys:           sys,
+		portName:      portName,
+		serialPortCfg: &serialPortConfiguration{},
+	}
+
+	for _, o := range opts {
+		o.apply(a.serialPortCfg)
+	}
+
+	return a
+}
+
+// Connect opens the serial port.
+func (a *Adaptor) Connect() error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.port != nil {
+		return fmt.Errorf("serial port '%s' is already connected", a.portName)
+	}
+
+	opts, err := a.systemOptions()
+	if err != nil {
+		return err
+	}
+
+	port, err := a.sys.NewPort(a.portName, opts...)
+	if err != nil {
+		return err
+	}
+
+	a.port = port
+	return nil
+}
+
+// Finalize closes the serial port.
+func (a *Adaptor) Finalize() error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.port == nil {
+		return nil
+	}
+
+	err := a.port.Close()
+	a.port = nil
+	return err
+}
+
+// IsConnected returns whether the serial port is opened.
+func (a *Adaptor) IsConnected() bool {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	return a.port != nil
+}
+
+// Port returns the name of the serial port.
+func (a *Adaptor) Port() string {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	return a.portName
+}
+
+// SetPort changes the name of the serial port, which is used on next Connect().
+func (a *Adaptor) SetPort(portName string) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.portName = portName
+}
+
+// SerialRead reads from the serial port. It blocks until data are received, or the read timeout has expired.
+func (a *Adaptor) SerialRead(b []byte) (int, error) {
+	port, err := a.connectedPort()
+	if err != nil {
+		return 0, err
+	}
+	// the port is not locked here, to prevent a blocking read from blocking all other calls
+	return port.Read(b)
+}
+
+// SerialWrite writes the given data to the serial port.
+func (a *Adaptor) SerialWrite(b []byte) (int, error) {
+	port, err := a.connectedPort()
+	if err != nil {
+		return 0, err
+	}
+	return port.Write(b)
+}
+
+// SerialResetInputBuffer discards all received, but not yet read data.
+func (a *Adaptor) SerialResetInputBuffer() error {
+	port, err := a.connectedPort()
+	if err != nil {
+		return err
+	}
+	return port.ResetInputBuffer()
+}
+
+// SerialReadWriter returns an io.ReadWriteCloser to the serial port, e.g. for usage with protocol parsers. Close()
+// finalizes the adaptor.
+func (a *Adaptor) SerialReadWriter() io.ReadWriteCloser {
+	return &serialPortReadWriter{a: a}
+}
+
+func (a *Adaptor) connectedPort() (gobot.SerialPortSystemDevicer, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.port == nil {
+		return nil, fmt.Errorf("serial port '%s' is not connected", a.portName)
+	}
+	return a.port, nil
+}
+
+func (a *Adaptor) systemOptions() ([]serialport.OptionApplier, error) {
+	cfg := a.serialPortCfg
+	var opts []serialport.OptionApplier
+	if cfg.baudRate != 0 {
+		opts = append(opts, serialport.WithBaudRate(cfg.baudRate))
+	}
+	if cfg.dataBits != 0 {
+		opts = append(opts, serialport.WithDataBits(cfg.dataBits))
+	}
+	opts = append(opts, serialport.WithParity(cfg.parity), serialport.WithStopBits(cfg.stopBits),
+		serialport.WithReadTimeout(cfg.readTimeout))
+
+	if cfg.rs485 != nil {
+		opts = append(opts, serialport.WithRS485(cfg.rs485.activeHighOnSend, cfg.rs485.delayBeforeSend,
+			cfg.rs485.delayAfterSend))
+	}
+
+	if cfg.rs485DirectionPin != "" {
+		if cfg.pinProvider == nil {
+			return nil, fmt.Errorf("no provider for the RS-485 direction pin '%s' of serial port '%s'",
+				cfg.rs485DirectionPin, a.portName)
+		}
+		pin, err := cfg.pinProvider.DigitalPin(cfg.rs485DirectionPin)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, serialport.WithRS485DirectionPin(pin))
+	}
+
+	return opts, nil
+}
+
+func (rw *serialPortReadWriter) Read(b []byte) (int, error) {
+	return rw.a.SerialRead(b)
+}
+
+func (rw *serialPortReadWriter) Write(b []byte) (int, error) {
+	return rw.a.SerialWrite(b)
+}
+
+func (rw *serialPortReadWriter) Close() error {
+	return rw.a.Finalize()
+}
@@ -0,0 +1,169 @@
+package serial
+
+import (
+	"time"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/system/serialport"
+)
+
+// OptionApplier needs to be implemented by each configurable option type
+type OptionApplier interface {
+	apply(cfg *serialPortConfiguration)
+}
+
+// serialPortConfiguration contains all changeable attributes of the adaptor.
+type serialPortConfiguration struct {
+	baudRate          int
+	dataBits          int
+	parity            serialport.Parity
+	stopBits          serialport.StopBits
+	readTimeout       time.Duration
+	rs485             *serialPortRS485Option
+	rs485DirectionPin string
+	pinProvider       gobot.DigitalPinnerProvider
+}
+
+// serialPortBaudRateOption is the type for applying another baud rate.
+type serialPortBaudRateOption int
+
+// serialPortDataBitsOption is the type for applying another than the default of 8 data bits.
+type serialPortDataBitsOption int
+
+// serialPortParityOption is the type for applying a parity.
+type serialPortParityOption serialport.Parity
+
+// serialPortStopBitsOption is the type for applying another than the default of 1 stop bit.
+type serialPortStopBitsOption serialport.StopBits
+
+// serialPortReadTimeoutOption is the type for applying a timeout for read operations.
+type serialPortReadTimeoutOption time.Duration
+
+// serialPortRS485Option is the type for applying the direction control of a RS-485 transceiver.
+type serialPortRS485Option struct {
+	activeHighOnSend bool
+	delayBeforeSend  time.Duration
+	delayAfterSend   time.Duration
+}
+
+// serialPortRS485DirectionPinOption is the type for applying a pin for the direction control of a RS-485
+// transceiver.
+type serialPortRS485DirectionPinOption string
+
+// serialPortDigitalPinnerProviderOption is the type for applying the provider of digital pins, used for the
+// direction control of a RS-485 transceiver.
+type serialPortDigitalPinnerProviderOption struct {
+	provider gobot.DigitalPinnerProvider
+}
+
+// WithBaudRate substitutes the default baud rate of the platform.
+func WithBaudRate(baudRate int) serialPortBaudRateOption {
+	return serialPortBaudRateOption(baudRate)
+}
+
+// WithDataBits substitutes the default of 8 data bits. Valid values are 5, 6, 7 and 8.
+func WithDataBits(dataBits int) serialPortDataBitsOption {
+	return serialPortDataBitsOption(dataBits)
+}
+
+// WithParity activates the given parity check, e.g. "serialport.ParityEven".
+func WithParity(parity serialport.Parity) serialPortParityOption {
+	return serialPortParityOption(parity)
+}
+
+// WithStopBits substitutes the default of 1 stop bit, e.g. by "serialport.StopBitsTwo".
+func WithStopBits(stopBits serialport.StopBits) serialPortStopBitsOption {
+	return serialPortStopBitsOption(stopBits)
+}
+
+// WithReadTimeout sets a timeout for read operations. By default the read blocks until data are received.
+func WithReadTimeout(timeout time.Duration) serialPortReadTimeoutOption {
+	return serialPortReadTimeoutOption(timeout)
+}
+
+// WithRS485 activates the direction control of a half duplex RS-485 transceiver by the RTS line. See
+// "serialport.WithRS485()" for details.
+func WithRS485(activeHighOnSend bool, delayBeforeSend, delayAfterSend time.Duration) serialPortRS485Option {
+	return serialPortRS485Option{
+		activeHighOnSend: activeHighOnSend,
+		delayBeforeSend:  delayBeforeSend,
+		delayAfterSend:   delayAfterSend,
+	}
+}
+
+// WithRS485DirectionPin uses the given pin for the direction control of a RS-485 transceiver, instead of
+// the RTS line. The platform needs to provide the access to the digital pins, see
+// "WithDigitalPinnerProvider".
+func WithRS485DirectionPin(pin string) serialPortRS485DirectionPinOption {
+	return serialPortRS485DirectionPinOption(pin)
+}
+
+// WithDigitalPinnerProvider sets the provider of the digital pins, which are used for the direction control
+// of a RS-485 transceiver. This option is normally applied by the platform and not by the user.
+func WithDigitalPinnerProvider(p gobot.DigitalPinnerProvider) serialPortDigitalPinnerProviderOption {
+	return serialPortDigitalPinnerProviderOption{provider: p}
+}
+
+func (o serialPortBaudRateOption) String() string {
+	return "baud rate option for serial ports"
+}
+
+func (o serialPortDataBitsOption) String() string {
+	return "data bits option for serial ports"
+}
+
+func (o serialPortParityOption) String() string {
+	return "parity option for serial ports"
+}
+
+func (o serialPortStopBitsOption) String() string {
+	return "stop bits option for serial ports"
+}
+
+func (o serialPortReadTimeoutOption) String() string {
+	return "read timeout option for serial ports"
+}
+
+func (o serialPortRS485Option) String() string {
+	return "RS-485 option for serial ports"
+}
+
+func (o serialPortRS485DirectionPinOption) String() string {
+	return "RS-485 direction pin option for serial ports"
+}
+
+func (o serialPortDigitalPinnerProviderOption) String() string {
+	return "digital pin provider option for serial ports"
+}
+
+func (o serialPortBaudRateOption) apply(cfg *serialPortConfiguration) {
+	cfg.baudRate = int(o)
+}
+
+func (o serialPortDataBitsOption) apply(cfg *serialPortConfiguration) {
+	cfg.dataBits = int(o)
+}
+
+func (o serialPortParityOption) apply(cfg *serialPortConfiguration) {
+	cfg.parity = serialport.Parity(o)
+}
+
+func (o serialPortStopBitsOption) apply(cfg *serialPortConfiguration) {
+	cfg.stopBits = serialport.StopBits(o)
+}
+
+func (o serialPortReadTimeoutOption) apply(cfg *serialPortConfiguration) {
+	cfg.readTimeout = time.Duration(o)
+}
+
+func (o serialPortRS485Option) apply(cfg *serialPortConfiguration) {
+	cfg.rs485 = &o
+}
+
+func (o serialPortRS485DirectionPinOption) apply(cfg *serialPortConfiguration) {
+	cfg.rs485DirectionPin = string(o)
+}
+
+func (o serialPortDigitalPinnerProviderOption) apply(cfg *serialPortConfiguration) {
+	cfg.pinProvider = o.provider
+}
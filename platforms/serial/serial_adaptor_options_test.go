@@ -0,0 +1,39 @@
+package serial
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithBaudRate(t *testing.T) {
+	// This is a general test, that options are applied by using the WithBaudRate() option.
+	// All other configuration options can also be tested by With..(val).apply(cfg).
+	// arrange & act
+	a := NewAdaptor(nil, "/dev/ttyS0", WithBaudRate(57600))
+	// assert
+	assert.Equal(t, 57600, a.serialPortCfg.baudRate)
+}
+
+func TestWithRS485(t *testing.T) {
+	// arrange
+	cfg := &serialPortConfiguration{}
+	// act
+	WithRS485(false, time.Millisecond, 2*time.Millisecond).apply(cfg)
+	// assert
+	assert.Equal(t, &serialPortRS485Option{delayBeforeSend: time.Millisecond, delayAfterSend: 2 * time.Millisecond},
+		cfg.rs485)
+}
+
+func TestWithRS485DirectionPin(t *testing.T) {
+	// arrange
+	p := &serialTestPinProvider{}
+	cfg := &serialPortConfiguration{}
+	// act
+	WithRS485DirectionPin("11").apply(cfg)
+	WithDigitalPinnerProvider(p).apply(cfg)
+	// assert
+	assert.Equal(t, "11", cfg.rs485DirectionPin)
+	assert.Equal(t, p, cfg.pinProvider)
+}
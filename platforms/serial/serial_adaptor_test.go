@@ -0,0 +1,239 @@
+package serial
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/system/serialport"
+)
+
+// make sure that this Adaptor fulfills all the required interfaces
+var _ gobot.Porter = (*Adaptor)(nil)
+
+// serialTestPinProvider provides a pin, which records the written values
+type serialTestPinProvider struct {
+	pin *serialTestPin
+}
+
+type serialTestPin struct {
+	gobot.DigitalPinner
+	gobot.DigitalPinOptioner
+	written []int
+}
+
+func (p *serialTestPinProvider) DigitalPin(string) (gobot.DigitalPinner, error) {
+	if p.pin == nil {
+		p.pin = &serialTestPin{}
+	}
+	return p.pin, nil
+}
+
+func (p *serialTestPin) ApplyOptions(options ...func(gobot.DigitalPinOptioner) bool) error {
+	for _, option := range options {
+		option(p)
+	}
+	return nil
+}
+
+func (p *serialTestPin) SetDirectionOutput(initialState int) bool {
+	p.written = append(p.written, initialState)
+	return true
+}
+
+func (p *serialTestPin) Write(val int) error {
+	p.written = append(p.written, val)
+	return nil
+}
+
+func initTestAdaptorWithMockedAccess(
+	opts ...OptionApplier,
+) (*Adaptor, *serialport.MockAccess) {
+	sys := serialport.NewAccesser()
+	spa := sys.UseMock()
+	a := NewAdaptor(sys, "/dev/ttyUSB0", opts...)
+	if err := a.Connect(); err != nil {
+		panic(err)
+	}
+	return a, spa
+}
+
+func TestNewAdaptor(t *testing.T) {
+	// arrange
+	a := NewAdaptor(nil, "/dev/ttyUSB0")
+	// act & assert
+	assert.Equal(t, "/dev/ttyUSB0", a.Port())
+	assert.False(t, a.IsConnected())
+	_, err := a.SerialRead(make([]byte, 1))
+	require.EqualError(t, err, "serial port '/dev/ttyUSB0' is not connected")
+	_, err = a.SerialWrite([]byte{1})
+	require.EqualError(t, err, "serial port '/dev/ttyUSB0' is not connected")
+	require.EqualError(t, a.SerialResetInputBuffer(), "serial port '/dev/ttyUSB0' is not connected")
+	require.NoError(t, a.Finalize())
+}
+
+func TestSerialPortConnect(t *testing.T) {
+	tests := map[string]struct {
+		opts         []OptionApplier
+		createErr    bool
+		wantBaudRate int
+		wantDataBits int
+		wantParity   serialport.Parity
+		wantStopBits serialport.StopBits
+		wantTimeout  time.Duration
+		wantRS485    bool
+		wantErr      string
+	}{
+		"defaults": {
+			wantBaudRate: 9600,
+			wantDataBits: 8,
+			wantTimeout:  -1,
+		},
+		"later_option_substitutes_former": {
+			opts:         []OptionApplier{WithBaudRate(57600), WithBaudRate(115200)},
+			wantBaudRate: 115200,
+			wantDataBits: 8,
+			wantTimeout:  -1,
+		},
+		"all_options": {
+			opts: []OptionApplier{
+				WithBaudRate(19200),
+				WithDataBits(7),
+				WithParity(serialport.ParityOdd),
+				WithStopBits(serialport.StopBitsTwo),
+				WithReadTimeout(time.Second),
+				WithRS485(true, 0, 0),
+			},
+			wantBaudRate: 19200,
+			wantDataBits: 7,
+			wantParity:   serialport.ParityOdd,
+			wantStopBits: serialport.StopBitsTwo,
+			wantTimeout:  time.Second,
+			wantRS485:    true,
+		},
+		"error_direction_pin_without_provider": {
+			opts:    []OptionApplier{WithRS485DirectionPin("7")},
+			wantErr: "no provider for the RS-485 direction pin '7' of serial port '/dev/ttyUSB0'",
+		},
+		"error_open": {
+			createErr: true,
+			wantErr:   "error while open serial port in mock",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			sys := serialport.NewAccesser()
+			spa := sys.UseMock()
+			spa.CreateError = tc.createErr
+			a := NewAdaptor(sys, "/dev/ttyUSB0", tc.opts...)
+			// act
+			err := a.Connect()
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				assert.False(t, a.IsConnected())
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, a.IsConnected())
+			assert.Equal(t, "/dev/ttyUSB0", spa.PortName())
+			assert.Equal(t, tc.wantBaudRate, spa.BaudRate())
+			assert.Equal(t, tc.wantDataBits, spa.DataBits())
+			assert.Equal(t, tc.wantParity, spa.Parity())
+			assert.Equal(t, tc.wantStopBits, spa.StopBits())
+			assert.Equal(t, tc.wantTimeout, spa.ReadTimeout())
+			assert.Equal(t, tc.wantRS485, spa.IsRS485())
+			// assert connect twice fails
+			require.EqualError(t, a.Connect(), "serial port '/dev/ttyUSB0' is already connected")
+		})
+	}
+}
+
+func TestSerialPortConnect_directionPin(t *testing.T) {
+	// arrange
+	sys := serialport.NewAccesser()
+	spa := sys.UseMock()
+	dpa := &serialTestPinProvider{}
+	a := NewAdaptor(sys, "/dev/ttyUSB0", WithDigitalPinnerProvider(dpa), WithRS485DirectionPin("7"))
+	// act
+	err := a.Connect()
+	// assert
+	require.NoError(t, err)
+	assert.True(t, spa.IsRS485())
+	_, err = a.SerialWrite([]byte{0x01})
+	require.NoError(t, err)
+	assert.Nil(t, spa.RTSStates())
+	assert.Equal(t, 1, spa.DrainCount())
+	assert.Equal(t, []int{0, 1, 0}, dpa.pin.written)
+}
+
+func TestSerialPortReadWrite(t *testing.T) {
+	// arrange
+	a, spa := initTestAdaptorWithMockedAccess()
+	spa.SetSimRead([]byte{0x01, 0x02, 0x03})
+	buf := make([]byte, 2)
+	// act
+	n, err := a.SerialRead(buf)
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, []byte{0x01, 0x02}, buf)
+	// act
+	n, err = a.SerialWrite([]byte{0x04, 0x05})
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, []byte{0x04, 0x05}, spa.Written())
+	// act
+	err = a.SerialResetInputBuffer()
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, 1, spa.InputResets())
+	_, err = a.SerialRead(buf)
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestSerialReadWriter(t *testing.T) {
+	// arrange
+	a, spa := initTestAdaptorWithMockedAccess()
+	spa.SetSimRead([]byte("hello"))
+	rw := a.SerialReadWriter()
+	// act
+	got, errRead := io.ReadAll(rw)
+	_, errWrite := io.WriteString(rw, "world")
+	// assert
+	require.NoError(t, errRead)
+	assert.Equal(t, "hello", string(got))
+	require.NoError(t, errWrite)
+	assert.Equal(t, "world", string(spa.Written()))
+	// assert close finalizes the adaptor
+	require.NoError(t, rw.Close())
+	assert.True(t, spa.IsClosed())
+	assert.False(t, a.IsConnected())
+}
+
+func TestSerialPortFinalize(t *testing.T) {
+	// arrange
+	a, spa := initTestAdaptorWithMockedAccess()
+	// act
+	err := a.Finalize()
+	// assert
+	require.NoError(t, err)
+	assert.True(t, spa.IsClosed())
+	assert.False(t, a.IsConnected())
+	// assert finalize after finalize is working
+	require.NoError(t, a.Finalize())
+	// assert reconnect to another port is working
+	a.SetPort("/dev/ttyUSB1")
+	require.NoError(t, a.Connect())
+	assert.Equal(t, "/dev/ttyUSB1", spa.PortName())
+	// assert close error
+	spa.SetCloseError(true)
+	require.EqualError(t, a.Finalize(), "error while closing serial port in mock")
+	assert.False(t, a.IsConnected())
+}
@@ -0,0 +1,47 @@
+/*
+Package socketcan contains the Gobot adaptor for CAN network interfaces of the Linux SocketCAN subsystem, e.g. "can0"
+for a MCP2515 connected by SPI, a USB adaptor or the CAN controller of the SoC, or "vcan0" for a virtual interface.
+The drivers are provided by the package "gobot.io/x/gobot/v2/drivers/can".
+
+Installing:
+
+	Please refer to the main [README.md](https://github.com/hybridgroup/gobot/blob/release/README.md)
+
+Example:
+
+	package main
+
+	import (
+		"fmt"
+
+		"gobot.io/x/gobot/v2"
+		"gobot.io/x/gobot/v2/drivers/can"
+		"gobot.io/x/gobot/v2/platforms/socketcan"
+	)
+
+	func main() {
+		adaptor := socketcan.NewAdaptor("can0")
+		bus := can.NewFrameDriver(adaptor)
+
+		work := func() {
+			_ = bus.On(can.Frame, func(data interface{}) {
+				frame := data.(gobot.CanFrame)
+				fmt.Printf("0x%03X % X\n", frame.ID, frame.Data)
+			})
+		}
+
+		robot := gobot.NewRobot("canBot",
+			[]gobot.Connection{adaptor},
+			[]gobot.Device{bus},
+			work,
+		)
+
+		if err := robot.Start(); err != nil {
+			panic(err)
+		}
+	}
+
+For further information refer to socketcan README:
+https://github.com/hybridgroup/gobot/blob/master/platforms/socketcan/README.md
+*/
+package socketcan // import "gobot.io/x/gobot/v2/platforms/socketcan"
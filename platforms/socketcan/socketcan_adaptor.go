@@ -0,0 +1,187 @@
+package socketcan
+
+import (
+	"fmt"
+	"sync"
+
+	multierror "github.com/hashicorp/go-multierror"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/drivers/can"
+	"gobot.io/x/gobot/v2/system"
+)
+
+// optionApplier needs to be implemented by each configurable option type
+type optionApplier interface {
+	apply(cfg *configuration)
+}
+
+// configuration contains all changeable attributes of the adaptor.
+type configuration struct {
+	fdFrames bool
+}
+
+// fdFramesOption is the type for activating CAN FD frames in the configuration
+type fdFramesOption bool
+
+// Adaptor is the gobot adaptor for a CAN network interface of the Linux SocketCAN subsystem, e.g. "can0" for a
+// MCP2515 connected by SPI or a USB adaptor, or "vcan0" for a virtual interface. Each driver gets its own raw socket,
+// so the filters of the drivers are independent and each driver receives all frames sent by the other drivers.
+type Adaptor struct {
+	name        string
+	iface       string
+	sys         *system.Accesser
+	cfg         *configuration
+	mutex       sync.Mutex
+	socket      gobot.CanSystemDevicer // used for sending by the adaptor, receives no frames
+	connections []*canConnection
+}
+
+// NewAdaptor creates a new adaptor for the given CAN network interface, e.g. "can0". The interface needs to be
+// configured and up, e.g. by "ip link set can0 up type can bitrate 500000".
+//
+// Supported options:
+//
+//	"WithFDFrames"
+func NewAdaptor(iface string, opts ...optionApplier) *Adaptor {
+	a := &Adaptor{
+		name:  gobot.DefaultName("SocketCAN"),
+		iface: iface,
+		sys:   system.NewAccesser(),
+		cfg:   &configuration{},
+	}
+
+	for _, o := range opts {
+		o.apply(a.cfg)
+	}
+
+	return a
+}
+
+// WithFDFrames activates sending and receiving of CAN FD frames in addition to classic frames. The interface needs
+// to be configured for CAN FD, e.g. by "ip link set can0 up type can bitrate 500000 dbitrate 2000000 fd on".
+func WithFDFrames() optionApplier {
+	return fdFramesOption(true)
+}
+
+// Name returns the adaptors name
+func (a *Adaptor) Name() string {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	return a.name
+}
+
+// SetName sets the adaptors name
+func (a *Adaptor) SetName(n string) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.name = n
+}
+
+// Interface returns the name of the CAN network interface.
+func (a *Adaptor) Interface() string {
+	return a.iface
+}
+
+// Connect opens the socket of the adaptor, which is used for sending only.
+func (a *Adaptor) Connect() error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	socket, err := a.sys.NewCanSocket(a.iface, a.cfg.fdFrames)
+	if err != nil {
+		return err
+	}
+
+	if err := socket.SetFilters(nil); err != nil {
+		_ = socket.Close()
+		return err
+	}
+
+	a.socket = socket
+	return nil
+}
+
+// Finalize closes the socket of the adaptor and all connections of the drivers.
+func (a *Adaptor) Finalize() error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	var err error
+	for _, connection := range a.connections {
+		// the socket is closed directly, because the mutex is already locked
+		if e := connection.CanSystemDevicer.Close(); e != nil {
+			err = multierror.Append(err, e)
+		}
+	}
+	a.connections = nil
+
+	if a.socket != nil {
+		if e := a.socket.Close(); e != nil {
+			err = multierror.Append(err, e)
+		}
+		a.socket = nil
+	}
+	return err
+}
+
+// GetCanConnection returns a new raw socket, which receives all frames of the bus until filters are applied.
+// Implements the interface can.Connector.
+func (a *Adaptor) GetCanConnection() (can.Connection, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.socket == nil {
+		return nil, fmt.Errorf("not connected")
+	}
+
+	socket, err := a.sys.NewCanSocket(a.iface, a.cfg.fdFrames)
+	if err != nil {
+		return nil, err
+	}
+
+	connection := &canConnection{CanSystemDevicer: socket, adaptor: a}
+	a.connections = append(a.connections, connection)
+	return connection, nil
+}
+
+// WriteFrame sends the given frame by the socket of the adaptor.
+func (a *Adaptor) WriteFrame(frame gobot.CanFrame) error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.socket == nil {
+		return fmt.Errorf("not connected")
+	}
+	return a.socket.WriteFrame(frame)
+}
+
+// canConnection removes itself from the adaptor on close, so the connection of a halted driver is not closed again
+// on Finalize()
+type canConnection struct {
+	gobot.CanSystemDevicer
+	adaptor *Adaptor
+}
+
+func (c *canConnection) Close() error {
+	c.adaptor.mutex.Lock()
+	for i, connection := range c.adaptor.connections {
+		if connection == c {
+			c.adaptor.connections = append(c.adaptor.connections[:i], c.adaptor.connections[i+1:]...)
+			break
+		}
+	}
+	c.adaptor.mutex.Unlock()
+
+	return c.CanSystemDevicer.Close()
+}
+
+func (o fdFramesOption) String() string {
+	return "CAN FD frames option for SocketCAN"
+}
+
+func (o fdFramesOption) apply(cfg *configuration) {
+	cfg.fdFrames = bool(o)
+}
@@ -0,0 +1,117 @@
+package socketcan
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/drivers/can"
+	"gobot.io/x/gobot/v2/system"
+)
+
+// make sure that this Adaptor fulfills all the required interfaces
+var (
+	_ gobot.Adaptor = (*Adaptor)(nil)
+	_ can.Connector = (*Adaptor)(nil)
+)
+
+func initTestAdaptorWithMockedSocket(opts ...optionApplier) (*Adaptor, *system.MockCanSocketAccess) {
+	a := NewAdaptor("vcan0", opts...)
+	bus := a.sys.UseMockCanSocket()
+	return a, bus
+}
+
+func TestNewAdaptor(t *testing.T) {
+	// arrange & act
+	a := NewAdaptor("can0", WithFDFrames())
+	// assert
+	assert.Contains(t, a.Name(), "SocketCAN")
+	assert.Equal(t, "can0", a.Interface())
+	assert.Equal(t, &configuration{fdFrames: true}, a.cfg)
+	a.SetName("motors")
+	assert.Equal(t, "motors", a.Name())
+}
+
+func TestConnectFinalize(t *testing.T) {
+	// arrange
+	a, bus := initTestAdaptorWithMockedSocket()
+	_, err := a.GetCanConnection()
+	require.EqualError(t, err, "not connected")
+	require.EqualError(t, a.WriteFrame(gobot.CanFrame{ID: 0x1}), "not connected")
+	// act
+	require.NoError(t, a.Connect())
+	c1, err := a.GetCanConnection()
+	require.NoError(t, err)
+	_, err = a.GetCanConnection()
+	require.NoError(t, err)
+	// assert
+	assert.Equal(t, 3, bus.OpenSockets())
+	// act: a closed connection is not closed again on finalize
+	require.NoError(t, c1.Close())
+	assert.Equal(t, 2, bus.OpenSockets())
+	require.NoError(t, a.Finalize())
+	// assert
+	assert.Equal(t, 0, bus.OpenSockets())
+	assert.Empty(t, a.connections)
+	require.NoError(t, a.Finalize())
+}
+
+func TestConnect_error(t *testing.T) {
+	// arrange
+	a, bus := initTestAdaptorWithMockedSocket()
+	bus.CreateError = true
+	// act
+	err := a.Connect()
+	// assert
+	require.EqualError(t, err, "error while open CAN socket in mock")
+}
+
+func TestWriteFrame(t *testing.T) {
+	// arrange
+	a, bus := initTestAdaptorWithMockedSocket()
+	require.NoError(t, a.Connect())
+	c, err := a.GetCanConnection()
+	require.NoError(t, err)
+	// act
+	require.NoError(t, a.WriteFrame(gobot.CanFrame{ID: 0x123, Data: []byte{0x11}}))
+	require.NoError(t, bus.SimulateReceive("vcan0", gobot.CanFrame{ID: 0x124}))
+	// assert: the connection receives the sent frame, the socket of the adaptor receives nothing
+	frame, err := c.ReadFrame()
+	require.NoError(t, err)
+	assert.Equal(t, uint32(0x123), frame.ID)
+	frame, err = c.ReadFrame()
+	require.NoError(t, err)
+	assert.Equal(t, uint32(0x124), frame.ID)
+	assert.Equal(t, []gobot.CanFrame{{ID: 0x123, Data: []byte{0x11}}}, bus.Written())
+	require.EqualError(t, a.WriteFrame(gobot.CanFrame{ID: 0x125, FD: true}),
+		"CAN interface 'vcan0': CAN FD frames are not enabled")
+	require.NoError(t, a.Finalize())
+}
+
+func TestFrameDriverWithAdaptor(t *testing.T) {
+	// arrange
+	a, bus := initTestAdaptorWithMockedSocket(WithFDFrames())
+	require.NoError(t, a.Connect())
+	d := can.NewFrameDriver(a, can.WithFilters(gobot.CanFilter{ID: 0x180, Mask: 0x7F0}))
+	frames := make(chan gobot.CanFrame, 10)
+	_ = d.On(d.Event(can.Frame), func(data interface{}) { frames <- data.(gobot.CanFrame) }) //nolint:forcetypeassert
+	require.NoError(t, d.Start())
+	// act
+	require.NoError(t, bus.SimulateReceive("vcan0", gobot.CanFrame{ID: 0x200}))
+	require.NoError(t, bus.SimulateReceive("vcan0", gobot.CanFrame{ID: 0x181, FD: true, Data: make([]byte, 12)}))
+	// assert
+	select {
+	case frame := <-frames:
+		assert.Equal(t, uint32(0x181), frame.ID)
+		assert.True(t, frame.FD)
+		assert.Len(t, frame.Data, 12)
+	case <-time.After(time.Second):
+		require.Fail(t, "frame event was not published")
+	}
+	require.NoError(t, d.Halt())
+	require.NoError(t, a.Finalize())
+	assert.Equal(t, 0, bus.OpenSockets())
+}
@@ -1,8 +1,6 @@
 package sphero
 
 import (
-	"errors"
-	"io"
 	"strings"
 	"testing"
 
@@ -10,50 +8,16 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/platforms/serial"
+	"gobot.io/x/gobot/v2/system/serialport"
 )
 
 var _ gobot.Adaptor = (*Adaptor)(nil)
 
-type nullReadWriteCloser struct {
-	testAdaptorRead  func(p []byte) (int, error)
-	testAdaptorWrite func(b []byte) (int, error)
-	testAdaptorClose func() error
-}
-
-func (n *nullReadWriteCloser) Write(p []byte) (int, error) {
-	return n.testAdaptorWrite(p)
-}
-
-func (n *nullReadWriteCloser) Read(b []byte) (int, error) {
-	return n.testAdaptorRead(b)
-}
-
-func (n *nullReadWriteCloser) Close() error {
-	return n.testAdaptorClose()
-}
-
-func NewNullReadWriteCloser() *nullReadWriteCloser {
-	return &nullReadWriteCloser{
-		testAdaptorRead: func(p []byte) (int, error) {
-			return len(p), nil
-		},
-		testAdaptorWrite: func(b []byte) (int, error) {
-			return len(b), nil
-		},
-		testAdaptorClose: func() error {
-			return nil
-		},
-	}
-}
-
-func initTestSpheroAdaptor() (*Adaptor, *nullReadWriteCloser) {
+func initTestSpheroAdaptor() (*Adaptor, *serialport.MockAccess) {
 	a := NewAdaptor("/dev/null")
-	rwc := NewNullReadWriteCloser()
-
-	a.connect = func(string) (io.ReadWriteCloser, error) {
-		return rwc, nil
-	}
-	return a, rwc
+	spa := a.sys.UseMock()
+	return a, spa
 }
 
 func TestSpheroAdaptorName(t *testing.T) {
@@ -72,35 +36,32 @@ func TestSpheroAdaptor(t *testing.T) {
 func TestSpheroAdaptorReconnect(t *testing.T) {
 	a, _ := initTestSpheroAdaptor()
 	_ = a.Connect()
-	assert.True(t, a.connected)
+	assert.True(t, a.IsConnected())
 	_ = a.Reconnect()
-	assert.True(t, a.connected)
+	assert.True(t, a.IsConnected())
 	_ = a.Disconnect()
-	assert.False(t, a.connected)
+	assert.False(t, a.IsConnected())
 	_ = a.Reconnect()
-	assert.True(t, a.connected)
+	assert.True(t, a.IsConnected())
 }
 
 func TestSpheroAdaptorFinalize(t *testing.T) {
-	a, rwc := initTestSpheroAdaptor()
+	a, spa := initTestSpheroAdaptor()
 	_ = a.Connect()
 	require.NoError(t, a.Finalize())
 
-	rwc.testAdaptorClose = func() error {
-		return errors.New("close error")
-	}
-
-	a.connected = true
-	require.ErrorContains(t, a.Finalize(), "close error")
+	require.NoError(t, a.Connect())
+	spa.SetCloseError(true)
+	require.ErrorContains(t, a.Finalize(), "error while closing serial port in mock")
 }
 
 func TestSpheroAdaptorConnect(t *testing.T) {
-	a, _ := initTestSpheroAdaptor()
+	a := NewAdaptor("/dev/null", serial.WithBaudRate(57600))
+	spa := a.sys.UseMock()
 	require.NoError(t, a.Connect())
+	assert.Equal(t, 57600, spa.BaudRate())
+	require.NoError(t, a.Finalize())
 
-	a.connect = func(string) (io.ReadWriteCloser, error) {
-		return nil, errors.New("connect error")
-	}
-
-	require.ErrorContains(t, a.Connect(), "connect error")
+	spa.CreateError = true
+	require.ErrorContains(t, a.Connect(), "error while open serial port in mock")
 }
@@ -233,7 +233,7 @@ func (s *SpheroDriver) Start() error {
 // Halt halts the SpheroDriver and sends a SpheroDriver.Stop command to the Sphero.
 // Returns true on successful halt.
 func (s *SpheroDriver) Halt() error {
-	if s.adaptor().connected {
+	if s.adaptor().IsConnected() {
 		gobot.Every(10*time.Millisecond, func() {
 			s.Stop()
 		})
@@ -423,7 +423,7 @@ func (s *SpheroDriver) write(packet *packet) error {
 	defer s.mtx.Unlock()
 	buf := append(packet.header, packet.body...)
 	buf = append(buf, packet.checksum)
-	length, err := s.adaptor().sp.Write(buf)
+	length, err := s.adaptor().SerialWrite(buf)
 	if err != nil {
 		return err
 	}
@@ -462,7 +462,7 @@ func (s *SpheroDriver) readNextChunk(length int) []uint8 {
 
 	for bytesRead < length {
 		time.Sleep(1 * time.Millisecond)
-		n, err := s.adaptor().sp.Read(read[bytesRead:])
+		n, err := s.adaptor().SerialRead(read[bytesRead:])
 		if err != nil {
 			return nil
 		}
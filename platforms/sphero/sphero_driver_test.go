@@ -92,7 +92,7 @@ func TestSpheroDriverStart(t *testing.T) {
 
 func TestSpheroDriverHalt(t *testing.T) {
 	d := initTestSpheroDriver()
-	d.adaptor().connected = true
+	require.True(t, d.adaptor().IsConnected())
 	require.NoError(t, d.Halt())
 }
 
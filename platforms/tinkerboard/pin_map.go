@@ -1,5 +1,7 @@
 package tinkerboard
 
+import "gobot.io/x/gobot/v2"
+
 // notes for character device
 // pins: A=0+Nr, B=8+Nr, C=16+Nr
 // tested: armbian Linux, OK: work as input and output, IN: work only as input
@@ -46,3 +48,15 @@ var analogPinDefinitions = map[string]analogPinDefinition{
 	"thermal_zone0": {path: "/sys/class/thermal/thermal_zone0/temp", r: true, w: false, bufLen: 7},
 	"thermal_zone1": {path: "/sys/class/thermal/thermal_zone1/temp", r: true, w: false, bufLen: 7},
 }
+
+// busPinDefinitions contains the header pins of the hardware buses, which are claimed in the pin registry
+var busPinDefinitions = map[string]map[int][]string{
+	gobot.PinFunctionI2c: {
+		1: {"3", "5"},   // I2C1 SDA, SCL
+		4: {"27", "28"}, // I2C4 SDA, SCL
+	},
+	gobot.PinFunctionSpi: {
+		0: {"13", "15", "11", "29", "31"}, // SPI0 TXD, RXD, CLK, CSN0, CSN1
+		2: {"19", "21", "23", "24", "26"}, // SPI2 TXD, RXD, CLK, CSN0, CSN1
+	},
+}
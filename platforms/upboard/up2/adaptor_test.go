@@ -24,6 +24,7 @@ var (
 	_ gobot.PWMPinnerProvider     = (*Adaptor)(nil)
 	_ gpio.DigitalReader          = (*Adaptor)(nil)
 	_ gpio.DigitalWriter          = (*Adaptor)(nil)
+	_ gpio.SysLedOpener           = (*Adaptor)(nil)
 	_ gpio.PwmWriter              = (*Adaptor)(nil)
 	_ gpio.ServoWriter            = (*Adaptor)(nil)
 	_ i2c.Connector               = (*Adaptor)(nil)
@@ -71,6 +72,7 @@ func initTestAdaptorWithMockedFilesystem(mockPaths []string) (*Adaptor, *system.
 func TestName(t *testing.T) {
 	a := NewAdaptor()
 	assert.True(t, strings.HasPrefix(a.Name(), "UP2"))
+	assert.Equal(t, []string{"3", "5"}, a.BusPins(gobot.PinFunctionI2c, 5))
 	a.SetName("NewName")
 	assert.Equal(t, "NewName", a.Name())
 }
@@ -1,5 +1,7 @@
 package up2
 
+import "gobot.io/x/gobot/v2"
+
 var fixedPins = map[string]sysfsPin{
 	"7": {
 		pin:    462, // GPIO4
@@ -46,3 +48,14 @@ var fixedPins = map[string]sysfsPin{
 		pwmPin: -1,
 	},
 }
+
+// busPinDefinitions contains the header pins of the hardware buses, which are claimed in the pin registry
+var busPinDefinitions = map[string]map[int][]string{
+	gobot.PinFunctionI2c: {
+		5: {"3", "5"},   // I2C0 SDA, SCL
+		6: {"27", "28"}, // I2C1 SDA, SCL
+	},
+	gobot.PinFunctionSpi: {
+		1: {"19", "21", "23", "24", "26"}, // SPI2 MOSI, MISO, CLK, CS0, CS1
+	},
+}
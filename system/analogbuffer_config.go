@@ -0,0 +1,81 @@
+package system
+
+import (
+	"gobot.io/x/gobot/v2"
+)
+
+const analogBufferDefaultLength = 256 // scans
+
+type analogBufferConfig struct {
+	samplingFrequency int // 0 keeps the current setting of the device
+	length            int
+	trigger           string // empty keeps the current trigger of the device
+	timestamp         bool
+}
+
+func newAnalogBufferConfig(options ...func(gobot.AnalogBufferOptioner) bool) *analogBufferConfig {
+	cfg := &analogBufferConfig{length: analogBufferDefaultLength}
+	for _, option := range options {
+		option(cfg)
+	}
+	return cfg
+}
+
+// WithAnalogBufferSamplingFrequency sets the sampling frequency in Hz. Without this option, the current setting of
+// the device is used.
+func WithAnalogBufferSamplingFrequency(frequency int) func(gobot.AnalogBufferOptioner) bool {
+	return func(o gobot.AnalogBufferOptioner) bool { return o.SetSamplingFrequency(frequency) }
+}
+
+// WithAnalogBufferLength substitutes the default buffer length of 256 scans.
+func WithAnalogBufferLength(length int) func(gobot.AnalogBufferOptioner) bool {
+	return func(o gobot.AnalogBufferOptioner) bool { return o.SetBufferLength(length) }
+}
+
+// WithAnalogBufferTrigger sets the trigger to use, e.g. "hrtimer0" or "sysfstrig1". Some devices sample
+// continuously and do not need a trigger (e.g. the ADC of the BeagleBone).
+func WithAnalogBufferTrigger(name string) func(gobot.AnalogBufferOptioner) bool {
+	return func(o gobot.AnalogBufferOptioner) bool { return o.SetTrigger(name) }
+}
+
+// WithAnalogBufferTimestamp activates the capture of the timestamp for each scan.
+func WithAnalogBufferTimestamp() func(gobot.AnalogBufferOptioner) bool {
+	return func(o gobot.AnalogBufferOptioner) bool { return o.SetTimestamp() }
+}
+
+// SetSamplingFrequency sets the sampling frequency. The function is intended to use by
+// WithAnalogBufferSamplingFrequency().
+func (c *analogBufferConfig) SetSamplingFrequency(frequency int) bool {
+	if c.samplingFrequency == frequency {
+		return false
+	}
+	c.samplingFrequency = frequency
+	return true
+}
+
+// SetBufferLength sets the buffer length. The function is intended to use by WithAnalogBufferLength().
+func (c *analogBufferConfig) SetBufferLength(length int) bool {
+	if c.length == length {
+		return false
+	}
+	c.length = length
+	return true
+}
+
+// SetTrigger sets the trigger name. The function is intended to use by WithAnalogBufferTrigger().
+func (c *analogBufferConfig) SetTrigger(name string) bool {
+	if c.trigger == name {
+		return false
+	}
+	c.trigger = name
+	return true
+}
+
+// SetTimestamp activates the timestamp capture. The function is intended to use by WithAnalogBufferTimestamp().
+func (c *analogBufferConfig) SetTimestamp() bool {
+	if c.timestamp {
+		return false
+	}
+	c.timestamp = true
+	return true
+}
@@ -0,0 +1,352 @@
+package system
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	multierror "github.com/hashicorp/go-multierror"
+
+	"gobot.io/x/gobot/v2"
+)
+
+// Linux Industrial I/O buffer interface.
+//
+//	https://docs.kernel.org/driver-api/iio/buffers.html
+//	https://www.kernel.org/doc/Documentation/ABI/testing/sysfs-bus-iio
+const (
+	iioCharDevicePath    = "/dev"
+	iioScanElementsDir   = "scan_elements"
+	iioBufferDir         = "buffer"
+	iioTimestampChannel  = "in_timestamp"
+	iioCurrentTriggerRel = "trigger/current_trigger"
+)
+
+var iioScanTypePattern = regexp.MustCompile(`^(be|le):(s|u)(\d+)/(\d+)(?:X(\d+))?>>(\d+)$`)
+
+// iioScanElement contains the layout of a channel in a scan, read from the "scan_elements" folder
+type iioScanElement struct {
+	name         string
+	index        int
+	bigEndian    bool
+	signed       bool
+	realBits     int
+	storageBytes int
+	shift        int
+	offset       int // position in the scan, calculated from the other enabled elements
+}
+
+// analogBufferIio is the implementation of a buffered analog input by the Linux IIO buffer interface
+type analogBufferIio struct {
+	devicePath string // e.g. /sys/bus/iio/devices/iio:device0
+	sfa        *sysfsFileAccess
+	cfg        *analogBufferConfig
+	channels   []string
+	elements   []*iioScanElement // in order of the given channels
+	timestamp  *iioScanElement
+	scanSize   int
+	file       File
+	mutex      sync.Mutex
+}
+
+// newAnalogBufferIio enables the given channels (e.g. "in_voltage0") of the given IIO device and the buffer. The
+// character device of the buffer is opened for reading.
+func newAnalogBufferIio(
+	sfa *sysfsFileAccess,
+	devicePath string,
+	channels []string,
+	options ...func(gobot.AnalogBufferOptioner) bool,
+) (*analogBufferIio, error) {
+	if len(channels) == 0 {
+		return nil, fmt.Errorf("at least one channel is needed for the analog buffer of '%s'", devicePath)
+	}
+
+	b := &analogBufferIio{
+		devicePath: devicePath,
+		sfa:        sfa,
+		cfg:        newAnalogBufferConfig(options...),
+		channels:   channels,
+	}
+
+	if b.cfg.length <= 0 {
+		return nil, fmt.Errorf("buffer length %d is not valid for '%s'", b.cfg.length, devicePath)
+	}
+
+	if err := b.enable(); err != nil {
+		_ = b.disable() // best effort cleanup
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// Channels returns the names of the channels, in the order of the values in each scan.
+func (b *analogBufferIio) Channels() []string {
+	return append([]string(nil), b.channels...)
+}
+
+// ReadScans blocks until scans are available and returns not more than the given count of scans.
+func (b *analogBufferIio) ReadScans(maxScans int) ([]gobot.AnalogScan, error) {
+	b.mutex.Lock()
+	file := b.file
+	b.mutex.Unlock()
+
+	if file == nil {
+		return nil, fmt.Errorf("the analog buffer of '%s' is closed", b.devicePath)
+	}
+
+	if maxScans <= 0 {
+		maxScans = 1
+	}
+
+	// the file is not locked here, to prevent a blocking read from blocking the close
+	buf := make([]byte, maxScans*b.scanSize)
+	n, err := file.Read(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	if n%b.scanSize != 0 {
+		return nil, fmt.Errorf("incomplete scan read from '%s' (%d bytes, scan size %d)", b.devicePath, n, b.scanSize)
+	}
+
+	scans := make([]gobot.AnalogScan, n/b.scanSize)
+	for i := range scans {
+		raw := buf[i*b.scanSize : (i+1)*b.scanSize]
+		scan := gobot.AnalogScan{Values: make([]int, len(b.elements))}
+		for j, e := range b.elements {
+			scan.Values[j] = int(e.decode(raw))
+		}
+		if b.timestamp != nil {
+			scan.Timestamp = time.Duration(b.timestamp.decode(raw))
+		}
+		scans[i] = scan
+	}
+
+	return scans, nil
+}
+
+// Close disables the buffer and all enabled channels and closes the character device. Calling Close twice is
+// possible.
+func (b *analogBufferIio) Close() error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.file == nil {
+		return nil
+	}
+
+	var err error
+	if e := b.file.Close(); e != nil {
+		err = multierror.Append(err, e)
+	}
+	b.file = nil
+
+	if e := b.disable(); e != nil {
+		err = multierror.Append(err, e)
+	}
+
+	return err
+}
+
+func (b *analogBufferIio) enable() error {
+	scanElementsPath := path.Join(b.devicePath, iioScanElementsDir)
+
+	for _, channel := range b.channels {
+		e, err := b.enableScanElement(scanElementsPath, channel)
+		if err != nil {
+			return err
+		}
+		b.elements = append(b.elements, e)
+	}
+
+	if b.cfg.timestamp {
+		e, err := b.enableScanElement(scanElementsPath, iioTimestampChannel)
+		if err != nil {
+			return err
+		}
+		b.timestamp = e
+	}
+
+	b.calculateLayout()
+
+	if b.cfg.samplingFrequency > 0 {
+		if err := b.sfa.writeInteger(b.samplingFrequencyPath(), b.cfg.samplingFrequency); err != nil {
+			return err
+		}
+	}
+
+	if b.cfg.trigger != "" {
+		triggerPath := path.Join(b.devicePath, iioCurrentTriggerRel)
+		if err := b.sfa.write(triggerPath, []byte(b.cfg.trigger)); err != nil {
+			return err
+		}
+	}
+
+	bufferPath := path.Join(b.devicePath, iioBufferDir)
+	if err := b.sfa.writeInteger(path.Join(bufferPath, "length"), b.cfg.length); err != nil {
+		return err
+	}
+
+	if err := b.sfa.writeInteger(path.Join(bufferPath, "enable"), 1); err != nil {
+		return err
+	}
+
+	file, err := b.sfa.fs.openFile(path.Join(iioCharDevicePath, path.Base(b.devicePath)), os.O_RDONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	b.file = file
+
+	if systemDebug {
+		fmt.Printf("analog buffer of '%s' enabled (channels: %v, scan size: %d)\n", b.devicePath, b.channels,
+			b.scanSize)
+	}
+
+	return nil
+}
+
+func (b *analogBufferIio) disable() error {
+	var err error
+	if e := b.sfa.writeInteger(path.Join(b.devicePath, iioBufferDir, "enable"), 0); e != nil {
+		err = multierror.Append(err, e)
+	}
+
+	for _, e := range b.enabledElements() {
+		enablePath := path.Join(b.devicePath, iioScanElementsDir, e.name+"_en")
+		if werr := b.sfa.writeInteger(enablePath, 0); werr != nil {
+			err = multierror.Append(err, werr)
+		}
+	}
+
+	return err
+}
+
+func (b *analogBufferIio) enableScanElement(scanElementsPath string, name string) (*iioScanElement, error) {
+	index, err := b.sfa.readInteger(path.Join(scanElementsPath, name+"_index"))
+	if err != nil {
+		return nil, err
+	}
+
+	scanType, err := b.sfa.read(path.Join(scanElementsPath, name+"_type"))
+	if err != nil {
+		return nil, err
+	}
+
+	e, err := parseIioScanType(name, strings.TrimSpace(string(scanType)))
+	if err != nil {
+		return nil, err
+	}
+	e.index = index
+
+	if err := b.sfa.writeInteger(path.Join(scanElementsPath, name+"_en"), 1); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// calculateLayout calculates the position of each element in the scan. The elements are ordered by the index and
+// each element is aligned to its own storage size. The scan size is aligned to the largest storage size.
+func (b *analogBufferIio) calculateLayout() {
+	elements := b.enabledElements()
+	sort.Slice(elements, func(i, j int) bool { return elements[i].index < elements[j].index })
+
+	var offset, maxStorageBytes int
+	for _, e := range elements {
+		offset = alignIio(offset, e.storageBytes)
+		e.offset = offset
+		offset += e.storageBytes
+		if e.storageBytes > maxStorageBytes {
+			maxStorageBytes = e.storageBytes
+		}
+	}
+
+	b.scanSize = alignIio(offset, maxStorageBytes)
+}
+
+func (b *analogBufferIio) enabledElements() []*iioScanElement {
+	elements := append([]*iioScanElement(nil), b.elements...)
+	if b.timestamp != nil {
+		elements = append(elements, b.timestamp)
+	}
+	return elements
+}
+
+func (b *analogBufferIio) samplingFrequencyPath() string {
+	// the name of the attribute depends on the kernel driver
+	for _, name := range []string{"sampling_frequency", "in_voltage_sampling_frequency"} {
+		p := path.Join(b.devicePath, name)
+		if _, err := b.sfa.fs.stat(p); err == nil {
+			return p
+		}
+	}
+	return path.Join(b.devicePath, "sampling_frequency")
+}
+
+// parseIioScanType parses the type description of a scan element, e.g. "le:s12/16>>4"
+func parseIioScanType(name string, scanType string) (*iioScanElement, error) {
+	m := iioScanTypePattern.FindStringSubmatch(scanType)
+	if m == nil {
+		return nil, fmt.Errorf("unknown type '%s' of scan element '%s'", scanType, name)
+	}
+
+	realBits, _ := strconv.Atoi(m[3])
+	storageBits, _ := strconv.Atoi(m[4])
+	shift, _ := strconv.Atoi(m[6])
+
+	if m[5] != "" && m[5] != "1" {
+		return nil, fmt.Errorf("repeated values (type '%s') of scan element '%s' are not supported", scanType, name)
+	}
+
+	if storageBits%8 != 0 || storageBits == 0 || storageBits > 64 || realBits > storageBits {
+		return nil, fmt.Errorf("storage bits of type '%s' of scan element '%s' are not supported", scanType, name)
+	}
+
+	return &iioScanElement{
+		name:         name,
+		bigEndian:    m[1] == "be",
+		signed:       m[2] == "s",
+		realBits:     realBits,
+		storageBytes: storageBits / 8,
+		shift:        shift,
+	}, nil
+}
+
+// decode extracts the value of the element from the given raw scan
+func (e *iioScanElement) decode(scan []byte) int64 {
+	data := scan[e.offset : e.offset+e.storageBytes]
+
+	var raw uint64
+	for i := range data {
+		if e.bigEndian {
+			raw = raw<<8 | uint64(data[i])
+		} else {
+			raw = raw<<8 | uint64(data[len(data)-1-i])
+		}
+	}
+
+	raw >>= uint(e.shift)
+	if e.realBits < 64 {
+		raw &= 1<<uint(e.realBits) - 1
+	}
+
+	if e.signed && e.realBits < 64 && raw&(1<<uint(e.realBits-1)) != 0 {
+		return int64(raw) - 1<<uint(e.realBits)
+	}
+
+	return int64(raw)
+}
+
+func alignIio(offset, size int) int {
+	if size == 0 {
+		return offset
+	}
+	return (offset + size - 1) / size * size
+}
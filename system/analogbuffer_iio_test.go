@@ -0,0 +1,262 @@
+package system
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+)
+
+// make sure that this implementation fulfills all the required interfaces
+var _ gobot.AnalogBufferSystemDevicer = (*analogBufferIio)(nil)
+
+const (
+	iioTestDevicePath   = "/sys/bus/iio/devices/iio:device0"
+	iioTestScanElements = iioTestDevicePath + "/scan_elements/"
+	iioTestCharDevice   = "/dev/iio:device0"
+)
+
+func initTestAnalogBufferIioFs(t *testing.T) (*Accesser, *MockFilesystem) {
+	t.Helper()
+	a := NewAccesser()
+	fs := a.UseMockFilesystem([]string{
+		iioTestScanElements + "in_voltage0_en",
+		iioTestScanElements + "in_voltage0_index",
+		iioTestScanElements + "in_voltage0_type",
+		iioTestScanElements + "in_voltage1_en",
+		iioTestScanElements + "in_voltage1_index",
+		iioTestScanElements + "in_voltage1_type",
+		iioTestScanElements + "in_timestamp_en",
+		iioTestScanElements + "in_timestamp_index",
+		iioTestScanElements + "in_timestamp_type",
+		iioTestDevicePath + "/buffer/length",
+		iioTestDevicePath + "/buffer/enable",
+		iioTestDevicePath + "/sampling_frequency",
+		iioTestDevicePath + "/trigger/current_trigger",
+		iioTestCharDevice,
+	})
+	fs.Files[iioTestScanElements+"in_voltage0_index"].Contents = "0\n"
+	fs.Files[iioTestScanElements+"in_voltage0_type"].Contents = "le:u12/16>>0\n"
+	fs.Files[iioTestScanElements+"in_voltage1_index"].Contents = "1\n"
+	fs.Files[iioTestScanElements+"in_voltage1_type"].Contents = "be:s12/16>>4\n"
+	fs.Files[iioTestScanElements+"in_timestamp_index"].Contents = "2\n"
+	fs.Files[iioTestScanElements+"in_timestamp_type"].Contents = "le:s64/64>>0\n"
+	return a, fs
+}
+
+func TestNewAnalogBuffer(t *testing.T) {
+	tests := map[string]struct {
+		channels      []string
+		options       []func(gobot.AnalogBufferOptioner) bool
+		wantScanSize  int
+		wantTimestamp string
+		wantFrequency string
+		wantTrigger   string
+		wantLength    string
+		wantCleanup   bool
+		wantErr       string
+	}{
+		"one_channel": {
+			channels:     []string{"in_voltage1"},
+			wantScanSize: 2,
+			wantLength:   "256",
+		},
+		"all_options": {
+			channels: []string{"in_voltage1", "in_voltage0"},
+			options: []func(gobot.AnalogBufferOptioner) bool{
+				WithAnalogBufferSamplingFrequency(10000),
+				WithAnalogBufferLength(1024),
+				WithAnalogBufferTrigger("hrtimer0"),
+				WithAnalogBufferTimestamp(),
+			},
+			wantScanSize:  16,
+			wantTimestamp: "1",
+			wantFrequency: "10000",
+			wantTrigger:   "hrtimer0",
+			wantLength:    "1024",
+		},
+		"error_no_channel": {
+			wantErr: "at least one channel is needed for the analog buffer of '/sys/bus/iio/devices/iio:device0'",
+		},
+		"error_length": {
+			channels: []string{"in_voltage0"},
+			options:  []func(gobot.AnalogBufferOptioner) bool{WithAnalogBufferLength(0)},
+			wantErr:  "buffer length 0 is not valid for '/sys/bus/iio/devices/iio:device0'",
+		},
+		"error_unknown_channel": {
+			channels:    []string{"in_voltage0", "in_voltage2"},
+			wantCleanup: true,
+			wantErr:     "in_voltage2_index: no such file",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a, fs := initTestAnalogBufferIioFs(t)
+			// act
+			dev, err := a.NewAnalogBuffer(iioTestDevicePath, tc.channels, tc.options...)
+			// assert
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				assert.Nil(t, dev)
+				if tc.wantCleanup {
+					assert.Equal(t, "0", fs.Files[iioTestDevicePath+"/buffer/enable"].Contents)
+					assert.Equal(t, "0", fs.Files[iioTestScanElements+"in_voltage0_en"].Contents)
+				}
+				return
+			}
+			require.NoError(t, err)
+			//nolint:forcetypeassert // ok here
+			b := dev.(*analogBufferIio)
+			assert.Equal(t, tc.channels, b.Channels())
+			assert.Equal(t, tc.wantScanSize, b.scanSize)
+			assert.Equal(t, "1", fs.Files[iioTestDevicePath+"/buffer/enable"].Contents)
+			assert.Equal(t, tc.wantLength, fs.Files[iioTestDevicePath+"/buffer/length"].Contents)
+			assert.Equal(t, tc.wantFrequency, fs.Files[iioTestDevicePath+"/sampling_frequency"].Contents)
+			assert.Equal(t, tc.wantTrigger, fs.Files[iioTestDevicePath+"/trigger/current_trigger"].Contents)
+			assert.Equal(t, tc.wantTimestamp, fs.Files[iioTestScanElements+"in_timestamp_en"].Contents)
+			for _, ch := range tc.channels {
+				assert.Equal(t, "1", fs.Files[iioTestScanElements+ch+"_en"].Contents)
+			}
+			assert.True(t, fs.Files[iioTestCharDevice].Opened)
+		})
+	}
+}
+
+func TestAnalogBufferReadScans(t *testing.T) {
+	tests := map[string]struct {
+		channels  []string
+		options   []func(gobot.AnalogBufferOptioner) bool
+		contents  []byte
+		maxScans  int
+		wantScans []gobot.AnalogScan
+		wantErr   string
+	}{
+		"two_scans_unsigned": {
+			channels: []string{"in_voltage0"},
+			contents: []byte{0x34, 0xF2, 0xFF, 0x0F},
+			maxScans: 4,
+			wantScans: []gobot.AnalogScan{
+				{Values: []int{0x234}},
+				{Values: []int{0xFFF}},
+			},
+		},
+		"max_scans_limits": {
+			channels:  []string{"in_voltage0"},
+			contents:  []byte{0x34, 0x02, 0xFF, 0x0F},
+			maxScans:  1,
+			wantScans: []gobot.AnalogScan{{Values: []int{0x234}}},
+		},
+		"signed_big_endian_shifted": {
+			channels:  []string{"in_voltage1"},
+			contents:  []byte{0xFF, 0xF0},
+			maxScans:  1,
+			wantScans: []gobot.AnalogScan{{Values: []int{-1}}},
+		},
+		"channel_order_and_timestamp": {
+			channels: []string{"in_voltage1", "in_voltage0"},
+			options:  []func(gobot.AnalogBufferOptioner) bool{WithAnalogBufferTimestamp()},
+			contents: []byte{
+				0x01, 0x00, // in_voltage0
+				0x00, 0x20, // in_voltage1
+				0x00, 0x00, 0x00, 0x00, // padding
+				0xE8, 0x03, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // timestamp
+			},
+			maxScans:  1,
+			wantScans: []gobot.AnalogScan{{Values: []int{2, 1}, Timestamp: 1000 * time.Nanosecond}},
+		},
+		"error_incomplete": {
+			channels: []string{"in_voltage0"},
+			contents: []byte{0x01, 0x02, 0x03},
+			maxScans: 2,
+			wantErr:  "incomplete scan read from '/sys/bus/iio/devices/iio:device0' (3 bytes, scan size 2)",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a, fs := initTestAnalogBufferIioFs(t)
+			dev, err := a.NewAnalogBuffer(iioTestDevicePath, tc.channels, tc.options...)
+			require.NoError(t, err)
+			fs.Files[iioTestCharDevice].Contents = string(tc.contents)
+			// act
+			scans, err := dev.ReadScans(tc.maxScans)
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				assert.Nil(t, scans)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantScans, scans)
+		})
+	}
+}
+
+func TestAnalogBufferClose(t *testing.T) {
+	// arrange
+	a, fs := initTestAnalogBufferIioFs(t)
+	dev, err := a.NewAnalogBuffer(iioTestDevicePath, []string{"in_voltage0"}, WithAnalogBufferTimestamp())
+	require.NoError(t, err)
+	// act
+	err = dev.Close()
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, "0", fs.Files[iioTestDevicePath+"/buffer/enable"].Contents)
+	assert.Equal(t, "0", fs.Files[iioTestScanElements+"in_voltage0_en"].Contents)
+	assert.Equal(t, "0", fs.Files[iioTestScanElements+"in_timestamp_en"].Contents)
+	assert.True(t, fs.Files[iioTestCharDevice].Closed)
+	// assert close twice and read after close
+	require.NoError(t, dev.Close())
+	_, err = dev.ReadScans(1)
+	require.EqualError(t, err, "the analog buffer of '/sys/bus/iio/devices/iio:device0' is closed")
+}
+
+func TestParseIioScanType(t *testing.T) {
+	tests := map[string]struct {
+		scanType string
+		want     *iioScanElement
+		wantErr  string
+	}{
+		"le_unsigned": {
+			scanType: "le:u12/16>>0",
+			want:     &iioScanElement{name: "ch", realBits: 12, storageBytes: 2},
+		},
+		"be_signed_shifted": {
+			scanType: "be:s24/32>>8",
+			want:     &iioScanElement{name: "ch", bigEndian: true, signed: true, realBits: 24, storageBytes: 4, shift: 8},
+		},
+		"repeat_one": {
+			scanType: "le:s16/16X1>>0",
+			want:     &iioScanElement{name: "ch", signed: true, realBits: 16, storageBytes: 2},
+		},
+		"error_unknown": {
+			scanType: "xx:u12/16>>0",
+			wantErr:  "unknown type 'xx:u12/16>>0' of scan element 'ch'",
+		},
+		"error_repeat": {
+			scanType: "le:s16/16X3>>0",
+			wantErr:  "repeated values (type 'le:s16/16X3>>0') of scan element 'ch' are not supported",
+		},
+		"error_storage": {
+			scanType: "le:u12/12>>0",
+			wantErr:  "storage bits of type 'le:u12/12>>0' of scan element 'ch' are not supported",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// act
+			got, err := parseIioScanType("ch", tc.scanType)
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,223 @@
+package system
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// BusTraceI2c marks a transaction on an i2c bus.
+	BusTraceI2c = "i2c"
+	// BusTraceSpi marks a transaction on a SPI bus.
+	BusTraceSpi = "spi"
+
+	// BusTraceRead marks a read transaction.
+	BusTraceRead = "read"
+	// BusTraceWrite marks a write transaction.
+	BusTraceWrite = "write"
+	// BusTraceTransfer marks a full duplex transaction (SPI).
+	BusTraceTransfer = "transfer"
+)
+
+// BusTransaction contains all information of a single transaction on a bus, recorded by the BusTracer.
+type BusTransaction struct {
+	Time         time.Time
+	Bus          string // BusTraceI2c or BusTraceSpi
+	Location     string // e.g. "/dev/i2c-1" or "/dev/spidev0.1"
+	Address      int    // the address of the i2c device, -1 for SPI
+	Register     int    // the register, -1 if the transaction has no explicit register
+	RegisterName string // decoded name of the register, empty if not known
+	Direction    string // BusTraceRead, BusTraceWrite or BusTraceTransfer
+	Data         []byte // the written or read data, for SPI the transmitted data
+	Rx           []byte // the received data of a SPI transfer
+	Duration     time.Duration
+	Err          error
+}
+
+type busTraceDevice struct {
+	location string
+	address  int
+}
+
+// BusTracer records the transactions of i2c and SPI buses. A bus is traced by wrapping its system device with
+// NewTracedI2cDevice() or NewTracedSpiDevice(), which is normally done by the platform adaptor, when the tracer is
+// given by an option. The recorded transactions can be written as text, as JSON or as value change dump (VCD), which
+// can be imported by waveform viewers and logic analyzer software with protocol decoders, e.g. PulseView (sigrok).
+type BusTracer struct {
+	mutex        sync.Mutex
+	maxCount     int
+	transactions []BusTransaction
+	namers       map[busTraceDevice]func(reg uint8) string
+	liveWriter   io.Writer
+	now          func() time.Time
+}
+
+// NewBusTracer creates a new tracer, which keeps the given count of the latest transactions. Zero or less means no
+// limit.
+func NewBusTracer(maxCount int) *BusTracer {
+	return &BusTracer{
+		maxCount: maxCount,
+		namers:   make(map[busTraceDevice]func(reg uint8) string),
+		now:      time.Now,
+	}
+}
+
+// SetRegisterNamer sets the function to decode the register names of the device with the given address on the
+// given bus location. For SPI devices use -1 as address, the first transmitted byte is used as register.
+func (t *BusTracer) SetRegisterNamer(location string, address int, namer func(reg uint8) string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.namers[busTraceDevice{location: location, address: address}] = namer
+}
+
+// SetLiveWriter sets a writer, which gets each transaction as a text line immediately, e.g. os.Stdout. Nil
+// deactivates the live output.
+func (t *BusTracer) SetLiveWriter(w io.Writer) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.liveWriter = w
+}
+
+// Transactions returns a copy of all recorded transactions.
+func (t *BusTracer) Transactions() []BusTransaction {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return append([]BusTransaction(nil), t.transactions...)
+}
+
+// Clear removes all recorded transactions.
+func (t *BusTracer) Clear() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.transactions = nil
+}
+
+// WriteText writes all recorded transactions as human readable text, one line per transaction.
+func (t *BusTracer) WriteText(w io.Writer) error {
+	for _, ta := range t.Transactions() {
+		if _, err := fmt.Fprintln(w, ta.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteJSON writes all recorded transactions as JSON array. The data is encoded as hex string and the duration is
+// given in nanoseconds.
+func (t *BusTracer) WriteJSON(w io.Writer) error {
+	type jsonTransaction struct {
+		Time         time.Time `json:"time"`
+		Bus          string    `json:"bus"`
+		Location     string    `json:"location"`
+		Address      int       `json:"address"`
+		Register     int       `json:"register"`
+		RegisterName string    `json:"registerName,omitempty"`
+		Direction    string    `json:"direction"`
+		Data         string    `json:"data"`
+		Rx           string    `json:"rx,omitempty"`
+		DurationNs   int64     `json:"durationNs"`
+		Error        string    `json:"error,omitempty"`
+	}
+
+	transactions := t.Transactions()
+	out := make([]jsonTransaction, len(transactions))
+	for i, ta := range transactions {
+		out[i] = jsonTransaction{
+			Time:         ta.Time,
+			Bus:          ta.Bus,
+			Location:     ta.Location,
+			Address:      ta.Address,
+			Register:     ta.Register,
+			RegisterName: ta.RegisterName,
+			Direction:    ta.Direction,
+			Data:         hex.EncodeToString(ta.Data),
+			Rx:           hex.EncodeToString(ta.Rx),
+			DurationNs:   ta.Duration.Nanoseconds(),
+		}
+		if ta.Err != nil {
+			out[i].Error = ta.Err.Error()
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+// WriteVCD writes all recorded transactions as value change dump (IEEE 1364). The waveforms of the bus lines are
+// synthesized with 100 kHz for i2c (SCL, SDA) and 1 MHz in mode 0 for SPI (SCLK, MOSI, MISO, CS). The timing
+// between the transactions is taken from the recording, as long as the synthesized waveforms do not overlap.
+func (t *BusTracer) WriteVCD(w io.Writer) error {
+	return writeBusTraceVCD(w, t.Transactions())
+}
+
+// String returns the transaction as human readable text.
+func (ta BusTransaction) String() string {
+	var sb strings.Builder
+	sb.WriteString(ta.Time.Format("15:04:05.000000"))
+	fmt.Fprintf(&sb, " %s %s", ta.Bus, ta.Location)
+	if ta.Address >= 0 {
+		fmt.Fprintf(&sb, " 0x%02X", ta.Address)
+	}
+	fmt.Fprintf(&sb, " %s", ta.Direction)
+	if ta.Register >= 0 {
+		fmt.Fprintf(&sb, " reg 0x%02X", ta.Register)
+	}
+	if ta.RegisterName != "" {
+		fmt.Fprintf(&sb, " (%s)", ta.RegisterName)
+	}
+	if ta.Direction == BusTraceTransfer {
+		fmt.Fprintf(&sb, " tx [% 02X] rx [% 02X]", ta.Data, ta.Rx)
+	} else {
+		fmt.Fprintf(&sb, " [% 02X]", ta.Data)
+	}
+	fmt.Fprintf(&sb, " %s", ta.Duration)
+	if ta.Err != nil {
+		fmt.Fprintf(&sb, " error: %v", ta.Err)
+	}
+	return sb.String()
+}
+
+// begin returns the start time of a new transaction.
+func (t *BusTracer) begin() time.Time {
+	return t.now()
+}
+
+// record finishes the transaction, which was started at the given time.
+func (t *BusTracer) record(start time.Time, ta BusTransaction) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	ta.Time = start
+	ta.Duration = t.now().Sub(start)
+	ta.Data = append([]byte(nil), ta.Data...)
+	if ta.Rx != nil {
+		ta.Rx = append([]byte(nil), ta.Rx...)
+	}
+
+	reg := ta.Register
+	if ta.Bus == BusTraceSpi && len(ta.Data) > 0 {
+		reg = int(ta.Data[0])
+	}
+	if namer, ok := t.namers[busTraceDevice{location: ta.Location, address: ta.Address}]; ok && reg >= 0 {
+		ta.RegisterName = namer(uint8(reg))
+	}
+
+	t.transactions = append(t.transactions, ta)
+	if t.maxCount > 0 && len(t.transactions) > t.maxCount {
+		t.transactions = t.transactions[len(t.transactions)-t.maxCount:]
+	}
+
+	if t.liveWriter != nil {
+		_, _ = fmt.Fprintln(t.liveWriter, ta.String())
+	}
+}
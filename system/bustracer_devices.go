@@ -0,0 +1,198 @@
+package system
+
+import (
+	"fmt"
+	"time"
+
+	"gobot.io/x/gobot/v2"
+)
+
+// i2cTracedDevice wraps an i2c system device and records all transactions by the tracer.
+type i2cTracedDevice struct {
+	bus      gobot.I2cSystemDevicer
+	location string
+	tracer   *BusTracer
+}
+
+// spiTracedDevice wraps a SPI system device and records all transactions by the tracer.
+type spiTracedDevice struct {
+	dev      gobot.SpiSystemDevicer
+	location string
+	tracer   *BusTracer
+}
+
+// NewTracedI2cDevice wraps the given i2c system device, so all transactions are recorded by the given tracer. The
+// location is used to identify the bus in the recording, e.g. "/dev/i2c-1".
+func NewTracedI2cDevice(bus gobot.I2cSystemDevicer, location string, tracer *BusTracer) gobot.I2cSystemDevicer {
+	return &i2cTracedDevice{bus: bus, location: location, tracer: tracer}
+}
+
+// NewTracedSpiDevice wraps the given SPI system device, so all transactions are recorded by the given tracer. The
+// location is used to identify the bus and chip in the recording, e.g. "/dev/spidev0.1".
+func NewTracedSpiDevice(dev gobot.SpiSystemDevicer, location string, tracer *BusTracer) gobot.SpiSystemDevicer {
+	return &spiTracedDevice{dev: dev, location: location, tracer: tracer}
+}
+
+// SetRegisterNamer implements gobot.BusRegisterNamer and forwards the namer to the tracer.
+func (d *i2cTracedDevice) SetRegisterNamer(address int, namer func(reg uint8) string) {
+	d.tracer.SetRegisterNamer(d.location, address, namer)
+}
+
+// Close implements gobot.I2cSystemDevicer and is not recorded.
+func (d *i2cTracedDevice) Close() error {
+	return d.bus.Close()
+}
+
+// ProbeAddress implements gobot.I2cProber, if the wrapped device does it. Probes are not recorded.
+func (d *i2cTracedDevice) ProbeAddress(address int) (bool, error) {
+	p, ok := d.bus.(gobot.I2cProber)
+	if !ok {
+		return false, fmt.Errorf("the i2c system device does not support probing of addresses")
+	}
+	return p.ProbeAddress(address)
+}
+
+// ReadByte implements gobot.I2cSystemDevicer.
+func (d *i2cTracedDevice) ReadByte(address int) (byte, error) {
+	start := d.tracer.begin()
+	val, err := d.bus.ReadByte(address)
+	d.record(start, address, -1, BusTraceRead, []byte{val}, err)
+	return val, err
+}
+
+// ReadByteData implements gobot.I2cSystemDevicer.
+func (d *i2cTracedDevice) ReadByteData(address int, reg uint8) (uint8, error) {
+	start := d.tracer.begin()
+	val, err := d.bus.ReadByteData(address, reg)
+	d.record(start, address, int(reg), BusTraceRead, []byte{val}, err)
+	return val, err
+}
+
+// ReadWordData implements gobot.I2cSystemDevicer.
+func (d *i2cTracedDevice) ReadWordData(address int, reg uint8) (uint16, error) {
+	start := d.tracer.begin()
+	val, err := d.bus.ReadWordData(address, reg)
+	d.record(start, address, int(reg), BusTraceRead, []byte{byte(val), byte(val >> 8)}, err)
+	return val, err
+}
+
+// ReadBlockData implements gobot.I2cSystemDevicer.
+func (d *i2cTracedDevice) ReadBlockData(address int, reg uint8, data []byte) error {
+	start := d.tracer.begin()
+	err := d.bus.ReadBlockData(address, reg, data)
+	d.record(start, address, int(reg), BusTraceRead, data, err)
+	return err
+}
+
+// WriteByte implements gobot.I2cSystemDevicer.
+func (d *i2cTracedDevice) WriteByte(address int, val byte) error {
+	start := d.tracer.begin()
+	err := d.bus.WriteByte(address, val)
+	d.record(start, address, -1, BusTraceWrite, []byte{val}, err)
+	return err
+}
+
+// WriteByteData implements gobot.I2cSystemDevicer.
+func (d *i2cTracedDevice) WriteByteData(address int, reg uint8, val uint8) error {
+	start := d.tracer.begin()
+	err := d.bus.WriteByteData(address, reg, val)
+	d.record(start, address, int(reg), BusTraceWrite, []byte{val}, err)
+	return err
+}
+
+// WriteWordData implements gobot.I2cSystemDevicer.
+func (d *i2cTracedDevice) WriteWordData(address int, reg uint8, val uint16) error {
+	start := d.tracer.begin()
+	err := d.bus.WriteWordData(address, reg, val)
+	d.record(start, address, int(reg), BusTraceWrite, []byte{byte(val), byte(val >> 8)}, err)
+	return err
+}
+
+// WriteBlockData implements gobot.I2cSystemDevicer.
+func (d *i2cTracedDevice) WriteBlockData(address int, reg uint8, data []byte) error {
+	start := d.tracer.begin()
+	err := d.bus.WriteBlockData(address, reg, data)
+	d.record(start, address, int(reg), BusTraceWrite, data, err)
+	return err
+}
+
+// WriteBytes implements gobot.I2cSystemDevicer.
+func (d *i2cTracedDevice) WriteBytes(address int, data []byte) error {
+	start := d.tracer.begin()
+	err := d.bus.WriteBytes(address, data)
+	d.record(start, address, -1, BusTraceWrite, data, err)
+	return err
+}
+
+// Read implements gobot.I2cSystemDevicer.
+func (d *i2cTracedDevice) Read(address int, b []byte) (int, error) {
+	start := d.tracer.begin()
+	n, err := d.bus.Read(address, b)
+	if n < 0 || n > len(b) {
+		n = 0
+	}
+	d.record(start, address, -1, BusTraceRead, b[:n], err)
+	return n, err
+}
+
+// Write implements gobot.I2cSystemDevicer.
+func (d *i2cTracedDevice) Write(address int, b []byte) (int, error) {
+	start := d.tracer.begin()
+	n, err := d.bus.Write(address, b)
+	d.record(start, address, -1, BusTraceWrite, b, err)
+	return n, err
+}
+
+func (d *i2cTracedDevice) record(start time.Time, address, reg int, direction string, data []byte, err error) {
+	if err != nil && direction == BusTraceRead {
+		data = nil // the content is undefined
+	}
+	d.tracer.record(start, BusTransaction{
+		Bus: BusTraceI2c, Location: d.location, Address: address, Register: reg, Direction: direction, Data: data,
+		Err: err,
+	})
+}
+
+// SetRegisterNamer implements gobot.BusRegisterNamer and forwards the namer to the tracer. The address is not used
+// for SPI devices.
+func (d *spiTracedDevice) SetRegisterNamer(_ int, namer func(reg uint8) string) {
+	d.tracer.SetRegisterNamer(d.location, -1, namer)
+}
+
+// TxRx implements gobot.SpiSystemDevicer.
+func (d *spiTracedDevice) TxRx(tx []byte, rx []byte) error {
+	start := d.tracer.begin()
+	err := d.dev.TxRx(tx, rx)
+	d.tracer.record(start, BusTransaction{
+		Bus: BusTraceSpi, Location: d.location, Address: -1, Register: -1, Direction: BusTraceTransfer,
+		Data: tx, Rx: rx, Err: err,
+	})
+	return err
+}
+
+// Transfer implements gobot.SpiSystemTransferer, each transfer of the message is recorded as a transaction.
+func (d *spiTracedDevice) Transfer(xfers []gobot.SpiXfer) error {
+	t, ok := d.dev.(gobot.SpiSystemTransferer)
+	if !ok {
+		return fmt.Errorf("the SPI system device does not support messages of multiple transfers")
+	}
+
+	start := d.tracer.begin()
+	err := t.Transfer(xfers)
+	for _, xfer := range xfers {
+		tx := xfer.Tx
+		if tx == nil {
+			tx = make([]byte, len(xfer.Rx))
+		}
+		d.tracer.record(start, BusTransaction{
+			Bus: BusTraceSpi, Location: d.location, Address: -1, Register: -1, Direction: BusTraceTransfer,
+			Data: tx, Rx: xfer.Rx, Err: err,
+		})
+	}
+	return err
+}
+
+// Close implements gobot.SpiSystemDevicer and is not recorded.
+func (d *spiTracedDevice) Close() error {
+	return d.dev.Close()
+}
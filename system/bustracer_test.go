@@ -0,0 +1,332 @@
+package system
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+)
+
+var (
+	_ gobot.I2cSystemDevicer    = (*i2cTracedDevice)(nil)
+	_ gobot.BusRegisterNamer    = (*i2cTracedDevice)(nil)
+	_ gobot.SpiSystemDevicer    = (*spiTracedDevice)(nil)
+	_ gobot.SpiSystemTransferer = (*spiTracedDevice)(nil)
+	_ gobot.BusRegisterNamer    = (*spiTracedDevice)(nil)
+)
+
+func initTestBusTracer(maxCount int) *BusTracer {
+	t := NewBusTracer(maxCount)
+	// each call of now() advances the time by 10 microseconds
+	current := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	t.now = func() time.Time {
+		current = current.Add(10 * time.Microsecond)
+		return current
+	}
+	return t
+}
+
+func initTestBusTracerI2cDevice() (*i2cDevice, *mockSyscall) {
+	d, msc := initTestI2cDeviceWithMockedSys()
+	d.funcs = I2C_FUNC_SMBUS_READ_BYTE | I2C_FUNC_SMBUS_READ_BYTE_DATA | I2C_FUNC_SMBUS_READ_WORD_DATA |
+		I2C_FUNC_SMBUS_WRITE_BYTE | I2C_FUNC_SMBUS_WRITE_BYTE_DATA | I2C_FUNC_SMBUS_WRITE_WORD_DATA
+	msc.dataSlice = []byte{0x11, 0x22} // simulated data for reads
+	return d, msc
+}
+
+func TestNewBusTracer(t *testing.T) {
+	// act
+	bt := NewBusTracer(5)
+	// assert
+	assert.Equal(t, 5, bt.maxCount)
+	assert.NotNil(t, bt.namers)
+	assert.NotNil(t, bt.now)
+	assert.Empty(t, bt.Transactions())
+}
+
+func TestBusTracerTracedI2cDevice(t *testing.T) {
+	tests := map[string]struct {
+		call          func(d gobot.I2cSystemDevicer) error
+		wantReg       int
+		wantDirection string
+		wantData      []byte
+	}{
+		"read_byte": {
+			call:          func(d gobot.I2cSystemDevicer) error { _, err := d.ReadByte(0x20); return err },
+			wantReg:       -1,
+			wantDirection: BusTraceRead,
+			wantData:      []byte{0x11},
+		},
+		"read_byte_data": {
+			call:          func(d gobot.I2cSystemDevicer) error { _, err := d.ReadByteData(0x20, 0x12); return err },
+			wantReg:       0x12,
+			wantDirection: BusTraceRead,
+			wantData:      []byte{0x11},
+		},
+		"read_word_data": {
+			call:          func(d gobot.I2cSystemDevicer) error { _, err := d.ReadWordData(0x20, 0x12); return err },
+			wantReg:       0x12,
+			wantDirection: BusTraceRead,
+			wantData:      []byte{0x11, 0x22},
+		},
+		"write_byte": {
+			call:          func(d gobot.I2cSystemDevicer) error { return d.WriteByte(0x20, 0x34) },
+			wantReg:       -1,
+			wantDirection: BusTraceWrite,
+			wantData:      []byte{0x34},
+		},
+		"write_byte_data": {
+			call:          func(d gobot.I2cSystemDevicer) error { return d.WriteByteData(0x20, 0x12, 0x34) },
+			wantReg:       0x12,
+			wantDirection: BusTraceWrite,
+			wantData:      []byte{0x34},
+		},
+		"write_word_data": {
+			call:          func(d gobot.I2cSystemDevicer) error { return d.WriteWordData(0x20, 0x12, 0x3456) },
+			wantReg:       0x12,
+			wantDirection: BusTraceWrite,
+			wantData:      []byte{0x56, 0x34},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			bus, _ := initTestBusTracerI2cDevice()
+			bt := initTestBusTracer(0)
+			d := NewTracedI2cDevice(bus, "/dev/i2c-1", bt)
+			// act
+			err := tc.call(d)
+			// assert
+			require.NoError(t, err)
+			got := bt.Transactions()
+			require.Len(t, got, 1)
+			assert.Equal(t, BusTraceI2c, got[0].Bus)
+			assert.Equal(t, "/dev/i2c-1", got[0].Location)
+			assert.Equal(t, 0x20, got[0].Address)
+			assert.Equal(t, tc.wantReg, got[0].Register)
+			assert.Equal(t, tc.wantDirection, got[0].Direction)
+			assert.Equal(t, tc.wantData, got[0].Data)
+			assert.Equal(t, 10*time.Microsecond, got[0].Duration)
+			require.NoError(t, got[0].Err)
+		})
+	}
+}
+
+func TestBusTracerTracedI2cDeviceError(t *testing.T) {
+	// arrange
+	bus, msc := initTestBusTracerI2cDevice()
+	msc.Impl = getSyscallFuncImpl(0x04)
+	bt := initTestBusTracer(0)
+	d := NewTracedI2cDevice(bus, "/dev/i2c-1", bt)
+	// act
+	_, err := d.ReadByteData(0x20, 0x12)
+	// assert
+	require.Error(t, err)
+	got := bt.Transactions()
+	require.Len(t, got, 1)
+	assert.Nil(t, got[0].Data)
+	assert.Equal(t, err, got[0].Err)
+}
+
+func TestBusTracerTracedSpiDevice(t *testing.T) {
+	// arrange
+	spi := newSpiMock(0, 1, 0, 8, 500000)
+	spi.simRead = []byte{0x0A, 0x0B}
+	bt := initTestBusTracer(0)
+	d := NewTracedSpiDevice(spi, "/dev/spidev0.1", bt)
+	rx := make([]byte, 2)
+	// act
+	err := d.TxRx([]byte{0x01, 0x02}, rx)
+	// assert
+	require.NoError(t, err)
+	got := bt.Transactions()
+	require.Len(t, got, 1)
+	assert.Equal(t, BusTraceSpi, got[0].Bus)
+	assert.Equal(t, "/dev/spidev0.1", got[0].Location)
+	assert.Equal(t, -1, got[0].Address)
+	assert.Equal(t, BusTraceTransfer, got[0].Direction)
+	assert.Equal(t, []byte{0x01, 0x02}, got[0].Data)
+	assert.Equal(t, []byte{0x0A, 0x0B}, got[0].Rx)
+}
+
+func TestBusTracerTracedSpiDeviceTransfer(t *testing.T) {
+	// arrange
+	spi := newSpiMock(0, 1, 0, 8, 500000)
+	spi.simRead = []byte{0x0A, 0x0B}
+	bt := initTestBusTracer(0)
+	d := NewTracedSpiDevice(spi, "/dev/spidev0.1", bt)
+	//nolint:forcetypeassert // ok here
+	transferer := d.(gobot.SpiSystemTransferer)
+	xfers := []gobot.SpiXfer{{Tx: []byte{0x03}}, {Rx: make([]byte, 2)}}
+	// act
+	err := transferer.Transfer(xfers)
+	// assert
+	require.NoError(t, err)
+	got := bt.Transactions()
+	require.Len(t, got, 2)
+	assert.Equal(t, []byte{0x03}, got[0].Data)
+	assert.Nil(t, got[0].Rx)
+	assert.Equal(t, []byte{0x00, 0x00}, got[1].Data)
+	assert.Equal(t, []byte{0x0A, 0x0B}, got[1].Rx)
+}
+
+func TestBusTracerRegisterNamer(t *testing.T) {
+	// arrange
+	bus, _ := initTestBusTracerI2cDevice()
+	spi := newSpiMock(0, 1, 0, 8, 500000)
+	bt := initTestBusTracer(0)
+	namer := func(reg uint8) string { return fmt.Sprintf("REG%d", reg) }
+	i2cDev := NewTracedI2cDevice(bus, "/dev/i2c-1", bt)
+	spiDev := NewTracedSpiDevice(spi, "/dev/spidev0.1", bt)
+	//nolint:forcetypeassert // ok here
+	i2cDev.(gobot.BusRegisterNamer).SetRegisterNamer(0x20, namer)
+	//nolint:forcetypeassert // ok here
+	spiDev.(gobot.BusRegisterNamer).SetRegisterNamer(0, namer)
+	// act
+	require.NoError(t, i2cDev.WriteByteData(0x20, 0x12, 0x34))
+	require.NoError(t, i2cDev.WriteByteData(0x21, 0x12, 0x34))
+	require.NoError(t, i2cDev.WriteByte(0x20, 0x34))
+	require.NoError(t, spiDev.TxRx([]byte{0x05, 0x00}, make([]byte, 2)))
+	// assert
+	got := bt.Transactions()
+	require.Len(t, got, 4)
+	assert.Equal(t, "REG18", got[0].RegisterName)
+	assert.Empty(t, got[1].RegisterName)
+	assert.Empty(t, got[2].RegisterName)
+	assert.Equal(t, "REG5", got[3].RegisterName)
+}
+
+func TestBusTracerMaxCountAndClear(t *testing.T) {
+	// arrange
+	bus, _ := initTestBusTracerI2cDevice()
+	bt := initTestBusTracer(2)
+	d := NewTracedI2cDevice(bus, "/dev/i2c-1", bt)
+	// act
+	for i := byte(1); i <= 3; i++ {
+		require.NoError(t, d.WriteByte(0x20, i))
+	}
+	// assert
+	got := bt.Transactions()
+	require.Len(t, got, 2)
+	assert.Equal(t, []byte{0x02}, got[0].Data)
+	assert.Equal(t, []byte{0x03}, got[1].Data)
+	// act
+	bt.Clear()
+	// assert
+	assert.Empty(t, bt.Transactions())
+}
+
+func TestBusTracerWriteText(t *testing.T) {
+	// arrange
+	bus, _ := initTestBusTracerI2cDevice()
+	spi := newSpiMock(0, 1, 0, 8, 500000)
+	spi.simRead = []byte{0x0A}
+	bt := initTestBusTracer(0)
+	i2cDev := NewTracedI2cDevice(bus, "/dev/i2c-1", bt)
+	//nolint:forcetypeassert // ok here
+	i2cDev.(gobot.BusRegisterNamer).SetRegisterNamer(0x20, func(uint8) string { return "GPIOA" })
+	spiDev := NewTracedSpiDevice(spi, "/dev/spidev0.1", bt)
+	require.NoError(t, i2cDev.WriteBlockData(0x20, 0x12, []byte{0x01, 0x02}))
+	require.NoError(t, spiDev.TxRx([]byte{0x01}, make([]byte, 1)))
+	var live bytes.Buffer
+	bt.SetLiveWriter(&live)
+	require.NoError(t, i2cDev.WriteByte(0x21, 0xFF))
+	var buf bytes.Buffer
+	// act
+	err := bt.WriteText(&buf)
+	// assert
+	require.NoError(t, err)
+	want := "03:04:05.000010 i2c /dev/i2c-1 0x20 write reg 0x12 (GPIOA) [01 02] 10µs\n" +
+		"03:04:05.000030 spi /dev/spidev0.1 transfer tx [01] rx [0A] 10µs\n" +
+		"03:04:05.000050 i2c /dev/i2c-1 0x21 write [FF] 10µs\n"
+	assert.Equal(t, want, buf.String())
+	assert.Equal(t, "03:04:05.000050 i2c /dev/i2c-1 0x21 write [FF] 10µs\n", live.String())
+}
+
+func TestBusTracerWriteJSON(t *testing.T) {
+	// arrange
+	bus, msc := initTestBusTracerI2cDevice()
+	bt := initTestBusTracer(0)
+	d := NewTracedI2cDevice(bus, "/dev/i2c-1", bt)
+	require.NoError(t, d.WriteWordData(0x20, 0x12, 0x3456))
+	msc.Impl = getSyscallFuncImpl(0x04)
+	_ = d.WriteByte(0x20, 0x01)
+	var buf bytes.Buffer
+	// act
+	err := bt.WriteJSON(&buf)
+	// assert
+	require.NoError(t, err)
+	var got []map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+	require.Len(t, got, 2)
+	assert.Equal(t, "i2c", got[0]["bus"])
+	assert.Equal(t, "/dev/i2c-1", got[0]["location"])
+	assert.InDelta(t, 0x20, got[0]["address"], 0)
+	assert.InDelta(t, 0x12, got[0]["register"], 0)
+	assert.Equal(t, "write", got[0]["direction"])
+	assert.Equal(t, "5634", got[0]["data"])
+	assert.InDelta(t, 10000, got[0]["durationNs"], 0)
+	assert.NotContains(t, got[0], "error")
+	assert.NotContains(t, got[0], "rx")
+	assert.Contains(t, got[1], "error")
+}
+
+func TestBusTracerWriteVCD(t *testing.T) {
+	// arrange
+	bus, _ := initTestBusTracerI2cDevice()
+	spi := newSpiMock(0, 1, 0, 8, 500000)
+	bt := initTestBusTracer(0)
+	i2cDev := NewTracedI2cDevice(bus, "/dev/i2c-1", bt)
+	spiDev := NewTracedSpiDevice(spi, "/dev/spidev0.1", bt)
+	require.NoError(t, i2cDev.WriteByteData(0x20, 0x12, 0x34))
+	require.NoError(t, i2cDev.WriteByteData(0x20, 0x13, 0x35))
+	require.NoError(t, spiDev.TxRx([]byte{0x80}, make([]byte, 1)))
+	var buf bytes.Buffer
+	// act
+	err := bt.WriteVCD(&buf)
+	// assert
+	require.NoError(t, err)
+	vcd := buf.String()
+	assert.Contains(t, vcd, "$timescale 1ns $end\n")
+	assert.Contains(t, vcd, "$scope module dev_i2c_1 $end\n$var wire 1 ! scl $end\n$var wire 1 \" sda $end\n")
+	assert.Contains(t, vcd, "$scope module dev_spidev0_1 $end\n$var wire 1 # sclk $end\n")
+	assert.Contains(t, vcd, "$var wire 1 & cs $end\n")
+	assert.Contains(t, vcd, "#0\n$dumpvars\n1!\n1\"\n0#\n0$\n0%\n1&\n$end\n")
+	// the start condition: SDA falls while SCL is high
+	assert.Contains(t, vcd, "#5000\n0\"\n#7500\n0!\n")
+	// the time stamps are increasing
+	var last int64 = -1
+	for _, line := range strings.Split(vcd, "\n") {
+		if strings.HasPrefix(line, "#") {
+			var ts int64
+			_, err := fmt.Sscanf(line, "#%d", &ts)
+			require.NoError(t, err)
+			assert.Greater(t, ts, last)
+			last = ts
+		}
+	}
+	// 3 bytes with ACK a 9 bits for the 2nd transaction must not overlap with the gap before the SPI transaction
+	assert.Greater(t, last, int64(2*(2*4+27*4)*busTraceVcdI2cQuarterBit))
+}
+
+func TestBusTracerVcdIdentifiers(t *testing.T) {
+	// arrange
+	v := &busTraceVcdWriter{}
+	var ids []string
+	// act
+	for i := 0; i < 96; i++ {
+		ids = append(ids, v.newSignal("s", 0).id)
+	}
+	// assert
+	assert.Equal(t, "!", ids[0])
+	assert.Equal(t, "~", ids[93])
+	assert.Equal(t, "!!", ids[94])
+	assert.Equal(t, "!\"", ids[95])
+}
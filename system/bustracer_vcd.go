@@ -0,0 +1,275 @@
+package system
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	busTraceVcdI2cQuarterBit = 2500 // ns, results in 100 kHz
+	busTraceVcdSpiHalfBit    = 500  // ns, results in 1 MHz
+	busTraceVcdGap           = 4 * busTraceVcdI2cQuarterBit
+)
+
+type busTraceVcdSignal struct {
+	name    string
+	id      string
+	initial byte
+	value   byte
+}
+
+type busTraceVcdEvent struct {
+	time   int64
+	signal *busTraceVcdSignal
+	value  byte
+}
+
+// busTraceVcdScope contains the signals of one bus location
+type busTraceVcdScope struct {
+	name    string
+	signals []*busTraceVcdSignal
+	end     int64
+}
+
+type busTraceVcdWriter struct {
+	scopes     map[string]*busTraceVcdScope
+	scopeOrder []*busTraceVcdScope
+	events     []busTraceVcdEvent
+	signalCnt  int
+}
+
+func writeBusTraceVCD(w io.Writer, transactions []BusTransaction) error {
+	v := &busTraceVcdWriter{scopes: make(map[string]*busTraceVcdScope)}
+
+	var base time.Time
+	if len(transactions) > 0 {
+		base = transactions[0].Time
+	}
+
+	for _, ta := range transactions {
+		scope := v.scope(ta.Bus, ta.Location)
+		start := ta.Time.Sub(base).Nanoseconds()
+		if scope.end > 0 && start < scope.end+busTraceVcdGap {
+			start = scope.end + busTraceVcdGap
+		}
+
+		switch ta.Bus {
+		case BusTraceI2c:
+			scope.end = v.synthesizeI2c(scope, start, ta)
+		case BusTraceSpi:
+			scope.end = v.synthesizeSpi(scope, start, ta)
+		}
+	}
+
+	return v.write(w, base)
+}
+
+func (v *busTraceVcdWriter) scope(bus string, location string) *busTraceVcdScope {
+	if s, ok := v.scopes[location]; ok {
+		return s
+	}
+
+	s := &busTraceVcdScope{name: busTraceVcdScopeName(location)}
+	switch bus {
+	case BusTraceI2c:
+		s.signals = []*busTraceVcdSignal{v.newSignal("scl", 1), v.newSignal("sda", 1)}
+	case BusTraceSpi:
+		s.signals = []*busTraceVcdSignal{
+			v.newSignal("sclk", 0), v.newSignal("mosi", 0), v.newSignal("miso", 0),
+			v.newSignal("cs", 1),
+		}
+	}
+	v.scopes[location] = s
+	v.scopeOrder = append(v.scopeOrder, s)
+	return s
+}
+
+func (v *busTraceVcdWriter) newSignal(name string, initial byte) *busTraceVcdSignal {
+	// the identifiers are created from the printable ASCII characters 33...126
+	var id string
+	for n := v.signalCnt; ; n = n/94 - 1 {
+		id = string(rune('!'+n%94)) + id
+		if n < 94 {
+			break
+		}
+	}
+	v.signalCnt++
+	return &busTraceVcdSignal{name: name, id: id, initial: initial, value: initial}
+}
+
+func (v *busTraceVcdWriter) set(s *busTraceVcdSignal, at int64, value byte) {
+	if s.value == value {
+		return
+	}
+	s.value = value
+	v.events = append(v.events, busTraceVcdEvent{time: at, signal: s, value: value})
+}
+
+// synthesizeI2c creates the waveform of SCL and SDA for the transaction and returns the end time.
+func (v *busTraceVcdWriter) synthesizeI2c(s *busTraceVcdScope, t int64, ta BusTransaction) int64 {
+	const q = busTraceVcdI2cQuarterBit
+	scl, sda := s.signals[0], s.signals[1]
+
+	bit := func(val byte) {
+		v.set(sda, t, val)
+		t += q
+		v.set(scl, t, 1)
+		t += 2 * q
+		v.set(scl, t, 0)
+		t += q
+	}
+	writeByte := func(b byte, ack bool) {
+		for i := 7; i >= 0; i-- {
+			bit(b >> uint(i) & 1)
+		}
+		if ack {
+			bit(0)
+		} else {
+			bit(1)
+		}
+	}
+	start := func() {
+		v.set(sda, t, 1)
+		t += q
+		v.set(scl, t, 1)
+		t += q
+		v.set(sda, t, 0)
+		t += q
+		v.set(scl, t, 0)
+		t += q
+	}
+	stop := func() {
+		v.set(sda, t, 0)
+		t += q
+		v.set(scl, t, 1)
+		t += q
+		v.set(sda, t, 1)
+		t += q
+	}
+
+	addressWrite := byte(ta.Address << 1)
+	addressRead := addressWrite | 1
+
+	start()
+	if ta.Err != nil {
+		// the real reason is unknown, the address is not acknowledged in the waveform
+		if ta.Direction == BusTraceRead && ta.Register < 0 {
+			writeByte(addressRead, false)
+		} else {
+			writeByte(addressWrite, false)
+		}
+		stop()
+		return t
+	}
+
+	if ta.Direction == BusTraceWrite {
+		writeByte(addressWrite, true)
+		if ta.Register >= 0 {
+			writeByte(byte(ta.Register), true)
+		}
+		for _, b := range ta.Data {
+			writeByte(b, true)
+		}
+	} else {
+		if ta.Register >= 0 {
+			writeByte(addressWrite, true)
+			writeByte(byte(ta.Register), true)
+			start() // repeated start
+		}
+		writeByte(addressRead, true)
+		for i, b := range ta.Data {
+			writeByte(b, i < len(ta.Data)-1) // the last byte is not acknowledged by the master
+		}
+	}
+	stop()
+
+	return t
+}
+
+// synthesizeSpi creates the waveform of SCLK, MOSI, MISO and CS for the transaction in mode 0 and returns the end
+// time.
+func (v *busTraceVcdWriter) synthesizeSpi(s *busTraceVcdScope, t int64, ta BusTransaction) int64 {
+	const h = busTraceVcdSpiHalfBit
+	sclk, mosi, miso, cs := s.signals[0], s.signals[1], s.signals[2], s.signals[3]
+
+	count := len(ta.Data)
+	if len(ta.Rx) > count {
+		count = len(ta.Rx)
+	}
+
+	v.set(cs, t, 0)
+	t += h
+	for i := 0; i < count; i++ {
+		var tx, rx byte
+		if i < len(ta.Data) {
+			tx = ta.Data[i]
+		}
+		if i < len(ta.Rx) {
+			rx = ta.Rx[i]
+		}
+		for j := 7; j >= 0; j-- {
+			v.set(mosi, t, tx>>uint(j)&1)
+			v.set(miso, t, rx>>uint(j)&1)
+			t += h
+			v.set(sclk, t, 1)
+			t += h
+			v.set(sclk, t, 0)
+		}
+	}
+	t += h
+	v.set(cs, t, 1)
+	t += h
+
+	return t
+}
+
+func (v *busTraceVcdWriter) write(w io.Writer, base time.Time) error {
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintf(bw, "$date %s $end\n", base.Format(time.RFC3339Nano))
+	fmt.Fprintln(bw, "$version gobot bus tracer $end")
+	fmt.Fprintln(bw, "$timescale 1ns $end")
+	for _, s := range v.scopeOrder {
+		fmt.Fprintf(bw, "$scope module %s $end\n", s.name)
+		for _, sig := range s.signals {
+			fmt.Fprintf(bw, "$var wire 1 %s %s $end\n", sig.id, sig.name)
+		}
+		fmt.Fprintln(bw, "$upscope $end")
+	}
+	fmt.Fprintln(bw, "$enddefinitions $end")
+
+	fmt.Fprintln(bw, "#0")
+	fmt.Fprintln(bw, "$dumpvars")
+	for _, s := range v.scopeOrder {
+		for _, sig := range s.signals {
+			fmt.Fprintf(bw, "%d%s\n", sig.initial, sig.id)
+		}
+	}
+	fmt.Fprintln(bw, "$end")
+
+	sort.SliceStable(v.events, func(i, j int) bool { return v.events[i].time < v.events[j].time })
+	last := int64(0) // "#0" is already written
+	for _, e := range v.events {
+		if e.time != last {
+			fmt.Fprintf(bw, "#%d\n", e.time)
+			last = e.time
+		}
+		fmt.Fprintf(bw, "%d%s\n", e.value, e.signal.id)
+	}
+
+	return bw.Flush()
+}
+
+func busTraceVcdScopeName(location string) string {
+	name := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return '_'
+	}, location)
+	return strings.Trim(name, "_")
+}
@@ -0,0 +1,305 @@
+package system
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"time"
+
+	"golang.org/x/sys/unix"
+
+	"gobot.io/x/gobot/v2"
+)
+
+// Linux SocketCAN, raw sockets.
+//
+//	https://docs.kernel.org/networking/can.html
+//	"linux/can.h", "linux/can/raw.h"
+const (
+	canMtu         = 16   // size of "struct can_frame"
+	canFdMtu       = 72   // size of "struct canfd_frame"
+	canMaxDataLen  = 8    // CAN_MAX_DLEN
+	canFdMaxLen    = 64   // CANFD_MAX_DLEN
+	canFdFlagBrs   = 0x01 // CANFD_BRS
+	canFdFlagEsi   = 0x02 // CANFD_ESI
+	canFdFlagFdf   = 0x04 // CANFD_FDF
+	canFrameOffLen = 4    // offset of the length in both frame types
+	canFrameOffFlg = 5    // offset of the flags in "struct canfd_frame"
+	canFrameOffDat = 8    // offset of the data in both frame types
+)
+
+// canFdLengths contains the valid data lengths of CAN FD frames above 8 bytes
+var canFdLengths = map[int]bool{12: true, 16: true, 20: true, 24: true, 32: true, 48: true, 64: true}
+
+// canSocketer is the unexposed interface to a raw CAN socket, implemented by the native and the mocked socket
+type canSocketer interface {
+	io.ReadWriteCloser
+	setFilters(filters []unix.CanFilter) error
+	setErrorFilter(mask uint32) error
+	setFDFrames(enable bool) error
+}
+
+// canSocketAccesser represents unexposed interface to allow the switch between different implementations and a
+// mocked one
+type canSocketAccesser interface {
+	openSocket(iface string) (canSocketer, error)
+}
+
+// nativeCanSocketAccess opens raw CAN sockets of the kernel
+type nativeCanSocketAccess struct{}
+
+// nativeCanSocket is a raw CAN socket, which is handled by the runtime poller
+type nativeCanSocket struct {
+	*os.File
+}
+
+// canSocketDevice is the implementation of a CAN socket at system level
+type canSocketDevice struct {
+	iface    string
+	socket   canSocketer
+	fdFrames bool
+}
+
+func (*nativeCanSocketAccess) openSocket(iface string) (canSocketer, error) {
+	ifi, err := net.InterfaceByName(iface)
+	if err != nil {
+		return nil, fmt.Errorf("CAN interface '%s': %v", iface, err)
+	}
+
+	fd, err := unix.Socket(unix.AF_CAN, unix.SOCK_RAW|unix.SOCK_NONBLOCK|unix.SOCK_CLOEXEC, unix.CAN_RAW)
+	if err != nil {
+		return nil, fmt.Errorf("CAN socket for '%s': %v", iface, err)
+	}
+	if err := unix.Bind(fd, &unix.SockaddrCAN{Ifindex: ifi.Index}); err != nil {
+		_ = unix.Close(fd)
+		return nil, fmt.Errorf("bind CAN socket to '%s': %v", iface, err)
+	}
+
+	// the non-blocking socket is registered at the runtime poller, so a blocked read returns on close
+	return &nativeCanSocket{File: os.NewFile(uintptr(fd), iface)}, nil
+}
+
+func (s *nativeCanSocket) setFilters(filters []unix.CanFilter) error {
+	return s.control(func(fd int) error {
+		return unix.SetsockoptCanRawFilter(fd, unix.SOL_CAN_RAW, unix.CAN_RAW_FILTER, filters)
+	})
+}
+
+func (s *nativeCanSocket) setErrorFilter(mask uint32) error {
+	return s.control(func(fd int) error {
+		return unix.SetsockoptInt(fd, unix.SOL_CAN_RAW, unix.CAN_RAW_ERR_FILTER, int(mask))
+	})
+}
+
+func (s *nativeCanSocket) setFDFrames(enable bool) error {
+	val := 0
+	if enable {
+		val = 1
+	}
+	return s.control(func(fd int) error {
+		return unix.SetsockoptInt(fd, unix.SOL_CAN_RAW, unix.CAN_RAW_FD_FRAMES, val)
+	})
+}
+
+func (s *nativeCanSocket) control(f func(fd int) error) error {
+	rc, err := s.SyscallConn()
+	if err != nil {
+		return err
+	}
+	var opErr error
+	if err := rc.Control(func(fd uintptr) { opErr = f(int(fd)) }); err != nil {
+		return err
+	}
+	return opErr
+}
+
+// newCanSocketDevice opens a raw socket for the given interface by the given accesser. If fdFrames is true, CAN FD
+// frames can be sent and received in addition to classic frames.
+func newCanSocketDevice(csa canSocketAccesser, iface string, fdFrames bool) (*canSocketDevice, error) {
+	socket, err := csa.openSocket(iface)
+	if err != nil {
+		return nil, err
+	}
+
+	if fdFrames {
+		if err := socket.setFDFrames(true); err != nil {
+			_ = socket.Close()
+			return nil, fmt.Errorf("enable CAN FD frames for '%s': %v", iface, err)
+		}
+	}
+
+	return &canSocketDevice{iface: iface, socket: socket, fdFrames: fdFrames}, nil
+}
+
+// ReadFrame blocks until a frame is received. Implements the interface gobot.CanSystemDevicer.
+func (d *canSocketDevice) ReadFrame() (gobot.CanFrame, error) {
+	buf := make([]byte, canFdMtu)
+	n, err := d.socket.Read(buf)
+	if err != nil {
+		return gobot.CanFrame{}, err
+	}
+
+	frame, err := decodeCanFrame(buf[:n])
+	if err != nil {
+		return gobot.CanFrame{}, fmt.Errorf("CAN interface '%s': %v", d.iface, err)
+	}
+	frame.Time = time.Now()
+	return frame, nil
+}
+
+// WriteFrame sends the given frame. Implements the interface gobot.CanSystemDevicer.
+func (d *canSocketDevice) WriteFrame(frame gobot.CanFrame) error {
+	if frame.Error {
+		return fmt.Errorf("CAN interface '%s': error frames can not be sent", d.iface)
+	}
+	if frame.FD && !d.fdFrames {
+		return fmt.Errorf("CAN interface '%s': CAN FD frames are not enabled", d.iface)
+	}
+
+	buf, err := encodeCanFrame(frame)
+	if err != nil {
+		return fmt.Errorf("CAN interface '%s': %v", d.iface, err)
+	}
+
+	n, err := d.socket.Write(buf)
+	if err != nil {
+		return err
+	}
+	if n != len(buf) {
+		return fmt.Errorf("CAN interface '%s': only %d of %d bytes of the frame written", d.iface, n, len(buf))
+	}
+	return nil
+}
+
+// SetFilters replaces the filters for received frames. Implements the interface gobot.CanSystemDevicer.
+func (d *canSocketDevice) SetFilters(filters []gobot.CanFilter) error {
+	return d.socket.setFilters(canFiltersToKernel(filters))
+}
+
+// SetErrorFilter activates the reception of error frames for the given error classes, see "linux/can/error.h".
+// Implements the interface gobot.CanSystemDevicer.
+func (d *canSocketDevice) SetErrorFilter(mask uint32) error {
+	return d.socket.setErrorFilter(mask & unix.CAN_ERR_MASK)
+}
+
+// Close releases the socket. Implements the interface gobot.CanSystemDevicer.
+func (d *canSocketDevice) Close() error {
+	return d.socket.Close()
+}
+
+// canFiltersToKernel converts the filters to the kernel representation, the frame format is always part of the mask
+func canFiltersToKernel(filters []gobot.CanFilter) []unix.CanFilter {
+	kernelFilters := make([]unix.CanFilter, 0, len(filters))
+	for _, f := range filters {
+		var kf unix.CanFilter
+		if f.Extended {
+			kf.Id = f.ID&unix.CAN_EFF_MASK | unix.CAN_EFF_FLAG
+			kf.Mask = f.Mask&unix.CAN_EFF_MASK | unix.CAN_EFF_FLAG
+		} else {
+			kf.Id = f.ID & unix.CAN_SFF_MASK
+			kf.Mask = f.Mask&unix.CAN_SFF_MASK | unix.CAN_EFF_FLAG
+		}
+		if f.Invert {
+			kf.Id |= unix.CAN_INV_FILTER
+		}
+		kernelFilters = append(kernelFilters, kf)
+	}
+	return kernelFilters
+}
+
+// encodeCanFrame creates the "struct can_frame" or, for CAN FD, the "struct canfd_frame"
+func encodeCanFrame(frame gobot.CanFrame) ([]byte, error) {
+	var canID uint32
+	switch {
+	case frame.Error:
+		canID = frame.ID&unix.CAN_ERR_MASK | unix.CAN_ERR_FLAG
+	case frame.Extended:
+		if frame.ID > unix.CAN_EFF_MASK {
+			return nil, fmt.Errorf("extended CAN ID 0x%X exceeds 29 bit", frame.ID)
+		}
+		canID = frame.ID | unix.CAN_EFF_FLAG
+	default:
+		if frame.ID > unix.CAN_SFF_MASK {
+			return nil, fmt.Errorf("standard CAN ID 0x%X exceeds 11 bit", frame.ID)
+		}
+		canID = frame.ID
+	}
+
+	dataLen := len(frame.Data)
+	size := canMtu
+	if frame.FD {
+		if frame.Remote {
+			return nil, fmt.Errorf("remote frames are not supported by CAN FD")
+		}
+		if dataLen > canMaxDataLen && !canFdLengths[dataLen] {
+			return nil, fmt.Errorf("invalid length %d of CAN FD data", dataLen)
+		}
+		size = canFdMtu
+	} else {
+		if dataLen > canMaxDataLen {
+			return nil, fmt.Errorf("length %d of CAN data exceeds %d bytes", dataLen, canMaxDataLen)
+		}
+		if frame.Remote {
+			canID |= unix.CAN_RTR_FLAG
+		}
+	}
+
+	buf := make([]byte, size)
+	binary.LittleEndian.PutUint32(buf, canID)
+	buf[canFrameOffLen] = byte(dataLen)
+	if frame.FD {
+		flags := byte(canFdFlagFdf)
+		if frame.BRS {
+			flags |= canFdFlagBrs
+		}
+		if frame.ESI {
+			flags |= canFdFlagEsi
+		}
+		buf[canFrameOffFlg] = flags
+	}
+	if !frame.Remote {
+		copy(buf[canFrameOffDat:], frame.Data)
+	}
+	return buf, nil
+}
+
+// decodeCanFrame parses the "struct can_frame" or the "struct canfd_frame", depending on the size
+func decodeCanFrame(buf []byte) (gobot.CanFrame, error) {
+	var frame gobot.CanFrame
+	maxLen := canMaxDataLen
+	switch len(buf) {
+	case canMtu:
+	case canFdMtu:
+		frame.FD = true
+		frame.BRS = buf[canFrameOffFlg]&canFdFlagBrs != 0
+		frame.ESI = buf[canFrameOffFlg]&canFdFlagEsi != 0
+		maxLen = canFdMaxLen
+	default:
+		return frame, fmt.Errorf("unexpected size %d of CAN frame", len(buf))
+	}
+
+	canID := binary.LittleEndian.Uint32(buf)
+	switch {
+	case canID&unix.CAN_ERR_FLAG != 0:
+		frame.Error = true
+		frame.ID = canID & unix.CAN_ERR_MASK
+	case canID&unix.CAN_EFF_FLAG != 0:
+		frame.Extended = true
+		frame.ID = canID & unix.CAN_EFF_MASK
+	default:
+		frame.ID = canID & unix.CAN_SFF_MASK
+	}
+	frame.Remote = !frame.FD && canID&unix.CAN_RTR_FLAG != 0
+
+	dataLen := int(buf[canFrameOffLen])
+	if dataLen > maxLen {
+		dataLen = maxLen
+	}
+	frame.Data = make([]byte, dataLen)
+	if !frame.Remote {
+		copy(frame.Data, buf[canFrameOffDat:canFrameOffDat+dataLen])
+	}
+	return frame, nil
+}
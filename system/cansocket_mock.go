@@ -0,0 +1,206 @@
+package system
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sync"
+
+	"golang.org/x/sys/unix"
+
+	"gobot.io/x/gobot/v2"
+)
+
+// canSocketMockQueueLen is the maximum count of not read frames of a mocked socket, further frames are dropped like
+// done by the kernel
+const canSocketMockQueueLen = 64
+
+// MockCanSocketAccess emulates the kernel for raw CAN sockets, all opened sockets are connected to the same
+// in-process bus. Like done by the kernel, the filters of each socket are applied and sent frames are looped back to
+// all other sockets of the same interface.
+type MockCanSocketAccess struct {
+	CreateError bool
+	mutex       sync.Mutex
+	sockets     []*canSocketMock
+	written     []gobot.CanFrame
+	simWriteErr bool
+}
+
+// canSocketMock is the mock implementation of a raw CAN socket
+type canSocketMock struct {
+	access    *MockCanSocketAccess
+	iface     string
+	rx        chan []byte
+	filters   []unix.CanFilter
+	errorMask uint32
+	fdFrames  bool
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+func (csa *MockCanSocketAccess) openSocket(iface string) (canSocketer, error) {
+	if csa.CreateError {
+		return nil, fmt.Errorf("error while open CAN socket in mock")
+	}
+
+	csa.mutex.Lock()
+	defer csa.mutex.Unlock()
+
+	s := &canSocketMock{
+		access:  csa,
+		iface:   iface,
+		rx:      make(chan []byte, canSocketMockQueueLen),
+		filters: []unix.CanFilter{{Id: 0, Mask: 0}}, // default of the kernel: receive all frames
+		closed:  make(chan struct{}),
+	}
+	csa.sockets = append(csa.sockets, s)
+	return s, nil
+}
+
+// SimulateReceive delivers the given frame to all open sockets of the given interface, according to their filters.
+func (csa *MockCanSocketAccess) SimulateReceive(iface string, frame gobot.CanFrame) error {
+	buf, err := encodeCanFrame(frame)
+	if err != nil {
+		return err
+	}
+
+	csa.mutex.Lock()
+	defer csa.mutex.Unlock()
+
+	csa.deliver(nil, iface, buf)
+	return nil
+}
+
+// Written returns all frames, written by any socket since the last reset.
+func (csa *MockCanSocketAccess) Written() []gobot.CanFrame {
+	csa.mutex.Lock()
+	defer csa.mutex.Unlock()
+
+	return append([]gobot.CanFrame(nil), csa.written...)
+}
+
+// OpenSockets returns the count of opened and not closed sockets.
+func (csa *MockCanSocketAccess) OpenSockets() int {
+	csa.mutex.Lock()
+	defer csa.mutex.Unlock()
+
+	return len(csa.sockets)
+}
+
+// SetWriteError can be used to simulate a write error.
+func (csa *MockCanSocketAccess) SetWriteError(val bool) {
+	csa.mutex.Lock()
+	defer csa.mutex.Unlock()
+
+	csa.simWriteErr = val
+}
+
+// Reset resets the recorded frames.
+func (csa *MockCanSocketAccess) Reset() {
+	csa.mutex.Lock()
+	defer csa.mutex.Unlock()
+
+	csa.written = nil
+}
+
+// deliver needs to be called with locked mutex
+func (csa *MockCanSocketAccess) deliver(sender *canSocketMock, iface string, buf []byte) {
+	for _, s := range csa.sockets {
+		if s == sender || s.iface != iface || !s.accepts(buf) {
+			continue
+		}
+		select {
+		case s.rx <- append([]byte(nil), buf...):
+		default:
+			// queue is full, the frame is dropped
+		}
+	}
+}
+
+// Read blocks until a frame is available or the socket is closed.
+func (s *canSocketMock) Read(b []byte) (int, error) {
+	select {
+	case buf := <-s.rx:
+		return copy(b, buf), nil
+	case <-s.closed:
+		return 0, fmt.Errorf("CAN socket for '%s' closed in mock", s.iface)
+	}
+}
+
+func (s *canSocketMock) Write(b []byte) (int, error) {
+	s.access.mutex.Lock()
+	defer s.access.mutex.Unlock()
+
+	if s.access.simWriteErr {
+		return 0, fmt.Errorf("error while writing to CAN socket in mock")
+	}
+	frame, err := decodeCanFrame(b)
+	if err != nil {
+		return 0, err
+	}
+	s.access.written = append(s.access.written, frame)
+	s.access.deliver(s, s.iface, b)
+	return len(b), nil
+}
+
+func (s *canSocketMock) Close() error {
+	s.closeOnce.Do(func() {
+		s.access.mutex.Lock()
+		defer s.access.mutex.Unlock()
+
+		close(s.closed)
+		for i, socket := range s.access.sockets {
+			if socket == s {
+				s.access.sockets = append(s.access.sockets[:i], s.access.sockets[i+1:]...)
+				break
+			}
+		}
+	})
+	return nil
+}
+
+func (s *canSocketMock) setFilters(filters []unix.CanFilter) error {
+	s.access.mutex.Lock()
+	defer s.access.mutex.Unlock()
+
+	s.filters = append([]unix.CanFilter(nil), filters...)
+	return nil
+}
+
+func (s *canSocketMock) setErrorFilter(mask uint32) error {
+	s.access.mutex.Lock()
+	defer s.access.mutex.Unlock()
+
+	s.errorMask = mask
+	return nil
+}
+
+func (s *canSocketMock) setFDFrames(enable bool) error {
+	s.access.mutex.Lock()
+	defer s.access.mutex.Unlock()
+
+	s.fdFrames = enable
+	return nil
+}
+
+// accepts applies the filters like done by the kernel, needs to be called with locked mutex
+func (s *canSocketMock) accepts(buf []byte) bool {
+	if len(buf) == canFdMtu && !s.fdFrames {
+		return false
+	}
+
+	canID := binary.LittleEndian.Uint32(buf)
+	if canID&unix.CAN_ERR_FLAG != 0 {
+		return canID&s.errorMask != 0
+	}
+
+	for _, f := range s.filters {
+		match := canID&f.Mask == f.Id&^unix.CAN_INV_FILTER&f.Mask
+		if f.Id&unix.CAN_INV_FILTER != 0 {
+			match = !match
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,239 @@
+package system
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+
+	"gobot.io/x/gobot/v2"
+)
+
+// make sure that this implementation fulfills all the required interfaces
+var (
+	_ gobot.CanSystemDevicer = (*canSocketDevice)(nil)
+	_ canSocketer            = (*nativeCanSocket)(nil)
+	_ canSocketer            = (*canSocketMock)(nil)
+)
+
+func TestEncodeDecodeCanFrame(t *testing.T) {
+	tests := map[string]struct {
+		frame    gobot.CanFrame
+		wantSize int
+		wantID   uint32
+		wantLen  byte
+		wantFlag byte
+	}{
+		"standard": {
+			frame:    gobot.CanFrame{ID: 0x123, Data: []byte{0x01, 0x02, 0x03}},
+			wantSize: 16,
+			wantID:   0x123,
+			wantLen:  3,
+		},
+		"extended": {
+			frame:    gobot.CanFrame{ID: 0x18FF50E5, Extended: true, Data: []byte{1, 2, 3, 4, 5, 6, 7, 8}},
+			wantSize: 16,
+			wantID:   0x98FF50E5,
+			wantLen:  8,
+		},
+		"remote": {
+			frame:    gobot.CanFrame{ID: 0x7FF, Remote: true, Data: make([]byte, 4)},
+			wantSize: 16,
+			wantID:   0x400007FF,
+			wantLen:  4,
+		},
+		"error": {
+			frame:    gobot.CanFrame{ID: 0x04, Error: true, Data: make([]byte, 8)},
+			wantSize: 16,
+			wantID:   0x20000004,
+			wantLen:  8,
+		},
+		"fd": {
+			frame:    gobot.CanFrame{ID: 0x10, FD: true, BRS: true, ESI: true, Data: make([]byte, 12)},
+			wantSize: 72,
+			wantID:   0x10,
+			wantLen:  12,
+			wantFlag: 0x07,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// act
+			buf, err := encodeCanFrame(tc.frame)
+			// assert
+			require.NoError(t, err)
+			require.Len(t, buf, tc.wantSize)
+			assert.Equal(t, tc.wantID, uint32(buf[0])|uint32(buf[1])<<8|uint32(buf[2])<<16|uint32(buf[3])<<24)
+			assert.Equal(t, tc.wantLen, buf[4])
+			assert.Equal(t, tc.wantFlag, buf[5])
+			// act
+			got, err := decodeCanFrame(buf)
+			// assert
+			require.NoError(t, err)
+			assert.Equal(t, tc.frame, got)
+		})
+	}
+}
+
+func TestEncodeCanFrame_errors(t *testing.T) {
+	tests := map[string]struct {
+		frame   gobot.CanFrame
+		wantErr string
+	}{
+		"standard_id": {
+			frame:   gobot.CanFrame{ID: 0x800},
+			wantErr: "standard CAN ID 0x800 exceeds 11 bit",
+		},
+		"extended_id": {
+			frame:   gobot.CanFrame{ID: 0x20000000, Extended: true},
+			wantErr: "extended CAN ID 0x20000000 exceeds 29 bit",
+		},
+		"classic_length": {
+			frame:   gobot.CanFrame{ID: 0x1, Data: make([]byte, 9)},
+			wantErr: "length 9 of CAN data exceeds 8 bytes",
+		},
+		"fd_length": {
+			frame:   gobot.CanFrame{ID: 0x1, FD: true, Data: make([]byte, 10)},
+			wantErr: "invalid length 10 of CAN FD data",
+		},
+		"fd_remote": {
+			frame:   gobot.CanFrame{ID: 0x1, FD: true, Remote: true},
+			wantErr: "remote frames are not supported by CAN FD",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// act
+			_, err := encodeCanFrame(tc.frame)
+			// assert
+			require.EqualError(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestDecodeCanFrame_error(t *testing.T) {
+	// act
+	_, err := decodeCanFrame(make([]byte, 10))
+	// assert
+	require.EqualError(t, err, "unexpected size 10 of CAN frame")
+}
+
+func Test_canFiltersToKernel(t *testing.T) {
+	// arrange
+	filters := []gobot.CanFilter{
+		{ID: 0x123, Mask: 0x7FF},
+		{ID: 0x18FF0000, Mask: 0x1FFF0000, Extended: true},
+		{ID: 0x100, Mask: 0x700, Invert: true},
+	}
+	// act
+	got := canFiltersToKernel(filters)
+	// assert
+	assert.Equal(t, []unix.CanFilter{
+		{Id: 0x123, Mask: 0x800007FF},
+		{Id: 0x98FF0000, Mask: 0x9FFF0000},
+		{Id: 0x20000100, Mask: 0x80000700},
+	}, got)
+}
+
+func TestCanSocketDeviceReadWrite(t *testing.T) {
+	// arrange
+	a := NewAccesser()
+	mcsa := a.UseMockCanSocket()
+	d1, err := a.NewCanSocket("vcan0", false)
+	require.NoError(t, err)
+	d2, err := a.NewCanSocket("vcan0", true)
+	require.NoError(t, err)
+	other, err := a.NewCanSocket("vcan1", false)
+	require.NoError(t, err)
+	require.Equal(t, 3, mcsa.OpenSockets())
+	// act: loopback to other sockets of the same interface
+	require.NoError(t, d1.WriteFrame(gobot.CanFrame{ID: 0x100, Data: []byte{0xAA}}))
+	got, err := d2.ReadFrame()
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, uint32(0x100), got.ID)
+	assert.Equal(t, []byte{0xAA}, got.Data)
+	assert.False(t, got.Time.IsZero())
+	assert.Equal(t, []gobot.CanFrame{{ID: 0x100, Data: []byte{0xAA}}}, mcsa.Written())
+	// act: FD frames only for sockets with activated FD frames
+	require.EqualError(t, d1.WriteFrame(gobot.CanFrame{ID: 0x101, FD: true}),
+		"CAN interface 'vcan0': CAN FD frames are not enabled")
+	require.NoError(t, d2.WriteFrame(gobot.CanFrame{ID: 0x101, FD: true, Data: make([]byte, 16)}))
+	require.NoError(t, mcsa.SimulateReceive("vcan0", gobot.CanFrame{ID: 0x102}))
+	got, err = d1.ReadFrame()
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, uint32(0x102), got.ID)
+	// act: error frames can not be sent
+	require.EqualError(t, d1.WriteFrame(gobot.CanFrame{ID: 0x1, Error: true}),
+		"CAN interface 'vcan0': error frames can not be sent")
+	// act: write error
+	mcsa.SetWriteError(true)
+	require.EqualError(t, d1.WriteFrame(gobot.CanFrame{ID: 0x1}), "error while writing to CAN socket in mock")
+	// act: close releases a blocked read
+	readErr := make(chan error)
+	go func() {
+		_, err := other.ReadFrame()
+		readErr <- err
+	}()
+	require.NoError(t, other.Close())
+	select {
+	case err := <-readErr:
+		require.EqualError(t, err, "CAN socket for 'vcan1' closed in mock")
+	case <-time.After(time.Second):
+		require.Fail(t, "read was not released by close")
+	}
+	assert.Equal(t, 2, mcsa.OpenSockets())
+}
+
+func TestCanSocketDeviceFilters(t *testing.T) {
+	// arrange
+	a := NewAccesser()
+	mcsa := a.UseMockCanSocket()
+	d, err := a.NewCanSocket("can0", false)
+	require.NoError(t, err)
+	require.NoError(t, d.SetFilters([]gobot.CanFilter{
+		{ID: 0x200, Mask: 0x7F0},
+		{ID: 0x18FF50E5, Mask: 0x1FFFFFFF, Extended: true},
+	}))
+	require.NoError(t, d.SetErrorFilter(0x04))
+	// act
+	for _, frame := range []gobot.CanFrame{
+		{ID: 0x300},                      // dropped
+		{ID: 0x205},                      // received
+		{ID: 0x200, Extended: true},      // dropped, extended format
+		{ID: 0x18FF50E5, Extended: true}, // received
+		{ID: 0x02, Error: true},          // dropped, error class not activated
+		{ID: 0x04, Error: true, Data: []byte{0, 0x10, 0, 0, 0, 0, 0, 0}}, // received
+	} {
+		require.NoError(t, mcsa.SimulateReceive("can0", frame))
+	}
+	// assert
+	var ids []uint32
+	for i := 0; i < 3; i++ {
+		frame, err := d.ReadFrame()
+		require.NoError(t, err)
+		ids = append(ids, frame.ID)
+	}
+	assert.Equal(t, []uint32{0x205, 0x18FF50E5, 0x04}, ids)
+	// act: no filter means no frame is received
+	require.NoError(t, d.SetFilters(nil))
+	require.NoError(t, mcsa.SimulateReceive("can0", gobot.CanFrame{ID: 0x205}))
+	require.NoError(t, d.Close())
+	_, err = d.ReadFrame()
+	// assert
+	require.Error(t, err)
+}
+
+func TestNewCanSocket_error(t *testing.T) {
+	// arrange
+	a := NewAccesser()
+	mcsa := a.UseMockCanSocket()
+	mcsa.CreateError = true
+	// act
+	_, err := a.NewCanSocket("can0", false)
+	// assert
+	require.EqualError(t, err, "error while open CAN socket in mock")
+}
@@ -0,0 +1,182 @@
+package system
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gobot.io/x/gobot/v2"
+)
+
+// Linux hardware monitoring, see https://docs.kernel.org/hwmon/sysfs-interface.html
+const hwmonClassPath = "/sys/class/hwmon"
+
+var (
+	hwmonDevicePattern  = regexp.MustCompile(`^hwmon\d+$`)
+	hwmonChannelPattern = regexp.MustCompile(`^(temp|in|curr|power|energy|humidity|fan)(\d+)_(input|average)$`)
+)
+
+// hwmonChannelTypes contains the unit and the scale of the raw value for each type of channel, the order of the
+// types is used for sorting of the channels
+var hwmonChannelTypes = []struct {
+	prefix string
+	unit   string
+	scale  float64
+}{
+	{prefix: "temp", unit: "°C", scale: 0.001},
+	{prefix: "in", unit: "V", scale: 0.001},
+	{prefix: "curr", unit: "A", scale: 0.001},
+	{prefix: "power", unit: "W", scale: 0.000001},
+	{prefix: "energy", unit: "J", scale: 0.000001},
+	{prefix: "humidity", unit: "%RH", scale: 0.001},
+	{prefix: "fan", unit: "RPM", scale: 1},
+}
+
+// HwmonDeviceInfo describes a hardware monitoring device found in the system.
+type HwmonDeviceInfo struct {
+	Path string // the sysfs path, e.g. "/sys/class/hwmon/hwmon0"
+	Name string // the name given by the kernel driver, e.g. "cpu_thermal" or "rpi_volt"
+}
+
+// hwmonChannelSysfs contains the attributes of a channel needed for reading
+type hwmonChannelSysfs struct {
+	gobot.HwmonChannel
+	inputPath string
+	typeIndex int
+	number    int
+	scale     float64
+}
+
+// hwmonDeviceSysfs is the implementation of a hardware monitoring device, which is accessed by the hwmon class of
+// the kernel.
+type hwmonDeviceSysfs struct {
+	name     string
+	channels map[string]*hwmonChannelSysfs
+	sfa      *sysfsFileAccess
+}
+
+// findHwmonDevices returns all hardware monitoring devices, sorted by the number of the device
+func findHwmonDevices(fs filesystem) ([]HwmonDeviceInfo, error) {
+	dirs, err := fs.find(hwmonClassPath, hwmonDevicePattern.String())
+	if err != nil {
+		return nil, err
+	}
+
+	var infos []HwmonDeviceInfo
+	known := make(map[string]bool)
+	for _, dir := range dirs {
+		if known[dir] {
+			continue
+		}
+		known[dir] = true
+		info := HwmonDeviceInfo{Path: dir}
+		if name, err := fs.readFile(path.Join(dir, "name")); err == nil {
+			info.Name = strings.TrimSpace(string(name))
+		}
+		infos = append(infos, info)
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		ni, _ := strconv.Atoi(strings.TrimPrefix(path.Base(infos[i].Path), "hwmon"))
+		nj, _ := strconv.Atoi(strings.TrimPrefix(path.Base(infos[j].Path), "hwmon"))
+		return ni < nj
+	})
+	return infos, nil
+}
+
+// newHwmonDeviceSysfs creates the device for the given sysfs path and enumerates all supported channels. Some older
+// drivers provide the attributes in the subdirectory "device".
+func newHwmonDeviceSysfs(fs filesystem, sfa *sysfsFileAccess, info HwmonDeviceInfo) (*hwmonDeviceSysfs, error) {
+	d := &hwmonDeviceSysfs{name: info.Name, channels: make(map[string]*hwmonChannelSysfs), sfa: sfa}
+	if err := d.addChannels(fs, info.Path); err != nil {
+		return nil, err
+	}
+	if len(d.channels) == 0 {
+		// an error is not relevant here, because the subdirectory not exists for most devices
+		_ = d.addChannels(fs, path.Join(info.Path, "device"))
+	}
+
+	if len(d.channels) == 0 {
+		return nil, fmt.Errorf("no supported channels found for hwmon device '%s' at '%s'", info.Name, info.Path)
+	}
+	return d, nil
+}
+
+// Name returns the name of the device. Implements gobot.HwmonSystemDevicer.
+func (d *hwmonDeviceSysfs) Name() string {
+	return d.name
+}
+
+// Channels returns all channels, sorted by type and number. Implements gobot.HwmonSystemDevicer.
+func (d *hwmonDeviceSysfs) Channels() []gobot.HwmonChannel {
+	sorted := make([]*hwmonChannelSysfs, 0, len(d.channels))
+	for _, ch := range d.channels {
+		sorted = append(sorted, ch)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].typeIndex != sorted[j].typeIndex {
+			return sorted[i].typeIndex < sorted[j].typeIndex
+		}
+		return sorted[i].number < sorted[j].number
+	})
+
+	channels := make([]gobot.HwmonChannel, len(sorted))
+	for i, ch := range sorted {
+		channels[i] = ch.HwmonChannel
+	}
+	return channels
+}
+
+// Read reads the value of the given channel, e.g. "temp1", and scales it to the unit of the channel.
+// Implements gobot.HwmonSystemDevicer.
+func (d *hwmonDeviceSysfs) Read(channel string) (float64, error) {
+	ch, ok := d.channels[channel]
+	if !ok {
+		return 0, fmt.Errorf("channel '%s' not available for hwmon device '%s'", channel, d.name)
+	}
+
+	val, err := d.sfa.readInteger(ch.inputPath)
+	if err != nil {
+		return 0, err
+	}
+	return float64(val) * ch.scale, nil
+}
+
+func (d *hwmonDeviceSysfs) addChannels(fs filesystem, dir string) error {
+	items, err := fs.find(dir, hwmonChannelPattern.String())
+	if err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		m := hwmonChannelPattern.FindStringSubmatch(path.Base(item))
+		name := m[1] + m[2]
+		if ch, ok := d.channels[name]; ok && (ch.inputPath == item || m[3] != "input") {
+			// the "input" attribute is preferred over "average", the mocked file system returns duplicates
+			continue
+		}
+
+		typeIndex := 0
+		for i, t := range hwmonChannelTypes {
+			if t.prefix == m[1] {
+				typeIndex = i
+			}
+		}
+		number, _ := strconv.Atoi(m[2])
+		ch := &hwmonChannelSysfs{
+			HwmonChannel: gobot.HwmonChannel{Name: name, Unit: hwmonChannelTypes[typeIndex].unit},
+			inputPath:    item,
+			typeIndex:    typeIndex,
+			number:       number,
+			scale:        hwmonChannelTypes[typeIndex].scale,
+		}
+		if label, err := fs.readFile(path.Join(dir, name+"_label")); err == nil {
+			ch.Label = strings.TrimSpace(string(label))
+		}
+		d.channels[name] = ch
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package system
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+)
+
+// make sure that this implementation fulfills all the required interfaces
+var _ gobot.HwmonSystemDevicer = (*hwmonDeviceSysfs)(nil)
+
+func initTestHwmonMockFilesystem() *MockFilesystem {
+	fs := newMockFilesystem([]string{
+		"/sys/class/hwmon/hwmon0/name",
+		"/sys/class/hwmon/hwmon0/temp1_input",
+		"/sys/class/hwmon/hwmon1/name",
+		"/sys/class/hwmon/hwmon1/in0_input",
+		"/sys/class/hwmon/hwmon1/in0_label",
+		"/sys/class/hwmon/hwmon1/temp2_input",
+		"/sys/class/hwmon/hwmon1/temp2_label",
+		"/sys/class/hwmon/hwmon1/temp2_max",
+		"/sys/class/hwmon/hwmon1/temp10_input",
+		"/sys/class/hwmon/hwmon1/fan1_input",
+		"/sys/class/hwmon/hwmon1/power1_average",
+		"/sys/class/hwmon/hwmon1/power1_input",
+		"/sys/class/hwmon/hwmon1/curr1_input",
+		"/sys/class/hwmon/hwmon1/pwm1",
+		"/sys/class/hwmon/hwmon10/name",
+		"/sys/class/hwmon/hwmon10/device/humidity1_input",
+		"/sys/class/hwmon/hwmon2/name",
+	})
+	fs.Files["/sys/class/hwmon/hwmon0/name"].Contents = "cpu_thermal\n"
+	fs.Files["/sys/class/hwmon/hwmon0/temp1_input"].Contents = "48686\n"
+	fs.Files["/sys/class/hwmon/hwmon1/name"].Contents = "ina3221\n"
+	fs.Files["/sys/class/hwmon/hwmon1/in0_input"].Contents = "5104\n"
+	fs.Files["/sys/class/hwmon/hwmon1/in0_label"].Contents = "VDD_IN\n"
+	fs.Files["/sys/class/hwmon/hwmon1/temp2_input"].Contents = "35250\n"
+	fs.Files["/sys/class/hwmon/hwmon1/temp2_label"].Contents = "Board\n"
+	fs.Files["/sys/class/hwmon/hwmon1/fan1_input"].Contents = "2400\n"
+	fs.Files["/sys/class/hwmon/hwmon1/power1_input"].Contents = "2500000\n"
+	fs.Files["/sys/class/hwmon/hwmon1/curr1_input"].Contents = "490\n"
+	fs.Files["/sys/class/hwmon/hwmon10/name"].Contents = "sht3x\n"
+	fs.Files["/sys/class/hwmon/hwmon10/device/humidity1_input"].Contents = "45500\n"
+	fs.Files["/sys/class/hwmon/hwmon2/name"].Contents = "rpi_volt\n"
+	return fs
+}
+
+func Test_findHwmonDevices(t *testing.T) {
+	// arrange
+	fs := initTestHwmonMockFilesystem()
+	// act
+	got, err := findHwmonDevices(fs)
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, []HwmonDeviceInfo{
+		{Path: "/sys/class/hwmon/hwmon0", Name: "cpu_thermal"},
+		{Path: "/sys/class/hwmon/hwmon1", Name: "ina3221"},
+		{Path: "/sys/class/hwmon/hwmon2", Name: "rpi_volt"},
+		{Path: "/sys/class/hwmon/hwmon10", Name: "sht3x"},
+	}, got)
+}
+
+func Test_newHwmonDeviceSysfs(t *testing.T) {
+	tests := map[string]struct {
+		info    HwmonDeviceInfo
+		want    []gobot.HwmonChannel
+		wantErr string
+	}{
+		"single_channel": {
+			info: HwmonDeviceInfo{Path: "/sys/class/hwmon/hwmon0", Name: "cpu_thermal"},
+			want: []gobot.HwmonChannel{{Name: "temp1", Unit: "°C"}},
+		},
+		"sorted_by_type_and_number": {
+			info: HwmonDeviceInfo{Path: "/sys/class/hwmon/hwmon1", Name: "ina3221"},
+			want: []gobot.HwmonChannel{
+				{Name: "temp2", Label: "Board", Unit: "°C"},
+				{Name: "temp10", Unit: "°C"},
+				{Name: "in0", Label: "VDD_IN", Unit: "V"},
+				{Name: "curr1", Unit: "A"},
+				{Name: "power1", Unit: "W"},
+				{Name: "fan1", Unit: "RPM"},
+			},
+		},
+		"device_subdirectory": {
+			info: HwmonDeviceInfo{Path: "/sys/class/hwmon/hwmon10", Name: "sht3x"},
+			want: []gobot.HwmonChannel{{Name: "humidity1", Unit: "%RH"}},
+		},
+		"error_no_channels": {
+			info:    HwmonDeviceInfo{Path: "/sys/class/hwmon/hwmon2", Name: "rpi_volt"},
+			wantErr: "no supported channels found for hwmon device 'rpi_volt' at '/sys/class/hwmon/hwmon2'",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			fs := initTestHwmonMockFilesystem()
+			sfa := &sysfsFileAccess{fs: fs, readBufLen: 32}
+			// act
+			d, err := newHwmonDeviceSysfs(fs, sfa, tc.info)
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.info.Name, d.Name())
+			assert.Equal(t, tc.want, d.Channels())
+		})
+	}
+}
+
+func TestHwmonDeviceSysfsRead(t *testing.T) {
+	tests := map[string]struct {
+		channel string
+		want    float64
+		wantErr string
+	}{
+		"temperature": {channel: "temp2", want: 35.25},
+		"voltage":     {channel: "in0", want: 5.104},
+		"current":     {channel: "curr1", want: 0.49},
+		"power":       {channel: "power1", want: 2.5},
+		"fan":         {channel: "fan1", want: 2400},
+		"error_unknown_channel": {
+			channel: "temp3",
+			wantErr: "channel 'temp3' not available for hwmon device 'ina3221'",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			fs := initTestHwmonMockFilesystem()
+			sfa := &sysfsFileAccess{fs: fs, readBufLen: 32}
+			d, err := newHwmonDeviceSysfs(fs, sfa, HwmonDeviceInfo{Path: "/sys/class/hwmon/hwmon1", Name: "ina3221"})
+			require.NoError(t, err)
+			// act
+			got, err := d.Read(tc.channel)
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.InDelta(t, tc.want, got, 0.000001)
+		})
+	}
+}
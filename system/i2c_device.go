@@ -1,10 +1,12 @@
 package system
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"os"
 	"sync"
+	"syscall"
 	"unsafe"
 )
 
@@ -25,6 +27,7 @@ const (
 
 	// From  /usr/include/linux/i2c.h:
 	// Adapter functionality
+	I2C_FUNC_SMBUS_QUICK            = 0x00010000
 	I2C_FUNC_SMBUS_READ_BYTE        = 0x00020000
 	I2C_FUNC_SMBUS_WRITE_BYTE       = 0x00040000
 	I2C_FUNC_SMBUS_READ_BYTE_DATA   = 0x00080000
@@ -36,6 +39,7 @@ const (
 	I2C_FUNC_SMBUS_READ_I2C_BLOCK   = 0x04000000 // I2C-like block transfer with 1-byte reg. addr.
 	I2C_FUNC_SMBUS_WRITE_I2C_BLOCK  = 0x08000000 // I2C-like block transfer with 1-byte reg. addr.
 	// Transaction types
+	I2C_SMBUS_QUICK            = 0
 	I2C_SMBUS_BYTE             = 1
 	I2C_SMBUS_BYTE_DATA        = 2
 	I2C_SMBUS_WORD_DATA        = 3
@@ -110,6 +114,42 @@ func (d *i2cDevice) ReadByte(address int) (byte, error) {
 	return data, err
 }
 
+// ProbeAddress returns true, if a device acknowledges the given address. An address, which is already used by a kernel
+// driver, is reported as present, like "UU" of "i2cdetect". Implements gobot.I2cProber.
+func (d *i2cDevice) ProbeAddress(address int) (bool, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	useRead := i2cProbeUsesRead(address)
+	if err := d.queryFunctionality(I2C_FUNC_SMBUS_QUICK|I2C_FUNC_SMBUS_READ_BYTE, "probe"); err != nil {
+		return false, err
+	}
+	if d.funcs&I2C_FUNC_SMBUS_QUICK == 0 {
+		useRead = true
+	}
+	if useRead && d.funcs&I2C_FUNC_SMBUS_READ_BYTE == 0 {
+		return false, fmt.Errorf("SMBus read byte not supported, needed to probe address 0x%02X", address)
+	}
+
+	if err := d.setAddress(address); err != nil {
+		if errors.Is(err, syscall.EBUSY) {
+			// the address is used by a kernel driver, so a device is present
+			return true, nil
+		}
+		return false, err
+	}
+
+	var err error
+	if useRead {
+		var data uint8
+		err = d.smbusAccess(address, I2C_SMBUS_READ, 0, I2C_SMBUS_BYTE, unsafe.Pointer(&data))
+	} else {
+		err = d.smbusAccess(address, I2C_SMBUS_WRITE, 0, I2C_SMBUS_QUICK, nil)
+	}
+	// an error means, that the address was not acknowledged
+	return err == nil, nil
+}
+
 // ReadByteData reads a byte from the given register of an i2c device.
 func (d *i2cDevice) ReadByteData(address int, reg uint8) (uint8, error) {
 	d.mutex.Lock()
@@ -320,6 +360,13 @@ func (d *i2cDevice) read(address int, b []byte) (int, error) {
 	return d.file.Read(b)
 }
 
+// i2cProbeUsesRead returns true for the address ranges, where "i2cdetect" uses the "read byte" command instead of
+// "quick write" for probing, because the quick write can corrupt some EEPROMs (0x50-0x5F) or can change the write
+// protection of SPD EEPROMs (0x30-0x37).
+func i2cProbeUsesRead(address int) bool {
+	return (address >= 0x30 && address <= 0x37) || (address >= 0x50 && address <= 0x5F)
+}
+
 func (d *i2cDevice) queryFunctionality(requested uint64, sender string) error {
 	// lazy initialization
 	if d.funcs == 0 {
@@ -383,7 +430,7 @@ func (d *i2cDevice) syscallIoctl(signal uintptr, payload unsafe.Pointer, address
 		return err
 	}
 	if _, _, errno := d.sys.syscall(Syscall_SYS_IOCTL, d.file, signal, payload, uint16(address)); errno != 0 {
-		return fmt.Errorf("%s failed with syscall.Errno %v", sender, errno)
+		return fmt.Errorf("%s failed with syscall.Errno %w", sender, errno)
 	}
 
 	return nil
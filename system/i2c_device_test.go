@@ -2,6 +2,7 @@ package system
 
 import (
 	"os"
+	"syscall"
 	"testing"
 	"unsafe"
 
@@ -162,6 +163,100 @@ func TestReadByte(t *testing.T) {
 	}
 }
 
+func TestProbeAddress(t *testing.T) {
+	tests := map[string]struct {
+		funcs        uint64
+		address      int
+		syscallImpl  func(trap, a1, a2 uintptr, a3 unsafe.Pointer) (r1, r2 uintptr, err SyscallErrno)
+		want         bool
+		wantReadByte bool
+		wantErr      string
+	}{
+		"quick_write": {
+			funcs:   I2C_FUNC_SMBUS_QUICK | I2C_FUNC_SMBUS_READ_BYTE,
+			address: 0x20,
+			want:    true,
+		},
+		"read_byte_for_eeprom": {
+			funcs:        I2C_FUNC_SMBUS_QUICK | I2C_FUNC_SMBUS_READ_BYTE,
+			address:      0x50,
+			want:         true,
+			wantReadByte: true,
+		},
+		"read_byte_for_write_protection": {
+			funcs:        I2C_FUNC_SMBUS_QUICK | I2C_FUNC_SMBUS_READ_BYTE,
+			address:      0x37,
+			want:         true,
+			wantReadByte: true,
+		},
+		"read_byte_if_quick_not_supported": {
+			funcs:        I2C_FUNC_SMBUS_READ_BYTE,
+			address:      0x20,
+			want:         true,
+			wantReadByte: true,
+		},
+		"not_acknowledged": {
+			funcs:       I2C_FUNC_SMBUS_QUICK | I2C_FUNC_SMBUS_READ_BYTE,
+			address:     0x20,
+			syscallImpl: getSyscallFuncImpl(0x04),
+			want:        false,
+		},
+		"busy_address": {
+			funcs:   I2C_FUNC_SMBUS_QUICK | I2C_FUNC_SMBUS_READ_BYTE,
+			address: 0x20,
+			syscallImpl: func(trap, a1, a2 uintptr, a3 unsafe.Pointer) (uintptr, uintptr, SyscallErrno) {
+				if a2 == I2C_SLAVE {
+					return 0, 0, SyscallErrno(syscall.EBUSY)
+				}
+				return 0, 0, 0
+			},
+			want: true,
+		},
+		"error_set_address": {
+			funcs:       I2C_FUNC_SMBUS_QUICK | I2C_FUNC_SMBUS_READ_BYTE,
+			address:     0x20,
+			syscallImpl: getSyscallFuncImpl(0x02),
+			wantErr:     "Setting address failed with syscall.Errno operation not permitted",
+		},
+		"error_read_byte_not_supported": {
+			funcs:   I2C_FUNC_SMBUS_QUICK,
+			address: 0x50,
+			wantErr: "SMBus read byte not supported, needed to probe address 0x50",
+		},
+		"error_not_supported": {
+			address: 0x20,
+			wantErr: "SMBus probe not supported",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, msc := initTestI2cDeviceWithMockedSys()
+			msc.Impl = tc.syscallImpl
+			d.funcs = tc.funcs
+			// act
+			got, err := d.ProbeAddress(tc.address)
+			// assert
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+			if tc.want && tc.syscallImpl == nil {
+				assert.Equal(t, uintptr(I2C_SMBUS), msc.lastSignal)
+				if tc.wantReadByte {
+					assert.Equal(t, byte(I2C_SMBUS_READ), msc.smbus.readWrite)
+					assert.Equal(t, uint32(I2C_SMBUS_BYTE), msc.smbus.protocol)
+				} else {
+					assert.Equal(t, byte(I2C_SMBUS_WRITE), msc.smbus.readWrite)
+					assert.Equal(t, uint32(I2C_SMBUS_QUICK), msc.smbus.protocol)
+				}
+			}
+		})
+	}
+}
+
 func TestReadByteData(t *testing.T) {
 	tests := map[string]struct {
 		funcs       uint64
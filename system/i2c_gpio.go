@@ -0,0 +1,508 @@
+package system
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+
+	"gobot.io/x/gobot/v2"
+)
+
+const (
+	i2cGpioDebug = false
+
+	// i2cGpioDefaultSpeed is the standard mode of i2c, used when no speed is given
+	i2cGpioDefaultSpeed = 100000
+	// i2cGpioMaxSpeed is the fast mode of i2c, most likely not reachable with GPIO's, so we limit to this value
+	i2cGpioMaxSpeed = 400000
+	// i2cGpioClockStretchTimeout is the maximum time a device can hold SCL low, according to the SMBus timeout
+	i2cGpioClockStretchTimeout = 35 * time.Millisecond
+)
+
+type i2cGpioConfig struct {
+	pinProvider gobot.DigitalPinnerProvider
+	sdaPinID    string
+	sclPinID    string
+}
+
+// i2cGpio is the implementation of the i2c bus interface by using GPIO's (bit banging). Both lines are driven in
+// open drain mode by switching the direction of the pin: for "low" the pin is an output with value 0, for "high" the
+// pin becomes an input, so the line is pulled up by the external resistor. This allows also the detection of clock
+// stretching by reading back the SCL line.
+type i2cGpio struct {
+	cfg i2cGpioConfig
+	// time between clock edges (i.e. half the cycle time)
+	tclk   time.Duration
+	sdaPin gobot.DigitalPinner
+	sclPin gobot.DigitalPinner
+	mutex  sync.Mutex
+}
+
+// NewI2cGpioDevice returns a new i2c bus based on the given GPIO's for SDA and SCL. The speed is given in Hz, a value of
+// zero or smaller will use the standard mode of 100 kHz. External pull-up resistors are needed for both lines.
+func (a *Accesser) NewI2cGpioDevice(
+	p gobot.DigitalPinnerProvider,
+	sdaPinID, sclPinID string,
+	maxSpeed int64,
+) (gobot.I2cSystemDevicer, error) {
+	if p == nil {
+		return nil, fmt.Errorf("a digital pin provider is needed for the i2c bus on GPIO's")
+	}
+	cfg := i2cGpioConfig{
+		pinProvider: p,
+		sdaPinID:    sdaPinID,
+		sclPinID:    sclPinID,
+	}
+	return newI2cGpio(cfg, maxSpeed)
+}
+
+// newI2cGpio creates and returns a new i2c bus based on given GPIO's.
+func newI2cGpio(cfg i2cGpioConfig, maxSpeed int64) (*i2cGpio, error) {
+	i := &i2cGpio{cfg: cfg}
+	i.initializeTime(maxSpeed)
+	return i, i.initializeGpios()
+}
+
+func (i *i2cGpio) initializeTime(maxSpeed int64) {
+	if maxSpeed <= 0 {
+		maxSpeed = i2cGpioDefaultSpeed
+	}
+	if maxSpeed > i2cGpioMaxSpeed {
+		if i2cGpioDebug {
+			fmt.Printf("reduce i2c speed for GPIO usage to %d Hz\n", i2cGpioMaxSpeed)
+		}
+		maxSpeed = i2cGpioMaxSpeed
+	}
+	// maxSpeed is given in Hz, tclk is half the cycle time, tclk=1/(2*f), tclk[ns]=1 000 000 000/(2*maxSpeed)
+	i.tclk = time.Duration(1000000000/2/maxSpeed) * time.Nanosecond
+	if i2cGpioDebug {
+		fmt.Println("clk", i.tclk)
+	}
+}
+
+// ReadByte reads a byte from the current register of an i2c device. Implements gobot.I2cSystemDevicer.
+func (i *i2cGpio) ReadByte(address int) (byte, error) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	buf := []byte{0}
+	err := i.transaction(func() error {
+		return i.readFromAddress(address, buf)
+	})
+	return buf[0], err
+}
+
+// ProbeAddress returns true, if a device acknowledges the given address. Like "i2cdetect" the sequence of
+// "quick write" is used, but "read byte" for the address ranges of EEPROMs. Implements gobot.I2cProber.
+func (i *i2cGpio) ProbeAddress(address int) (bool, error) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	if address < 0 || address > 0x7F {
+		return false, fmt.Errorf("i2c address 0x%X is not a valid 7 bit address", address)
+	}
+
+	read := i2cProbeUsesRead(address)
+	addrByte := byte(address << 1)
+	if read {
+		addrByte |= 0x01
+	}
+
+	var ack bool
+	err := i.transaction(func() error {
+		var err error
+		if ack, err = i.writeByte(addrByte); err != nil || !ack || !read {
+			return err
+		}
+		_, err = i.readByte(false)
+		return err
+	})
+	return ack && err == nil, err
+}
+
+// ReadByteData reads a byte from the given register of an i2c device. Implements gobot.I2cSystemDevicer.
+func (i *i2cGpio) ReadByteData(address int, reg uint8) (uint8, error) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	buf := []byte{0}
+	err := i.readRegister(address, reg, buf)
+	return buf[0], err
+}
+
+// ReadWordData reads a 16 bit value starting from the given register of an i2c device.
+// Implements gobot.I2cSystemDevicer.
+func (i *i2cGpio) ReadWordData(address int, reg uint8) (uint16, error) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	buf := []byte{0, 0}
+	err := i.readRegister(address, reg, buf)
+	return uint16(buf[0]) | uint16(buf[1])<<8, err
+}
+
+// ReadBlockData fills the given buffer with reads starting from the given register of an i2c device.
+// Implements gobot.I2cSystemDevicer.
+func (i *i2cGpio) ReadBlockData(address int, reg uint8, data []byte) error {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	if len(data) > 32 {
+		return fmt.Errorf("Reading blocks larger than 32 bytes (%v) not supported", len(data))
+	}
+
+	return i.readRegister(address, reg, data)
+}
+
+// WriteByte writes the given byte value to the current register of an i2c device. Implements gobot.I2cSystemDevicer.
+func (i *i2cGpio) WriteByte(address int, val byte) error {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	return i.transaction(func() error {
+		return i.writeToAddress(address, []byte{val})
+	})
+}
+
+// WriteByteData writes the given byte value to the given register of an i2c device.
+// Implements gobot.I2cSystemDevicer.
+func (i *i2cGpio) WriteByteData(address int, reg uint8, val uint8) error {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	return i.transaction(func() error {
+		return i.writeToAddress(address, []byte{reg, val})
+	})
+}
+
+// WriteWordData writes the given 16 bit value starting from the given register of an i2c device.
+// Implements gobot.I2cSystemDevicer.
+func (i *i2cGpio) WriteWordData(address int, reg uint8, val uint16) error {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	return i.transaction(func() error {
+		return i.writeToAddress(address, []byte{reg, byte(val & 0xFF), byte(val >> 8)})
+	})
+}
+
+// WriteBlockData writes the given buffer starting from the given register of an i2c device.
+// Implements gobot.I2cSystemDevicer.
+func (i *i2cGpio) WriteBlockData(address int, reg uint8, data []byte) error {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	if len(data) > 32 {
+		return fmt.Errorf("Writing blocks larger than 32 bytes (%v) not supported", len(data))
+	}
+
+	buf := make([]byte, len(data)+1)
+	copy(buf[1:], data)
+	buf[0] = reg
+
+	return i.transaction(func() error {
+		return i.writeToAddress(address, buf)
+	})
+}
+
+// WriteBytes writes the given buffer starting from the current register of an i2c device.
+// Implements gobot.I2cSystemDevicer.
+func (i *i2cGpio) WriteBytes(address int, data []byte) error {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	return i.transaction(func() error {
+		return i.writeToAddress(address, data)
+	})
+}
+
+// Read implements direct i2c read operations. Implements gobot.I2cSystemDevicer.
+func (i *i2cGpio) Read(address int, b []byte) (int, error) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	if err := i.transaction(func() error { return i.readFromAddress(address, b) }); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+// Write implements direct i2c write operations. Implements gobot.I2cSystemDevicer.
+func (i *i2cGpio) Write(address int, b []byte) (int, error) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	if err := i.transaction(func() error { return i.writeToAddress(address, b) }); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+// Close the i2c connection. Implements gobot.I2cSystemDevicer.
+func (i *i2cGpio) Close() error {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	var err error
+	if i.sclPin != nil {
+		if e := i.sclPin.Unexport(); e != nil {
+			err = multierror.Append(err, e)
+		}
+	}
+	if i.sdaPin != nil {
+		if e := i.sdaPin.Unexport(); e != nil {
+			err = multierror.Append(err, e)
+		}
+	}
+	return err
+}
+
+func (cfg *i2cGpioConfig) String() string {
+	return fmt.Sprintf("sda: %s, scl: %s", cfg.sdaPinID, cfg.sclPinID)
+}
+
+// readRegister implements the sequence "S Addr Wr [A] Comm [A] Sr Addr Rd [A] [Data] A ... [Data] NA P"
+func (i *i2cGpio) readRegister(address int, reg uint8, data []byte) error {
+	return i.transaction(func() error {
+		if err := i.writeToAddress(address, []byte{reg}); err != nil {
+			return err
+		}
+		if err := i.repeatedStart(); err != nil {
+			return err
+		}
+		return i.readFromAddress(address, data)
+	})
+}
+
+// transaction embeds the given function between start and stop condition. The stop condition is always sent,
+// also when an error occurs, to release the bus.
+func (i *i2cGpio) transaction(f func() error) error {
+	var err error
+	if err = i.start(); err == nil {
+		err = f()
+	}
+	if e := i.stop(); e != nil {
+		err = multierror.Append(err, e)
+	}
+	return err
+}
+
+// writeToAddress sends the address with write flag and all given bytes, each byte needs to be acknowledged
+func (i *i2cGpio) writeToAddress(address int, data []byte) error {
+	if err := i.writeAddress(address, false); err != nil {
+		return err
+	}
+	for idx, b := range data {
+		ack, err := i.writeByte(b)
+		if err != nil {
+			return err
+		}
+		if !ack {
+			return fmt.Errorf("NACK received for data byte %d (0x%02X) from address 0x%02X", idx, b, address)
+		}
+	}
+	return nil
+}
+
+// readFromAddress sends the address with read flag and reads all bytes, each byte except the last is acknowledged
+func (i *i2cGpio) readFromAddress(address int, data []byte) error {
+	if err := i.writeAddress(address, true); err != nil {
+		return err
+	}
+	for idx := range data {
+		val, err := i.readByte(idx < len(data)-1)
+		if err != nil {
+			return err
+		}
+		data[idx] = val
+	}
+	return nil
+}
+
+func (i *i2cGpio) writeAddress(address int, read bool) error {
+	if address < 0 || address > 0x7F {
+		return fmt.Errorf("i2c address 0x%X is not a valid 7 bit address", address)
+	}
+	addrByte := byte(address << 1)
+	if read {
+		addrByte |= 0x01
+	}
+	ack, err := i.writeByte(addrByte)
+	if err != nil {
+		return err
+	}
+	if !ack {
+		return fmt.Errorf("NACK received for address 0x%02X", address)
+	}
+	return nil
+}
+
+// start sends the start condition: SDA goes low while SCL is high
+func (i *i2cGpio) start() error {
+	if err := i.sdaRelease(); err != nil {
+		return err
+	}
+	if err := i.sclRelease(); err != nil {
+		return err
+	}
+	if v, err := i.sdaPin.Read(); err != nil || v == 0 {
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("i2c bus on GPIO's is busy, SDA is low (%s)", i.cfg.String())
+	}
+	time.Sleep(i.tclk)
+	if err := i.sdaLow(); err != nil {
+		return err
+	}
+	time.Sleep(i.tclk)
+	return i.sclLow()
+}
+
+// repeatedStart sends a start condition without a previous stop condition
+func (i *i2cGpio) repeatedStart() error {
+	if err := i.sdaRelease(); err != nil {
+		return err
+	}
+	time.Sleep(i.tclk)
+	if err := i.sclRelease(); err != nil {
+		return err
+	}
+	time.Sleep(i.tclk)
+	if err := i.sdaLow(); err != nil {
+		return err
+	}
+	time.Sleep(i.tclk)
+	return i.sclLow()
+}
+
+// stop sends the stop condition: SDA goes high while SCL is high
+func (i *i2cGpio) stop() error {
+	if err := i.sdaLow(); err != nil {
+		return err
+	}
+	time.Sleep(i.tclk)
+	if err := i.sclRelease(); err != nil {
+		return err
+	}
+	time.Sleep(i.tclk)
+	if err := i.sdaRelease(); err != nil {
+		return err
+	}
+	time.Sleep(i.tclk)
+	return nil
+}
+
+// writeByte sends the byte, starting with MSB, and returns true if the device has acknowledged
+func (i *i2cGpio) writeByte(b byte) (bool, error) {
+	for bitMask := uint8(0x80); bitMask > 0; bitMask >>= 1 {
+		if err := i.writeBit(b&bitMask != 0); err != nil {
+			return false, err
+		}
+	}
+	nack, err := i.readBit()
+	return !nack, err
+}
+
+// readByte reads the byte, starting with MSB, and sends an ACK or NACK afterwards
+func (i *i2cGpio) readByte(ack bool) (byte, error) {
+	var b byte
+	for idx := 0; idx < 8; idx++ {
+		bit, err := i.readBit()
+		if err != nil {
+			return 0, err
+		}
+		b <<= 1
+		if bit {
+			b |= 0x01
+		}
+	}
+	return b, i.writeBit(!ack)
+}
+
+func (i *i2cGpio) writeBit(bit bool) error {
+	var err error
+	if bit {
+		err = i.sdaRelease()
+	} else {
+		err = i.sdaLow()
+	}
+	if err != nil {
+		return err
+	}
+	time.Sleep(i.tclk)
+	if err := i.sclRelease(); err != nil {
+		return err
+	}
+	time.Sleep(i.tclk)
+	return i.sclLow()
+}
+
+func (i *i2cGpio) readBit() (bool, error) {
+	if err := i.sdaRelease(); err != nil {
+		return false, err
+	}
+	time.Sleep(i.tclk)
+	if err := i.sclRelease(); err != nil {
+		return false, err
+	}
+	v, err := i.sdaPin.Read()
+	if err != nil {
+		return false, err
+	}
+	time.Sleep(i.tclk)
+	return v != 0, i.sclLow()
+}
+
+// sclRelease lets the SCL line going high by the pull-up and waits as long as the device stretches the clock
+func (i *i2cGpio) sclRelease() error {
+	if err := i.sclPin.ApplyOptions(WithPinDirectionInput()); err != nil {
+		return err
+	}
+	start := time.Now()
+	for {
+		v, err := i.sclPin.Read()
+		if err != nil {
+			return err
+		}
+		if v != 0 {
+			return nil
+		}
+		if time.Since(start) > i2cGpioClockStretchTimeout {
+			return fmt.Errorf("timeout of %s reached while waiting for SCL high (%s)", i2cGpioClockStretchTimeout,
+				i.cfg.String())
+		}
+		time.Sleep(i.tclk)
+	}
+}
+
+func (i *i2cGpio) sclLow() error {
+	return i.sclPin.ApplyOptions(WithPinDirectionOutput(0))
+}
+
+func (i *i2cGpio) sdaRelease() error {
+	return i.sdaPin.ApplyOptions(WithPinDirectionInput())
+}
+
+func (i *i2cGpio) sdaLow() error {
+	return i.sdaPin.ApplyOptions(WithPinDirectionOutput(0))
+}
+
+func (i *i2cGpio) initializeGpios() error {
+	var err error
+	// both lines are released (input) in idle state
+	i.sdaPin, err = i.cfg.pinProvider.DigitalPin(i.cfg.sdaPinID)
+	if err != nil {
+		return err
+	}
+	if err := i.sdaRelease(); err != nil {
+		return err
+	}
+	i.sclPin, err = i.cfg.pinProvider.DigitalPin(i.cfg.sclPinID)
+	if err != nil {
+		return err
+	}
+	return i.sclPin.ApplyOptions(WithPinDirectionInput())
+}
@@ -0,0 +1,410 @@
+package system
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+)
+
+// make sure that this implementation fulfills all the required interfaces
+var _ gobot.I2cSystemDevicer = (*i2cGpio)(nil)
+
+const i2cGpioTestSlaveAddress = 0x42
+
+// i2cGpioTestBus simulates the open drain lines SDA and SCL together with a simple device with 256 registers.
+type i2cGpioTestBus struct {
+	mtx             sync.Mutex
+	sdaMasterHigh   bool
+	sclMasterHigh   bool
+	sdaSlaveHigh    bool
+	sclSlaveHigh    bool
+	stretchReads    int // count of SCL reads, the slave holds SCL low after release by master, -1 for infinite
+	lastSda         bool
+	lastScl         bool
+	receiving       bool
+	transmitting    bool
+	isAddress       bool
+	readMode        bool
+	isFirstData     bool
+	ackDriven       bool
+	bitIdx          int
+	shift           byte
+	txByte          byte
+	register        byte
+	memory          [256]byte
+	startConditions int
+	stopConditions  int
+}
+
+type i2cGpioTestPin struct {
+	bus      *i2cGpioTestBus
+	isSda    bool
+	exported bool
+}
+
+func newI2cGpioTestBus() *i2cGpioTestBus {
+	return &i2cGpioTestBus{
+		sdaMasterHigh: true, sclMasterHigh: true, sdaSlaveHigh: true, sclSlaveHigh: true,
+		lastSda: true, lastScl: true,
+	}
+}
+
+func (b *i2cGpioTestBus) DigitalPin(id string) (gobot.DigitalPinner, error) {
+	switch id {
+	case "sda":
+		return &i2cGpioTestPin{bus: b, isSda: true}, nil
+	case "scl":
+		return &i2cGpioTestPin{bus: b}, nil
+	}
+	return nil, fmt.Errorf("unknown pin '%s'", id)
+}
+
+func (p *i2cGpioTestPin) ApplyOptions(options ...func(gobot.DigitalPinOptioner) bool) error {
+	p.bus.mtx.Lock()
+	defer p.bus.mtx.Unlock()
+
+	cfg := newDigitalPinConfig("", options...)
+	high := cfg.direction == IN || cfg.outInitialState != 0
+	if p.isSda {
+		p.bus.sdaMasterHigh = high
+	} else {
+		if high && !p.bus.sclMasterHigh && p.bus.stretchReads != 0 {
+			p.bus.sclSlaveHigh = false
+		}
+		p.bus.sclMasterHigh = high
+	}
+	p.bus.update()
+	return nil
+}
+
+func (p *i2cGpioTestPin) Read() (int, error) {
+	p.bus.mtx.Lock()
+	defer p.bus.mtx.Unlock()
+
+	if p.isSda {
+		return boolToInt(p.bus.sda()), nil
+	}
+	if !p.bus.sclSlaveHigh {
+		if p.bus.stretchReads > 0 {
+			p.bus.stretchReads--
+		}
+		if p.bus.stretchReads == 0 {
+			p.bus.sclSlaveHigh = true
+			p.bus.update()
+		}
+	}
+	return boolToInt(p.bus.scl()), nil
+}
+
+func (p *i2cGpioTestPin) Write(int) error { return fmt.Errorf("write is not used for open drain") }
+func (p *i2cGpioTestPin) Export() error   { p.exported = true; return nil }
+func (p *i2cGpioTestPin) Unexport() error { p.exported = false; return nil }
+
+func (b *i2cGpioTestBus) sda() bool { return b.sdaMasterHigh && b.sdaSlaveHigh }
+func (b *i2cGpioTestBus) scl() bool { return b.sclMasterHigh && b.sclSlaveHigh }
+
+// update detects the conditions and edges and runs the state machine of the simulated device
+func (b *i2cGpioTestBus) update() {
+	sda, scl := b.sda(), b.scl()
+	switch {
+	case scl && b.lastScl && b.lastSda && !sda:
+		b.startCondition()
+	case scl && b.lastScl && !b.lastSda && sda:
+		b.stopConditions++
+		b.receiving, b.transmitting = false, false
+		b.sdaSlaveHigh = true
+	case scl && !b.lastScl:
+		b.risingClock(sda)
+	case !scl && b.lastScl:
+		b.fallingClock()
+	}
+	b.lastSda, b.lastScl = b.sda(), b.scl()
+}
+
+func (b *i2cGpioTestBus) startCondition() {
+	b.startConditions++
+	b.receiving, b.transmitting = true, false
+	b.isAddress, b.ackDriven = true, false
+	b.bitIdx, b.shift = 0, 0
+	b.sdaSlaveHigh = true
+}
+
+func (b *i2cGpioTestBus) risingClock(sda bool) {
+	if b.receiving && b.bitIdx < 8 {
+		b.shift = b.shift<<1 | byte(boolToInt(sda))
+		b.bitIdx++
+	}
+	if b.transmitting && b.bitIdx == 8 && sda {
+		// NACK from master, wait for stop condition
+		b.transmitting = false
+	}
+}
+
+func (b *i2cGpioTestBus) fallingClock() {
+	switch {
+	case b.receiving && b.bitIdx == 8 && !b.ackDriven:
+		if b.isAddress {
+			if b.shift>>1 != i2cGpioTestSlaveAddress {
+				b.receiving = false
+				return
+			}
+			b.readMode = b.shift&0x01 != 0
+		} else {
+			if b.isFirstData {
+				b.register = b.shift
+				b.isFirstData = false
+			} else {
+				b.memory[b.register] = b.shift
+				b.register++
+			}
+		}
+		b.sdaSlaveHigh = false
+		b.ackDriven = true
+	case b.receiving && b.ackDriven:
+		b.sdaSlaveHigh = true
+		b.ackDriven = false
+		b.bitIdx, b.shift = 0, 0
+		if b.isAddress {
+			b.isAddress = false
+			b.isFirstData = true
+			if b.readMode {
+				b.receiving, b.transmitting = false, true
+				b.loadNextByte()
+			}
+		}
+	case b.transmitting && b.bitIdx < 7:
+		b.bitIdx++
+		b.sdaSlaveHigh = b.txByte&(0x80>>b.bitIdx) != 0
+	case b.transmitting && b.bitIdx == 7:
+		b.bitIdx++
+		b.sdaSlaveHigh = true // release for ACK of master
+	case b.transmitting && b.bitIdx == 8:
+		b.loadNextByte()
+	}
+}
+
+func (b *i2cGpioTestBus) loadNextByte() {
+	b.txByte = b.memory[b.register]
+	b.register++
+	b.bitIdx = 0
+	b.sdaSlaveHigh = b.txByte&0x80 != 0
+}
+
+func boolToInt(v bool) int {
+	if v {
+		return 1
+	}
+	return 0
+}
+
+func initTestI2cGpioWithSimulatedBus(t *testing.T) (*i2cGpio, *i2cGpioTestBus) {
+	bus := newI2cGpioTestBus()
+	a := NewAccesser()
+	d, err := a.NewI2cGpioDevice(bus, "sda", "scl", 400000)
+	require.NoError(t, err)
+	//nolint:forcetypeassert // ok here
+	return d.(*i2cGpio), bus
+}
+
+func TestNewI2cGpioDevice(t *testing.T) {
+	tests := map[string]struct {
+		speed    int64
+		wantTclk int64
+	}{
+		"default":     {speed: 0, wantTclk: 5000},
+		"slow":        {speed: 10000, wantTclk: 50000},
+		"limited_max": {speed: 1000000, wantTclk: 1250},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := NewAccesser()
+			// act
+			d, err := a.NewI2cGpioDevice(newI2cGpioTestBus(), "sda", "scl", tc.speed)
+			// assert
+			require.NoError(t, err)
+			//nolint:forcetypeassert // ok here
+			assert.Equal(t, tc.wantTclk, d.(*i2cGpio).tclk.Nanoseconds())
+		})
+	}
+}
+
+func TestNewI2cGpioDevice_error(t *testing.T) {
+	// arrange
+	a := NewAccesser()
+	// act
+	_, errProvider := a.NewI2cGpioDevice(nil, "sda", "scl", 0)
+	_, errPin := a.NewI2cGpioDevice(newI2cGpioTestBus(), "sda", "unknown", 0)
+	// assert
+	require.ErrorContains(t, errProvider, "a digital pin provider is needed")
+	require.ErrorContains(t, errPin, "unknown pin 'unknown'")
+}
+
+func TestI2cGpioWriteAndReadByteData(t *testing.T) {
+	// arrange
+	d, bus := initTestI2cGpioWithSimulatedBus(t)
+	// act
+	errWrite := d.WriteByteData(i2cGpioTestSlaveAddress, 0x10, 0xA5)
+	got, errRead := d.ReadByteData(i2cGpioTestSlaveAddress, 0x10)
+	// assert
+	require.NoError(t, errWrite)
+	require.NoError(t, errRead)
+	assert.Equal(t, uint8(0xA5), bus.memory[0x10])
+	assert.Equal(t, uint8(0xA5), got)
+	assert.Equal(t, 3, bus.startConditions) // includes the repeated start
+	assert.Equal(t, 2, bus.stopConditions)
+	assert.True(t, bus.sda())
+	assert.True(t, bus.scl())
+}
+
+func TestI2cGpioWriteAndReadWordData(t *testing.T) {
+	// arrange
+	d, bus := initTestI2cGpioWithSimulatedBus(t)
+	// act
+	errWrite := d.WriteWordData(i2cGpioTestSlaveAddress, 0x20, 0x1234)
+	got, errRead := d.ReadWordData(i2cGpioTestSlaveAddress, 0x20)
+	// assert
+	require.NoError(t, errWrite)
+	require.NoError(t, errRead)
+	assert.Equal(t, []byte{0x34, 0x12}, bus.memory[0x20:0x22])
+	assert.Equal(t, uint16(0x1234), got)
+}
+
+func TestI2cGpioWriteAndReadBlockData(t *testing.T) {
+	// arrange
+	d, bus := initTestI2cGpioWithSimulatedBus(t)
+	want := []byte{0x01, 0x02, 0x03, 0xFE}
+	got := make([]byte, len(want))
+	// act
+	errWrite := d.WriteBlockData(i2cGpioTestSlaveAddress, 0x30, want)
+	errRead := d.ReadBlockData(i2cGpioTestSlaveAddress, 0x30, got)
+	// assert
+	require.NoError(t, errWrite)
+	require.NoError(t, errRead)
+	assert.Equal(t, want, bus.memory[0x30:0x34])
+	assert.Equal(t, want, got)
+}
+
+func TestI2cGpioBlockData_tooLarge(t *testing.T) {
+	// arrange
+	d, _ := initTestI2cGpioWithSimulatedBus(t)
+	buf := make([]byte, 33)
+	// act & assert
+	require.ErrorContains(t, d.WriteBlockData(i2cGpioTestSlaveAddress, 0, buf), "larger than 32 bytes")
+	require.ErrorContains(t, d.ReadBlockData(i2cGpioTestSlaveAddress, 0, buf), "larger than 32 bytes")
+}
+
+func TestI2cGpioWriteByteAndReadByte(t *testing.T) {
+	// arrange
+	d, bus := initTestI2cGpioWithSimulatedBus(t)
+	bus.memory[0x05] = 0x5A
+	// act
+	errWrite := d.WriteByte(i2cGpioTestSlaveAddress, 0x05) // sets the register pointer
+	got, errRead := d.ReadByte(i2cGpioTestSlaveAddress)
+	// assert
+	require.NoError(t, errWrite)
+	require.NoError(t, errRead)
+	assert.Equal(t, byte(0x5A), got)
+}
+
+func TestI2cGpioWriteAndRead(t *testing.T) {
+	// arrange
+	d, bus := initTestI2cGpioWithSimulatedBus(t)
+	got := make([]byte, 2)
+	// act
+	n, errWrite := d.Write(i2cGpioTestSlaveAddress, []byte{0x40, 0x11, 0x22})
+	errPointer := d.WriteBytes(i2cGpioTestSlaveAddress, []byte{0x40})
+	m, errRead := d.Read(i2cGpioTestSlaveAddress, got)
+	// assert
+	require.NoError(t, errWrite)
+	require.NoError(t, errPointer)
+	require.NoError(t, errRead)
+	assert.Equal(t, 3, n)
+	assert.Equal(t, 2, m)
+	assert.Equal(t, []byte{0x11, 0x22}, bus.memory[0x40:0x42])
+	assert.Equal(t, []byte{0x11, 0x22}, got)
+}
+
+func TestI2cGpioClockStretching(t *testing.T) {
+	// arrange
+	d, bus := initTestI2cGpioWithSimulatedBus(t)
+	bus.stretchReads = 3
+	// act
+	err := d.WriteByteData(i2cGpioTestSlaveAddress, 0x01, 0x02)
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, uint8(0x02), bus.memory[0x01])
+}
+
+func TestI2cGpioClockStretching_timeout(t *testing.T) {
+	// arrange
+	d, bus := initTestI2cGpioWithSimulatedBus(t)
+	bus.stretchReads = -1 // never ends
+	// act
+	err := d.WriteByteData(i2cGpioTestSlaveAddress, 0x01, 0x02)
+	// assert
+	require.ErrorContains(t, err, "timeout of 35ms reached while waiting for SCL high (sda: sda, scl: scl)")
+}
+
+func TestI2cGpioNack(t *testing.T) {
+	// arrange
+	d, bus := initTestI2cGpioWithSimulatedBus(t)
+	// act
+	err := d.WriteByteData(0x11, 0x01, 0x02)
+	// assert
+	require.ErrorContains(t, err, "NACK received for address 0x11")
+	assert.Equal(t, 1, bus.stopConditions) // bus released
+	assert.True(t, bus.sda())
+}
+
+func TestI2cGpioProbeAddress(t *testing.T) {
+	tests := map[string]struct {
+		address int
+		want    bool
+	}{
+		"present":             {address: i2cGpioTestSlaveAddress, want: true},
+		"absent":              {address: 0x43, want: false},
+		"absent_eeprom_range": {address: 0x50, want: false},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, bus := initTestI2cGpioWithSimulatedBus(t)
+			// act
+			got, err := d.ProbeAddress(tc.address)
+			// assert
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+			assert.Equal(t, 1, bus.startConditions)
+			assert.Equal(t, 1, bus.stopConditions) // bus released
+			assert.True(t, bus.sda())
+		})
+	}
+}
+
+func TestI2cGpioInvalidAddress(t *testing.T) {
+	// arrange
+	d, _ := initTestI2cGpioWithSimulatedBus(t)
+	// act
+	_, err := d.ReadByte(0x80)
+	// assert
+	require.ErrorContains(t, err, "i2c address 0x80 is not a valid 7 bit address")
+}
+
+func TestI2cGpioClose(t *testing.T) {
+	// arrange
+	d, _ := initTestI2cGpioWithSimulatedBus(t)
+	//nolint:forcetypeassert // ok here
+	sda := d.sdaPin.(*i2cGpioTestPin)
+	sda.exported = true
+	// act
+	err := d.Close()
+	// assert
+	require.NoError(t, err)
+	assert.False(t, sda.exported)
+}
@@ -0,0 +1,159 @@
+package system
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gobot.io/x/gobot/v2"
+)
+
+// Linux input subsystem, event device interface (evdev).
+//
+//	https://docs.kernel.org/input/input.html#event-interface
+//	https://www.kernel.org/doc/Documentation/ABI/testing/sysfs-class-input
+const (
+	evdevClassPath      = "/sys/class/input"
+	evdevCharDevicePath = "/dev/input"
+	evdevIoctlGrab      = 0x40044590 // EVIOCGRAB, _IOW('E', 0x90, int)
+	evdevReadEvents     = 64         // maximum count of events read at once
+)
+
+// evdevWordSize is the size of "long" at kernel side, which is used for the time stamp of "struct input_event"
+const evdevWordSize = strconv.IntSize / 8
+
+// InputDeviceInfo describes an input device found in the system.
+type InputDeviceInfo struct {
+	Path string // the character device, e.g. "/dev/input/event0"
+	Name string // the name given by the driver, e.g. "gpio-keys" or "Logitech USB Receiver"
+	Phys string // the physical location, e.g. "usb-0000:01:00.0-1.3/input0", empty for most platform devices
+}
+
+// evdevInputDevice is the implementation of an input device by the Linux event interface
+type evdevInputDevice struct {
+	path     string
+	file     File
+	sys      systemCaller
+	wordSize int
+	buf      []byte
+}
+
+// findInputDevices returns all event devices, sorted by the number of the device
+func findInputDevices(fs filesystem) ([]InputDeviceInfo, error) {
+	dirs, err := fs.find(evdevClassPath, `^event\d+$`)
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]InputDeviceInfo, 0, len(dirs))
+	seen := make(map[string]bool)
+	for _, dir := range dirs {
+		base := path.Base(dir)
+		if seen[base] {
+			continue
+		}
+		seen[base] = true
+		info := InputDeviceInfo{Path: path.Join(evdevCharDevicePath, base)}
+		if name, err := fs.readFile(path.Join(dir, "device", "name")); err == nil {
+			info.Name = strings.TrimSpace(string(name))
+		}
+		if phys, err := fs.readFile(path.Join(dir, "device", "phys")); err == nil {
+			info.Phys = strings.TrimSpace(string(phys))
+		}
+		infos = append(infos, info)
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		ni, _ := strconv.Atoi(strings.TrimPrefix(path.Base(infos[i].Path), "event"))
+		nj, _ := strconv.Atoi(strings.TrimPrefix(path.Base(infos[j].Path), "event"))
+		return ni < nj
+	})
+	return infos, nil
+}
+
+// newEvdevInputDevice opens the given character device for reading of events.
+func newEvdevInputDevice(fs filesystem, sys systemCaller, devicePath string) (*evdevInputDevice, error) {
+	file, err := fs.openFile(devicePath, os.O_RDONLY, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &evdevInputDevice{path: devicePath, file: file, sys: sys, wordSize: evdevWordSize}
+	d.buf = make([]byte, evdevReadEvents*d.eventSize())
+	return d, nil
+}
+
+// ReadEvents blocks until at least one event is available and returns all available events. Implements the
+// interface gobot.InputSystemDevicer.
+func (d *evdevInputDevice) ReadEvents() ([]gobot.InputEvent, error) {
+	n, err := d.file.Read(d.buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseEvdevEvents(d.buf[:n], d.wordSize)
+}
+
+// Grab gets or releases the exclusive access to the device. Implements the interface gobot.InputSystemDevicer.
+func (d *evdevInputDevice) Grab(grab bool) error {
+	var val uint16
+	if grab {
+		val = 1
+	}
+	// the value is given by the address parameter, see the comment on the native syscall
+	if _, _, errNo := d.sys.syscall(Syscall_SYS_IOCTL, d.file, evdevIoctlGrab, nil, val); errNo != 0 {
+		return fmt.Errorf("grab (%t) of input device '%s' failed with syscall.Errno %v", grab, d.path, errNo)
+	}
+	return nil
+}
+
+// Close releases the device. Implements the interface gobot.InputSystemDevicer.
+func (d *evdevInputDevice) Close() error {
+	return d.file.Close()
+}
+
+func (d *evdevInputDevice) eventSize() int {
+	return evdevEventSize(d.wordSize)
+}
+
+// evdevEventSize returns the size of "struct input_event": seconds and microseconds of the time stamp, each of the
+// given word size, followed by type (u16), code (u16) and value (s32)
+func evdevEventSize(wordSize int) int {
+	return 2*wordSize + 8
+}
+
+// parseEvdevEvents converts the raw data to events. The kernel writes only complete events, so the length of the data
+// needs to be a multiple of the event size. The byte order is little endian, like for all supported platforms.
+func parseEvdevEvents(data []byte, wordSize int) ([]gobot.InputEvent, error) {
+	size := evdevEventSize(wordSize)
+	if len(data)%size != 0 {
+		return nil, fmt.Errorf("length of input event data (%d) is not a multiple of the event size (%d)", len(data),
+			size)
+	}
+
+	events := make([]gobot.InputEvent, 0, len(data)/size)
+	for offset := 0; offset < len(data); offset += size {
+		raw := data[offset : offset+size]
+		var sec, usec int64
+		if wordSize == 8 {
+			sec = int64(binary.LittleEndian.Uint64(raw[0:]))
+			usec = int64(binary.LittleEndian.Uint64(raw[8:]))
+		} else {
+			sec = int64(int32(binary.LittleEndian.Uint32(raw[0:])))
+			usec = int64(int32(binary.LittleEndian.Uint32(raw[4:])))
+		}
+		rest := raw[2*wordSize:]
+		events = append(events, gobot.InputEvent{
+			Time:  time.Unix(sec, usec*int64(time.Microsecond)),
+			Type:  binary.LittleEndian.Uint16(rest[0:]),
+			Code:  binary.LittleEndian.Uint16(rest[2:]),
+			Value: int32(binary.LittleEndian.Uint32(rest[4:])),
+		})
+	}
+	return events, nil
+}
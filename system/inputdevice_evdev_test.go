@@ -0,0 +1,188 @@
+package system
+
+import (
+	"bytes"
+	"encoding/hex"
+	"io"
+	"testing"
+	"time"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+)
+
+// evdevTestRecording is a recording of "/dev/input/event*" on a 64-bit system for a key press and release of "A" on
+// an USB keyboard, each with MSC_SCAN, KEY_A and SYN_REPORT
+const evdevTestRecording = "00f153650000000040e20100000000000400040004000700" +
+	"00f153650000000040e201000000000001001e0001000000" +
+	"00f153650000000040e20100000000000000000000000000" +
+	"00f1536500000000c01a0300000000000400040004000700" +
+	"00f1536500000000c01a03000000000001001e0000000000" +
+	"00f1536500000000c01a0300000000000000000000000000"
+
+// evdevTestFile provides the recorded data as a stream, the kernel returns only complete events
+type evdevTestFile struct {
+	MockFile
+	reader    *bytes.Reader
+	chunkSize int
+}
+
+func (f *evdevTestFile) Read(b []byte) (int, error) {
+	if len(b) > f.chunkSize {
+		b = b[:f.chunkSize]
+	}
+	return f.reader.Read(b)
+}
+
+func initTestEvdevInputDevice(t *testing.T, chunkSize int) *evdevInputDevice {
+	data, err := hex.DecodeString(evdevTestRecording)
+	require.NoError(t, err)
+	return &evdevInputDevice{
+		path:     "/dev/input/event3",
+		file:     &evdevTestFile{reader: bytes.NewReader(data), chunkSize: chunkSize},
+		wordSize: 8,
+		buf:      make([]byte, evdevReadEvents*evdevEventSize(8)),
+	}
+}
+
+func TestFindInputDevices(t *testing.T) {
+	// arrange
+	a := NewAccesser()
+	fs := a.UseMockFilesystem([]string{
+		"/sys/class/input/event10/device/name",
+		"/sys/class/input/event2/device/name",
+		"/sys/class/input/event2/device/phys",
+		"/sys/class/input/mouse0/device/name",
+		"/sys/class/input/input2/name",
+	})
+	fs.Files["/sys/class/input/event10/device/name"].Contents = "gpio-keys\n"
+	fs.Files["/sys/class/input/event2/device/name"].Contents = "Logitech USB Receiver\n"
+	fs.Files["/sys/class/input/event2/device/phys"].Contents = "usb-0000:01:00.0-1.3/input0\n"
+	// act
+	got, err := a.FindInputDevices()
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, []InputDeviceInfo{
+		{Path: "/dev/input/event2", Name: "Logitech USB Receiver", Phys: "usb-0000:01:00.0-1.3/input0"},
+		{Path: "/dev/input/event10", Name: "gpio-keys"},
+	}, got)
+}
+
+func TestNewInputDevice(t *testing.T) {
+	// arrange
+	a := NewAccesser()
+	_ = a.UseMockFilesystem([]string{"/dev/input/event0"})
+	// act
+	d, err := a.NewInputDevice("/dev/input/event0")
+	_, errMissing := a.NewInputDevice("/dev/input/event1")
+	// assert
+	require.NoError(t, err)
+	assert.NotNil(t, d)
+	require.ErrorContains(t, errMissing, "/dev/input/event1: no such file")
+}
+
+func TestEvdevReadEvents(t *testing.T) {
+	tests := map[string]struct {
+		chunkSize int
+		wantReads int
+	}{
+		"all_at_once":    {chunkSize: 1024, wantReads: 1},
+		"event_by_event": {chunkSize: 24, wantReads: 6},
+		"by_report":      {chunkSize: 3 * 24, wantReads: 2},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d := initTestEvdevInputDevice(t, tc.chunkSize)
+			var got []gobot.InputEvent
+			var reads int
+			// act
+			for {
+				events, err := d.ReadEvents()
+				if err == io.EOF {
+					break
+				}
+				require.NoError(t, err)
+				got = append(got, events...)
+				reads++
+			}
+			// assert
+			assert.Equal(t, tc.wantReads, reads)
+			pressed := time.Unix(1700000000, 123456000)
+			released := time.Unix(1700000000, 203456000)
+			assert.Equal(t, []gobot.InputEvent{
+				{Time: pressed, Type: 4, Code: 4, Value: 0x70004},
+				{Time: pressed, Type: 1, Code: 30, Value: 1},
+				{Time: pressed, Type: 0, Code: 0, Value: 0},
+				{Time: released, Type: 4, Code: 4, Value: 0x70004},
+				{Time: released, Type: 1, Code: 30, Value: 0},
+				{Time: released, Type: 0, Code: 0, Value: 0},
+			}, got)
+		})
+	}
+}
+
+func TestEvdevReadEventsIncomplete(t *testing.T) {
+	// arrange
+	d := initTestEvdevInputDevice(t, 20)
+	// act
+	got, err := d.ReadEvents()
+	// assert
+	require.EqualError(t, err, "length of input event data (20) is not a multiple of the event size (24)")
+	assert.Nil(t, got)
+}
+
+func Test_parseEvdevEvents32bit(t *testing.T) {
+	// arrange: REL_WHEEL -1 of a mouse on a 32-bit system
+	data, err := hex.DecodeString("00f1536540e2010002000800ffffffff")
+	require.NoError(t, err)
+	// act
+	got, err := parseEvdevEvents(data, 4)
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, []gobot.InputEvent{{Time: time.Unix(1700000000, 123456000), Type: 2, Code: 8, Value: -1}}, got)
+}
+
+func TestEvdevGrab(t *testing.T) {
+	tests := map[string]struct {
+		grab    bool
+		errNo   SyscallErrno
+		want    uintptr
+		wantErr string
+	}{
+		"grab":    {grab: true, want: 1},
+		"release": {grab: false, want: 0},
+		"busy": {
+			grab:    true,
+			errNo:   SyscallErrno(Syscall_EBUSY),
+			want:    1,
+			wantErr: "grab (true) of input device '/dev/input/event3' failed with syscall.Errno device or resource busy",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := NewAccesser()
+			msc := a.UseMockSyscall()
+			msc.devAddress = 0xFF
+			msc.Impl = func(trap, a1, a2 uintptr, a3 unsafe.Pointer) (uintptr, uintptr, SyscallErrno) {
+				return 0, 0, tc.errNo
+			}
+			d := initTestEvdevInputDevice(t, 1024)
+			d.sys = a.sys
+			// act
+			err := d.Grab(tc.grab)
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, uintptr(evdevIoctlGrab), msc.lastSignal)
+			assert.Equal(t, tc.want, msc.devAddress)
+		})
+	}
+}
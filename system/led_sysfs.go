@@ -0,0 +1,127 @@
+package system
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Linux LED class, see https://docs.kernel.org/leds/leds-class.html
+const (
+	ledClassPath    = "/sys/class/leds"
+	ledTriggerNone  = "none"
+	ledTriggerTimer = "timer"
+)
+
+// ledSysfs is the implementation of a LED, which is accessed by the LED class of the kernel.
+type ledSysfs struct {
+	name      string
+	sysfsPath string
+	sfa       *sysfsFileAccess
+}
+
+func newLedSysfs(sfa *sysfsFileAccess, name string) *ledSysfs {
+	return &ledSysfs{name: name, sysfsPath: path.Join(ledClassPath, name), sfa: sfa}
+}
+
+// findLeds returns the names of all LEDs, sorted by name
+func findLeds(fs filesystem) ([]string, error) {
+	items, err := fs.find(ledClassPath, ".+")
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	known := make(map[string]bool)
+	for _, item := range items {
+		name := path.Base(item)
+		if !known[name] {
+			known[name] = true
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// Name returns the name of the LED. Implements gobot.LedSystemDevicer.
+func (l *ledSysfs) Name() string {
+	return l.name
+}
+
+// Brightness reads the current brightness. Implements gobot.LedSystemDevicer.
+func (l *ledSysfs) Brightness() (int, error) {
+	return l.sfa.readInteger(path.Join(l.sysfsPath, "brightness"))
+}
+
+// SetBrightness writes the brightness. Implements gobot.LedSystemDevicer.
+func (l *ledSysfs) SetBrightness(val int) error {
+	return l.sfa.writeInteger(path.Join(l.sysfsPath, "brightness"), val)
+}
+
+// MaxBrightness reads the maximum brightness. Implements gobot.LedSystemDevicer.
+func (l *ledSysfs) MaxBrightness() (int, error) {
+	return l.sfa.readInteger(path.Join(l.sysfsPath, "max_brightness"))
+}
+
+// Trigger reads the current trigger, which is marked by square brackets in the list of triggers.
+// Implements gobot.LedSystemDevicer.
+func (l *ledSysfs) Trigger() (string, error) {
+	items, err := l.readTriggers()
+	if err != nil {
+		return "", err
+	}
+
+	for _, item := range items {
+		if strings.HasPrefix(item, "[") && strings.HasSuffix(item, "]") {
+			return strings.Trim(item, "[]"), nil
+		}
+	}
+	return ledTriggerNone, nil
+}
+
+// Triggers reads all available triggers. Implements gobot.LedSystemDevicer.
+func (l *ledSysfs) Triggers() ([]string, error) {
+	items, err := l.readTriggers()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, item := range items {
+		items[i] = strings.Trim(item, "[]")
+	}
+	return items, nil
+}
+
+// SetTrigger activates the given trigger. Implements gobot.LedSystemDevicer.
+func (l *ledSysfs) SetTrigger(trigger string) error {
+	return l.sfa.write(path.Join(l.sysfsPath, "trigger"), []byte(trigger))
+}
+
+// SetTimerDelays writes the on and off time for blinking with a resolution of milliseconds. The files are provided
+// by the kernel only, if the trigger "timer" is active. Implements gobot.LedSystemDevicer.
+func (l *ledSysfs) SetTimerDelays(on, off time.Duration) error {
+	trigger, err := l.Trigger()
+	if err != nil {
+		return err
+	}
+	if trigger != ledTriggerTimer {
+		return fmt.Errorf("timer delays of LED '%s' can not be set with active trigger '%s', '%s' is needed", l.name,
+			trigger, ledTriggerTimer)
+	}
+
+	if err := l.sfa.writeInteger(path.Join(l.sysfsPath, "delay_on"), int(on.Milliseconds())); err != nil {
+		return err
+	}
+	return l.sfa.writeInteger(path.Join(l.sysfsPath, "delay_off"), int(off.Milliseconds()))
+}
+
+func (l *ledSysfs) readTriggers() ([]string, error) {
+	buf, err := l.sfa.read(path.Join(l.sysfsPath, "trigger"))
+	if err != nil {
+		return nil, err
+	}
+	return strings.Fields(string(buf)), nil
+}
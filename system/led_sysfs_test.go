@@ -0,0 +1,140 @@
+package system
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+)
+
+// make sure that this implementation fulfills all the required interfaces
+var _ gobot.LedSystemDevicer = (*ledSysfs)(nil)
+
+const (
+	ledTestPath     = "/sys/class/leds/ACT"
+	ledTestTriggers = "none rc-feedback kbd-scrolllock timer oneshot [heartbeat] backlight gpio cpu cpu0 mmc0\n"
+)
+
+func initTestLedSysfs() (*ledSysfs, *MockFilesystem) {
+	fs := newMockFilesystem([]string{
+		ledTestPath + "/brightness",
+		ledTestPath + "/max_brightness",
+		ledTestPath + "/trigger",
+		ledTestPath + "/delay_on",
+		ledTestPath + "/delay_off",
+	})
+	fs.Files[ledTestPath+"/brightness"].Contents = "0\n"
+	fs.Files[ledTestPath+"/max_brightness"].Contents = "255\n"
+	fs.Files[ledTestPath+"/trigger"].Contents = ledTestTriggers
+	sfa := &sysfsFileAccess{fs: fs, readBufLen: 4096}
+	return newLedSysfs(sfa, "ACT"), fs
+}
+
+func TestLedSysfsBrightness(t *testing.T) {
+	// arrange
+	l, fs := initTestLedSysfs()
+	// act & assert
+	assert.Equal(t, "ACT", l.Name())
+	maxVal, err := l.MaxBrightness()
+	require.NoError(t, err)
+	assert.Equal(t, 255, maxVal)
+	require.NoError(t, l.SetBrightness(128))
+	assert.Equal(t, "128", fs.Files[ledTestPath+"/brightness"].Contents)
+	got, err := l.Brightness()
+	require.NoError(t, err)
+	assert.Equal(t, 128, got)
+}
+
+func TestLedSysfsTrigger(t *testing.T) {
+	tests := map[string]struct {
+		contents string
+		want     string
+		wantErr  string
+	}{
+		"heartbeat": {
+			contents: ledTestTriggers,
+			want:     "heartbeat",
+		},
+		"none": {
+			contents: "[none] timer heartbeat\n",
+			want:     "none",
+		},
+		"no_marker": {
+			contents: "none timer heartbeat\n",
+			want:     "none",
+		},
+		"error_read": {
+			wantErr: "read error",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			l, fs := initTestLedSysfs()
+			fs.Files[ledTestPath+"/trigger"].Contents = tc.contents
+			fs.WithReadError = tc.wantErr != ""
+			// act
+			got, err := l.Trigger()
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestLedSysfsTriggers(t *testing.T) {
+	// arrange
+	l, fs := initTestLedSysfs()
+	// act
+	got, err := l.Triggers()
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"none", "rc-feedback", "kbd-scrolllock", "timer", "oneshot", "heartbeat", "backlight", "gpio", "cpu",
+		"cpu0", "mmc0",
+	}, got)
+	// act
+	require.NoError(t, l.SetTrigger("timer"))
+	// assert
+	assert.Equal(t, "timer", fs.Files[ledTestPath+"/trigger"].Contents)
+}
+
+func TestLedSysfsSetTimerDelays(t *testing.T) {
+	// arrange
+	l, fs := initTestLedSysfs()
+	// act
+	err := l.SetTimerDelays(100*time.Millisecond, 900*time.Millisecond)
+	// assert
+	require.EqualError(t, err,
+		"timer delays of LED 'ACT' can not be set with active trigger 'heartbeat', 'timer' is needed")
+	// arrange
+	fs.Files[ledTestPath+"/trigger"].Contents = "none [timer] heartbeat\n"
+	// act
+	err = l.SetTimerDelays(100*time.Millisecond, 900*time.Millisecond)
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, "100", fs.Files[ledTestPath+"/delay_on"].Contents)
+	assert.Equal(t, "900", fs.Files[ledTestPath+"/delay_off"].Contents)
+}
+
+func Test_findLeds(t *testing.T) {
+	// arrange
+	fs := newMockFilesystem([]string{
+		"/sys/class/leds/PWR/brightness",
+		"/sys/class/leds/PWR/trigger",
+		"/sys/class/leds/ACT/brightness",
+		"/sys/class/leds/ACT/trigger",
+	})
+	// act
+	got, err := findLeds(fs)
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ACT", "PWR"}, got)
+}
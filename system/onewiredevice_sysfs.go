@@ -0,0 +1,68 @@
+package system
+
+import (
+	"path"
+)
+
+const onewireDevicePath = "/sys/bus/w1/devices"
+
+// onewireDeviceSysfs is the implementation of a 1-wire device, which is accessed by the kernel w1 subsystem
+// and its slave drivers.
+type onewireDeviceSysfs struct {
+	id        string
+	sysfsPath string
+	sfa       *sysfsFileAccess
+}
+
+func newOneWireDeviceSysfs(sfa *sysfsFileAccess, id string) *onewireDeviceSysfs {
+	p := &onewireDeviceSysfs{
+		id:        id,
+		sysfsPath: path.Join(onewireDevicePath, id),
+		sfa:       sfa,
+	}
+	return p
+}
+
+// ID returns the device id in the form "family code"-"serial number". Implements gobot.OneWireSystemDevicer.
+func (o *onewireDeviceSysfs) ID() string {
+	return o.id
+}
+
+// ReadData reads byte data from the device file with the name of the given command. The data is truncated to the
+// length of the given buffer. Implements gobot.OneWireSystemDevicer.
+func (o *onewireDeviceSysfs) ReadData(command string, data []byte) error {
+	p := path.Join(o.sysfsPath, command)
+	buf, err := o.sfa.read(p)
+	if err != nil {
+		return err
+	}
+	copy(data, buf)
+	return nil
+}
+
+// WriteData writes byte data to the device file with the name of the given command.
+// Implements gobot.OneWireSystemDevicer.
+func (o *onewireDeviceSysfs) WriteData(command string, data []byte) error {
+	p := path.Join(o.sysfsPath, command)
+	return o.sfa.write(p, data)
+}
+
+// ReadInteger reads an integer value from the device file with the name of the given command.
+// Implements gobot.OneWireSystemDevicer.
+func (o *onewireDeviceSysfs) ReadInteger(command string) (int, error) {
+	p := path.Join(o.sysfsPath, command)
+	return o.sfa.readInteger(p)
+}
+
+// WriteInteger writes an integer value to the device file with the name of the given command.
+// Implements gobot.OneWireSystemDevicer.
+func (o *onewireDeviceSysfs) WriteInteger(command string, val int) error {
+	p := path.Join(o.sysfsPath, command)
+	return o.sfa.writeInteger(p, val)
+}
+
+// Close the device. Implements gobot.OneWireSystemDevicer.
+func (o *onewireDeviceSysfs) Close() error {
+	// currently nothing to do here, because all files are closed after each access
+	return nil
+}
@@ -0,0 +1,109 @@
+package system
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+)
+
+// make sure that this implementation fulfills all the required interfaces
+var _ gobot.OneWireSystemDevicer = (*onewireDeviceSysfs)(nil)
+
+const onewireTestDevicePath = "/sys/bus/w1/devices/28-0000075a1b2c"
+
+func initTestOneWireDeviceSysfs(files []string) (*onewireDeviceSysfs, *MockFilesystem) {
+	fs := newMockFilesystem(files)
+	sfa := &sysfsFileAccess{fs: fs, readBufLen: 200}
+	return newOneWireDeviceSysfs(sfa, "28-0000075a1b2c"), fs
+}
+
+func Test_newOneWireDeviceSysfs(t *testing.T) {
+	// arrange
+	sfa := &sysfsFileAccess{fs: &MockFilesystem{}, readBufLen: 200}
+	// act
+	d := newOneWireDeviceSysfs(sfa, "28-0000075a1b2c")
+	// assert
+	assert.Equal(t, "28-0000075a1b2c", d.ID())
+	assert.Equal(t, onewireTestDevicePath, d.sysfsPath)
+	assert.Equal(t, sfa, d.sfa)
+	require.NoError(t, d.Close())
+}
+
+func TestOneWireDeviceSysfsReadData(t *testing.T) {
+	tests := map[string]struct {
+		simErr  bool
+		bufLen  int
+		want    []byte
+		wantErr string
+	}{
+		"read_ok": {
+			bufLen: 5,
+			want:   []byte("a2 01"),
+		},
+		"read_truncated": {
+			bufLen: 2,
+			want:   []byte("a2"),
+		},
+		"error_read": {
+			bufLen:  5,
+			simErr:  true,
+			wantErr: "read error",
+			want:    []byte{0, 0, 0, 0, 0},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, fs := initTestOneWireDeviceSysfs([]string{onewireTestDevicePath + "/w1_slave"})
+			fs.Files[onewireTestDevicePath+"/w1_slave"].Contents = "a2 01"
+			fs.WithReadError = tc.simErr
+			data := make([]byte, tc.bufLen)
+			// act
+			err := d.ReadData("w1_slave", data)
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.want, data)
+		})
+	}
+}
+
+func TestOneWireDeviceSysfsWriteData(t *testing.T) {
+	// arrange
+	d, fs := initTestOneWireDeviceSysfs([]string{onewireTestDevicePath + "/eeprom"})
+	// act
+	err := d.WriteData("eeprom", []byte("data"))
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, "data", fs.Files[onewireTestDevicePath+"/eeprom"].Contents)
+}
+
+func TestOneWireDeviceSysfsReadInteger(t *testing.T) {
+	// arrange
+	d, fs := initTestOneWireDeviceSysfs([]string{onewireTestDevicePath + "/temperature"})
+	fs.Files[onewireTestDevicePath+"/temperature"].Contents = "23187\n"
+	// act
+	got, err := d.ReadInteger("temperature")
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, 23187, got)
+	// act & assert
+	_, err = d.ReadInteger("unknown")
+	require.ErrorContains(t, err, "no such file")
+}
+
+func TestOneWireDeviceSysfsWriteInteger(t *testing.T) {
+	// arrange
+	d, fs := initTestOneWireDeviceSysfs([]string{onewireTestDevicePath + "/resolution"})
+	// act
+	err := d.WriteInteger("resolution", 10)
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, "10", fs.Files[onewireTestDevicePath+"/resolution"].Contents)
+}
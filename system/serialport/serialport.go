@@ -0,0 +1,209 @@
+// Package serialport provides the access to serial ports (UART) at system level. In contrast to the package
+// "system", it has no dependencies to Linux specific packages, so it can be used on all platforms supported by the
+// "go.bug.st/serial" package, e.g. Windows and macOS.
+package serialport
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"go.bug.st/serial"
+
+	"gobot.io/x/gobot/v2"
+)
+
+// serialPortDebug switches on the output of opened ports
+const serialPortDebug = false
+
+// serialPorter is the unexposed interface to a serial port, implemented by the native and the mocked port
+type serialPorter interface {
+	io.ReadWriteCloser
+	Drain() error
+	ResetInputBuffer() error
+	SetReadTimeout(timeout time.Duration) error
+	SetRTS(rts bool) error
+}
+
+// serialPortAccesser represents unexposed interface to allow the switch between different implementations and a
+// mocked one
+type serialPortAccesser interface {
+	openPort(portName string, cfg *serialPortConfig) (serialPorter, error)
+}
+
+// Accesser provides access to the serial ports of the system.
+type Accesser struct {
+	serialPortAccess serialPortAccesser
+}
+
+// bugstSerialPortAccess opens serial ports by the "go.bug.st/serial" package
+type bugstSerialPortAccess struct{}
+
+// serialPortDevice is the implementation of a serial port at system level, including a software direction control
+// for RS-485 transceivers
+type serialPortDevice struct {
+	name  string
+	port  serialPorter
+	cfg   *serialPortConfig
+	mutex sync.Mutex // to ensure the direction is not switched while writing
+}
+
+// NewAccesser returns an accesser to the native serial ports.
+func NewAccesser() *Accesser {
+	return &Accesser{serialPortAccess: &bugstSerialPortAccess{}}
+}
+
+// UseMock sets the serial port implementation of the accesser to the mocked one. Used only for tests.
+func (a *Accesser) UseMock() *MockAccess {
+	mspa := &MockAccess{}
+	a.serialPortAccess = mspa
+	return mspa
+}
+
+// NewPort opens the serial port with the given name (e.g. "/dev/ttyUSB0", "COM3") and applies the given options. By
+// default 9600 baud, 8 data bits, no parity, 1 stop bit and no read timeout is used.
+func (a *Accesser) NewPort(portName string, opts ...OptionApplier) (gobot.SerialPortSystemDevicer, error) {
+	return newSerialPortDevice(a.serialPortAccess, portName, opts...)
+}
+
+func (*bugstSerialPortAccess) openPort(portName string, cfg *serialPortConfig) (serialPorter, error) {
+	parities := map[Parity]serial.Parity{
+		ParityNone:  serial.NoParity,
+		ParityOdd:   serial.OddParity,
+		ParityEven:  serial.EvenParity,
+		ParityMark:  serial.MarkParity,
+		ParitySpace: serial.SpaceParity,
+	}
+	stopBits := map[StopBits]serial.StopBits{
+		StopBitsOne:          serial.OneStopBit,
+		StopBitsOnePointFive: serial.OnePointFiveStopBits,
+		StopBitsTwo:          serial.TwoStopBits,
+	}
+	mode := &serial.Mode{
+		BaudRate: cfg.baudRate,
+		DataBits: cfg.dataBits,
+		Parity:   parities[cfg.parity],
+		StopBits: stopBits[cfg.stopBits],
+	}
+	return serial.Open(portName, mode)
+}
+
+// newSerialPortDevice opens the serial port with the given name by the given accesser and applies the configuration.
+func newSerialPortDevice(
+	spa serialPortAccesser,
+	portName string,
+	opts ...OptionApplier,
+) (*serialPortDevice, error) {
+	cfg := newSerialPortConfig(opts...)
+	if err := cfg.validate(); err != nil {
+		return nil, fmt.Errorf("serial port '%s': %v", portName, err)
+	}
+
+	port, err := spa.openPort(portName, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &serialPortDevice{name: portName, port: port, cfg: cfg}
+	if err := d.SetReadTimeout(cfg.readTimeout); err != nil {
+		_ = port.Close()
+		return nil, err
+	}
+
+	if cfg.rs485 != nil {
+		// start in receive mode
+		if err := d.initDirection(); err != nil {
+			_ = port.Close()
+			return nil, err
+		}
+	}
+
+	if serialPortDebug {
+		fmt.Printf("serial port '%s' opened with %s\n", portName, cfg)
+	}
+
+	return d, nil
+}
+
+// Read reads from the serial port. It blocks until data are received, or the read timeout has expired.
+func (d *serialPortDevice) Read(b []byte) (int, error) {
+	return d.port.Read(b)
+}
+
+// Write writes the given data to the serial port. If the direction control for RS-485 is active, the direction is
+// switched to send before writing and back to receive, after all data are transmitted.
+func (d *serialPortDevice) Write(b []byte) (int, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.cfg.rs485 == nil {
+		return d.port.Write(b)
+	}
+
+	if err := d.setSendDirection(true); err != nil {
+		return 0, err
+	}
+	time.Sleep(d.cfg.rs485.delayBeforeSend)
+
+	n, err := d.port.Write(b)
+	if err == nil {
+		err = d.port.Drain()
+	}
+
+	time.Sleep(d.cfg.rs485.delayAfterSend)
+	if dirErr := d.setSendDirection(false); dirErr != nil && err == nil {
+		err = dirErr
+	}
+
+	return n, err
+}
+
+// Close closes the serial port.
+func (d *serialPortDevice) Close() error {
+	return d.port.Close()
+}
+
+// Drain waits until all written data are transmitted.
+func (d *serialPortDevice) Drain() error {
+	return d.port.Drain()
+}
+
+// ResetInputBuffer discards all received, but not yet read data.
+func (d *serialPortDevice) ResetInputBuffer() error {
+	return d.port.ResetInputBuffer()
+}
+
+// SetReadTimeout sets the timeout for the read operation, a value <= 0 disables the timeout.
+func (d *serialPortDevice) SetReadTimeout(timeout time.Duration) error {
+	if timeout <= 0 {
+		timeout = serial.NoTimeout
+	}
+	return d.port.SetReadTimeout(timeout)
+}
+
+func (d *serialPortDevice) initDirection() error {
+	if d.cfg.rs485.directionPin == nil {
+		return d.setSendDirection(false)
+	}
+
+	level := d.directionLevel(false)
+	return d.cfg.rs485.directionPin.ApplyOptions(func(o gobot.DigitalPinOptioner) bool {
+		return o.SetDirectionOutput(level)
+	})
+}
+
+func (d *serialPortDevice) setSendDirection(send bool) error {
+	if d.cfg.rs485.directionPin == nil {
+		return d.port.SetRTS(d.directionLevel(send) == 1)
+	}
+
+	return d.cfg.rs485.directionPin.Write(d.directionLevel(send))
+}
+
+func (d *serialPortDevice) directionLevel(send bool) int {
+	if send == d.cfg.rs485.activeHighOnSend {
+		return 1
+	}
+	return 0
+}
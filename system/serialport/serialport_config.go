@@ -0,0 +1,241 @@
+package serialport
+
+import (
+	"fmt"
+	"time"
+
+	"gobot.io/x/gobot/v2"
+)
+
+const (
+	serialPortDefaultBaudRate = 9600
+	serialPortDefaultDataBits = 8
+)
+
+// Parity is the type for the parity setting of a serial port.
+type Parity int
+
+const (
+	// ParityNone disables the parity bit (default)
+	ParityNone Parity = iota
+	// ParityOdd enables the odd parity check
+	ParityOdd
+	// ParityEven enables the even parity check
+	ParityEven
+	// ParityMark enables the mark parity (always 1)
+	ParityMark
+	// ParitySpace enables the space parity (always 0)
+	ParitySpace
+)
+
+// StopBits is the type for the number of stop bits of a serial port.
+type StopBits int
+
+const (
+	// StopBitsOne sets 1 stop bit (default)
+	StopBitsOne StopBits = iota
+	// StopBitsOnePointFive sets 1.5 stop bits
+	StopBitsOnePointFive
+	// StopBitsTwo sets 2 stop bits
+	StopBitsTwo
+)
+
+// OptionApplier needs to be implemented by each configurable option type
+type OptionApplier interface {
+	apply(cfg *serialPortConfig)
+}
+
+// serialPortRS485Config contains the settings for the direction control of a RS-485 transceiver.
+type serialPortRS485Config struct {
+	activeHighOnSend bool
+	delayBeforeSend  time.Duration
+	delayAfterSend   time.Duration
+	directionPin     gobot.DigitalPinner // if not given, the RTS line is used
+}
+
+// serialPortConfig contains all changeable attributes of a serial port.
+type serialPortConfig struct {
+	baudRate    int
+	dataBits    int
+	parity      Parity
+	stopBits    StopBits
+	readTimeout time.Duration
+	rs485       *serialPortRS485Config
+}
+
+// serialPortBaudRateOption is the type for applying another than the default baud rate of 9600.
+type serialPortBaudRateOption int
+
+// serialPortDataBitsOption is the type for applying another than the default of 8 data bits.
+type serialPortDataBitsOption int
+
+// serialPortParityOption is the type for applying a parity.
+type serialPortParityOption Parity
+
+// serialPortStopBitsOption is the type for applying another than the default of 1 stop bit.
+type serialPortStopBitsOption StopBits
+
+// serialPortReadTimeoutOption is the type for applying a timeout for read operations.
+type serialPortReadTimeoutOption time.Duration
+
+// serialPortRS485Option is the type for applying the direction control of a RS-485 transceiver.
+type serialPortRS485Option struct {
+	activeHighOnSend bool
+	delayBeforeSend  time.Duration
+	delayAfterSend   time.Duration
+}
+
+// serialPortRS485DirectionPinOption is the type for applying a GPIO for the direction control of a RS-485
+// transceiver, instead of the RTS line.
+type serialPortRS485DirectionPinOption struct {
+	pin gobot.DigitalPinner
+}
+
+func newSerialPortConfig(opts ...OptionApplier) *serialPortConfig {
+	cfg := &serialPortConfig{
+		baudRate: serialPortDefaultBaudRate,
+		dataBits: serialPortDefaultDataBits,
+	}
+	for _, o := range opts {
+		o.apply(cfg)
+	}
+	return cfg
+}
+
+// WithBaudRate substitutes the default baud rate of 9600.
+func WithBaudRate(baudRate int) OptionApplier {
+	return serialPortBaudRateOption(baudRate)
+}
+
+// WithDataBits substitutes the default of 8 data bits. Valid values are 5, 6, 7 and 8.
+func WithDataBits(dataBits int) OptionApplier {
+	return serialPortDataBitsOption(dataBits)
+}
+
+// WithParity activates the given parity check.
+func WithParity(parity Parity) OptionApplier {
+	return serialPortParityOption(parity)
+}
+
+// WithStopBits substitutes the default of 1 stop bit.
+func WithStopBits(stopBits StopBits) OptionApplier {
+	return serialPortStopBitsOption(stopBits)
+}
+
+// WithReadTimeout sets a timeout for read operations. After the timeout is reached without any received
+// data, the read returns with 0 bytes and without an error. By default the read blocks until data are received.
+func WithReadTimeout(timeout time.Duration) OptionApplier {
+	return serialPortReadTimeoutOption(timeout)
+}
+
+// WithRS485 activates the direction control of a half duplex RS-485 transceiver. Before each write the
+// direction line (by default RTS) is set to the level for sending. After all data are transmitted, the line is set
+// back to the level for receiving. The given delays are applied after the switch to sending and before the switch
+// back to receiving. This is a software implementation, so the timing depends on the system load.
+func WithRS485(activeHighOnSend bool, delayBeforeSend, delayAfterSend time.Duration) OptionApplier {
+	return serialPortRS485Option{
+		activeHighOnSend: activeHighOnSend,
+		delayBeforeSend:  delayBeforeSend,
+		delayAfterSend:   delayAfterSend,
+	}
+}
+
+// WithRS485DirectionPin uses the given GPIO for the direction control of a RS-485 transceiver, instead of
+// the RTS line. If "WithRS485()" is not given, the pin is high for sending without delays.
+func WithRS485DirectionPin(pin gobot.DigitalPinner) OptionApplier {
+	return serialPortRS485DirectionPinOption{pin: pin}
+}
+
+func (cfg *serialPortConfig) validate() error {
+	if cfg.baudRate <= 0 {
+		return fmt.Errorf("baud rate %d is not valid", cfg.baudRate)
+	}
+	if cfg.dataBits < 5 || cfg.dataBits > 8 {
+		return fmt.Errorf("%d data bits are not valid, use 5, 6, 7 or 8", cfg.dataBits)
+	}
+	if cfg.parity < ParityNone || cfg.parity > ParitySpace {
+		return fmt.Errorf("parity %d is not valid", cfg.parity)
+	}
+	if cfg.stopBits < StopBitsOne || cfg.stopBits > StopBitsTwo {
+		return fmt.Errorf("stop bits %d are not valid", cfg.stopBits)
+	}
+	return nil
+}
+
+func (cfg *serialPortConfig) String() string {
+	parities := []string{"N", "O", "E", "M", "S"}
+	stopBits := []string{"1", "1.5", "2"}
+	s := fmt.Sprintf("%d %d%s%s", cfg.baudRate, cfg.dataBits, parities[cfg.parity], stopBits[cfg.stopBits])
+	if cfg.rs485 != nil {
+		s += " RS-485"
+	}
+	return s
+}
+
+func (o serialPortBaudRateOption) String() string {
+	return "baud rate option for serial ports"
+}
+
+func (o serialPortDataBitsOption) String() string {
+	return "data bits option for serial ports"
+}
+
+func (o serialPortParityOption) String() string {
+	return "parity option for serial ports"
+}
+
+func (o serialPortStopBitsOption) String() string {
+	return "stop bits option for serial ports"
+}
+
+func (o serialPortReadTimeoutOption) String() string {
+	return "read timeout option for serial ports"
+}
+
+func (o serialPortRS485Option) String() string {
+	return "RS-485 option for serial ports"
+}
+
+func (o serialPortRS485DirectionPinOption) String() string {
+	return "RS-485 direction pin option for serial ports"
+}
+
+func (o serialPortBaudRateOption) apply(cfg *serialPortConfig) {
+	cfg.baudRate = int(o)
+}
+
+func (o serialPortDataBitsOption) apply(cfg *serialPortConfig) {
+	cfg.dataBits = int(o)
+}
+
+func (o serialPortParityOption) apply(cfg *serialPortConfig) {
+	cfg.parity = Parity(o)
+}
+
+func (o serialPortStopBitsOption) apply(cfg *serialPortConfig) {
+	cfg.stopBits = StopBits(o)
+}
+
+func (o serialPortReadTimeoutOption) apply(cfg *serialPortConfig) {
+	cfg.readTimeout = time.Duration(o)
+}
+
+func (o serialPortRS485Option) apply(cfg *serialPortConfig) {
+	var pin gobot.DigitalPinner
+	if cfg.rs485 != nil {
+		pin = cfg.rs485.directionPin
+	}
+	cfg.rs485 = &serialPortRS485Config{
+		activeHighOnSend: o.activeHighOnSend,
+		delayBeforeSend:  o.delayBeforeSend,
+		delayAfterSend:   o.delayAfterSend,
+		directionPin:     pin,
+	}
+}
+
+func (o serialPortRS485DirectionPinOption) apply(cfg *serialPortConfig) {
+	if cfg.rs485 == nil {
+		cfg.rs485 = &serialPortRS485Config{activeHighOnSend: true}
+	}
+	cfg.rs485.directionPin = o.pin
+}
@@ -0,0 +1,217 @@
+package serialport
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// MockAccess contains parameters of mocked serial port access
+type MockAccess struct {
+	CreateError bool
+	portName    string
+	cfg         *serialPortConfig
+	port        *serialPortMock
+}
+
+// serialPortMock is the mock implementation of a serial port
+type serialPortMock struct {
+	mutex       sync.Mutex
+	simRead     []byte
+	written     []byte
+	rtsStates   []bool
+	readTimeout time.Duration
+	closed      bool
+	simReadErr  bool
+	simWriteErr bool
+	simCloseErr bool
+	drainCount  int
+	inputResets int
+}
+
+func (spa *MockAccess) openPort(portName string, cfg *serialPortConfig) (serialPorter, error) {
+	spa.portName = portName
+	spa.cfg = cfg
+	spa.port = &serialPortMock{}
+	if spa.CreateError {
+		return nil, fmt.Errorf("error while open serial port in mock")
+	}
+	return spa.port, nil
+}
+
+// PortName returns the name of the last opened port.
+func (spa *MockAccess) PortName() string {
+	return spa.portName
+}
+
+// BaudRate returns the baud rate of the last opened port.
+func (spa *MockAccess) BaudRate() int {
+	return spa.cfg.baudRate
+}
+
+// DataBits returns the number of data bits of the last opened port.
+func (spa *MockAccess) DataBits() int {
+	return spa.cfg.dataBits
+}
+
+// Parity returns the parity of the last opened port.
+func (spa *MockAccess) Parity() Parity {
+	return spa.cfg.parity
+}
+
+// StopBits returns the stop bits of the last opened port.
+func (spa *MockAccess) StopBits() StopBits {
+	return spa.cfg.stopBits
+}
+
+// ReadTimeout returns the currently set read timeout of the last opened port.
+func (spa *MockAccess) ReadTimeout() time.Duration {
+	spa.port.mutex.Lock()
+	defer spa.port.mutex.Unlock()
+	return spa.port.readTimeout
+}
+
+// IsRS485 returns whether the direction control for RS-485 is active for the last opened port.
+func (spa *MockAccess) IsRS485() bool {
+	return spa.cfg.rs485 != nil
+}
+
+// SetSimRead is used to add data to the byte stream for the next reads.
+func (spa *MockAccess) SetSimRead(data []byte) {
+	spa.port.mutex.Lock()
+	defer spa.port.mutex.Unlock()
+	spa.port.simRead = append(spa.port.simRead, data...)
+}
+
+// Written returns all data written since the port was opened or the last reset.
+func (spa *MockAccess) Written() []byte {
+	spa.port.mutex.Lock()
+	defer spa.port.mutex.Unlock()
+	return append([]byte(nil), spa.port.written...)
+}
+
+// RTSStates returns all states of the RTS line, set since the port was opened or the last reset.
+func (spa *MockAccess) RTSStates() []bool {
+	spa.port.mutex.Lock()
+	defer spa.port.mutex.Unlock()
+	return append([]bool(nil), spa.port.rtsStates...)
+}
+
+// DrainCount returns the number of calls of Drain().
+func (spa *MockAccess) DrainCount() int {
+	spa.port.mutex.Lock()
+	defer spa.port.mutex.Unlock()
+	return spa.port.drainCount
+}
+
+// InputResets returns the number of calls of ResetInputBuffer().
+func (spa *MockAccess) InputResets() int {
+	spa.port.mutex.Lock()
+	defer spa.port.mutex.Unlock()
+	return spa.port.inputResets
+}
+
+// IsClosed returns whether the last opened port was closed.
+func (spa *MockAccess) IsClosed() bool {
+	spa.port.mutex.Lock()
+	defer spa.port.mutex.Unlock()
+	return spa.port.closed
+}
+
+// SetReadError can be used to simulate a read error.
+func (spa *MockAccess) SetReadError(val bool) {
+	spa.port.mutex.Lock()
+	defer spa.port.mutex.Unlock()
+	spa.port.simReadErr = val
+}
+
+// SetWriteError can be used to simulate a write error.
+func (spa *MockAccess) SetWriteError(val bool) {
+	spa.port.mutex.Lock()
+	defer spa.port.mutex.Unlock()
+	spa.port.simWriteErr = val
+}
+
+// SetCloseError can be used to simulate a error on Close().
+func (spa *MockAccess) SetCloseError(val bool) {
+	spa.port.mutex.Lock()
+	defer spa.port.mutex.Unlock()
+	spa.port.simCloseErr = val
+}
+
+// Reset resets the recorded data and the simulated data for reading.
+func (spa *MockAccess) Reset() {
+	spa.port.mutex.Lock()
+	defer spa.port.mutex.Unlock()
+	spa.port.simRead = nil
+	spa.port.written = nil
+	spa.port.rtsStates = nil
+	spa.port.drainCount = 0
+	spa.port.inputResets = 0
+}
+
+// Read copies the simulated data. If no data are available, io.EOF is returned, which is the same behavior like a
+// disconnected port.
+func (p *serialPortMock) Read(b []byte) (int, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.simReadErr {
+		return 0, fmt.Errorf("error while reading from serial port in mock")
+	}
+	if len(p.simRead) == 0 {
+		return 0, io.EOF
+	}
+	n := copy(b, p.simRead)
+	p.simRead = p.simRead[n:]
+	return n, nil
+}
+
+func (p *serialPortMock) Write(b []byte) (int, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.simWriteErr {
+		return 0, fmt.Errorf("error while writing to serial port in mock")
+	}
+	p.written = append(p.written, b...)
+	return len(b), nil
+}
+
+func (p *serialPortMock) Close() error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.closed = true
+	if p.simCloseErr {
+		return fmt.Errorf("error while closing serial port in mock")
+	}
+	return nil
+}
+
+func (p *serialPortMock) Drain() error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.drainCount++
+	return nil
+}
+
+func (p *serialPortMock) ResetInputBuffer() error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.inputResets++
+	p.simRead = nil
+	return nil
+}
+
+func (p *serialPortMock) SetReadTimeout(timeout time.Duration) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.readTimeout = timeout
+	return nil
+}
+
+func (p *serialPortMock) SetRTS(rts bool) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.rtsStates = append(p.rtsStates, rts)
+	return nil
+}
@@ -0,0 +1,228 @@
+package serialport
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+)
+
+// make sure that this implementation fulfills all the required interfaces
+var _ gobot.SerialPortSystemDevicer = (*serialPortDevice)(nil)
+
+type serialPortTestDirectionPin struct {
+	gobot.DigitalPinner
+	gobot.DigitalPinOptioner
+	written []int
+}
+
+func (p *serialPortTestDirectionPin) ApplyOptions(options ...func(gobot.DigitalPinOptioner) bool) error {
+	for _, option := range options {
+		option(p)
+	}
+	return nil
+}
+
+// SetDirectionOutput records the initial level, which is applied together with the output direction
+func (p *serialPortTestDirectionPin) SetDirectionOutput(initialState int) bool {
+	p.written = append(p.written, initialState)
+	return true
+}
+
+func (p *serialPortTestDirectionPin) Write(val int) error {
+	p.written = append(p.written, val)
+	return nil
+}
+
+func TestNewPort(t *testing.T) {
+	tests := map[string]struct {
+		opts         []OptionApplier
+		createErr    bool
+		wantBaudRate int
+		wantDataBits int
+		wantParity   Parity
+		wantStopBits StopBits
+		wantTimeout  time.Duration
+		wantRS485    bool
+		wantRTS      []bool
+		wantErr      string
+	}{
+		"defaults": {
+			wantBaudRate: 9600,
+			wantDataBits: 8,
+			wantTimeout:  -1,
+		},
+		"all_options": {
+			opts: []OptionApplier{
+				WithBaudRate(115200),
+				WithDataBits(7),
+				WithParity(ParityEven),
+				WithStopBits(StopBitsTwo),
+				WithReadTimeout(100 * time.Millisecond),
+				WithRS485(true, 0, 0),
+			},
+			wantBaudRate: 115200,
+			wantDataBits: 7,
+			wantParity:   ParityEven,
+			wantStopBits: StopBitsTwo,
+			wantTimeout:  100 * time.Millisecond,
+			wantRS485:    true,
+			wantRTS:      []bool{false},
+		},
+		"error_baud_rate": {
+			opts:    []OptionApplier{WithBaudRate(0)},
+			wantErr: "serial port '/dev/ttyS0': baud rate 0 is not valid",
+		},
+		"error_data_bits": {
+			opts:    []OptionApplier{WithDataBits(9)},
+			wantErr: "serial port '/dev/ttyS0': 9 data bits are not valid, use 5, 6, 7 or 8",
+		},
+		"error_parity": {
+			opts:    []OptionApplier{WithParity(5)},
+			wantErr: "serial port '/dev/ttyS0': parity 5 is not valid",
+		},
+		"error_stop_bits": {
+			opts:    []OptionApplier{WithStopBits(3)},
+			wantErr: "serial port '/dev/ttyS0': stop bits 3 are not valid",
+		},
+		"error_open": {
+			createErr: true,
+			wantErr:   "error while open serial port in mock",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := NewAccesser()
+			spa := a.UseMock()
+			spa.CreateError = tc.createErr
+			// act
+			port, err := a.NewPort("/dev/ttyS0", tc.opts...)
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				assert.Nil(t, port)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, port)
+			assert.Equal(t, "/dev/ttyS0", spa.PortName())
+			assert.Equal(t, tc.wantBaudRate, spa.BaudRate())
+			assert.Equal(t, tc.wantDataBits, spa.DataBits())
+			assert.Equal(t, tc.wantParity, spa.Parity())
+			assert.Equal(t, tc.wantStopBits, spa.StopBits())
+			assert.Equal(t, tc.wantTimeout, spa.ReadTimeout())
+			assert.Equal(t, tc.wantRS485, spa.IsRS485())
+			assert.Equal(t, tc.wantRTS, spa.RTSStates())
+		})
+	}
+}
+
+func TestSerialPortReadWrite(t *testing.T) {
+	// arrange
+	a := NewAccesser()
+	spa := a.UseMock()
+	port, err := a.NewPort("/dev/ttyS0")
+	require.NoError(t, err)
+	spa.SetSimRead([]byte{1, 2, 3})
+	buf := make([]byte, 2)
+	// act
+	n, errRead := port.Read(buf)
+	nw, errWrite := port.Write([]byte{4, 5})
+	// assert
+	require.NoError(t, errRead)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, []byte{1, 2}, buf)
+	require.NoError(t, errWrite)
+	assert.Equal(t, 2, nw)
+	assert.Equal(t, []byte{4, 5}, spa.Written())
+	assert.Nil(t, spa.RTSStates())
+	assert.Equal(t, 0, spa.DrainCount())
+}
+
+func TestSerialPortWriteRS485(t *testing.T) {
+	tests := map[string]struct {
+		opts      []OptionApplier
+		withPin   bool
+		writeErr  bool
+		wantRTS   []bool
+		wantPin   []int
+		wantDrain int
+		wantErr   string
+	}{
+		"rts_active_high": {
+			opts:      []OptionApplier{WithRS485(true, time.Microsecond, time.Microsecond)},
+			wantRTS:   []bool{false, true, false},
+			wantDrain: 1,
+		},
+		"rts_active_low": {
+			opts:      []OptionApplier{WithRS485(false, 0, 0)},
+			wantRTS:   []bool{true, false, true},
+			wantDrain: 1,
+		},
+		"direction_pin": {
+			withPin:   true,
+			wantPin:   []int{0, 1, 0},
+			wantDrain: 1,
+		},
+		"direction_pin_active_low": {
+			opts:      []OptionApplier{WithRS485(false, 0, 0)},
+			withPin:   true,
+			wantPin:   []int{1, 0, 1},
+			wantDrain: 1,
+		},
+		"error_write": {
+			opts:     []OptionApplier{WithRS485(true, 0, 0)},
+			writeErr: true,
+			wantRTS:  []bool{false, true, false},
+			wantErr:  "error while writing to serial port in mock",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := NewAccesser()
+			spa := a.UseMock()
+			pin := &serialPortTestDirectionPin{}
+			opts := tc.opts
+			if tc.withPin {
+				opts = append([]OptionApplier{WithRS485DirectionPin(pin)}, opts...)
+			}
+			port, err := a.NewPort("/dev/ttyS0", opts...)
+			require.NoError(t, err)
+			spa.SetWriteError(tc.writeErr)
+			// act
+			_, err = port.Write([]byte{0x55})
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, []byte{0x55}, spa.Written())
+			}
+			assert.Equal(t, tc.wantRTS, spa.RTSStates())
+			assert.Equal(t, tc.wantPin, pin.written)
+			assert.Equal(t, tc.wantDrain, spa.DrainCount())
+		})
+	}
+}
+
+func TestSerialPortClose(t *testing.T) {
+	// arrange
+	a := NewAccesser()
+	spa := a.UseMock()
+	port, err := a.NewPort("/dev/ttyS0")
+	require.NoError(t, err)
+	spa.SetSimRead([]byte{1})
+	// act
+	errReset := port.ResetInputBuffer()
+	errClose := port.Close()
+	// assert
+	require.NoError(t, errReset)
+	require.NoError(t, errClose)
+	assert.Equal(t, 1, spa.InputResets())
+	assert.True(t, spa.IsClosed())
+}
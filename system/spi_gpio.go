@@ -36,17 +36,9 @@ func newSpiGpio(cfg spiGpioConfig, maxSpeed int64) (*spiGpio, error) {
 }
 
 func (s *spiGpio) initializeTime(maxSpeed int64) {
-	// maxSpeed is given in Hz, tclk is half the cycle time, tclk=1/(2*f), tclk[ns]=1 000 000 000/(2*maxSpeed)
-	// but with gpio's a speed of more than ~15kHz is most likely not possible, so we limit to 10kHz
-	if maxSpeed > 10000 {
-		if systemDebug {
-			fmt.Printf("reduce SPI speed for GPIO usage to 10Khz")
-		}
-		maxSpeed = 10000
-	}
-	tclk := time.Duration(1000000000/2/maxSpeed) * time.Nanosecond
+	s.tclk = spiGpioTclk(maxSpeed)
 	if systemDebug {
-		fmt.Println("clk", tclk)
+		fmt.Println("clk", s.tclk)
 	}
 }
 
@@ -77,6 +69,62 @@ func (s *spiGpio) TxRx(tx []byte, rx []byte) error {
 	return s.nssPin.Write(1)
 }
 
+// Transfer sends/receives all given transfers as one message. Implements gobot.SpiSystemTransferer.
+// Only 8 bits per word are supported.
+func (s *spiGpio) Transfer(xfers []gobot.SpiXfer) error {
+	for i, xfer := range xfers {
+		if xfer.BitsPerWord != 0 && xfer.BitsPerWord != 8 {
+			return fmt.Errorf("%d bits per word not supported for transfer %d, only 8 bits", xfer.BitsPerWord, i)
+		}
+		if xfer.Tx != nil && xfer.Rx != nil && len(xfer.Tx) != len(xfer.Rx) {
+			return fmt.Errorf("length of tx (%d) must be the same as length of rx (%d) for transfer %d", len(xfer.Tx),
+				len(xfer.Rx), i)
+		}
+	}
+
+	defaultTclk := s.tclk
+	defer func() { s.tclk = defaultTclk }()
+
+	if err := s.nssPin.Write(0); err != nil {
+		return err
+	}
+
+	for i, xfer := range xfers {
+		s.tclk = defaultTclk
+		if xfer.SpeedHz > 0 {
+			s.tclk = spiGpioTclk(xfer.SpeedHz)
+		}
+
+		tx := xfer.Tx
+		if tx == nil {
+			tx = make([]byte, len(xfer.Rx))
+		}
+		for idx, b := range tx {
+			val, err := s.transferByte(b)
+			if err != nil {
+				return err
+			}
+			if xfer.Rx != nil {
+				xfer.Rx[idx] = val
+			}
+		}
+
+		time.Sleep(xfer.Delay)
+
+		if xfer.CSChange && i < len(xfers)-1 {
+			if err := s.nssPin.Write(1); err != nil {
+				return err
+			}
+			time.Sleep(s.tclk)
+			if err := s.nssPin.Write(0); err != nil {
+				return err
+			}
+		}
+	}
+
+	return s.nssPin.Write(1)
+}
+
 // Close the SPI connection. Implements gobot.SpiSystemDevicer.
 func (s *spiGpio) Close() error {
 	var err error
@@ -107,6 +155,19 @@ func (cfg *spiGpioConfig) String() string {
 	return fmt.Sprintf("sclk: %s, nss: %s, mosi: %s, miso: %s", cfg.sclkPinID, cfg.nssPinID, cfg.mosiPinID, cfg.misoPinID)
 }
 
+// spiGpioTclk calculates the time between clock edges for the given speed
+func spiGpioTclk(maxSpeed int64) time.Duration {
+	// maxSpeed is given in Hz, tclk is half the cycle time, tclk=1/(2*f), tclk[ns]=1 000 000 000/(2*maxSpeed)
+	// but with gpio's a speed of more than ~15kHz is most likely not possible, so we limit to 10kHz
+	if maxSpeed > 10000 {
+		if systemDebug {
+			fmt.Printf("reduce SPI speed for GPIO usage to 10Khz")
+		}
+		maxSpeed = 10000
+	}
+	return time.Duration(1000000000/2/maxSpeed) * time.Nanosecond
+}
+
 // transferByte simultaneously transmit and receive a byte
 // polarity and phase are assumed to be both 0 (CPOL=0, CPHA=0), so:
 // * input data is captured on rising edge of SCLK
@@ -0,0 +1,159 @@
+package system
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+)
+
+// make sure that this implementation fulfills all the required interfaces
+var (
+	_ gobot.SpiSystemDevicer    = (*spiGpio)(nil)
+	_ gobot.SpiSystemTransferer = (*spiGpio)(nil)
+)
+
+// spiGpioTestBus simulates a device, which sends back the received bits (loopback) and records the bytes for
+// each activation of the chip select.
+type spiGpioTestBus struct {
+	nss      int
+	sclk     int
+	mosi     int
+	bitIdx   int
+	shift    byte
+	messages [][]byte
+}
+
+type spiGpioTestPin struct {
+	bus *spiGpioTestBus
+	id  string
+}
+
+func (b *spiGpioTestBus) DigitalPin(id string) (gobot.DigitalPinner, error) {
+	switch id {
+	case "nss", "sclk", "mosi", "miso":
+		return &spiGpioTestPin{bus: b, id: id}, nil
+	}
+	return nil, fmt.Errorf("unknown pin '%s'", id)
+}
+
+func (p *spiGpioTestPin) ApplyOptions(...func(gobot.DigitalPinOptioner) bool) error { return nil }
+func (p *spiGpioTestPin) Export() error                                             { return nil }
+func (p *spiGpioTestPin) Unexport() error                                           { return nil }
+func (p *spiGpioTestPin) Read() (int, error)                                        { return p.bus.mosi, nil }
+
+func (p *spiGpioTestPin) Write(val int) error {
+	if val != 0 {
+		val = 1
+	}
+	b := p.bus
+	switch p.id {
+	case "nss":
+		if b.nss == 1 && val == 0 {
+			b.messages = append(b.messages, []byte{})
+			b.bitIdx, b.shift = 0, 0
+		}
+		b.nss = val
+	case "sclk":
+		if b.sclk == 0 && val == 1 && b.nss == 0 {
+			b.shift = b.shift<<1 | byte(b.mosi)
+			b.bitIdx++
+			if b.bitIdx == 8 {
+				last := len(b.messages) - 1
+				b.messages[last] = append(b.messages[last], b.shift)
+				b.bitIdx, b.shift = 0, 0
+			}
+		}
+		b.sclk = val
+	case "mosi":
+		b.mosi = val
+	}
+	return nil
+}
+
+func initTestSpiGpio(t *testing.T) (*spiGpio, *spiGpioTestBus) {
+	bus := &spiGpioTestBus{nss: 1}
+	cfg := spiGpioConfig{pinProvider: bus, sclkPinID: "sclk", nssPinID: "nss", mosiPinID: "mosi", misoPinID: "miso"}
+	s, err := newSpiGpio(cfg, 10000)
+	require.NoError(t, err)
+	return s, bus
+}
+
+func TestNewSpiGpio(t *testing.T) {
+	// arrange & act
+	s, _ := initTestSpiGpio(t)
+	// assert
+	assert.Equal(t, 50*time.Microsecond, s.tclk)
+}
+
+func TestSpiGpioTxRx(t *testing.T) {
+	// arrange
+	s, bus := initTestSpiGpio(t)
+	rx := make([]byte, 2)
+	// act
+	err := s.TxRx([]byte{0xA5, 0x3C}, rx)
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0xA5, 0x3C}, rx)
+	assert.Equal(t, [][]byte{{0xA5, 0x3C}}, bus.messages)
+	assert.Equal(t, 1, bus.nss)
+}
+
+func TestSpiGpioTransfer(t *testing.T) {
+	tests := map[string]struct {
+		xfers        []gobot.SpiXfer
+		wantMessages [][]byte
+		wantRx       [][]byte
+		wantErr      string
+	}{
+		"cs_hold": {
+			xfers:        []gobot.SpiXfer{{Tx: []byte{0x01}}, {Tx: []byte{0x02, 0x03}}},
+			wantMessages: [][]byte{{0x01, 0x02, 0x03}},
+		},
+		"cs_change": {
+			xfers:        []gobot.SpiXfer{{Tx: []byte{0x01}, CSChange: true}, {Tx: []byte{0x02}, CSChange: true}},
+			wantMessages: [][]byte{{0x01}, {0x02}},
+		},
+		"with_rx_and_speed_delay": {
+			xfers: []gobot.SpiXfer{
+				{Tx: []byte{0x81}, Rx: make([]byte, 1), SpeedHz: 5000, Delay: time.Millisecond},
+				{Rx: make([]byte, 2)},
+			},
+			wantMessages: [][]byte{{0x81, 0x00, 0x00}},
+			wantRx:       [][]byte{{0x81}, {0x00, 0x00}},
+		},
+		"error_bits_per_word": {
+			xfers:   []gobot.SpiXfer{{Tx: []byte{0x01}, BitsPerWord: 9}},
+			wantErr: "9 bits per word not supported for transfer 0, only 8 bits",
+		},
+		"error_length": {
+			xfers:   []gobot.SpiXfer{{Tx: []byte{0x01}, Rx: make([]byte, 2)}},
+			wantErr: "length of tx (1) must be the same as length of rx (2) for transfer 0",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			s, bus := initTestSpiGpio(t)
+			// act
+			err := s.Transfer(tc.xfers)
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				assert.Empty(t, bus.messages)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantMessages, bus.messages)
+			assert.Equal(t, 1, bus.nss)
+			assert.Equal(t, 50*time.Microsecond, s.tclk)
+			for i, want := range tc.wantRx {
+				assert.Equal(t, want, tc.xfers[i].Rx)
+			}
+		})
+	}
+}
@@ -64,9 +64,15 @@ func (spi *MockSpiAccess) Written() []byte {
 	return spi.sysdev.written
 }
 
+// Transfers returns the transfers of all messages, which were sent by Transfer().
+func (spi *MockSpiAccess) Transfers() []gobot.SpiXfer {
+	return spi.sysdev.transfers
+}
+
 // Reset resets the last written values.
 func (spi *MockSpiAccess) Reset() {
 	spi.sysdev.written = []byte{}
+	spi.sysdev.transfers = nil
 }
 
 // spiMock is the a mock implementation, used in tests
@@ -77,6 +83,7 @@ type spiMock struct {
 	simCloseErr bool
 	written     []byte
 	simRead     []byte
+	transfers   []gobot.SpiXfer
 }
 
 // newSpiMock creates and returns a new connection to a specific
@@ -103,3 +110,19 @@ func (c *spiMock) TxRx(tx []byte, rx []byte) error {
 	copy(rx, c.simRead)
 	return nil
 }
+
+// Transfer sends/receives all given transfers as one message. Implements gobot.SpiSystemTransferer.
+func (c *spiMock) Transfer(xfers []gobot.SpiXfer) error {
+	if c.simReadErr {
+		return fmt.Errorf("error while SPI transfer in mock")
+	}
+	readIdx := 0
+	for _, xfer := range xfers {
+		c.written = append(c.written, xfer.Tx...)
+		if xfer.Rx != nil && readIdx < len(c.simRead) {
+			readIdx += copy(xfer.Rx, c.simRead[readIdx:])
+		}
+		c.transfers = append(c.transfers, xfer)
+	}
+	return nil
+}
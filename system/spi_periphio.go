@@ -2,16 +2,22 @@ package system
 
 import (
 	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
 
 	"periph.io/x/conn/v3/physic"
 	xspi "periph.io/x/conn/v3/spi"
 	xsysfs "periph.io/x/host/v3/sysfs"
+
+	"gobot.io/x/gobot/v2"
 )
 
 // spiPeriphIo is the implementation of the SPI interface using the periph.io sysfs implementation for Linux.
 type spiPeriphIo struct {
-	port xspi.PortCloser
-	dev  xspi.Conn
+	port     xspi.PortCloser
+	dev      xspi.Conn
+	maxSpeed int64
 }
 
 // newSpiPeriphIo creates and returns a new connection to a specific SPI device on a bus/chip
@@ -25,7 +31,7 @@ func newSpiPeriphIo(busNum, chipNum, mode, bits int, maxSpeed int64) (*spiPeriph
 	if err != nil {
 		return nil, err
 	}
-	return &spiPeriphIo{port: p, dev: c}, nil
+	return &spiPeriphIo{port: p, dev: c, maxSpeed: maxSpeed}, nil
 }
 
 // TxRx uses the SPI device TX to send/receive data. Implements gobot.SpiSystemDevicer.
@@ -40,6 +46,74 @@ func (c *spiPeriphIo) TxRx(tx []byte, rx []byte) error {
 	return nil
 }
 
+// Transfer sends/receives all given transfers as one message. Implements gobot.SpiSystemTransferer.
+//
+// The packets of periph's TxPackets() provide no delay and no speed, although the kernel supports both per transfer
+// (delay_usecs and speed_hz of spi_ioc_transfer). So the message is split into more calls at each delay, which is
+// done by a sleep, and at each change of the speed, which is set for the port. In this case the chip select is hold
+// between the calls. Because the speed of the connection is the upper limit, a higher speed for a transfer has no
+// effect.
+func (c *spiPeriphIo) Transfer(xfers []gobot.SpiXfer) error {
+	var packets []xspi.Packet
+	groupSpeed := c.maxSpeed
+	portSpeed := c.maxSpeed
+
+	flush := func(keepCS bool) error {
+		if len(packets) == 0 {
+			return nil
+		}
+		packets[len(packets)-1].KeepCS = keepCS
+		if groupSpeed != portSpeed {
+			if err := c.port.LimitSpeed(physic.Frequency(groupSpeed) * physic.Hertz); err != nil {
+				return err
+			}
+			portSpeed = groupSpeed
+		}
+		err := c.dev.TxPackets(packets)
+		packets = nil
+		return err
+	}
+
+	err := func() error {
+		for i, xfer := range xfers {
+			speed := xfer.SpeedHz
+			if speed == 0 {
+				speed = c.maxSpeed
+			}
+			if speed != groupSpeed {
+				// the chip select is kept active, if not requested to change by the previous transfer
+				if len(packets) > 0 {
+					if err := flush(packets[len(packets)-1].KeepCS); err != nil {
+						return err
+					}
+				}
+				groupSpeed = speed
+			}
+			packets = append(packets, xspi.Packet{
+				W:           xfer.Tx,
+				R:           xfer.Rx,
+				BitsPerWord: uint8(xfer.BitsPerWord),
+				KeepCS:      !xfer.CSChange,
+			})
+			last := i == len(xfers)-1
+			if xfer.Delay > 0 || last {
+				if err := flush(!last && !xfer.CSChange); err != nil {
+					return err
+				}
+				time.Sleep(xfer.Delay)
+			}
+		}
+		return nil
+	}()
+
+	if portSpeed != c.maxSpeed {
+		if e := c.port.LimitSpeed(physic.Frequency(c.maxSpeed) * physic.Hertz); e != nil {
+			err = multierror.Append(err, e)
+		}
+	}
+	return err
+}
+
 // Close the SPI connection. Implements gobot.SpiSystemDevicer.
 func (c *spiPeriphIo) Close() error {
 	return c.port.Close()
@@ -39,8 +39,8 @@ func (sys *nativeSyscall) syscall(
 	address uint16,
 ) (r1, r2 uintptr, err SyscallErrno) {
 	var errNo unix.Errno
-	if signal == I2C_SLAVE {
-		// this is the setup for the address, it just needs to be converted to an uintptr,
+	if signal == I2C_SLAVE || signal == evdevIoctlGrab {
+		// this is the setup for the address or the grab value, it just needs to be converted to an uintptr,
 		// the given payload is not used in this case, see the comment on the function
 		r1, r2, errNo = unix.Syscall(trap, f.Fd(), signal, uintptr(address))
 	} else {
@@ -54,3 +54,9 @@ func (sys *nativeSyscall) syscall(
 func (e SyscallErrno) Error() string {
 	return unix.Errno(e).Error()
 }
+
+// Is reports whether the target is the same "unix.Errno", e.g. syscall.EBUSY.
+func (e SyscallErrno) Is(target error) bool {
+	t, ok := target.(unix.Errno)
+	return ok && unix.Errno(e) == t
+}
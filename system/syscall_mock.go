@@ -30,8 +30,8 @@ func (sys *mockSyscall) syscall(
 	sys.lastFile = f        // a character device file (e.g. file to path "/dev/i2c-1")
 	sys.lastSignal = signal // points to used function type (e.g. I2C_SMBUS, I2C_RDWR)
 
-	if signal == I2C_SLAVE {
-		// this is the setup for the address, it needs to be converted to an uintptr,
+	if signal == I2C_SLAVE || signal == evdevIoctlGrab {
+		// this is the setup for the address or the grab value, it needs to be converted to an uintptr,
 		// the given payload is not used in this case, see the comment on the function used for production
 		sys.devAddress = uintptr(address)
 	}
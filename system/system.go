@@ -1,7 +1,12 @@
 package system
 
 import (
+	"fmt"
 	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
 	"unsafe"
 
 	"gobot.io/x/gobot/v2"
@@ -62,6 +67,7 @@ type Accesser struct {
 	fs               filesystem
 	digitalPinAccess digitalPinAccesser
 	spiAccess        spiAccesser
+	canSocketAccess  canSocketAccesser
 }
 
 // NewAccesser returns a accesser to native system call, native file system and the chosen digital pin access.
@@ -72,6 +78,7 @@ func NewAccesser(options ...func(Optioner)) *Accesser {
 		fs:  &nativeFilesystem{},
 	}
 	s.spiAccess = &periphioSpiAccess{fs: s.fs}
+	s.canSocketAccess = &nativeCanSocketAccess{}
 	s.digitalPinAccess = &sysfsDigitalPinAccess{sfa: &sysfsFileAccess{fs: s.fs, readBufLen: 2}}
 	for _, option := range options {
 		option(s)
@@ -118,6 +125,13 @@ func (a *Accesser) UseMockSpi() *MockSpiAccess {
 	return msc
 }
 
+// UseMockCanSocket sets the CAN socket implementation of the accesser to the mocked one. Used only for tests.
+func (a *Accesser) UseMockCanSocket() *MockCanSocketAccess {
+	mcsa := &MockCanSocketAccess{}
+	a.canSocketAccess = mcsa
+	return mcsa
+}
+
 // NewDigitalPin returns a new system digital pin, according to the given pin number.
 func (a *Accesser) NewDigitalPin(chip string, pin int,
 	o ...func(gobot.DigitalPinOptioner) bool,
@@ -146,11 +160,131 @@ func (a *Accesser) NewAnalogPin(path string, r, w bool, readBufLen uint16) gobot
 	return newAnalogPinSysfs(&sysfsFileAccess{fs: a.fs, readBufLen: readBufLen}, path, r, w)
 }
 
+// NewAnalogBuffer returns a new buffered analog input of the given IIO device (e.g.
+// "/sys/bus/iio/devices/iio:device0") for the given channels (e.g. "in_voltage0").
+func (a *Accesser) NewAnalogBuffer(
+	devicePath string,
+	channels []string,
+	options ...func(gobot.AnalogBufferOptioner) bool,
+) (gobot.AnalogBufferSystemDevicer, error) {
+	sfa := &sysfsFileAccess{fs: a.fs, readBufLen: 200}
+	return newAnalogBufferIio(sfa, devicePath, channels, options...)
+}
+
 // NewSpiDevice returns a new connection to SPI with the given parameters.
 func (a *Accesser) NewSpiDevice(busNum, chipNum, mode, bits int, maxSpeed int64) (gobot.SpiSystemDevicer, error) {
 	return a.spiAccess.createDevice(busNum, chipNum, mode, bits, maxSpeed)
 }
 
+// SpiGpioPins returns the pins used for SPI, if SPI is driven by GPIO's, otherwise nil. The order of the pins is
+// SCLK, NSS, MOSI, MISO.
+func (a *Accesser) SpiGpioPins() []string {
+	gsa, ok := a.spiAccess.(*gpioSpiAccess)
+	if !ok {
+		return nil
+	}
+	return []string{gsa.cfg.sclkPinID, gsa.cfg.nssPinID, gsa.cfg.mosiPinID, gsa.cfg.misoPinID}
+}
+
+// FindInputDevices returns all input devices with event interface (evdev), e.g. "/dev/input/event0", together with
+// the name and the physical location, which can be used to select a device.
+func (a *Accesser) FindInputDevices() ([]InputDeviceInfo, error) {
+	return findInputDevices(a.fs)
+}
+
+// NewInputDevice opens the input device with the given path, e.g. "/dev/input/event0", for reading of events.
+func (a *Accesser) NewInputDevice(devicePath string) (gobot.InputSystemDevicer, error) {
+	return newEvdevInputDevice(a.fs, a.sys, devicePath)
+}
+
+// FindLeds returns the names of all LEDs of the LED class, e.g. "ACT" or "beaglebone:green:usr0".
+func (a *Accesser) FindLeds() ([]string, error) {
+	return findLeds(a.fs)
+}
+
+// NewLed returns the LED with the given name, e.g. "ACT", which is accessed by "/sys/class/leds/<name>".
+func (a *Accesser) NewLed(name string) (gobot.LedSystemDevicer, error) {
+	if _, err := a.fs.stat(path.Join(ledClassPath, name)); err != nil {
+		return nil, fmt.Errorf("LED '%s' not found: %v", name, err)
+	}
+	// the list of triggers can be longer than 1000 characters, if many block or network devices are available
+	sfa := &sysfsFileAccess{fs: a.fs, readBufLen: 4096}
+	return newLedSysfs(sfa, name), nil
+}
+
+// FindHwmonDevices returns all hardware monitoring devices, e.g. "/sys/class/hwmon/hwmon0", together with the name,
+// which can be used to select a device.
+func (a *Accesser) FindHwmonDevices() ([]HwmonDeviceInfo, error) {
+	return findHwmonDevices(a.fs)
+}
+
+// NewHwmonDevice returns the hardware monitoring device with the given name, e.g. "cpu_thermal", or the given
+// directory name, e.g. "hwmon0". If more than one device has the same name, the first one is used.
+func (a *Accesser) NewHwmonDevice(name string) (gobot.HwmonSystemDevicer, error) {
+	infos, err := findHwmonDevices(a.fs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, info := range infos {
+		if info.Name == name || path.Base(info.Path) == name {
+			sfa := &sysfsFileAccess{fs: a.fs, readBufLen: 32}
+			return newHwmonDeviceSysfs(a.fs, sfa, info)
+		}
+	}
+	return nil, fmt.Errorf("hwmon device '%s' not found", name)
+}
+
+// NewWatchdog opens the hardware watchdog with the given character device, e.g. "/dev/watchdog". Opening the device
+// starts the watchdog.
+func (a *Accesser) NewWatchdog(devicePath string) (gobot.WatchdogSystemDevicer, error) {
+	return newWatchdogDevice(a.fs, a.sys, devicePath)
+}
+
+// NewCanSocket opens a raw socket for the given CAN network interface, e.g. "can0" or "vcan0". If fdFrames is true,
+// CAN FD frames can be sent and received in addition to classic frames. By default all frames, but no error frames,
+// are received.
+func (a *Accesser) NewCanSocket(iface string, fdFrames bool) (gobot.CanSystemDevicer, error) {
+	return newCanSocketDevice(a.canSocketAccess, iface, fdFrames)
+}
+
+// NewOneWireDevice returns a new 1-wire device with the given parameters.
+// note: this is a basic implementation without using the possibilities of bus controller
+// it depends on automatic device search, see https://www.kernel.org/doc/Documentation/w1/w1.generic
+func (a *Accesser) NewOneWireDevice(familyCode byte, serialNumber uint64) (gobot.OneWireSystemDevicer, error) {
+	id := fmt.Sprintf("%02x-%012x", familyCode, serialNumber)
+	if _, err := a.fs.stat(path.Join(onewireDevicePath, id)); err != nil {
+		return nil, fmt.Errorf("1-wire device '%s' not found: %v", id, err)
+	}
+	sfa := &sysfsFileAccess{fs: a.fs, readBufLen: 200}
+	return newOneWireDeviceSysfs(sfa, id), nil
+}
+
+// FindOneWireDevices returns the serial numbers of all 1-wire devices with the given family code, which are
+// detected by the kernel on all 1-wire buses.
+func (a *Accesser) FindOneWireDevices(familyCode byte) ([]uint64, error) {
+	items, err := a.fs.find(onewireDevicePath, fmt.Sprintf("^%02x-[0-9a-f]{12}$", familyCode))
+	if err != nil {
+		return nil, err
+	}
+
+	var serialNumbers []uint64
+	known := make(map[uint64]bool)
+	for _, item := range items {
+		serial := strings.SplitN(path.Base(item), "-", 2)[1]
+		serialNumber, err := strconv.ParseUint(serial, 16, 64)
+		if err != nil {
+			return nil, err
+		}
+		if !known[serialNumber] {
+			known[serialNumber] = true
+			serialNumbers = append(serialNumbers, serialNumber)
+		}
+	}
+	sort.Slice(serialNumbers, func(i, j int) bool { return serialNumbers[i] < serialNumbers[j] })
+	return serialNumbers, nil
+}
+
 // OpenFile opens file of given name from native or the mocked file system
 func (a *Accesser) OpenFile(name string, flag int, perm os.FileMode) (File, error) {
 	return a.fs.openFile(name, flag, perm)
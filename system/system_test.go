@@ -6,6 +6,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
 )
 
 func TestNewAccesser(t *testing.T) {
@@ -45,6 +47,16 @@ func TestNewAccesser_NewSpiDevice(t *testing.T) {
 	assert.Equal(t, maxSpeed, spi.maxSpeed)
 }
 
+func TestNewAccesser_SpiGpioPins(t *testing.T) {
+	// arrange
+	a := NewAccesser()
+	require.Nil(t, a.SpiGpioPins())
+	// act
+	a.setSpiToGpioAccess(nil, "11", "12", "13", "14")
+	// assert
+	assert.Equal(t, []string{"11", "12", "13", "14"}, a.SpiGpioPins())
+}
+
 func TestNewAccesser_IsSysfsDigitalPinAccess(t *testing.T) {
 	tests := map[string]struct {
 		gpiodAccesser bool
@@ -92,3 +104,75 @@ func TestNewAccesser_IsSysfsDigitalPinAccess(t *testing.T) {
 		})
 	}
 }
+
+func TestNewAccesser_NewOneWireDevice(t *testing.T) {
+	// arrange
+	a := NewAccesser()
+	a.UseMockFilesystem([]string{"/sys/bus/w1/devices/28-0000075a1b2c/temperature"})
+	// act
+	dev, err := a.NewOneWireDevice(0x28, 0x75a1b2c)
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, "28-0000075a1b2c", dev.ID())
+	// act & assert
+	_, err = a.NewOneWireDevice(0x28, 0x1234)
+	require.ErrorContains(t, err, "1-wire device '28-000000001234' not found")
+}
+
+func TestNewAccesser_FindOneWireDevices(t *testing.T) {
+	// arrange
+	a := NewAccesser()
+	a.UseMockFilesystem([]string{
+		"/sys/bus/w1/devices/w1_bus_master1/w1_master_slaves",
+		"/sys/bus/w1/devices/28-0000075a1b2c/temperature",
+		"/sys/bus/w1/devices/28-0000075a1b2c/resolution",
+		"/sys/bus/w1/devices/28-000000001234/temperature",
+		"/sys/bus/w1/devices/3b-0000000abcde/temperature",
+	})
+	// act
+	got, err := a.FindOneWireDevices(0x28)
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{0x1234, 0x75a1b2c}, got)
+}
+
+func TestNewAccesser_NewLed(t *testing.T) {
+	// arrange
+	a := NewAccesser()
+	a.UseMockFilesystem([]string{"/sys/class/leds/ACT/brightness"})
+	// act
+	led, err := a.NewLed("ACT")
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, "ACT", led.Name())
+	// act & assert
+	_, err = a.NewLed("PWR")
+	require.ErrorContains(t, err, "LED 'PWR' not found")
+}
+
+func TestNewAccesser_NewHwmonDevice(t *testing.T) {
+	// arrange
+	a := NewAccesser()
+	fs := a.UseMockFilesystem([]string{
+		"/sys/class/hwmon/hwmon0/name",
+		"/sys/class/hwmon/hwmon0/temp1_input",
+		"/sys/class/hwmon/hwmon1/name",
+		"/sys/class/hwmon/hwmon1/temp1_input",
+	})
+	fs.Files["/sys/class/hwmon/hwmon0/name"].Contents = "cpu_thermal\n"
+	fs.Files["/sys/class/hwmon/hwmon1/name"].Contents = "cpu_thermal\n"
+	// act
+	byName, err := a.NewHwmonDevice("cpu_thermal")
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, "cpu_thermal", byName.Name())
+	assert.Equal(t, []gobot.HwmonChannel{{Name: "temp1", Unit: "°C"}}, byName.Channels())
+	// act
+	byDir, err := a.NewHwmonDevice("hwmon1")
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, "cpu_thermal", byDir.Name())
+	// act & assert
+	_, err = a.NewHwmonDevice("rpi_volt")
+	require.EqualError(t, err, "hwmon device 'rpi_volt' not found")
+}
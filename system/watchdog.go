@@ -0,0 +1,107 @@
+package system
+
+import (
+	"fmt"
+	"os"
+	"time"
+	"unsafe"
+)
+
+// Linux watchdog device API.
+//
+//	https://docs.kernel.org/watchdog/watchdog-api.html
+//	"linux/watchdog.h"
+const (
+	watchdogIoctlGetBootStatus = 0x80045702 // WDIOC_GETBOOTSTATUS, _IOR('W', 2, int)
+	watchdogIoctlKeepalive     = 0x80045705 // WDIOC_KEEPALIVE, _IOR('W', 5, int)
+	watchdogIoctlSetTimeout    = 0xC0045706 // WDIOC_SETTIMEOUT, _IOWR('W', 6, int)
+	watchdogIoctlGetTimeout    = 0x80045707 // WDIOC_GETTIMEOUT, _IOR('W', 7, int)
+	watchdogIoctlGetTimeLeft   = 0x8004570A // WDIOC_GETTIMELEFT, _IOR('W', 10, int)
+	watchdogMagicCloseChar     = "V"
+)
+
+// watchdogDevice is the implementation of a hardware watchdog by the Linux watchdog device API
+type watchdogDevice struct {
+	path string
+	file File
+	sys  systemCaller
+}
+
+// newWatchdogDevice opens the watchdog device, which starts the watchdog
+func newWatchdogDevice(fs filesystem, sys systemCaller, devicePath string) (*watchdogDevice, error) {
+	file, err := fs.openFile(devicePath, os.O_WRONLY, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &watchdogDevice{path: devicePath, file: file, sys: sys}, nil
+}
+
+// Keepalive resets the timer of the watchdog. Implements the interface gobot.WatchdogSystemDevicer.
+func (d *watchdogDevice) Keepalive() error {
+	var dummy int32
+	return d.ioctl("keepalive", watchdogIoctlKeepalive, &dummy)
+}
+
+// SetTimeout sets the timeout with a resolution of one second, the value is rounded up. The timeout used by the
+// kernel driver is returned. Implements the interface gobot.WatchdogSystemDevicer.
+func (d *watchdogDevice) SetTimeout(timeout time.Duration) (time.Duration, error) {
+	if timeout < time.Second {
+		return 0, fmt.Errorf("timeout %s of watchdog '%s' is less than one second", timeout, d.path)
+	}
+
+	seconds := int32((timeout + time.Second - 1) / time.Second)
+	if err := d.ioctl("set timeout", watchdogIoctlSetTimeout, &seconds); err != nil {
+		return 0, err
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
+
+// Timeout reads the current timeout. Implements the interface gobot.WatchdogSystemDevicer.
+func (d *watchdogDevice) Timeout() (time.Duration, error) {
+	var seconds int32
+	if err := d.ioctl("get timeout", watchdogIoctlGetTimeout, &seconds); err != nil {
+		return 0, err
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
+
+// TimeLeft reads the time left before the system is reset. Implements the interface gobot.WatchdogSystemDevicer.
+func (d *watchdogDevice) TimeLeft() (time.Duration, error) {
+	var seconds int32
+	if err := d.ioctl("get time left", watchdogIoctlGetTimeLeft, &seconds); err != nil {
+		return 0, err
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
+
+// BootStatus reads the flags for the reason of the last reboot. Implements the interface gobot.WatchdogSystemDevicer.
+func (d *watchdogDevice) BootStatus() (uint32, error) {
+	var status int32
+	if err := d.ioctl("get boot status", watchdogIoctlGetBootStatus, &status); err != nil {
+		return 0, err
+	}
+	return uint32(status), nil
+}
+
+// MagicClose writes the magic character before closing the device, which stops the watchdog. Implements the interface
+// gobot.WatchdogSystemDevicer.
+func (d *watchdogDevice) MagicClose() error {
+	if _, err := d.file.Write([]byte(watchdogMagicCloseChar)); err != nil {
+		_ = d.file.Close()
+		return fmt.Errorf("magic close of watchdog '%s' failed: %v", d.path, err)
+	}
+	return d.file.Close()
+}
+
+// Close releases the device without stopping the watchdog. Implements the interface gobot.WatchdogSystemDevicer.
+func (d *watchdogDevice) Close() error {
+	return d.file.Close()
+}
+
+func (d *watchdogDevice) ioctl(name string, signal uintptr, val *int32) error {
+	if _, _, errNo := d.sys.syscall(Syscall_SYS_IOCTL, d.file, signal, unsafe.Pointer(val), 0); errNo != 0 {
+		return fmt.Errorf("%s of watchdog '%s' failed with syscall.Errno %v", name, d.path, errNo)
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package system
+
+import (
+	"testing"
+	"time"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+)
+
+// make sure that this implementation fulfills all the required interfaces
+var _ gobot.WatchdogSystemDevicer = (*watchdogDevice)(nil)
+
+const watchdogTestPath = "/dev/watchdog"
+
+// watchdogTestKernel emulates the kernel driver of the watchdog
+type watchdogTestKernel struct {
+	timeout    int32
+	timeLeft   int32
+	bootStatus int32
+	keepalives int
+	errNo      SyscallErrno
+}
+
+func initTestWatchdog(t *testing.T) (gobot.WatchdogSystemDevicer, *watchdogTestKernel, *MockFilesystem) {
+	a := NewAccesser()
+	fs := a.UseMockFilesystem([]string{watchdogTestPath})
+	msc := a.UseMockSyscall()
+	kernel := &watchdogTestKernel{timeout: 15, timeLeft: 12, bootStatus: 0x20}
+	msc.Impl = func(trap, a1, a2 uintptr, a3 unsafe.Pointer) (uintptr, uintptr, SyscallErrno) {
+		if kernel.errNo != 0 {
+			return 0, 0, kernel.errNo
+		}
+		val := (*int32)(a3)
+		switch a2 {
+		case watchdogIoctlKeepalive:
+			kernel.keepalives++
+		case watchdogIoctlSetTimeout:
+			// the kernel driver supports only even values
+			kernel.timeout = (*val + 1) &^ 1
+			*val = kernel.timeout
+		case watchdogIoctlGetTimeout:
+			*val = kernel.timeout
+		case watchdogIoctlGetTimeLeft:
+			*val = kernel.timeLeft
+		case watchdogIoctlGetBootStatus:
+			*val = kernel.bootStatus
+		}
+		return 0, 0, 0
+	}
+	d, err := a.NewWatchdog(watchdogTestPath)
+	require.NoError(t, err)
+	return d, kernel, fs
+}
+
+func TestNewWatchdog_error(t *testing.T) {
+	// arrange
+	a := NewAccesser()
+	_ = a.UseMockFilesystem(nil)
+	// act
+	_, err := a.NewWatchdog(watchdogTestPath)
+	// assert
+	require.ErrorContains(t, err, "/dev/watchdog: no such file")
+}
+
+func TestWatchdogDevice(t *testing.T) {
+	// arrange
+	d, kernel, _ := initTestWatchdog(t)
+	// act & assert
+	require.NoError(t, d.Keepalive())
+	assert.Equal(t, 1, kernel.keepalives)
+	got, err := d.SetTimeout(2500 * time.Millisecond)
+	require.NoError(t, err)
+	assert.Equal(t, 4*time.Second, got)
+	got, err = d.Timeout()
+	require.NoError(t, err)
+	assert.Equal(t, 4*time.Second, got)
+	got, err = d.TimeLeft()
+	require.NoError(t, err)
+	assert.Equal(t, 12*time.Second, got)
+	status, err := d.BootStatus()
+	require.NoError(t, err)
+	assert.Equal(t, uint32(0x20), status)
+	_, err = d.SetTimeout(500 * time.Millisecond)
+	require.EqualError(t, err, "timeout 500ms of watchdog '/dev/watchdog' is less than one second")
+	// arrange
+	kernel.errNo = SyscallErrno(Syscall_EINVAL)
+	// act & assert
+	require.EqualError(t, d.Keepalive(), "keepalive of watchdog '/dev/watchdog' failed with syscall.Errno invalid argument")
+	_, err = d.TimeLeft()
+	require.EqualError(t, err, "get time left of watchdog '/dev/watchdog' failed with syscall.Errno invalid argument")
+}
+
+func TestWatchdogDeviceClose(t *testing.T) {
+	tests := map[string]struct {
+		magic        bool
+		writeErr     bool
+		wantContents string
+		wantErr      string
+	}{
+		"magic_close": {magic: true, wantContents: "V"},
+		"close":       {magic: false},
+		"error_magic_close": {
+			magic:    true,
+			writeErr: true,
+			wantErr:  "magic close of watchdog '/dev/watchdog' failed: write error",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, _, fs := initTestWatchdog(t)
+			fs.WithWriteError = tc.writeErr
+			// act
+			var err error
+			if tc.magic {
+				err = d.MagicClose()
+			} else {
+				err = d.Close()
+			}
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.wantContents, fs.Files[watchdogTestPath].Contents)
+			assert.True(t, fs.Files[watchdogTestPath].Closed)
+		})
+	}
+}